@@ -12,17 +12,27 @@ import (
 	"text/tabwriter"
 
 	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/airgap"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/buildinfo"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/container"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/curl"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/dotnet"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/mvn"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/npm"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/oc"
 	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/onboarding"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/replication"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/repository"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/toolchain"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/airgapexport"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/airgapimport"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/artifactdiff"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildadddependencies"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildaddgit"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildahpull"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildahpush"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildappend"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildclean"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildcollectenv"
@@ -31,19 +41,34 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpromote"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpublish"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildscan"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/checksumsearch"
 	copydocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/copy"
 	curldocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/curl"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/delete"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/deleteprops"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dependenciesprefetch"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/deploymentmanifest"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/directdownload"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockercleanup"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockercopy"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpromote"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpull"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpush"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockertagretention"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerwarmcache"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/download"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/gitlfsclean"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/jdkprovision"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/latestupdate"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/manifestsync"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/move"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/mvnpromote"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/nodeprovision"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/npmbundle"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/npmdisttag"
 	nugettree "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/nugetdepstree"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ocstartbuild"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/onboardinganalyze"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ping"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/podmanpull"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/podmanpush"
@@ -51,13 +76,23 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationdelete"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationtemplate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repocreate"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repocreateremote"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repocreateset"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repodelete"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repotemplate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repoupdate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/search"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/setprops"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/upload"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
 	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/har"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/httpcache"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/ratelimit"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/receipt"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/sshtunnel"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/tracing"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/workspace"
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/commandWrappers"
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
 	coregeneric "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
@@ -76,6 +111,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
@@ -271,6 +307,15 @@ func GetCommands() []components.Command {
 			Category:         buildCategory,
 			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
 		},
+		{
+			Name:        "deployment-manifest",
+			Flags:       flagkit.GetCommandFlags(flagkit.DeploymentManifest),
+			Aliases:     []string{"dm"},
+			Description: deploymentmanifest.GetDescription(),
+			Arguments:   deploymentmanifest.GetArguments(),
+			Action:      deploymentManifestCmd,
+			Category:    buildCategory,
+		},
 		{
 			Name:             "git-lfs-clean",
 			Flags:            flagkit.GetCommandFlags(flagkit.GitLfsClean),
@@ -291,6 +336,177 @@ func GetCommands() []components.Command {
 			Category:         buildCategory,
 			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
 		},
+		{
+			Name:             "docker-cleanup",
+			Flags:            flagkit.GetCommandFlags(flagkit.DockerCleanup),
+			Aliases:          []string{"dcl"},
+			Description:      dockercleanup.GetDescription(),
+			Arguments:        dockercleanup.GetArguments(),
+			Action:           dockerCleanupCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "docker-tag-retention",
+			Flags:            flagkit.GetCommandFlags(flagkit.DockerTagRetention),
+			Aliases:          []string{"dtr"},
+			Description:      dockertagretention.GetDescription(),
+			Arguments:        dockertagretention.GetArguments(),
+			Action:           dockerTagRetentionCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "docker-copy",
+			Flags:            flagkit.GetCommandFlags(flagkit.DockerCopy),
+			Aliases:          []string{"dcp"},
+			Description:      dockercopy.GetDescription(),
+			Arguments:        dockercopy.GetArguments(),
+			Action:           dockerCopyCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+		},
+		{
+			Name:             "docker-warm-cache",
+			Flags:            flagkit.GetCommandFlags(flagkit.DockerWarmCache),
+			Aliases:          []string{"dwc"},
+			Description:      dockerwarmcache.GetDescription(),
+			Arguments:        dockerwarmcache.GetArguments(),
+			Action:           dockerWarmCacheCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "dependencies-prefetch",
+			Flags:            flagkit.GetCommandFlags(flagkit.DependenciesPrefetch),
+			Aliases:          []string{"dpf"},
+			Description:      dependenciesprefetch.GetDescription(),
+			Arguments:        dependenciesprefetch.GetArguments(),
+			Action:           dependenciesPrefetchCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:        "airgap-export",
+			Flags:       flagkit.GetCommandFlags(flagkit.AirGapExport),
+			Aliases:     []string{"age"},
+			Description: airgapexport.GetDescription(),
+			Arguments:   airgapexport.GetArguments(),
+			Action:      airGapExportCmd,
+			Category:    otherCategory,
+		},
+		{
+			Name:        "airgap-import",
+			Flags:       flagkit.GetCommandFlags(flagkit.AirGapImport),
+			Aliases:     []string{"agi"},
+			Description: airgapimport.GetDescription(),
+			Arguments:   airgapimport.GetArguments(),
+			Action:      airGapImportCmd,
+			Category:    otherCategory,
+		},
+		{
+			Name:             "artifact-diff",
+			Flags:            flagkit.GetCommandFlags(flagkit.ArtifactDiff),
+			Aliases:          []string{"adf"},
+			Description:      artifactdiff.GetDescription(),
+			Arguments:        artifactdiff.GetArguments(),
+			Action:           artifactDiffCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "manifest-sync",
+			Flags:            flagkit.GetCommandFlags(flagkit.ManifestSync),
+			Aliases:          []string{"mfs"},
+			Description:      manifestsync.GetDescription(),
+			Arguments:        manifestsync.GetArguments(),
+			Action:           manifestSyncCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "checksum-search",
+			Flags:            flagkit.GetCommandFlags(flagkit.ChecksumSearch),
+			Aliases:          []string{"css"},
+			Description:      checksumsearch.GetDescription(),
+			Arguments:        checksumsearch.GetArguments(),
+			Action:           checksumSearchCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "latest-update",
+			Flags:            flagkit.GetCommandFlags(flagkit.LatestUpdate),
+			Aliases:          []string{"lu"},
+			Description:      latestupdate.GetDescription(),
+			Arguments:        latestupdate.GetArguments(),
+			Action:           latestUpdateCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+			DefaultFormat:    coreformat.Json,
+		},
+		{
+			Name:             "npm-dist-tag",
+			Flags:            flagkit.GetCommandFlags(flagkit.NpmDistTag),
+			Aliases:          []string{"ndt"},
+			Description:      npmdisttag.GetDescription(),
+			Arguments:        npmdisttag.GetArguments(),
+			Action:           npmDistTagCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+			DefaultFormat:    coreformat.Json,
+		},
+		{
+			Name:             "npm-bundle",
+			Flags:            flagkit.GetCommandFlags(flagkit.NpmBundle),
+			Aliases:          []string{"nb"},
+			Description:      npmbundle.GetDescription(),
+			Arguments:        npmbundle.GetArguments(),
+			Action:           npmBundleCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+			DefaultFormat:    coreformat.Json,
+		},
+		{
+			Name:             "onboarding-analyze",
+			Flags:            flagkit.GetCommandFlags(flagkit.OnboardingAnalyze),
+			Aliases:          []string{"oba"},
+			Description:      onboardinganalyze.GetDescription(),
+			Arguments:        onboardinganalyze.GetArguments(),
+			Action:           onboardingAnalyzeCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "mvn-promote",
+			Flags:            flagkit.GetCommandFlags(flagkit.MvnPromote),
+			Aliases:          []string{"mvnp"},
+			Description:      mvnpromote.GetDescription(),
+			Arguments:        mvnpromote.GetArguments(),
+			Action:           mvnPromoteCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+		},
+		{
+			Name:             "jdk-provision",
+			Flags:            flagkit.GetCommandFlags(flagkit.JdkProvision),
+			Aliases:          []string{"jdkp"},
+			Description:      jdkprovision.GetDescription(),
+			Arguments:        jdkprovision.GetArguments(),
+			Action:           jdkProvisionCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+		},
+		{
+			Name:             "node-provision",
+			Flags:            flagkit.GetCommandFlags(flagkit.NodeProvision),
+			Aliases:          []string{"nodep"},
+			Description:      nodeprovision.GetDescription(),
+			Arguments:        nodeprovision.GetArguments(),
+			Action:           nodeProvisionCmd,
+			Category:         otherCategory,
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json},
+		},
 		{
 			Name:             "docker-push",
 			Hidden:           true,
@@ -339,6 +555,30 @@ func GetCommands() []components.Command {
 			},
 			Category: otherCategory,
 		},
+		{
+			Name:             "buildah-push",
+			Flags:            flagkit.GetCommandFlags(flagkit.ContainerPush),
+			Aliases:          []string{"bhp"},
+			Description:      buildahpush.GetDescription(),
+			Arguments:        buildahpush.GetArguments(),
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+			Action: func(c *components.Context) error {
+				return containerPushCmd(c, containerutils.Buildah)
+			},
+			Category: otherCategory,
+		},
+		{
+			Name:             "buildah-pull",
+			Flags:            flagkit.GetCommandFlags(flagkit.ContainerPull),
+			Aliases:          []string{"bhpl"},
+			Description:      buildahpull.GetDescription(),
+			Arguments:        buildahpull.GetArguments(),
+			SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table},
+			Action: func(c *components.Context) error {
+				return containerPullCmd(c, containerutils.Buildah)
+			},
+			Category: otherCategory,
+		},
 		{
 			Name:        "build-docker-create",
 			Flags:       flagkit.GetCommandFlags(flagkit.BuildDockerCreate),
@@ -421,6 +661,24 @@ func GetCommands() []components.Command {
 			Action:      repoDeleteCmd,
 			Category:    repoCategory,
 		},
+		{
+			Name:        "repo-create-set",
+			Aliases:     []string{"rcs"},
+			Flags:       flagkit.GetCommandFlags(flagkit.RepoCreateSet),
+			Description: repocreateset.GetDescription(),
+			Arguments:   repocreateset.GetArguments(),
+			Action:      repoCreateSetCmd,
+			Category:    repoCategory,
+		},
+		{
+			Name:        "repo-create-remote",
+			Aliases:     []string{"rcr"},
+			Flags:       flagkit.GetCommandFlags(flagkit.RepoCreateRemote),
+			Description: repocreateremote.GetDescription(),
+			Arguments:   repocreateremote.GetArguments(repository.GetRemoteRepoPresetNames()),
+			Action:      repoCreateRemoteCmd,
+			Category:    repoCategory,
+		},
 		{
 			Name:        "replication-template",
 			Aliases:     []string{"rplt"},
@@ -451,7 +709,7 @@ func GetCommands() []components.Command {
 		},
 	}
 
-	return commands
+	return addPorcelainFormat(commands)
 }
 
 func getRetries(c *components.Context) (retries int, err error) {
@@ -498,6 +756,17 @@ func getRetryWaitTime(c *components.Context) (waitMilliSecs int, err error) {
 	return
 }
 
+func getPropsBatchSize(c *components.Context) (batchSize int, err error) {
+	if c.GetStringFlagValue("batch-size") != "" {
+		batchSize, err = strconv.Atoi(c.GetStringFlagValue("batch-size"))
+		if err != nil || batchSize < 0 {
+			err = errors.New("The '--batch-size' option should have a non-negative numeric value. " + common.GetDocumentationMessage())
+			return 0, err
+		}
+	}
+	return batchSize, nil
+}
+
 func getRetryWaitTimeVerificationError() error {
 	return errorutils.CheckError(errors.New("The '--retry-wait-time' option should have a numeric value with 's'/'ms' suffix. " + common.GetDocumentationMessage()))
 }
@@ -507,7 +776,7 @@ func dockerPromoteCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -524,21 +793,581 @@ func dockerPromoteCmd(c *components.Context) error {
 	dockerPromoteCommand := container.NewDockerPromoteCommand()
 	dockerPromoteCommand.SetParams(params).SetServerDetails(artDetails)
 
-	if err = commands.Exec(dockerPromoteCommand); err != nil {
+	if err = execRateLimited(dockerPromoteCommand); err != nil {
+		return err
+	}
+
+	// error == nil guarantees the server responded with 200.
+	// The client layer discards the body, so we pass nil and let the helper
+	// synthesize {"status_code": 200, "message": "OK"}.
+	if outputFormat != coreformat.None {
+		return printStatusJSON(w, outputFormat, 200, "OK")
+	}
+	return nil
+}
+
+func dockerCleanupCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	dockerCleanupCommand := container.NewDockerCleanupCommand()
+	dockerCleanupCommand.SetRepo(c.GetArgumentAt(0)).SetImagePath(c.GetStringFlagValue("image")).
+		SetDelete(c.GetBoolFlagValue("delete")).SetQuiet(common.GetQuietValue(c)).SetServerDetails(artDetails)
+	if err = execRateLimited(dockerCleanupCommand); err != nil {
+		return err
+	}
+	result := dockerCleanupCommand.Result()
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		return container.PrintDockerCleanupTable(result)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt docker-cleanup. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func dockerTagRetentionCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	rules := container.TagRetentionRules{ProtectReleases: c.GetBoolFlagValue("protect-releases")}
+	if rules.KeepLast, err = c.GetDefaultIntFlagValueIfNotSet("keep-last", 0); err != nil {
+		return err
+	}
+	if olderThan := c.GetStringFlagValue("older-than"); olderThan != "" {
+		if rules.OlderThan, err = container.ParseRetentionAge(olderThan); err != nil {
+			return err
+		}
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	dockerTagRetentionCommand := container.NewDockerTagRetentionCommand()
+	dockerTagRetentionCommand.SetRepo(c.GetArgumentAt(0)).SetImagePath(c.GetStringFlagValue("image")).SetRules(rules).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).SetQuiet(common.GetQuietValue(c)).SetServerDetails(artDetails)
+	if err = execRateLimited(dockerTagRetentionCommand); err != nil {
+		return err
+	}
+	result := dockerTagRetentionCommand.Result()
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		return container.PrintTagRetentionTable(result)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt docker-tag-retention. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func dockerCopyCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	dockerCopyCommand := container.NewDockerCopyCommand()
+	dockerCopyCommand.SetSource(c.GetArgumentAt(0)).SetTarget(c.GetArgumentAt(1)).SetServerDetails(artDetails)
+	if targetServerId := c.GetStringFlagValue("target-server-id"); targetServerId != "" {
+		targetDetails, err := config.GetSpecificConfig(targetServerId, true, false)
+		if err != nil {
+			return err
+		}
+		dockerCopyCommand.SetTargetServerDetails(targetDetails)
+	}
+	if err = execRateLimited(dockerCopyCommand); err != nil {
+		return err
+	}
+	if isJsonOutput(outputFormat) {
+		return printResultJSON(w, outputFormat, dockerCopyCommand.Result())
+	}
+	return nil
+}
+
+func dockerWarmCacheCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	dockerWarmCacheCommand := container.NewDockerWarmCacheCommand()
+	dockerWarmCacheCommand.SetRepo(c.GetArgumentAt(0)).SetImageListFiles(c.Arguments[1:]).SetThreads(threads).SetServerDetails(artDetails)
+	err = execRateLimited(dockerWarmCacheCommand)
+	// The result includes the images which failed to be pulled.
+	result := dockerWarmCacheCommand.Result()
+	if result == nil {
+		return err
+	}
+	var printErr error
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		printErr = printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		printErr = container.PrintWarmCacheTable(result)
+	default:
+		printErr = errorutils.CheckErrorf("unsupported format '%s' for rt docker-warm-cache. Acceptable values are: json, table", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	return printErr
+}
+
+func dependenciesPrefetchCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	dependenciesPrefetchCommand := mvn.NewDependenciesPrefetchCommand()
+	dependenciesPrefetchCommand.SetRepo(c.GetArgumentAt(0)).SetDependenciesFiles(c.Arguments[1:]).SetThreads(threads).SetServerDetails(artDetails)
+	err = commands.Exec(dependenciesPrefetchCommand)
+	// The result includes the files which failed to be fetched.
+	result := dependenciesPrefetchCommand.Result()
+	if result == nil {
+		return err
+	}
+	var printErr error
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		printErr = printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		printErr = mvn.PrintPrefetchTable(result)
+	default:
+		printErr = errorutils.CheckErrorf("unsupported format '%s' for rt dependencies-prefetch. Acceptable values are: json, table", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	return printErr
+}
+
+func airGapExportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	buildName, buildNumber, err := servicesUtils.ParseNameAndVersion(c.GetStringFlagValue("build"), true)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	airGapExportCommand := airgap.NewAirGapExportCommand()
+	airGapExportCommand.SetArchivePath(c.GetArgumentAt(0)).SetDependenciesFiles(c.Arguments[1:]).SetBuild(buildName, buildNumber).
+		SetProject(common.GetProject(c)).SetRepo(c.GetStringFlagValue("repo")).SetThreads(threads).SetServerDetails(artDetails)
+	return commands.Exec(airGapExportCommand)
+}
+
+func airGapImportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	airGapImportCommand := airgap.NewAirGapImportCommand()
+	airGapImportCommand.SetArchivePath(c.GetArgumentAt(0)).SetTargetRepo(c.GetStringFlagValue("repo")).SetThreads(threads).SetServerDetails(artDetails)
+	return execRateLimited(airGapImportCommand)
+}
+
+func artifactDiffCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	artifactDiffCommand := generic.NewArtifactDiffCommand()
+	artifactDiffCommand.SetSource(c.GetArgumentAt(0)).SetTarget(c.GetArgumentAt(1)).SetServerDetails(artDetails)
+	if err = execRateLimited(artifactDiffCommand); err != nil {
+		return err
+	}
+	result := artifactDiffCommand.Result()
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		err = printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		err = generic.PrintArtifactDiffTable(result)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt artifact-diff. Acceptable values are: json, table", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	if !result.Identical && c.GetBoolFlagValue("fail-on-diff") {
+		return errorutils.CheckErrorf("the content of %s and %s differs in %d entries", result.Source, result.Target, len(result.Entries))
+	}
+	return nil
+}
+
+func checksumSearchCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	checksumSearchCommand := generic.NewChecksumSearchCommand()
+	checksumSearchCommand.SetChecksum(c.GetArgumentAt(0)).SetServerDetails(artDetails)
+	if repos := c.GetStringFlagValue("repos"); repos != "" {
+		checksumSearchCommand.SetRepos(strings.Split(repos, ","))
+	}
+	if err = execRateLimited(checksumSearchCommand); err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, checksumSearchCommand.Result())
+	case coreformat.Table, coreformat.None:
+		return generic.PrintChecksumSearchTable(checksumSearchCommand.Result())
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt checksum-search. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func latestUpdateCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 1 || c.GetNumberOfArgs() > 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	latestCommand := generic.NewLatestCommand()
+	latestCommand.SetPattern(c.GetArgumentAt(0)).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(artDetails)
+	if c.GetNumberOfArgs() == 2 {
+		latestCommand.SetLatestPath(c.GetArgumentAt(1))
+	}
+	if c.IsFlagSet("mode") {
+		latestCommand.SetMode(c.GetStringFlagValue("mode"))
+	}
+	if c.IsFlagSet("sort-by") {
+		latestCommand.SetSortBy(c.GetStringFlagValue("sort-by"))
+	}
+	if c.IsFlagSet("property") {
+		latestCommand.SetProperty(c.GetStringFlagValue("property"))
+	}
+	if err = execRateLimited(latestCommand); err != nil {
+		return err
+	}
+	return printResultJSON(w, outputFormat, latestCommand.Result())
+}
+
+func npmDistTagCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 3 || c.GetNumberOfArgs() > 4 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	distTagCommand := npm.NewNpmDistTagCommand()
+	distTagCommand.SetAction(c.GetArgumentAt(0)).SetRepo(c.GetArgumentAt(1)).SetPackageSpec(c.GetArgumentAt(2)).
+		SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	if c.GetNumberOfArgs() == 4 {
+		distTagCommand.SetTag(c.GetArgumentAt(3))
+	}
+	if err = execRateLimited(distTagCommand); err != nil {
+		return err
+	}
+	return printResultJSON(w, outputFormat, distTagCommand.Result())
+}
+
+func npmBundleCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	bundleCommand := npm.NewNpmBundleCommand()
+	bundleCommand.SetRepo(c.GetArgumentAt(0)).SetTarget(c.GetArgumentAt(1)).SetArchive(c.GetBoolFlagValue("archive")).
+		SetWorkingDirectory(workingDirectory).SetServerDetails(artDetails)
+	if err = commands.Exec(bundleCommand); err != nil {
+		return err
+	}
+	return printResultJSON(w, outputFormat, bundleCommand.Manifest())
+}
+
+func onboardingAnalyzeCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	projectPath := "."
+	if c.GetNumberOfArgs() == 1 {
+		projectPath = c.GetArgumentAt(0)
+	}
+	analyzeCommand := onboarding.NewOnboardingAnalyzeCommand()
+	analyzeCommand.SetPath(projectPath).SetOutputDir(c.GetStringFlagValue("output-dir")).SetServerId(c.GetStringFlagValue("server-id")).
+		SetBuildName(c.GetStringFlagValue("build-name"))
+	if err = commands.Exec(analyzeCommand); err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, analyzeCommand.Report())
+	case coreformat.Table, coreformat.None:
+		return onboarding.PrintOnboardingReport(analyzeCommand.Report())
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt onboarding-analyze. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func jdkProvisionCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	provisionCommand := toolchain.NewJdkProvisionCommand()
+	provisionCommand.SetRepo(c.GetArgumentAt(0)).SetVersion(c.GetArgumentAt(1)).SetVendor(c.GetStringFlagValue("vendor")).
+		SetOs(c.GetStringFlagValue("os")).SetArch(c.GetStringFlagValue("arch")).SetLayout(c.GetStringFlagValue("layout")).
+		SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	if err = commands.Exec(provisionCommand); err != nil {
+		return err
+	}
+	if isJsonOutput(outputFormat) {
+		return printResultJSON(w, outputFormat, provisionCommand.Result())
+	}
+	// The export commands are printed to the standard output, so that they can be evaluated by the shell.
+	for _, exportCommand := range toolchain.ExportCommands(provisionCommand.Result().JavaHome) {
+		if _, err = fmt.Fprintln(w, exportCommand); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+func nodeProvisionCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	provisionCommand := toolchain.NewNodeProvisionCommand()
+	provisionCommand.SetRepo(c.GetArgumentAt(0)).SetVersion(c.GetArgumentAt(1)).SetOs(c.GetStringFlagValue("os")).
+		SetArch(c.GetStringFlagValue("arch")).SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	if err = commands.Exec(provisionCommand); err != nil {
+		return err
+	}
+	if isJsonOutput(outputFormat) {
+		return printResultJSON(w, outputFormat, provisionCommand.Result())
+	}
+	for _, exportCommand := range toolchain.PathExportCommands(provisionCommand.Result().BinDir) {
+		if _, err = fmt.Fprintln(w, exportCommand); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+func manifestSyncCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	manifestSyncCommand := generic.NewManifestSyncCommand()
+	manifestSyncCommand.SetManifestPath(c.GetArgumentAt(0)).SetTarget(c.GetArgumentAt(1)).SetThreads(threads).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).SetQuiet(common.GetQuietValue(c)).SetServerDetails(artDetails)
+	if err = commands.Exec(manifestSyncCommand); err != nil {
+		return err
+	}
+	result := manifestSyncCommand.Result()
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		return generic.PrintManifestSyncTable(result)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt manifest-sync. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func mvnPromoteCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 3 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	mvnPromoteCommand := mvn.NewMvnPromoteCommand()
+	mvnPromoteCommand.SetBuild(c.GetArgumentAt(0), c.GetArgumentAt(1)).SetReleaseRepo(c.GetArgumentAt(2)).
+		SetReleaseVersion(c.GetStringFlagValue("release-version")).
+		SetTargetBuild(c.GetStringFlagValue("target-build-name"), c.GetStringFlagValue("target-build-number")).
+		SetProject(common.GetProject(c)).SetDryRun(c.GetBoolFlagValue("dry-run")).SetThreads(threads).SetServerDetails(artDetails)
+	if err = execRateLimited(mvnPromoteCommand); err != nil {
+		return err
+	}
+	result := mvnPromoteCommand.Result()
+	switch outputFormat {
+	case coreformat.Json, formats.Porcelain:
+		return printResultJSON(w, outputFormat, result)
+	case coreformat.Table, coreformat.None:
+		return mvn.PrintMvnPromoteTable(result)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for rt mvn-promote. Acceptable values are: json, table", outputFormat)
+	}
+}
+
+func deploymentManifestCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	buildName, buildNumber, err := servicesUtils.ParseNameAndVersion(c.GetStringFlagValue("build"), true)
+	if err != nil {
 		return err
 	}
+	bundleName, bundleVersion, err := servicesUtils.ParseNameAndVersion(c.GetStringFlagValue("bundle"), false)
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	deploymentManifestCommand := buildinfo.NewDeploymentManifestCommand()
+	deploymentManifestCommand.SetTemplatePath(c.GetArgumentAt(0)).SetOutputPath(c.GetStringFlagValue("output")).
+		SetBuild(buildName, buildNumber).SetReleaseBundle(bundleName, bundleVersion).SetProject(common.GetProject(c)).
+		SetServerDetails(artDetails)
+	return commands.Exec(deploymentManifestCommand)
+}
 
-	// error == nil guarantees the server responded with 200.
-	// The client layer discards the body, so we pass nil and let the helper
-	// synthesize {"status_code": 200, "message": "OK"}.
-	if outputFormat != coreformat.None {
-		return printStatusJSON(200, "OK")
+// printResultJSON writes the result of a command to w as indented JSON, or as porcelain lines.
+func printResultJSON(w io.Writer, outputFormat coreformat.OutputFormat, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorutils.CheckError(err)
 	}
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
 // printStatusJSON emits a synthetic JSON response with the given HTTP status code and message.
-func printStatusJSON(statusCode int, message string) error {
+func printStatusJSON(w io.Writer, outputFormat coreformat.OutputFormat, statusCode int, message string) error {
 	data, err := json.Marshal(struct {
 		StatusCode int    `json:"status_code"`
 		Message    string `json:"message"`
@@ -546,14 +1375,13 @@ func printStatusJSON(statusCode int, message string) error {
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	log.Output(clientutils.IndentJson(data))
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
 // printCountsTable writes a two-row FIELD/VALUE tabwriter table with success and failure counts.
 func printCountsTable(succeeded, failed int, w io.Writer) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "FIELD\tVALUE")
+	_, _ = fmt.Fprintln(tw, fieldValueHeader())
 	_, _ = fmt.Fprintf(tw, "success\t%d\n", succeeded)
 	_, _ = fmt.Fprintf(tw, "failure\t%d\n", failed)
 	return tw.Flush()
@@ -562,8 +1390,8 @@ func printCountsTable(succeeded, failed int, w io.Writer) error {
 // printCountBasedResponse renders a succeeded/failed count result in the requested output format.
 func printCountBasedResponse(cmdName string, succeeded, failed int, outputFormat coreformat.OutputFormat, w io.Writer, failNoOp bool, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		if err := printSummaryJSON(succeeded, failed, failNoOp, originalErr); err != nil {
+	case coreformat.Json, formats.Porcelain:
+		if err := printSummaryJSON(w, outputFormat, succeeded, failed, failNoOp, originalErr); err != nil {
 			return err
 		}
 		return common.GetCliError(originalErr, succeeded, failed, failNoOp)
@@ -577,41 +1405,21 @@ func printCountBasedResponse(cmdName string, succeeded, failed int, outputFormat
 	}
 }
 
-// printSummaryJSON marshals a summary report to indented JSON and writes it to the log output.
-func printSummaryJSON(succeeded, failed int, failNoOp bool, originalErr error) error {
+// printSummaryJSON marshals a summary report, and writes it to w as indented JSON, or as porcelain lines.
+func printSummaryJSON(w io.Writer, outputFormat coreformat.OutputFormat, succeeded, failed int, failNoOp bool, originalErr error) error {
 	summaryReport := summary.GetSummaryReport(succeeded, failed, failNoOp, originalErr)
 	data, err := summaryReport.Marshal()
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	log.Output(clientutils.IndentJson(data))
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
-
 func containerPushCmd(c *components.Context, containerManagerType containerutils.ContainerManagerType) (err error) {
 	if c.GetNumberOfArgs() != 2 {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
-	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
-	if err != nil {
-		return
-	}
-	imageTag := c.GetArgumentAt(0)
-	targetRepo := c.GetArgumentAt(1)
-	skipLogin := c.GetBoolFlagValue("skip-login")
-	validateSha := c.GetBoolFlagValue("validate-sha")
-
-	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
-	if err != nil {
-		return
-	}
-	dockerPushCommand := container.NewPushCommand(containerManagerType)
-	threads, err := common.GetThreadsCount(c)
-	if err != nil {
-		return
-	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return
 	}
@@ -619,7 +1427,10 @@ func containerPushCmd(c *components.Context, containerManagerType containerutils
 	// When a structured format is requested we need the per-layer transfer details reader,
 	// so force detailed-summary mode regardless of the explicit flag.
 	needDetailedReader := outputFormat != coreformat.None
-	dockerPushCommand.SetThreads(threads).SetDetailedSummary(detailedSummary || printDeploymentView || needDetailedReader).SetCmdParams([]string{"push", imageTag}).SetSkipLogin(skipLogin).SetBuildConfiguration(buildConfiguration).SetRepo(targetRepo).SetServerDetails(artDetails).SetImageTag(imageTag).SetValidateSha(validateSha)
+	dockerPushCommand, err := createContainerPushCommand(c, containerManagerType, detailedSummary || printDeploymentView || needDetailedReader)
+	if err != nil {
+		return
+	}
 	err = commandWrappers.ShowDockerDeprecationMessageIfNeeded(containerManagerType, dockerPushCommand.IsGetRepoSupported)
 	if err != nil {
 		return
@@ -633,15 +1444,43 @@ func containerPushCmd(c *components.Context, containerManagerType containerutils
 		err = common.PrintCommandSummary(dockerPushCommand.Result(), detailedSummary, printDeploymentView, false, err)
 		return
 	}
-	err = printContainerPushResponse(result, outputFormat, os.Stdout, err)
+	err = printContainerPushResponse(result, outputFormat, w, err)
 	return
 }
 
+// createContainerPushCommand creates the push command of the image of the first argument to the repository of the second argument.
+// The build-info of the pushed image is recorded in the build of the project of the --project option, or of JFROG_CLI_BUILD_PROJECT.
+func createContainerPushCommand(c *components.Context, containerManagerType containerutils.ContainerManagerType, detailedSummary bool) (*container.PushCommand, error) {
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return nil, err
+	}
+	imageTag := c.GetArgumentAt(0)
+	targetRepo := c.GetArgumentAt(1)
+	skipLogin := c.GetBoolFlagValue("skip-login")
+	validateSha := c.GetBoolFlagValue("validate-sha")
+	sbomFile := c.GetStringFlagValue("sbom")
+	generateSbom := c.GetBoolFlagValue("generate-sbom")
+
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return nil, err
+	}
+	dockerPushCommand := container.NewPushCommand(containerManagerType)
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return nil, err
+	}
+	dockerPushCommand.SetThreads(threads).SetDetailedSummary(detailedSummary).SetCmdParams([]string{"push", imageTag}).SetSkipLogin(skipLogin).SetBuildConfiguration(buildConfiguration).SetRepo(targetRepo).SetServerDetails(artDetails).SetImageTag(imageTag).SetValidateSha(validateSha)
+	dockerPushCommand.SetSbomFile(sbomFile).SetGenerateSbom(generateSbom)
+	return dockerPushCommand, nil
+}
+
 // printContainerPushResponse renders the container push result in the requested output format.
 func printContainerPushResponse(result *commandUtils.Result, outputFormat coreformat.OutputFormat, w io.Writer, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		return common.PrintCommandSummary(result, true, false, false, originalErr)
+	case coreformat.Json, formats.Porcelain:
+		return printDetailedSummaryJSON(w, outputFormat, result, true, false, originalErr)
 	case coreformat.Table:
 		err := printContainerPushTable(result, w)
 		if err != nil {
@@ -676,7 +1515,7 @@ func printContainerPushTable(result *commandUtils.Result, w io.Writer) error {
 		return err
 	}
 	reader.Reset()
-	return coreutils.PrintTable(rows, "Push Results", "No layers were pushed.", false)
+	return coreutils.PrintTable(rows, formats.Localize("push.title", "Push Results"), formats.Localize("push.empty", "No layers were pushed."), false)
 }
 
 func containerPullCmd(c *components.Context, containerManagerType containerutils.ContainerManagerType) error {
@@ -694,7 +1533,7 @@ func containerPullCmd(c *components.Context, containerManagerType containerutils
 	if err != nil {
 		return err
 	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return err
 	}
@@ -710,14 +1549,14 @@ func containerPullCmd(c *components.Context, containerManagerType containerutils
 	if outputFormat == coreformat.None {
 		return nil
 	}
-	return printContainerPullResponse(imageTag, sourceRepo, outputFormat, os.Stdout)
+	return printContainerPullResponse(imageTag, sourceRepo, outputFormat, w)
 }
 
 // printContainerPullResponse renders the container pull result in the requested output format.
 func printContainerPullResponse(imageTag, sourceRepo string, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	switch outputFormat {
-	case coreformat.Json:
-		return printContainerPullJSON(imageTag, sourceRepo)
+	case coreformat.Json, formats.Porcelain:
+		return printContainerPullJSON(imageTag, sourceRepo, outputFormat, w)
 	case coreformat.Table:
 		return printContainerPullTable(imageTag, sourceRepo, w)
 	default:
@@ -725,8 +1564,8 @@ func printContainerPullResponse(imageTag, sourceRepo string, outputFormat corefo
 	}
 }
 
-// printContainerPullJSON emits a JSON summary of the container pull operation to stdout via log.Output.
-func printContainerPullJSON(imageTag, sourceRepo string) error {
+// printContainerPullJSON writes a JSON summary of the container pull operation to w, or its porcelain lines.
+func printContainerPullJSON(imageTag, sourceRepo string, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	result := map[string]interface{}{
 		"status": "ok",
 		"image":  imageTag,
@@ -736,14 +1575,13 @@ func printContainerPullJSON(imageTag, sourceRepo string) error {
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	log.Output(clientutils.IndentJson(data))
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
 // printContainerPullTable renders a FIELD/VALUE table for the container pull operation.
 func printContainerPullTable(imageTag, sourceRepo string, w io.Writer) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "FIELD\tVALUE")
+	_, _ = fmt.Fprintln(tw, fieldValueHeader())
 	_, _ = fmt.Fprintf(tw, "status\t%s\n", "ok")
 	_, _ = fmt.Fprintf(tw, "image\t%s\n", imageTag)
 	_, _ = fmt.Fprintf(tw, "repo\t%s\n", sourceRepo)
@@ -831,19 +1669,18 @@ func nugetDepsTreeCmd(c *components.Context) error {
 	if err != nil {
 		return err
 	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return err
 	}
-	return printNugetDepsTreeResponse(content, outputFormat, os.Stdout)
+	return printNugetDepsTreeResponse(content, outputFormat, w)
 }
 
 // printNugetDepsTreeResponse renders the dependency tree in the requested output format.
 func printNugetDepsTreeResponse(data []byte, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	switch outputFormat {
-	case coreformat.Json:
-		log.Output(clientutils.IndentJson(data))
-		return nil
+	case coreformat.Json, formats.Porcelain:
+		return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 	case coreformat.Table:
 		return printNugetDepsTreeTable(data, w)
 	default:
@@ -869,7 +1706,7 @@ func printNugetDepsTreeTable(data []byte, w io.Writer) error {
 		return errorutils.CheckErrorf("failed to parse nuget-deps-tree response: %s", err.Error())
 	}
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "PROJECT\tDEPENDENCY_COUNT")
+	_, _ = fmt.Fprintln(tw, formats.Localize("nuget.deps-tree.project", "PROJECT")+"\t"+formats.Localize("nuget.deps-tree.dependency-count", "DEPENDENCY_COUNT"))
 	for _, p := range sol.Projects {
 		_, _ = fmt.Fprintf(tw, "%s\t%d\n", p.Name, len(p.Dependencies))
 	}
@@ -886,17 +1723,22 @@ func pingCmd(c *components.Context) error {
 	}
 	cmd := coregeneric.NewPingCommand()
 	cmd.SetServerDetails(artDetails)
-	err = commands.Exec(cmd)
+	err = execWithTrafficOptions(c, artDetails, func() error { return commands.Exec(cmd) })
 	resBody := cmd.Response()
 	resString := clientutils.IndentJson(resBody)
 	if err != nil {
 		return errors.New(err.Error() + "\n" + resString)
 	}
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
-	return printPingResponse(resBody, outputFormat, os.Stdout)
+	return printPingResponse(resBody, outputFormat, w)
+}
+
+// fieldValueHeader returns the header of the tables of the fields of a result.
+func fieldValueHeader() string {
+	return formats.Localize("table.field", "FIELD") + "\t" + formats.Localize("table.value", "VALUE")
 }
 
 // printPingResponse renders the raw ping body in the requested output format.
@@ -906,8 +1748,8 @@ func printPingResponse(body []byte, outputFormat coreformat.OutputFormat, w io.W
 		// Backward-compatible: print the raw (or indented) response as before.
 		log.Output(clientutils.IndentJson(body))
 		return nil
-	case coreformat.Json:
-		return printPingJSON(body)
+	case coreformat.Json, formats.Porcelain:
+		return printPingJSON(body, outputFormat, w)
 	case coreformat.Table:
 		return printPingTable(body, w)
 	default:
@@ -932,22 +1774,21 @@ func pingResponseFromBody(body []byte) pingResponse {
 	return pingResponse{StatusCode: http.StatusOK, Message: msg}
 }
 
-// printPingJSON emits the ping result as indented JSON.
-func printPingJSON(body []byte) error {
+// printPingJSON writes the ping result to w as indented JSON, or as porcelain lines.
+func printPingJSON(body []byte, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	resp := pingResponseFromBody(body)
 	data, err := json.Marshal(resp)
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	log.Output(clientutils.IndentJson(data))
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
 // printPingTable renders the ping result as a two-column tabwriter table.
 func printPingTable(body []byte, w io.Writer) error {
 	resp := pingResponseFromBody(body)
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "FIELD\tVALUE")
+	_, _ = fmt.Fprintln(tw, fieldValueHeader())
 	_, _ = fmt.Fprintf(tw, "status_code\t%d\n", resp.StatusCode)
 	_, _ = fmt.Fprintf(tw, "message\t%s\n", resp.Message)
 	return tw.Flush()
@@ -964,8 +1805,15 @@ func prepareDownloadCommand(c *components.Context) (*spec.SpecFiles, error) {
 	var downloadSpec *spec.SpecFiles
 	var err error
 
+	if c.IsFlagSet("bundle") {
+		bundle, err := workspace.ResolveValue(c.GetStringFlagValue("bundle"))
+		if err != nil {
+			return nil, err
+		}
+		c.SetStringFlagValue("bundle", bundle)
+	}
 	if c.IsFlagSet("spec") {
-		downloadSpec, err = commonCliUtils.GetSpec(c, true, true)
+		downloadSpec, err = getSpec(c, true, true)
 	} else {
 		downloadSpec, err = createDefaultDownloadSpec(c)
 	}
@@ -975,6 +1823,9 @@ func prepareDownloadCommand(c *components.Context) (*spec.SpecFiles, error) {
 	}
 
 	setTransitiveInDownloadSpec(downloadSpec)
+	if err = applyAqlFilters(c, downloadSpec); err != nil {
+		return nil, err
+	}
 	err = spec.ValidateSpec(downloadSpec.Files, false, true)
 	if err != nil {
 		return nil, err
@@ -996,7 +1847,7 @@ func prepareDirectDownloadCommand(c *components.Context) (*spec.SpecFiles, error
 	)
 
 	if c.IsFlagSet("spec") {
-		downloadSpec, err = commonCliUtils.GetSpec(c, true, true)
+		downloadSpec, err = getSpec(c, true, true)
 	} else {
 		downloadSpec = createDirectDownloadSpec(c)
 	}
@@ -1037,6 +1888,7 @@ func createDirectDownloadSpec(c *components.Context) *spec.SpecFiles {
 		Flat(c.GetBoolFlagValue("flat")).
 		Explode(strconv.FormatBool(c.GetBoolFlagValue("explode"))).
 		Target(c.GetArgumentAt(1)).
+		Project(common.GetProject(c)).
 		BuildSpec()
 }
 
@@ -1079,7 +1931,7 @@ func directDownloadCmd(c *components.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return err
 	}
@@ -1108,7 +1960,7 @@ func directDownloadCmd(c *components.Context) (err error) {
 		err = common.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, err)
 		return common.GetCliError(err, result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c))
 	}
-	err = printDirectDownloadResponse(result, outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	err = printDirectDownloadResponse(result, outputFormat, w, common.IsFailNoOp(c), err)
 	return
 }
 
@@ -1139,7 +1991,7 @@ func downloadCmd(c *components.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return err
 	}
@@ -1147,15 +1999,38 @@ func downloadCmd(c *components.Context) (err error) {
 	// When a structured format is requested we need the per-file transfer details reader,
 	// so force detailed-summary mode regardless of the explicit flag.
 	needDetailedReader := outputFormat != coreformat.None
-	downloadCommand := generic.NewDownloadCommand()
+	validationReport, err := getValidationReportOptions(c)
+	if err != nil {
+		return err
+	}
+	downloadCommand := generic.NewDownloadCommand().SetValidationReport(validationReport).SetValidateOnly(c.GetBoolFlagValue("validate-only"))
+	if expectedArtifactsPath := c.GetStringFlagValue("expected-artifacts"); expectedArtifactsPath != "" {
+		expectedArtifacts, err := generic.ReadExpectedArtifacts(expectedArtifactsPath)
+		if err != nil {
+			return err
+		}
+		downloadCommand.SetExpectedArtifacts(expectedArtifacts)
+	} else if c.GetBoolFlagValue("validate-only") {
+		return errorutils.CheckErrorf("the --validate-only option requires --expected-artifacts")
+	}
 	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.GetBoolFlagValue("dry-run")).SetSyncDeletesPath(c.GetStringFlagValue("sync-deletes")).SetQuiet(common.GetQuietValue(c)).SetDetailedSummary(detailedSummary || needDetailedReader).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if downloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	var execCommand progressbar.CommandWithProgress = downloadCommand
+	// Each folder of the spec is downloaded as a single archive, generated by Artifactory.
+	if archiveType := c.GetStringFlagValue("folder-archive"); archiveType != "" {
+		if err = generic.ValidateArchiveType(archiveType); err != nil {
+			return err
+		}
+		archiveDownloadCommand := &generic.ArchiveDownloadCommand{DownloadCommand: *downloadCommand}
+		execCommand = archiveDownloadCommand.SetArchiveType(archiveType)
+		downloadCommand = &archiveDownloadCommand.DownloadCommand
+	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(downloadCommand)
+	err = execWithTrafficOptions(c, serverDetails, func() error { return progressbar.ExecWithProgress(execCommand) })
 	result := downloadCommand.Result()
 	defer common.CleanupResult(result, &err)
 	if outputFormat == coreformat.None {
@@ -1166,7 +2041,7 @@ func downloadCmd(c *components.Context) (err error) {
 		err = common.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, err)
 		return common.GetCliError(err, result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c))
 	}
-	err = printDownloadResponse(result, outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	err = printDownloadResponse(result, outputFormat, w, common.IsFailNoOp(c), err)
 	return
 }
 
@@ -1174,12 +2049,12 @@ func downloadCmd(c *components.Context) (err error) {
 // It preserves the fail-no-op and error-accounting semantics of PrintDetailedSummaryReport.
 func printDownloadResponse(result *commandUtils.Result, outputFormat coreformat.OutputFormat, w io.Writer, failNoOp bool, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		basicSummary, err := common.CreateSummaryReportString(result.SuccessCount(), result.FailCount(), failNoOp, originalErr)
-		if err != nil {
-			return err
+	case coreformat.Json, formats.Porcelain:
+		// The summary isn't written if the download failed, as the summary of the core commands.
+		if originalErr != nil {
+			return originalErr
 		}
-		return common.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, originalErr)
+		return printDetailedSummaryJSON(w, outputFormat, result, false, failNoOp, nil)
 	case coreformat.Table:
 		err := printDownloadTable(result, w)
 		if err != nil {
@@ -1214,19 +2089,19 @@ func printDownloadTable(result *commandUtils.Result, w io.Writer) error {
 		return err
 	}
 	reader.Reset()
-	return coreutils.PrintTable(rows, "Download Results", "No files were downloaded.", false)
+	return coreutils.PrintTable(rows, formats.Localize("download.title", "Download Results"), formats.Localize("download.empty", "No files were downloaded."), false)
 }
 
 // printDirectDownloadResponse renders the direct-download result in the requested output format.
 // It preserves the fail-no-op and error-accounting semantics of PrintDetailedSummaryReport.
 func printDirectDownloadResponse(result *commandUtils.Result, outputFormat coreformat.OutputFormat, w io.Writer, failNoOp bool, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		basicSummary, err := common.CreateSummaryReportString(result.SuccessCount(), result.FailCount(), failNoOp, originalErr)
-		if err != nil {
-			return err
+	case coreformat.Json, formats.Porcelain:
+		// The summary isn't written if the download failed, as the summary of the core commands.
+		if originalErr != nil {
+			return originalErr
 		}
-		return common.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, originalErr)
+		return printDetailedSummaryJSON(w, outputFormat, result, false, failNoOp, nil)
 	case coreformat.Table:
 		err := printDirectDownloadTable(result, w)
 		if err != nil {
@@ -1255,7 +2130,7 @@ func printDirectDownloadTable(result *commandUtils.Result, w io.Writer) error {
 		return err
 	}
 	reader.Reset()
-	return coreutils.PrintTable(rows, "Direct Download Results", "No files were downloaded.", false)
+	return coreutils.PrintTable(rows, formats.Localize("direct-download.title", "Direct Download Results"), formats.Localize("download.empty", "No files were downloaded."), false)
 }
 
 func checkRbExistenceInV2(c *components.Context) (bool, error) {
@@ -1274,7 +2149,7 @@ func checkRbExistenceInV2(c *components.Context) (bool, error) {
 		return false, err
 	}
 
-	return lcServicesManager.IsReleaseBundleExist(rbName, rbVersion, c.GetStringFlagValue("project"))
+	return lcServicesManager.IsReleaseBundleExist(rbName, rbVersion, common.GetProject(c))
 }
 
 func createLifecycleDetailsByFlags(c *components.Context) (*config.ServerDetails, error) {
@@ -1307,16 +2182,24 @@ func PlatformToLifecycleUrls(lcDetails *config.ServerDetails) {
 }
 
 func uploadCmd(c *components.Context) (err error) {
+	if c.IsFlagSet("spec") && c.IsFlagSet("routing-rules") {
+		return common.PrintHelpAndReturnError("The --spec and --routing-rules options cannot be used together.", c)
+	}
 	if c.GetNumberOfArgs() > 0 && c.IsFlagSet("spec") {
 		return common.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
 	}
-	if c.GetNumberOfArgs() != 2 && (c.GetNumberOfArgs() != 0 || !c.IsFlagSet("spec")) {
+	if c.GetNumberOfArgs() > 0 && c.IsFlagSet("routing-rules") {
+		return common.PrintHelpAndReturnError("No arguments should be sent when the routing-rules option is used.", c)
+	}
+	if c.GetNumberOfArgs() != 2 && (c.GetNumberOfArgs() != 0 || (!c.IsFlagSet("spec") && !c.IsFlagSet("routing-rules"))) {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
 	var uploadSpec *spec.SpecFiles
 	if c.IsFlagSet("spec") {
-		uploadSpec, err = commonCliUtils.GetSpec(c, false, true)
+		uploadSpec, err = getSpec(c, false, true)
+	} else if c.IsFlagSet("routing-rules") {
+		uploadSpec, err = createRoutingRulesUploadSpec(c)
 	} else {
 		uploadSpec, err = createDefaultUploadSpec(c)
 	}
@@ -1344,11 +2227,15 @@ func uploadCmd(c *components.Context) (err error) {
 	if err != nil {
 		return
 	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
+	if err != nil {
+		return
+	}
+	validationReport, err := getValidationReportOptions(c)
 	if err != nil {
 		return
 	}
-	uploadCmd := generic.NewUploadCommand()
+	uploadCmd := generic.NewUploadCommand().SetValidationReport(validationReport).SetResume(c.GetBoolFlagValue("resume")).SetExtractProps(c.GetBoolFlagValue("extract-props"))
 	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return
@@ -1364,14 +2251,14 @@ func uploadCmd(c *components.Context) (err error) {
 		return nil
 	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(uploadCmd)
+	err = execWithTrafficOptions(c, rtDetails, func() error { return progressbar.ExecWithProgress(uploadCmd) })
 	result := uploadCmd.Result()
 	defer common.CleanupResult(result, &err)
 	if outputFormat == coreformat.None {
 		err = common.PrintCommandSummary(uploadCmd.Result(), detailedSummary, printDeploymentView, common.IsFailNoOp(c), err)
 		return
 	}
-	err = printUploadResponse(result, outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	err = printUploadResponse(result, outputFormat, w, common.IsFailNoOp(c), err)
 	return
 }
 
@@ -1379,8 +2266,8 @@ func uploadCmd(c *components.Context) (err error) {
 // It preserves the fail-no-op and error-accounting semantics of PrintCommandSummary.
 func printUploadResponse(result *commandUtils.Result, outputFormat coreformat.OutputFormat, w io.Writer, failNoOp bool, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		return common.PrintCommandSummary(result, true, false, failNoOp, originalErr)
+	case coreformat.Json, formats.Porcelain:
+		return printDetailedSummaryJSON(w, outputFormat, result, true, failNoOp, originalErr)
 	case coreformat.Table:
 		err := printUploadTable(result, w)
 		if err != nil {
@@ -1417,7 +2304,7 @@ func printUploadTable(result *commandUtils.Result, w io.Writer) error {
 		return err
 	}
 	reader.Reset()
-	return coreutils.PrintTable(rows, "Upload Results", "No files were uploaded.", false)
+	return coreutils.PrintTable(rows, formats.Localize("upload.title", "Upload Results"), formats.Localize("upload.empty", "No files were uploaded."), false)
 }
 
 func prepareCopyMoveCommand(c *components.Context) (*spec.SpecFiles, error) {
@@ -1431,7 +2318,7 @@ func prepareCopyMoveCommand(c *components.Context) (*spec.SpecFiles, error) {
 	var copyMoveSpec *spec.SpecFiles
 	var err error
 	if c.IsFlagSet("spec") {
-		copyMoveSpec, err = commonCliUtils.GetSpec(c, false, true)
+		copyMoveSpec, err = getSpec(c, false, true)
 	} else {
 		copyMoveSpec, err = createDefaultCopyMoveSpec(c)
 	}
@@ -1468,17 +2355,18 @@ func moveCmd(c *components.Context) error {
 		return err
 	}
 	mvCmd.SetThreads(threads).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(mvCmd)
+	mvCmd.SetOverrideProtection(c.GetBoolFlagValue("override-protection"))
+	err = execWithTrafficOptions(c, rtDetails, func() error { return commands.Exec(mvCmd) })
 	result := mvCmd.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("move", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("move", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
 }
 
 func copyCmd(c *components.Context) error {
@@ -1505,17 +2393,188 @@ func copyCmd(c *components.Context) error {
 		return err
 	}
 	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(copyCommand)
+	err = execWithTrafficOptions(c, rtDetails, func() error { return commands.Exec(copyCommand) })
 	result := copyCommand.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("copy", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("copy", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
+}
+
+// execWithTrafficOptions runs exec while recording the HTTP traffic sent using the server details to the file of the
+// --capture-har option, if set, while propagating the trace context of the span of the command, if enabled by tracing.SpanEnv,
+// and while caching the metadata API responses, if enabled by the httpcache.CacheEnv environment variable.
+// The requests wait while the servers throttle the process, unless disabled by the ratelimit.RetriesEnv environment variable.
+// The connections to the servers are opened through the SSH tunnel of the server profile, if the SSH tunnels file defines one.
+func execWithTrafficOptions(c *components.Context, serverDetails *config.ServerDetails, exec func() error) error {
+	// The proxies point the URLs of the server details to themselves, so the URL of the server is kept for the results of the command.
+	artifactoryUrl := serverDetails.ArtifactoryUrl
+	// The SSH tunnel is started before the other proxies, so that it opens the connections to the servers for all of them.
+	stopTunnel, err := sshtunnel.Start(serverDetails)
+	if err != nil {
+		return err
+	}
+	err = execWithRateLimit(c, serverDetails, artifactoryUrl, exec)
+	if stopErr := stopTunnel(); stopErr != nil {
+		if err == nil {
+			return stopErr
+		}
+		log.Error("Failed to stop the SSH tunnel:", stopErr.Error())
+	}
+	return err
+}
+
+func execWithRateLimit(c *components.Context, serverDetails *config.ServerDetails, artifactoryUrl string, exec func() error) error {
+	// The rate limit proxy is started first, so that it's the closest to the servers, and the other proxies only see the
+	// responses of the throttled requests once they were retried.
+	return withRateLimit(serverDetails, func() error {
+		return execWithReceipt(c, serverDetails, artifactoryUrl, exec)
+	})
+}
+
+// execRateLimited executes the command, while its requests wait while the servers throttle the process, unless disabled by the
+// ratelimit.RetriesEnv environment variable. It's used by the commands which send their requests by the services manager only.
+// The commands which pass the URLs of the servers to the tools they run, or write them to files, are executed as is, since
+// the URLs of the proxy would outlive it.
+func execRateLimited(command commands.Command) error {
+	serverDetails, err := command.ServerDetails()
+	if err != nil || serverDetails == nil {
+		return commands.Exec(command)
+	}
+	return withRateLimit(serverDetails, func() error {
+		return commands.Exec(command)
+	})
+}
+
+func withRateLimit(serverDetails *config.ServerDetails, exec func() error) error {
+	stopRateLimit, err := ratelimit.Start(serverDetails)
+	if err != nil {
+		return err
+	}
+	err = exec()
+	if stopErr := stopRateLimit(); stopErr != nil {
+		if err == nil {
+			return stopErr
+		}
+		log.Error("Failed to stop the rate limit proxy:", stopErr.Error())
+	}
+	return err
+}
+
+// execWithReceipt records the operations of the command to the receipt file of the --receipt option, if set. The operations are
+// also recorded when a workspace is enabled, so that the paths they succeeded on are saved to the workspace.
+// The receipt references the server by artifactoryUrl, which is the URL of the server details before any proxy started.
+func execWithReceipt(c *components.Context, serverDetails *config.ServerDetails, artifactoryUrl string, exec func() error) error {
+	receiptPath := c.GetStringFlagValue("receipt")
+	if receiptPath == "" && !workspace.IsEnabled() {
+		return execWithHarCapture(c, serverDetails, exec)
+	}
+	// The receipt is started first, so that its proxy receives the requests with the trace context of the tracing proxy.
+	recording, err := receipt.Start(serverDetails, artifactoryUrl, c.CommandName, receiptPath)
+	if err != nil {
+		return err
+	}
+	err = execWithHarCapture(c, serverDetails, exec)
+	if closeErr := recording.Close(); closeErr != nil {
+		if err == nil {
+			return closeErr
+		}
+		log.Error("Failed to write the receipt file:", closeErr.Error())
+	}
+	if saveErr := workspace.SaveReceipt(recording.Receipt()); saveErr != nil {
+		if err == nil {
+			return saveErr
+		}
+		log.Error("Failed to save the results to the workspace:", saveErr.Error())
+	}
+	return err
+}
+
+// getSpec returns the spec of the --spec option, with the workspace references expanded. With a workspace, the spec is saved as
+// its last spec, which --spec=@last-spec uses.
+func getSpec(c *components.Context, isDownload, overrideFieldsIfSet bool) (specFiles *spec.SpecFiles, err error) {
+	specPath := c.GetStringFlagValue("spec")
+	if key, isReference := workspace.ParseReference(specPath); isReference {
+		if key != workspace.LastSpec {
+			return nil, errorutils.CheckErrorf("the %s reference can't be used as a spec", specPath)
+		}
+		if specFiles, err = workspace.GetSpec(); err != nil {
+			return nil, err
+		}
+		for i := range specFiles.Files {
+			if isDownload {
+				specFiles.Get(i).Pattern = strings.TrimPrefix(specFiles.Get(i).Pattern, "/")
+			}
+			if overrideFieldsIfSet {
+				commonCliUtils.OverrideFieldsIfSet(specFiles.Get(i), c)
+			}
+		}
+	} else {
+		if specFiles, err = commonCliUtils.GetSpec(c, isDownload, overrideFieldsIfSet); err != nil {
+			return nil, err
+		}
+		if err = workspace.SaveSpec(c.CommandName, specFiles); err != nil {
+			return nil, err
+		}
+	}
+	return workspace.ExpandSpec(specFiles)
+}
+
+func execWithHarCapture(c *components.Context, serverDetails *config.ServerDetails, exec func() error) error {
+	harPath := c.GetStringFlagValue("capture-har")
+	if harPath == "" {
+		return execWithTracing(c, serverDetails, exec)
+	}
+	capture, err := har.StartCapture(serverDetails, harPath)
+	if err != nil {
+		return err
+	}
+	// The cache is started after the capture, so that the HAR file records the conditional requests sent to the server.
+	err = execWithTracing(c, serverDetails, exec)
+	if closeErr := capture.Close(); closeErr != nil {
+		if err == nil {
+			return closeErr
+		}
+		log.Error("Failed to write the HAR file:", closeErr.Error())
+	}
+	return err
+}
+
+// execWithTracing runs exec while propagating the trace context on the requests. The tracing is started before the cache,
+// so that only the requests sent to the servers are traced, and the request IDs they return are recorded.
+func execWithTracing(c *components.Context, serverDetails *config.ServerDetails, exec func() error) error {
+	endTracing, err := tracing.Start(serverDetails, c.CommandName)
+	if err != nil {
+		return err
+	}
+	err = execWithHttpCache(serverDetails, exec)
+	if endErr := endTracing(); endErr != nil {
+		if err == nil {
+			return endErr
+		}
+		log.Error("Failed to stop the tracing:", endErr.Error())
+	}
+	return err
+}
+
+func execWithHttpCache(serverDetails *config.ServerDetails, exec func() error) error {
+	stopCache, err := httpcache.Start(serverDetails)
+	if err != nil {
+		return err
+	}
+	err = exec()
+	if stopErr := stopCache(); stopErr != nil {
+		if err == nil {
+			return stopErr
+		}
+		log.Error("Failed to stop the HTTP cache:", stopErr.Error())
+	}
+	return err
 }
 
 // Prints a 'brief' (not detailed) summary and returns the appropriate exit error.
@@ -1535,7 +2594,7 @@ func prepareDeleteCommand(c *components.Context) (*spec.SpecFiles, error) {
 	var deleteSpec *spec.SpecFiles
 	var err error
 	if c.IsFlagSet("spec") {
-		deleteSpec, err = commonCliUtils.GetSpec(c, false, true)
+		deleteSpec, err = getSpec(c, false, true)
 	} else {
 		deleteSpec, err = createDefaultDeleteSpec(c)
 	}
@@ -1574,17 +2633,18 @@ func deleteCmd(c *components.Context) error {
 		return err
 	}
 	deleteCommand.SetThreads(threads).SetQuiet(common.GetQuietValue(c)).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(deleteCommand)
+	deleteCommand.SetOverrideProtection(c.GetBoolFlagValue("override-protection"))
+	err = execWithTrafficOptions(c, rtDetails, func() error { return commands.Exec(deleteCommand) })
 	result := deleteCommand.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("delete", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("delete", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
 }
 
 func prepareSearchCommand(c *components.Context) (*spec.SpecFiles, error) {
@@ -1598,13 +2658,16 @@ func prepareSearchCommand(c *components.Context) (*spec.SpecFiles, error) {
 	var searchSpec *spec.SpecFiles
 	var err error
 	if c.IsFlagSet("spec") {
-		searchSpec, err = commonCliUtils.GetSpec(c, false, true)
+		searchSpec, err = getSpec(c, false, true)
 	} else {
 		searchSpec, err = createDefaultSearchSpec(c)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if err = applyAqlFilters(c, searchSpec); err != nil {
+		return nil, err
+	}
 	err = spec.ValidateSpec(searchSpec.Files, false, true)
 	if err != nil {
 		return nil, err
@@ -1612,6 +2675,43 @@ func prepareSearchCommand(c *components.Context) (*spec.SpecFiles, error) {
 	return searchSpec, err
 }
 
+// applyAqlFilters limits the file groups of the spec to the artifacts matching their properties expressions, and to the artifacts
+// created and last modified before the --point-in-time option. The expression of each group is read from the spec file, and the --props-expr option
+// overrides it, as the other options override the spec fields.
+func applyAqlFilters(c *components.Context, specFiles *spec.SpecFiles) (err error) {
+	exprs := make([]string, len(specFiles.Files))
+	if c.IsFlagSet("spec") {
+		if exprs, err = artifactoryUtils.ReadSpecPropsExprs(c.GetStringFlagValue("spec"), coreutils.SpecVarsStringToMap(c.GetStringFlagValue("spec-vars"))); err != nil {
+			return
+		}
+	}
+	for i := 0; i < len(specFiles.Files) && i < len(exprs); i++ {
+		expr := exprs[i]
+		if c.IsFlagSet("props-expr") {
+			expr = c.GetStringFlagValue("props-expr")
+		}
+		if expr == "" {
+			continue
+		}
+		if err = artifactoryUtils.ApplyPropsExpr(specFiles.Get(i), expr); err != nil {
+			return
+		}
+	}
+	if !c.IsFlagSet("point-in-time") {
+		return
+	}
+	pointInTime, err := artifactoryUtils.ParsePointInTime(c.GetStringFlagValue("point-in-time"))
+	if err != nil {
+		return
+	}
+	for i := 0; i < len(specFiles.Files); i++ {
+		if err = artifactoryUtils.ApplyPointInTime(specFiles.Get(i), pointInTime); err != nil {
+			return
+		}
+	}
+	return
+}
+
 func searchCmd(c *components.Context) (err error) {
 	searchSpec, err := prepareSearchCommand(c)
 	if err != nil {
@@ -1631,7 +2731,7 @@ func searchCmd(c *components.Context) (err error) {
 	}
 	cmd := generic.NewSearchCommand()
 	cmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(cmd)
+	err = execWithTrafficOptions(c, artDetails, func() error { return commands.Exec(cmd) })
 	if err != nil {
 		return
 	}
@@ -1645,15 +2745,18 @@ func searchCmd(c *components.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	if c.GetBoolFlagValue("count") {
-		log.Output(length)
-		return nil
-	}
-	outputFormat, err := c.GetOutputFormat()
+	outputFormat, w, err := getOutput(c)
 	if err != nil {
 		return err
 	}
-	return printSearchResponse(reader, outputFormat)
+	if c.GetBoolFlagValue("count") {
+		if isJsonOutput(outputFormat) {
+			return formats.NewResultWriter(w, outputFormat).WriteJSON([]byte(strconv.Itoa(length)))
+		}
+		_, err = fmt.Fprintln(w, length)
+		return errorutils.CheckError(err)
+	}
+	return printSearchResponse(reader, outputFormat, w)
 }
 
 // searchTableRow is a table-printable representation of a search result item.
@@ -1667,10 +2770,10 @@ type searchTableRow struct {
 }
 
 // printSearchResponse renders ContentReader results in the requested output format.
-func printSearchResponse(reader *content.ContentReader, outputFormat coreformat.OutputFormat) error {
+func printSearchResponse(reader *content.ContentReader, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	switch outputFormat {
-	case coreformat.Json:
-		return utils.PrintSearchResults(reader)
+	case coreformat.Json, formats.Porcelain:
+		return printSearchJSON(reader, outputFormat, w)
 	case coreformat.Table:
 		return printSearchTable(reader)
 	default:
@@ -1678,6 +2781,22 @@ func printSearchResponse(reader *content.ContentReader, outputFormat coreformat.
 	}
 }
 
+// printSearchJSON writes the search results to w as a JSON array, or as porcelain lines. The results are streamed from the reader.
+func printSearchJSON(reader *content.ContentReader, outputFormat coreformat.OutputFormat, w io.Writer) error {
+	err := formats.NewResultWriter(w, outputFormat).WriteJSONArray(func() (any, bool) {
+		item := new(utils.SearchResult)
+		return item, reader.NextRecord(item) == nil
+	})
+	if err != nil {
+		return err
+	}
+	if err = reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return nil
+}
+
 // printSearchTable prints search results as a human-readable table using coreutils.PrintTable.
 func printSearchTable(reader *content.ContentReader) error {
 	var rows []searchTableRow
@@ -1695,7 +2814,7 @@ func printSearchTable(reader *content.ContentReader) error {
 		return err
 	}
 	reader.Reset()
-	return coreutils.PrintTable(rows, "Search Results", "No artifacts found.", false)
+	return coreutils.PrintTable(rows, formats.Localize("search.title", "Search Results"), formats.Localize("search.empty", "No artifacts found."), false)
 }
 
 func preparePropsCmd(c *components.Context) (*generic.PropsCommand, error) {
@@ -1711,7 +2830,7 @@ func preparePropsCmd(c *components.Context) (*generic.PropsCommand, error) {
 	var props string
 	if c.IsFlagSet("spec") {
 		props = c.GetArgumentAt(0)
-		propsSpec, err = commonCliUtils.GetSpec(c, false, true)
+		propsSpec, err = getSpec(c, false, true)
 	} else {
 		propsSpec, err = createDefaultPropertiesSpec(c)
 		if c.GetNumberOfArgs() == 1 {
@@ -1739,7 +2858,12 @@ func preparePropsCmd(c *components.Context) (*generic.PropsCommand, error) {
 		return nil, err
 	}
 
-	cmd := command.SetProps(props)
+	batchSize, err := getPropsBatchSize(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.SetProps(props).SetBatchSize(batchSize).SetRecursiveFolders(c.GetBoolFlagValue("recursive-folders"))
 	cmd.SetThreads(threads).SetSpec(propsSpec).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails)
 	return cmd, nil
 }
@@ -1757,19 +2881,23 @@ func setPropsCmd(c *components.Context) error {
 	if err != nil {
 		return err
 	}
+	rtDetails, err := cmd.ServerDetails()
+	if err != nil {
+		return err
+	}
 	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd).SetRepoOnly(c.GetBoolFlagValue("repo-only"))
 	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(propsCmd)
+	err = execWithTrafficOptions(c, rtDetails, func() error { return commands.Exec(propsCmd) })
 	result := propsCmd.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("set-props", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("set-props", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
 }
 
 func deletePropsCmd(c *components.Context) error {
@@ -1785,19 +2913,23 @@ func deletePropsCmd(c *components.Context) error {
 	if err != nil {
 		return err
 	}
+	rtDetails, err := cmd.ServerDetails()
+	if err != nil {
+		return err
+	}
 	propsCmd := generic.NewDeletePropsCommand().DeletePropsCommand(*cmd).SetRepoOnly(c.GetBoolFlagValue("repo-only"))
 	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(propsCmd)
+	err = execWithTrafficOptions(c, rtDetails, func() error { return commands.Exec(propsCmd) })
 	result := propsCmd.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("delete-props", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("delete-props", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
 }
 
 func buildPublishCmd(c *components.Context) error {
@@ -1814,7 +2946,7 @@ func buildPublishCmd(c *components.Context) error {
 		return err
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -1825,6 +2957,8 @@ func buildPublishCmd(c *components.Context) error {
 	cmd.SetDotGitPath(c.GetStringFlagValue("dot-git-path"))
 	cmd.SetConfigFilePath(c.GetStringFlagValue("git-config-file-path"))
 	cmd.SetDepExcludeScopes(c.GetStringsArrFlagValue("dep-exclude-scopes"))
+	cmd.SetDependencySources(c.GetBoolFlagValue("dependency-sources"))
+	cmd.SetDependencySourcesReport(c.GetStringFlagValue("dependency-sources-report"))
 
 	// When --format is set, suppress the internal logJsonOutput call so that the
 	// CLI layer can render the URL itself, and collect sha256.
@@ -1853,14 +2987,14 @@ func buildPublishCmd(c *components.Context) error {
 	if s := cmd.GetSummary(); s != nil {
 		sha256 = s.GetSha256()
 	}
-	return printBuildPublishResponse(cmd.GetBuildInfoUiUrl(), sha256, outputFormat, os.Stdout)
+	return printBuildPublishResponse(cmd.GetBuildInfoUiUrl(), sha256, outputFormat, w)
 }
 
 // printBuildPublishResponse renders the build-publish result in the requested output format.
 func printBuildPublishResponse(buildInfoUiUrl, sha256 string, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	switch outputFormat {
-	case coreformat.Json:
-		return printBuildPublishJSON(buildInfoUiUrl, sha256)
+	case coreformat.Json, formats.Porcelain:
+		return printBuildPublishJSON(buildInfoUiUrl, sha256, outputFormat, w)
 	case coreformat.Table:
 		return printBuildPublishTable(buildInfoUiUrl, sha256, w)
 	default:
@@ -1885,21 +3019,20 @@ func (o *buildPublishFormatOutput) json() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// printBuildPublishJSON emits the build-publish result as indented JSON.
-func printBuildPublishJSON(buildInfoUiUrl, sha256 string) error {
+// printBuildPublishJSON writes the build-publish result to w as indented JSON, or as porcelain lines.
+func printBuildPublishJSON(buildInfoUiUrl, sha256 string, outputFormat coreformat.OutputFormat, w io.Writer) error {
 	output := &buildPublishFormatOutput{BuildInfoUiUrl: buildInfoUiUrl, Sha256: sha256}
 	data, err := output.json()
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	log.Output(clientutils.IndentJson(data))
-	return nil
+	return formats.NewResultWriter(w, outputFormat).WriteJSON(data)
 }
 
 // printBuildPublishTable renders the build-publish result as a two-column tabwriter table.
 func printBuildPublishTable(buildInfoUiUrl, sha256 string, w io.Writer) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "FIELD\tVALUE")
+	_, _ = fmt.Fprintln(tw, fieldValueHeader())
 	_, _ = fmt.Fprintf(tw, "buildInfoUiUrl\t%s\n", buildInfoUiUrl)
 	if sha256 != "" {
 		_, _ = fmt.Fprintf(tw, "sha256\t%s\n", sha256)
@@ -1945,7 +3078,7 @@ func buildAddDependenciesCmd(c *components.Context) error {
 	var rtDetails *config.ServerDetails
 	var err error
 	if c.IsFlagSet("spec") {
-		dependenciesSpec, err = commonCliUtils.GetSpec(c, true, true)
+		dependenciesSpec, err = getSpec(c, true, true)
 		if err != nil {
 			return err
 		}
@@ -1961,17 +3094,17 @@ func buildAddDependenciesCmd(c *components.Context) error {
 		common.FixWinPathsForFileSystemSourcedCmds(dependenciesSpec, c)
 	}
 	buildAddDependenciesCmd := buildinfo.NewBuildAddDependenciesCommand().SetDryRun(c.GetBoolFlagValue("dry-run")).SetBuildConfiguration(buildConfiguration).SetDependenciesSpec(dependenciesSpec).SetServerDetails(rtDetails)
-	err = commands.Exec(buildAddDependenciesCmd)
+	err = execRateLimited(buildAddDependenciesCmd)
 	result := buildAddDependenciesCmd.Result()
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 	}
-	return printCountBasedResponse("build-add-dependencies", result.SuccessCount(), result.FailCount(), outputFormat, os.Stdout, common.IsFailNoOp(c), err)
+	return printCountBasedResponse("build-add-dependencies", result.SuccessCount(), result.FailCount(), outputFormat, w, common.IsFailNoOp(c), err)
 }
 
 // printBuildAddDependenciesResponse renders the build-add-dependencies result in the requested output format.
@@ -2019,7 +3152,7 @@ func buildScanLegacyCmd(c *components.Context) error {
 		return err
 	}
 	buildScanCmd := buildinfo.NewBuildScanLegacyCommand().SetServerDetails(rtDetails).SetFailBuild(c.GetBoolTFlagValue("fail")).SetBuildConfiguration(buildConfiguration)
-	err = commands.Exec(buildScanCmd)
+	err = execRateLimited(buildScanCmd)
 
 	return checkBuildScanError(err)
 }
@@ -2053,7 +3186,7 @@ func buildPromoteCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -2068,7 +3201,7 @@ func buildPromoteCmd(c *components.Context) error {
 		return err
 	}
 	buildPromotionCmd := buildinfo.NewBuildPromotionCommand().SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetPromotionParams(configuration).SetBuildConfiguration(buildConfiguration)
-	if err = commands.Exec(buildPromotionCmd); err != nil {
+	if err = execRateLimited(buildPromotionCmd); err != nil {
 		return err
 	}
 
@@ -2076,7 +3209,7 @@ func buildPromoteCmd(c *components.Context) error {
 	// The client layer discards the body, so we pass nil and let the helper
 	// synthesize {"status_code": 200, "message": "OK"}.
 	if outputFormat != coreformat.None {
-		return printStatusJSON(200, "OK")
+		return printStatusJSON(w, outputFormat, 200, "OK")
 	}
 	return nil
 }
@@ -2086,7 +3219,7 @@ func buildDiscardCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -2102,7 +3235,7 @@ func buildDiscardCmd(c *components.Context) error {
 	}
 	buildDiscardCommand.SetServerDetails(rtDetails).SetDiscardBuildsParams(configuration)
 
-	if err = commands.Exec(buildDiscardCommand); err != nil {
+	if err = execRateLimited(buildDiscardCommand); err != nil {
 		return err
 	}
 
@@ -2110,7 +3243,7 @@ func buildDiscardCmd(c *components.Context) error {
 	// The client layer discards the body, so we pass nil and let the helper
 	// synthesize {"status_code": 204, "message": "No Content"}.
 	if outputFormat != coreformat.None {
-		return printStatusJSON(204, "No Content")
+		return printStatusJSON(w, outputFormat, 204, "No Content")
 	}
 	return nil
 }
@@ -2135,25 +3268,25 @@ func gitLfsCleanCmd(c *components.Context) error {
 	}
 	gitLfsCmd.SetConfiguration(configuration).SetServerDetails(rtDetails).SetDryRun(c.GetBoolFlagValue("dry-run")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
-	err = commands.Exec(gitLfsCmd)
+	err = execRateLimited(gitLfsCmd)
 	succeeded, total := gitLfsCmd.Result()
 	failed := total - succeeded
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
 	if outputFormat == coreformat.None {
 		return err
 	}
-	return printGitLfsCleanResponse(succeeded, failed, outputFormat, os.Stdout, err)
+	return printGitLfsCleanResponse(succeeded, failed, outputFormat, w, err)
 }
 
 // printGitLfsCleanResponse renders the git-lfs-clean result in the requested output format.
 func printGitLfsCleanResponse(succeeded, failed int, outputFormat coreformat.OutputFormat, w io.Writer, originalErr error) error {
 	switch outputFormat {
-	case coreformat.Json:
-		if err := printSummaryJSON(succeeded, failed, false, originalErr); err != nil {
+	case coreformat.Json, formats.Porcelain:
+		if err := printSummaryJSON(w, outputFormat, succeeded, failed, false, originalErr); err != nil {
 			return err
 		}
 		return originalErr
@@ -2225,7 +3358,7 @@ func repoCreateCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -2238,7 +3371,7 @@ func repoCreateCmd(c *components.Context) error {
 	// Run command.
 	repoCreateCommand := repository.NewRepoCreateCommand()
 	repoCreateCommand.SetTemplatePath(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetVars(c.GetStringFlagValue("vars"))
-	if err = commands.Exec(repoCreateCommand); err != nil {
+	if err = execRateLimited(repoCreateCommand); err != nil {
 		return err
 	}
 
@@ -2246,7 +3379,7 @@ func repoCreateCmd(c *components.Context) error {
 	// The client layer discards the body, so we pass nil and let the helper
 	// synthesize {"status_code": 200, "message": "OK"}.
 	if outputFormat != coreformat.None {
-		return printStatusJSON(200, "OK")
+		return printStatusJSON(w, outputFormat, 200, "OK")
 	}
 	return nil
 }
@@ -2256,7 +3389,7 @@ func repoUpdateCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -2269,7 +3402,7 @@ func repoUpdateCmd(c *components.Context) error {
 	// Run command.
 	repoUpdateCommand := repository.NewRepoUpdateCommand()
 	repoUpdateCommand.SetTemplatePath(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetVars(c.GetStringFlagValue("vars"))
-	if err = commands.Exec(repoUpdateCommand); err != nil {
+	if err = execRateLimited(repoUpdateCommand); err != nil {
 		return err
 	}
 
@@ -2277,7 +3410,7 @@ func repoUpdateCmd(c *components.Context) error {
 	// The client layer discards the body, so we pass nil and let the helper
 	// synthesize {"status_code": 200, "message": "OK"}.
 	if outputFormat != coreformat.None {
-		return printStatusJSON(200, "OK")
+		return printStatusJSON(w, outputFormat, 200, "OK")
 	}
 	return nil
 }
@@ -2294,7 +3427,40 @@ func repoDeleteCmd(c *components.Context) error {
 
 	repoDeleteCmd := repository.NewRepoDeleteCommand()
 	repoDeleteCmd.SetRepoPattern(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetQuiet(common.GetQuietValue(c))
-	return commands.Exec(repoDeleteCmd)
+	return execRateLimited(repoDeleteCmd)
+}
+
+func repoCreateSetCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	repoCreateSetCmd := repository.NewRepoCreateSetCommand()
+	repoCreateSetCmd.SetPackageType(c.GetArgumentAt(0)).SetName(c.GetArgumentAt(1)).SetRemoteUrl(c.GetStringFlagValue("remote-url")).
+		SetRemotePreset(c.GetStringFlagValue("preset")).SetIncludeRepos(c.GetStringsArrFlagValue("include-repos")).
+		SetProjectKey(common.GetProject(c)).SetServerDetails(rtDetails)
+	return execRateLimited(repoCreateSetCmd)
+}
+
+func repoCreateRemoteCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	repoCreateRemoteCmd := repository.NewRepoCreateRemoteCommand()
+	repoCreateRemoteCmd.SetPresetName(c.GetArgumentAt(0)).SetRepoKey(c.GetArgumentAt(1)).SetPackageType(c.GetStringFlagValue("package-type")).
+		SetProjectKey(common.GetProject(c)).SetServerDetails(rtDetails)
+	return execRateLimited(repoCreateRemoteCmd)
 }
 
 func replicationTemplateCmd(c *components.Context) error {
@@ -2311,7 +3477,7 @@ func replicationCreateCmd(c *components.Context) error {
 		return common.WrongNumberOfArgumentsHandler(c)
 	}
 
-	outputFormat, fmtErr := c.GetOutputFormat()
+	outputFormat, w, fmtErr := getOutput(c)
 	if fmtErr != nil {
 		return fmtErr
 	}
@@ -2322,7 +3488,7 @@ func replicationCreateCmd(c *components.Context) error {
 	}
 	replicationCreateCommand := replication.NewReplicationCreateCommand()
 	replicationCreateCommand.SetTemplatePath(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetVars(c.GetStringFlagValue("vars"))
-	if err = commands.Exec(replicationCreateCommand); err != nil {
+	if err = execRateLimited(replicationCreateCommand); err != nil {
 		return err
 	}
 
@@ -2330,7 +3496,7 @@ func replicationCreateCmd(c *components.Context) error {
 	// The client layer discards the body, so we pass nil and let the helper
 	// synthesize {"status_code": 200, "message": "OK"}.
 	if outputFormat != coreformat.None {
-		return printStatusJSON(200, "OK")
+		return printStatusJSON(w, outputFormat, 200, "OK")
 	}
 	return nil
 }
@@ -2345,7 +3511,7 @@ func replicationDeleteCmd(c *components.Context) error {
 	}
 	replicationDeleteCmd := replication.NewReplicationDeleteCommand()
 	replicationDeleteCmd.SetRepoKey(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetQuiet(common.GetQuietValue(c))
-	return commands.Exec(replicationDeleteCmd)
+	return execRateLimited(replicationDeleteCmd)
 }
 
 func createDefaultCopyMoveSpec(c *components.Context) (*spec.SpecFiles, error) {
@@ -2353,7 +3519,7 @@ func createDefaultCopyMoveSpec(c *components.Context) (*spec.SpecFiles, error) {
 	if err != nil {
 		return nil, err
 	}
-	return spec.NewBuilder().
+	return workspace.ExpandSpec(spec.NewBuilder().
 		Pattern(c.GetArgumentAt(0)).
 		Props(c.GetStringFlagValue("props")).
 		ExcludeProps(c.GetStringFlagValue("exclude-props")).
@@ -2372,7 +3538,7 @@ func createDefaultCopyMoveSpec(c *components.Context) (*spec.SpecFiles, error) {
 		IncludeDirs(true).
 		Target(c.GetArgumentAt(1)).
 		ArchiveEntries(c.GetStringFlagValue("archive-entries")).
-		BuildSpec(), nil
+		BuildSpec())
 }
 
 func createDefaultDeleteSpec(c *components.Context) (*spec.SpecFiles, error) {
@@ -2380,7 +3546,7 @@ func createDefaultDeleteSpec(c *components.Context) (*spec.SpecFiles, error) {
 	if err != nil {
 		return nil, err
 	}
-	return spec.NewBuilder().
+	return workspace.ExpandSpec(spec.NewBuilder().
 		Pattern(c.GetArgumentAt(0)).
 		Props(c.GetStringFlagValue("props")).
 		ExcludeProps(c.GetStringFlagValue("exclude-props")).
@@ -2396,7 +3562,7 @@ func createDefaultDeleteSpec(c *components.Context) (*spec.SpecFiles, error) {
 		Recursive(c.GetBoolTFlagValue("recursive")).
 		Exclusions(c.GetStringsArrFlagValue("exclusions")).
 		ArchiveEntries(c.GetStringFlagValue("archive-entries")).
-		BuildSpec(), nil
+		BuildSpec())
 }
 
 func createDefaultSearchSpec(c *components.Context) (*spec.SpecFiles, error) {
@@ -2404,7 +3570,7 @@ func createDefaultSearchSpec(c *components.Context) (*spec.SpecFiles, error) {
 	if err != nil {
 		return nil, err
 	}
-	return spec.NewBuilder().
+	return workspace.ExpandSpec(spec.NewBuilder().
 		Pattern(c.GetArgumentAt(0)).
 		Props(c.GetStringFlagValue("props")).
 		ExcludeProps(c.GetStringFlagValue("exclude-props")).
@@ -2423,7 +3589,7 @@ func createDefaultSearchSpec(c *components.Context) (*spec.SpecFiles, error) {
 		ArchiveEntries(c.GetStringFlagValue("archive-entries")).
 		Transitive(c.GetBoolFlagValue("transitive")).
 		Include(c.GetStringsArrFlagValue("include")).
-		BuildSpec(), nil
+		BuildSpec())
 }
 
 func createDefaultPropertiesSpec(c *components.Context) (*spec.SpecFiles, error) {
@@ -2431,7 +3597,7 @@ func createDefaultPropertiesSpec(c *components.Context) (*spec.SpecFiles, error)
 	if err != nil {
 		return nil, err
 	}
-	return spec.NewBuilder().
+	return workspace.ExpandSpec(spec.NewBuilder().
 		Pattern(c.GetArgumentAt(0)).
 		Props(c.GetStringFlagValue("props")).
 		ExcludeProps(c.GetStringFlagValue("exclude-props")).
@@ -2449,7 +3615,7 @@ func createDefaultPropertiesSpec(c *components.Context) (*spec.SpecFiles, error)
 		IncludeDirs(c.GetBoolFlagValue("include-dirs")).
 		ArchiveEntries(c.GetStringFlagValue("archive-entries")).
 		RepoOnly(c.GetBoolTFlagValue("repo-only")).
-		BuildSpec(), nil
+		BuildSpec())
 }
 
 func createBuildInfoConfiguration(c *components.Context) *buildinfocmd.Configuration {
@@ -2463,7 +3629,7 @@ func createBuildInfoConfiguration(c *components.Context) *buildinfocmd.Configura
 	}
 	// Allow using `env-exclude=""` and get no filters
 	if flags.EnvExclude == "" {
-		flags.EnvExclude = "*password*;*psw*;*secret*;*key*;*token*;*auth*"
+		flags.EnvExclude = buildinfo.DefaultEnvExclude
 	}
 	flags.Overwrite = c.GetBoolFlagValue("overwrite")
 	return flags
@@ -2550,7 +3716,7 @@ func createDefaultDownloadSpec(c *components.Context) (*spec.SpecFiles, error) {
 		excludeArtifacts = false
 	}
 
-	return spec.NewBuilder().
+	return workspace.ExpandSpec(spec.NewBuilder().
 		Pattern(getSourcePattern(c)).
 		Props(c.GetStringFlagValue("props")).
 		ExcludeProps(c.GetStringFlagValue("exclude-props")).
@@ -2573,7 +3739,7 @@ func createDefaultDownloadSpec(c *components.Context) (*spec.SpecFiles, error) {
 		Target(c.GetArgumentAt(1)).
 		ArchiveEntries(c.GetStringFlagValue("archive-entries")).
 		ValidateSymlinks(c.GetBoolFlagValue("validate-symlinks")).
-		BuildSpec(), nil
+		BuildSpec())
 }
 
 func getSourcePattern(c *components.Context) string {
@@ -2601,7 +3767,7 @@ func getSourcePattern(c *components.Context) string {
 
 func buildSourceForRbv2(c *components.Context) string {
 	bundleNameAndVersion := c.GetStringFlagValue("bundle")
-	projectKey := c.GetStringFlagValue("project")
+	projectKey := common.GetProject(c)
 	source := projectKey
 
 	// Reset bundle flag
@@ -2654,6 +3820,43 @@ func createDefaultUploadSpec(c *components.Context) (*spec.SpecFiles, error) {
 		BuildSpec(), nil
 }
 
+// getValidationReportOptions returns the options of the transfer validation report, or nil if no report was requested.
+func getValidationReportOptions(c *components.Context) (*generic.ValidationReportOptions, error) {
+	options := &generic.ValidationReportOptions{
+		Path:       c.GetStringFlagValue("validation-report"),
+		SigningKey: c.GetStringFlagValue("validation-report-key"),
+		Target:     c.GetStringFlagValue("validation-report-target"),
+	}
+	if options.Path == "" {
+		if options.SigningKey != "" || options.Target != "" {
+			return nil, common.PrintHelpAndReturnError("The --validation-report-key and --validation-report-target options require the --validation-report option.", c)
+		}
+		return nil, nil
+	}
+	return options, nil
+}
+
+// createRoutingRulesUploadSpec creates an upload spec from the routing rules file, applying the command options to all rules.
+func createRoutingRulesUploadSpec(c *components.Context) (*spec.SpecFiles, error) {
+	routingRules, err := generic.ReadUploadRoutingRules(c.GetStringFlagValue("routing-rules"))
+	if err != nil {
+		return nil, err
+	}
+	template := spec.NewBuilder().
+		TargetProps(c.GetStringFlagValue("target-props")).
+		Recursive(c.GetBoolTFlagValue("recursive")).
+		Exclusions(c.GetStringsArrFlagValue("exclusions")).
+		Flat(c.GetBoolFlagValue("flat")).
+		Explode(strconv.FormatBool(c.GetBoolFlagValue("explode"))).
+		Regexp(c.GetBoolFlagValue("regexp")).
+		Ant(c.GetBoolFlagValue("ant")).
+		IncludeDirs(c.GetBoolFlagValue("include-dirs")).
+		Symlinks(c.GetBoolFlagValue("symlinks")).
+		Archive(c.GetStringFlagValue("archive")).
+		BuildSpec()
+	return routingRules.ToSpec(*template.Get(0))
+}
+
 func createDefaultBuildAddDependenciesSpec(c *components.Context) *spec.SpecFiles {
 	pattern := c.GetArgumentAt(2)
 	if pattern == "" {
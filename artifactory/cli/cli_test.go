@@ -7,9 +7,12 @@ import (
 	"strings"
 	"testing"
 
+	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
 	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/stretchr/testify/assert"
@@ -62,8 +65,10 @@ func TestPrintSearchResponse_JSON(t *testing.T) {
 		}
 	}()
 
-	err := printSearchResponse(reader, coreformat.Json)
+	var output bytes.Buffer
+	err := printSearchResponse(reader, coreformat.Json, &output)
 	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"path":"repo/path/file.txt","type":"file","size":1234,"sha256":"abc123"}]`, output.String())
 }
 
 func TestPrintSearchResponse_Table(t *testing.T) {
@@ -77,7 +82,7 @@ func TestPrintSearchResponse_Table(t *testing.T) {
 		}
 	}()
 
-	err := printSearchResponse(reader, coreformat.Table)
+	err := printSearchResponse(reader, coreformat.Table, &bytes.Buffer{})
 	assert.NoError(t, err)
 }
 
@@ -89,7 +94,7 @@ func TestPrintSearchResponse_UnsupportedFormat(t *testing.T) {
 		}
 	}()
 
-	err := printSearchResponse(reader, coreformat.Sarif)
+	err := printSearchResponse(reader, coreformat.Sarif, &bytes.Buffer{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported format")
 	assert.Contains(t, err.Error(), "rt search")
@@ -496,9 +501,9 @@ func TestPrintCountBasedResponse_UnsupportedFormat(t *testing.T) {
 
 func TestPrintBuildPublishResponse_JSON(t *testing.T) {
 	var buf bytes.Buffer
-	// printBuildPublishJSON writes to log.Output (stdout); we verify no error.
 	err := printBuildPublishResponse("https://example.jfrog.io/ui/builds/myapp/1/123/published", "", coreformat.Json, &buf)
 	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"buildInfoUiUrl": "https://example.jfrog.io/ui/builds/myapp/1/123/published"`)
 }
 
 func TestPrintBuildPublishResponse_JSON_WithSha256(t *testing.T) {
@@ -587,14 +592,17 @@ func TestPrintBuildPublishTable_WithSha256(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestPrintBuildPublishJSON_ValidURL(t *testing.T) {
-	// Output goes to log.Output; we just verify no error is returned.
-	err := printBuildPublishJSON("https://example.jfrog.io/ui/builds/myapp/1/123/published", "")
+	var buf bytes.Buffer
+	err := printBuildPublishJSON("https://example.jfrog.io/ui/builds/myapp/1/123/published", "", coreformat.Json, &buf)
 	require.NoError(t, err)
+	assert.JSONEq(t, `{"buildInfoUiUrl":"https://example.jfrog.io/ui/builds/myapp/1/123/published"}`, buf.String())
 }
 
 func TestPrintBuildPublishJSON_WithSha256(t *testing.T) {
-	err := printBuildPublishJSON("https://example.jfrog.io/ui/builds/myapp/1/123/published", "abc123")
+	var buf bytes.Buffer
+	err := printBuildPublishJSON("https://example.jfrog.io/ui/builds/myapp/1/123/published", "abc123", formats.Porcelain, &buf)
 	require.NoError(t, err)
+	assert.Equal(t, "buildInfoUiUrl\thttps://example.jfrog.io/ui/builds/myapp/1/123/published\nsha256\tabc123\n", buf.String())
 }
 
 // ---------------------------------------------------------------------------
@@ -966,9 +974,9 @@ func TestPrintGitLfsCleanResponse_UnsupportedFormat(t *testing.T) {
 
 func TestPrintContainerPullResponse_JSON(t *testing.T) {
 	var buf bytes.Buffer
-	// printContainerPullJSON writes to log.Output (stdout); we only check no error is returned.
 	err := printContainerPullResponse("myrepo.example.com/myimage:latest", "docker-local", coreformat.Json, &buf)
 	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"docker-local"`)
 }
 
 func TestPrintContainerPullResponse_Table(t *testing.T) {
@@ -1031,14 +1039,15 @@ func TestPrintContainerPullTable_EmptyValues(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestPrintContainerPullJSON_Success(t *testing.T) {
-	// Output goes to log.Output (stdout); we just verify no error is returned.
-	err := printContainerPullJSON("registry.example.com/myimage:latest", "docker-local")
+	var buf bytes.Buffer
+	err := printContainerPullJSON("registry.example.com/myimage:latest", "docker-local", coreformat.Json, &buf)
 	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"registry.example.com/myimage:latest"`)
 }
 
 func TestPrintContainerPullJSON_EmptyValues(t *testing.T) {
 	// Even with empty image/repo the function should not error.
-	err := printContainerPullJSON("", "")
+	err := printContainerPullJSON("", "", coreformat.Json, &bytes.Buffer{})
 	require.NoError(t, err)
 }
 
@@ -1270,25 +1279,62 @@ func TestRepoUpdateFormat_XMLRejected(t *testing.T) {
 }
 
 func TestPrintSummaryJSONSuccessStatus(t *testing.T) {
-	err := printSummaryJSON(3, 0, false, nil)
+	var buf bytes.Buffer
+	err := printSummaryJSON(&buf, coreformat.Json, 3, 0, false, nil)
 	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"success","totals":{"success":3,"failure":0}}`, buf.String())
 }
 
 func TestPrintSummaryJSONFailureStatus(t *testing.T) {
-	err := printSummaryJSON(2, 1, false, nil)
+	var buf bytes.Buffer
+	err := printSummaryJSON(&buf, formats.Porcelain, 2, 1, false, nil)
 	require.NoError(t, err)
+	assert.Equal(t, "status\tfailure\ntotals.failure\t1\ntotals.success\t2\n", buf.String())
 }
 
 func TestPrintStatusJSONOK(t *testing.T) {
 	require.NotPanics(t, func() {
-		err := printStatusJSON(200, "OK")
+		var buf bytes.Buffer
+		err := printStatusJSON(&buf, coreformat.Json, 200, "OK")
 		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status_code":200,"message":"OK"}`, buf.String())
 	})
 }
 
 func TestPrintStatusJSONNoContent(t *testing.T) {
 	require.NotPanics(t, func() {
-		err := printStatusJSON(204, "No Content")
+		var buf bytes.Buffer
+		err := printStatusJSON(&buf, formats.Porcelain, 204, "No Content")
 		assert.NoError(t, err)
+		assert.Equal(t, "message\tNo Content\nstatus_code\t204\n", buf.String())
 	})
 }
+
+func TestCreateDirectDownloadSpecProject(t *testing.T) {
+	ctx := newTestContext(map[string]string{"project": "proj1", "build": "my-build/1"})
+	ctx.Arguments = []string{"repo/path/*", "target/"}
+	downloadSpec := createDirectDownloadSpec(ctx)
+	require.Len(t, downloadSpec.Files, 1)
+	assert.Equal(t, "proj1", downloadSpec.Files[0].Project)
+	assert.Equal(t, "my-build/1", downloadSpec.Files[0].Build)
+}
+
+func TestCreateContainerPushCommandProject(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	flags := map[string]string{"url": "https://acme.jfrog.io/artifactory", "access-token": "token", "build-name": "app", "build-number": "1"}
+	ctx := newTestContext(flags)
+	ctx.Arguments = []string{"acme.jfrog.io/docker-local/app:1.0", "docker-local"}
+
+	// The project falls back to JFROG_CLI_BUILD_PROJECT.
+	t.Setenv(coreutils.Project, "env-proj")
+	pushCommand, err := createContainerPushCommand(ctx, containerutils.DockerClient, false)
+	require.NoError(t, err)
+	assert.Equal(t, "env-proj", pushCommand.BuildConfiguration().GetProject())
+
+	flags["project"] = "proj1"
+	ctx = newTestContext(flags)
+	ctx.Arguments = []string{"acme.jfrog.io/docker-local/app:1.0", "docker-local"}
+	pushCommand, err = createContainerPushCommand(ctx, containerutils.DockerClient, false)
+	require.NoError(t, err)
+	assert.Equal(t, "proj1", pushCommand.BuildConfiguration().GetProject())
+}
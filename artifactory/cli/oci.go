@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ocipull"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ocipush"
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+)
+
+// GetOciCommands returns the commands of the oci namespace.
+func GetOciCommands() []components.Command {
+	return []components.Command{
+		{
+			Name:        "push",
+			Flags:       flagkit.GetCommandFlags(flagkit.OciPush),
+			Description: ocipush.GetDescription(),
+			Arguments:   ocipush.GetArguments(),
+			Action:      ociPushCmd,
+		},
+		{
+			Name:        "pull",
+			Flags:       flagkit.GetCommandFlags(flagkit.OciPull),
+			Description: ocipull.GetDescription(),
+			Arguments:   ocipull.GetArguments(),
+			Action:      ociPullCmd,
+		},
+	}
+}
+
+func ociPushCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	annotations, err := ociartifact.ParseAnnotations(c.GetStringFlagValue(flagkit.OciAnnotations))
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	pushCommand := ociartifact.NewOciPushCommand().SetReference(c.GetArgumentAt(0)).SetFiles(c.Arguments[1:]).
+		SetArtifactType(c.GetStringFlagValue(flagkit.OciArtifactType)).SetAnnotations(annotations).
+		SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	return commands.Exec(pushCommand)
+}
+
+func ociPullCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 1 || c.GetNumberOfArgs() > 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	pullCommand := ociartifact.NewOciPullCommand().SetReference(c.GetArgumentAt(0)).
+		SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	if c.GetNumberOfArgs() == 2 {
+		pullCommand.SetTarget(c.GetArgumentAt(1))
+	}
+	return commands.Exec(pullCommand)
+}
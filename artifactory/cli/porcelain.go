@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils/summary"
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// addPorcelainFormat adds the porcelain and quiet formats to the commands which support the JSON format. Their porcelain output
+// is rendered from their JSON output by their result printers, and their quiet output is discarded.
+func addPorcelainFormat(commands []components.Command) []components.Command {
+	for i := range commands {
+		if slices.Contains(commands[i].SupportedFormats, coreformat.Json) {
+			commands[i].SupportedFormats = append(slices.Clone(commands[i].SupportedFormats), formats.Porcelain, formats.Quiet)
+		}
+	}
+	return commands
+}
+
+// getOutput returns the output format of the results of the command, and the writer the result printers write them to.
+// The results of the quiet format are written as JSON, and discarded.
+func getOutput(c *components.Context) (coreformat.OutputFormat, io.Writer, error) {
+	outputFormat, err := c.GetOutputFormat()
+	if err != nil {
+		return "", nil, err
+	}
+	if outputFormat == formats.Quiet {
+		return coreformat.Json, io.Discard, nil
+	}
+	return outputFormat, os.Stdout, nil
+}
+
+// isJsonOutput returns true if the results are written as JSON, which the porcelain lines are rendered from.
+func isJsonOutput(outputFormat coreformat.OutputFormat) bool {
+	return outputFormat == coreformat.Json || outputFormat == formats.Porcelain
+}
+
+// WithOutputMode applies the output mode of the formats.OutputModeEnv environment variable to the commands. The commands which
+// support the mode as their format run with it, unless their --format option is set to another format than their default one.
+// With the porcelain mode, the other commands write their status once they're done, since they have no results which the
+// porcelain lines can be rendered from.
+func WithOutputMode(commands []components.Command) []components.Command {
+	for i := range commands {
+		action := commands[i].Action
+		if action == nil {
+			continue
+		}
+		supportsModes := slices.Contains(commands[i].SupportedFormats, formats.Porcelain)
+		defaultFormat := commands[i].DefaultFormat
+		commands[i].Action = func(c *components.Context) error {
+			outputMode := coreformat.OutputFormat(os.Getenv(formats.OutputModeEnv))
+			switch outputMode {
+			case "":
+				return action(c)
+			case formats.Porcelain, formats.Quiet:
+			default:
+				return errorutils.CheckErrorf("invalid value '%s' of %s. Acceptable values are: %s, %s", outputMode, formats.OutputModeEnv, formats.Porcelain, formats.Quiet)
+			}
+			if supportsModes {
+				// The option has the value of the default format when it isn't set, so the default format doesn't override the mode.
+				if outputFormat := coreformat.OutputFormat(c.GetStringFlagValue(coreformat.FlagName)); outputFormat == "" || outputFormat == defaultFormat {
+					c.SetStringFlagValue(coreformat.FlagName, string(outputMode))
+				}
+				return action(c)
+			}
+			err := action(c)
+			if outputMode == formats.Porcelain {
+				err = errors.Join(err, writePorcelainStatus(os.Stdout, err))
+			}
+			return err
+		}
+	}
+	return commands
+}
+
+// writePorcelainStatus writes the status of a command which has no results, as its porcelain output.
+func writePorcelainStatus(w io.Writer, commandErr error) error {
+	status := "success"
+	if commandErr != nil {
+		status = "failure"
+	}
+	return formats.NewResultWriter(w, formats.Porcelain).WriteJSON(fmt.Appendf(nil, `{"status":%q}`, status))
+}
+
+// printDetailedSummaryJSON writes the summary report of the result, with the details of its transferred files, to w as indented
+// JSON, or as porcelain lines. The files are read one by one from the reader of the result. The returned error is the error of
+// the command, as GetCliError returns it.
+func printDetailedSummaryJSON(w io.Writer, outputFormat coreformat.OutputFormat, result *commandUtils.Result, uploaded, failNoOp bool, originalErr error) error {
+	summaryReport := summary.GetSummaryReport(result.SuccessCount(), result.FailCount(), failNoOp, originalErr)
+	data, err := summaryReport.Marshal()
+	if err != nil {
+		return errors.Join(originalErr, errorutils.CheckError(err))
+	}
+	resultWriter := formats.NewResultWriter(w, outputFormat)
+	reader := result.Reader()
+	if reader == nil {
+		err = resultWriter.WriteJSON(data)
+	} else {
+		err = resultWriter.WriteJSONWithArray(data, "files", func() (any, bool) {
+			details := new(clientutils.FileTransferDetails)
+			if reader.NextRecord(details) != nil {
+				return nil, false
+			}
+			return detailedSummaryRecord(details, uploaded), true
+		})
+		reader.Reset()
+		err = errors.Join(err, reader.GetError())
+	}
+	if err != nil {
+		return errors.Join(originalErr, err)
+	}
+	return common.GetCliError(originalErr, result.SuccessCount(), result.FailCount(), failNoOp)
+}
+
+// detailedSummaryRecord returns the record of a transferred file in the detailed summary, as the summary of the core commands has it.
+func detailedSummaryRecord(details *clientutils.FileTransferDetails, uploaded bool) any {
+	if uploaded {
+		return common.ExtendedDetailedSummaryRecord{
+			DetailedSummaryRecord: common.DetailedSummaryRecord{Source: details.SourcePath, Target: details.RtUrl + details.TargetPath},
+			Sha256:                details.Sha256,
+		}
+	}
+	return common.DetailedSummaryRecord{Source: details.RtUrl + details.SourcePath, Target: details.TargetPath}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPorcelainFormat(t *testing.T) {
+	commands := addPorcelainFormat([]components.Command{
+		{Name: "with-json", SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table}, Action: func(*components.Context) error { return nil }},
+		{Name: "table-only", SupportedFormats: []coreformat.OutputFormat{coreformat.Table}},
+		{Name: "no-formats"},
+	})
+	assert.Equal(t, []coreformat.OutputFormat{coreformat.Json, coreformat.Table, formats.Porcelain, formats.Quiet}, commands[0].SupportedFormats)
+	assert.Equal(t, []coreformat.OutputFormat{coreformat.Table}, commands[1].SupportedFormats)
+	assert.Empty(t, commands[2].SupportedFormats)
+}
+
+func TestGetOutput(t *testing.T) {
+	var outputFormat coreformat.OutputFormat
+	var w io.Writer
+	app, err := components.ConvertApp(components.App{Namespace: components.Namespace{Name: "test", Commands: addPorcelainFormat([]components.Command{
+		{Name: "search", SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table}, Action: func(c *components.Context) (err error) {
+			outputFormat, w, err = getOutput(c)
+			return err
+		}},
+	})}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		format         string
+		expectedFormat coreformat.OutputFormat
+		discarded      bool
+	}{
+		{format: "json", expectedFormat: coreformat.Json},
+		{format: "porcelain", expectedFormat: formats.Porcelain},
+		{format: "quiet", expectedFormat: coreformat.Json, discarded: true},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			require.NoError(t, app.Run([]string{"test", "search", "--format", test.format}))
+			assert.Equal(t, test.expectedFormat, outputFormat)
+			assert.Equal(t, test.discarded, w == io.Discard)
+		})
+	}
+}
+
+func TestPrintersWritePorcelain(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, printCountBasedResponse("rt move", 2, 0, formats.Porcelain, &output, false, nil))
+	assert.Equal(t, "status\tsuccess\ntotals.failure\t0\ntotals.success\t2\n", output.String())
+}
+
+func TestWithOutputMode(t *testing.T) {
+	var actionFormat coreformat.OutputFormat
+	app, err := components.ConvertApp(components.App{Namespace: components.Namespace{Name: "test", Commands: WithOutputMode(addPorcelainFormat([]components.Command{
+		{Name: "upload", SupportedFormats: []coreformat.OutputFormat{coreformat.Json, coreformat.Table}, DefaultFormat: coreformat.Table, Action: func(c *components.Context) (err error) {
+			actionFormat, err = c.GetOutputFormat()
+			return err
+		}},
+	}))}})
+	require.NoError(t, err)
+
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv(formats.OutputModeEnv, "")
+		require.NoError(t, app.Run([]string{"test", "upload"}))
+		assert.Equal(t, coreformat.Table, actionFormat)
+	})
+	t.Run("mode", func(t *testing.T) {
+		t.Setenv(formats.OutputModeEnv, "porcelain")
+		require.NoError(t, app.Run([]string{"test", "upload"}))
+		assert.Equal(t, formats.Porcelain, actionFormat)
+		t.Setenv(formats.OutputModeEnv, "quiet")
+		require.NoError(t, app.Run([]string{"test", "upload"}))
+		assert.Equal(t, formats.Quiet, actionFormat)
+	})
+	t.Run("format option", func(t *testing.T) {
+		// The format of the option takes precedence over the mode.
+		t.Setenv(formats.OutputModeEnv, "porcelain")
+		require.NoError(t, app.Run([]string{"test", "upload", "--format", "json"}))
+		assert.Equal(t, coreformat.Json, actionFormat)
+	})
+	t.Run("invalid mode", func(t *testing.T) {
+		t.Setenv(formats.OutputModeEnv, "xml")
+		assert.ErrorContains(t, app.Run([]string{"test", "upload"}), "invalid value 'xml' of "+formats.OutputModeEnv)
+	})
+}
+
+func TestWritePorcelainStatus(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, writePorcelainStatus(&output, nil))
+	assert.Equal(t, "status\tsuccess\n", output.String())
+
+	output.Reset()
+	require.NoError(t, writePorcelainStatus(&output, errors.New("failed")))
+	assert.Equal(t, "status\tfailure\n", output.String())
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/python"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/pypiupload"
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+)
+
+// GetPypiCommands returns the commands of the pypi namespace.
+func GetPypiCommands() []components.Command {
+	return []components.Command{
+		{
+			Name:        "upload",
+			Aliases:     []string{"u"},
+			Flags:       flagkit.GetCommandFlags(flagkit.PypiUpload),
+			Description: pypiupload.GetDescription(),
+			Arguments:   pypiupload.GetArguments(),
+			Action:      pypiUploadCmd,
+		},
+	}
+}
+
+func pypiUploadCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := common.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	uploadCommand := python.NewPypiUploadCommand().SetRepo(c.GetStringFlagValue(flagkit.PackageRepo)).SetPattern(c.GetArgumentAt(0)).
+		SetBuildConfiguration(buildConfiguration).SetServerDetails(artDetails)
+	return commands.Exec(uploadCommand)
+}
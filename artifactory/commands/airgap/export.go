@@ -0,0 +1,257 @@
+package airgap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/mvn"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	defaultThreads = 3
+	// The number of checksums searched by a single AQL query.
+	checksumsPerQuery = 100
+)
+
+// AirGapExportCommand downloads the dependencies of a build, or the dependencies listed by Gradle lockfiles and Maven
+// dependency lists, into a portable archive with a manifest, which AirGapImportCommand imports into an isolated instance.
+type AirGapExportCommand struct {
+	serverDetails     *config.ServerDetails
+	archivePath       string
+	buildName         string
+	buildNumber       string
+	project           string
+	repo              string
+	dependenciesFiles []string
+	threads           int
+	manifest          *AirGapManifest
+}
+
+func NewAirGapExportCommand() *AirGapExportCommand {
+	return &AirGapExportCommand{threads: defaultThreads}
+}
+
+func (aec *AirGapExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *AirGapExportCommand {
+	aec.serverDetails = serverDetails
+	return aec
+}
+
+func (aec *AirGapExportCommand) SetArchivePath(archivePath string) *AirGapExportCommand {
+	aec.archivePath = archivePath
+	return aec
+}
+
+// SetBuild exports the dependencies recorded by the build info. They are found in Artifactory by their checksums.
+func (aec *AirGapExportCommand) SetBuild(buildName, buildNumber string) *AirGapExportCommand {
+	aec.buildName, aec.buildNumber = buildName, buildNumber
+	return aec
+}
+
+func (aec *AirGapExportCommand) SetProject(project string) *AirGapExportCommand {
+	aec.project = project
+	return aec
+}
+
+// SetRepo sets the repository the dependencies of the dependencies files are downloaded through.
+func (aec *AirGapExportCommand) SetRepo(repo string) *AirGapExportCommand {
+	aec.repo = repo
+	return aec
+}
+
+// SetDependenciesFiles exports the dependencies listed by the Gradle lockfiles and the outputs of 'mvn dependency:list'.
+func (aec *AirGapExportCommand) SetDependenciesFiles(dependenciesFiles []string) *AirGapExportCommand {
+	aec.dependenciesFiles = dependenciesFiles
+	return aec
+}
+
+func (aec *AirGapExportCommand) SetThreads(threads int) *AirGapExportCommand {
+	aec.threads = threads
+	return aec
+}
+
+func (aec *AirGapExportCommand) Manifest() *AirGapManifest {
+	return aec.manifest
+}
+
+func (aec *AirGapExportCommand) CommandName() string {
+	return "rt_airgap_export"
+}
+
+func (aec *AirGapExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return aec.serverDetails, nil
+}
+
+func (aec *AirGapExportCommand) Run() (err error) {
+	if (aec.buildName == "") == (len(aec.dependenciesFiles) == 0) {
+		return errorutils.CheckErrorf("exactly one of a build or dependencies files must be provided")
+	}
+	if len(aec.dependenciesFiles) > 0 && aec.repo == "" {
+		return errorutils.CheckErrorf("the repository to download the dependencies through must be provided")
+	}
+	servicesManager, err := utils.CreateServiceManager(aec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	stagingDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(stagingDir); err == nil {
+			err = removeErr
+		}
+	}()
+	var source string
+	if aec.buildName != "" {
+		source = "build " + aec.buildName + "/" + aec.buildNumber
+		err = aec.stageBuildDependencies(servicesManager, stagingDir)
+	} else {
+		source = strings.Join(aec.dependenciesFiles, ", ")
+		err = aec.stageListedDependencies(servicesManager, stagingDir)
+	}
+	if err != nil {
+		return err
+	}
+	if aec.manifest, err = createManifest(stagingDir, source, time.Now()); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Writing %d files to %s...", len(aec.manifest.Files), aec.archivePath))
+	return writeArchive(stagingDir, aec.archivePath, aec.manifest)
+}
+
+func (aec *AirGapExportCommand) stageBuildDependencies(servicesManager artifactory.ArtifactoryServicesManager, stagingDir string) error {
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: aec.buildName, BuildNumber: aec.buildNumber, ProjectKey: aec.project})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s was not found", aec.buildName, aec.buildNumber)
+	}
+	checksums := getDependenciesChecksums(&publishedBuildInfo.BuildInfo)
+	log.Info(fmt.Sprintf("Searching for the %d dependencies of build %s/%s...", len(checksums), aec.buildName, aec.buildNumber))
+	var items []servicesUtils.ResultItem
+	for start := 0; start < len(checksums); start += checksumsPerQuery {
+		batchItems, err := artifactoryUtils.ExecuteAqlQuery(servicesManager, createChecksumsAqlQuery(checksums[start:min(start+checksumsPerQuery, len(checksums))]))
+		if err != nil {
+			return err
+		}
+		items = append(items, batchItems...)
+	}
+	files, missing := selectDependencyFiles(checksums, items)
+	if len(missing) > 0 {
+		return errorutils.CheckErrorf("%d dependencies of the build weren't found in Artifactory. Their sha1 checksums are: %s", len(missing), strings.Join(missing, ", "))
+	}
+	for _, file := range files {
+		filePath := path.Join(file.Path, file.Name)
+		if file.Path == "." {
+			filePath = file.Name
+		}
+		if err = downloadToStaging(servicesManager, stagingDir, file.Repo, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (aec *AirGapExportCommand) stageListedDependencies(servicesManager artifactory.ArtifactoryServicesManager, stagingDir string) error {
+	coordinates, err := mvn.ReadDependenciesFiles(aec.dependenciesFiles)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Downloading %d dependencies through %s...", len(coordinates), aec.repo))
+	// The files are staged, and read back by FetchDependencies from the staging directory.
+	readFile := func(filePath string) (io.ReadCloser, error) {
+		if err := downloadToStaging(servicesManager, stagingDir, aec.repo, filePath); err != nil {
+			return nil, err
+		}
+		file, err := os.Open(stagedFilePath(stagingDir, targetRepository(aec.repo), filePath))
+		return file, errorutils.CheckError(err)
+	}
+	result := mvn.FetchDependencies(aec.repo, coordinates, readFile, aec.threads)
+	if result.Failed > 0 {
+		return errorutils.CheckErrorf("failed to download %d files through %s", result.Failed, aec.repo)
+	}
+	return nil
+}
+
+func downloadToStaging(servicesManager artifactory.ArtifactoryServicesManager, stagingDir, repo, filePath string) (err error) {
+	log.Debug("Downloading", repo+"/"+filePath)
+	reader, err := servicesManager.ReadRemoteFile(repo + "/" + filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	return stageFile(stagingDir, targetRepository(repo), filePath, reader)
+}
+
+// getDependenciesChecksums returns the sha1 checksums of the dependencies of all the modules of the build, without duplicates.
+func getDependenciesChecksums(buildInfo *buildinfo.BuildInfo) []string {
+	unique := make(map[string]bool)
+	var checksums []string
+	for _, module := range buildInfo.Modules {
+		for _, dependency := range module.Dependencies {
+			if dependency.Sha1 == "" {
+				log.Warn("Skipping the dependency", dependency.Id, "of", module.Id+", since it has no sha1 checksum.")
+				continue
+			}
+			if !unique[dependency.Sha1] {
+				unique[dependency.Sha1] = true
+				checksums = append(checksums, dependency.Sha1)
+			}
+		}
+	}
+	sort.Strings(checksums)
+	return checksums
+}
+
+func createChecksumsAqlQuery(checksums []string) string {
+	conditions := make([]string, 0, len(checksums))
+	for _, checksum := range checksums {
+		conditions = append(conditions, fmt.Sprintf(`{"actual_sha1":%q}`, checksum))
+	}
+	return `items.find({"type":"file","$or":[` + strings.Join(conditions, ",") + `]}).include("repo","path","name","actual_sha1")`
+}
+
+// selectDependencyFiles selects a single file for each checksum, and returns the checksums which weren't found.
+// A file can be stored in more than one repository, so the first one by repository and path is selected.
+func selectDependencyFiles(checksums []string, items []servicesUtils.ResultItem) (files []servicesUtils.ResultItem, missing []string) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Repo != items[j].Repo {
+			return items[i].Repo < items[j].Repo
+		}
+		return path.Join(items[i].Path, items[i].Name) < path.Join(items[j].Path, items[j].Name)
+	})
+	bySha1 := make(map[string]servicesUtils.ResultItem)
+	for _, item := range items {
+		if _, exists := bySha1[item.Actual_Sha1]; !exists {
+			bySha1[item.Actual_Sha1] = item
+		}
+	}
+	for _, checksum := range checksums {
+		if item, found := bySha1[checksum]; found {
+			files = append(files, item)
+		} else {
+			missing = append(missing, checksum)
+		}
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package airgap
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDependenciesChecksums(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{
+		{Id: "app", Dependencies: []buildinfo.Dependency{
+			{Id: "b:b:1", Checksum: buildinfo.Checksum{Sha1: "bbb"}},
+			{Id: "a:a:1", Checksum: buildinfo.Checksum{Sha1: "aaa"}},
+			{Id: "no-checksum"},
+		}},
+		{Id: "lib", Dependencies: []buildinfo.Dependency{
+			{Id: "a:a:1", Checksum: buildinfo.Checksum{Sha1: "aaa"}},
+		}},
+	}}
+	assert.Equal(t, []string{"aaa", "bbb"}, getDependenciesChecksums(buildInfo))
+}
+
+func TestCreateChecksumsAqlQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"type":"file","$or":[{"actual_sha1":"aaa"},{"actual_sha1":"bbb"}]}).include("repo","path","name","actual_sha1")`,
+		createChecksumsAqlQuery([]string{"aaa", "bbb"}))
+}
+
+func TestSelectDependencyFiles(t *testing.T) {
+	items := []servicesUtils.ResultItem{
+		{Repo: "maven-remote-cache", Path: "org/a/1", Name: "a-1.jar", Actual_Sha1: "aaa"},
+		{Repo: "libs-release-local", Path: "org/a/1", Name: "a-1.jar", Actual_Sha1: "aaa"},
+		{Repo: "maven-remote-cache", Path: "org/b/1", Name: "b-1.jar", Actual_Sha1: "bbb"},
+	}
+	files, missing := selectDependencyFiles([]string{"aaa", "bbb", "ccc"}, items)
+	assert.Equal(t, []servicesUtils.ResultItem{
+		{Repo: "libs-release-local", Path: "org/a/1", Name: "a-1.jar", Actual_Sha1: "aaa"},
+		{Repo: "maven-remote-cache", Path: "org/b/1", Name: "b-1.jar", Actual_Sha1: "bbb"},
+	}, files)
+	assert.Equal(t, []string{"ccc"}, missing)
+}
@@ -0,0 +1,116 @@
+package airgap
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jfrog/gofrog/unarchive"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// AirGapImportCommand verifies the files of an archive created by AirGapExportCommand against its manifest,
+// and uploads them into the repositories of an isolated instance.
+type AirGapImportCommand struct {
+	serverDetails *config.ServerDetails
+	archivePath   string
+	targetRepo    string
+	threads       int
+	uploaded      int
+}
+
+func NewAirGapImportCommand() *AirGapImportCommand {
+	return &AirGapImportCommand{threads: defaultThreads}
+}
+
+func (aic *AirGapImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *AirGapImportCommand {
+	aic.serverDetails = serverDetails
+	return aic
+}
+
+func (aic *AirGapImportCommand) SetArchivePath(archivePath string) *AirGapImportCommand {
+	aic.archivePath = archivePath
+	return aic
+}
+
+// SetTargetRepo uploads all the files to the repository. If empty, each file is uploaded to the repository recorded by the manifest.
+func (aic *AirGapImportCommand) SetTargetRepo(targetRepo string) *AirGapImportCommand {
+	aic.targetRepo = targetRepo
+	return aic
+}
+
+func (aic *AirGapImportCommand) SetThreads(threads int) *AirGapImportCommand {
+	aic.threads = threads
+	return aic
+}
+
+func (aic *AirGapImportCommand) Uploaded() int {
+	return aic.uploaded
+}
+
+func (aic *AirGapImportCommand) CommandName() string {
+	return "rt_airgap_import"
+}
+
+func (aic *AirGapImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return aic.serverDetails, nil
+}
+
+func (aic *AirGapImportCommand) Run() (err error) {
+	extractedDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(extractedDir); err == nil {
+			err = removeErr
+		}
+	}()
+	unarchiver := &unarchive.Unarchiver{}
+	if err = unarchiver.Unarchive(aic.archivePath, filepath.Base(aic.archivePath), extractedDir); err != nil {
+		return errorutils.CheckErrorf("failed to extract %s: %s", aic.archivePath, err.Error())
+	}
+	manifest, err := readManifest(extractedDir)
+	if err != nil {
+		return err
+	}
+	if err = verifyFiles(extractedDir, manifest); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(aic.serverDetails, false, aic.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Importing %d files from %s, created from %s...", len(manifest.Files), aic.archivePath, manifest.Source))
+	var failed int
+	aic.uploaded, failed, err = servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, createUploadParams(extractedDir, manifest, aic.targetRepo)...)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to import %d of the %d files", failed, len(manifest.Files))
+	}
+	return nil
+}
+
+// createUploadParams creates the upload parameters of each file, so that it's uploaded to its path in the target repository.
+func createUploadParams(extractedDir string, manifest *AirGapManifest, targetRepo string) []services.UploadParams {
+	params := make([]services.UploadParams, 0, len(manifest.Files))
+	for _, file := range manifest.Files {
+		repo := file.Repository
+		if targetRepo != "" {
+			repo = targetRepo
+		}
+		uploadParams := services.NewUploadParams()
+		uploadParams.Pattern = stagedFilePath(extractedDir, file.Repository, file.Path)
+		uploadParams.Target = repo + "/" + file.Path
+		uploadParams.Flat = true
+		params = append(params, uploadParams)
+	}
+	return params
+}
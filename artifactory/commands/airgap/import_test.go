@@ -0,0 +1,19 @@
+package airgap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUploadParams(t *testing.T) {
+	manifest := &AirGapManifest{Files: []AirGapFile{{Repository: "maven-remote", Path: "org/a/1/a-1.jar"}}}
+	params := createUploadParams("/tmp/extracted", manifest, "")
+	assert.Len(t, params, 1)
+	assert.Equal(t, stagedFilePath("/tmp/extracted", "maven-remote", "org/a/1/a-1.jar"), params[0].Pattern)
+	assert.Equal(t, "maven-remote/org/a/1/a-1.jar", params[0].Target)
+	assert.True(t, params[0].Flat)
+
+	params = createUploadParams("/tmp/extracted", manifest, "libs-release-local")
+	assert.Equal(t, "libs-release-local/org/a/1/a-1.jar", params[0].Target)
+}
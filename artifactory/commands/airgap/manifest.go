@@ -0,0 +1,215 @@
+package airgap
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The manifest is the first entry of the archive, followed by the files under files/<repository>/<path>.
+	ManifestFileName = "airgap-manifest.json"
+	filesDirName     = "files"
+	manifestVersion  = 1
+	// The suffix of the repositories which cache the artifacts of remote repositories.
+	remoteCacheSuffix = "-cache"
+)
+
+// AirGapFile is a file of the archive, and the path it's imported to.
+type AirGapFile struct {
+	Repository string `json:"repository"`
+	// The path of the file in the repository.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Sha1   string `json:"sha1"`
+	Sha256 string `json:"sha256"`
+}
+
+// AirGapManifest lists the files of an air-gap archive, so that they can be verified and imported into an isolated instance.
+type AirGapManifest struct {
+	Version int    `json:"version"`
+	Created string `json:"created"`
+	// The build, or the dependencies files, the archive was created from.
+	Source string       `json:"source"`
+	Files  []AirGapFile `json:"files"`
+}
+
+// stagedFilePath returns the local path of a file of the repository, under the staging directory.
+func stagedFilePath(stagingDir, repo, filePath string) string {
+	return filepath.Join(stagingDir, filesDirName, repo, filepath.FromSlash(filePath))
+}
+
+// stageFile writes the content of a file of the repository under the staging directory.
+func stageFile(stagingDir, repo, filePath string, reader io.Reader) (err error) {
+	if !isSafePath(repo) || !isSafePath(filePath) {
+		return errorutils.CheckErrorf("invalid file path %s/%s", repo, filePath)
+	}
+	localPath := stagedFilePath(stagingDir, repo, filePath)
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
+
+// createManifest lists the staged files, with their checksums.
+func createManifest(stagingDir, source string, now time.Time) (*AirGapManifest, error) {
+	manifest := &AirGapManifest{Version: manifestVersion, Created: now.UTC().Format(time.RFC3339), Source: source, Files: []AirGapFile{}}
+	filesDir := filepath.Join(stagingDir, filesDirName)
+	err := filepath.WalkDir(filesDir, func(localPath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(filesDir, localPath)
+		if err != nil {
+			return err
+		}
+		repo, filePath, _ := strings.Cut(filepath.ToSlash(relativePath), "/")
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		checksums, err := crypto.GetFileChecksums(localPath, crypto.SHA1, crypto.SHA256)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, AirGapFile{Repository: repo, Path: filePath, Size: info.Size(), Sha1: checksums[crypto.SHA1], Sha256: checksums[crypto.SHA256]})
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		if manifest.Files[i].Repository != manifest.Files[j].Repository {
+			return manifest.Files[i].Repository < manifest.Files[j].Repository
+		}
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	return manifest, nil
+}
+
+// writeArchive writes the manifest and the staged files it lists to a zip archive.
+func writeArchive(stagingDir, archivePath string, manifest *AirGapManifest) (err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := archive.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	writer := zip.NewWriter(archive)
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	manifestWriter, err := writer.Create(ManifestFileName)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if _, err = manifestWriter.Write(manifestContent); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, file := range manifest.Files {
+		if err = addFileToArchive(writer, stagedFilePath(stagingDir, file.Repository, file.Path), path.Join(filesDirName, file.Repository, file.Path)); err != nil {
+			return err
+		}
+	}
+	return errorutils.CheckError(writer.Close())
+}
+
+func addFileToArchive(writer *zip.Writer, localPath, archivePath string) (err error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	// The dependencies are usually compressed archives already, so they are stored as is.
+	entryWriter, err := writer.CreateHeader(&zip.FileHeader{Name: archivePath, Method: zip.Store})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = io.Copy(entryWriter, file)
+	return errorutils.CheckError(err)
+}
+
+func readManifest(extractedDir string) (*AirGapManifest, error) {
+	content, err := os.ReadFile(filepath.Join(extractedDir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("the archive has no %s. Is it an air-gap archive?", ManifestFileName)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	manifest := new(AirGapManifest)
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read %s: %s", ManifestFileName, err.Error())
+	}
+	if manifest.Version != manifestVersion {
+		return nil, errorutils.CheckErrorf("unsupported air-gap manifest version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+// verifyFiles verifies that the extracted files match the checksums of the manifest.
+func verifyFiles(extractedDir string, manifest *AirGapManifest) error {
+	for _, file := range manifest.Files {
+		if !isSafePath(file.Repository) || !isSafePath(file.Path) {
+			return errorutils.CheckErrorf("invalid file path %s/%s in %s", file.Repository, file.Path, ManifestFileName)
+		}
+		localPath := stagedFilePath(extractedDir, file.Repository, file.Path)
+		checksums, err := crypto.GetFileChecksums(localPath, crypto.SHA256)
+		if err != nil {
+			return errorutils.CheckErrorf("failed to read %s/%s from the archive: %s", file.Repository, file.Path, err.Error())
+		}
+		if !strings.EqualFold(checksums[crypto.SHA256], file.Sha256) {
+			return errorutils.CheckErrorf("the checksum of %s/%s doesn't match the manifest. Expected %s, but got %s", file.Repository, file.Path, file.Sha256, checksums[crypto.SHA256])
+		}
+	}
+	log.Debug("Verified the checksums of", len(manifest.Files), "files")
+	return nil
+}
+
+// isSafePath returns false for empty and absolute paths, and for paths which leave their parent directory.
+func isSafePath(filePath string) bool {
+	if filePath == "" || path.IsAbs(filePath) || strings.Contains(filePath, `\`) {
+		return false
+	}
+	for _, element := range strings.Split(filePath, "/") {
+		if element == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// targetRepository returns the repository a file is recorded with, and imported to by default. Artifacts cached by a remote
+// repository are stored in its cache repository, which can't be deployed to, so they are recorded with the name of the remote repository.
+func targetRepository(repo string) string {
+	return strings.TrimSuffix(repo, remoteCacheSuffix)
+}
@@ -0,0 +1,72 @@
+package airgap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/gofrog/unarchive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	stagingDir := t.TempDir()
+	require.NoError(t, stageFile(stagingDir, "maven-remote", "org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.jar", strings.NewReader("jar")))
+	require.NoError(t, stageFile(stagingDir, "maven-remote", "org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.pom", strings.NewReader("pom")))
+	require.NoError(t, stageFile(stagingDir, "npm-remote", "lodash/-/lodash-4.17.21.tgz", strings.NewReader("tgz")))
+	assert.Error(t, stageFile(stagingDir, "maven-remote", "../escape.jar", strings.NewReader("jar")))
+
+	manifest, err := createManifest(stagingDir, "build app/1", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-02T03:04:05Z", manifest.Created)
+	require.Len(t, manifest.Files, 3)
+	assert.Equal(t, AirGapFile{
+		Repository: "maven-remote",
+		Path:       "org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.jar",
+		Size:       3,
+		Sha1:       "f92e777f4341930bad9b2422283c4680d00dbc06",
+		Sha256:     "0163f1eea7894350060624d315234d40c508ab251ba121714e234503045faadd",
+	}, manifest.Files[0])
+	assert.Equal(t, "npm-remote", manifest.Files[2].Repository)
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, writeArchive(stagingDir, archivePath, manifest))
+
+	extractedDir := t.TempDir()
+	require.NoError(t, (&unarchive.Unarchiver{}).Unarchive(archivePath, "bundle.zip", extractedDir))
+	readBack, err := readManifest(extractedDir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, readBack)
+	assert.NoError(t, verifyFiles(extractedDir, readBack))
+
+	// A modified file fails the verification.
+	require.NoError(t, os.WriteFile(stagedFilePath(extractedDir, "npm-remote", "lodash/-/lodash-4.17.21.tgz"), []byte("modified"), 0644))
+	assert.ErrorContains(t, verifyFiles(extractedDir, readBack), "the checksum of npm-remote/lodash/-/lodash-4.17.21.tgz doesn't match the manifest")
+}
+
+func TestReadManifestErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := readManifest(dir)
+	assert.ErrorContains(t, err, "the archive has no "+ManifestFileName)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(`{"version":2}`), 0644))
+	_, err = readManifest(dir)
+	assert.ErrorContains(t, err, "unsupported air-gap manifest version 2")
+}
+
+func TestIsSafePath(t *testing.T) {
+	assert.True(t, isSafePath("org/app/1.0/app-1.0.jar"))
+	assert.True(t, isSafePath("maven-remote"))
+	assert.False(t, isSafePath(""))
+	assert.False(t, isSafePath("/etc/passwd"))
+	assert.False(t, isSafePath("org/../../escape"))
+	assert.False(t, isSafePath(`org\app`))
+}
+
+func TestTargetRepository(t *testing.T) {
+	assert.Equal(t, "maven-remote", targetRepository("maven-remote-cache"))
+	assert.Equal(t, "libs-release-local", targetRepository("libs-release-local"))
+}
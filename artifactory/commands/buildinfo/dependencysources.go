@@ -0,0 +1,246 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The prefix of the build-info properties which count the dependencies each repository served, such as dependency.source.npm-remote-cache=120.
+	DependencySourcePropertyPrefix = "dependency.source."
+
+	// The types of the repositories which serve the dependencies.
+	LocalSourceType       = "local"
+	RemoteCacheSourceType = "remote-cache"
+
+	remoteCacheSuffix = "-cache"
+	// The number of checksums looked up by each AQL query.
+	dependencySourcesBatchSize = 100
+)
+
+// The module types whose dependencies are resolved from Artifactory by their package managers.
+var dependencySourceModuleTypes = []buildinfo.ModuleType{buildinfo.Maven, buildinfo.Gradle, buildinfo.Npm}
+
+// DependencySource is the repository which served a dependency of a build.
+type DependencySource struct {
+	Module     string `json:"module"`
+	Dependency string `json:"dependency"`
+	Sha1       string `json:"sha1,omitempty"`
+	// The local repository or the cache of the remote repository which stores the dependency. Empty if the dependency wasn't found.
+	Repository     string `json:"repository,omitempty"`
+	RepositoryType string `json:"repositoryType,omitempty"`
+	// The virtual repositories through which the dependency was resolved from the repository.
+	VirtualRepositories []string `json:"virtualRepositories,omitempty"`
+	// The other repositories which store the same file. A dependency which is served by a remote repository, while a local
+	// one stores it too, may be exposed to dependency confusion.
+	OtherRepositories []string `json:"otherRepositories,omitempty"`
+}
+
+// DependencySourcesReport is the report of the repositories which served the dependencies of a build.
+type DependencySourcesReport struct {
+	BuildName    string             `json:"buildName"`
+	BuildNumber  string             `json:"buildNumber"`
+	Dependencies []DependencySource `json:"dependencies"`
+}
+
+// repositoryIndex holds the repositories of Artifactory the dependencies may be served by.
+type repositoryIndex struct {
+	// The types of the repositories, by their keys.
+	types map[string]string
+	// The members of the virtual repositories, in their resolution order.
+	virtualMembers map[string][]string
+}
+
+func newRepositoryIndex(servicesManager artifactory.ArtifactoryServicesManager) (*repositoryIndex, error) {
+	repositories, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	index := &repositoryIndex{types: make(map[string]string), virtualMembers: make(map[string][]string)}
+	for _, repository := range *repositories {
+		repoType := strings.ToLower(repository.GetRepoType())
+		index.types[repository.Key] = repoType
+		if repoType != "virtual" || !isDependencySourcePackageType(repository.PackageType) {
+			continue
+		}
+		virtualParams := services.VirtualRepositoryBaseParams{}
+		if err = servicesManager.GetRepository(repository.Key, &virtualParams); err != nil {
+			return nil, err
+		}
+		index.virtualMembers[repository.Key] = virtualParams.Repositories
+	}
+	return index, nil
+}
+
+func isDependencySourcePackageType(packageType string) bool {
+	return slices.ContainsFunc(dependencySourceModuleTypes, func(moduleType buildinfo.ModuleType) bool {
+		return strings.EqualFold(string(moduleType), packageType)
+	})
+}
+
+// getSourceType returns the type of the repository which stores an item, and the key of the repository in the virtual repositories.
+// The items of the remote repositories are stored in their caches, whose keys have the -cache suffix.
+func (ri *repositoryIndex) getSourceType(repo string) (sourceType, memberKey string) {
+	switch repoType := ri.types[repo]; repoType {
+	case "local", "federated":
+		return LocalSourceType, repo
+	case "":
+		if remote, found := strings.CutSuffix(repo, remoteCacheSuffix); found && ri.types[remote] == "remote" {
+			return RemoteCacheSourceType, remote
+		}
+		return "", repo
+	default:
+		return repoType, repo
+	}
+}
+
+// getVirtualRepositories returns the virtual repositories which include the repository, sorted by their keys.
+func (ri *repositoryIndex) getVirtualRepositories(memberKey string) (virtuals []string) {
+	for virtual, members := range ri.virtualMembers {
+		if slices.Contains(members, memberKey) {
+			virtuals = append(virtuals, virtual)
+		}
+	}
+	slices.Sort(virtuals)
+	return
+}
+
+// rankRepository returns the position of the repository in the order Artifactory resolves it. When the dependency was resolved
+// through a virtual repository, its members are resolved in their order. Otherwise, the local repositories are resolved before
+// the remote ones.
+func (ri *repositoryIndex) rankRepository(repo, resolver string) int {
+	sourceType, memberKey := ri.getSourceType(repo)
+	if members, isVirtual := ri.virtualMembers[resolver]; isVirtual {
+		if i := slices.Index(members, memberKey); i >= 0 {
+			return i
+		}
+		return len(members) + rankBySourceType(sourceType)
+	}
+	return rankBySourceType(sourceType)
+}
+
+func rankBySourceType(sourceType string) int {
+	switch sourceType {
+	case LocalSourceType:
+		return 0
+	case RemoteCacheSourceType:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// resolveDependencySources finds the repositories which store the dependencies of the Maven, Gradle and npm modules by their
+// checksums, and sets the repository which served each of them in the build-info. The number of dependencies each repository
+// served is added to the build-info properties.
+func resolveDependencySources(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo) ([]DependencySource, error) {
+	var sha1s []string
+	for _, module := range buildInfo.Modules {
+		if !slices.Contains(dependencySourceModuleTypes, module.Type) {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			if dependency.Sha1 != "" && !slices.Contains(sha1s, dependency.Sha1) {
+				sha1s = append(sha1s, dependency.Sha1)
+			}
+		}
+	}
+	if len(sha1s) == 0 {
+		return nil, nil
+	}
+	index, err := newRepositoryIndex(servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	reposBySha1, err := findRepositoriesBySha1(servicesManager, sha1s)
+	if err != nil {
+		return nil, err
+	}
+	var sources []DependencySource
+	servedCounts := make(map[string]int)
+	for i := range buildInfo.Modules {
+		module := &buildInfo.Modules[i]
+		if !slices.Contains(dependencySourceModuleTypes, module.Type) {
+			continue
+		}
+		for j := range module.Dependencies {
+			dependency := &module.Dependencies[j]
+			source := DependencySource{Module: module.Id, Dependency: dependency.Id, Sha1: dependency.Sha1}
+			if repos := slices.Clone(reposBySha1[dependency.Sha1]); len(repos) > 0 {
+				resolver := dependency.Repository
+				slices.SortStableFunc(repos, func(a, b string) int {
+					return index.rankRepository(a, resolver) - index.rankRepository(b, resolver)
+				})
+				var memberKey string
+				source.Repository, source.OtherRepositories = repos[0], repos[1:]
+				source.RepositoryType, memberKey = index.getSourceType(source.Repository)
+				source.VirtualRepositories = index.getVirtualRepositories(memberKey)
+				dependency.Repository = source.Repository
+				servedCounts[source.Repository]++
+			}
+			sources = append(sources, source)
+		}
+	}
+	if buildInfo.Properties == nil {
+		buildInfo.Properties = make(buildinfo.Env)
+	}
+	for repo, count := range servedCounts {
+		buildInfo.Properties[DependencySourcePropertyPrefix+repo] = strconv.Itoa(count)
+	}
+	if unresolved := len(sources) - sumCounts(servedCounts); unresolved > 0 {
+		log.Warn(fmt.Sprintf("The repositories which served %d of the dependencies of the build weren't found in Artifactory.", unresolved))
+	}
+	return sources, nil
+}
+
+// findRepositoriesBySha1 returns the repositories which store the files of the checksums, sorted by their keys.
+func findRepositoriesBySha1(servicesManager artifactory.ArtifactoryServicesManager, sha1s []string) (map[string][]string, error) {
+	reposBySha1 := make(map[string][]string)
+	for batch := range slices.Chunk(sha1s, dependencySourcesBatchSize) {
+		criteria := make([]string, 0, len(batch))
+		for _, sha1 := range batch {
+			criteria = append(criteria, fmt.Sprintf(`{"actual_sha1":%q}`, sha1))
+		}
+		results, err := artifactoryutils.NewAqlPager(servicesManager, fmt.Sprintf(`"type":"file","$or":[%s]`, strings.Join(criteria, ","))).
+			SetInclude("actual_sha1").All()
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			if !slices.Contains(reposBySha1[result.Actual_Sha1], result.Repo) {
+				reposBySha1[result.Actual_Sha1] = append(reposBySha1[result.Actual_Sha1], result.Repo)
+			}
+		}
+	}
+	return reposBySha1, nil
+}
+
+func sumCounts(counts map[string]int) (sum int) {
+	for _, count := range counts {
+		sum += count
+	}
+	return
+}
+
+func writeDependencySourcesReport(reportPath string, report *DependencySourcesReport) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(reportPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The dependency sources report was written to", reportPath)
+	return nil
+}
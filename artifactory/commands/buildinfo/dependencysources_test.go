@@ -0,0 +1,104 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dependencySourcesServicesManager struct {
+	artifactory.EmptyArtifactoryServicesManager
+	repositories   []services.RepositoryDetails
+	virtualMembers map[string][]string
+	aqlResults     string
+}
+
+func (m *dependencySourcesServicesManager) GetAllRepositories() (*[]services.RepositoryDetails, error) {
+	return &m.repositories, nil
+}
+
+func (m *dependencySourcesServicesManager) GetRepository(repoKey string, repoDetails interface{}) error {
+	repoDetails.(*services.VirtualRepositoryBaseParams).Repositories = m.virtualMembers[repoKey]
+	return nil
+}
+
+func (m *dependencySourcesServicesManager) Aql(string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(m.aqlResults)), nil
+}
+
+func TestRecordDependencySources(t *testing.T) {
+	servicesManager := &dependencySourcesServicesManager{
+		repositories: []services.RepositoryDetails{
+			{Key: "npm-local", Type: "LOCAL", PackageType: "Npm"},
+			{Key: "npm-remote", Type: "REMOTE", PackageType: "Npm"},
+			{Key: "npm", Type: "VIRTUAL", PackageType: "Npm"},
+			{Key: "libs-release", Type: "LOCAL", PackageType: "Maven"},
+		},
+		virtualMembers: map[string][]string{"npm": {"npm-remote", "npm-local"}},
+		aqlResults: `{"results":[
+			{"repo":"libs-release","path":"org/acme/core/1.0","name":"core-1.0.jar","actual_sha1":"aaa"},
+			{"repo":"npm-local","path":"internal-utils/-","name":"internal-utils-1.0.0.tgz","actual_sha1":"bbb"},
+			{"repo":"npm-remote-cache","path":"internal-utils/-","name":"internal-utils-1.0.0.tgz","actual_sha1":"bbb"},
+			{"repo":"npm-local","path":"left-pad/-","name":"left-pad-1.3.0.tgz","actual_sha1":"ccc"}
+		]}`,
+	}
+	buildInfo := &buildinfo.BuildInfo{Name: "app", Number: "7", Modules: []buildinfo.Module{
+		{Id: "org.acme:app:1.0", Type: buildinfo.Maven, Dependencies: []buildinfo.Dependency{
+			{Id: "org.acme:core:1.0", Checksum: buildinfo.Checksum{Sha1: "aaa"}},
+			{Id: "org.acme:missing:1.0", Checksum: buildinfo.Checksum{Sha1: "ddd"}},
+		}},
+		{Id: "app:1.0.0", Type: buildinfo.Npm, Dependencies: []buildinfo.Dependency{
+			// Resolved through the npm virtual repository, which resolves its remote member first.
+			{Id: "internal-utils:1.0.0", Repository: "npm", Checksum: buildinfo.Checksum{Sha1: "bbb"}},
+			{Id: "left-pad:1.3.0", Checksum: buildinfo.Checksum{Sha1: "ccc"}},
+		}},
+		{Id: "app", Type: buildinfo.Generic, Dependencies: []buildinfo.Dependency{{Id: "data.zip", Checksum: buildinfo.Checksum{Sha1: "aaa"}}}},
+	}}
+	reportPath := filepath.Join(t.TempDir(), "sources.json")
+	publishCommand := NewBuildPublishCommand().SetDependencySourcesReport(reportPath)
+	require.NoError(t, publishCommand.recordDependencySources(servicesManager, buildInfo))
+
+	assert.Equal(t, "libs-release", buildInfo.Modules[0].Dependencies[0].Repository)
+	assert.Empty(t, buildInfo.Modules[0].Dependencies[1].Repository)
+	assert.Equal(t, "npm-remote-cache", buildInfo.Modules[1].Dependencies[0].Repository)
+	assert.Equal(t, "npm-local", buildInfo.Modules[1].Dependencies[1].Repository)
+	assert.Empty(t, buildInfo.Modules[2].Dependencies[0].Repository)
+	assert.Equal(t, buildinfo.Env{
+		DependencySourcePropertyPrefix + "libs-release":     "1",
+		DependencySourcePropertyPrefix + "npm-remote-cache": "1",
+		DependencySourcePropertyPrefix + "npm-local":        "1",
+	}, buildInfo.Properties)
+
+	content, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report DependencySourcesReport
+	require.NoError(t, json.Unmarshal(content, &report))
+	assert.Equal(t, "app", report.BuildName)
+	assert.Equal(t, "7", report.BuildNumber)
+	assert.Equal(t, []DependencySource{
+		{Module: "org.acme:app:1.0", Dependency: "org.acme:core:1.0", Sha1: "aaa", Repository: "libs-release", RepositoryType: LocalSourceType},
+		{Module: "org.acme:app:1.0", Dependency: "org.acme:missing:1.0", Sha1: "ddd"},
+		{Module: "app:1.0.0", Dependency: "internal-utils:1.0.0", Sha1: "bbb", Repository: "npm-remote-cache", RepositoryType: RemoteCacheSourceType,
+			VirtualRepositories: []string{"npm"}, OtherRepositories: []string{"npm-local"}},
+		{Module: "app:1.0.0", Dependency: "left-pad:1.3.0", Sha1: "ccc", Repository: "npm-local", RepositoryType: LocalSourceType, VirtualRepositories: []string{"npm"}},
+	}, report.Dependencies)
+}
+
+func TestRecordDependencySourcesDisabled(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{
+		{Type: buildinfo.Npm, Dependencies: []buildinfo.Dependency{{Id: "left-pad:1.3.0", Checksum: buildinfo.Checksum{Sha1: "ccc"}}}},
+	}}
+	// The services manager isn't used unless the dependency sources are recorded.
+	require.NoError(t, NewBuildPublishCommand().recordDependencySources(nil, buildInfo))
+	assert.Empty(t, buildInfo.Modules[0].Dependencies[0].Repository)
+	assert.Nil(t, buildInfo.Properties)
+}
@@ -0,0 +1,273 @@
+package buildinfo
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	lifecycleServices "github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	dockerManifestName     = "manifest.json"
+	dockerListManifestName = "list.manifest.json"
+	dockerImageTagProp     = "docker.image.tag"
+)
+
+// DeploymentImage is a Docker image recorded by a build or a release bundle, referenced by its digest.
+type DeploymentImage struct {
+	// The image name, including the registry, such as "acme.jfrog.io/docker-local/app".
+	Name   string
+	Tag    string
+	Digest string
+	// The name and the digest, such as "acme.jfrog.io/docker-local/app@sha256:...".
+	Reference string
+}
+
+// DeploymentArtifact is a file recorded by a build or a release bundle.
+type DeploymentArtifact struct {
+	Name string
+	// The path of the artifact in Artifactory, including the repository when it's known.
+	Path   string
+	Sha256 string
+}
+
+// DeploymentManifestData is the data the template is executed with.
+type DeploymentManifestData struct {
+	// "build" or "release-bundle"
+	Source    string
+	Name      string
+	Version   string
+	Images    []DeploymentImage
+	Artifacts []DeploymentArtifact
+}
+
+// Image returns the image whose name is, or ends with, the name. Templates use it as {{ (.Image "app").Reference }}.
+// Fails if there is no such image, so that a deployment never references an image which wasn't published.
+func (data DeploymentManifestData) Image(name string) (DeploymentImage, error) {
+	for _, image := range data.Images {
+		if image.Name == name || strings.HasSuffix(image.Name, "/"+name) {
+			return image, nil
+		}
+	}
+	return DeploymentImage{}, errorutils.CheckErrorf("no image named '%s' was found in %s %s/%s", name, data.Source, data.Name, data.Version)
+}
+
+// DeploymentManifestCommand renders a deployment manifest, such as Kubernetes YAML, Helm values or a Compose file,
+// from the image digests and the artifacts recorded by a build or a release bundle, using a Go template.
+type DeploymentManifestCommand struct {
+	serverDetails        *config.ServerDetails
+	templatePath         string
+	outputPath           string
+	buildName            string
+	buildNumber          string
+	releaseBundleName    string
+	releaseBundleVersion string
+	project              string
+}
+
+func NewDeploymentManifestCommand() *DeploymentManifestCommand {
+	return &DeploymentManifestCommand{}
+}
+
+func (dmc *DeploymentManifestCommand) SetServerDetails(serverDetails *config.ServerDetails) *DeploymentManifestCommand {
+	dmc.serverDetails = serverDetails
+	return dmc
+}
+
+func (dmc *DeploymentManifestCommand) SetTemplatePath(templatePath string) *DeploymentManifestCommand {
+	dmc.templatePath = templatePath
+	return dmc
+}
+
+// SetOutputPath sets the file the manifest is written to. The manifest is printed if empty.
+func (dmc *DeploymentManifestCommand) SetOutputPath(outputPath string) *DeploymentManifestCommand {
+	dmc.outputPath = outputPath
+	return dmc
+}
+
+func (dmc *DeploymentManifestCommand) SetBuild(buildName, buildNumber string) *DeploymentManifestCommand {
+	dmc.buildName, dmc.buildNumber = buildName, buildNumber
+	return dmc
+}
+
+func (dmc *DeploymentManifestCommand) SetReleaseBundle(releaseBundleName, releaseBundleVersion string) *DeploymentManifestCommand {
+	dmc.releaseBundleName, dmc.releaseBundleVersion = releaseBundleName, releaseBundleVersion
+	return dmc
+}
+
+func (dmc *DeploymentManifestCommand) SetProject(project string) *DeploymentManifestCommand {
+	dmc.project = project
+	return dmc
+}
+
+func (dmc *DeploymentManifestCommand) CommandName() string {
+	return "rt_deployment_manifest"
+}
+
+func (dmc *DeploymentManifestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dmc.serverDetails, nil
+}
+
+func (dmc *DeploymentManifestCommand) Run() error {
+	if (dmc.buildName == "") == (dmc.releaseBundleName == "") {
+		return errorutils.CheckErrorf("exactly one of a build or a release bundle must be provided")
+	}
+	templateContent, err := os.ReadFile(dmc.templatePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var data *DeploymentManifestData
+	if dmc.buildName != "" {
+		data, err = dmc.getBuildData()
+	} else {
+		data, err = dmc.getReleaseBundleData()
+	}
+	if err != nil {
+		return err
+	}
+	manifest, err := renderDeploymentManifest(path.Base(dmc.templatePath), string(templateContent), data)
+	if err != nil {
+		return err
+	}
+	if dmc.outputPath == "" {
+		log.Output(strings.TrimSuffix(manifest, "\n"))
+		return nil
+	}
+	log.Info("Writing the deployment manifest to", dmc.outputPath)
+	return errorutils.CheckError(os.WriteFile(dmc.outputPath, []byte(manifest), 0644))
+}
+
+func (dmc *DeploymentManifestCommand) getBuildData() (*DeploymentManifestData, error) {
+	servicesManager, err := utils.CreateServiceManager(dmc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: dmc.buildName, BuildNumber: dmc.buildNumber, ProjectKey: dmc.project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found", dmc.buildName, dmc.buildNumber)
+	}
+	return newBuildDeploymentManifestData(&publishedBuildInfo.BuildInfo), nil
+}
+
+func (dmc *DeploymentManifestCommand) getReleaseBundleData() (*DeploymentManifestData, error) {
+	servicesManager, err := utils.CreateLifecycleServiceManager(dmc.serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	rbDetails := lifecycleServices.ReleaseBundleDetails{ReleaseBundleName: dmc.releaseBundleName, ReleaseBundleVersion: dmc.releaseBundleVersion}
+	spec, err := servicesManager.GetReleaseBundleSpecification(rbDetails)
+	if err != nil {
+		return nil, err
+	}
+	return newReleaseBundleDeploymentManifestData(dmc.releaseBundleName, dmc.releaseBundleVersion, getRegistryHost(dmc.serverDetails), spec), nil
+}
+
+// newBuildDeploymentManifestData collects the images of the Docker modules of the build, and the artifacts of all its modules.
+func newBuildDeploymentManifestData(buildInfo *buildinfo.BuildInfo) *DeploymentManifestData {
+	data := &DeploymentManifestData{Source: "build", Name: buildInfo.Name, Version: buildInfo.Number, Images: []DeploymentImage{}, Artifacts: []DeploymentArtifact{}}
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			artifactPath := artifact.Path
+			if artifact.OriginalDeploymentRepo != "" {
+				artifactPath = artifact.OriginalDeploymentRepo + "/" + artifactPath
+			}
+			data.Artifacts = append(data.Artifacts, DeploymentArtifact{Name: artifact.Name, Path: artifactPath, Sha256: artifact.Sha256})
+		}
+		if module.Type != buildinfo.Docker {
+			continue
+		}
+		props, ok := module.Properties.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		imageTag, _ := props[dockerImageTagProp].(string)
+		if digest := getImageDigest(module.Artifacts); imageTag != "" && digest != "" {
+			data.Images = append(data.Images, newDeploymentImage(imageTag, digest))
+		}
+	}
+	return data
+}
+
+// getImageDigest returns the digest of the image, which is the checksum of its manifest.
+// The list manifest of a multi-platform image is preferred over the manifests of its platforms.
+func getImageDigest(artifacts []buildinfo.Artifact) string {
+	digest := ""
+	for _, artifact := range artifacts {
+		switch artifact.Name {
+		case dockerListManifestName:
+			return "sha256:" + artifact.Sha256
+		case dockerManifestName:
+			digest = "sha256:" + artifact.Sha256
+		}
+	}
+	return digest
+}
+
+// newReleaseBundleDeploymentManifestData collects the images, identified by their manifests, and the artifacts of the release bundle.
+// The images are referenced by the registry host of the server, with the repository path method.
+func newReleaseBundleDeploymentManifestData(name, version, registryHost string, spec lifecycleServices.ReleaseBundleSpecResponse) *DeploymentManifestData {
+	data := &DeploymentManifestData{Source: "release-bundle", Name: name, Version: version, Images: []DeploymentImage{}, Artifacts: []DeploymentArtifact{}}
+	for _, artifact := range spec.Artifacts {
+		data.Artifacts = append(data.Artifacts, DeploymentArtifact{Name: path.Base(artifact.Path), Path: artifact.Path, Sha256: artifact.Checksum})
+		fileName := path.Base(artifact.Path)
+		if fileName != dockerManifestName && fileName != dockerListManifestName {
+			continue
+		}
+		// The path is <repo>/<image>/<tag>/manifest.json. The manifests of the platforms of multi-platform images are in digest folders.
+		imagePath, tag := path.Split(path.Dir(artifact.Path))
+		if strings.HasPrefix(tag, "sha256:") || strings.Count(imagePath, "/") < 2 {
+			continue
+		}
+		imageName := strings.TrimSuffix(imagePath, "/")
+		if registryHost != "" {
+			imageName = registryHost + "/" + imageName
+		}
+		data.Images = append(data.Images, newDeploymentImage(imageName+":"+tag, "sha256:"+artifact.Checksum))
+	}
+	return data
+}
+
+func newDeploymentImage(imageTag, digest string) DeploymentImage {
+	name, tag := imageTag, ""
+	if separator := strings.LastIndex(imageTag, ":"); separator > strings.LastIndex(imageTag, "/") {
+		name, tag = imageTag[:separator], imageTag[separator+1:]
+	}
+	return DeploymentImage{Name: name, Tag: tag, Digest: digest, Reference: name + "@" + digest}
+}
+
+func getRegistryHost(serverDetails *config.ServerDetails) string {
+	serverUrl, err := url.Parse(serverDetails.GetUrl())
+	if err != nil || serverUrl.Host == "" {
+		serverUrl, err = url.Parse(serverDetails.GetArtifactoryUrl())
+		if err != nil {
+			return ""
+		}
+	}
+	return serverUrl.Host
+}
+
+// renderDeploymentManifest executes the template with the data. Referencing missing fields fails the rendering.
+func renderDeploymentManifest(name, templateContent string, data *DeploymentManifestData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(templateContent)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the deployment manifest template: %s", err.Error())
+	}
+	var manifest bytes.Buffer
+	if err = tmpl.Execute(&manifest, data); err != nil {
+		return "", errorutils.CheckErrorf("failed to render the deployment manifest: %s", err.Error())
+	}
+	return manifest.String(), nil
+}
@@ -0,0 +1,120 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	lifecycleServices "github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuildDeploymentManifestData(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{
+		Name:   "app-build",
+		Number: "7",
+		Modules: []buildinfo.Module{
+			{
+				Id:         "acme.jfrog.io/docker-local/app:1.0",
+				Type:       buildinfo.Docker,
+				Properties: map[string]interface{}{dockerImageTagProp: "acme.jfrog.io/docker-local/app:1.0"},
+				Artifacts: []buildinfo.Artifact{
+					{Name: "manifest.json", Path: "app/1.0/manifest.json", OriginalDeploymentRepo: "docker-local", Checksum: buildinfo.Checksum{Sha256: "platform"}},
+					{Name: dockerListManifestName, Path: "app/1.0/list.manifest.json", OriginalDeploymentRepo: "docker-local", Checksum: buildinfo.Checksum{Sha256: "list"}},
+				},
+			},
+			{
+				Id:   "org:lib:1.0",
+				Type: buildinfo.Maven,
+				Artifacts: []buildinfo.Artifact{
+					{Name: "lib-1.0.jar", Path: "org/lib/1.0/lib-1.0.jar", Checksum: buildinfo.Checksum{Sha256: "jar"}},
+				},
+			},
+		},
+	}
+	data := newBuildDeploymentManifestData(buildInfo)
+	assert.Equal(t, "build", data.Source)
+	assert.Equal(t, "app-build", data.Name)
+	assert.Equal(t, "7", data.Version)
+	assert.Equal(t, []DeploymentImage{{
+		Name:      "acme.jfrog.io/docker-local/app",
+		Tag:       "1.0",
+		Digest:    "sha256:list",
+		Reference: "acme.jfrog.io/docker-local/app@sha256:list",
+	}}, data.Images)
+	assert.Equal(t, []DeploymentArtifact{
+		{Name: "manifest.json", Path: "docker-local/app/1.0/manifest.json", Sha256: "platform"},
+		{Name: "list.manifest.json", Path: "docker-local/app/1.0/list.manifest.json", Sha256: "list"},
+		{Name: "lib-1.0.jar", Path: "org/lib/1.0/lib-1.0.jar", Sha256: "jar"},
+	}, data.Artifacts)
+}
+
+func TestNewReleaseBundleDeploymentManifestData(t *testing.T) {
+	var spec lifecycleServices.ReleaseBundleSpecResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"artifacts":[
+		{"path":"docker-local/team/app/1.0/list.manifest.json","checksum":"list"},
+		{"path":"docker-local/team/app/sha256:platform/manifest.json","checksum":"platform"},
+		{"path":"docker-local/web/2.0/manifest.json","checksum":"web"},
+		{"path":"generic-local/config.yaml","checksum":"config"}
+	]}`), &spec))
+	data := newReleaseBundleDeploymentManifestData("bundle", "1.0.0", "acme.jfrog.io", spec)
+	assert.Equal(t, "release-bundle", data.Source)
+	assert.Equal(t, []DeploymentImage{
+		{Name: "acme.jfrog.io/docker-local/team/app", Tag: "1.0", Digest: "sha256:list", Reference: "acme.jfrog.io/docker-local/team/app@sha256:list"},
+		{Name: "acme.jfrog.io/docker-local/web", Tag: "2.0", Digest: "sha256:web", Reference: "acme.jfrog.io/docker-local/web@sha256:web"},
+	}, data.Images)
+	assert.Len(t, data.Artifacts, 4)
+	assert.Equal(t, DeploymentArtifact{Name: "config.yaml", Path: "generic-local/config.yaml", Sha256: "config"}, data.Artifacts[3])
+}
+
+func TestNewDeploymentImage(t *testing.T) {
+	tests := []struct {
+		imageTag     string
+		expectedName string
+		expectedTag  string
+	}{
+		{"acme.jfrog.io/docker-local/app:1.0", "acme.jfrog.io/docker-local/app", "1.0"},
+		{"acme.jfrog.io:8082/docker-local/app:1.0", "acme.jfrog.io:8082/docker-local/app", "1.0"},
+		{"acme.jfrog.io:8082/docker-local/app", "acme.jfrog.io:8082/docker-local/app", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.imageTag, func(t *testing.T) {
+			image := newDeploymentImage(test.imageTag, "sha256:abc")
+			assert.Equal(t, test.expectedName, image.Name)
+			assert.Equal(t, test.expectedTag, image.Tag)
+			assert.Equal(t, test.expectedName+"@sha256:abc", image.Reference)
+		})
+	}
+}
+
+func TestRenderDeploymentManifest(t *testing.T) {
+	data := &DeploymentManifestData{
+		Source:  "build",
+		Name:    "app-build",
+		Version: "7",
+		Images: []DeploymentImage{{
+			Name:      "acme.jfrog.io/docker-local/app",
+			Tag:       "1.0",
+			Digest:    "sha256:abc",
+			Reference: "acme.jfrog.io/docker-local/app@sha256:abc",
+		}},
+	}
+	t.Run("image reference", func(t *testing.T) {
+		manifest, err := renderDeploymentManifest("values.yaml", "# {{ .Name }}/{{ .Version }}\nimage: {{ (.Image \"app\").Reference }}\n", data)
+		require.NoError(t, err)
+		assert.Equal(t, "# app-build/7\nimage: acme.jfrog.io/docker-local/app@sha256:abc\n", manifest)
+	})
+	t.Run("missing image", func(t *testing.T) {
+		_, err := renderDeploymentManifest("values.yaml", "image: {{ (.Image \"web\").Reference }}", data)
+		assert.ErrorContains(t, err, "no image named 'web' was found in build app-build/7")
+	})
+	t.Run("missing field", func(t *testing.T) {
+		_, err := renderDeploymentManifest("values.yaml", "image: {{ .Registry }}", data)
+		assert.Error(t, err)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := renderDeploymentManifest("values.yaml", "image: {{ .Name ", data)
+		assert.ErrorContains(t, err, "failed to parse the deployment manifest template")
+	})
+}
@@ -1,6 +1,7 @@
 package buildinfo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"github.com/jfrog/build-info-go/utils/cienv"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -26,6 +28,10 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+// DefaultEnvExclude is the default of the --env-exclude option of the build-publish command: the environment variables which
+// match its patterns aren't added to the published build-info.
+const DefaultEnvExclude = "*password*;*psw*;*secret*;*key*;*token*;*auth*"
+
 type BuildPublishCommand struct {
 	buildConfiguration *build.BuildConfiguration
 	serverDetails      *config.ServerDetails
@@ -35,6 +41,10 @@ type BuildPublishCommand struct {
 	collectGitInfo     bool
 	collectEnv         bool
 	depExcludeScopes   []string
+	// dependencySources causes Run() to record the repository which served each Maven, Gradle and npm dependency.
+	dependencySources bool
+	// dependencySourcesReport is the path the report of the dependency sources is written to, if set.
+	dependencySourcesReport string
 	// buildInfoUiUrl holds the Artifactory UI URL of the published build info.
 	// It is populated by Run() and exposed via GetBuildInfoUiUrl() so the CLI
 	// layer can render it when --format is set.
@@ -45,6 +55,8 @@ type BuildPublishCommand struct {
 	// collectSha256 causes Run() to store the Sha256Summary even when
 	// detailedSummary is false, so the CLI format layer can include the sha256.
 	collectSha256 bool
+	// The requests of the publishing are canceled with the context, if set.
+	ctx context.Context
 	BuildAddGitCommand
 }
 
@@ -139,12 +151,37 @@ func (bpc *BuildPublishCommand) SetDepExcludeScopes(scopes []string) *BuildPubli
 	return bpc
 }
 
+// SetContext sets the context of the publishing. Its requests are canceled when the context is canceled.
+func (bpc *BuildPublishCommand) SetContext(ctx context.Context) *BuildPublishCommand {
+	bpc.ctx = ctx
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) context() context.Context {
+	if bpc.ctx == nil {
+		return context.Background()
+	}
+	return bpc.ctx
+}
+
+// SetDependencySources causes Run() to record, in the build-info, the repository which served each Maven, Gradle and npm dependency.
+func (bpc *BuildPublishCommand) SetDependencySources(dependencySources bool) *BuildPublishCommand {
+	bpc.dependencySources = dependencySources
+	return bpc
+}
+
+// SetDependencySourcesReport sets the path of the JSON report of the dependency sources. Setting it records the dependency sources.
+func (bpc *BuildPublishCommand) SetDependencySourcesReport(reportPath string) *BuildPublishCommand {
+	bpc.dependencySourcesReport = reportPath
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) ServerDetails() (*config.ServerDetails, error) {
 	return bpc.serverDetails, nil
 }
 
 func (bpc *BuildPublishCommand) Run() error {
-	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, bpc.config.DryRun)
+	servicesManager, err := utils.CreateServiceManagerWithContext(bpc.context(), bpc.serverDetails, bpc.config.DryRun, 0, -1, 0, 0)
 	if err != nil {
 		return err
 	}
@@ -205,6 +242,9 @@ func (bpc *BuildPublishCommand) Run() error {
 		return err
 	}
 	bpc.excludeDependenciesByScope(buildInfo)
+	if err = bpc.recordDependencySources(servicesManager, buildInfo); err != nil {
+		return err
+	}
 	if bpc.buildConfiguration.IsLoadedFromConfigFile() {
 		buildInfo.Number, err = bpc.getNextBuildNumber(buildInfo.Name, servicesManager)
 		if errorutils.CheckError(err) != nil {
@@ -259,6 +299,7 @@ func (bpc *BuildPublishCommand) Run() error {
 	if err = recordCommandSummary(buildInfo, buildLink); err != nil {
 		return err
 	}
+	jobsummary.RecordBuildInfo(buildInfo.Name, buildInfo.Number, bpc.buildConfiguration.GetProject(), buildLink)
 
 	// Always store the URL so the CLI layer can access it for --format rendering.
 	bpc.buildInfoUiUrl = buildLink
@@ -407,6 +448,23 @@ func (bpc *BuildPublishCommand) setCIVcsPropsOnArtifacts(
 	log.Debug("CI VCS: Property setting completed")
 }
 
+// recordDependencySources sets the repository which served each dependency in the build-info, and writes the report of the
+// dependency sources, if its path is set.
+func (bpc *BuildPublishCommand) recordDependencySources(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo) error {
+	if !bpc.dependencySources && bpc.dependencySourcesReport == "" {
+		return nil
+	}
+	sources, err := resolveDependencySources(servicesManager, buildInfo)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Recorded the sources of %d dependencies.", len(sources)))
+	if bpc.dependencySourcesReport == "" {
+		return nil
+	}
+	return writeDependencySourcesReport(bpc.dependencySourcesReport, &DependencySourcesReport{BuildName: buildInfo.Name, BuildNumber: buildInfo.Number, Dependencies: sources})
+}
+
 func (bpc *BuildPublishCommand) excludeDependenciesByScope(buildInfo *buildinfo.BuildInfo) {
 	if len(bpc.depExcludeScopes) == 0 {
 		return
@@ -1,7 +1,10 @@
 package buildinfo
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
@@ -12,7 +15,9 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/stretchr/testify/assert"
@@ -142,9 +147,12 @@ func TestPrintBuildInfoLink(t *testing.T) {
 			false,
 			false,
 			nil,
+			false,
+			"",
 			"",
 			false,
 			false,
+			nil,
 			BuildAddGitCommand{},
 		}
 		buildPubComService, err := buildPubConf.getBuildInfoUiUrl(linkTypes[i].majorVersion, linkTypes[i].buildTime)
@@ -532,3 +540,50 @@ func TestHasScopeMatch(t *testing.T) {
 		})
 	}
 }
+
+// The build-info is read from the build dir of the project, and published to the project, which is set by --project, or by the
+// JFROG_CLI_BUILD_PROJECT environment variable.
+func TestBuildPublishProject(t *testing.T) {
+	for _, fromEnv := range []bool{false, true} {
+		t.Run("fromEnv="+strconv.FormatBool(fromEnv), func(t *testing.T) {
+			t.Setenv(coreutils.HomeDir, t.TempDir())
+			var publishedProject, publishedName string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/artifactory/api/build":
+					publishedProject = r.URL.Query().Get("project")
+					publishedBuild := new(buildinfo.BuildInfo)
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(publishedBuild))
+					publishedName = publishedBuild.Name
+					w.WriteHeader(http.StatusNoContent)
+				case r.URL.Path == "/artifactory/api/system/version":
+					_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			// The build dirs are in the persistent temp dir of the process, so each test uses a build number of its own.
+			buildNumber := strconv.FormatBool(fromEnv)
+			require.NoError(t, build.SaveBuildGeneralDetails("project-build", buildNumber, "proj1"))
+			t.Cleanup(func() {
+				assert.NoError(t, build.RemoveBuildDir("project-build", buildNumber, "proj1"))
+			})
+			project := "proj1"
+			if fromEnv {
+				t.Setenv(coreutils.Project, "proj1")
+				project = ""
+			}
+			publishCommand := NewBuildPublishCommand().
+				SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).
+				SetBuildConfiguration(build.NewBuildConfiguration("project-build", buildNumber, "", project)).
+				SetConfig(&biconf.Configuration{}).
+				SetSuppressOutput(true)
+			require.NoError(t, publishCommand.Run())
+			assert.Equal(t, "proj1", publishedProject)
+			assert.Equal(t, "project-build", publishedName)
+			assert.Contains(t, publishCommand.GetBuildInfoUiUrl(), "projectKey=proj1")
+		})
+	}
+}
@@ -40,7 +40,12 @@ func (bc *BuildCommand) Run() error {
 		return err
 	}
 
-	bc.strategy = strategies.CreateStrategy(bc.dockerBuildOptions)
+	// docker buildx build collects the build-info of the images pushed by BuildKit
+	if strategies.IsBuildxBuild(bc.cmdParams) {
+		bc.strategy = strategies.NewBuildxStrategy(bc.dockerBuildOptions)
+	} else {
+		bc.strategy = strategies.CreateStrategy(bc.dockerBuildOptions)
+	}
 
 	// Set server details on the strategy if available
 	if bc.serverDetails != nil {
@@ -0,0 +1,316 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	manifestFileName     = "manifest.json"
+	listManifestFileName = "list.manifest.json"
+	// Artifactory stores the blobs of an image in the folder of its manifest, named by their digest.
+	blobFilePrefix = "sha256__"
+	// Remote repositories store markers for blobs which weren't downloaded yet.
+	blobMarkerSuffix = ".marker"
+	// Manifests, which are referenced by a list manifest or pushed by digest, are stored in a folder named by their digest,
+	// such as sha256__<hex>.
+	digestFolderPrefix = "sha256__"
+
+	GarbageTypeManifest = "manifest"
+	GarbageTypeLayer    = "layer"
+)
+
+// DockerGarbageItem is a manifest, which isn't referenced by any tag, or a layer, which isn't referenced by any manifest.
+type DockerGarbageItem struct {
+	// The path of the manifest folder or of the layer, relative to the repository.
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+type DockerCleanupResult struct {
+	Repository string              `json:"repository"`
+	Items      []DockerGarbageItem `json:"items"`
+	// The storage freed by deleting the items. Identical blobs are stored once, so blobs which are also stored
+	// in folders that are kept aren't counted.
+	ReclaimableBytes int64 `json:"reclaimableBytes"`
+	Deleted          int   `json:"deleted"`
+}
+
+// DockerCleanupCommand finds the manifests and layers of a Docker repository, which aren't referenced by any tag,
+// and optionally deletes them.
+type DockerCleanupCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	imagePath     string
+	delete        bool
+	quiet         bool
+	result        *DockerCleanupResult
+}
+
+func NewDockerCleanupCommand() *DockerCleanupCommand {
+	return &DockerCleanupCommand{}
+}
+
+func (dcc *DockerCleanupCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerCleanupCommand {
+	dcc.serverDetails = serverDetails
+	return dcc
+}
+
+func (dcc *DockerCleanupCommand) SetRepo(repo string) *DockerCleanupCommand {
+	dcc.repo = repo
+	return dcc
+}
+
+// SetImagePath limits the cleanup to the images under the path, such as "team/app".
+func (dcc *DockerCleanupCommand) SetImagePath(imagePath string) *DockerCleanupCommand {
+	dcc.imagePath = strings.Trim(imagePath, "/")
+	return dcc
+}
+
+func (dcc *DockerCleanupCommand) SetDelete(delete bool) *DockerCleanupCommand {
+	dcc.delete = delete
+	return dcc
+}
+
+func (dcc *DockerCleanupCommand) SetQuiet(quiet bool) *DockerCleanupCommand {
+	dcc.quiet = quiet
+	return dcc
+}
+
+func (dcc *DockerCleanupCommand) Result() *DockerCleanupResult {
+	return dcc.result
+}
+
+func (dcc *DockerCleanupCommand) CommandName() string {
+	return "rt_docker_cleanup"
+}
+
+func (dcc *DockerCleanupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dcc.serverDetails, nil
+}
+
+func (dcc *DockerCleanupCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Searching for unreferenced manifests and layers in " + dcc.repo + "...")
+	files, err := artifactoryUtils.NewAqlPager(servicesManager, dcc.createAqlCriteria()).SetInclude(cleanupAqlInclude...).All()
+	if err != nil {
+		return err
+	}
+	readManifest := func(manifestPath string) (manifest []byte, err error) {
+		reader, err := servicesManager.ReadRemoteFile(dcc.repo + "/" + manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if closeErr := reader.Close(); err == nil {
+				err = errorutils.CheckError(closeErr)
+			}
+		}()
+		manifest, err = io.ReadAll(reader)
+		return manifest, errorutils.CheckError(err)
+	}
+	result, garbage := analyzeDockerGarbage(dcc.repo, files, readManifest)
+	dcc.result = result
+	log.Info(fmt.Sprintf("Found %d unreferenced items. Reclaimable storage: %s.", len(result.Items), formatSize(result.ReclaimableBytes)))
+	if !dcc.delete || len(garbage) == 0 {
+		return nil
+	}
+	if !dcc.quiet && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to permanently delete %d unreferenced items from %s?", len(garbage), dcc.repo), false) {
+		return nil
+	}
+	filePath, err := artifactoryUtils.WriteResultItemsToFile(garbage)
+	if err != nil {
+		return err
+	}
+	reader := content.NewContentReader(filePath, content.DefaultKey)
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Warn("Failed to close the reader of the deleted items:", closeErr.Error())
+		}
+	}()
+	result.Deleted, err = servicesManager.DeleteFiles(reader)
+	return err
+}
+
+// The fields of the files of the repository, which are read in pages.
+var cleanupAqlInclude = []string{"repo", "path", "name", "type", "size", "sha256"}
+
+func (dcc *DockerCleanupCommand) createAqlCriteria() string {
+	criteria := fmt.Sprintf(`"repo":%q,"type":"file"`, dcc.repo)
+	if dcc.imagePath != "" {
+		criteria += fmt.Sprintf(`,"$or":[{"path":{"$eq":%q}},{"path":{"$match":%q}}]`, dcc.imagePath, dcc.imagePath+"/*")
+	}
+	return criteria
+}
+
+type imageManifest struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Layers []struct {
+		Digest string `json:"digest"`
+	} `json:"layers"`
+	// Set in list manifests.
+	Manifests []struct {
+		Digest string `json:"digest"`
+	} `json:"manifests"`
+	// Set in the manifests of OCI referrers, such as SBOMs and signatures, which are pushed by digest and refer to an image.
+	Subject *struct {
+		Digest string `json:"digest"`
+	} `json:"subject,omitempty"`
+}
+
+// digestToFolderName returns the name of the folder of a manifest, which is stored by its digest.
+func digestToFolderName(digest string) string {
+	return strings.Replace(digest, ":", "__", 1)
+}
+
+// analyzeDockerGarbage finds the unreferenced manifests and layers among the files of the repository.
+// It returns the result, and the items to delete. Folders whose manifest can't be read are kept.
+func analyzeDockerGarbage(repo string, files []servicesUtils.ResultItem, readManifest func(manifestPath string) ([]byte, error)) (*DockerCleanupResult, []servicesUtils.ResultItem) {
+	folders := make(map[string][]servicesUtils.ResultItem)
+	for _, file := range files {
+		folders[file.Path] = append(folders[file.Path], file)
+	}
+	folderPaths := make([]string, 0, len(folders))
+	for folderPath := range folders {
+		folderPaths = append(folderPaths, folderPath)
+	}
+	sort.Strings(folderPaths)
+
+	// The digests referenced by the list manifests, by the image path.
+	referencedDigests := make(map[string]map[string]bool)
+	// The blobs referenced by the manifest of each folder.
+	referencedBlobs := make(map[string]map[string]bool)
+	unreadable := make(map[string]bool)
+	// The folders of the OCI referrers, which aren't referenced by any tag, but by the referrers API of the image they refer to.
+	referrers := make(map[string]bool)
+	// Images with an unreadable manifest may have list manifests referencing their digest folders, so those are kept.
+	unreadableImages := make(map[string]bool)
+	for _, folderPath := range folderPaths {
+		for _, file := range folders[folderPath] {
+			if file.Name != manifestFileName && file.Name != listManifestFileName {
+				continue
+			}
+			manifestContent, err := readManifest(path.Join(folderPath, file.Name))
+			manifest := new(imageManifest)
+			if err == nil {
+				err = json.Unmarshal(manifestContent, manifest)
+			}
+			if err != nil {
+				log.Warn(fmt.Sprintf("Skipping %s, since its manifest can't be read: %s", folderPath, err.Error()))
+				unreadable[folderPath] = true
+				unreadableImages[path.Dir(folderPath)] = true
+				continue
+			}
+			if referencedBlobs[folderPath] == nil {
+				referencedBlobs[folderPath] = make(map[string]bool)
+			}
+			referencedBlobs[folderPath][manifest.Config.Digest] = true
+			for _, layer := range manifest.Layers {
+				referencedBlobs[folderPath][layer.Digest] = true
+			}
+			if manifest.Subject != nil && manifest.Subject.Digest != "" {
+				referrers[folderPath] = true
+			}
+			imagePath := path.Dir(folderPath)
+			for _, referenced := range manifest.Manifests {
+				if referencedDigests[imagePath] == nil {
+					referencedDigests[imagePath] = make(map[string]bool)
+				}
+				referencedDigests[imagePath][digestToFolderName(referenced.Digest)] = true
+			}
+		}
+	}
+
+	result := &DockerCleanupResult{Repository: repo, Items: []DockerGarbageItem{}}
+	var garbage []servicesUtils.ResultItem
+	keptChecksums := make(map[string]bool)
+	garbageChecksums := make(map[string]int64)
+	for _, folderPath := range folderPaths {
+		folderName := path.Base(folderPath)
+		if unreadable[folderPath] {
+			addChecksums(keptChecksums, folders[folderPath])
+			continue
+		}
+		imagePath := path.Dir(folderPath)
+		if strings.HasPrefix(folderName, digestFolderPrefix) && !referrers[folderPath] && !unreadableImages[imagePath] && !referencedDigests[imagePath][folderName] {
+			var size int64
+			for _, file := range folders[folderPath] {
+				size += file.Size
+				garbageChecksums[file.Sha256] = file.Size
+			}
+			result.Items = append(result.Items, DockerGarbageItem{Path: folderPath, Type: GarbageTypeManifest, Size: size})
+			garbage = append(garbage, servicesUtils.ResultItem{Repo: repo, Path: imagePath, Name: folderName, Type: "folder"})
+			continue
+		}
+		for _, file := range folders[folderPath] {
+			digest, isBlob := strings.CutPrefix(strings.TrimSuffix(file.Name, blobMarkerSuffix), blobFilePrefix)
+			if !isBlob || referencedBlobs[folderPath]["sha256:"+digest] {
+				keptChecksums[file.Sha256] = true
+				continue
+			}
+			garbageChecksums[file.Sha256] = file.Size
+			result.Items = append(result.Items, DockerGarbageItem{Path: path.Join(folderPath, file.Name), Type: GarbageTypeLayer, Size: file.Size})
+			garbage = append(garbage, file)
+		}
+	}
+	for checksum, size := range garbageChecksums {
+		if !keptChecksums[checksum] {
+			result.ReclaimableBytes += size
+		}
+	}
+	return result, garbage
+}
+
+type dockerGarbageRow struct {
+	Path string `col-name:"Path"`
+	Type string `col-name:"Type"`
+	Size string `col-name:"Size"`
+}
+
+// PrintDockerCleanupTable prints the unreferenced items of the result as a table.
+func PrintDockerCleanupTable(result *DockerCleanupResult) error {
+	rows := make([]dockerGarbageRow, 0, len(result.Items))
+	for _, item := range result.Items {
+		rows = append(rows, dockerGarbageRow{Path: item.Path, Type: item.Type, Size: formatSize(item.Size)})
+	}
+	return coreutils.PrintTable(rows, "Unreferenced items in "+result.Repository+" (reclaimable: "+formatSize(result.ReclaimableBytes)+")", "No unreferenced items found", false)
+}
+
+func addChecksums(checksums map[string]bool, files []servicesUtils.ResultItem) {
+	for _, file := range files {
+		checksums[file.Sha256] = true
+	}
+}
+
+// formatSize formats a number of bytes in binary units, e.g. "1.5 MiB".
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
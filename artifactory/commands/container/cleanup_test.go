@@ -0,0 +1,78 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeDockerGarbage(t *testing.T) {
+	files := []servicesUtils.ResultItem{
+		// A tagged image, with a layer left over from a previous push of the tag.
+		{Repo: "docker-local", Path: "app/1.0", Name: manifestFileName, Sha256: "m1", Size: 1},
+		{Repo: "docker-local", Path: "app/1.0", Name: "sha256__c1", Sha256: "c1", Size: 10},
+		{Repo: "docker-local", Path: "app/1.0", Name: "sha256__l1", Sha256: "l1", Size: 100},
+		{Repo: "docker-local", Path: "app/1.0", Name: "sha256__old", Sha256: "old", Size: 1000},
+		// A multi-arch tag, referencing one of the digest folders.
+		{Repo: "docker-local", Path: "app/2.0", Name: listManifestFileName, Sha256: "list", Size: 1},
+		{Repo: "docker-local", Path: "app/sha256__amd64", Name: manifestFileName, Sha256: "m2", Size: 1},
+		{Repo: "docker-local", Path: "app/sha256__amd64", Name: "sha256__l1", Sha256: "l1", Size: 100},
+		// A dangling manifest, sharing a layer with the tagged image.
+		{Repo: "docker-local", Path: "app/sha256__dangling", Name: manifestFileName, Sha256: "m3", Size: 1},
+		{Repo: "docker-local", Path: "app/sha256__dangling", Name: "sha256__l1", Sha256: "l1", Size: 100},
+		{Repo: "docker-local", Path: "app/sha256__dangling", Name: "sha256__l3", Sha256: "l3", Size: 10000},
+		// An upload which wasn't completed.
+		{Repo: "docker-local", Path: "app/_uploads", Name: "sha256__partial.marker", Sha256: "partial", Size: 5},
+		// An image with an unreadable manifest is kept.
+		{Repo: "docker-local", Path: "broken/1.0", Name: manifestFileName, Sha256: "m4", Size: 1},
+		{Repo: "docker-local", Path: "broken/1.0", Name: "sha256__l4", Sha256: "l4", Size: 7},
+		{Repo: "docker-local", Path: "broken/sha256__child", Name: manifestFileName, Sha256: "m5", Size: 1},
+		// An SBOM attached to the tagged image, which is pushed by its digest.
+		{Repo: "docker-local", Path: "app/sha256__sbom", Name: manifestFileName, Sha256: "m6", Size: 1},
+		{Repo: "docker-local", Path: "app/sha256__sbom", Name: "sha256__bom", Sha256: "bom", Size: 50},
+	}
+	manifests := map[string]string{
+		"app/1.0/" + manifestFileName:              `{"config":{"digest":"sha256:c1"},"layers":[{"digest":"sha256:l1"}]}`,
+		"app/2.0/" + listManifestFileName:          `{"manifests":[{"digest":"sha256:amd64"}]}`,
+		"app/sha256__amd64/" + manifestFileName:    `{"layers":[{"digest":"sha256:l1"}]}`,
+		"app/sha256__dangling/" + manifestFileName: `{"layers":[{"digest":"sha256:l1"},{"digest":"sha256:l3"}]}`,
+		"broken/sha256__child/" + manifestFileName: `{}`,
+		"app/sha256__sbom/" + manifestFileName:     `{"config":{"digest":"sha256:empty"},"layers":[{"digest":"sha256:bom"}],"subject":{"digest":"sha256:m1"}}`,
+	}
+	readManifest := func(manifestPath string) ([]byte, error) {
+		manifest, ok := manifests[manifestPath]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return []byte(manifest), nil
+	}
+
+	result, garbage := analyzeDockerGarbage("docker-local", files, readManifest)
+	assert.Equal(t, []DockerGarbageItem{
+		{Path: "app/1.0/sha256__old", Type: GarbageTypeLayer, Size: 1000},
+		{Path: "app/_uploads/sha256__partial.marker", Type: GarbageTypeLayer, Size: 5},
+		{Path: "app/sha256__dangling", Type: GarbageTypeManifest, Size: 10101},
+	}, result.Items)
+	// The shared layer is still stored for the kept images.
+	assert.Equal(t, int64(1000+5+1+10000), result.ReclaimableBytes)
+	assert.Equal(t, []servicesUtils.ResultItem{
+		files[3],
+		files[10],
+		{Repo: "docker-local", Path: "app", Name: "sha256__dangling", Type: "folder"},
+	}, garbage)
+}
+
+func TestDockerCleanupAqlCriteria(t *testing.T) {
+	dcc := NewDockerCleanupCommand().SetRepo("docker-local")
+	assert.Equal(t, `"repo":"docker-local","type":"file"`, dcc.createAqlCriteria())
+	dcc.SetImagePath("/team/app/")
+	assert.Equal(t, `"repo":"docker-local","type":"file","$or":[{"path":{"$eq":"team/app"}},{"path":{"$match":"team/app/*"}}]`, dcc.createAqlCriteria())
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KiB", formatSize(1536))
+	assert.Equal(t, "2.0 GiB", formatSize(2<<30))
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 )
 
-// General utils for docker/podman commands
+// General utils for docker/podman/buildah commands
 type ContainerCommand struct {
 	ContainerCommandBase
 	skipLogin            bool
@@ -0,0 +1,124 @@
+package container
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type DockerCopyResult struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Set when the image is streamed between Artifactory instances.
+	*ociartifact.CopySummary
+}
+
+// DockerCopyCommand copies a Docker image to another repository, and tags it under the target name. Within an Artifactory
+// instance, the image is copied by the server, with the Docker promotion API. When the target repository is in another instance,
+// the missing blobs are streamed from the source to the target through the Docker registry API, without pulling the image locally.
+type DockerCopyCommand struct {
+	serverDetails       *config.ServerDetails
+	targetServerDetails *config.ServerDetails
+	source              string
+	target              string
+	result              *DockerCopyResult
+}
+
+func NewDockerCopyCommand() *DockerCopyCommand {
+	return &DockerCopyCommand{}
+}
+
+func (dcc *DockerCopyCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerCopyCommand {
+	dcc.serverDetails = serverDetails
+	return dcc
+}
+
+// SetTargetServerDetails sets the Artifactory instance of the target repository. If it isn't set, the image is copied within
+// the instance of the source repository.
+func (dcc *DockerCopyCommand) SetTargetServerDetails(targetServerDetails *config.ServerDetails) *DockerCopyCommand {
+	dcc.targetServerDetails = targetServerDetails
+	return dcc
+}
+
+// SetSource sets the image to copy, in the form of <repo>/<image>:<tag> or <repo>/<image>@<digest>.
+func (dcc *DockerCopyCommand) SetSource(source string) *DockerCopyCommand {
+	dcc.source = source
+	return dcc
+}
+
+// SetTarget sets the repository and the tag of the copy, in the form of <repo>/<image>:<tag>.
+func (dcc *DockerCopyCommand) SetTarget(target string) *DockerCopyCommand {
+	dcc.target = target
+	return dcc
+}
+
+func (dcc *DockerCopyCommand) Result() *DockerCopyResult {
+	return dcc.result
+}
+
+func (dcc *DockerCopyCommand) CommandName() string {
+	return "rt_docker_copy"
+}
+
+func (dcc *DockerCopyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dcc.serverDetails, nil
+}
+
+func (dcc *DockerCopyCommand) Run() error {
+	source, err := ociartifact.ParseReference(dcc.source)
+	if err != nil {
+		return err
+	}
+	target, err := ociartifact.ParseReference(dcc.target)
+	if err != nil {
+		return err
+	}
+	if target.Digest != "" {
+		return errorutils.CheckErrorf("the image must be copied to a tag, rather than to the digest of %s", target)
+	}
+	dcc.result = &DockerCopyResult{Source: source.String(), Target: target.String()}
+	if dcc.targetServerDetails == nil {
+		return dcc.copyWithinInstance(source, target)
+	}
+	return dcc.copyBetweenInstances(source, target)
+}
+
+// copyWithinInstance copies the image by promoting it, including the manifests of the platforms of multi-arch images.
+func (dcc *DockerCopyCommand) copyWithinInstance(source, target *ociartifact.Reference) error {
+	if source.Digest != "" {
+		return errorutils.CheckErrorf("the image %s must be copied by a tag within an Artifactory instance", source)
+	}
+	params := services.NewDockerPromoteParams(source.Name, source.Repo, target.Repo)
+	params.SourceTag = source.Tag
+	params.TargetDockerImage = target.Name
+	params.TargetTag = target.Tag
+	params.Copy = true
+	dockerPromoteCommand := NewDockerPromoteCommand().SetParams(params).SetServerDetails(dcc.serverDetails)
+	if err := dockerPromoteCommand.Run(); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Copied %s to %s.", source, target))
+	return nil
+}
+
+func (dcc *DockerCopyCommand) copyBetweenInstances(source, target *ociartifact.Reference) error {
+	sourceManager, err := utils.CreateServiceManager(dcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	targetManager, err := utils.CreateServiceManager(dcc.targetServerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if dcc.result.CopySummary, err = ociartifact.CopyImage(sourceManager, targetManager, source, target); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Copied %s to %s on %s, with the digest %s. Copied %d blobs (%d bytes), and skipped %d blobs the target repository already had.",
+		source, target, dcc.targetServerDetails.ArtifactoryUrl, dcc.result.Digest, dcc.result.CopiedBlobs, dcc.result.CopiedBytes, dcc.result.SkippedBlobs))
+	return nil
+}
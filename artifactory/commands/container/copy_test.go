@@ -0,0 +1,53 @@
+package container
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerCopyWithinInstance(t *testing.T) {
+	var promotion map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case r.URL.Path == "/artifactory/api/docker/docker-dev/v2/promote":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&promotion))
+		case r.URL.Path == "/artifactory/api/search/aql":
+			// The tag isn't of a multi-arch image.
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dcc := NewDockerCopyCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).
+		SetSource("docker-dev/team/app:2.0").SetTarget("docker-prod/app:release")
+	require.NoError(t, dcc.Run())
+	assert.Equal(t, map[string]any{
+		"targetRepo":             "docker-prod",
+		"dockerRepository":       "team/app",
+		"targetDockerRepository": "app",
+		"tag":                    "2.0",
+		"targetTag":              "release",
+		"copy":                   true,
+	}, promotion)
+	assert.Equal(t, &DockerCopyResult{Source: "docker-dev/team/app:2.0", Target: "docker-prod/app:release"}, dcc.Result())
+}
+
+func TestDockerCopyInvalidReferences(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"}
+	err := NewDockerCopyCommand().SetServerDetails(serverDetails).SetSource("docker-dev/app:2.0").SetTarget("docker-prod/app@sha256:abc").Run()
+	assert.ErrorContains(t, err, "must be copied to a tag")
+	err = NewDockerCopyCommand().SetServerDetails(serverDetails).SetSource("docker-dev/app@sha256:abc").SetTarget("docker-prod/app:2.0").Run()
+	assert.ErrorContains(t, err, "must be copied by a tag within an Artifactory instance")
+	err = NewDockerCopyCommand().SetServerDetails(serverDetails).SetSource("app").SetTarget("docker-prod/app:2.0").Run()
+	assert.ErrorContains(t, err, "must be in the form of")
+}
@@ -1,9 +1,21 @@
 package container
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type DockerPromoteCommand struct {
@@ -22,7 +34,128 @@ func (dp *DockerPromoteCommand) Run() error {
 		return err
 	}
 	// Promote docker
-	return servicesManager.PromoteDocker(dp.params)
+	if err = servicesManager.PromoteDocker(dp.params); err != nil {
+		return err
+	}
+	return dp.promotePlatformManifests(servicesManager)
+}
+
+// promotePlatformManifests promotes the manifests of the platforms of a multi-arch tag. The list manifest of the tag is promoted
+// with the tag folder, but the manifests it references are stored in folders named by their digest, next to the tag folders.
+// The digest folders which weren't promoted with the tag are copied or moved to the target repository too, so that the promoted
+// list manifest doesn't reference missing manifests.
+func (dp *DockerPromoteCommand) promotePlatformManifests(servicesManager artifactory.ArtifactoryServicesManager) error {
+	if dp.params.SourceTag == "" {
+		// The entire docker repository, including the digest folders, is promoted.
+		return nil
+	}
+	sourceImage := strings.Trim(dp.params.SourceDockerImage, "/")
+	targetImage, targetTag := sourceImage, dp.params.SourceTag
+	if dp.params.TargetDockerImage != "" {
+		targetImage = strings.Trim(dp.params.TargetDockerImage, "/")
+	}
+	if dp.params.TargetTag != "" {
+		targetTag = dp.params.TargetTag
+	}
+	listManifests, err := artifactoryUtils.NewAqlPager(servicesManager, fmt.Sprintf(`"repo":%q,"path":%q,"name":%q`,
+		dp.params.TargetRepo, path.Join(targetImage, targetTag), listManifestFileName)).All()
+	if err != nil || len(listManifests) == 0 {
+		return err
+	}
+	digests, err := readListManifestDigests(servicesManager, path.Join(dp.params.TargetRepo, targetImage, targetTag, listManifestFileName))
+	if err != nil {
+		return err
+	}
+	sourceFolders, err := findDigestFolders(servicesManager, dp.params.SourceRepo, sourceImage, digests)
+	if err != nil {
+		return err
+	}
+	targetFolders, err := findDigestFolders(servicesManager, dp.params.TargetRepo, targetImage, digests)
+	if err != nil {
+		return err
+	}
+	promoted := 0
+	for _, digest := range digests {
+		if targetFolders[digest] {
+			continue
+		}
+		if !sourceFolders[digest] {
+			log.Warn(fmt.Sprintf("The manifest %s, referenced by the list manifest of %s:%s, wasn't found in the %s repository.", digest, targetImage, targetTag, dp.params.SourceRepo))
+			continue
+		}
+		if err = dp.promoteFolder(servicesManager, path.Join(sourceImage, digest), path.Join(targetImage, digest)); err != nil {
+			return err
+		}
+		promoted++
+	}
+	if promoted > 0 {
+		log.Info(fmt.Sprintf("Promoted the manifests of %d platforms of %s to the %s repository.", promoted, targetImage+":"+targetTag, dp.params.TargetRepo))
+	}
+	return nil
+}
+
+// readListManifestDigests returns the digests of the manifests referenced by a list manifest.
+func readListManifestDigests(servicesManager artifactory.ArtifactoryServicesManager, listManifestPath string) (digests []string, err error) {
+	reader, err := servicesManager.ReadRemoteFile(listManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var listManifest imageManifest
+	if err = json.Unmarshal(content, &listManifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the list manifest %s: %s", listManifestPath, err.Error())
+	}
+	for _, manifest := range listManifest.Manifests {
+		digests = append(digests, manifest.Digest)
+	}
+	return digests, nil
+}
+
+// findDigestFolders returns the digests whose folders exist under the image path of the repository.
+func findDigestFolders(servicesManager artifactory.ArtifactoryServicesManager, repo, imagePath string, digests []string) (map[string]bool, error) {
+	names := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		names = append(names, fmt.Sprintf(`{"name":%q}`, digest))
+	}
+	folders, err := artifactoryUtils.NewAqlPager(servicesManager, fmt.Sprintf(`"repo":%q,"path":%q,"type":"folder","$or":[%s]`,
+		repo, imagePath, strings.Join(names, ","))).All()
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(folders))
+	for _, folder := range folders {
+		found[folder.Name] = true
+	}
+	return found, nil
+}
+
+// promoteFolder copies or moves a folder of the source repository to the target repository.
+func (dp *DockerPromoteCommand) promoteFolder(servicesManager artifactory.ArtifactoryServicesManager, sourcePath, targetPath string) error {
+	operation := "move"
+	if dp.params.Copy {
+		operation = "copy"
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestUrl, err := clientUtils.BuildUrl(serviceDetails.GetUrl(), path.Join("api", operation, dp.params.SourceRepo, sourcePath),
+		map[string]string{"to": "/" + path.Join(dp.params.TargetRepo, targetPath)})
+	if err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Promoting %s/%s to %s/%s", dp.params.SourceRepo, sourcePath, dp.params.TargetRepo, targetPath))
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
 }
 
 func (dp *DockerPromoteCommand) CommandName() string {
@@ -0,0 +1,55 @@
+package container
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerPromoteMultiArchImage(t *testing.T) {
+	var mutex sync.Mutex
+	var promotedFolders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case r.URL.Path == "/artifactory/api/docker/docker-dev/v2/promote":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/artifactory/api/search/aql":
+			query, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			switch {
+			case strings.Contains(string(query), `"name":"list.manifest.json"`):
+				_, _ = w.Write([]byte(`{"results":[{"repo":"docker-prod","path":"app/2.0","name":"list.manifest.json"}]}`))
+			case strings.Contains(string(query), `"repo":"docker-dev"`):
+				_, _ = w.Write([]byte(`{"results":[{"repo":"docker-dev","path":"app","name":"sha256:amd64","type":"folder"},{"repo":"docker-dev","path":"app","name":"sha256:arm64","type":"folder"}]}`))
+			default:
+				// The manifest of amd64 was already promoted with the tag.
+				_, _ = w.Write([]byte(`{"results":[{"repo":"docker-prod","path":"app","name":"sha256:amd64","type":"folder"}]}`))
+			}
+		case r.URL.Path == "/artifactory/docker-prod/app/2.0/list.manifest.json":
+			_, _ = w.Write([]byte(`{"manifests":[{"digest":"sha256:amd64"},{"digest":"sha256:arm64"},{"digest":"sha256:missing"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/artifactory/api/move/"):
+			mutex.Lock()
+			promotedFolders = append(promotedFolders, strings.TrimPrefix(r.URL.Path, "/artifactory/api/move/")+" "+r.URL.Query().Get("to"))
+			mutex.Unlock()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	params := services.NewDockerPromoteParams("app", "docker-dev", "docker-prod")
+	params.SourceTag = "2.0"
+	dp := NewDockerPromoteCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).SetParams(params)
+	require.NoError(t, dp.Run())
+	assert.Equal(t, []string{"docker-dev/app/sha256:arm64 /docker-prod/app/sha256:arm64"}, promotedFolders)
+}
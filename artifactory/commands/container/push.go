@@ -1,10 +1,14 @@
 package container
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
 	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -14,6 +18,7 @@ import (
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -22,8 +27,16 @@ type PushCommand struct {
 	threads         int
 	detailedSummary bool
 	result          *commandsutils.Result
+	// The digest of the pushed manifest, as written by the container manager.
+	manifestDigest string
+	sbomFile       string
+	generateSbom   bool
+	sbom           []byte
+	sbomReferrer   *ociartifact.Referrer
 }
 
+const digestFileOption = "--digestfile"
+
 func NewPushCommand(containerManagerType containerutils.ContainerManagerType) *PushCommand {
 	return &PushCommand{
 		ContainerCommand: ContainerCommand{
@@ -63,6 +76,11 @@ func (pc *PushCommand) Result() *commandsutils.Result {
 	return pc.result
 }
 
+// ManifestDigest returns the digest of the pushed manifest, if the container manager writes it. See SupportsDigestFile.
+func (pc *PushCommand) ManifestDigest() string {
+	return pc.manifestDigest
+}
+
 func (pc *PushCommand) SetResult(result *commandsutils.Result) *PushCommand {
 	pc.result = result
 	return pc
@@ -72,6 +90,9 @@ func (pc *PushCommand) Run() error {
 	if err := pc.init(); err != nil {
 		return err
 	}
+	if err := pc.readSbomFile(); err != nil {
+		return err
+	}
 	if pc.containerManagerType == containerutils.DockerClient {
 		err := containerutils.ValidateClientApiVersion()
 		if err != nil {
@@ -88,8 +109,10 @@ func (pc *PushCommand) Run() error {
 	}
 	// Perform push.
 	cm := containerutils.NewManager(pc.containerManagerType)
-	err = cm.RunNativeCmd(pc.cmdParams)
-	if err != nil {
+	if err = pc.push(cm); err != nil {
+		return err
+	}
+	if err = pc.attachSbom(serverDetails); err != nil {
 		return err
 	}
 
@@ -117,8 +140,8 @@ func (pc *PushCommand) Run() error {
 		if err != nil {
 			return err
 		}
-		var imageSha256 string
-		if pc.IsValidateSha() {
+		imageSha256 := pc.manifestDigest
+		if imageSha256 == "" && pc.IsValidateSha() {
 			log.Info("Performing SHA-based validation for Docker push...")
 			imageSha256, err = cm.Id(pc.image, containerutils.Push)
 			if err != nil {
@@ -141,13 +164,16 @@ func (pc *PushCommand) Run() error {
 		return err
 	}
 
-	// If SHA validation is enabled, log it
-	if pc.IsValidateSha() {
-		log.Info("Performing SHA-based validation for Docker push...")
-		// Get image SHA from the container manager
-		imageSha256, err := cm.Id(pc.image, containerutils.Push)
-		if err != nil {
-			return err
+	// If SHA validation is enabled, or the digest of the pushed manifest is known, the image is found by its SHA.
+	if pc.IsValidateSha() || pc.manifestDigest != "" {
+		imageSha256 := pc.manifestDigest
+		if imageSha256 == "" {
+			log.Info("Performing SHA-based validation for Docker push...")
+			// Get image SHA from the container manager
+			imageSha256, err = cm.Id(pc.image, containerutils.Push)
+			if err != nil {
+				return err
+			}
 		}
 		log.Debug("Using image SHA256 for validation: " + imageSha256)
 
@@ -168,6 +194,7 @@ func (pc *PushCommand) Run() error {
 			if buildInfoModule == nil {
 				return errorutils.CheckError(fmt.Errorf("failed to create build info module: module is nil"))
 			}
+			pc.addSbomToBuildInfo(buildInfoModule)
 			if err = build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), buildInfoModule); err != nil {
 				return errorutils.CheckError(fmt.Errorf("failed to save build info: %w", err))
 			}
@@ -199,6 +226,7 @@ func (pc *PushCommand) Run() error {
 		if err != nil || buildInfoModule == nil {
 			return err
 		}
+		pc.addSbomToBuildInfo(buildInfoModule)
 		if err = build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), buildInfoModule); err != nil {
 			return err
 		}
@@ -220,6 +248,50 @@ func (pc *PushCommand) Run() error {
 	return nil
 }
 
+// push runs the push command of the container manager. If the container manager supports it, the digest of the pushed
+// manifest is read from the file written by the --digestfile option, so that the image is identified in Artifactory by it,
+// without reading the local image.
+func (pc *PushCommand) push(cm containerutils.ContainerManager) (err error) {
+	if !pc.containerManagerType.SupportsDigestFile() {
+		return cm.RunNativeCmd(pc.cmdParams)
+	}
+	cmdParams, digestFile := pc.cmdParams, getDigestFile(pc.cmdParams)
+	if digestFile == "" {
+		var tempDir string
+		if tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+		}()
+		digestFile = filepath.Join(tempDir, "digest")
+		cmdParams = append([]string{cmdParams[0], digestFileOption, digestFile}, cmdParams[1:]...)
+	}
+	if err = cm.RunNativeCmd(cmdParams); err != nil {
+		return err
+	}
+	digest, err := os.ReadFile(digestFile)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read the digest of the pushed image from %s: %s", digestFile, err.Error())
+	}
+	pc.manifestDigest = strings.TrimSpace(string(digest))
+	log.Debug("The digest of the pushed manifest is " + pc.manifestDigest)
+	return nil
+}
+
+// getDigestFile returns the file set by the --digestfile option of the push command parameters, if they set it.
+func getDigestFile(cmdParams []string) string {
+	for i, param := range cmdParams {
+		if param == digestFileOption && i+1 < len(cmdParams) {
+			return cmdParams[i+1]
+		}
+		if value, found := strings.CutPrefix(param, digestFileOption+"="); found {
+			return value
+		}
+	}
+	return ""
+}
+
 func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, layers *[]servicesutils.ResultItem) error {
 	var details []clientutils.FileTransferDetails
 	for _, layer := range *layers {
@@ -0,0 +1,68 @@
+package container
+
+import (
+	"os"
+	"testing"
+
+	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// digestWritingManager is a container manager, which writes the digest file set by the push command parameters.
+type digestWritingManager struct {
+	containerutils.ContainerManager
+	digest    string
+	cmdParams []string
+}
+
+func (dwm *digestWritingManager) RunNativeCmd(cmdParams []string) error {
+	dwm.cmdParams = cmdParams
+	if digestFile := getDigestFile(cmdParams); digestFile != "" {
+		return os.WriteFile(digestFile, []byte(dwm.digest+"\n"), 0600)
+	}
+	return nil
+}
+
+func TestGetDigestFile(t *testing.T) {
+	assert.Equal(t, "", getDigestFile([]string{"push", "acme.jfrog.io/docker-local/app:1.0"}))
+	assert.Equal(t, "/tmp/digest", getDigestFile([]string{"push", "--digestfile", "/tmp/digest", "acme.jfrog.io/docker-local/app:1.0"}))
+	assert.Equal(t, "/tmp/digest", getDigestFile([]string{"push", "--digestfile=/tmp/digest", "acme.jfrog.io/docker-local/app:1.0"}))
+}
+
+func TestPushCapturesDigest(t *testing.T) {
+	for _, containerManagerType := range []containerutils.ContainerManagerType{containerutils.Podman, containerutils.Buildah} {
+		t.Run(containerManagerType.String(), func(t *testing.T) {
+			cm := &digestWritingManager{digest: "sha256:0123abcd"}
+			pushCommand := NewPushCommand(containerManagerType)
+			pushCommand.SetCmdParams([]string{"push", "acme.jfrog.io/docker-local/app:1.0"})
+			require.NoError(t, pushCommand.push(cm))
+			assert.Equal(t, "sha256:0123abcd", pushCommand.ManifestDigest())
+			require.Len(t, cm.cmdParams, 4)
+			assert.Equal(t, []string{"push", "--digestfile"}, cm.cmdParams[:2])
+			assert.Equal(t, "acme.jfrog.io/docker-local/app:1.0", cm.cmdParams[3])
+			// The temporary digest file is removed.
+			assert.NoFileExists(t, cm.cmdParams[2])
+		})
+	}
+}
+
+func TestPushKeepsDigestFileOfParams(t *testing.T) {
+	digestFile := t.TempDir() + "/digest"
+	cm := &digestWritingManager{digest: "sha256:0123abcd"}
+	pushCommand := NewPushCommand(containerutils.Podman)
+	pushCommand.SetCmdParams([]string{"push", "--digestfile", digestFile, "acme.jfrog.io/docker-local/app:1.0"})
+	require.NoError(t, pushCommand.push(cm))
+	assert.Equal(t, "sha256:0123abcd", pushCommand.ManifestDigest())
+	assert.Len(t, cm.cmdParams, 4)
+	assert.FileExists(t, digestFile)
+}
+
+func TestPushDockerWithoutDigest(t *testing.T) {
+	cm := &digestWritingManager{}
+	pushCommand := NewPushCommand(containerutils.DockerClient)
+	pushCommand.SetCmdParams([]string{"push", "acme.jfrog.io/docker-local/app:1.0"})
+	require.NoError(t, pushCommand.push(cm))
+	assert.Empty(t, pushCommand.ManifestDigest())
+	assert.Equal(t, []string{"push", "acme.jfrog.io/docker-local/app:1.0"}, cm.cmdParams)
+}
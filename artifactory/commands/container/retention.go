@@ -0,0 +1,288 @@
+package container
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Release tags, such as 1.2.3 or v1.2.3. Pre-release and build metadata tags, such as 1.2.3-rc.1, aren't release tags.
+var semverReleaseTagRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)$`)
+
+const (
+	TagActionKeep   = "keep"
+	TagActionDelete = "delete"
+)
+
+// TagRetentionRules selects the tags of each image to delete.
+// The KeepLast most recently pushed tags of each image are kept. Of the other tags, the ones pushed before OlderThan are deleted,
+// or all of them if OlderThan is zero. Release tags are never deleted when ProtectReleases is set.
+type TagRetentionRules struct {
+	KeepLast        int
+	OlderThan       time.Duration
+	ProtectReleases bool
+}
+
+func (rules TagRetentionRules) validate() error {
+	if rules.KeepLast < 0 {
+		return errorutils.CheckErrorf("the number of tags to keep must not be negative")
+	}
+	if rules.OlderThan < 0 {
+		return errorutils.CheckErrorf("the age of the tags to delete must not be negative")
+	}
+	if rules.KeepLast == 0 && rules.OlderThan == 0 {
+		return errorutils.CheckErrorf("at least one of the rules keeping the last tags or deleting the tags older than an age must be set")
+	}
+	return nil
+}
+
+type TagRetentionItem struct {
+	Image  string `json:"image"`
+	Tag    string `json:"tag"`
+	Pushed string `json:"pushed"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+type TagRetentionResult struct {
+	Repository string             `json:"repository"`
+	DryRun     bool               `json:"dryRun"`
+	Tags       []TagRetentionItem `json:"tags"`
+	Deleted    int                `json:"deleted"`
+}
+
+// DockerTagRetentionCommand applies tag retention rules to the images of a Docker repository.
+// The tags to delete are computed from the current state of the repository, so running the command repeatedly
+// with the same rules, for example on a schedule, deletes nothing once the repository complies with them.
+type DockerTagRetentionCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	imagePath     string
+	rules         TagRetentionRules
+	dryRun        bool
+	quiet         bool
+	result        *TagRetentionResult
+}
+
+func NewDockerTagRetentionCommand() *DockerTagRetentionCommand {
+	return &DockerTagRetentionCommand{}
+}
+
+func (dtrc *DockerTagRetentionCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerTagRetentionCommand {
+	dtrc.serverDetails = serverDetails
+	return dtrc
+}
+
+func (dtrc *DockerTagRetentionCommand) SetRepo(repo string) *DockerTagRetentionCommand {
+	dtrc.repo = repo
+	return dtrc
+}
+
+// SetImagePath limits the retention to the images under the path, such as "team/app".
+func (dtrc *DockerTagRetentionCommand) SetImagePath(imagePath string) *DockerTagRetentionCommand {
+	dtrc.imagePath = strings.Trim(imagePath, "/")
+	return dtrc
+}
+
+func (dtrc *DockerTagRetentionCommand) SetRules(rules TagRetentionRules) *DockerTagRetentionCommand {
+	dtrc.rules = rules
+	return dtrc
+}
+
+func (dtrc *DockerTagRetentionCommand) SetDryRun(dryRun bool) *DockerTagRetentionCommand {
+	dtrc.dryRun = dryRun
+	return dtrc
+}
+
+func (dtrc *DockerTagRetentionCommand) SetQuiet(quiet bool) *DockerTagRetentionCommand {
+	dtrc.quiet = quiet
+	return dtrc
+}
+
+func (dtrc *DockerTagRetentionCommand) Result() *TagRetentionResult {
+	return dtrc.result
+}
+
+func (dtrc *DockerTagRetentionCommand) CommandName() string {
+	return "rt_docker_tag_retention"
+}
+
+func (dtrc *DockerTagRetentionCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dtrc.serverDetails, nil
+}
+
+func (dtrc *DockerTagRetentionCommand) Run() error {
+	if err := dtrc.rules.validate(); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(dtrc.serverDetails, -1, 0, dtrc.dryRun)
+	if err != nil {
+		return err
+	}
+	manifests, err := artifactoryUtils.NewAqlPager(servicesManager, dtrc.createAqlCriteria()).SetInclude(retentionAqlInclude...).All()
+	if err != nil {
+		return err
+	}
+	items, toDelete, err := applyTagRetentionRules(manifests, dtrc.rules, time.Now())
+	if err != nil {
+		return err
+	}
+	dtrc.result = &TagRetentionResult{Repository: dtrc.repo, DryRun: dtrc.dryRun, Tags: items}
+	log.Info(fmt.Sprintf("%d of %d tags match the retention rules for deletion.", len(toDelete), len(items)))
+	if dtrc.dryRun || len(toDelete) == 0 {
+		return nil
+	}
+	if !dtrc.quiet && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to permanently delete %d tags from %s?", len(toDelete), dtrc.repo), false) {
+		return nil
+	}
+	filePath, err := artifactoryUtils.WriteResultItemsToFile(toDelete)
+	if err != nil {
+		return err
+	}
+	reader := content.NewContentReader(filePath, content.DefaultKey)
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Warn("Failed to close the reader of the deleted tags:", closeErr.Error())
+		}
+	}()
+	dtrc.result.Deleted, err = servicesManager.DeleteFiles(reader)
+	return err
+}
+
+// The fields of the manifests of the tags, which are read in pages.
+var retentionAqlInclude = []string{"repo", "path", "name", "created", "modified"}
+
+// createAqlCriteria finds the manifests of the tags. The manifests of the digest folders
+// are referenced by list manifests, and aren't tags.
+func (dtrc *DockerTagRetentionCommand) createAqlCriteria() string {
+	criteria := fmt.Sprintf(`"repo":%q,"type":"file","$or":[{"name":%q},{"name":%q}],"path":{"$nmatch":"*/%s*"}`,
+		dtrc.repo, manifestFileName, listManifestFileName, digestFolderPrefix)
+	if dtrc.imagePath != "" {
+		criteria += fmt.Sprintf(`,"$and":[{"path":{"$match":%q}}]`, dtrc.imagePath+"/*")
+	}
+	return criteria
+}
+
+type imageTag struct {
+	name   string
+	pushed time.Time
+	folder servicesUtils.ResultItem
+}
+
+// applyTagRetentionRules decides the action of each tag, by the manifests of the tags.
+// Returns the tags sorted by image and by push time, most recent first, and the tag folders to delete.
+func applyTagRetentionRules(manifests []servicesUtils.ResultItem, rules TagRetentionRules, now time.Time) ([]TagRetentionItem, []servicesUtils.ResultItem, error) {
+	images := make(map[string][]imageTag)
+	for _, manifest := range manifests {
+		imagePath, tagName := path.Split(manifest.Path)
+		imagePath = strings.TrimSuffix(imagePath, "/")
+		if imagePath == "" || strings.HasPrefix(tagName, digestFolderPrefix) {
+			continue
+		}
+		pushed := manifest.Modified
+		if pushed == "" {
+			pushed = manifest.Created
+		}
+		pushedTime, err := time.Parse(time.RFC3339, pushed)
+		if err != nil {
+			return nil, nil, errorutils.CheckErrorf("failed to parse the push time of %s/%s: %s", manifest.Path, manifest.Name, err.Error())
+		}
+		images[imagePath] = append(images[imagePath], imageTag{
+			name:   tagName,
+			pushed: pushedTime,
+			folder: servicesUtils.ResultItem{Repo: manifest.Repo, Path: imagePath, Name: tagName, Type: "folder"},
+		})
+	}
+	imagePaths := make([]string, 0, len(images))
+	for imagePath := range images {
+		imagePaths = append(imagePaths, imagePath)
+	}
+	sort.Strings(imagePaths)
+
+	items := []TagRetentionItem{}
+	var toDelete []servicesUtils.ResultItem
+	cutoff := now.Add(-rules.OlderThan)
+	for _, imagePath := range imagePaths {
+		tags := images[imagePath]
+		// The tag name breaks ties, so that the result doesn't depend on the order of the search results.
+		sort.Slice(tags, func(i, j int) bool {
+			if !tags[i].pushed.Equal(tags[j].pushed) {
+				return tags[i].pushed.After(tags[j].pushed)
+			}
+			return tags[i].name < tags[j].name
+		})
+		for i, tag := range tags {
+			action, reason := TagActionDelete, ""
+			switch {
+			case i < rules.KeepLast:
+				action, reason = TagActionKeep, "one of the last "+strconv.Itoa(rules.KeepLast)+" tags"
+			case rules.ProtectReleases && semverReleaseTagRegex.MatchString(tag.name):
+				action, reason = TagActionKeep, "release tag"
+			case rules.OlderThan > 0 && tag.pushed.After(cutoff):
+				action, reason = TagActionKeep, "newer than "+rules.OlderThan.String()
+			case rules.OlderThan > 0:
+				reason = "older than " + rules.OlderThan.String()
+			default:
+				reason = "not one of the last " + strconv.Itoa(rules.KeepLast) + " tags"
+			}
+			items = append(items, TagRetentionItem{Image: imagePath, Tag: tag.name, Pushed: tag.pushed.UTC().Format(time.RFC3339), Action: action, Reason: reason})
+			if action == TagActionDelete {
+				toDelete = append(toDelete, tag.folder)
+			}
+		}
+	}
+	return items, toDelete, nil
+}
+
+// ParseRetentionAge parses an age such as "30d", "2w" or "12h". The units d (days) and w (weeks) are supported,
+// in addition to the units of time.ParseDuration.
+func ParseRetentionAge(age string) (time.Duration, error) {
+	for unit, unitDuration := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, found := strings.CutSuffix(age, unit); found {
+			count, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, errorutils.CheckErrorf("invalid age '%s'. Expected a number followed by a unit, such as 30d", age)
+			}
+			return time.Duration(count) * unitDuration, nil
+		}
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("invalid age '%s'. Expected a number followed by a unit, such as 30d", age)
+	}
+	return duration, nil
+}
+
+type tagRetentionRow struct {
+	Image  string `col-name:"Image"`
+	Tag    string `col-name:"Tag"`
+	Pushed string `col-name:"Pushed"`
+	Action string `col-name:"Action"`
+	Reason string `col-name:"Reason"`
+}
+
+// PrintTagRetentionTable prints the tags of the result and their actions as a table.
+func PrintTagRetentionTable(result *TagRetentionResult) error {
+	rows := make([]tagRetentionRow, 0, len(result.Tags))
+	for _, tag := range result.Tags {
+		rows = append(rows, tagRetentionRow(tag))
+	}
+	title := "Tags of " + result.Repository
+	if result.DryRun {
+		title += " (dry run)"
+	}
+	return coreutils.PrintTable(rows, title, "No tags found", false)
+}
@@ -0,0 +1,71 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTagRetentionRules(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	manifest := func(tagPath, pushed string) servicesUtils.ResultItem {
+		return servicesUtils.ResultItem{Repo: "docker-local", Path: tagPath, Name: manifestFileName, Modified: pushed}
+	}
+	manifests := []servicesUtils.ResultItem{
+		manifest("app/1.0.0", "2024-01-01T00:00:00.000Z"),
+		manifest("app/1.1.0-rc.1", "2024-02-01T00:00:00.000Z"),
+		manifest("app/dev", "2024-06-29T00:00:00.000Z"),
+		manifest("app/latest", "2024-06-29T12:00:00.000+02:00"),
+		manifest("app/feature", "2024-03-01T00:00:00.000Z"),
+		manifest("team/tool/v2.0.0", "2024-01-01T00:00:00.000Z"),
+		// Manifests of the digest folders aren't tags.
+		manifest("app/sha256__abc", "2024-01-01T00:00:00.000Z"),
+	}
+
+	t.Run("keep last and protect releases", func(t *testing.T) {
+		items, toDelete, err := applyTagRetentionRules(manifests, TagRetentionRules{KeepLast: 2, ProtectReleases: true}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []TagRetentionItem{
+			{Image: "app", Tag: "latest", Pushed: "2024-06-29T10:00:00Z", Action: TagActionKeep, Reason: "one of the last 2 tags"},
+			{Image: "app", Tag: "dev", Pushed: "2024-06-29T00:00:00Z", Action: TagActionKeep, Reason: "one of the last 2 tags"},
+			{Image: "app", Tag: "feature", Pushed: "2024-03-01T00:00:00Z", Action: TagActionDelete, Reason: "not one of the last 2 tags"},
+			{Image: "app", Tag: "1.1.0-rc.1", Pushed: "2024-02-01T00:00:00Z", Action: TagActionDelete, Reason: "not one of the last 2 tags"},
+			{Image: "app", Tag: "1.0.0", Pushed: "2024-01-01T00:00:00Z", Action: TagActionKeep, Reason: "release tag"},
+			{Image: "team/tool", Tag: "v2.0.0", Pushed: "2024-01-01T00:00:00Z", Action: TagActionKeep, Reason: "one of the last 2 tags"},
+		}, items)
+		assert.Equal(t, []servicesUtils.ResultItem{
+			{Repo: "docker-local", Path: "app", Name: "feature", Type: "folder"},
+			{Repo: "docker-local", Path: "app", Name: "1.1.0-rc.1", Type: "folder"},
+		}, toDelete)
+	})
+
+	t.Run("older than", func(t *testing.T) {
+		_, toDelete, err := applyTagRetentionRules(manifests, TagRetentionRules{KeepLast: 1, OlderThan: 130 * 24 * time.Hour}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []servicesUtils.ResultItem{
+			{Repo: "docker-local", Path: "app", Name: "1.1.0-rc.1", Type: "folder"},
+			{Repo: "docker-local", Path: "app", Name: "1.0.0", Type: "folder"},
+		}, toDelete)
+	})
+}
+
+func TestTagRetentionRulesValidate(t *testing.T) {
+	assert.Error(t, TagRetentionRules{}.validate())
+	assert.Error(t, TagRetentionRules{KeepLast: -1}.validate())
+	assert.NoError(t, TagRetentionRules{OlderThan: time.Hour}.validate())
+}
+
+func TestParseRetentionAge(t *testing.T) {
+	for age, expected := range map[string]time.Duration{"30d": 30 * 24 * time.Hour, "2w": 14 * 24 * time.Hour, "12h": 12 * time.Hour} {
+		duration, err := ParseRetentionAge(age)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, duration, age)
+	}
+	_, err := ParseRetentionAge("d")
+	assert.Error(t, err)
+	_, err = ParseRetentionAge("month")
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The tool which generates the SBOMs of the images, when they aren't provided.
+const sbomGenerator = "syft"
+
+// SetSbomFile sets a CycloneDX SBOM of the image, in JSON, which is attached to the image after it's pushed.
+func (pc *PushCommand) SetSbomFile(sbomFile string) *PushCommand {
+	pc.sbomFile = sbomFile
+	return pc
+}
+
+// SetGenerateSbom sets whether to generate a CycloneDX SBOM of the image with Syft, and to attach it to the image after it's pushed.
+func (pc *PushCommand) SetGenerateSbom(generateSbom bool) *PushCommand {
+	pc.generateSbom = generateSbom
+	return pc
+}
+
+// SbomReferrer returns the SBOM attached to the pushed image, if one was attached.
+func (pc *PushCommand) SbomReferrer() *ociartifact.Referrer {
+	return pc.sbomReferrer
+}
+
+// readSbomFile reads and validates the provided SBOM before the image is pushed, so that an invalid SBOM fails the push early.
+func (pc *PushCommand) readSbomFile() (err error) {
+	if pc.sbomFile != "" && pc.generateSbom {
+		return errorutils.CheckErrorf("the SBOM of the image can either be provided or generated, but not both")
+	}
+	if pc.sbomFile == "" {
+		return nil
+	}
+	if pc.sbom, err = os.ReadFile(pc.sbomFile); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return validateCycloneDxSbom(pc.sbomFile, pc.sbom)
+}
+
+// attachSbom attaches the SBOM to the pushed image, as an artifact which refers to the manifest of the image by the OCI
+// referrers API of the repository, so that the SBOM is listed by the referrers of the image.
+func (pc *PushCommand) attachSbom(serverDetails *config.ServerDetails) (err error) {
+	if pc.sbomFile == "" && !pc.generateSbom {
+		return nil
+	}
+	if pc.generateSbom {
+		if pc.sbom, err = generateSbom(pc.image.Name()); err != nil {
+			return err
+		}
+	}
+	repo, err := pc.GetRepo()
+	if err != nil {
+		return err
+	}
+	subject, err := getReferrerSubject(pc.image, repo)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	annotations := map[string]string{"org.opencontainers.image.created": time.Now().UTC().Format(time.RFC3339)}
+	if pc.sbomReferrer, err = ociartifact.AttachReferrer(serviceManager, subject, ociartifact.CycloneDxMediaType, ociartifact.CycloneDxMediaType, pc.sbom, annotations); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Attached the SBOM to %s, with the digest %s.", pc.sbomReferrer.Subject, pc.sbomReferrer.Digest))
+	return nil
+}
+
+// generateSbom generates a CycloneDX SBOM of the image with Syft, which reads the image from the local daemon or from the registry.
+func generateSbom(image string) ([]byte, error) {
+	if _, err := exec.LookPath(sbomGenerator); err != nil {
+		return nil, errorutils.CheckErrorf("generating the SBOM of the image requires %s, which wasn't found in the PATH. Install it, or provide the SBOM with the --sbom option instead", sbomGenerator)
+	}
+	log.Info(fmt.Sprintf("Generating the SBOM of %s with %s...", image, sbomGenerator))
+	var stdout, stderr bytes.Buffer
+	command := exec.Command(sbomGenerator, image, "--output", "cyclonedx-json", "--quiet")
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err := command.Run(); err != nil {
+		return nil, errorutils.CheckErrorf("failed to generate the SBOM of %s: %s %s", image, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), validateCycloneDxSbom(sbomGenerator+" output", stdout.Bytes())
+}
+
+func validateCycloneDxSbom(source string, sbom []byte) error {
+	var document struct {
+		BomFormat string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(sbom, &document); err != nil {
+		return errorutils.CheckErrorf("failed to parse the SBOM of %s: %s", source, err.Error())
+	}
+	if document.BomFormat != "CycloneDX" {
+		return errorutils.CheckErrorf("the SBOM of %s must be a CycloneDX SBOM in JSON", source)
+	}
+	return nil
+}
+
+// getReferrerSubject returns the pushed image in the repository. When the image is pushed with the repository path method,
+// such as acme.jfrog.io/docker-local/app:1.0, its name in the repository doesn't include the repository.
+func getReferrerSubject(image *containerutils.Image, repo string) (*ociartifact.Reference, error) {
+	name, err := image.GetImageLongName()
+	if err != nil {
+		return nil, err
+	}
+	if repoKey, imageName, found := strings.Cut(name, "/"); found && repoKey == repo {
+		name = imageName
+	}
+	tag, err := image.GetImageTag()
+	if err != nil {
+		return nil, err
+	}
+	subject := &ociartifact.Reference{Repo: repo, Name: name, Tag: tag}
+	if strings.HasPrefix(tag, "sha256:") {
+		subject.Tag, subject.Digest = "", tag
+	}
+	return subject, nil
+}
+
+// addSbomToBuildInfo adds the manifest and the blob of the attached SBOM to the artifacts of the module of the image, so that
+// the digest of the SBOM is recorded by the build-info, and the SBOM is promoted with the build.
+func (pc *PushCommand) addSbomToBuildInfo(buildInfo *buildinfo.BuildInfo) {
+	if pc.sbomReferrer == nil || len(buildInfo.Modules) == 0 {
+		return
+	}
+	buildInfo.Modules[0].Artifacts = append(buildInfo.Modules[0].Artifacts, pc.sbomReferrer.BuildInfoArtifacts()...)
+}
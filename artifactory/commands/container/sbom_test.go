@@ -0,0 +1,56 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReferrerSubject(t *testing.T) {
+	tests := []struct {
+		image    string
+		repo     string
+		expected ociartifact.Reference
+	}{
+		{"acme.jfrog.io/docker-local/app:1.0", "docker-local", ociartifact.Reference{Repo: "docker-local", Name: "app", Tag: "1.0"}},
+		{"acme.jfrog.io/docker-local/org/app:1.0", "docker-local", ociartifact.Reference{Repo: "docker-local", Name: "org/app", Tag: "1.0"}},
+		{"docker-local.acme.jfrog.io/org/app:1.0", "docker-local", ociartifact.Reference{Repo: "docker-local", Name: "org/app", Tag: "1.0"}},
+		{"acme.jfrog.io/docker-local/app@sha256:1234", "docker-local", ociartifact.Reference{Repo: "docker-local", Name: "app", Digest: "sha256:1234"}},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			subject, err := getReferrerSubject(containerutils.NewImage(test.image), test.repo)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, *subject)
+		})
+	}
+}
+
+func TestReadSbomFile(t *testing.T) {
+	dir := t.TempDir()
+	sbomPath := filepath.Join(dir, "sbom.cdx.json")
+	require.NoError(t, os.WriteFile(sbomPath, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`), 0644))
+	spdxPath := filepath.Join(dir, "sbom.spdx.json")
+	require.NoError(t, os.WriteFile(spdxPath, []byte(`{"spdxVersion":"SPDX-2.3"}`), 0644))
+
+	pushCommand := NewPushCommand(containerutils.DockerClient).SetSbomFile(sbomPath)
+	require.NoError(t, pushCommand.readSbomFile())
+	assert.Contains(t, string(pushCommand.sbom), "CycloneDX")
+
+	assert.ErrorContains(t, NewPushCommand(containerutils.DockerClient).SetSbomFile(spdxPath).readSbomFile(), "must be a CycloneDX SBOM")
+	assert.ErrorContains(t, NewPushCommand(containerutils.DockerClient).SetSbomFile(sbomPath).SetGenerateSbom(true).readSbomFile(), "but not both")
+	assert.NoError(t, NewPushCommand(containerutils.DockerClient).readSbomFile())
+}
+
+func TestAddSbomToBuildInfoWithoutSbom(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{Id: "app:1.0", Artifacts: []buildinfo.Artifact{{Name: "manifest.json"}}}}}
+	pushCommand := NewPushCommand(containerutils.DockerClient)
+	pushCommand.addSbomToBuildInfo(buildInfo)
+	assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+}
@@ -0,0 +1,236 @@
+package strategies
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/container/dockerfileutils"
+	container "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	metadataFileFlag  = "--metadata-file"
+	defaultDockerfile = "Dockerfile"
+)
+
+// BuildxStrategy runs docker buildx build, and collects the build-info of the images pushed by BuildKit,
+// so that no separate 'jf rt build-docker-create' step is needed.
+type BuildxStrategy struct {
+	DockerBuildStrategyBase
+}
+
+func NewBuildxStrategy(options DockerBuildOptions) *BuildxStrategy {
+	return &BuildxStrategy{
+		DockerBuildStrategyBase: DockerBuildStrategyBase{
+			containerManager:   container.NewManager(container.DockerClient),
+			dockerBuildOptions: options,
+		},
+	}
+}
+
+// buildxMetadata is the content of the file written by docker buildx build --metadata-file.
+type buildxMetadata struct {
+	ImageName   string `json:"image.name"`
+	ImageDigest string `json:"containerimage.digest"`
+}
+
+// IsBuildxBuild returns true if the command parameters run docker buildx build.
+func IsBuildxBuild(cmdParams []string) bool {
+	return len(cmdParams) > 1 && cmdParams[0] == "buildx" && cmdParams[1] == "build"
+}
+
+func (s *BuildxStrategy) Execute(cmdParams []string, buildConfig *build.BuildConfiguration) error {
+	toCollect, err := buildConfig.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if !toCollect {
+		return s.GetContainerManager().RunNativeCmd(cmdParams)
+	}
+	if !isPushed(cmdParams) {
+		log.Warn("The build-info of docker buildx build is collected only for pushed images. Add --push to the command to collect it.")
+		return s.GetContainerManager().RunNativeCmd(cmdParams)
+	}
+
+	// BuildKit reports the names and the digest of the pushed image in the metadata file.
+	metadataFilePath := getFlagValue(cmdParams, metadataFileFlag)
+	runParams := cmdParams
+	if metadataFilePath == "" {
+		tempDir, err := fileutils.CreateTempDir()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if removeErr := fileutils.RemoveTempDir(tempDir); removeErr != nil {
+				log.Debug("Failed to remove the buildx metadata temp dir:", removeErr.Error())
+			}
+		}()
+		metadataFilePath = filepath.Join(tempDir, "metadata.json")
+		runParams = append([]string{cmdParams[0], cmdParams[1], metadataFileFlag, metadataFilePath}, cmdParams[2:]...)
+	}
+	if err = s.GetContainerManager().RunNativeCmd(runParams); err != nil {
+		return err
+	}
+
+	if err = s.collectBuildInfo(cmdParams, metadataFilePath, buildConfig); err != nil {
+		// just warn, no need to fail the build if build info collection fails
+		log.Warn("Failed to collect build info. Error:", err)
+	}
+	return nil
+}
+
+func (s *BuildxStrategy) collectBuildInfo(cmdParams []string, metadataFilePath string, buildConfig *build.BuildConfiguration) error {
+	log.Info("Collecting build info...")
+	metadata, err := readBuildxMetadata(metadataFilePath)
+	if err != nil {
+		return err
+	}
+
+	dockerfilePath := s.dockerBuildOptions.DockerFilePath
+	if dockerfilePath == "" {
+		dockerfilePath = getDockerfilePath(cmdParams)
+	}
+	baseImageInfos, err := dockerfileutils.ParseDockerfileBaseImages(dockerfilePath)
+	if err != nil {
+		return errorutils.CheckErrorf("Failed to parse Dockerfile: %s", err.Error())
+	}
+
+	serverDetails := s.GetServerDetails()
+	if serverDetails == nil {
+		log.Warn("Server configuration not available")
+		return nil
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return errorutils.CheckErrorf("Failed to create Artifactory service manager: %s", err.Error())
+	}
+	buildName, err := buildConfig.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfig.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := buildConfig.GetProject()
+	labels := getLabels(cmdParams)
+
+	// An image pushed with several tags is recorded as a module per tag.
+	for _, imageTag := range strings.Split(metadata.ImageName, ",") {
+		imageTag = strings.TrimSpace(imageTag)
+		if imageTag == "" {
+			continue
+		}
+		builder := container.NewDockerBuildInfoBuilder(buildName, buildNumber, project, buildConfig.GetModule(), serviceManager,
+			imageTag, baseImageInfos, true, cmdParams).
+			SetImageDigest(metadata.ImageDigest).
+			SetLabels(labels)
+		if err = builder.Build(); err != nil {
+			return errorutils.CheckErrorf("Failed to build build-info: %s", err.Error())
+		}
+		log.Info(fmt.Sprintf("Build-info collected successfully for image: %s", imageTag))
+	}
+	return nil
+}
+
+func readBuildxMetadata(metadataFilePath string) (*buildxMetadata, error) {
+	content, err := os.ReadFile(metadataFilePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the buildx metadata file: %s", err.Error())
+	}
+	metadata := new(buildxMetadata)
+	if err = json.Unmarshal(content, metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the buildx metadata file '%s': %s", metadataFilePath, err.Error())
+	}
+	if metadata.ImageName == "" || metadata.ImageDigest == "" {
+		return nil, errorutils.CheckErrorf("the buildx metadata file '%s' doesn't include the name and the digest of the pushed image", metadataFilePath)
+	}
+	return metadata, nil
+}
+
+// isPushed returns true if BuildKit pushes the image to the registry, by --push or by an output of type registry.
+func isPushed(cmdParams []string) bool {
+	for i, param := range cmdParams {
+		switch {
+		case param == "--push" || param == "--push=true":
+			return true
+		case param == "--output" || param == "-o":
+			if i+1 < len(cmdParams) && isPushOutput(cmdParams[i+1]) {
+				return true
+			}
+		case strings.HasPrefix(param, "--output="):
+			if isPushOutput(strings.TrimPrefix(param, "--output=")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isPushOutput returns true if an output, such as type=image,push=true, pushes the image.
+func isPushOutput(output string) bool {
+	attributes := strings.Split(output, ",")
+	for _, attribute := range attributes {
+		if attribute == "type=registry" || attribute == "push=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// getFlagValue returns the value of a flag, given as either '--flag value' or '--flag=value'.
+func getFlagValue(cmdParams []string, flag string) string {
+	for i, param := range cmdParams {
+		if param == flag && i+1 < len(cmdParams) {
+			return cmdParams[i+1]
+		}
+		if value, found := strings.CutPrefix(param, flag+"="); found {
+			return value
+		}
+	}
+	return ""
+}
+
+// getDockerfilePath returns the Dockerfile set by -f or --file, or the Dockerfile of the build context,
+// which is the last parameter of the command.
+func getDockerfilePath(cmdParams []string) string {
+	for _, flag := range []string{"--file", "-f"} {
+		if dockerfilePath := getFlagValue(cmdParams, flag); dockerfilePath != "" {
+			return dockerfilePath
+		}
+	}
+	if len(cmdParams) > 2 {
+		buildContext := cmdParams[len(cmdParams)-1]
+		if !strings.HasPrefix(buildContext, "-") && !strings.Contains(buildContext, "://") {
+			return filepath.Join(buildContext, defaultDockerfile)
+		}
+	}
+	return defaultDockerfile
+}
+
+// getLabels returns the labels set by --label key=value.
+func getLabels(cmdParams []string) map[string]string {
+	labels := make(map[string]string)
+	for i, param := range cmdParams {
+		var label string
+		if param == "--label" && i+1 < len(cmdParams) {
+			label = cmdParams[i+1]
+		} else if value, found := strings.CutPrefix(param, "--label="); found {
+			label = value
+		} else {
+			continue
+		}
+		if key, value, found := strings.Cut(label, "="); found && key != "" {
+			labels[key] = value
+		}
+	}
+	return labels
+}
@@ -0,0 +1,54 @@
+package strategies
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBuildxBuild(t *testing.T) {
+	assert.True(t, IsBuildxBuild([]string{"buildx", "build", "--push", "."}))
+	assert.False(t, IsBuildxBuild([]string{"build", "."}))
+	assert.False(t, IsBuildxBuild([]string{"buildx", "ls"}))
+}
+
+func TestIsPushed(t *testing.T) {
+	assert.True(t, isPushed([]string{"buildx", "build", "--push", "."}))
+	assert.True(t, isPushed([]string{"buildx", "build", "--output", "type=registry", "."}))
+	assert.True(t, isPushed([]string{"buildx", "build", "--output=type=image,name=my/app,push=true", "."}))
+	assert.False(t, isPushed([]string{"buildx", "build", "--load", "."}))
+	assert.False(t, isPushed([]string{"buildx", "build", "-o", "type=image,push=false", "."}))
+}
+
+func TestGetDockerfilePath(t *testing.T) {
+	assert.Equal(t, "build/Dockerfile.prod", getDockerfilePath([]string{"buildx", "build", "-f", "build/Dockerfile.prod", "--push", "."}))
+	assert.Equal(t, "Dockerfile.prod", getDockerfilePath([]string{"buildx", "build", "--file=Dockerfile.prod", "."}))
+	assert.Equal(t, filepath.Join("app", defaultDockerfile), getDockerfilePath([]string{"buildx", "build", "--push", "app"}))
+	assert.Equal(t, defaultDockerfile, getDockerfilePath([]string{"buildx", "build", "--push", "-"}))
+}
+
+func TestGetLabels(t *testing.T) {
+	labels := getLabels([]string{"buildx", "build", "--label", "org.opencontainers.image.source=https://github.com/my/app", "--label=maintainer=ci", "--label", "invalid", "."})
+	assert.Equal(t, map[string]string{"org.opencontainers.image.source": "https://github.com/my/app", "maintainer": "ci"}, labels)
+}
+
+func TestReadBuildxMetadata(t *testing.T) {
+	metadataFilePath := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(metadataFilePath, []byte(`{
+  "buildx.build.ref": "builder/builder0/abc",
+  "containerimage.digest": "sha256:0123",
+  "image.name": "acme.jfrog.io/docker-local/app:1.0,acme.jfrog.io/docker-local/app:latest"
+}`), 0644))
+	metadata, err := readBuildxMetadata(metadataFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:0123", metadata.ImageDigest)
+	assert.Equal(t, "acme.jfrog.io/docker-local/app:1.0,acme.jfrog.io/docker-local/app:latest", metadata.ImageName)
+
+	// The image wasn't pushed, so its name isn't reported.
+	require.NoError(t, os.WriteFile(metadataFilePath, []byte(`{"containerimage.digest": "sha256:0123"}`), 0644))
+	_, err = readBuildxMetadata(metadataFilePath)
+	assert.ErrorContains(t, err, "doesn't include the name and the digest")
+}
@@ -0,0 +1,204 @@
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	WarmCacheStatusWarmed = "warmed"
+	WarmCacheStatusFailed = "failed"
+
+	defaultWarmCacheThreads = 3
+)
+
+type WarmCacheItem struct {
+	Image     string `json:"image"`
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Manifests int    `json:"manifests"`
+	// The blobs fetched for the image. The blobs it shares with the other images are counted for one of them.
+	Blobs int    `json:"blobs"`
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+type WarmCacheResult struct {
+	Repository string          `json:"repository"`
+	Images     []WarmCacheItem `json:"images"`
+	Warmed     int             `json:"warmed"`
+	Failed     int             `json:"failed"`
+}
+
+// DockerWarmCacheCommand pulls the manifests and the blobs of a list of images through an Artifactory Docker repository,
+// without a Docker client. Pulling the images through a remote repository caches them, so that the first pulls of the
+// images, such as on a release day, don't wait for them to be fetched from the remote registry.
+type DockerWarmCacheCommand struct {
+	serverDetails  *config.ServerDetails
+	repo           string
+	imageListFiles []string
+	threads        int
+	result         *WarmCacheResult
+}
+
+func NewDockerWarmCacheCommand() *DockerWarmCacheCommand {
+	return &DockerWarmCacheCommand{threads: defaultWarmCacheThreads}
+}
+
+func (dwc *DockerWarmCacheCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerWarmCacheCommand {
+	dwc.serverDetails = serverDetails
+	return dwc
+}
+
+func (dwc *DockerWarmCacheCommand) SetRepo(repo string) *DockerWarmCacheCommand {
+	dwc.repo = repo
+	return dwc
+}
+
+// SetImageListFiles sets the files of the images to pull, each listing an image per line, such as "library/nginx:1.27".
+func (dwc *DockerWarmCacheCommand) SetImageListFiles(imageListFiles []string) *DockerWarmCacheCommand {
+	dwc.imageListFiles = imageListFiles
+	return dwc
+}
+
+// SetThreads sets the number of images pulled in parallel.
+func (dwc *DockerWarmCacheCommand) SetThreads(threads int) *DockerWarmCacheCommand {
+	dwc.threads = threads
+	return dwc
+}
+
+func (dwc *DockerWarmCacheCommand) Result() *WarmCacheResult {
+	return dwc.result
+}
+
+func (dwc *DockerWarmCacheCommand) CommandName() string {
+	return "rt_docker_warm_cache"
+}
+
+func (dwc *DockerWarmCacheCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dwc.serverDetails, nil
+}
+
+func (dwc *DockerWarmCacheCommand) Run() error {
+	images, err := ReadImageListFiles(dwc.imageListFiles)
+	if err != nil {
+		return err
+	}
+	references := make([]*ociartifact.Reference, 0, len(images))
+	for _, image := range images {
+		reference, err := ociartifact.ParseReference(dwc.repo + "/" + image)
+		if err != nil {
+			return err
+		}
+		references = append(references, reference)
+	}
+	servicesManager, err := utils.CreateServiceManager(dwc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Pulling %d images through %s...", len(references), dwc.repo))
+	dwc.result = WarmImages(dwc.repo, references, ociartifact.NewImageFetcher(servicesManager).Fetch, dwc.threads)
+	log.Info(fmt.Sprintf("Pulled %d images, %d failed.", dwc.result.Warmed, dwc.result.Failed))
+	if dwc.result.Failed > 0 {
+		return errorutils.CheckErrorf("failed to pull %d images through %s", dwc.result.Failed, dwc.repo)
+	}
+	return nil
+}
+
+// ReadImageListFiles returns the images listed by the files, without duplicates. Empty lines and lines which start with # are skipped.
+func ReadImageListFiles(imageListFiles []string) ([]string, error) {
+	var images []string
+	listed := make(map[string]bool)
+	for _, imageListFile := range imageListFiles {
+		content, err := os.ReadFile(imageListFile)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			image := strings.TrimSpace(scanner.Text())
+			if image == "" || strings.HasPrefix(image, "#") || listed[image] {
+				continue
+			}
+			listed[image] = true
+			images = append(images, image)
+		}
+		if err = scanner.Err(); err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the images of %s: %s", imageListFile, err.Error())
+		}
+	}
+	return images, nil
+}
+
+// WarmImages pulls the images in parallel, using fetch.
+func WarmImages(repo string, references []*ociartifact.Reference, fetch func(reference *ociartifact.Reference) (*ociartifact.FetchSummary, error), threads int) *WarmCacheResult {
+	if threads < 1 {
+		threads = 1
+	}
+	// Each task writes the item of its image, so no locking is needed.
+	items := make([]WarmCacheItem, len(references))
+	runner := parallel.NewRunner(threads, uint(len(references)), false)
+	go func() {
+		defer runner.Done()
+		for i, reference := range references {
+			_, _ = runner.AddTask(func(int) error {
+				items[i] = warmImage(reference, fetch)
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+
+	result := &WarmCacheResult{Repository: repo, Images: items}
+	for _, item := range items {
+		if item.Status == WarmCacheStatusWarmed {
+			result.Warmed++
+		} else {
+			result.Failed++
+		}
+	}
+	return result
+}
+
+func warmImage(reference *ociartifact.Reference, fetch func(reference *ociartifact.Reference) (*ociartifact.FetchSummary, error)) WarmCacheItem {
+	log.Debug("Pulling", reference.String())
+	item := WarmCacheItem{Image: reference.String(), Status: WarmCacheStatusWarmed}
+	summary, err := fetch(reference)
+	if err != nil {
+		item.Status, item.Error = WarmCacheStatusFailed, err.Error()
+		log.Warn(fmt.Sprintf("Failed to pull %s: %s", reference, err.Error()))
+		return item
+	}
+	item.Digest, item.Manifests, item.Blobs, item.Bytes = summary.Digest, summary.Manifests, summary.FetchedBlobs, summary.FetchedBytes
+	return item
+}
+
+type warmCacheRow struct {
+	Image     string `col-name:"Image"`
+	Status    string `col-name:"Status"`
+	Manifests int    `col-name:"Manifests"`
+	Blobs     int    `col-name:"Blobs"`
+	Size      string `col-name:"Size"`
+	Error     string `col-name:"Error" omitempty:"true"`
+}
+
+// PrintWarmCacheTable prints the pulled images of the result as a table.
+func PrintWarmCacheTable(result *WarmCacheResult) error {
+	rows := make([]warmCacheRow, 0, len(result.Images))
+	for _, image := range result.Images {
+		rows = append(rows, warmCacheRow{Image: image.Image, Status: image.Status, Manifests: image.Manifests, Blobs: image.Blobs,
+			Size: formatSize(image.Bytes), Error: image.Error})
+	}
+	return coreutils.PrintTable(rows, "Images pulled through "+result.Repository, "No images found", false)
+}
@@ -0,0 +1,51 @@
+package container
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ociartifact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadImageListFiles(t *testing.T) {
+	dir := t.TempDir()
+	firstList := filepath.Join(dir, "release.txt")
+	require.NoError(t, os.WriteFile(firstList, []byte("# Base images\nlibrary/nginx:1.27\n\n  library/redis@sha256:abc  \n"), 0644))
+	secondList := filepath.Join(dir, "tools.txt")
+	require.NoError(t, os.WriteFile(secondList, []byte("library/nginx:1.27\ngrafana/grafana:11.0.0\n"), 0644))
+	images, err := ReadImageListFiles([]string{firstList, secondList})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"library/nginx:1.27", "library/redis@sha256:abc", "grafana/grafana:11.0.0"}, images)
+
+	_, err = ReadImageListFiles([]string{filepath.Join(dir, "missing.txt")})
+	assert.Error(t, err)
+}
+
+func TestWarmImages(t *testing.T) {
+	var references []*ociartifact.Reference
+	for _, image := range []string{"docker-remote/library/nginx:1.27", "docker-remote/library/redis:7"} {
+		reference, err := ociartifact.ParseReference(image)
+		require.NoError(t, err)
+		references = append(references, reference)
+	}
+	fetch := func(reference *ociartifact.Reference) (*ociartifact.FetchSummary, error) {
+		if reference.Name == "library/redis" {
+			return nil, errors.New("404 Not Found")
+		}
+		return &ociartifact.FetchSummary{Digest: "sha256:nginx", Manifests: 3, FetchedBlobs: 5, FetchedBytes: 1024}, nil
+	}
+	result := WarmImages("docker-remote", references, fetch, 2)
+	assert.Equal(t, &WarmCacheResult{
+		Repository: "docker-remote",
+		Images: []WarmCacheItem{
+			{Image: "docker-remote/library/nginx:1.27", Status: WarmCacheStatusWarmed, Digest: "sha256:nginx", Manifests: 3, Blobs: 5, Bytes: 1024},
+			{Image: "docker-remote/library/redis:7", Status: WarmCacheStatusFailed, Error: "404 Not Found"},
+		},
+		Warmed: 1,
+		Failed: 1,
+	}, result)
+}
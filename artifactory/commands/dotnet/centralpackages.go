@@ -0,0 +1,371 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	dotnetDependencies "github.com/jfrog/build-info-go/build/utils/dotnet/dependencies"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	centralPackagesFileName = "Directory.Packages.props"
+	// The MSBuild property function which the central packages files use to import the one of a parent directory.
+	getPathOfFileAbove = "GetPathOfFileAbove"
+
+	// CentralPackageVersionsProp is the build-info environment property which records the package versions managed centrally by
+	// the Directory.Packages.props files of the solution, as a JSON array of CentralPackageVersions.
+	CentralPackageVersionsProp = "buildInfo.nuget.centralPackageVersions"
+)
+
+// msbuildProject is the content of a Directory.Packages.props or a project file, which is relevant to the central package management.
+type msbuildProject struct {
+	PropertyGroups []struct {
+		ManagePackageVersionsCentrally         string `xml:"ManagePackageVersionsCentrally"`
+		CentralPackageTransitivePinningEnabled string `xml:"CentralPackageTransitivePinningEnabled"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageVersions         []msbuildPackageItem `xml:"PackageVersion"`
+		GlobalPackageReferences []msbuildPackageItem `xml:"GlobalPackageReference"`
+		PackageReferences       []msbuildPackageItem `xml:"PackageReference"`
+	} `xml:"ItemGroup"`
+	Imports []struct {
+		Project string `xml:"Project,attr"`
+	} `xml:"Import"`
+}
+
+// msbuildPackageItem is a package item. Its versions may be set as attributes or as child elements.
+type msbuildPackageItem struct {
+	Include                string `xml:"Include,attr"`
+	Update                 string `xml:"Update,attr"`
+	VersionAttr            string `xml:"Version,attr"`
+	VersionElement         string `xml:"Version"`
+	VersionOverrideAttr    string `xml:"VersionOverride,attr"`
+	VersionOverrideElement string `xml:"VersionOverride"`
+}
+
+func (item msbuildPackageItem) id() string {
+	if item.Include != "" {
+		return item.Include
+	}
+	return item.Update
+}
+
+func (item msbuildPackageItem) version() string {
+	return firstNonEmpty(item.VersionAttr, item.VersionElement)
+}
+
+func (item msbuildPackageItem) versionOverride() string {
+	return firstNonEmpty(item.VersionOverrideAttr, item.VersionOverrideElement)
+}
+
+// CentralPackageVersions are the package versions managed centrally by the Directory.Packages.props files of a project.
+type CentralPackageVersions struct {
+	Files []string `json:"files"`
+	// The transitive dependencies are pinned to the central versions as well, rather than only the direct ones.
+	TransitivePinning bool `json:"transitivePinning"`
+	// The versions of the packages, by their lower case ids. Includes the global package references.
+	Versions   map[string]string `json:"versions"`
+	Mismatches []RestoreMismatch `json:"mismatches,omitempty"`
+}
+
+// loadCentralPackageVersions loads the central package management of the project directory, from the Directory.Packages.props file of the
+// directory or of the nearest parent directory, and the files it imports. Returns nil if the central package management isn't enabled.
+func loadCentralPackageVersions(projectDir string) (*CentralPackageVersions, error) {
+	propsPath, err := findFileAbove(projectDir, centralPackagesFileName)
+	if err != nil || propsPath == "" {
+		return nil, err
+	}
+	central := &CentralPackageVersions{Versions: make(map[string]string)}
+	enabled, err := central.load(propsPath)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	return central, nil
+}
+
+// load loads the central packages file. Its imported files are loaded first, so that the file overrides the versions and the properties
+// of its parents.
+func (cpv *CentralPackageVersions) load(propsPath string) (enabled bool, err error) {
+	if slices.Contains(cpv.Files, propsPath) {
+		return false, nil
+	}
+	cpv.Files = append(cpv.Files, propsPath)
+	props, err := readMsbuildProject(propsPath)
+	if err != nil {
+		return false, err
+	}
+	for _, imported := range props.Imports {
+		importedPath, err := resolveImport(filepath.Dir(propsPath), imported.Project)
+		if err != nil {
+			return false, err
+		}
+		if importedPath == "" {
+			continue
+		}
+		importedEnabled, err := cpv.load(importedPath)
+		if err != nil {
+			return false, err
+		}
+		enabled = enabled || importedEnabled
+	}
+	for _, propertyGroup := range props.PropertyGroups {
+		if value := propertyGroup.ManagePackageVersionsCentrally; value != "" {
+			enabled = strings.EqualFold(value, "true")
+		}
+		if value := propertyGroup.CentralPackageTransitivePinningEnabled; value != "" {
+			cpv.TransitivePinning = strings.EqualFold(value, "true")
+		}
+	}
+	for _, itemGroup := range props.ItemGroups {
+		for _, item := range slices.Concat(itemGroup.PackageVersions, itemGroup.GlobalPackageReferences) {
+			if item.id() != "" && item.version() != "" {
+				cpv.Versions[strings.ToLower(item.id())] = item.version()
+			}
+		}
+	}
+	return enabled, nil
+}
+
+// resolveImport returns the path of an imported file. Only the imports of the central packages file of a parent directory, and the
+// imports of relative paths without MSBuild properties are resolved.
+func resolveImport(dir, project string) (string, error) {
+	if strings.Contains(project, getPathOfFileAbove) {
+		return findFileAbove(filepath.Dir(dir), centralPackagesFileName)
+	}
+	if project == "" || strings.Contains(project, "$(") {
+		log.Debug(fmt.Sprintf("Skipping the import of '%s' in %s: only the %s files of the parent directories are imported.", project, dir, centralPackagesFileName))
+		return "", nil
+	}
+	importedPath := filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(project, `\`, "/")))
+	exists, err := fileutils.IsFileExists(importedPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	return importedPath, nil
+}
+
+// findFileAbove returns the path of the file in the directory or in the nearest parent directory, or an empty string if it doesn't exist.
+func findFileAbove(dir, fileName string) (string, error) {
+	for {
+		filePath := filepath.Join(dir, fileName)
+		exists, err := fileutils.IsFileExists(filePath, false)
+		if err != nil || exists {
+			return filePath, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func readMsbuildProject(projectPath string) (*msbuildProject, error) {
+	content, err := os.ReadFile(projectPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	project := &msbuildProject{}
+	if err = xml.Unmarshal(content, project); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", projectPath, err.Error())
+	}
+	return project, nil
+}
+
+// getProjectVersions returns the central versions of the packages of the project, with the versions overridden by the VersionOverride
+// metadata of its package references.
+func (cpv *CentralPackageVersions) getProjectVersions(projectPath string) (map[string]string, error) {
+	versions := maps.Clone(cpv.Versions)
+	if projectPath == "" {
+		return versions, nil
+	}
+	exists, err := fileutils.IsFileExists(projectPath, false)
+	if err != nil || !exists {
+		return versions, err
+	}
+	project, err := readMsbuildProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, itemGroup := range project.ItemGroups {
+		for _, reference := range itemGroup.PackageReferences {
+			if override := reference.versionOverride(); override != "" && reference.id() != "" {
+				versions[strings.ToLower(reference.id())] = override
+			}
+		}
+	}
+	return versions, nil
+}
+
+// getRestoredVersion returns the version which NuGet restores for a version range: the lowest version of the range.
+// Returns an empty string if the range has no inclusive lower bound.
+func getRestoredVersion(versionRange string) string {
+	versionRange = strings.TrimSpace(versionRange)
+	if !strings.ContainsAny(versionRange, "[(") {
+		return versionRange
+	}
+	if !strings.HasPrefix(versionRange, "[") {
+		return ""
+	}
+	lowerBound, _, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(versionRange, "["), "]"), ",")
+	return strings.TrimSpace(lowerBound)
+}
+
+// projectAssets is the content of a project.assets.json file, which is relevant to the central package management.
+type projectAssets struct {
+	Libraries map[string]struct {
+		Type string `json:"type"`
+	} `json:"libraries"`
+	Project struct {
+		Restore struct {
+			ProjectPath string `json:"projectPath"`
+		} `json:"restore"`
+		Frameworks map[string]struct {
+			Dependencies map[string]json.RawMessage `json:"dependencies"`
+		} `json:"frameworks"`
+	} `json:"project"`
+}
+
+// verifyCentralPackageVersions compares the restored packages of the project with their central versions. The direct dependencies are
+// compared, and the transitive dependencies as well if they're pinned.
+func (cpv *CentralPackageVersions) verifyCentralPackageVersions(assetsPath string) error {
+	content, err := os.ReadFile(assetsPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var assets projectAssets
+	if err = json.Unmarshal(content, &assets); err != nil {
+		return errorutils.CheckErrorf("failed to parse %s: %s", assetsPath, err.Error())
+	}
+	versions, err := cpv.getProjectVersions(assets.Project.Restore.ProjectPath)
+	if err != nil {
+		return err
+	}
+	direct := make(map[string]bool)
+	for _, framework := range assets.Project.Frameworks {
+		for id := range framework.Dependencies {
+			direct[strings.ToLower(id)] = true
+		}
+	}
+	for libraryId, library := range assets.Libraries {
+		id, restoredVersion, found := strings.Cut(libraryId, "/")
+		if !found || library.Type != "package" || (!direct[strings.ToLower(id)] && !cpv.TransitivePinning) {
+			continue
+		}
+		centralVersion, managed := versions[strings.ToLower(id)]
+		if !managed {
+			continue
+		}
+		expectedVersion := getRestoredVersion(centralVersion)
+		if expectedVersion == "" || strings.EqualFold(expectedVersion, restoredVersion) {
+			continue
+		}
+		mismatch := RestoreMismatch{Id: id, Version: restoredVersion, Reason: fmt.Sprintf("the version managed centrally is %s", centralVersion)}
+		if !slices.Contains(cpv.Mismatches, mismatch) {
+			cpv.Mismatches = append(cpv.Mismatches, mismatch)
+		}
+	}
+	return nil
+}
+
+// saveCentralPackageVersions records the package versions managed centrally by the Directory.Packages.props files of the projects of the
+// solution in the build-info, and warns about the restored packages whose versions don't match them. The dependencies of the build-info
+// are read from the project.assets.json files of the restore, so they're the versions which were actually restored.
+func (dc *DotnetCommand) saveCentralPackageVersions() error {
+	assetsFiles, err := findAssetsFiles(dc.solutionPath)
+	if err != nil {
+		return err
+	}
+	// The projects which share a Directory.Packages.props file share its central package versions.
+	centralByFile := make(map[string]*CentralPackageVersions)
+	var centrals []*CentralPackageVersions
+	for _, assetsPath := range assetsFiles {
+		central, err := loadCentralPackageVersions(filepath.Dir(filepath.Dir(assetsPath)))
+		if err != nil {
+			return err
+		}
+		if central == nil {
+			continue
+		}
+		if existing, exists := centralByFile[central.Files[0]]; exists {
+			central = existing
+		} else {
+			log.Debug("The package versions are managed centrally by", strings.Join(central.Files, ", "))
+			centralByFile[central.Files[0]] = central
+			centrals = append(centrals, central)
+		}
+		if err = central.verifyCentralPackageVersions(assetsPath); err != nil {
+			return err
+		}
+	}
+	if len(centrals) == 0 {
+		return nil
+	}
+	for _, central := range centrals {
+		for _, mismatch := range central.Mismatches {
+			log.Warn(fmt.Sprintf("%s %s was restored, but %s.", mismatch.Id, mismatch.Version, mismatch.Reason))
+		}
+	}
+	content, err := json.Marshal(centrals)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	buildName, err := dc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := dc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return commonBuild.SavePartialBuildInfo(buildName, buildNumber, dc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Env = buildinfo.Env{CentralPackageVersionsProp: string(content)}
+	})
+}
+
+// findAssetsFiles returns the project.assets.json files under the obj directories of the projects of the solution.
+func findAssetsFiles(solutionPath string) (assetsFiles []string, err error) {
+	err = filepath.WalkDir(solutionPath, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && (entry.Name() == "bin" || entry.Name() == ".git") {
+			return filepath.SkipDir
+		}
+		if !entry.IsDir() && entry.Name() == dotnetDependencies.AssetFileName && filepath.Base(filepath.Dir(filePath)) == dotnetDependencies.AssetDirName {
+			assetsFiles = append(assetsFiles, filePath)
+		}
+		return nil
+	})
+	return assetsFiles, errorutils.CheckError(err)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// saveCentralPackageVersionsIfNeeded records the central package versions when the build-info is collected. The test command doesn't
+// restore the packages, so it's skipped.
+func (dc *DotnetCommand) saveCentralPackageVersionsIfNeeded() error {
+	collectBuildInfo, err := dc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo || dc.isDotnetTestCommand() {
+		return err
+	}
+	return dc.saveCentralPackageVersions()
+}
@@ -0,0 +1,124 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRootPackagesProps = `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Newtonsoft.Json" Version="13.0.1" />
+    <PackageVersion Include="Serilog" Version="3.0.0" />
+    <GlobalPackageReference Include="Nerdbank.GitVersioning" Version="3.6.133" />
+  </ItemGroup>
+</Project>`
+
+	testProjectPackagesProps = `<Project>
+  <Import Project="$([MSBuild]::GetPathOfFileAbove(Directory.Packages.props, $(MSBuildThisFileDirectory)..))" />
+  <PropertyGroup>
+    <CentralPackageTransitivePinningEnabled>true</CentralPackageTransitivePinningEnabled>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Update="Newtonsoft.Json" Version="[13.0.3]" />
+    <PackageVersion Include="System.Text.Json">
+      <Version>8.0.0</Version>
+    </PackageVersion>
+  </ItemGroup>
+</Project>`
+
+	testProjectFile = `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" />
+    <PackageReference Include="Serilog" VersionOverride="3.1.1" />
+  </ItemGroup>
+</Project>`
+
+	testProjectAssets = `{
+  "libraries": {
+    "Newtonsoft.Json/13.0.3": {"type": "package"},
+    "Serilog/3.1.1": {"type": "package"},
+    "System.Text.Json/8.0.4": {"type": "package"},
+    "Nerdbank.GitVersioning/3.6.133": {"type": "package"},
+    "Other.Project/1.0.0": {"type": "project"}
+  },
+  "project": {
+    "restore": {"projectPath": "%s"},
+    "frameworks": {"net8.0": {"dependencies": {"Newtonsoft.Json": {}, "Serilog": {}, "Nerdbank.GitVersioning": {}}}}
+  }
+}`
+)
+
+// createTestCentralPackagesSolution creates a solution with a Directory.Packages.props file, and a project with another one which
+// imports it.
+func createTestCentralPackagesSolution(t *testing.T) (solutionPath, projectDir string) {
+	solutionPath = t.TempDir()
+	projectDir = filepath.Join(solutionPath, "src", "MyProject")
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "obj"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(solutionPath, centralPackagesFileName), []byte(testRootPackagesProps), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, centralPackagesFileName), []byte(testProjectPackagesProps), 0644))
+	projectPath := filepath.Join(projectDir, "MyProject.csproj")
+	require.NoError(t, os.WriteFile(projectPath, []byte(testProjectFile), 0644))
+	projectPathJson, err := json.Marshal(projectPath)
+	require.NoError(t, err)
+	assets := strings.Replace(testProjectAssets, `"%s"`, string(projectPathJson), 1)
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "obj", "project.assets.json"), []byte(assets), 0644))
+	return
+}
+
+func TestLoadCentralPackageVersions(t *testing.T) {
+	solutionPath, projectDir := createTestCentralPackagesSolution(t)
+	central, err := loadCentralPackageVersions(projectDir)
+	require.NoError(t, err)
+	require.NotNil(t, central)
+	assert.Equal(t, []string{filepath.Join(projectDir, centralPackagesFileName), filepath.Join(solutionPath, centralPackagesFileName)}, central.Files)
+	assert.True(t, central.TransitivePinning)
+	assert.Equal(t, map[string]string{"newtonsoft.json": "[13.0.3]", "serilog": "3.0.0", "system.text.json": "8.0.0", "nerdbank.gitversioning": "3.6.133"}, central.Versions)
+
+	// The central package management isn't enabled without Directory.Packages.props files, or without ManagePackageVersionsCentrally.
+	central, err = loadCentralPackageVersions(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, central)
+	require.NoError(t, os.WriteFile(filepath.Join(solutionPath, centralPackagesFileName), []byte("<Project />"), 0644))
+	central, err = loadCentralPackageVersions(solutionPath)
+	require.NoError(t, err)
+	assert.Nil(t, central)
+}
+
+func TestGetRestoredVersion(t *testing.T) {
+	assert.Equal(t, "1.2.3", getRestoredVersion("1.2.3"))
+	assert.Equal(t, "1.2.3", getRestoredVersion("[1.2.3]"))
+	assert.Equal(t, "1.0.0", getRestoredVersion("[1.0.0, 2.0.0)"))
+	assert.Equal(t, "", getRestoredVersion("(1.0.0, 2.0.0)"))
+}
+
+func TestSaveCentralPackageVersions(t *testing.T) {
+	solutionPath, projectDir := createTestCentralPackagesSolution(t)
+	buildDir, err := commonBuild.GetBuildDir("nuget-central-packages", "1", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(buildDir))
+	}()
+
+	dc := &DotnetCommand{solutionPath: solutionPath, buildConfiguration: commonBuild.NewBuildConfiguration("nuget-central-packages", "1", "", "")}
+	require.NoError(t, dc.saveCentralPackageVersions())
+	partials, err := commonBuild.ReadPartialBuildInfoFiles("nuget-central-packages", "1", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	var centrals []CentralPackageVersions
+	require.NoError(t, json.Unmarshal([]byte(partials[0].Env[CentralPackageVersionsProp]), &centrals))
+	require.Len(t, centrals, 1)
+	assert.Equal(t, filepath.Join(projectDir, centralPackagesFileName), centrals[0].Files[0])
+	// Serilog's version is overridden by the project, and System.Text.Json is a transitive dependency pinned to another version.
+	assert.Equal(t, []RestoreMismatch{{Id: "System.Text.Json", Version: "8.0.4", Reason: "the version managed centrally is 8.0.0"}}, centrals[0].Mismatches)
+}
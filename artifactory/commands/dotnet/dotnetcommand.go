@@ -47,8 +47,10 @@ type DotnetCommand struct {
 	useNugetV2    bool
 	// By default, package sources are required to use HTTPS. This option allows sources to use HTTP.
 	allowInsecureConnections bool
-	buildConfiguration       *commonBuild.BuildConfiguration
-	serverDetails            *config.ServerDetails
+	// Verify the restored packages against the lock files and the checksums in Artifactory.
+	verifyLockFile     bool
+	buildConfiguration *commonBuild.BuildConfiguration
+	serverDetails      *config.ServerDetails
 }
 
 func (dc *DotnetCommand) SetServerDetails(serverDetails *config.ServerDetails) *DotnetCommand {
@@ -111,6 +113,12 @@ func (dc *DotnetCommand) CommandName() string {
 // Exec all consume type nuget commands, install, update, add, restore.
 func (dc *DotnetCommand) Exec() (err error) {
 	log.Info("Running " + dc.toolchainType.String() + "...")
+	if dc.isPushCommand() {
+		return dc.push()
+	}
+	if err = dc.extractVerifyLockFile(); err != nil {
+		return err
+	}
 	buildName, err := dc.buildConfiguration.GetBuildName()
 	if err != nil {
 		return err
@@ -144,6 +152,14 @@ func (dc *DotnetCommand) Exec() (err error) {
 		}
 		return err
 	}
+	if err = dc.saveCentralPackageVersionsIfNeeded(); err != nil {
+		return err
+	}
+	if dc.verifyLockFile {
+		if err = dc.verifyRestore(); err != nil {
+			return err
+		}
+	}
 	log.Info(fmt.Sprintf("%s finished successfully.", dc.toolchainType))
 	return nil
 }
@@ -0,0 +1,277 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	pushCommand      = "push"
+	packageExtension = ".nupkg"
+	symbolsExtension = ".snupkg"
+)
+
+// nugetPackage is a package pushed by nuget push or dotnet nuget push, with its symbols package, if any.
+type nugetPackage struct {
+	id          string
+	version     string
+	path        string
+	symbolsPath string
+}
+
+type nuspec struct {
+	Metadata struct {
+		Id      string `xml:"id"`
+		Version string `xml:"version"`
+	} `xml:"metadata"`
+}
+
+// isPushCommand returns true for 'nuget push' and 'dotnet nuget push'.
+func (dc *DotnetCommand) isPushCommand() bool {
+	if dc.toolchainType == dotnet.DotnetCore {
+		return dc.subCommand == "nuget" && len(dc.argAndFlags) > 0 && dc.argAndFlags[0] == pushCommand
+	}
+	return dc.subCommand == pushCommand
+}
+
+// push deploys the packages given as arguments, with their matching symbols packages, to the repository. If --scan is set, the packages
+// are scanned by Xray first, and nothing is deployed if any of them violates the Xray policies.
+// When the build-info is collected, the deployed packages are added to it as artifacts, with their checksums.
+// The other options of the native push command, such as the source and the API key, are ignored.
+func (dc *DotnetCommand) push() (err error) {
+	args := dc.argAndFlags
+	if dc.toolchainType == dotnet.DotnetCore {
+		args = args[1:]
+	}
+	args, xrayScan, scanOutputFormat, err := extractScanOptions(args)
+	if err != nil {
+		return err
+	}
+	packages, err := findPushedPackages(args)
+	if err != nil {
+		return err
+	}
+	if xrayScan {
+		if err = dc.scanPackages(packages, scanOutputFormat); err != nil {
+			return err
+		}
+	}
+	collectBuildInfo, err := dc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	buildProps := ""
+	if collectBuildInfo {
+		if buildProps, err = commonBuild.CreateBuildPropsFromConfiguration(dc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(dc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		if err = dc.deployPackage(servicesManager, pkg, buildProps, collectBuildInfo); err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("%s push finished successfully.", dc.toolchainType))
+	return nil
+}
+
+// extractScanOptions extracts the --scan and --format options of the conditional upload from the args.
+func extractScanOptions(args []string) (cleanArgs []string, xrayScan bool, scanOutputFormat format.OutputFormat, err error) {
+	cleanArgs, xrayScan, err = coreutils.ExtractXrayScanFromArgs(args)
+	if err != nil {
+		return
+	}
+	cleanArgs, outputFormat, err := coreutils.ExtractXrayOutputFormatFromArgs(cleanArgs)
+	if err != nil {
+		return
+	}
+	scanOutputFormat = format.Table
+	if outputFormat != "" {
+		scanOutputFormat, err = format.ParseOutputFormat(outputFormat, format.All)
+	}
+	return
+}
+
+// findPushedPackages returns the packages matching the paths or the wildcard patterns of the .nupkg arguments.
+// The symbols package of each package is the .snupkg file with the same name next to it.
+func findPushedPackages(args []string) ([]nugetPackage, error) {
+	var packages []nugetPackage
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") || !strings.EqualFold(filepath.Ext(arg), packageExtension) {
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if len(matches) == 0 {
+			return nil, errorutils.CheckErrorf("no packages matching '%s' were found", arg)
+		}
+		for _, packagePath := range matches {
+			pkg, err := readNugetPackage(packagePath)
+			if err != nil {
+				return nil, err
+			}
+			packages = append(packages, *pkg)
+		}
+	}
+	if len(packages) == 0 {
+		return nil, errorutils.CheckErrorf("the path of the %s packages to push is missing", packageExtension)
+	}
+	return packages, nil
+}
+
+// readNugetPackage reads the id and the version of the package from the .nuspec file at its root.
+func readNugetPackage(packagePath string) (pkg *nugetPackage, err error) {
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the package %s: %s", packagePath, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	for _, file := range reader.File {
+		if strings.Contains(file.Name, "/") || !strings.EqualFold(path.Ext(file.Name), ".nuspec") {
+			continue
+		}
+		var pkgSpec nuspec
+		if pkgSpec, err = readNuspec(file); err != nil {
+			return nil, err
+		}
+		if pkgSpec.Metadata.Id == "" || pkgSpec.Metadata.Version == "" {
+			return nil, errorutils.CheckErrorf("the id and the version of the package %s weren't found in %s", packagePath, file.Name)
+		}
+		pkg = &nugetPackage{id: pkgSpec.Metadata.Id, version: pkgSpec.Metadata.Version, path: packagePath}
+		symbolsPath := strings.TrimSuffix(packagePath, filepath.Ext(packagePath)) + symbolsExtension
+		if exists, e := fileutils.IsFileExists(symbolsPath, false); e != nil {
+			return nil, e
+		} else if exists {
+			pkg.symbolsPath = symbolsPath
+		}
+		return pkg, nil
+	}
+	return nil, errorutils.CheckErrorf("the package %s has no .nuspec file", packagePath)
+}
+
+func readNuspec(file *zip.File) (pkgSpec nuspec, err error) {
+	nuspecFile, err := file.Open()
+	if err != nil {
+		return pkgSpec, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(nuspecFile.Close()))
+	}()
+	err = errorutils.CheckError(xml.NewDecoder(nuspecFile).Decode(&pkgSpec))
+	return
+}
+
+// scanPackages scans the packages by Xray before they're deployed. The symbols packages are deployed with their packages, so
+// they aren't scanned separately.
+func (dc *DotnetCommand) scanPackages(packages []nugetPackage, scanOutputFormat format.OutputFormat) error {
+	if commandsutils.ConditionalUploadScanFunc == nil {
+		return errorutils.CheckErrorf("the Xray scan isn't available in this build of JFrog CLI")
+	}
+	for _, pkg := range packages {
+		log.Info(fmt.Sprintf("Scanning %s by Xray before pushing it to '%s'...", filepath.Base(pkg.path), dc.repoName))
+		fileSpec := spec.NewBuilder().Pattern(pkg.path).Target(dc.getPushTarget(pkg, pkg.path)).BuildSpec()
+		err := commandsutils.ConditionalUploadScanFunc(dc.serverDetails, fileSpec, 1, scanOutputFormat)
+		jobsummary.RecordScanGate(filepath.Base(pkg.path), err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployPackage deploys the package and its symbols package, and adds them to the build-info.
+func (dc *DotnetCommand) deployPackage(servicesManager artifactory.ArtifactoryServicesManager, pkg nugetPackage, buildProps string, collectBuildInfo bool) (err error) {
+	log.Info(fmt.Sprintf("Pushing %s %s to '%s'...", pkg.id, pkg.version, dc.repoName))
+	var uploadParams []services.UploadParams
+	for _, filePath := range []string{pkg.path, pkg.symbolsPath} {
+		if filePath == "" {
+			continue
+		}
+		params := services.NewUploadParams()
+		params.Pattern = filePath
+		params.Target = dc.getPushTarget(pkg, filePath)
+		params.Flat = true
+		params.TargetProps = servicesUtils.NewProperties()
+		params.BuildProps = buildProps
+		uploadParams = append(uploadParams, params)
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.Close())
+	}()
+	if summary.TotalFailed > 0 || summary.TotalSucceeded < len(uploadParams) {
+		return errorutils.CheckErrorf("failed to push %s %s to '%s'", pkg.id, pkg.version, dc.repoName)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	return dc.addPushedArtifacts(pkg, summary.ArtifactsDetailsReader)
+}
+
+// getPushTarget returns the path of a file of the package in the repository: <repo>/<id>/<file name>.
+func (dc *DotnetCommand) getPushTarget(pkg nugetPackage, filePath string) string {
+	return path.Join(dc.repoName, pkg.id, filepath.Base(filePath))
+}
+
+// addPushedArtifacts adds the deployed files of the package to the build-info, in the module of the configuration, or in a module
+// named after the package id.
+func (dc *DotnetCommand) addPushedArtifacts(pkg nugetPackage, artifactsDetailsReader *content.ContentReader) error {
+	artifacts, err := servicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(artifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	for i := range artifacts {
+		artifacts[i].Type = strings.TrimPrefix(path.Ext(artifacts[i].Name), ".")
+		artifacts[i].OriginalDeploymentRepo = dc.repoName
+	}
+	buildName, err := dc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := dc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	moduleName := dc.buildConfiguration.GetModule()
+	if moduleName == "" {
+		moduleName = pkg.id
+	}
+	return commonBuild.SavePartialBuildInfo(buildName, buildNumber, dc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+		partial.ModuleId = moduleName
+		partial.ModuleType = buildinfo.Nuget
+	})
+}
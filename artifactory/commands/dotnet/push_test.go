@@ -0,0 +1,122 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPackage(t *testing.T, packagePath, nuspecContent string) {
+	file, err := os.Create(packagePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(file)
+	nuspecWriter, err := writer.Create("My.Package.nuspec")
+	require.NoError(t, err)
+	_, err = nuspecWriter.Write([]byte(nuspecContent))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+}
+
+const testNuspec = `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>My.Package</id>
+    <version>1.2.3</version>
+  </metadata>
+</package>`
+
+func TestIsPushCommand(t *testing.T) {
+	assert.True(t, (&DotnetCommand{toolchainType: dotnet.Nuget, subCommand: "push"}).isPushCommand())
+	assert.True(t, (&DotnetCommand{toolchainType: dotnet.DotnetCore, subCommand: "nuget", argAndFlags: []string{"push", "a.nupkg"}}).isPushCommand())
+	assert.False(t, (&DotnetCommand{toolchainType: dotnet.DotnetCore, subCommand: "nuget", argAndFlags: []string{"locals", "all"}}).isPushCommand())
+	assert.False(t, (&DotnetCommand{toolchainType: dotnet.Nuget, subCommand: "restore"}).isPushCommand())
+}
+
+func TestFindPushedPackages(t *testing.T) {
+	packagesDir := t.TempDir()
+	createTestPackage(t, filepath.Join(packagesDir, "My.Package.1.2.3.nupkg"), testNuspec)
+	require.NoError(t, os.WriteFile(filepath.Join(packagesDir, "My.Package.1.2.3.snupkg"), []byte("symbols"), 0644))
+
+	packages, err := findPushedPackages([]string{filepath.Join(packagesDir, "*.nupkg"), "-Source", "nuget.org", "--skip-duplicate"})
+	require.NoError(t, err)
+	assert.Equal(t, []nugetPackage{{
+		id:          "My.Package",
+		version:     "1.2.3",
+		path:        filepath.Join(packagesDir, "My.Package.1.2.3.nupkg"),
+		symbolsPath: filepath.Join(packagesDir, "My.Package.1.2.3.snupkg"),
+	}}, packages)
+
+	_, err = findPushedPackages([]string{filepath.Join(packagesDir, "Other.*.nupkg")})
+	assert.ErrorContains(t, err, "no packages matching")
+	_, err = findPushedPackages([]string{"-Source", "nuget.org"})
+	assert.ErrorContains(t, err, "the path of the .nupkg packages to push is missing")
+
+	createTestPackage(t, filepath.Join(packagesDir, "Invalid.nupkg"), "<package><metadata><id>Invalid</id></metadata></package>")
+	_, err = findPushedPackages([]string{filepath.Join(packagesDir, "Invalid.nupkg")})
+	assert.ErrorContains(t, err, "the id and the version of the package")
+}
+
+func TestPush(t *testing.T) {
+	packagesDir := t.TempDir()
+	createTestPackage(t, filepath.Join(packagesDir, "My.Package.1.2.3.nupkg"), testNuspec)
+	require.NoError(t, os.WriteFile(filepath.Join(packagesDir, "My.Package.1.2.3.snupkg"), []byte("symbols"), 0644))
+	var mutex sync.Mutex
+	var deployed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/artifactory/api/system/version" {
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+			return
+		}
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The build properties are sent as matrix parameters.
+		deployedPath, props, _ := strings.Cut(r.URL.Path, ";")
+		assert.Contains(t, props, "build.name=nuget-push;build.number=1;")
+		mutex.Lock()
+		deployed = append(deployed, deployedPath)
+		mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"checksums":{"sha1":"sha1","md5":"md5","sha256":"sha256"}}`))
+	}))
+	defer server.Close()
+
+	buildConfiguration := commonBuild.NewBuildConfiguration("nuget-push", "1", "", "")
+	buildDir, err := commonBuild.GetBuildDir("nuget-push", "1", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(buildDir))
+	}()
+	nugetCmd := NewNugetCommand()
+	nugetCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).SetRepoName("nuget-local").
+		SetBuildConfiguration(buildConfiguration).SetBasicCommand("push").
+		SetArgAndFlags([]string{filepath.Join(packagesDir, "My.Package.1.2.3.nupkg"), "-Source", "JFrogCli"})
+	require.NoError(t, nugetCmd.Run())
+	assert.ElementsMatch(t, []string{"/artifactory/nuget-local/My.Package/My.Package.1.2.3.nupkg", "/artifactory/nuget-local/My.Package/My.Package.1.2.3.snupkg"}, deployed)
+
+	partials, err := commonBuild.ReadPartialBuildInfoFiles("nuget-push", "1", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, "My.Package", partials[0].ModuleId)
+	assert.Equal(t, buildinfo.Nuget, partials[0].ModuleType)
+	require.Len(t, partials[0].Artifacts, 2)
+	for _, artifact := range partials[0].Artifacts {
+		assert.Equal(t, "nuget-local", artifact.OriginalDeploymentRepo)
+		assert.Equal(t, "sha256", artifact.Sha256)
+		assert.Contains(t, []string{"nupkg", "snupkg"}, artifact.Type)
+	}
+}
@@ -0,0 +1,331 @@
+package dotnet
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	verifyLockFileFlag = "verify-lock-file"
+	restoreCommand     = "restore"
+	lockFileName       = "packages.lock.json"
+	// The packages of the lock files which are projects of the solution rather than packages.
+	projectPackageType = "Project"
+	// The number of packages which are looked up in Artifactory by each AQL query.
+	lookupBatchSize = 100
+
+	// RestoreVerificationProp is the build-info environment property which records the result of the verification of the
+	// restored packages, as a JSON RestoreVerification.
+	RestoreVerificationProp = "buildInfo.nuget.restoreVerification"
+)
+
+// The directories of the build outputs, which may contain copies of the lock files.
+var skippedLockFileDirs = []string{"bin", "obj", ".git"}
+
+type lockFile struct {
+	// The locked packages of each target framework, by their ids.
+	Dependencies map[string]map[string]lockedPackage `json:"dependencies"`
+}
+
+type lockedPackage struct {
+	Type     string `json:"type"`
+	Resolved string `json:"resolved"`
+	// The base64 encoded SHA-512 of the .nupkg file.
+	ContentHash string `json:"contentHash"`
+}
+
+// restoredPackage is a package locked by the lock files, and restored to the global packages folder.
+type restoredPackage struct {
+	id          string
+	version     string
+	contentHash string
+	lockFile    string
+	sha256      string
+}
+
+// RestoreVerification is the result of the verification of the restored packages.
+type RestoreVerification struct {
+	LockFiles  []string          `json:"lockFiles"`
+	Verified   int               `json:"verified"`
+	Mismatches []RestoreMismatch `json:"mismatches,omitempty"`
+}
+
+type RestoreMismatch struct {
+	Id      string `json:"id"`
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+func (rm RestoreMismatch) String() string {
+	return fmt.Sprintf("%s %s: %s", rm.Id, rm.Version, rm.Reason)
+}
+
+// extractVerifyLockFile extracts the --verify-lock-file option from the args of the command.
+func (dc *DotnetCommand) extractVerifyLockFile() (err error) {
+	if dc.argAndFlags, dc.verifyLockFile, err = coreutils.ExtractBoolFlagFromArgs(dc.argAndFlags, verifyLockFileFlag); err != nil {
+		return
+	}
+	if dc.verifyLockFile && dc.subCommand != restoreCommand {
+		return errorutils.CheckErrorf("the --%s option is supported only by the %s command", verifyLockFileFlag, restoreCommand)
+	}
+	return
+}
+
+// verifyRestore verifies the restored packages of the lock files of the solution. The SHA-512 of each restored package must match
+// the content hash of the lock file, and its SHA-256 must match the checksum of the package in Artifactory.
+// The result is recorded in the build-info, if it's collected, and an error is returned if any of the packages doesn't match.
+func (dc *DotnetCommand) verifyRestore() (err error) {
+	log.Info("Verifying the restored packages against the lock files and Artifactory...")
+	lockFiles, err := findLockFiles(dc.solutionPath)
+	if err != nil {
+		return err
+	}
+	packages, verification, err := readLockedPackages(lockFiles)
+	if err != nil {
+		return err
+	}
+	globalPackagesDir, err := dc.getGlobalPackagesDir()
+	if err != nil {
+		return err
+	}
+	var restored []*restoredPackage
+	for _, pkg := range packages {
+		if mismatch := verifyRestoredPackage(globalPackagesDir, pkg); mismatch != nil {
+			verification.Mismatches = append(verification.Mismatches, *mismatch)
+			continue
+		}
+		restored = append(restored, pkg)
+	}
+	if len(restored) > 0 {
+		servicesManager, err := rtUtils.CreateServiceManager(dc.serverDetails, -1, 0, false)
+		if err != nil {
+			return err
+		}
+		mismatches, err := verifyArtifactoryChecksums(servicesManager, restored)
+		if err != nil {
+			return err
+		}
+		verification.Mismatches = append(verification.Mismatches, mismatches...)
+	}
+	verification.Verified = len(packages) - countMismatchedPackages(verification.Mismatches)
+	if err = dc.saveRestoreVerification(verification); err != nil {
+		return err
+	}
+	if len(verification.Mismatches) > 0 {
+		details := make([]string, 0, len(verification.Mismatches))
+		for _, mismatch := range verification.Mismatches {
+			details = append(details, mismatch.String())
+		}
+		return errorutils.CheckErrorf("the verification of the restored packages failed:\n%s", strings.Join(details, "\n"))
+	}
+	log.Info(fmt.Sprintf("All the %d restored packages were verified.", verification.Verified))
+	return nil
+}
+
+// findLockFiles returns the lock files of the projects under the solution directory.
+func findLockFiles(solutionPath string) (lockFiles []string, err error) {
+	err = filepath.WalkDir(solutionPath, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != solutionPath && slices.Contains(skippedLockFileDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() == lockFileName {
+			lockFiles = append(lockFiles, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(lockFiles) == 0 {
+		return nil, errorutils.CheckErrorf("no %s files were found in %s. Set RestorePackagesWithLockFile to true in the projects to generate them", lockFileName, solutionPath)
+	}
+	return lockFiles, nil
+}
+
+// readLockedPackages returns the packages of the lock files, each once. A package locked with different content hashes
+// by different lock files is recorded as a mismatch.
+func readLockedPackages(lockFiles []string) ([]*restoredPackage, *RestoreVerification, error) {
+	verification := &RestoreVerification{}
+	packagesByKey := make(map[string]*restoredPackage)
+	var packages []*restoredPackage
+	for _, lockFilePath := range lockFiles {
+		content, err := os.ReadFile(lockFilePath)
+		if err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		var locked lockFile
+		if err = json.Unmarshal(content, &locked); err != nil {
+			return nil, nil, errorutils.CheckErrorf("failed to parse the lock file %s: %s", lockFilePath, err.Error())
+		}
+		verification.LockFiles = append(verification.LockFiles, lockFilePath)
+		for _, frameworkPackages := range locked.Dependencies {
+			for id, lockedPkg := range frameworkPackages {
+				if lockedPkg.Type == projectPackageType {
+					continue
+				}
+				key := strings.ToLower(id + "/" + lockedPkg.Resolved)
+				if existing, exists := packagesByKey[key]; exists {
+					if existing.contentHash != lockedPkg.ContentHash {
+						verification.Mismatches = append(verification.Mismatches, RestoreMismatch{Id: id, Version: lockedPkg.Resolved,
+							Reason: fmt.Sprintf("the content hash in %s doesn't match the one in %s", lockFilePath, existing.lockFile)})
+					}
+					continue
+				}
+				pkg := &restoredPackage{id: id, version: lockedPkg.Resolved, contentHash: lockedPkg.ContentHash, lockFile: lockFilePath}
+				packagesByKey[key] = pkg
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	slices.SortFunc(packages, func(a, b *restoredPackage) int {
+		return strings.Compare(strings.ToLower(a.id+"/"+a.version), strings.ToLower(b.id+"/"+b.version))
+	})
+	return packages, verification, nil
+}
+
+// getGlobalPackagesDir returns the global packages folder, which the packages are restored to. It's set by the packages option
+// of the command, the NUGET_PACKAGES environment variable, or is ~/.nuget/packages by default.
+func (dc *DotnetCommand) getGlobalPackagesDir() (string, error) {
+	packagesFlag := "-PackagesDirectory"
+	if dc.toolchainType == dotnet.DotnetCore {
+		packagesFlag = "--packages"
+	}
+	packagesDir, err := getFlagValueIfExists(packagesFlag, dc.argAndFlags)
+	if err != nil || packagesDir != "" {
+		return packagesDir, err
+	}
+	if packagesDir = os.Getenv("NUGET_PACKAGES"); packagesDir != "" {
+		return packagesDir, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".nuget", "packages"), nil
+}
+
+// verifyRestoredPackage compares the SHA-512 of the restored package with the content hash of the lock file, and calculates its
+// SHA-256. The global packages folder stores the packages at <id>/<version>/<id>.<version>.nupkg, in lower case.
+func verifyRestoredPackage(globalPackagesDir string, pkg *restoredPackage) *RestoreMismatch {
+	id, version := strings.ToLower(pkg.id), strings.ToLower(pkg.version)
+	packagePath := filepath.Join(globalPackagesDir, id, version, id+"."+version+packageExtension)
+	sha512Checksum, sha256Checksum, err := calcPackageChecksums(packagePath)
+	if err != nil {
+		return &RestoreMismatch{Id: pkg.id, Version: pkg.version, Reason: fmt.Sprintf("the restored package can't be read: %s", err.Error())}
+	}
+	if sha512Checksum != pkg.contentHash {
+		return &RestoreMismatch{Id: pkg.id, Version: pkg.version, Reason: fmt.Sprintf("the hash of the restored package doesn't match the content hash in %s", pkg.lockFile)}
+	}
+	pkg.sha256 = sha256Checksum
+	return nil
+}
+
+// calcPackageChecksums returns the base64 encoded SHA-512 of the file, as in the lock files, and its hex encoded SHA-256, as in Artifactory.
+func calcPackageChecksums(packagePath string) (sha512Checksum, sha256Checksum string, err error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	sha512Hash, sha256Hash := sha512.New(), sha256.New()
+	if _, err = io.Copy(io.MultiWriter(sha512Hash, sha256Hash), file); err != nil {
+		return
+	}
+	return base64.StdEncoding.EncodeToString(sha512Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
+
+// verifyArtifactoryChecksums compares the SHA-256 of the restored packages with the checksums of the packages of the same file names
+// in Artifactory. A package is verified if any of the files of its name in Artifactory matches it, since it may be cached in a remote
+// repository and deployed to a local one.
+func verifyArtifactoryChecksums(servicesManager artifactory.ArtifactoryServicesManager, packages []*restoredPackage) ([]RestoreMismatch, error) {
+	checksumsByName := make(map[string][]string)
+	for batch := range slices.Chunk(packages, lookupBatchSize) {
+		names := make([]string, 0, len(batch))
+		for _, pkg := range batch {
+			names = append(names, fmt.Sprintf(`{"name":%q}`, getPackageFileName(pkg)))
+		}
+		results, err := utils.NewAqlPager(servicesManager, fmt.Sprintf(`"type":"file","$or":[%s]`, strings.Join(names, ","))).
+			SetInclude("sha256").All()
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			name := strings.ToLower(result.Name)
+			checksumsByName[name] = append(checksumsByName[name], result.Sha256)
+		}
+	}
+	var mismatches []RestoreMismatch
+	for _, pkg := range packages {
+		checksums, exists := checksumsByName[strings.ToLower(getPackageFileName(pkg))]
+		switch {
+		case !exists:
+			mismatches = append(mismatches, RestoreMismatch{Id: pkg.id, Version: pkg.version, Reason: "the package wasn't found in Artifactory"})
+		case !slices.Contains(checksums, pkg.sha256):
+			mismatches = append(mismatches, RestoreMismatch{Id: pkg.id, Version: pkg.version, Reason: "the hash of the restored package doesn't match its checksum in Artifactory"})
+		}
+	}
+	return mismatches, nil
+}
+
+func getPackageFileName(pkg *restoredPackage) string {
+	return pkg.id + "." + pkg.version + packageExtension
+}
+
+func countMismatchedPackages(mismatches []RestoreMismatch) int {
+	mismatched := make(map[string]bool)
+	for _, mismatch := range mismatches {
+		mismatched[strings.ToLower(mismatch.Id+"/"+mismatch.Version)] = true
+	}
+	return len(mismatched)
+}
+
+// saveRestoreVerification records the result of the verification in the build-info, if it's collected.
+func (dc *DotnetCommand) saveRestoreVerification(verification *RestoreVerification) error {
+	collectBuildInfo, err := dc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	content, err := json.Marshal(verification)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	buildName, err := dc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := dc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return commonBuild.SavePartialBuildInfo(buildName, buildNumber, dc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Env = buildinfo.Env{RestoreVerificationProp: string(content)}
+	})
+}
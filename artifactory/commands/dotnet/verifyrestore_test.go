@@ -0,0 +1,131 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestRestore restores the packages to the global packages folder, and creates the lock file of a project which locks them.
+func createTestRestore(t *testing.T, packages map[string]string) (solutionPath string, sha256ByName map[string]string) {
+	solutionPath = t.TempDir()
+	globalPackagesDir := t.TempDir()
+	t.Setenv("NUGET_PACKAGES", globalPackagesDir)
+	sha256ByName = make(map[string]string)
+	locked := map[string]lockedPackage{"My.Project": {Type: projectPackageType}}
+	for id, version := range packages {
+		packageDir := filepath.Join(globalPackagesDir, strings.ToLower(id), version)
+		require.NoError(t, os.MkdirAll(packageDir, 0755))
+		packagePath := filepath.Join(packageDir, strings.ToLower(id)+"."+version+packageExtension)
+		require.NoError(t, os.WriteFile(packagePath, []byte(id+" "+version), 0644))
+		sha512Checksum, sha256Checksum, err := calcPackageChecksums(packagePath)
+		require.NoError(t, err)
+		sha256ByName[id+"."+version+packageExtension] = sha256Checksum
+		locked[id] = lockedPackage{Type: "Direct", Resolved: version, ContentHash: sha512Checksum}
+	}
+	content, err := json.Marshal(lockFile{Dependencies: map[string]map[string]lockedPackage{"net8.0": locked}})
+	require.NoError(t, err)
+	projectDir := filepath.Join(solutionPath, "MyProject")
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "obj"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, lockFileName), content, 0644))
+	// Copies of the lock files in the build outputs are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "obj", lockFileName), []byte("invalid"), 0644))
+	return
+}
+
+func createTestAqlServer(t *testing.T, sha256ByName map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/search/aql":
+			query, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var results []string
+			for name, sha256Checksum := range sha256ByName {
+				if strings.Contains(string(query), fmt.Sprintf(`{"name":%q}`, name)) {
+					results = append(results, fmt.Sprintf(`{"repo":"nuget-remote-cache","path":".","name":%q,"sha256":%q}`, name, sha256Checksum))
+				}
+			}
+			_, _ = w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestExtractVerifyLockFile(t *testing.T) {
+	dc := &DotnetCommand{subCommand: restoreCommand, argAndFlags: []string{"--verify-lock-file", "--locked-mode"}}
+	require.NoError(t, dc.extractVerifyLockFile())
+	assert.True(t, dc.verifyLockFile)
+	assert.Equal(t, []string{"--locked-mode"}, dc.argAndFlags)
+
+	dc = &DotnetCommand{subCommand: "build", argAndFlags: []string{"--verify-lock-file"}}
+	assert.ErrorContains(t, dc.extractVerifyLockFile(), "supported only by the restore command")
+}
+
+func TestVerifyRestore(t *testing.T) {
+	solutionPath, sha256ByName := createTestRestore(t, map[string]string{"Newtonsoft.Json": "13.0.3", "Serilog": "3.1.1"})
+	server := createTestAqlServer(t, sha256ByName)
+	defer server.Close()
+	buildDir, err := commonBuild.GetBuildDir("nuget-verify-restore", "1", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(buildDir))
+	}()
+
+	dc := &DotnetCommand{solutionPath: solutionPath, serverDetails: &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"},
+		buildConfiguration: commonBuild.NewBuildConfiguration("nuget-verify-restore", "1", "", "")}
+	require.NoError(t, dc.verifyRestore())
+
+	// A package whose checksum in Artifactory doesn't match the restored one, and a package which isn't in Artifactory.
+	sha256ByName["Serilog.3.1.1.nupkg"] = "other"
+	delete(sha256ByName, "Newtonsoft.Json.13.0.3.nupkg")
+	err = dc.verifyRestore()
+	assert.ErrorContains(t, err, "Newtonsoft.Json 13.0.3: the package wasn't found in Artifactory")
+	assert.ErrorContains(t, err, "Serilog 3.1.1: the hash of the restored package doesn't match its checksum in Artifactory")
+
+	partials, err := commonBuild.ReadPartialBuildInfoFiles("nuget-verify-restore", "1", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 2)
+	var mismatches []int
+	for _, partial := range partials {
+		var verification RestoreVerification
+		require.NoError(t, json.Unmarshal([]byte(partial.Env[RestoreVerificationProp]), &verification))
+		assert.Equal(t, []string{filepath.Join(solutionPath, "MyProject", lockFileName)}, verification.LockFiles)
+		assert.Equal(t, 2, verification.Verified+len(verification.Mismatches))
+		mismatches = append(mismatches, len(verification.Mismatches))
+	}
+	assert.ElementsMatch(t, []int{0, 2}, mismatches)
+}
+
+func TestVerifyRestoredPackage(t *testing.T) {
+	solutionPath, _ := createTestRestore(t, map[string]string{"Serilog": "3.1.1"})
+	lockFiles, err := findLockFiles(solutionPath)
+	require.NoError(t, err)
+	packages, _, err := readLockedPackages(lockFiles)
+	require.NoError(t, err)
+	require.Len(t, packages, 1)
+	assert.Nil(t, verifyRestoredPackage(os.Getenv("NUGET_PACKAGES"), packages[0]))
+	assert.NotEmpty(t, packages[0].sha256)
+
+	// The restored package was modified after it was locked.
+	require.NoError(t, os.WriteFile(filepath.Join(os.Getenv("NUGET_PACKAGES"), "serilog", "3.1.1", "serilog.3.1.1.nupkg"), []byte("tampered"), 0644))
+	mismatch := verifyRestoredPackage(os.Getenv("NUGET_PACKAGES"), packages[0])
+	require.NotNil(t, mismatch)
+	assert.Contains(t, mismatch.Reason, "doesn't match the content hash")
+
+	_, err = findLockFiles(t.TempDir())
+	assert.ErrorContains(t, err, "RestorePackagesWithLockFile")
+}
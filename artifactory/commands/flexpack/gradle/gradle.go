@@ -24,7 +24,43 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+// GradleCollectOptions controls the build info collected by CollectGradleBuildInfoWithOptions.
+type GradleCollectOptions struct {
+	// Collect the build info of the builds included by the settings file using includeBuild, in addition to the root build.
+	// Tasks addressed to an included build (e.g. ":included-build:publish") are applied to that build only.
+	IncludeCompositeBuilds bool
+	// Record the complete paths of the dependencies in the resolved dependency graph of the compileClasspath,
+	// runtimeClasspath and testRuntimeClasspath configurations, and the versions changed by the conflict resolution.
+	DependencyGraph bool
+}
+
 func CollectGradleBuildInfoWithFlexPack(workingDir, buildName, buildNumber string, tasks []string, buildConfiguration *buildUtils.BuildConfiguration, serverDetails *config.ServerDetails) error {
+	return CollectGradleBuildInfoWithOptions(workingDir, buildName, buildNumber, tasks, buildConfiguration, serverDetails, GradleCollectOptions{})
+}
+
+func CollectGradleBuildInfoWithOptions(workingDir, buildName, buildNumber string, tasks []string, buildConfiguration *buildUtils.BuildConfiguration, serverDetails *config.ServerDetails, options GradleCollectOptions) error {
+	if err := collectGradleBuildInfo(workingDir, buildName, buildNumber, tasks, buildConfiguration, serverDetails, options); err != nil {
+		return err
+	}
+	if options.IncludeCompositeBuilds {
+		includedBuilds, err := findIncludedBuilds(workingDir)
+		if err != nil {
+			return err
+		}
+		for _, includedBuild := range includedBuilds {
+			log.Info("Collecting build info for the included build: " + includedBuild.name)
+			includedTasks := filterIncludedBuildTasks(tasks, includedBuild.name)
+			if err = collectGradleBuildInfo(includedBuild.dir, buildName, buildNumber, includedTasks, buildConfiguration, serverDetails, options); err != nil {
+				return fmt.Errorf("included build %s: %w", includedBuild.name, err)
+			}
+		}
+	}
+	log.Info("Build info saved locally. Use 'jf rt bp " + buildName + " " + buildNumber + "' to publish it to Artifactory.")
+	log.Info("Gradle build completed successfully")
+	return nil
+}
+
+func collectGradleBuildInfo(workingDir, buildName, buildNumber string, tasks []string, buildConfiguration *buildUtils.BuildConfiguration, serverDetails *config.ServerDetails, options GradleCollectOptions) error {
 	if workingDir == "" {
 		return fmt.Errorf("working directory is required")
 	}
@@ -55,6 +91,11 @@ func CollectGradleBuildInfoWithFlexPack(workingDir, buildName, buildNumber strin
 	if err != nil {
 		return fmt.Errorf("failed to collect build info with FlexPack")
 	}
+	if options.DependencyGraph {
+		if err := setDependencyGraph(buildInfo, workingDir); err != nil {
+			log.Warn("Failed to collect the Gradle dependency graph: " + err.Error())
+		}
+	}
 
 	projectKey := ""
 	if buildConfiguration != nil {
@@ -69,8 +110,6 @@ func CollectGradleBuildInfoWithFlexPack(workingDir, buildName, buildNumber strin
 
 	if err := saveGradleFlexPackBuildInfo(buildInfo, projectKey); err != nil {
 		return fmt.Errorf("failed to save build info for jfrog-cli compatibility")
-	} else {
-		log.Info("Build info saved locally. Use 'jf rt bp " + buildName + " " + buildNumber + "' to publish it to Artifactory.")
 	}
 
 	if isPublishCommand {
@@ -78,7 +117,6 @@ func CollectGradleBuildInfoWithFlexPack(workingDir, buildName, buildNumber strin
 			log.Warn("Failed to set build properties on deployed artifacts")
 		}
 	}
-	log.Info("Gradle build completed successfully")
 	return nil
 }
 
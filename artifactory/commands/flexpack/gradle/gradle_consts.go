@@ -5,6 +5,7 @@ const (
 
 	// File Names
 	gradlePropertiesFileName = "gradle.properties"
+	settingsFileBaseName     = "settings"
 
 	// Directories
 	initDDirName   = "init.d"
@@ -34,4 +35,5 @@ const (
 	blockUploadArchives   = "uploadArchives"
 	blockDepResManagement = "dependencyResolutionManagement"
 	blockExt              = "ext"
+	blockPluginManagement = "pluginManagement"
 )
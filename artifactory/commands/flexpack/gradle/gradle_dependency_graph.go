@@ -0,0 +1,238 @@
+package flexpack
+
+// Record the resolved dependency graph of the Gradle configurations in the build-info: the complete paths from each
+// dependency to its module, and the requested versions which were changed by the Gradle conflict resolution.
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	buildinfoflexpack "github.com/jfrog/build-info-go/flexpack/gradle"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	moduleNameProp = "moduleName"
+	// Module property recording a version changed by the conflict resolution, e.g. "gradle.resolution.runtimeClasspath.org.slf4j:slf4j-api" = "1.7.30 -> 2.0.9"
+	resolutionPropPrefix = "gradle.resolution."
+)
+
+var dependencyGraphConfigurations = []string{"compileClasspath", "runtimeClasspath", "testRuntimeClasspath"}
+
+// Runs the dependencies task of the module for the configuration, and returns its output.
+type dependenciesTaskRunner func(moduleName, configuration string) ([]byte, error)
+
+// dependencyGraph is the resolved dependency graph of a module, merged over its configurations.
+type dependencyGraph struct {
+	moduleId string
+	// The dependencies requesting each dependency. The direct dependencies are requested by the module.
+	parents map[string][]string
+	// The requested versions changed by the conflict resolution, per configuration and "group:module".
+	resolutions map[string]map[string]string
+}
+
+func newDependencyGraph(moduleId string) *dependencyGraph {
+	return &dependencyGraph{moduleId: moduleId, parents: make(map[string][]string), resolutions: make(map[string]map[string]string)}
+}
+
+// setDependencyGraph replaces the requested-by paths of the dependencies of the build-info modules with their complete
+// paths in the resolved dependency graph, and records the versions changed by the conflict resolution in the module properties.
+func setDependencyGraph(buildInfo *entities.BuildInfo, workingDir string) error {
+	gradleExecPath, err := buildinfoflexpack.GetGradleExecutablePath(workingDir)
+	if err != nil {
+		return err
+	}
+	runDependenciesTask := func(moduleName, configuration string) ([]byte, error) {
+		task := "dependencies"
+		if moduleName != "" {
+			task = ":" + moduleName + ":" + task
+		}
+		cmd := exec.Command(gradleExecPath, task, "--configuration", configuration, "--quiet")
+		cmd.Dir = workingDir
+		return cmd.Output()
+	}
+	applyDependencyGraph(buildInfo, runDependenciesTask)
+	return nil
+}
+
+func applyDependencyGraph(buildInfo *entities.BuildInfo, runDependenciesTask dependenciesTaskRunner) {
+	// Project dependencies are referenced by their path in the dependencies task output.
+	moduleIds := make(map[string]string)
+	for _, module := range buildInfo.Modules {
+		moduleIds[getModuleName(module)] = module.Id
+	}
+	for i := range buildInfo.Modules {
+		module := &buildInfo.Modules[i]
+		moduleName := getModuleName(*module)
+		graph := newDependencyGraph(module.Id)
+		for _, configuration := range dependencyGraphConfigurations {
+			output, err := runDependenciesTask(moduleName, configuration)
+			if err != nil {
+				log.Debug(fmt.Sprintf("Failed to get the dependency graph of module '%s' for configuration %s: %s", moduleName, configuration, err.Error()))
+				continue
+			}
+			graph.addDependenciesTaskOutput(configuration, string(output), moduleIds)
+		}
+		for j := range module.Dependencies {
+			if paths := graph.requestedBy(module.Dependencies[j].Id); len(paths) > 0 {
+				module.Dependencies[j].RequestedBy = paths
+			}
+		}
+		setResolutionProperties(module, graph.resolutions)
+	}
+}
+
+// addDependenciesTaskOutput adds the dependency tree printed by the dependencies task to the graph.
+// Example of the tree:
+//
+//	+--- org.apache.commons:commons-lang3:3.12.0
+//	\--- com.fasterxml.jackson.core:jackson-databind:2.15.0
+//	     +--- com.fasterxml.jackson.core:jackson-annotations:2.15.0 -> 2.15.2
+//	     \--- com.fasterxml.jackson.core:jackson-core:2.15.0 (*)
+func (dg *dependencyGraph) addDependenciesTaskOutput(configuration, output string, moduleIds map[string]string) {
+	// The dependency at each depth of the current branch. An empty ID marks an omitted dependency, whose subtree is skipped.
+	var branch []string
+	for _, line := range strings.Split(output, "\n") {
+		markerIdx := strings.Index(line, "+--- ")
+		if markerIdx == -1 {
+			markerIdx = strings.Index(line, "\\--- ")
+		}
+		if markerIdx == -1 || markerIdx%5 != 0 {
+			continue
+		}
+		depth := markerIdx / 5
+		if depth > len(branch) {
+			// The parent wasn't parsed.
+			continue
+		}
+		branch = branch[:depth]
+		if depth > 0 && branch[depth-1] == "" {
+			branch = append(branch, "")
+			continue
+		}
+		id, requested, resolved := parseDependencyLine(strings.TrimSpace(line[markerIdx+5:]), moduleIds)
+		branch = append(branch, id)
+		if id == "" {
+			continue
+		}
+		parent := dg.moduleId
+		if depth > 0 {
+			parent = branch[depth-1]
+		}
+		dg.addParent(id, parent)
+		if requested != "" && requested != resolved {
+			if dg.resolutions[configuration] == nil {
+				dg.resolutions[configuration] = make(map[string]string)
+			}
+			dg.resolutions[configuration][strings.Join(strings.Split(id, ":")[:2], ":")] = requested + " -> " + resolved
+		}
+	}
+}
+
+func (dg *dependencyGraph) addParent(id, parent string) {
+	for _, existing := range dg.parents[id] {
+		if existing == parent {
+			return
+		}
+	}
+	dg.parents[id] = append(dg.parents[id], parent)
+}
+
+// requestedBy returns the paths from the dependency to the module. Each path starts with the dependency which requested
+// it directly, and ends with the module. The number of paths is limited by entities.RequestedByMaxLength.
+func (dg *dependencyGraph) requestedBy(id string) [][]string {
+	var paths [][]string
+	var walk func(current string, path []string, visited map[string]bool)
+	walk = func(current string, path []string, visited map[string]bool) {
+		parents := dg.parents[current]
+		sort.Strings(parents)
+		for _, parent := range parents {
+			if len(paths) >= entities.RequestedByMaxLength {
+				return
+			}
+			if visited[parent] {
+				continue
+			}
+			parentPath := append(append([]string{}, path...), parent)
+			if parent == dg.moduleId {
+				paths = append(paths, parentPath)
+				continue
+			}
+			visited[parent] = true
+			walk(parent, parentPath, visited)
+			delete(visited, parent)
+		}
+	}
+	walk(id, nil, map[string]bool{id: true})
+	return paths
+}
+
+// parseDependencyLine parses a dependency of the dependencies task output, and returns its ID with the resolved version.
+// The ID is empty for dependencies which aren't resolved, such as constraints (c) and unresolved dependencies (n).
+func parseDependencyLine(content string, moduleIds map[string]string) (id, requestedVersion, resolvedVersion string) {
+	content = strings.TrimSuffix(content, " (*)")
+	if strings.HasSuffix(content, " (c)") || strings.HasSuffix(content, " (n)") || strings.HasSuffix(content, " FAILED") {
+		return
+	}
+	if atIdx := strings.LastIndex(content, "@"); atIdx != -1 {
+		content = content[:atIdx]
+	}
+	if arrowIdx := strings.Index(content, " -> "); arrowIdx != -1 {
+		resolvedVersion = strings.TrimSpace(content[arrowIdx+4:])
+		content = strings.TrimSpace(content[:arrowIdx])
+	}
+	if projectPath, isProject := strings.CutPrefix(content, "project "); isProject {
+		id = moduleIds[strings.TrimPrefix(projectPath, ":")]
+		return
+	}
+	parts := strings.Split(content, ":")
+	if len(parts) < 2 {
+		return
+	}
+	if len(parts) > 2 {
+		requestedVersion = parts[2]
+	}
+	if resolvedVersion == "" {
+		resolvedVersion = requestedVersion
+	}
+	if resolvedVersion == "" {
+		return
+	}
+	idParts := []string{parts[0], parts[1], resolvedVersion}
+	// Classifier
+	if len(parts) > 3 {
+		idParts = append(idParts, parts[3])
+	}
+	id = strings.Join(idParts, ":")
+	return
+}
+
+func getModuleName(module entities.Module) string {
+	if props, ok := module.Properties.(map[string]string); ok {
+		return props[moduleNameProp]
+	}
+	return ""
+}
+
+func setResolutionProperties(module *entities.Module, resolutions map[string]map[string]string) {
+	if len(resolutions) == 0 {
+		return
+	}
+	props, ok := module.Properties.(map[string]string)
+	if !ok {
+		if module.Properties != nil {
+			log.Debug("Skipping the conflict resolutions of module " + module.Id + ", since its properties have an unexpected type")
+			return
+		}
+		props = make(map[string]string)
+		module.Properties = props
+	}
+	for configuration, configurationResolutions := range resolutions {
+		for dependency, resolution := range configurationResolutions {
+			props[resolutionPropPrefix+configuration+"."+dependency] = resolution
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package flexpack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+const runtimeClasspathOutput = `
+runtimeClasspath - Runtime classpath of source set 'main'.
++--- project :lib
+|    \--- org.slf4j:slf4j-api:1.7.30 -> 2.0.9
++--- com.fasterxml.jackson.core:jackson-databind:2.15.0
+|    +--- com.fasterxml.jackson.core:jackson-annotations:2.15.0 -> 2.15.2
+|    +--- org.slf4j:slf4j-api:2.0.9 (*)
+|    \--- com.fasterxml.jackson:jackson-bom:2.15.0 (c)
+|         \--- com.fasterxml.jackson.core:jackson-core:2.15.0
+\--- org.slf4j:slf4j-api:2.0.9
+`
+
+func TestParseDependencyLine(t *testing.T) {
+	moduleIds := map[string]string{"libs:lib": "com.example:lib:1.0"}
+	tests := []struct {
+		content, id, requested, resolved string
+	}{
+		{"org.slf4j:slf4j-api:1.7.30", "org.slf4j:slf4j-api:1.7.30", "1.7.30", "1.7.30"},
+		{"org.slf4j:slf4j-api:1.7.30 -> 2.0.9 (*)", "org.slf4j:slf4j-api:2.0.9", "1.7.30", "2.0.9"},
+		{"org.slf4j:slf4j-api -> 2.0.9", "org.slf4j:slf4j-api:2.0.9", "", "2.0.9"},
+		{"com.google:lib:1.0:jdk8@aar", "com.google:lib:1.0:jdk8", "1.0", "1.0"},
+		{"project :libs:lib", "com.example:lib:1.0", "", ""},
+		{"project :unknown", "", "", ""},
+		{"org.slf4j:slf4j-api:2.0.9 (c)", "", "", ""},
+		{"org.slf4j:slf4j-api:2.0.9 (n)", "", "", ""},
+		{"org.slf4j:slf4j-api:9.9.9 FAILED", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.content, func(t *testing.T) {
+			id, requested, resolved := parseDependencyLine(test.content, moduleIds)
+			assert.Equal(t, test.id, id)
+			assert.Equal(t, test.requested, requested)
+			assert.Equal(t, test.resolved, resolved)
+		})
+	}
+}
+
+func TestApplyDependencyGraph(t *testing.T) {
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{
+		{
+			Id: "com.example:app:1.0",
+			Dependencies: []entities.Dependency{
+				{Id: "org.slf4j:slf4j-api:2.0.9", RequestedBy: [][]string{{"com.fasterxml.jackson.core:jackson-databind:2.15.0"}}},
+				{Id: "com.fasterxml.jackson.core:jackson-annotations:2.15.2"},
+				{Id: "com.fasterxml.jackson.core:jackson-core:2.15.0"},
+			},
+		},
+		{Id: "com.example:lib:1.0", Properties: map[string]string{moduleNameProp: "lib"}},
+	}}
+	applyDependencyGraph(buildInfo, func(moduleName, configuration string) ([]byte, error) {
+		if moduleName == "" && configuration == "runtimeClasspath" {
+			return []byte(runtimeClasspathOutput), nil
+		}
+		return nil, errors.New("no output")
+	})
+
+	app := buildInfo.Modules[0]
+	assert.Equal(t, [][]string{
+		{"com.example:app:1.0"},
+		{"com.example:lib:1.0", "com.example:app:1.0"},
+		{"com.fasterxml.jackson.core:jackson-databind:2.15.0", "com.example:app:1.0"},
+	}, app.Dependencies[0].RequestedBy)
+	assert.Equal(t, [][]string{{"com.fasterxml.jackson.core:jackson-databind:2.15.0", "com.example:app:1.0"}}, app.Dependencies[1].RequestedBy)
+	// Dependencies under omitted dependencies are skipped.
+	assert.Nil(t, app.Dependencies[2].RequestedBy)
+	assert.Equal(t, map[string]string{
+		"gradle.resolution.runtimeClasspath.org.slf4j:slf4j-api":                            "1.7.30 -> 2.0.9",
+		"gradle.resolution.runtimeClasspath.com.fasterxml.jackson.core:jackson-annotations": "2.15.0 -> 2.15.2",
+	}, app.Properties)
+	assert.Equal(t, map[string]string{moduleNameProp: "lib"}, buildInfo.Modules[1].Properties)
+}
+
+func TestDependencyGraphRequestedByLimit(t *testing.T) {
+	graph := newDependencyGraph("module")
+	for i := 0; i < entities.RequestedByMaxLength+5; i++ {
+		parent := "parent" + string(rune('a'+i))
+		graph.addParent(parent, "module")
+		graph.addParent("dependency", parent)
+	}
+	assert.Len(t, graph.requestedBy("dependency"), entities.RequestedByMaxLength)
+}
@@ -0,0 +1,93 @@
+package flexpack
+
+// Discover the builds included in a Gradle composite build, by parsing includeBuild in the settings files.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	buildinfoflexpack "github.com/jfrog/build-info-go/flexpack/gradle"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// includeBuildRe matches includeBuild in both Groovy and Kotlin DSL, at the start of a line so that commented out lines are ignored.
+// example: includeBuild("../library") or includeBuild '../library'
+// Capture group 1: included build path
+var includeBuildRe = regexp.MustCompile(`(?m)^\s*includeBuild\s*\(?\s*['"]([^'"]+)['"]`)
+
+type includedBuild struct {
+	// The name of the build, as used in task paths such as ":name:publish".
+	name string
+	dir  string
+}
+
+// findIncludedBuilds returns the builds included by the settings file in rootDir, including nested included builds.
+// Builds included in pluginManagement only provide plugins to the build, so they are not returned.
+func findIncludedBuilds(rootDir string) ([]includedBuild, error) {
+	absRootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve absolute path for %s: %w", rootDir, err)
+	}
+	var builds []includedBuild
+	visited := map[string]bool{absRootDir: true}
+	pendingDirs := []string{absRootDir}
+	for len(pendingDirs) > 0 {
+		dir := pendingDirs[0]
+		pendingDirs = pendingDirs[1:]
+		for _, includedDir := range parseIncludedBuildDirs(dir) {
+			if visited[includedDir] {
+				continue
+			}
+			visited[includedDir] = true
+			if info, err := os.Stat(includedDir); err != nil || !info.IsDir() {
+				log.Warn("Skipping the included build " + includedDir + ", since it is not a directory")
+				continue
+			}
+			builds = append(builds, includedBuild{name: filepath.Base(includedDir), dir: includedDir})
+			pendingDirs = append(pendingDirs, includedDir)
+		}
+	}
+	return builds, nil
+}
+
+// parseIncludedBuildDirs returns the absolute directories of the builds included by the settings file in dir.
+func parseIncludedBuildDirs(dir string) []string {
+	settingsPath, _, err := buildinfoflexpack.FindGradleFile(dir, settingsFileBaseName)
+	if err != nil {
+		return nil
+	}
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		log.Debug("Failed to read " + settingsPath + ": " + err.Error())
+		return nil
+	}
+	settings := string(content)
+	for _, block := range extractAllGradleBlocks(settings, blockPluginManagement) {
+		settings = strings.Replace(settings, block, "", 1)
+	}
+	var dirs []string
+	for _, match := range includeBuildRe.FindAllStringSubmatch(settings, -1) {
+		path := match[1]
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		dirs = append(dirs, filepath.Clean(path))
+	}
+	return dirs
+}
+
+// filterIncludedBuildTasks returns the tasks addressed to the included build.
+// Gradle runs tasks without a build prefix in the root build only.
+func filterIncludedBuildTasks(tasks []string, buildName string) []string {
+	prefix := ":" + buildName + ":"
+	var includedTasks []string
+	for _, task := range tasks {
+		if strings.HasPrefix(task, prefix) {
+			includedTasks = append(includedTasks, task)
+		}
+	}
+	return includedTasks
+}
@@ -0,0 +1,58 @@
+package flexpack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSettingsFile(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestFindIncludedBuilds(t *testing.T) {
+	rootDir := t.TempDir()
+	writeSettingsFile(t, rootDir, "settings.gradle.kts", `
+pluginManagement {
+    includeBuild("build-logic")
+}
+rootProject.name = "app"
+includeBuild("library")
+// includeBuild("commented-out")
+includeBuild("../missing")
+`)
+	writeSettingsFile(t, filepath.Join(rootDir, "build-logic"), "settings.gradle.kts", "")
+	libraryDir := filepath.Join(rootDir, "library")
+	writeSettingsFile(t, libraryDir, "settings.gradle", `
+includeBuild 'nested'
+includeBuild '..'
+`)
+	nestedDir := filepath.Join(libraryDir, "nested")
+	writeSettingsFile(t, nestedDir, "settings.gradle", "")
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "commented-out"), 0755))
+
+	builds, err := findIncludedBuilds(rootDir)
+	require.NoError(t, err)
+	absRootDir, err := filepath.Abs(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []includedBuild{
+		{name: "library", dir: filepath.Join(absRootDir, "library")},
+		{name: "nested", dir: filepath.Join(absRootDir, "library", "nested")},
+	}, builds)
+}
+
+func TestFindIncludedBuildsWithoutSettings(t *testing.T) {
+	builds, err := findIncludedBuilds(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, builds)
+}
+
+func TestFilterIncludedBuildTasks(t *testing.T) {
+	tasks := []string{"clean", "publish", ":library:publish", ":library:sub:build", ":library-extra:publish", "--info"}
+	assert.Equal(t, []string{":library:publish", ":library:sub:build"}, filterIncludedBuildTasks(tasks, "library"))
+	assert.Empty(t, filterIncludedBuildTasks(tasks, "other"))
+}
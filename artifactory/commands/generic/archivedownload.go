@@ -0,0 +1,205 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/gofrog/unarchive"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+var archiveTypes = []string{"zip", "tar", "tar.gz", "tgz"}
+
+// ArchiveDownloadCommand downloads the folders of the spec as archives generated by Artifactory, in a single request per folder,
+// rather than downloading the files of the folders one by one.
+type ArchiveDownloadCommand struct {
+	DownloadCommand
+	archiveType string
+}
+
+func NewArchiveDownloadCommand() *ArchiveDownloadCommand {
+	return &ArchiveDownloadCommand{DownloadCommand: *NewDownloadCommand()}
+}
+
+func (adc *ArchiveDownloadCommand) SetArchiveType(archiveType string) *ArchiveDownloadCommand {
+	adc.archiveType = archiveType
+	return adc
+}
+
+func (adc *ArchiveDownloadCommand) CommandName() string {
+	return "rt_archive_download"
+}
+
+func (adc *ArchiveDownloadCommand) Run() error {
+	return adc.archiveDownload()
+}
+
+// ValidateArchiveType returns an error if Artifactory doesn't generate archives of the type.
+func ValidateArchiveType(archiveType string) error {
+	for _, supportedType := range archiveTypes {
+		if archiveType == supportedType {
+			return nil
+		}
+	}
+	return errorutils.CheckErrorf("the folder archive type must be one of %s, got '%s'", strings.Join(archiveTypes, ", "), archiveType)
+}
+
+func (adc *ArchiveDownloadCommand) archiveDownload() error {
+	if adc.progress != nil {
+		adc.progress.SetHeadlineMsg("")
+		adc.progress.InitProgressReaders()
+	}
+	if err := ValidateArchiveType(adc.archiveType); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(adc.serverDetails, adc.retries, adc.retryWaitTimeMilliSecs, adc.DryRun())
+	if err != nil {
+		return err
+	}
+	toCollect, err := adc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if toCollect {
+		log.Warn("The files downloaded in folder archives aren't recorded as dependencies of the build-info.")
+	}
+
+	var errorOccurred = false
+	var totalDownloaded, totalFailed int
+	for i := 0; i < len(adc.Spec().Files); i++ {
+		if err = adc.downloadFolder(servicesManager, adc.Spec().Get(i)); err != nil {
+			errorOccurred = true
+			totalFailed++
+			log.Error(err)
+			continue
+		}
+		totalDownloaded++
+	}
+	adc.result.SetSuccessCount(totalDownloaded)
+	adc.result.SetFailCount(totalFailed)
+	if errorOccurred {
+		return errors.New("download finished with errors, please review the logs")
+	}
+	return nil
+}
+
+// downloadFolder downloads the archive of the folder of the file spec. The archive is written to the target of the spec, or
+// extracted into it if explode is set.
+func (adc *ArchiveDownloadCommand) downloadFolder(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File) (err error) {
+	if file.Build != "" || file.Bundle != "" || file.Aql.ItemsFind != "" {
+		return errorutils.CheckErrorf("folder archives can be downloaded only by the pattern of the folder")
+	}
+	folderPath := strings.Trim(file.Pattern, "/")
+	if folderPath == "" || strings.ContainsAny(folderPath, "*?") {
+		return errorutils.CheckErrorf("the pattern '%s' must be the path of a folder, without wildcards, to download it as an archive", file.Pattern)
+	}
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return err
+	}
+	explode, err := file.IsExplode(false)
+	if err != nil {
+		return err
+	}
+	archiveName := path.Base(folderPath) + "." + adc.archiveType
+	folderLocalPath, archiveLocalPath := getArchiveLocalPaths(folderPath, archiveName, file.Target, flat)
+	if !explode {
+		if adc.DryRun() {
+			log.Info(fmt.Sprintf("[Dry run] Downloading the %s archive of %s to %s", adc.archiveType, folderPath, archiveLocalPath))
+			return nil
+		}
+		log.Info(fmt.Sprintf("Downloading the %s archive of %s to %s...", adc.archiveType, folderPath, archiveLocalPath))
+		return adc.downloadArchive(servicesManager, folderPath, archiveLocalPath)
+	}
+	if adc.DryRun() {
+		log.Info(fmt.Sprintf("[Dry run] Downloading the %s archive of %s and extracting it to %s", adc.archiveType, folderPath, folderLocalPath))
+		return nil
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	archivePath := filepath.Join(tempDir, archiveName)
+	log.Info(fmt.Sprintf("Downloading the %s archive of %s and extracting it to %s...", adc.archiveType, folderPath, folderLocalPath))
+	if err = adc.downloadArchive(servicesManager, folderPath, archivePath); err != nil {
+		return err
+	}
+	bypassInspection, err := file.IsBypassArchiveInspection(false)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(folderLocalPath, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	unarchiver := &unarchive.Unarchiver{BypassInspection: bypassInspection}
+	if err = unarchiver.Unarchive(archivePath, archiveName, folderLocalPath); err != nil {
+		return errorutils.CheckErrorf("failed to extract the archive of %s: %s", folderPath, err.Error())
+	}
+	return nil
+}
+
+// getArchiveLocalPaths returns the local path the folder is extracted to, and the path its archive is downloaded to.
+// Like the downloaded files, the folder is placed under its path in the repository, unless flat is set. A target which
+// doesn't end with a slash is the path of the archive itself.
+func getArchiveLocalPaths(folderPath, archiveName, target string, flat bool) (folderLocalPath, archiveLocalPath string) {
+	if target == "" {
+		target = "." + string(filepath.Separator)
+	}
+	if !strings.HasSuffix(target, "/") && !strings.HasSuffix(target, string(filepath.Separator)) {
+		return target, target
+	}
+	if flat {
+		return target, filepath.Join(target, archiveName)
+	}
+	_, pathInRepo, _ := strings.Cut(folderPath, "/")
+	parentLocalPath := filepath.Join(target, filepath.FromSlash(path.Dir(pathInRepo)))
+	return filepath.Join(target, filepath.FromSlash(pathInRepo)), filepath.Join(parentLocalPath, archiveName)
+}
+
+// downloadArchive streams the archive of the folder, generated by Artifactory, to the local path.
+func (adc *ArchiveDownloadCommand) downloadArchive(servicesManager artifactory.ArtifactoryServicesManager, folderPath, localPath string) (err error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestUrl, err := clientutils.BuildUrl(serviceDetails.GetUrl(), "api/archive/download/"+folderPath, map[string]string{"archiveType": adc.archiveType})
+	if err != nil {
+		return err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	reader, resp, err := servicesManager.Client().ReadRemoteFile(requestUrl, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, readErr := io.ReadAll(resp.Body)
+		return errors.Join(errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK), errorutils.CheckError(readErr), errorutils.CheckError(resp.Body.Close()))
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,88 @@
+package generic
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetArchiveLocalPaths(t *testing.T) {
+	folderLocalPath, archiveLocalPath := getArchiveLocalPaths("generic-local/releases/app", "app.zip", "out/", false)
+	assert.Equal(t, filepath.Join("out", "releases", "app"), folderLocalPath)
+	assert.Equal(t, filepath.Join("out", "releases", "app.zip"), archiveLocalPath)
+
+	folderLocalPath, archiveLocalPath = getArchiveLocalPaths("generic-local/releases/app", "app.zip", "out/", true)
+	assert.Equal(t, "out/", folderLocalPath)
+	assert.Equal(t, filepath.Join("out", "app.zip"), archiveLocalPath)
+
+	_, archiveLocalPath = getArchiveLocalPaths("generic-local", "generic-local.zip", "", false)
+	assert.Equal(t, "generic-local.zip", archiveLocalPath)
+	_, archiveLocalPath = getArchiveLocalPaths("generic-local/releases/app", "app.zip", "out/app-1.0.zip", false)
+	assert.Equal(t, "out/app-1.0.zip", archiveLocalPath)
+}
+
+func TestArchiveDownload(t *testing.T) {
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	entry, err := zipWriter.Create("bin/app.sh")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte("echo app"))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/archive/download/generic-local/releases/app":
+			if r.URL.Query().Get("archiveType") != "zip" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write(archive.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"status":404,"message":"Not Found"}]}`))
+		}
+	}))
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+	target := t.TempDir() + string(filepath.Separator)
+
+	downloadSpec := spec.NewBuilder().Pattern("generic-local/releases/app/").Target(target).BuildSpec()
+	command := NewArchiveDownloadCommand().SetArchiveType("zip")
+	command.SetServerDetails(serverDetails).SetSpec(downloadSpec)
+	require.NoError(t, command.Run())
+	content, err := os.ReadFile(filepath.Join(target, "releases", "app.zip"))
+	require.NoError(t, err)
+	assert.Equal(t, archive.Bytes(), content)
+	assert.Equal(t, 1, command.Result().SuccessCount())
+
+	// The archive is extracted into the path of the folder when explode is set.
+	downloadSpec = spec.NewBuilder().Pattern("generic-local/releases/app").Target(target).Explode("true").BuildSpec()
+	command = NewArchiveDownloadCommand().SetArchiveType("zip")
+	command.SetServerDetails(serverDetails).SetSpec(downloadSpec)
+	require.NoError(t, command.Run())
+	content, err = os.ReadFile(filepath.Join(target, "releases", "app", "bin", "app.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, "echo app", string(content))
+
+	downloadSpec = spec.NewBuilder().Pattern("generic-local/releases/missing").Target(target).BuildSpec()
+	command = NewArchiveDownloadCommand().SetArchiveType("zip")
+	command.SetServerDetails(serverDetails).SetSpec(downloadSpec)
+	assert.Error(t, command.Run())
+	assert.Equal(t, 1, command.Result().FailCount())
+
+	downloadSpec = spec.NewBuilder().Pattern("generic-local/releases/*").Target(target).BuildSpec()
+	assert.ErrorContains(t, NewArchiveDownloadCommand().SetArchiveType("zip").downloadFolder(nil, downloadSpec.Get(0)), "without wildcards")
+	assert.ErrorContains(t, ValidateArchiveType("7z"), "must be one of zip, tar, tar.gz, tgz")
+}
@@ -0,0 +1,397 @@
+package generic
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DiffEntryAdded   = "added"
+	DiffEntryRemoved = "removed"
+	DiffEntryChanged = "changed"
+
+	// Archives nested deeper than this are compared by their checksums only.
+	maxNestedArchiveDepth = 5
+	// Separates the path of a nested archive from the path of its entry, as in app.war!/WEB-INF/lib/lib.jar!/Lib.class.
+	nestedEntrySeparator = "!/"
+)
+
+type archiveKind int
+
+const (
+	notArchive archiveKind = iota
+	zipArchive
+	tarArchive
+	tarGzArchive
+)
+
+// ArtifactDiffEntry is a file which differs between the compared artifacts.
+type ArtifactDiffEntry struct {
+	Path         string `json:"path"`
+	Status       string `json:"status"`
+	SourceSha256 string `json:"sourceSha256,omitempty"`
+	TargetSha256 string `json:"targetSha256,omitempty"`
+}
+
+type ArtifactDiffResult struct {
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	SourceSha256 string `json:"sourceSha256"`
+	TargetSha256 string `json:"targetSha256"`
+	// True if the artifacts have the same content. Archives with the same entries are identical,
+	// even if their checksums differ, for example due to the timestamps of the entries.
+	Identical bool                `json:"identical"`
+	Entries   []ArtifactDiffEntry `json:"entries"`
+}
+
+// ArtifactDiffCommand compares two artifacts by content. Each artifact is either a local file, or a path in Artifactory in
+// the format of <repository name>/<repository path>. Archives are compared entry by entry, including the archives they contain.
+type ArtifactDiffCommand struct {
+	serverDetails   *config.ServerDetails
+	source          string
+	target          string
+	servicesManager artifactory.ArtifactoryServicesManager
+	result          *ArtifactDiffResult
+}
+
+func NewArtifactDiffCommand() *ArtifactDiffCommand {
+	return &ArtifactDiffCommand{}
+}
+
+func (adc *ArtifactDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *ArtifactDiffCommand {
+	adc.serverDetails = serverDetails
+	return adc
+}
+
+func (adc *ArtifactDiffCommand) SetSource(source string) *ArtifactDiffCommand {
+	adc.source = source
+	return adc
+}
+
+func (adc *ArtifactDiffCommand) SetTarget(target string) *ArtifactDiffCommand {
+	adc.target = target
+	return adc
+}
+
+func (adc *ArtifactDiffCommand) Result() *ArtifactDiffResult {
+	return adc.result
+}
+
+func (adc *ArtifactDiffCommand) CommandName() string {
+	return "rt_artifact_diff"
+}
+
+func (adc *ArtifactDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return adc.serverDetails, nil
+}
+
+func (adc *ArtifactDiffCommand) Run() (err error) {
+	sourcePath, err := adc.getLocalPath(adc.source)
+	if err != nil {
+		return err
+	}
+	if sourcePath != adc.source {
+		defer func() {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(sourcePath)))
+		}()
+	}
+	targetPath, err := adc.getLocalPath(adc.target)
+	if err != nil {
+		return err
+	}
+	if targetPath != adc.target {
+		defer func() {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(targetPath)))
+		}()
+	}
+	adc.result, err = diffArtifacts(adc.source, sourcePath, adc.target, targetPath)
+	return err
+}
+
+// getLocalPath returns the path of the artifact if it's a local file. Otherwise, the artifact is downloaded from Artifactory to a temp file.
+func (adc *ArtifactDiffCommand) getLocalPath(artifact string) (localPath string, err error) {
+	exists, err := fileutils.IsFileExists(artifact, false)
+	if err != nil || exists {
+		return artifact, err
+	}
+	if adc.servicesManager == nil {
+		if adc.servicesManager, err = utils.CreateServiceManager(adc.serverDetails, -1, 0, false); err != nil {
+			return "", err
+		}
+	}
+	log.Info("Downloading", artifact+"...")
+	reader, err := adc.servicesManager.ReadRemoteFile(strings.TrimPrefix(artifact, "/"))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	tempFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(tempFile, reader)
+	err = errors.Join(err, tempFile.Close())
+	if err != nil {
+		return "", errorutils.CheckError(errors.Join(err, os.Remove(tempFile.Name())))
+	}
+	return tempFile.Name(), nil
+}
+
+func diffArtifacts(source, sourcePath, target, targetPath string) (*ArtifactDiffResult, error) {
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = sourceFile.Close()
+	}()
+	targetFile, err := os.Open(targetPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = targetFile.Close()
+	}()
+	result := &ArtifactDiffResult{Source: source, Target: target, Entries: []ArtifactDiffEntry{}}
+	if result.SourceSha256, err = fileSha256(sourceFile); err != nil {
+		return nil, err
+	}
+	if result.TargetSha256, err = fileSha256(targetFile); err != nil {
+		return nil, err
+	}
+	if result.SourceSha256 != result.TargetSha256 {
+		kind := getArchiveKind(source)
+		if kind != notArchive && kind == getArchiveKind(target) {
+			result.Entries, err = diffArchiveFiles(kind, source, sourceFile, target, targetFile)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			result.Entries = append(result.Entries, ArtifactDiffEntry{Path: path.Base(source), Status: DiffEntryChanged, SourceSha256: result.SourceSha256, TargetSha256: result.TargetSha256})
+		}
+	}
+	result.Identical = len(result.Entries) == 0
+	return result, nil
+}
+
+func diffArchiveFiles(kind archiveKind, source string, sourceFile *os.File, target string, targetFile *os.File) ([]ArtifactDiffEntry, error) {
+	sourceEntries, err := readArchiveFile(kind, source, sourceFile)
+	if err != nil {
+		return nil, err
+	}
+	targetEntries, err := readArchiveFile(kind, target, targetFile)
+	if err != nil {
+		return nil, err
+	}
+	return diffArchiveEntries("", sourceEntries, targetEntries, 1), nil
+}
+
+func readArchiveFile(kind archiveKind, name string, file *os.File) (map[string]archiveEntry, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	entries, err := readArchiveEntries(kind, file, info.Size())
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the archive %s: %s", name, err.Error())
+	}
+	return entries, nil
+}
+
+// diffArchiveEntries compares the entries of two archives by their checksums. The changed entries which are archives themselves
+// are compared recursively, so that only the changed files they contain are returned.
+func diffArchiveEntries(prefix string, sourceEntries, targetEntries map[string]archiveEntry, depth int) []ArtifactDiffEntry {
+	paths := make(map[string]bool, len(sourceEntries))
+	for entryPath := range sourceEntries {
+		paths[entryPath] = true
+	}
+	for entryPath := range targetEntries {
+		paths[entryPath] = true
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for entryPath := range paths {
+		sortedPaths = append(sortedPaths, entryPath)
+	}
+	sort.Strings(sortedPaths)
+
+	entries := []ArtifactDiffEntry{}
+	for _, entryPath := range sortedPaths {
+		sourceEntry, inSource := sourceEntries[entryPath]
+		targetEntry, inTarget := targetEntries[entryPath]
+		switch {
+		case !inTarget:
+			entries = append(entries, ArtifactDiffEntry{Path: prefix + entryPath, Status: DiffEntryRemoved, SourceSha256: sourceEntry.sha256})
+		case !inSource:
+			entries = append(entries, ArtifactDiffEntry{Path: prefix + entryPath, Status: DiffEntryAdded, TargetSha256: targetEntry.sha256})
+		case sourceEntry.sha256 != targetEntry.sha256:
+			if nestedEntries, ok := diffNestedArchives(prefix+entryPath, sourceEntry, targetEntry, depth); ok {
+				entries = append(entries, nestedEntries...)
+				continue
+			}
+			entries = append(entries, ArtifactDiffEntry{Path: prefix + entryPath, Status: DiffEntryChanged, SourceSha256: sourceEntry.sha256, TargetSha256: targetEntry.sha256})
+		}
+	}
+	return entries
+}
+
+// diffNestedArchives compares the entries of an archive contained by the compared archives.
+// Returns false if the entry isn't an archive, or can't be read as one.
+func diffNestedArchives(entryPath string, sourceEntry, targetEntry archiveEntry, depth int) ([]ArtifactDiffEntry, bool) {
+	kind := getArchiveKind(entryPath)
+	if kind == notArchive || depth >= maxNestedArchiveDepth {
+		return nil, false
+	}
+	sourceEntries, err := readArchiveEntries(kind, bytes.NewReader(sourceEntry.content), int64(len(sourceEntry.content)))
+	if err != nil {
+		log.Debug("Comparing", entryPath, "by its checksum, since it couldn't be read as an archive:", err.Error())
+		return nil, false
+	}
+	targetEntries, err := readArchiveEntries(kind, bytes.NewReader(targetEntry.content), int64(len(targetEntry.content)))
+	if err != nil {
+		log.Debug("Comparing", entryPath, "by its checksum, since it couldn't be read as an archive:", err.Error())
+		return nil, false
+	}
+	return diffArchiveEntries(entryPath+nestedEntrySeparator, sourceEntries, targetEntries, depth+1), true
+}
+
+type archiveEntry struct {
+	sha256 string
+	// The content is kept only for nested archives, which may be compared recursively.
+	content []byte
+}
+
+// readArchiveEntries returns the files of the archive by their paths. Directories and links are skipped.
+func readArchiveEntries(kind archiveKind, reader io.ReaderAt, size int64) (map[string]archiveEntry, error) {
+	entries := make(map[string]archiveEntry)
+	if kind == zipArchive {
+		zipReader, err := zip.NewReader(reader, size)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range zipReader.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			entryReader, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			entry, err := readArchiveEntry(file.Name, entryReader)
+			err = errors.Join(err, entryReader.Close())
+			if err != nil {
+				return nil, err
+			}
+			entries[file.Name] = entry
+		}
+		return entries, nil
+	}
+	var tarStream io.Reader = io.NewSectionReader(reader, 0, size)
+	if kind == tarGzArchive {
+		gzipReader, err := gzip.NewReader(tarStream)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+		tarStream = gzipReader
+	}
+	tarReader := tar.NewReader(tarStream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		entryPath := strings.TrimPrefix(header.Name, "./")
+		if entries[entryPath], err = readArchiveEntry(entryPath, tarReader); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func readArchiveEntry(entryPath string, reader io.Reader) (archiveEntry, error) {
+	hash := sha256.New()
+	var content bytes.Buffer
+	writer := io.Writer(hash)
+	if getArchiveKind(entryPath) != notArchive {
+		writer = io.MultiWriter(hash, &content)
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		return archiveEntry{}, err
+	}
+	return archiveEntry{sha256: hex.EncodeToString(hash.Sum(nil)), content: content.Bytes()}, nil
+}
+
+func fileSha256(file *os.File) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// getArchiveKind returns the kind of the archive by the extension of its name.
+func getArchiveKind(name string) archiveKind {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return tarGzArchive
+	case strings.HasSuffix(name, ".tar"):
+		return tarArchive
+	}
+	switch path.Ext(name) {
+	case ".zip", ".jar", ".war", ".ear", ".aar", ".nupkg", ".whl":
+		return zipArchive
+	}
+	return notArchive
+}
+
+type artifactDiffRow struct {
+	Path         string `col-name:"Path"`
+	Status       string `col-name:"Status"`
+	SourceSha256 string `col-name:"Source SHA-256" omitempty:"true"`
+	TargetSha256 string `col-name:"Target SHA-256" omitempty:"true"`
+}
+
+// PrintArtifactDiffTable prints the entries which differ between the compared artifacts as a table.
+func PrintArtifactDiffTable(result *ArtifactDiffResult) error {
+	rows := make([]artifactDiffRow, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		rows = append(rows, artifactDiffRow(entry))
+	}
+	title := fmt.Sprintf("Differences between %s and %s", result.Source, result.Target)
+	emptyTableMessage := "The artifacts are identical"
+	if result.SourceSha256 != result.TargetSha256 {
+		emptyTableMessage = "The artifacts have the same content, but different checksums"
+	}
+	return coreutils.PrintTable(rows, title, emptyTableMessage, false)
+}
@@ -0,0 +1,133 @@
+package generic
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testArchiveEntry struct {
+	name    string
+	content []byte
+}
+
+func createTestZip(t *testing.T, modified time.Time, entries ...testArchiveEntry) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, entry := range entries {
+		entryWriter, err := writer.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modified})
+		require.NoError(t, err)
+		_, err = entryWriter.Write(entry.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func writeTestFile(t *testing.T, dir, name string, content []byte) string {
+	filePath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(filePath, content, 0644))
+	return filePath
+}
+
+func TestDiffArtifactsNestedArchives(t *testing.T) {
+	dir := t.TempDir()
+	firstBuild := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondBuild := firstBuild.Add(time.Hour)
+	sourceWar := createTestZip(t, firstBuild,
+		testArchiveEntry{"WEB-INF/web.xml", []byte("<web-app/>")},
+		testArchiveEntry{"WEB-INF/lib/lib.jar", createTestZip(t, firstBuild, testArchiveEntry{"Lib.class", []byte("v1")}, testArchiveEntry{"Util.class", []byte("util")})},
+		testArchiveEntry{"WEB-INF/lib/same.jar", createTestZip(t, firstBuild, testArchiveEntry{"Same.class", []byte("same")})},
+		testArchiveEntry{"index.html", []byte("index")},
+	)
+	// Rebuilt with different timestamps. same.jar has the same content, so it isn't reported.
+	targetWar := createTestZip(t, secondBuild,
+		testArchiveEntry{"WEB-INF/web.xml", []byte("<web-app/>")},
+		testArchiveEntry{"WEB-INF/lib/lib.jar", createTestZip(t, secondBuild, testArchiveEntry{"Lib.class", []byte("v2")}, testArchiveEntry{"Util.class", []byte("util")})},
+		testArchiveEntry{"WEB-INF/lib/same.jar", createTestZip(t, secondBuild, testArchiveEntry{"Same.class", []byte("same")})},
+		testArchiveEntry{"about.html", []byte("about")},
+	)
+	source := writeTestFile(t, dir, "source.war", sourceWar)
+	target := writeTestFile(t, dir, "target.war", targetWar)
+
+	result, err := diffArtifacts(source, source, target, target)
+	require.NoError(t, err)
+	assert.False(t, result.Identical)
+	assert.NotEqual(t, result.SourceSha256, result.TargetSha256)
+	require.Len(t, result.Entries, 3)
+	assert.Equal(t, "WEB-INF/lib/lib.jar!/Lib.class", result.Entries[0].Path)
+	assert.Equal(t, DiffEntryChanged, result.Entries[0].Status)
+	assert.NotEmpty(t, result.Entries[0].SourceSha256)
+	assert.NotEmpty(t, result.Entries[0].TargetSha256)
+	assert.Equal(t, ArtifactDiffEntry{Path: "about.html", Status: DiffEntryAdded, TargetSha256: result.Entries[1].TargetSha256}, result.Entries[1])
+	assert.Equal(t, ArtifactDiffEntry{Path: "index.html", Status: DiffEntryRemoved, SourceSha256: result.Entries[2].SourceSha256}, result.Entries[2])
+}
+
+func TestDiffArtifactsSameContent(t *testing.T) {
+	dir := t.TempDir()
+	entry := testArchiveEntry{"Main.class", []byte("main")}
+	source := writeTestFile(t, dir, "source.jar", createTestZip(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), entry))
+	target := writeTestFile(t, dir, "target.jar", createTestZip(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), entry))
+
+	result, err := diffArtifacts(source, source, target, target)
+	require.NoError(t, err)
+	assert.True(t, result.Identical)
+	assert.NotEqual(t, result.SourceSha256, result.TargetSha256)
+	assert.Empty(t, result.Entries)
+}
+
+func TestDiffArtifactsTarGz(t *testing.T) {
+	createTarGz := func(content string) []byte {
+		var buffer bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buffer)
+		tarWriter := tar.NewWriter(gzipWriter)
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "./package/", Typeflag: tar.TypeDir, Mode: 0755}))
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "./package/index.js", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, tarWriter.Close())
+		require.NoError(t, gzipWriter.Close())
+		return buffer.Bytes()
+	}
+	dir := t.TempDir()
+	source := writeTestFile(t, dir, "source.tgz", createTarGz("v1"))
+	target := writeTestFile(t, dir, "target.tgz", createTarGz("v2"))
+
+	result, err := diffArtifacts(source, source, target, target)
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, "package/index.js", result.Entries[0].Path)
+	assert.Equal(t, DiffEntryChanged, result.Entries[0].Status)
+}
+
+func TestDiffArtifactsPlainFiles(t *testing.T) {
+	dir := t.TempDir()
+	source := writeTestFile(t, dir, "source.txt", []byte("a"))
+	target := writeTestFile(t, dir, "target.txt", []byte("b"))
+
+	result, err := diffArtifacts(source, source, target, target)
+	require.NoError(t, err)
+	assert.False(t, result.Identical)
+	assert.Equal(t, []ArtifactDiffEntry{{Path: "source.txt", Status: DiffEntryChanged, SourceSha256: result.SourceSha256, TargetSha256: result.TargetSha256}}, result.Entries)
+
+	result, err = diffArtifacts(source, source, source, source)
+	require.NoError(t, err)
+	assert.True(t, result.Identical)
+}
+
+func TestGetArchiveKind(t *testing.T) {
+	assert.Equal(t, zipArchive, getArchiveKind("libs-release/app/1.0/app-1.0.JAR"))
+	assert.Equal(t, zipArchive, getArchiveKind("package.whl"))
+	assert.Equal(t, tarGzArchive, getArchiveKind("package-1.0.0.tgz"))
+	assert.Equal(t, tarGzArchive, getArchiveKind("dist.tar.gz"))
+	assert.Equal(t, tarArchive, getArchiveKind("image.tar"))
+	assert.Equal(t, notArchive, getArchiveKind("app.pom"))
+}
@@ -0,0 +1,63 @@
+package generic
+
+import (
+	"errors"
+	"os"
+
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// fileInfoGetter returns the details of an artifact, by its path in the form of repo/path. It's the FileInfo of the services manager.
+type fileInfoGetter func(relativePath string) (*rtServicesUtils.FileInfo, error)
+
+// verifyArtifactChecksums verifies the checksum of the whole local file against the checksum of the artifact in Artifactory.
+// The sha256 is compared, or the sha1 if the server didn't calculate the sha256 of the artifact.
+func verifyArtifactChecksums(getFileInfo fileInfoGetter, localPath, artifactPath string) error {
+	fileInfo, err := getFileInfo(artifactPath)
+	if err != nil {
+		return err
+	}
+	algorithm, name, expected := crypto.SHA256, "sha256", fileInfo.Checksums.Sha256
+	if expected == "" {
+		algorithm, name, expected = crypto.SHA1, "sha1", fileInfo.Checksums.Sha1
+	}
+	if expected == "" {
+		return errorutils.CheckErrorf("Artifactory returned no checksums of %s", artifactPath)
+	}
+	checksums, err := crypto.GetFileChecksums(localPath, algorithm)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if checksums[algorithm] != expected {
+		return errorutils.CheckErrorf("the %s of %s is %s, while the %s of %s in Artifactory is %s",
+			name, localPath, checksums[algorithm], name, artifactPath, expected)
+	}
+	return nil
+}
+
+// verifySplitDownloads verifies the checksums of the downloaded files which were large enough to be downloaded in concurrent parts,
+// against the checksums of their artifacts. The files which were extracted, and therefore removed, are skipped.
+// The transfer details reader is reset.
+func verifySplitDownloads(getFileInfo fileInfoGetter, transferDetailsReader *content.ContentReader, configuration *utils.DownloadConfiguration) (err error) {
+	if configuration.SkipChecksum || configuration.SplitCount == 0 || configuration.MinSplitSize < 0 {
+		return nil
+	}
+	defer transferDetailsReader.Reset()
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		fileInfo, statErr := os.Stat(item.TargetPath)
+		if statErr != nil || fileInfo.IsDir() || fileInfo.Size() < configuration.MinSplitSize*1000 {
+			continue
+		}
+		log.Debug("Verifying the checksum of the file downloaded in parts:", item.TargetPath)
+		if verifyErr := verifyArtifactChecksums(getFileInfo, item.TargetPath, item.SourcePath); verifyErr != nil {
+			err = errors.Join(err, verifyErr)
+		}
+	}
+	return errors.Join(err, transferDetailsReader.GetError())
+}
@@ -0,0 +1,95 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Of(fileContent string) string {
+	hash := sha256.Sum256([]byte(fileContent))
+	return hex.EncodeToString(hash[:])
+}
+
+// newFileInfoGetter returns the file info of the artifacts with the given sha256, by their paths.
+func newFileInfoGetter(sha256ByPath map[string]string) fileInfoGetter {
+	return func(relativePath string) (*rtServicesUtils.FileInfo, error) {
+		checksum, exists := sha256ByPath[relativePath]
+		if !exists {
+			return nil, errorutils.CheckErrorf("%s not found", relativePath)
+		}
+		fileInfo := &rtServicesUtils.FileInfo{}
+		fileInfo.Checksums.Sha256 = checksum
+		return fileInfo, nil
+	}
+}
+
+func TestVerifyArtifactChecksums(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "a.bin")
+	require.NoError(t, os.WriteFile(localPath, []byte("content"), 0644))
+
+	assert.NoError(t, verifyArtifactChecksums(newFileInfoGetter(map[string]string{"repo/a.bin": sha256Of("content")}), localPath, "repo/a.bin"))
+	assert.ErrorContains(t, verifyArtifactChecksums(newFileInfoGetter(map[string]string{"repo/a.bin": sha256Of("other")}), localPath, "repo/a.bin"), "sha256")
+	assert.ErrorContains(t, verifyArtifactChecksums(newFileInfoGetter(nil), localPath, "repo/a.bin"), "not found")
+
+	// Without a sha256, the sha1 is compared.
+	withSha1 := func(string) (*rtServicesUtils.FileInfo, error) {
+		fileInfo := &rtServicesUtils.FileInfo{}
+		fileInfo.Checksums.Sha1 = "040f06fd774092478d450774f5ba30c5da78acc8"
+		return fileInfo, nil
+	}
+	assert.NoError(t, verifyArtifactChecksums(withSha1, localPath, "repo/a.bin"))
+	withoutChecksums := func(string) (*rtServicesUtils.FileInfo, error) { return &rtServicesUtils.FileInfo{}, nil }
+	assert.ErrorContains(t, verifyArtifactChecksums(withoutChecksums, localPath, "repo/a.bin"), "no checksums")
+}
+
+func TestVerifySplitDownloads(t *testing.T) {
+	dir := t.TempDir()
+	large, corrupted, small := filepath.Join(dir, "large.bin"), filepath.Join(dir, "corrupted.bin"), filepath.Join(dir, "small.bin")
+	largeContent := string(make([]byte, 2000))
+	require.NoError(t, os.WriteFile(large, []byte(largeContent), 0644))
+	require.NoError(t, os.WriteFile(corrupted, []byte(largeContent+"!"), 0644))
+	require.NoError(t, os.WriteFile(small, []byte("small"), 0644))
+
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	writer.Write(clientUtils.FileTransferDetails{SourcePath: "repo/large.bin", TargetPath: large})
+	// The small file isn't verified, since it wasn't downloaded in parts, and an extracted archive isn't verified, since it's removed once extracted.
+	writer.Write(clientUtils.FileTransferDetails{SourcePath: "repo/small.bin", TargetPath: small})
+	writer.Write(clientUtils.FileTransferDetails{SourcePath: "repo/extracted.zip", TargetPath: filepath.Join(dir, "extracted.zip")})
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	getFileInfo := newFileInfoGetter(map[string]string{"repo/large.bin": sha256Of(largeContent), "repo/corrupted.bin": sha256Of(largeContent)})
+	configuration := &utils.DownloadConfiguration{SplitCount: 3, MinSplitSize: 1}
+	assert.NoError(t, verifySplitDownloads(getFileInfo, reader, configuration))
+
+	corruptedWriter, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	corruptedWriter.Write(clientUtils.FileTransferDetails{SourcePath: "repo/corrupted.bin", TargetPath: corrupted})
+	corruptedWriter.Write(clientUtils.FileTransferDetails{SourcePath: "repo/small.bin", TargetPath: small})
+	require.NoError(t, corruptedWriter.Close())
+	corruptedReader := content.NewContentReader(corruptedWriter.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, corruptedReader.Close())
+	}()
+	err = verifySplitDownloads(getFileInfo, corruptedReader, configuration)
+	assert.ErrorContains(t, err, corrupted)
+	assert.NotContains(t, err.Error(), small)
+
+	// The checksums aren't verified with --skip-checksum.
+	configuration.SkipChecksum = true
+	assert.NoError(t, verifySplitDownloads(getFileInfo, corruptedReader, configuration))
+}
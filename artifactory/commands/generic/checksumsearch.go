@@ -0,0 +1,206 @@
+package generic
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	ChecksumSha256 = "sha256"
+	ChecksumSha1   = "sha1"
+	ChecksumMd5    = "md5"
+
+	checksumSearchApi = "api/search/checksum"
+	// The checksum search returns the details of the artifacts, in addition to their URIs, with this header.
+	resultDetailHeader = "X-Result-Detail"
+	// The prefix of the storage API URIs of the artifacts returned by the checksum search.
+	storageApiPrefix = "/api/storage/"
+)
+
+// ChecksumSearchItem is an artifact whose content has the searched checksum.
+type ChecksumSearchItem struct {
+	Repo         string `json:"repo"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size,omitempty"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+type ChecksumSearchResult struct {
+	Checksum  string               `json:"checksum"`
+	Algorithm string               `json:"algorithm"`
+	Artifacts []ChecksumSearchItem `json:"artifacts"`
+}
+
+// ChecksumSearchCommand finds the artifacts with the same content across the repositories, by the SHA-256, SHA-1 or MD5 checksum
+// of the content, using the checksum search API.
+type ChecksumSearchCommand struct {
+	serverDetails *config.ServerDetails
+	checksum      string
+	repos         []string
+	result        *ChecksumSearchResult
+}
+
+func NewChecksumSearchCommand() *ChecksumSearchCommand {
+	return &ChecksumSearchCommand{}
+}
+
+func (csc *ChecksumSearchCommand) SetServerDetails(serverDetails *config.ServerDetails) *ChecksumSearchCommand {
+	csc.serverDetails = serverDetails
+	return csc
+}
+
+// SetChecksum sets the checksum to search for. Its algorithm is detected by its length.
+func (csc *ChecksumSearchCommand) SetChecksum(checksum string) *ChecksumSearchCommand {
+	csc.checksum = checksum
+	return csc
+}
+
+// SetRepos limits the search to the repositories. By default, all the repositories are searched.
+func (csc *ChecksumSearchCommand) SetRepos(repos []string) *ChecksumSearchCommand {
+	csc.repos = repos
+	return csc
+}
+
+func (csc *ChecksumSearchCommand) Result() *ChecksumSearchResult {
+	return csc.result
+}
+
+func (csc *ChecksumSearchCommand) CommandName() string {
+	return "rt_checksum_search"
+}
+
+func (csc *ChecksumSearchCommand) ServerDetails() (*config.ServerDetails, error) {
+	return csc.serverDetails, nil
+}
+
+func (csc *ChecksumSearchCommand) Run() error {
+	checksum := strings.ToLower(strings.TrimSpace(csc.checksum))
+	algorithm, err := checksumAlgorithm(checksum)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(csc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	artifacts, err := searchByChecksum(servicesManager, algorithm, checksum, csc.repos)
+	if err != nil {
+		return err
+	}
+	csc.result = &ChecksumSearchResult{Checksum: checksum, Algorithm: algorithm, Artifacts: artifacts}
+	return nil
+}
+
+// checksumAlgorithm returns the algorithm of the checksum by its length.
+func checksumAlgorithm(checksum string) (string, error) {
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return "", errorutils.CheckErrorf("invalid checksum '%s'. The checksum must be a SHA-256, SHA-1 or MD5 hex string", checksum)
+	}
+	switch len(checksum) {
+	case 64:
+		return ChecksumSha256, nil
+	case 40:
+		return ChecksumSha1, nil
+	case 32:
+		return ChecksumMd5, nil
+	}
+	return "", errorutils.CheckErrorf("invalid checksum '%s'. The checksum must be a SHA-256, SHA-1 or MD5 hex string", checksum)
+}
+
+type checksumSearchResponse struct {
+	Results []struct {
+		Uri          string `json:"uri"`
+		Repo         string `json:"repo"`
+		Path         string `json:"path"`
+		Size         string `json:"size"`
+		Created      string `json:"created"`
+		LastModified string `json:"lastModified"`
+	} `json:"results"`
+}
+
+func searchByChecksum(servicesManager artifactory.ArtifactoryServicesManager, algorithm, checksum string, repos []string) ([]ChecksumSearchItem, error) {
+	query := url.Values{algorithm: {checksum}}
+	if len(repos) > 0 {
+		query.Set("repos", strings.Join(repos, ","))
+	}
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.Headers[resultDetailHeader] = "info"
+	searchUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + checksumSearchApi + "?" + query.Encode()
+	resp, body, _, err := servicesManager.Client().SendGet(searchUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var response checksumSearchResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the checksum search response: %s", err.Error())
+	}
+	artifacts := []ChecksumSearchItem{}
+	for _, result := range response.Results {
+		item := ChecksumSearchItem{Repo: result.Repo, Path: strings.TrimPrefix(result.Path, "/"), Created: result.Created, LastModified: result.LastModified}
+		if item.Repo == "" || item.Path == "" {
+			// Without the details, the artifact is identified by its storage API URI, such as <url>/api/storage/<repo>/<path>.
+			if item.Repo, item.Path, err = parseStorageUri(result.Uri); err != nil {
+				return nil, err
+			}
+		}
+		if result.Size != "" {
+			if item.Size, err = strconv.ParseInt(result.Size, 10, 64); err != nil {
+				return nil, errorutils.CheckErrorf("unexpected size '%s' of %s/%s in the checksum search response", result.Size, item.Repo, item.Path)
+			}
+		}
+		artifacts = append(artifacts, item)
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Repo != artifacts[j].Repo {
+			return artifacts[i].Repo < artifacts[j].Repo
+		}
+		return artifacts[i].Path < artifacts[j].Path
+	})
+	return artifacts, nil
+}
+
+func parseStorageUri(uri string) (repo, artifactPath string, err error) {
+	parsedUri, err := url.Parse(uri)
+	if err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	index := strings.Index(parsedUri.Path, storageApiPrefix)
+	if index < 0 {
+		return "", "", errorutils.CheckErrorf("unexpected artifact URI in the checksum search response: %s", uri)
+	}
+	repo, artifactPath, _ = strings.Cut(parsedUri.Path[index+len(storageApiPrefix):], "/")
+	return repo, artifactPath, nil
+}
+
+type checksumSearchRow struct {
+	Path         string `col-name:"Path"`
+	Size         int64  `col-name:"Size"`
+	Created      string `col-name:"Created" omitempty:"true"`
+	LastModified string `col-name:"Last Modified" omitempty:"true"`
+}
+
+func PrintChecksumSearchTable(result *ChecksumSearchResult) error {
+	rows := make([]checksumSearchRow, 0, len(result.Artifacts))
+	for _, artifact := range result.Artifacts {
+		rows = append(rows, checksumSearchRow{Path: path.Join(artifact.Repo, artifact.Path), Size: artifact.Size, Created: artifact.Created, LastModified: artifact.LastModified})
+	}
+	title := fmt.Sprintf("Artifacts with the %s checksum %s", result.Algorithm, result.Checksum)
+	return coreutils.PrintTable(rows, title, "No artifacts were found with the checksum", false)
+}
@@ -0,0 +1,69 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchedSha256 = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestChecksumAlgorithm(t *testing.T) {
+	for checksum, expected := range map[string]string{
+		searchedSha256: ChecksumSha256,
+		"0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33": ChecksumSha1,
+		"acbd18db4cc2f85cedef654fccc4a4d8":         ChecksumMd5,
+	} {
+		algorithm, err := checksumAlgorithm(checksum)
+		require.NoError(t, err)
+		assert.Equal(t, expected, algorithm)
+	}
+	_, err := checksumAlgorithm("acbd18db4cc2f85c")
+	assert.ErrorContains(t, err, "invalid checksum")
+	_, err = checksumAlgorithm("zzbd18db4cc2f85cedef654fccc4a4d8")
+	assert.ErrorContains(t, err, "invalid checksum")
+}
+
+func TestChecksumSearchCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifactory/api/search/checksum" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, searchedSha256, r.URL.Query().Get(ChecksumSha256))
+		assert.Equal(t, "libs-release-local,generic-local", r.URL.Query().Get("repos"))
+		assert.Equal(t, "info", r.Header.Get("X-Result-Detail"))
+		_, _ = w.Write([]byte(`{"results":[
+			{"uri":"http://` + r.Host + `/artifactory/api/storage/libs-release-local/org/app/1.0/app-1.0.jar","repo":"libs-release-local","path":"/org/app/1.0/app-1.0.jar","size":"1024","created":"2024-01-31T12:00:00.000Z"},
+			{"uri":"http://` + r.Host + `/artifactory/api/storage/generic-local/copies/app.jar"}
+		]}`))
+	}))
+	defer server.Close()
+
+	command := NewChecksumSearchCommand().SetChecksum(" " + searchedSha256 + " ").SetRepos([]string{"libs-release-local", "generic-local"}).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+	require.NoError(t, command.Run())
+	assert.Equal(t, &ChecksumSearchResult{
+		Checksum:  searchedSha256,
+		Algorithm: ChecksumSha256,
+		Artifacts: []ChecksumSearchItem{
+			{Repo: "generic-local", Path: "copies/app.jar"},
+			{Repo: "libs-release-local", Path: "org/app/1.0/app-1.0.jar", Size: 1024, Created: "2024-01-31T12:00:00.000Z"},
+		},
+	}, command.Result())
+	assert.NoError(t, PrintChecksumSearchTable(command.Result()))
+}
+
+func TestParseStorageUri(t *testing.T) {
+	repo, artifactPath, err := parseStorageUri("https://acme.jfrog.io/artifactory/api/storage/libs-release-local/org/app/1.0/app-1.0.jar")
+	require.NoError(t, err)
+	assert.Equal(t, "libs-release-local", repo)
+	assert.Equal(t, "org/app/1.0/app-1.0.jar", artifactPath)
+
+	_, _, err = parseStorageUri("https://acme.jfrog.io/artifactory/libs-release-local/app.jar")
+	assert.ErrorContains(t, err, "unexpected artifact URI")
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/concurrency"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -35,6 +37,9 @@ func (dc *DeleteCommand) CommandName() string {
 }
 
 func (dc *DeleteCommand) Run() (err error) {
+	if err = checkDeletionProtection(dc.serverDetails, dc.Spec(), "delete", dc.overrideProtection); err != nil {
+		return
+	}
 	reader, err := dc.GetPathsToDelete()
 	if err != nil {
 		return
@@ -107,7 +112,14 @@ func (dc *DeleteCommand) DeleteFiles(reader *content.ContentReader) (successCoun
 	if errorutils.CheckError(err) != nil {
 		return 0, 0, err
 	}
-	servicesManager, err := utils.CreateDeleteServiceManager(serverDetails, dc.Threads(), dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun())
+	threads, releaseThreads, err := concurrency.Acquire(serverproxy.OriginalUrl(serverDetails.GetArtifactoryUrl()), dc.Threads())
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		err = errors.Join(err, releaseThreads())
+	}()
+	servicesManager, err := utils.CreateDeleteServiceManager(serverDetails, threads, dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun())
 	if err != nil {
 		return 0, 0, err
 	}
@@ -1,10 +1,7 @@
 package generic
 
 import (
-	"fmt"
-
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 )
 
 type DeletePropsCommand struct {
@@ -30,31 +27,5 @@ func (dp *DeletePropsCommand) CommandName() string {
 }
 
 func (dp *DeletePropsCommand) Run() error {
-	serverDetails, err := dp.ServerDetails()
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	servicesManager, err := createPropsServiceManager(dp.threads, dp.retries, dp.retryWaitTimeMilliSecs, serverDetails)
-	if err != nil {
-		return err
-	}
-	reader, err := searchItems(dp.Spec(), servicesManager)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if closeErr := reader.Close(); closeErr != nil {
-			log.Debug(fmt.Sprintf("Failed to close reader: %s", closeErr))
-		}
-	}()
-	propsParams := GetPropsParams(reader, dp.props, dp.repoOnly)
-	success, err := servicesManager.DeleteProps(propsParams)
-	result := dp.Result()
-	result.SetSuccessCount(success)
-	totalLength, totalLengthErr := reader.Length()
-	result.SetFailCount(totalLength - success)
-	if totalLengthErr != nil {
-		return totalLengthErr
-	}
-	return err
+	return dp.run("Delete properties", artifactory.ArtifactoryServicesManager.DeleteProps)
 }
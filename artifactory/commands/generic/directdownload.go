@@ -8,6 +8,7 @@ import (
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
 	gofrog "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -94,6 +95,9 @@ func (ddc *DirectDownloadCommand) directDownload() error {
 		}
 		if summary != nil {
 			defer gofrog.Close(summary.ArtifactsDetailsReader, &err)
+			if summary.TransferDetailsReader, err = serverproxy.RestoreTransferDetails(summary.TransferDetailsReader); err != nil {
+				return err
+			}
 			// If 'detailed summary' was requested, then the reader should not be closed here.
 			// It will be closed after it will be used to generate the summary.
 			if ddc.DetailedSummary() {
@@ -6,9 +6,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
 	gofrog "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/concurrency"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
@@ -25,8 +28,13 @@ import (
 type DownloadCommand struct {
 	buildConfiguration *build.BuildConfiguration
 	GenericCommand
-	configuration *utils.DownloadConfiguration
-	progress      ioUtils.ProgressMgr
+	configuration    *utils.DownloadConfiguration
+	progress         ioUtils.ProgressMgr
+	validationReport *ValidationReportOptions
+	// The artifacts which the spec is expected to resolve to, validated before the download.
+	expectedArtifacts       *ExpectedArtifacts
+	validateOnly            bool
+	expectedArtifactsResult *ExpectedArtifactsResult
 }
 
 func NewDownloadCommand() *DownloadCommand {
@@ -47,6 +55,11 @@ func (dc *DownloadCommand) SetConfiguration(configuration *utils.DownloadConfigu
 	return dc
 }
 
+func (dc *DownloadCommand) SetValidationReport(validationReport *ValidationReportOptions) *DownloadCommand {
+	dc.validationReport = validationReport
+	return dc
+}
+
 func (dc *DownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	dc.progress = progress
 }
@@ -64,13 +77,29 @@ func (dc *DownloadCommand) Run() error {
 }
 
 func (dc *DownloadCommand) download() (err error) {
+	startedAt := time.Now()
 	// Init progress bar if needed
 	if dc.progress != nil {
 		dc.progress.SetHeadlineMsg("")
 		dc.progress.InitProgressReaders()
 	}
+	if dc.validateOnly && dc.expectedArtifacts == nil {
+		return errorutils.CheckErrorf("the validate-only mode requires the expected artifacts")
+	}
+	if dc.expectedArtifacts != nil {
+		if err = dc.validateExpectedArtifacts(); err != nil || dc.validateOnly {
+			return err
+		}
+	}
 	// Create Service Manager:
-	servicesManager, err := utils.CreateDownloadServiceManager(dc.serverDetails, dc.configuration.Threads, dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun(), dc.progress)
+	threads, releaseThreads, err := concurrency.Acquire(serverproxy.OriginalUrl(dc.serverDetails.GetArtifactoryUrl()), dc.configuration.Threads)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, releaseThreads())
+	}()
+	servicesManager, err := dc.createTransferServiceManager(threads, dc.progress)
 	if err != nil {
 		return err
 	}
@@ -113,7 +142,10 @@ func (dc *DownloadCommand) download() (err error) {
 	// otherwise we use the download service which provides only general counters.
 	var totalDownloaded, totalFailed int
 	var summary *serviceutils.OperationSummary
-	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() {
+	createReport := dc.validationReport != nil && !dc.DryRun()
+	// The files downloaded in concurrent parts are verified once they're assembled, which requires the summary of the downloaded files.
+	verifySplits := !dc.DryRun() && !dc.configuration.SkipChecksum && dc.configuration.SplitCount > 0
+	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() || createReport || verifySplits {
 		summary, err = servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
 		if err != nil {
 			errorOccurred = true
@@ -121,6 +153,21 @@ func (dc *DownloadCommand) download() (err error) {
 		}
 		if summary != nil {
 			defer gofrog.Close(summary.ArtifactsDetailsReader, &err)
+			if summary.TransferDetailsReader, err = serverproxy.RestoreTransferDetails(summary.TransferDetailsReader); err != nil {
+				return err
+			}
+			if verifySplits {
+				if verifyErr := verifySplitDownloads(servicesManager.FileInfo, summary.TransferDetailsReader, dc.configuration); verifyErr != nil {
+					errorOccurred = true
+					log.Error(verifyErr)
+				}
+			}
+			if createReport {
+				if reportErr := createValidationReport("download", startedAt, summary, dc.validationReport, dc.serverDetails, dc.retries, dc.retryWaitTimeMilliSecs); reportErr != nil {
+					errorOccurred = true
+					log.Error(reportErr)
+				}
+			}
 			// If 'detailed summary' was requested, then the reader should not be closed here.
 			// It will be closed after it will be used to generate the summary.
 			if dc.DetailedSummary() {
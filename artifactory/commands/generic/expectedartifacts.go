@@ -0,0 +1,193 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ExpectedArtifacts is the set of artifacts which a download spec is expected to resolve to.
+type ExpectedArtifacts struct {
+	// The expected number of artifacts. Not validated if not set.
+	Count *int `json:"count,omitempty"`
+	// The expected artifacts. If set, the spec must resolve to exactly these artifacts.
+	Artifacts []ExpectedArtifact `json:"artifacts,omitempty"`
+}
+
+// ExpectedArtifact is matched by its path, in the form of <repo>/<path>, or by its name if the path isn't set.
+// The checksums which are set must match the checksums of the resolved artifact.
+type ExpectedArtifact struct {
+	Path   string `json:"path,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+	Sha1   string `json:"sha1,omitempty"`
+	Md5    string `json:"md5,omitempty"`
+}
+
+func (ea *ExpectedArtifact) String() string {
+	if ea.Path != "" {
+		return ea.Path
+	}
+	return ea.Name
+}
+
+// ExpectedArtifactsResult is the difference between the resolved and the expected artifacts.
+type ExpectedArtifactsResult struct {
+	Resolved   int      `json:"resolved"`
+	Expected   *int     `json:"expected,omitempty"`
+	Missing    []string `json:"missing,omitempty"`
+	Unexpected []string `json:"unexpected,omitempty"`
+	Mismatched []string `json:"mismatched,omitempty"`
+}
+
+func (ear *ExpectedArtifactsResult) Differs() bool {
+	return (ear.Expected != nil && *ear.Expected != ear.Resolved) || len(ear.Missing) > 0 || len(ear.Unexpected) > 0 || len(ear.Mismatched) > 0
+}
+
+// ReadExpectedArtifacts reads the expected artifacts from a JSON file.
+func ReadExpectedArtifacts(filePath string) (*ExpectedArtifacts, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	expected := new(ExpectedArtifacts)
+	if err = json.Unmarshal(data, expected); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the expected artifacts file %s: %s", filePath, err.Error())
+	}
+	if expected.Count == nil && len(expected.Artifacts) == 0 {
+		return nil, errorutils.CheckErrorf("the expected artifacts file %s must include the count or the artifacts", filePath)
+	}
+	for _, artifact := range expected.Artifacts {
+		if artifact.Path == "" && artifact.Name == "" {
+			return nil, errorutils.CheckErrorf("each expected artifact in %s must include its path or its name", filePath)
+		}
+	}
+	return expected, nil
+}
+
+func (dc *DownloadCommand) SetExpectedArtifacts(expectedArtifacts *ExpectedArtifacts) *DownloadCommand {
+	dc.expectedArtifacts = expectedArtifacts
+	return dc
+}
+
+// SetValidateOnly sets whether the spec is only validated against the expected artifacts, without downloading them.
+func (dc *DownloadCommand) SetValidateOnly(validateOnly bool) *DownloadCommand {
+	dc.validateOnly = validateOnly
+	return dc
+}
+
+func (dc *DownloadCommand) ExpectedArtifactsResult() *ExpectedArtifactsResult {
+	return dc.expectedArtifactsResult
+}
+
+// validateExpectedArtifacts resolves the artifacts of the spec, as the download does, and fails if they differ from the expected artifacts.
+func (dc *DownloadCommand) validateExpectedArtifacts() (err error) {
+	log.Info("Validating the artifacts of the download spec against the expected artifacts...")
+	searchCmd := NewSearchCommand()
+	searchCmd.SetServerDetails(dc.serverDetails).SetSpec(dc.Spec()).SetRetries(dc.retries).SetRetryWaitMilliSecs(dc.retryWaitTimeMilliSecs)
+	reader, err := searchCmd.Search()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	resolved, err := readSearchResults(reader)
+	if err != nil {
+		return err
+	}
+	dc.expectedArtifactsResult = compareExpectedArtifacts(dc.expectedArtifacts, resolved)
+	if !dc.expectedArtifactsResult.Differs() {
+		log.Info(fmt.Sprintf("The download spec resolves to the %d expected artifacts.", dc.expectedArtifactsResult.Resolved))
+		return nil
+	}
+	result := dc.expectedArtifactsResult
+	if result.Expected != nil && *result.Expected != result.Resolved {
+		log.Error(fmt.Sprintf("Expected %d artifacts, but the download spec resolves to %d artifacts.", *result.Expected, result.Resolved))
+	}
+	for _, missing := range result.Missing {
+		log.Error("Missing expected artifact:", missing)
+	}
+	for _, unexpected := range result.Unexpected {
+		log.Error("Unexpected artifact:", unexpected)
+	}
+	for _, mismatched := range result.Mismatched {
+		log.Error("Checksum mismatch:", mismatched)
+	}
+	return errorutils.CheckErrorf("the artifacts resolved by the download spec differ from the expected artifacts")
+}
+
+func readSearchResults(reader *content.ContentReader) ([]utils.SearchResult, error) {
+	var results []utils.SearchResult
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		results = append(results, *result)
+	}
+	return results, reader.GetError()
+}
+
+// compareExpectedArtifacts compares the resolved artifacts with the expected artifacts. The expected artifacts with
+// a path are matched first, so that the artifacts matched by their names are the ones which no path matched.
+func compareExpectedArtifacts(expected *ExpectedArtifacts, resolved []utils.SearchResult) *ExpectedArtifactsResult {
+	result := &ExpectedArtifactsResult{Resolved: len(resolved), Expected: expected.Count}
+	if len(expected.Artifacts) == 0 {
+		return result
+	}
+	matched := make([]bool, len(resolved))
+	match := func(artifact ExpectedArtifact, matches func(resolved utils.SearchResult) bool) {
+		for i := range resolved {
+			if !matched[i] && matches(resolved[i]) {
+				matched[i] = true
+				if mismatch := checksumMismatch(artifact, resolved[i]); mismatch != "" {
+					result.Mismatched = append(result.Mismatched, mismatch)
+				}
+				return
+			}
+		}
+		result.Missing = append(result.Missing, artifact.String())
+	}
+	for _, artifact := range expected.Artifacts {
+		if artifact.Path != "" {
+			expectedPath := strings.TrimPrefix(artifact.Path, "/")
+			match(artifact, func(resolved utils.SearchResult) bool { return resolved.Path == expectedPath })
+		}
+	}
+	for _, artifact := range expected.Artifacts {
+		if artifact.Path == "" {
+			match(artifact, func(resolved utils.SearchResult) bool { return path.Base(resolved.Path) == artifact.Name })
+		}
+	}
+	for i := range resolved {
+		if !matched[i] {
+			result.Unexpected = append(result.Unexpected, resolved[i].Path)
+		}
+	}
+	sort.Strings(result.Missing)
+	sort.Strings(result.Unexpected)
+	return result
+}
+
+func checksumMismatch(expected ExpectedArtifact, resolved utils.SearchResult) string {
+	var mismatches []string
+	for _, checksum := range []struct{ name, expected, actual string }{
+		{"sha256", expected.Sha256, resolved.Sha256},
+		{"sha1", expected.Sha1, resolved.Sha1},
+		{"md5", expected.Md5, resolved.Md5},
+	} {
+		if checksum.expected != "" && !strings.EqualFold(checksum.expected, checksum.actual) {
+			mismatches = append(mismatches, fmt.Sprintf("expected %s %s, but got '%s'", checksum.name, checksum.expected, checksum.actual))
+		}
+	}
+	if len(mismatches) == 0 {
+		return ""
+	}
+	return resolved.Path + ": " + strings.Join(mismatches, ", ")
+}
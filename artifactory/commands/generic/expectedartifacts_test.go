@@ -0,0 +1,96 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareExpectedArtifacts(t *testing.T) {
+	resolved := []utils.SearchResult{
+		{Path: "generic-local/app/1.0/app.zip", Sha256: "a1", Sha1: "b1"},
+		{Path: "generic-local/app/1.0/app.pom", Sha256: "a2"},
+		{Path: "generic-local/app/1.0/extra.txt", Sha256: "a3"},
+	}
+	count := 2
+	result := compareExpectedArtifacts(&ExpectedArtifacts{Count: &count, Artifacts: []ExpectedArtifact{
+		{Path: "/generic-local/app/1.0/app.zip", Sha256: "A1", Sha1: "b2"},
+		{Name: "app.pom"},
+		{Name: "app.jar"},
+	}}, resolved)
+	assert.True(t, result.Differs())
+	assert.Equal(t, &ExpectedArtifactsResult{
+		Resolved:   3,
+		Expected:   &count,
+		Missing:    []string{"app.jar"},
+		Unexpected: []string{"generic-local/app/1.0/extra.txt"},
+		Mismatched: []string{"generic-local/app/1.0/app.zip: expected sha1 b2, but got 'b1'"},
+	}, result)
+
+	count = 3
+	assert.False(t, compareExpectedArtifacts(&ExpectedArtifacts{Count: &count}, resolved).Differs())
+}
+
+func TestReadExpectedArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	expectedPath := filepath.Join(dir, "expected.json")
+	require.NoError(t, os.WriteFile(expectedPath, []byte(`{"count": 1, "artifacts": [{"path": "generic-local/app.zip", "sha256": "a1"}]}`), 0644))
+	expected, err := ReadExpectedArtifacts(expectedPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *expected.Count)
+	assert.Equal(t, []ExpectedArtifact{{Path: "generic-local/app.zip", Sha256: "a1"}}, expected.Artifacts)
+
+	for content, expectedErr := range map[string]string{
+		`{}`:                                "must include the count or the artifacts",
+		`{"artifacts": [{"sha256": "a1"}]}`: "must include its path or its name",
+		`[`:                                 "failed to parse",
+	} {
+		require.NoError(t, os.WriteFile(expectedPath, []byte(content), 0644))
+		_, err = ReadExpectedArtifacts(expectedPath)
+		assert.ErrorContains(t, err, expectedErr)
+	}
+}
+
+func TestDownloadValidateOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+			return
+		case "/artifactory/api/search/aql":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"repo":"generic-local","path":"app/1.0","name":"app.zip","type":"file","size":3,"sha256":"a1","actual_sha1":"b1","actual_md5":"c1"}],"range":{"start_pos":0,"end_pos":1,"total":1}}`))
+	}))
+	defer server.Close()
+
+	downloadSpec := spec.NewBuilder().Pattern("generic-local/app/1.0/*").Target(t.TempDir() + "/").BuildSpec()
+	newCommand := func(expected *ExpectedArtifacts) *DownloadCommand {
+		command := NewDownloadCommand().SetExpectedArtifacts(expected).SetValidateOnly(true)
+		command.SetSpec(downloadSpec).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+		return command
+	}
+
+	command := newCommand(&ExpectedArtifacts{Artifacts: []ExpectedArtifact{{Path: "generic-local/app/1.0/app.zip", Sha256: "a1", Md5: "c1"}}})
+	require.NoError(t, command.Run())
+	assert.False(t, command.ExpectedArtifactsResult().Differs())
+	assert.Zero(t, command.Result().SuccessCount())
+
+	command = newCommand(&ExpectedArtifacts{Artifacts: []ExpectedArtifact{{Name: "app.zip", Sha256: "a2"}}})
+	err := command.Run()
+	assert.ErrorContains(t, err, "differ from the expected artifacts")
+	assert.True(t, strings.HasPrefix(command.ExpectedArtifactsResult().Mismatched[0], "generic-local/app/1.0/app.zip: expected sha256 a2"))
+
+	assert.ErrorContains(t, NewDownloadCommand().SetValidateOnly(true).Run(), "requires the expected artifacts")
+}
@@ -1,9 +1,14 @@
 package generic
 
 import (
+	"context"
+
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 )
 
 type GenericCommand struct {
@@ -17,6 +22,9 @@ type GenericCommand struct {
 	retries                int
 	retryWaitTimeMilliSecs int
 	aqlInclude             []string
+	overrideProtection     bool
+	// The requests of the uploads and the downloads are canceled with the context, if set.
+	ctx context.Context
 }
 
 func NewGenericCommand() *GenericCommand {
@@ -103,3 +111,28 @@ func (gc *GenericCommand) SetAqlInclude(include []string) *GenericCommand {
 	gc.aqlInclude = include
 	return gc
 }
+
+func (gc *GenericCommand) OverrideProtection() bool {
+	return gc.overrideProtection
+}
+
+// SetOverrideProtection allows the delete and the move of the artifacts protected by the deletion protection policy, once confirmed.
+func (gc *GenericCommand) SetOverrideProtection(overrideProtection bool) *GenericCommand {
+	gc.overrideProtection = overrideProtection
+	return gc
+}
+
+// SetContext sets the context of the uploads and the downloads. Their requests are canceled when the context is canceled.
+func (gc *GenericCommand) SetContext(ctx context.Context) *GenericCommand {
+	gc.ctx = ctx
+	return gc
+}
+
+// createTransferServiceManager creates the services manager of the uploads and the downloads. The progress isn't reported
+// when the context is set.
+func (gc *GenericCommand) createTransferServiceManager(threads int, progress ioUtils.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
+	if gc.ctx != nil {
+		return utils.CreateServiceManagerWithContext(gc.ctx, gc.serverDetails, gc.DryRun(), threads, gc.retries, gc.retryWaitTimeMilliSecs, 0)
+	}
+	return utils.CreateServiceManagerWithProgressBar(gc.serverDetails, threads, gc.retries, gc.retryWaitTimeMilliSecs, gc.DryRun(), progress)
+}
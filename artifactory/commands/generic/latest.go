@@ -0,0 +1,305 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The latest artifact of the family is copied to the stable path.
+	LatestModeCopy = "copy"
+	// The latest artifact of the family is marked by a property, which is removed from the previous latest artifact.
+	LatestModeProperty = "property"
+
+	// The artifacts are sorted by the versions matched by the first wildcard of the pattern.
+	LatestSortByVersion = "version"
+	// The artifacts are sorted by their creation time.
+	LatestSortByCreated = "created"
+
+	DefaultLatestProperty = "latest"
+	// The property of the stable path, which points to the versioned artifact it was copied from.
+	latestSourceProperty = "latest.source"
+)
+
+// LatestResult is the latest artifact of the family, and the stable path or property which points to it.
+type LatestResult struct {
+	Latest  string `json:"latest"`
+	Version string `json:"version,omitempty"`
+	Mode    string `json:"mode"`
+	Pointer string `json:"pointer"`
+	Updated bool   `json:"updated"`
+}
+
+// LatestCommand maintains a stable "latest" pointer to the latest artifact of a versioned artifact family, so that
+// consumers can fetch a fixed path without sorting the versions themselves.
+// The family is the artifacts matched by a wildcard pattern, such as generic-local/app/*/app-*.zip.
+type LatestCommand struct {
+	serverDetails *config.ServerDetails
+	pattern       string
+	latestPath    string
+	mode          string
+	sortBy        string
+	property      string
+	dryRun        bool
+	result        *LatestResult
+}
+
+func NewLatestCommand() *LatestCommand {
+	return &LatestCommand{mode: LatestModeCopy, sortBy: LatestSortByVersion, property: DefaultLatestProperty}
+}
+
+func (lc *LatestCommand) SetServerDetails(serverDetails *config.ServerDetails) *LatestCommand {
+	lc.serverDetails = serverDetails
+	return lc
+}
+
+// SetPattern sets the wildcard pattern of the artifact family, in the form of <repo>/<path>.
+func (lc *LatestCommand) SetPattern(pattern string) *LatestCommand {
+	lc.pattern = pattern
+	return lc
+}
+
+// SetLatestPath sets the stable path which the latest artifact is copied to, in the copy mode.
+func (lc *LatestCommand) SetLatestPath(latestPath string) *LatestCommand {
+	lc.latestPath = latestPath
+	return lc
+}
+
+func (lc *LatestCommand) SetMode(mode string) *LatestCommand {
+	lc.mode = mode
+	return lc
+}
+
+func (lc *LatestCommand) SetSortBy(sortBy string) *LatestCommand {
+	lc.sortBy = sortBy
+	return lc
+}
+
+// SetProperty sets the property which marks the latest artifact, in the property mode.
+func (lc *LatestCommand) SetProperty(property string) *LatestCommand {
+	lc.property = property
+	return lc
+}
+
+func (lc *LatestCommand) SetDryRun(dryRun bool) *LatestCommand {
+	lc.dryRun = dryRun
+	return lc
+}
+
+func (lc *LatestCommand) Result() *LatestResult {
+	return lc.result
+}
+
+func (lc *LatestCommand) CommandName() string {
+	return "rt_latest_update"
+}
+
+func (lc *LatestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lc.serverDetails, nil
+}
+
+func (lc *LatestCommand) Run() error {
+	if err := lc.validate(); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(lc.serverDetails, -1, 0, lc.dryRun)
+	if err != nil {
+		return err
+	}
+	family, err := searchResultItems(servicesManager, lc.pattern, "")
+	if err != nil {
+		return err
+	}
+	latest, latestVersion, err := selectLatest(family, lc.pattern, lc.latestPath, lc.sortBy)
+	if err != nil {
+		return err
+	}
+	lc.result = &LatestResult{Latest: latest.GetItemRelativePath(), Version: latestVersion, Mode: lc.mode}
+	if lc.mode == LatestModeProperty {
+		lc.result.Pointer = lc.property + "=true"
+		lc.result.Updated, err = lc.markLatest(servicesManager, latest)
+	} else {
+		lc.result.Pointer = lc.latestPath
+		lc.result.Updated, err = lc.copyLatest(servicesManager, latest)
+	}
+	return err
+}
+
+func (lc *LatestCommand) validate() error {
+	if !strings.Contains(strings.Trim(lc.pattern, "/"), "/") {
+		return errorutils.CheckErrorf("the pattern of the artifact family must be in the form of <repo>/<path>, but got '%s'", lc.pattern)
+	}
+	if lc.sortBy != LatestSortByVersion && lc.sortBy != LatestSortByCreated {
+		return errorutils.CheckErrorf("unsupported sort-by '%s'. Acceptable values are: %s, %s", lc.sortBy, LatestSortByVersion, LatestSortByCreated)
+	}
+	if lc.sortBy == LatestSortByVersion && !strings.Contains(lc.pattern, "*") {
+		return errorutils.CheckErrorf("the pattern of the artifact family must include a wildcard which matches the versions, but got '%s'", lc.pattern)
+	}
+	switch lc.mode {
+	case LatestModeCopy:
+		if lc.latestPath == "" || strings.Contains(lc.latestPath, "*") || strings.HasSuffix(lc.latestPath, "/") {
+			return errorutils.CheckErrorf("the copy mode requires the stable path of the latest artifact, without wildcards, such as generic-local/app/latest/app.zip")
+		}
+	case LatestModeProperty:
+		if lc.property == "" || strings.ContainsAny(lc.property, "=;,") {
+			return errorutils.CheckErrorf("invalid property '%s' of the latest artifact", lc.property)
+		}
+	default:
+		return errorutils.CheckErrorf("unsupported mode '%s'. Acceptable values are: %s, %s", lc.mode, LatestModeCopy, LatestModeProperty)
+	}
+	return nil
+}
+
+// copyLatest copies the latest artifact to the stable path, unless it's already the source of the stable path.
+// Artifactory replaces the file of the stable path in a single operation, so the consumers get either the previous
+// or the new latest artifact.
+func (lc *LatestCommand) copyLatest(servicesManager artifactory.ArtifactoryServicesManager, latest rtServicesUtils.ResultItem) (bool, error) {
+	current, err := searchResultItems(servicesManager, lc.latestPath, "")
+	if err != nil {
+		return false, err
+	}
+	if len(current) == 1 && current[0].Sha256 != "" && current[0].Sha256 == latest.Sha256 {
+		log.Info(fmt.Sprintf("%s is already the latest artifact %s.", lc.latestPath, latest.GetItemRelativePath()))
+		return false, nil
+	}
+	log.Info(fmt.Sprintf("Copying the latest artifact %s to %s...", latest.GetItemRelativePath(), lc.latestPath))
+	if lc.dryRun {
+		return false, nil
+	}
+	params := services.NewMoveCopyParams()
+	params.Pattern = latest.GetItemRelativePath()
+	params.Target = lc.latestPath
+	params.Flat = true
+	succeeded, failed, err := servicesManager.Copy(params)
+	if err != nil {
+		return false, err
+	}
+	if succeeded != 1 || failed != 0 {
+		return false, errorutils.CheckErrorf("failed to copy the latest artifact %s to %s", latest.GetItemRelativePath(), lc.latestPath)
+	}
+	reader, err := createTargetsReader([]string{lc.latestPath})
+	if err != nil {
+		return true, err
+	}
+	_, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: latestSourceProperty + "=" + escapePropValue(latest.GetItemRelativePath())})
+	return true, errors.Join(err, reader.Close())
+}
+
+// markLatest sets the property on the latest artifact, and then removes it from the previous latest artifacts of the family,
+// so that there's always an artifact marked as the latest.
+func (lc *LatestCommand) markLatest(servicesManager artifactory.ArtifactoryServicesManager, latest rtServicesUtils.ResultItem) (bool, error) {
+	marked, err := searchResultItems(servicesManager, lc.pattern, lc.property+"=true")
+	if err != nil {
+		return false, err
+	}
+	var previous []string
+	alreadyMarked := false
+	for _, item := range marked {
+		if item.GetItemRelativePath() == latest.GetItemRelativePath() {
+			alreadyMarked = true
+		} else {
+			previous = append(previous, item.GetItemRelativePath())
+		}
+	}
+	if alreadyMarked && len(previous) == 0 {
+		log.Info(fmt.Sprintf("%s is already marked as the latest artifact.", latest.GetItemRelativePath()))
+		return false, nil
+	}
+	log.Info(fmt.Sprintf("Marking %s as the latest artifact, instead of: %s", latest.GetItemRelativePath(), strings.Join(previous, ", ")))
+	if lc.dryRun {
+		return false, nil
+	}
+	if !alreadyMarked {
+		if err = lc.updateProps(servicesManager.SetProps, []string{latest.GetItemRelativePath()}, lc.property+"=true"); err != nil {
+			return false, err
+		}
+	}
+	if len(previous) > 0 {
+		err = lc.updateProps(servicesManager.DeleteProps, previous, lc.property)
+	}
+	return true, err
+}
+
+func (lc *LatestCommand) updateProps(update func(services.PropsParams) (int, error), targets []string, props string) error {
+	reader, err := createTargetsReader(targets)
+	if err != nil {
+		return err
+	}
+	_, err = update(services.PropsParams{Reader: reader, Props: props})
+	return errors.Join(err, reader.Close())
+}
+
+// searchResultItems returns the files matched by the pattern, and with the properties, if set.
+func searchResultItems(servicesManager artifactory.ArtifactoryServicesManager, pattern, props string) (items []rtServicesUtils.ResultItem, err error) {
+	reader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &rtServicesUtils.CommonParams{Pattern: pattern, Props: props, Recursive: true}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(rtServicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(rtServicesUtils.ResultItem) {
+		items = append(items, *item)
+	}
+	return items, reader.GetError()
+}
+
+// selectLatest returns the latest artifact of the family, excluding the stable path itself, and its version.
+func selectLatest(family []rtServicesUtils.ResultItem, pattern, latestPath, sortBy string) (rtServicesUtils.ResultItem, string, error) {
+	versionRegExp := familyPatternToRegExp(pattern)
+	type candidate struct {
+		item    rtServicesUtils.ResultItem
+		version string
+	}
+	var candidates []candidate
+	for _, item := range family {
+		if item.GetItemRelativePath() == latestPath {
+			continue
+		}
+		itemVersion := ""
+		if match := versionRegExp.FindStringSubmatch(item.GetItemRelativePath()); len(match) > 1 {
+			itemVersion = match[1]
+		}
+		candidates = append(candidates, candidate{item: item, version: itemVersion})
+	}
+	if len(candidates) == 0 {
+		return rtServicesUtils.ResultItem{}, "", errorutils.CheckErrorf("no artifacts were found by the pattern '%s'", pattern)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if sortBy == LatestSortByCreated {
+			return candidates[i].item.Created > candidates[j].item.Created
+		}
+		if candidates[i].version == candidates[j].version {
+			return candidates[i].item.GetItemRelativePath() > candidates[j].item.GetItemRelativePath()
+		}
+		return version.NewVersion(candidates[j].version).Compare(candidates[i].version) > 0
+	})
+	return candidates[0].item, candidates[0].version, nil
+}
+
+// familyPatternToRegExp converts the wildcard pattern of the family to a regular expression, which captures the path
+// segments matched by the wildcards.
+func familyPatternToRegExp(pattern string) *regexp.Regexp {
+	parts := strings.Split(strings.TrimPrefix(path.Clean(pattern), "/"), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, "(.*)") + "$")
+}
+
+func escapePropValue(value string) string {
+	return strings.NewReplacer(";", `\;`, ",", `\,`).Replace(value)
+}
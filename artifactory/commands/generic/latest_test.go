@@ -0,0 +1,48 @@
+package generic
+
+import (
+	"testing"
+
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var appFamily = []rtServicesUtils.ResultItem{
+	{Repo: "generic-local", Path: "app/1.9.0", Name: "app-1.9.0.zip", Created: "2024-03-01T12:00:00.000Z"},
+	{Repo: "generic-local", Path: "app/1.10.0", Name: "app-1.10.0.zip", Created: "2024-02-01T12:00:00.000Z"},
+	{Repo: "generic-local", Path: "app/1.2.0", Name: "app-1.2.0.zip", Created: "2024-01-01T12:00:00.000Z"},
+	{Repo: "generic-local", Path: "app/latest", Name: "app-latest.zip", Created: "2024-03-02T12:00:00.000Z"},
+}
+
+func TestSelectLatest(t *testing.T) {
+	latest, latestVersion, err := selectLatest(appFamily, "generic-local/app/*/app-*.zip", "generic-local/app/latest/app-latest.zip", LatestSortByVersion)
+	require.NoError(t, err)
+	assert.Equal(t, "generic-local/app/1.10.0/app-1.10.0.zip", latest.GetItemRelativePath())
+	assert.Equal(t, "1.10.0", latestVersion)
+
+	latest, _, err = selectLatest(appFamily, "generic-local/app/*/app-*.zip", "generic-local/app/latest/app-latest.zip", LatestSortByCreated)
+	require.NoError(t, err)
+	assert.Equal(t, "generic-local/app/1.9.0/app-1.9.0.zip", latest.GetItemRelativePath())
+
+	_, _, err = selectLatest(appFamily[3:], "generic-local/app/*/app-*.zip", "generic-local/app/latest/app-latest.zip", LatestSortByVersion)
+	assert.ErrorContains(t, err, "no artifacts were found")
+}
+
+func TestFamilyPatternToRegExp(t *testing.T) {
+	match := familyPatternToRegExp("/generic-local/app+tools/*/app-*.zip").FindStringSubmatch("generic-local/app+tools/2.0.0-rc.1/app-2.0.0-rc.1.zip")
+	assert.Equal(t, []string{"generic-local/app+tools/2.0.0-rc.1/app-2.0.0-rc.1.zip", "2.0.0-rc.1", "2.0.0-rc.1"}, match)
+	assert.Nil(t, familyPatternToRegExp("generic-local/app/*/app-*.zip").FindStringSubmatch("generic-local/app/1.0/app-1.0.tgz"))
+}
+
+func TestLatestCommandValidate(t *testing.T) {
+	assert.NoError(t, NewLatestCommand().SetPattern("generic-local/app/*/app-*.zip").SetLatestPath("generic-local/app/latest/app.zip").validate())
+	assert.NoError(t, NewLatestCommand().SetPattern("generic-local/app/*/app-*.zip").SetMode(LatestModeProperty).validate())
+
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local").SetLatestPath("generic-local/app.zip").validate(), "<repo>/<path>")
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local/app/app.zip").SetLatestPath("generic-local/latest.zip").validate(), "must include a wildcard")
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local/app/*").SetLatestPath("generic-local/app/latest/").validate(), "requires the stable path")
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local/app/*").SetMode(LatestModeProperty).SetProperty("latest=true").validate(), "invalid property")
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local/app/*").SetMode("symlink").validate(), "unsupported mode")
+	assert.ErrorContains(t, NewLatestCommand().SetPattern("generic-local/app/*").SetSortBy("name").validate(), "unsupported sort-by")
+}
@@ -0,0 +1,464 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ManifestSyncUpload   = "upload"
+	ManifestSyncUpdate   = "update"
+	ManifestSyncSetProps = "set-props"
+	ManifestSyncDelete   = "delete"
+)
+
+// SyncManifest is the desired state of a repository path.
+type SyncManifest struct {
+	// The properties of all the files. The properties of a file override them.
+	Props map[string]string  `json:"props,omitempty"`
+	Files []SyncManifestFile `json:"files"`
+}
+
+// SyncManifestFile is a file of the repository path. Its content is either a local file, or the file already
+// in Artifactory with the sha256 checksum.
+type SyncManifestFile struct {
+	// The path of the file, relative to the repository path.
+	Path string `json:"path"`
+	// The local file, relative to the manifest.
+	File   string            `json:"file,omitempty"`
+	Sha256 string            `json:"sha256,omitempty"`
+	Props  map[string]string `json:"props,omitempty"`
+}
+
+// ManifestSyncAction is a change made to converge the repository path to the manifest.
+type ManifestSyncAction struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	// The local file of the uploaded files.
+	File string `json:"file,omitempty"`
+}
+
+type ManifestSyncResult struct {
+	Target    string               `json:"target"`
+	DryRun    bool                 `json:"dryRun"`
+	Actions   []ManifestSyncAction `json:"actions"`
+	Unchanged int                  `json:"unchanged"`
+}
+
+type manifestSyncRow struct {
+	Path   string `col-name:"Path"`
+	Action string `col-name:"Action"`
+	File   string `col-name:"Local File"`
+}
+
+// desiredFile is a file of the manifest, with its resolved local path, checksum and properties.
+type desiredFile struct {
+	path      string
+	localPath string
+	sha256    string
+	props     map[string]string
+}
+
+// ManifestSyncCommand converges a repository path to the state described by a manifest: the files missing from the path are
+// uploaded, the files whose content changed are uploaded again, the files which aren't in the manifest are deleted, and the
+// properties of the manifest are set on the files. Properties which aren't in the manifest are kept.
+type ManifestSyncCommand struct {
+	serverDetails *config.ServerDetails
+	manifestPath  string
+	target        string
+	threads       int
+	dryRun        bool
+	quiet         bool
+	result        *ManifestSyncResult
+}
+
+func NewManifestSyncCommand() *ManifestSyncCommand {
+	return &ManifestSyncCommand{threads: 3}
+}
+
+func (msc *ManifestSyncCommand) SetServerDetails(serverDetails *config.ServerDetails) *ManifestSyncCommand {
+	msc.serverDetails = serverDetails
+	return msc
+}
+
+func (msc *ManifestSyncCommand) SetManifestPath(manifestPath string) *ManifestSyncCommand {
+	msc.manifestPath = manifestPath
+	return msc
+}
+
+// SetTarget sets the repository path to converge, in the format of <repository name>/<repository path>.
+func (msc *ManifestSyncCommand) SetTarget(target string) *ManifestSyncCommand {
+	msc.target = strings.Trim(target, "/")
+	return msc
+}
+
+func (msc *ManifestSyncCommand) SetThreads(threads int) *ManifestSyncCommand {
+	msc.threads = threads
+	return msc
+}
+
+// SetDryRun only computes the changes, without making them.
+func (msc *ManifestSyncCommand) SetDryRun(dryRun bool) *ManifestSyncCommand {
+	msc.dryRun = dryRun
+	return msc
+}
+
+// SetQuiet skips the confirmation before deleting the files which aren't in the manifest.
+func (msc *ManifestSyncCommand) SetQuiet(quiet bool) *ManifestSyncCommand {
+	msc.quiet = quiet
+	return msc
+}
+
+func (msc *ManifestSyncCommand) Result() *ManifestSyncResult {
+	return msc.result
+}
+
+func (msc *ManifestSyncCommand) CommandName() string {
+	return "rt_manifest_sync"
+}
+
+func (msc *ManifestSyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return msc.serverDetails, nil
+}
+
+func (msc *ManifestSyncCommand) Run() error {
+	if msc.target == "" {
+		return errorutils.CheckErrorf("the target repository path is mandatory")
+	}
+	manifest, err := ReadSyncManifest(msc.manifestPath)
+	if err != nil {
+		return err
+	}
+	desired, err := resolveDesiredFiles(manifest, filepath.Dir(msc.manifestPath))
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(msc.serverDetails, false, msc.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	existing, err := msc.searchExistingFiles(servicesManager)
+	if err != nil {
+		return err
+	}
+	plan, err := planManifestSync(desired, existing)
+	if err != nil {
+		return err
+	}
+	msc.result = &ManifestSyncResult{Target: msc.target, DryRun: msc.dryRun, Actions: plan.actions(), Unchanged: plan.unchanged}
+	if msc.dryRun || len(msc.result.Actions) == 0 {
+		return nil
+	}
+	return msc.apply(servicesManager, plan)
+}
+
+// ReadSyncManifest reads and validates the manifest file.
+func ReadSyncManifest(manifestPath string) (*SyncManifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the manifest: %s", err.Error())
+	}
+	manifest := new(SyncManifest)
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the manifest %s: %s", manifestPath, err.Error())
+	}
+	// An empty manifest would delete the whole repository path, which is more likely a mistake.
+	if len(manifest.Files) == 0 {
+		return nil, errorutils.CheckErrorf("the manifest %s has no files", manifestPath)
+	}
+	paths := make(map[string]bool)
+	for i, file := range manifest.Files {
+		cleanPath := path.Clean(file.Path)
+		if file.Path == "" || path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return nil, errorutils.CheckErrorf("the path '%s' of the manifest must be relative to the target repository path", file.Path)
+		}
+		if file.File == "" && file.Sha256 == "" {
+			return nil, errorutils.CheckErrorf("the file %s of the manifest must have a local file or a sha256 checksum", file.Path)
+		}
+		if paths[cleanPath] {
+			return nil, errorutils.CheckErrorf("the path %s appears more than once in the manifest", cleanPath)
+		}
+		paths[cleanPath] = true
+		manifest.Files[i].Path = cleanPath
+	}
+	return manifest, nil
+}
+
+// resolveDesiredFiles calculates the checksums of the local files, and merges the properties of the files with the common ones.
+func resolveDesiredFiles(manifest *SyncManifest, manifestDir string) ([]desiredFile, error) {
+	var desired []desiredFile
+	for _, file := range manifest.Files {
+		props := make(map[string]string, len(manifest.Props)+len(file.Props))
+		for key, value := range manifest.Props {
+			props[key] = value
+		}
+		for key, value := range file.Props {
+			props[key] = value
+		}
+		desiredFile := desiredFile{path: file.Path, sha256: strings.ToLower(file.Sha256), props: props}
+		if file.File != "" {
+			desiredFile.localPath = file.File
+			if !filepath.IsAbs(desiredFile.localPath) {
+				desiredFile.localPath = filepath.Join(manifestDir, desiredFile.localPath)
+			}
+			details, err := fileutils.GetFileDetails(desiredFile.localPath, true)
+			if err != nil {
+				return nil, errorutils.CheckErrorf("failed to read the local file of %s: %s", file.Path, err.Error())
+			}
+			if desiredFile.sha256 != "" && desiredFile.sha256 != details.Checksum.Sha256 {
+				return nil, errorutils.CheckErrorf("the sha256 of %s doesn't match the checksum of the manifest for %s", desiredFile.localPath, file.Path)
+			}
+			desiredFile.sha256 = details.Checksum.Sha256
+		}
+		desired = append(desired, desiredFile)
+	}
+	return desired, nil
+}
+
+// searchExistingFiles returns the files under the target, by their path relative to the target.
+func (msc *ManifestSyncCommand) searchExistingFiles(servicesManager artifactory.ArtifactoryServicesManager) (existing map[string]servicesUtils.ResultItem, err error) {
+	reader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &servicesUtils.CommonParams{Pattern: msc.target + "/*", Recursive: true}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	existing = make(map[string]servicesUtils.ResultItem)
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		relativePath := strings.TrimPrefix(item.GetItemRelativePath(), msc.target+"/")
+		existing[relativePath] = *item
+	}
+	return existing, reader.GetError()
+}
+
+type manifestSyncPlan struct {
+	// The files to upload, which are missing or whose content changed.
+	uploads []desiredFile
+	updated map[string]bool
+	// The files whose properties differ from the manifest, and whose content didn't change.
+	propsUpdates []desiredFile
+	// The files which aren't in the manifest.
+	deletes   []servicesUtils.ResultItem
+	existing  map[string]servicesUtils.ResultItem
+	unchanged int
+}
+
+// planManifestSync compares the manifest to the files in Artifactory. All the changes are planned before any is made,
+// so a manifest which can't be applied fails without changing the repository.
+func planManifestSync(desired []desiredFile, existing map[string]servicesUtils.ResultItem) (*manifestSyncPlan, error) {
+	plan := &manifestSyncPlan{updated: make(map[string]bool), existing: existing}
+	inManifest := make(map[string]bool)
+	for _, file := range desired {
+		inManifest[file.path] = true
+		item, exists := existing[file.path]
+		switch {
+		case exists && item.Sha256 == file.sha256:
+			if propsDiffer(item.Properties, file.props) {
+				plan.propsUpdates = append(plan.propsUpdates, file)
+			} else {
+				plan.unchanged++
+			}
+		case file.localPath == "" && !exists:
+			return nil, errorutils.CheckErrorf("%s isn't in Artifactory, and the manifest has no local file to upload", file.path)
+		case file.localPath == "":
+			return nil, errorutils.CheckErrorf("the sha256 of %s in Artifactory is %s instead of %s, and the manifest has no local file to upload", file.path, item.Sha256, file.sha256)
+		default:
+			plan.updated[file.path] = exists
+			plan.uploads = append(plan.uploads, file)
+		}
+	}
+	extraneous := make([]string, 0)
+	for relativePath := range existing {
+		if !inManifest[relativePath] {
+			extraneous = append(extraneous, relativePath)
+		}
+	}
+	sort.Strings(extraneous)
+	for _, relativePath := range extraneous {
+		plan.deletes = append(plan.deletes, existing[relativePath])
+	}
+	return plan, nil
+}
+
+// propsDiffer returns true if one of the desired properties isn't set, or has other values.
+func propsDiffer(existing []servicesUtils.Property, desired map[string]string) bool {
+	values := make(map[string][]string)
+	for _, property := range existing {
+		values[property.Key] = append(values[property.Key], property.Value)
+	}
+	for key, value := range desired {
+		if len(values[key]) != 1 || values[key][0] != value {
+			return true
+		}
+	}
+	return false
+}
+
+func (plan *manifestSyncPlan) actions() []ManifestSyncAction {
+	var actions []ManifestSyncAction
+	for _, file := range plan.uploads {
+		action := ManifestSyncUpload
+		if plan.updated[file.path] {
+			action = ManifestSyncUpdate
+		}
+		actions = append(actions, ManifestSyncAction{Path: file.path, Action: action, File: file.localPath})
+	}
+	for _, file := range plan.propsUpdates {
+		actions = append(actions, ManifestSyncAction{Path: file.path, Action: ManifestSyncSetProps})
+	}
+	for _, item := range plan.deletes {
+		actions = append(actions, ManifestSyncAction{Path: item.GetItemRelativePath(), Action: ManifestSyncDelete})
+	}
+	return actions
+}
+
+// apply uploads the files, sets the properties and deletes the extraneous files. The files aren't deleted if an upload failed,
+// so that the repository path isn't left with fewer files than before.
+func (msc *ManifestSyncCommand) apply(servicesManager artifactory.ArtifactoryServicesManager, plan *manifestSyncPlan) error {
+	if len(plan.deletes) > 0 && !msc.quiet && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to delete %d files of %s which aren't in the manifest?", len(plan.deletes), msc.target), false) {
+		return errorutils.CheckErrorf("the manifest sync was aborted")
+	}
+	if err := msc.upload(servicesManager, plan.uploads); err != nil {
+		return err
+	}
+	if err := msc.setProps(servicesManager, plan); err != nil {
+		return err
+	}
+	if len(plan.deletes) == 0 {
+		return nil
+	}
+	deleted, err := deleteItems(servicesManager, plan.deletes)
+	if err != nil {
+		return err
+	}
+	if deleted < len(plan.deletes) {
+		return errorutils.CheckErrorf("failed to delete %d of the %d files which aren't in the manifest", len(plan.deletes)-deleted, len(plan.deletes))
+	}
+	return nil
+}
+
+func (msc *ManifestSyncCommand) upload(servicesManager artifactory.ArtifactoryServicesManager, files []desiredFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+	var params []services.UploadParams
+	for _, file := range files {
+		uploadParams := services.NewUploadParams()
+		uploadParams.Pattern = file.localPath
+		uploadParams.Target = msc.target + "/" + file.path
+		uploadParams.TargetProps = createPropsFromMap(file.props)
+		uploadParams.Flat = true
+		params = append(params, uploadParams)
+	}
+	log.Info(fmt.Sprintf("Uploading %d files to %s...", len(params), msc.target))
+	succeeded, failed, err := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, params...)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || succeeded < len(params) {
+		return errorutils.CheckErrorf("failed to upload %d of the %d files of the manifest. The files which aren't in the manifest weren't deleted", len(params)-succeeded, len(params))
+	}
+	return nil
+}
+
+// setProps sets the properties of the files whose content didn't change. The files with the same properties are updated together.
+func (msc *ManifestSyncCommand) setProps(servicesManager artifactory.ArtifactoryServicesManager, plan *manifestSyncPlan) error {
+	itemsByProps := make(map[string][]servicesUtils.ResultItem)
+	for _, file := range plan.propsUpdates {
+		props := formatProps(file.props)
+		itemsByProps[props] = append(itemsByProps[props], plan.existing[file.path])
+	}
+	for props, items := range itemsByProps {
+		if err := setItemsProps(servicesManager, items, props); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setItemsProps(servicesManager artifactory.ArtifactoryServicesManager, items []servicesUtils.ResultItem, props string) (err error) {
+	filePath, err := artifactoryutils.WriteResultItemsToFile(items)
+	if err != nil {
+		return err
+	}
+	reader := content.NewContentReader(filePath, "results")
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	succeeded, err := servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	if err == nil && succeeded < len(items) {
+		err = errorutils.CheckErrorf("failed to set the properties of %d files", len(items)-succeeded)
+	}
+	return err
+}
+
+func deleteItems(servicesManager artifactory.ArtifactoryServicesManager, items []servicesUtils.ResultItem) (deleted int, err error) {
+	filePath, err := artifactoryutils.WriteResultItemsToFile(items)
+	if err != nil {
+		return 0, err
+	}
+	reader := content.NewContentReader(filePath, "results")
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	return servicesManager.DeleteFiles(reader)
+}
+
+func createPropsFromMap(props map[string]string) *servicesUtils.Properties {
+	properties := servicesUtils.NewProperties()
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		properties.AddProperty(key, props[key])
+	}
+	return properties
+}
+
+// formatProps returns the properties in the format of the set-props command, key1=value1;key2=value2, escaping the separators in the values.
+func formatProps(props map[string]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var formatted []string
+	for _, key := range keys {
+		value := strings.NewReplacer(";", `\;`, ",", `\,`).Replace(props[key])
+		formatted = append(formatted, key+"="+value)
+	}
+	return strings.Join(formatted, ";")
+}
+
+func PrintManifestSyncTable(result *ManifestSyncResult) error {
+	rows := make([]manifestSyncRow, 0, len(result.Actions))
+	for _, action := range result.Actions {
+		rows = append(rows, manifestSyncRow{Path: action.Path, Action: action.Action, File: action.File})
+	}
+	title := fmt.Sprintf("Changes to %s (%d files unchanged)", result.Target, result.Unchanged)
+	if result.DryRun {
+		title = fmt.Sprintf("Planned changes to %s (%d files unchanged)", result.Target, result.Unchanged)
+	}
+	return coreutils.PrintTable(rows, title, result.Target+" already matches the manifest", false)
+}
@@ -0,0 +1,109 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSha256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestReadSyncManifestValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		errorMsg string
+	}{
+		{"no files", `{"files":[]}`, "has no files"},
+		{"absolute path", `{"files":[{"path":"/a.txt","sha256":"abc"}]}`, "must be relative"},
+		{"parent path", `{"files":[{"path":"dir/../../a.txt","sha256":"abc"}]}`, "must be relative"},
+		{"no content", `{"files":[{"path":"a.txt"}]}`, "a local file or a sha256 checksum"},
+		{"duplicate path", `{"files":[{"path":"a.txt","sha256":"abc"},{"path":"./a.txt","sha256":"abc"}]}`, "more than once"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifestPath := writeTestFile(t, t.TempDir(), "manifest.json", []byte(test.manifest))
+			_, err := ReadSyncManifest(manifestPath)
+			assert.ErrorContains(t, err, test.errorMsg)
+		})
+	}
+}
+
+func TestResolveDesiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "app.jar", []byte("app"))
+	manifestPath := writeTestFile(t, dir, "manifest.json", []byte(`{
+		"props": {"release": "1.0", "env": "prod"},
+		"files": [
+			{"path": "lib/app.jar", "file": "app.jar", "props": {"env": "staging"}},
+			{"path": "docs/readme.md", "sha256": "ABC"}
+		]
+	}`))
+	manifest, err := ReadSyncManifest(manifestPath)
+	require.NoError(t, err)
+
+	desired, err := resolveDesiredFiles(manifest, dir)
+	require.NoError(t, err)
+	require.Len(t, desired, 2)
+	assert.Equal(t, desiredFile{path: "lib/app.jar", localPath: filepath.Join(dir, "app.jar"), sha256: testSha256("app"),
+		props: map[string]string{"release": "1.0", "env": "staging"}}, desired[0])
+	assert.Equal(t, desiredFile{path: "docs/readme.md", sha256: "abc", props: map[string]string{"release": "1.0", "env": "prod"}}, desired[1])
+
+	manifest.Files[0].Sha256 = testSha256("other")
+	_, err = resolveDesiredFiles(manifest, dir)
+	assert.ErrorContains(t, err, "doesn't match the checksum of the manifest")
+}
+
+func TestPlanManifestSync(t *testing.T) {
+	props := map[string]string{"release": "1.0"}
+	desired := []desiredFile{
+		{path: "new.txt", localPath: "/local/new.txt", sha256: testSha256("new"), props: props},
+		{path: "changed.txt", localPath: "/local/changed.txt", sha256: testSha256("changed"), props: props},
+		{path: "same.txt", sha256: testSha256("same"), props: props},
+		{path: "retagged.txt", sha256: testSha256("retagged"), props: props},
+	}
+	existing := map[string]servicesUtils.ResultItem{
+		"changed.txt":  {Repo: "repo", Path: "path", Name: "changed.txt", Sha256: testSha256("old")},
+		"same.txt":     {Repo: "repo", Path: "path", Name: "same.txt", Sha256: testSha256("same"), Properties: []servicesUtils.Property{{Key: "release", Value: "1.0"}, {Key: "other", Value: "kept"}}},
+		"retagged.txt": {Repo: "repo", Path: "path", Name: "retagged.txt", Sha256: testSha256("retagged"), Properties: []servicesUtils.Property{{Key: "release", Value: "0.9"}}},
+		"b/extra.txt":  {Repo: "repo", Path: "path/b", Name: "extra.txt"},
+		"a/extra.txt":  {Repo: "repo", Path: "path/a", Name: "extra.txt"},
+	}
+
+	plan, err := planManifestSync(desired, existing)
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.unchanged)
+	assert.Equal(t, []ManifestSyncAction{
+		{Path: "new.txt", Action: ManifestSyncUpload, File: "/local/new.txt"},
+		{Path: "changed.txt", Action: ManifestSyncUpdate, File: "/local/changed.txt"},
+		{Path: "retagged.txt", Action: ManifestSyncSetProps},
+		{Path: "repo/path/a/extra.txt", Action: ManifestSyncDelete},
+		{Path: "repo/path/b/extra.txt", Action: ManifestSyncDelete},
+	}, plan.actions())
+}
+
+func TestPlanManifestSyncWithoutLocalFile(t *testing.T) {
+	desired := []desiredFile{{path: "missing.txt", sha256: testSha256("missing")}}
+	_, err := planManifestSync(desired, map[string]servicesUtils.ResultItem{})
+	assert.ErrorContains(t, err, "missing.txt isn't in Artifactory")
+
+	existing := map[string]servicesUtils.ResultItem{"missing.txt": {Sha256: testSha256("other")}}
+	_, err = planManifestSync(desired, existing)
+	assert.ErrorContains(t, err, "has no local file to upload")
+}
+
+func TestFormatProps(t *testing.T) {
+	formatted := formatProps(map[string]string{"b": "x;y", "a": "1,2"})
+	assert.Equal(t, `a=1\,2;b=x\;y`, formatted)
+	parsed, err := servicesUtils.ParseProperties(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"a": {"1,2"}, "b": {"x;y"}}, parsed.ToMap())
+}
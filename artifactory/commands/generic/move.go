@@ -29,6 +29,9 @@ func (mc *MoveCommand) SetThreads(threads int) *MoveCommand {
 
 // Moves the artifacts using the specified move pattern.
 func (mc *MoveCommand) Run() error {
+	if err := checkDeletionProtection(mc.serverDetails, mc.Spec(), "move", mc.overrideProtection); err != nil {
+		return err
+	}
 	// Create Service Manager:
 	servicesManager, err := utils.CreateServiceManagerWithThreads(mc.serverDetails, mc.DryRun(), mc.threads, mc.retries, mc.retryWaitTimeMilliSecs)
 	if err != nil {
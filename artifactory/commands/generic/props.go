@@ -15,9 +15,12 @@ import (
 )
 
 type PropsCommand struct {
-	props    string
-	threads  int
-	repoOnly bool
+	props     string
+	threads   int
+	repoOnly  bool
+	batchSize int
+	// If true, properties are applied recursively on the top-most matched folders, rather than on each of their files.
+	recursiveFolders bool
 	GenericCommand
 }
 
@@ -48,6 +51,77 @@ func (pc *PropsCommand) SetRepoOnlyFlag(repoOnly bool) *PropsCommand {
 	return pc
 }
 
+func (pc *PropsCommand) BatchSize() int {
+	return pc.batchSize
+}
+
+func (pc *PropsCommand) SetBatchSize(batchSize int) *PropsCommand {
+	pc.batchSize = batchSize
+	return pc
+}
+
+func (pc *PropsCommand) RecursiveFolders() bool {
+	return pc.recursiveFolders
+}
+
+func (pc *PropsCommand) SetRecursiveFolders(recursiveFolders bool) *PropsCommand {
+	pc.recursiveFolders = recursiveFolders
+	return pc
+}
+
+// run searches for the items matched by the spec, and applies the properties action on them.
+// The number of items the action succeeded and failed on is set in the command's result.
+func (pc *PropsCommand) run(operationName string, action func(artifactory.ArtifactoryServicesManager, services.PropsParams) (int, error)) (err error) {
+	serverDetails, err := pc.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	servicesManager, err := createPropsServiceManager(pc.threads, pc.retries, pc.retryWaitTimeMilliSecs, serverDetails)
+	if err != nil {
+		return err
+	}
+	searchSpec := pc.Spec()
+	if pc.recursiveFolders {
+		if err = validateRecursiveFoldersSpec(searchSpec); err != nil {
+			return err
+		}
+		searchSpec = includeDirsInSpec(searchSpec)
+	}
+	searchReader, err := searchItems(searchSpec, servicesManager)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, searchReader.Close())
+	}()
+	reader := searchReader
+	if pc.recursiveFolders {
+		var collapsedReader *content.ContentReader
+		collapsedReader, err = collapseToTopFolders(searchReader)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, collapsedReader.Close())
+		}()
+		reader = collapsedReader
+	}
+	success, err := applyPropsInBatches(reader, pc.batchSize, operationName, func(batch *content.ContentReader) (int, error) {
+		propsParams := GetPropsParams(batch, pc.props, pc.repoOnly)
+		propsParams.IsRecursive = pc.recursiveFolders
+		return action(servicesManager, propsParams)
+	})
+
+	result := pc.Result()
+	result.SetSuccessCount(success)
+	totalLength, totalLengthErr := reader.Length()
+	result.SetFailCount(totalLength - success)
+	if totalLengthErr != nil {
+		return totalLengthErr
+	}
+	return err
+}
+
 func createPropsServiceManager(threads, httpRetries, retryWaitMilliSecs int, serverDetails *config.ServerDetails) (artifactory.ArtifactoryServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
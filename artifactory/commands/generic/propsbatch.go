@@ -0,0 +1,132 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// propsOperation sets or deletes properties on the items of the reader, and returns the number of items it succeeded on.
+type propsOperation func(reader *content.ContentReader) (int, error)
+
+// applyPropsInBatches runs the operation on the items of the reader in batches of batchSize items, and logs the
+// progress after each batch. Errors of a single batch do not stop the processing of the following batches.
+// If batchSize isn't positive, the operation runs on all items at once.
+func applyPropsInBatches(reader *content.ContentReader, batchSize int, operationName string, operation propsOperation) (success int, err error) {
+	total, err := reader.Length()
+	if err != nil {
+		return
+	}
+	if batchSize <= 0 || total <= batchSize {
+		return operation(reader)
+	}
+	processed := 0
+	for {
+		batch, count, batchErr := nextPropsBatch(reader, batchSize)
+		if batchErr != nil {
+			return success, errors.Join(err, batchErr)
+		}
+		if batch == nil {
+			break
+		}
+		batchSuccess, batchErr := operation(batch)
+		success += batchSuccess
+		processed += count
+		err = errors.Join(err, batchErr, batch.Close())
+		log.Info(fmt.Sprintf("%s: processed %d/%d items (%d succeeded).", operationName, processed, total, success))
+	}
+	reader.Reset()
+	return
+}
+
+// nextPropsBatch reads the next batchSize items of the reader into a new reader.
+// Returns a nil batch once all the items of the reader were read.
+func nextPropsBatch(reader *content.ContentReader, batchSize int) (batch *content.ContentReader, count int, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for item := new(servicesUtils.ResultItem); count < batchSize && reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		writer.Write(*item)
+		count++
+	}
+	if err = errors.Join(writer.Close(), reader.GetError()); err != nil {
+		return nil, 0, err
+	}
+	if count == 0 {
+		return nil, 0, nil
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), count, nil
+}
+
+// validateRecursiveFoldersSpec verifies that the spec matches entire folders, since with recursive folders the
+// properties are applied server-side on all the content of the matched folders, regardless of file level filters.
+func validateRecursiveFoldersSpec(specFiles *spec.SpecFiles) error {
+	for _, file := range specFiles.Files {
+		if len(file.Exclusions) > 0 || file.Props != "" || file.ExcludeProps != "" || file.Build != "" || file.Bundle != "" || file.Aql.ItemsFind != "" {
+			return errorutils.CheckErrorf("the recursive-folders option applies the properties on the entire content of the matched folders, " +
+				"and therefore cannot be used with exclusions, props, exclude-props, build, bundle or AQL based file filtering")
+		}
+	}
+	return nil
+}
+
+// includeDirsInSpec returns a copy of the spec, which also matches the folders of the patterns.
+func includeDirsInSpec(specFiles *spec.SpecFiles) *spec.SpecFiles {
+	dirsSpec := new(spec.SpecFiles)
+	for _, file := range specFiles.Files {
+		file.IncludeDirs = "true"
+		dirsSpec.Files = append(dirsSpec.Files, file)
+	}
+	return dirsSpec
+}
+
+// collapseToTopFolders reduces the items of the reader to the top-most folders, and the files which are not located
+// under any of these folders. Applying properties recursively on the reduced items updates the entire content of the
+// folders server-side, using a single request per folder instead of a request per file.
+func collapseToTopFolders(reader *content.ContentReader) (collapsed *content.ContentReader, err error) {
+	folders := make(map[string]struct{})
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		if item.Type == string(servicesUtils.Folder) {
+			folders[strings.TrimSuffix(item.GetItemRelativePath(), "/")] = struct{}{}
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return
+	}
+	reader.Reset()
+
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		if !isUnderFolder(strings.TrimSuffix(item.GetItemRelativePath(), "/"), folders) {
+			writer.Write(*item)
+		}
+	}
+	if err = errors.Join(writer.Close(), reader.GetError()); err != nil {
+		return
+	}
+	reader.Reset()
+	if writer.IsEmpty() {
+		return content.NewEmptyContentReader(content.DefaultKey), nil
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+func isUnderFolder(itemPath string, folders map[string]struct{}) bool {
+	for parent := path.Dir(itemPath); parent != "." && parent != "/"; parent = path.Dir(parent) {
+		if _, exists := folders[parent]; exists {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createResultItemsReader(t *testing.T, items ...servicesUtils.ResultItem) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, item := range items {
+		writer.Write(item)
+	}
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	t.Cleanup(func() { assert.NoError(t, reader.Close()) })
+	return reader
+}
+
+func readItemPaths(t *testing.T, reader *content.ContentReader) (paths []string) {
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		paths = append(paths, item.GetItemRelativePath())
+	}
+	require.NoError(t, reader.GetError())
+	reader.Reset()
+	return
+}
+
+func TestApplyPropsInBatches(t *testing.T) {
+	var items []servicesUtils.ResultItem
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		items = append(items, servicesUtils.ResultItem{Repo: "repo", Path: "dir", Name: name, Type: "file"})
+	}
+	reader := createResultItemsReader(t, items...)
+
+	var batchSizes []int
+	success, err := applyPropsInBatches(reader, 2, "Set properties", func(batch *content.ContentReader) (int, error) {
+		length, err := batch.Length()
+		batchSizes = append(batchSizes, length)
+		return length, err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, success)
+	assert.Equal(t, []int{2, 2, 1}, batchSizes)
+
+	// Without a batch size, the operation runs once on the original reader.
+	batchSizes = nil
+	success, err = applyPropsInBatches(reader, 0, "Set properties", func(batch *content.ContentReader) (int, error) {
+		assert.Equal(t, reader, batch)
+		batchSizes = append(batchSizes, 5)
+		return 5, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, success)
+	assert.Len(t, batchSizes, 1)
+}
+
+func TestCollapseToTopFolders(t *testing.T) {
+	reader := createResultItemsReader(t,
+		servicesUtils.ResultItem{Repo: "repo", Path: ".", Name: "dir", Type: "folder"},
+		servicesUtils.ResultItem{Repo: "repo", Path: "dir", Name: "sub", Type: "folder"},
+		servicesUtils.ResultItem{Repo: "repo", Path: "dir/sub", Name: "a.bin", Type: "file"},
+		servicesUtils.ResultItem{Repo: "repo", Path: "dir", Name: "b.bin", Type: "file"},
+		servicesUtils.ResultItem{Repo: "repo", Path: "other", Name: "c.bin", Type: "file"},
+		servicesUtils.ResultItem{Repo: "repo", Path: ".", Name: "dir-sibling.bin", Type: "file"},
+	)
+	collapsed, err := collapseToTopFolders(reader)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, collapsed.Close())
+	}()
+	assert.ElementsMatch(t, []string{"repo/dir", "repo/other/c.bin", "repo/dir-sibling.bin"}, readItemPaths(t, collapsed))
+}
+
+func TestValidateRecursiveFoldersSpec(t *testing.T) {
+	assert.NoError(t, validateRecursiveFoldersSpec(spec.NewBuilder().Pattern("repo/dir/").BuildSpec()))
+	assert.Error(t, validateRecursiveFoldersSpec(spec.NewBuilder().Pattern("repo/dir/").Exclusions([]string{"*.tmp"}).BuildSpec()))
+	assert.Error(t, validateRecursiveFoldersSpec(spec.NewBuilder().Pattern("repo/dir/").Props("a=b").BuildSpec()))
+
+	dirsSpec := includeDirsInSpec(spec.NewBuilder().Pattern("repo/dir/").BuildSpec())
+	assert.Equal(t, "true", dirsSpec.Get(0).IncludeDirs)
+}
@@ -0,0 +1,201 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The path of the deletion protection policy file. If not set, the deletion-protection.json file in the JFrog CLI home directory is used,
+	// if it exists.
+	DeletionProtectionPolicyEnv  = "JFROG_CLI_DELETION_PROTECTION_POLICY"
+	deletionProtectionPolicyFile = "deletion-protection.json"
+	// The number of protected paths which are listed when a delete or a move is refused.
+	maxListedProtectedPaths = 10
+)
+
+// The console input of the typed confirmation, which is replaced by the tests.
+var scanProtectionConfirmation = ioutils.ScanFromConsole
+
+// DeletionProtectionPolicy is a local policy of the artifacts which the delete and the move commands refuse to remove,
+// unless the protection is overridden explicitly and confirmed by typing the names of the protected repositories.
+// It's a last line of defense against mistyped patterns, and doesn't replace the permissions in Artifactory.
+type DeletionProtectionPolicy struct {
+	// Wildcard patterns of the repositories whose artifacts are protected, such as *-release-local.
+	ProtectedRepos []string `json:"protectedRepos,omitempty"`
+	// The properties which mark protected artifacts, in the form of key=value, or key for any value.
+	ProtectedProps []string `json:"protectedProps,omitempty"`
+}
+
+// protectedPath is an artifact which the policy protects, and the rule which protects it.
+type protectedPath struct {
+	repo   string
+	path   string
+	reason string
+}
+
+// LoadDeletionProtectionPolicy reads the deletion protection policy file. It returns nil if no policy file is found.
+func LoadDeletionProtectionPolicy() (*DeletionProtectionPolicy, error) {
+	policyPath := os.Getenv(DeletionProtectionPolicyEnv)
+	if policyPath == "" {
+		homeDir, err := coreutils.GetJfrogHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		policyPath = filepath.Join(homeDir, deletionProtectionPolicyFile)
+		if _, err = os.Stat(policyPath); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the deletion protection policy file: %s", err.Error())
+	}
+	policy := new(DeletionProtectionPolicy)
+	if err = json.Unmarshal(content, policy); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the deletion protection policy file '%s': %s", policyPath, err.Error())
+	}
+	return policy, policy.Validate()
+}
+
+func (dpp *DeletionProtectionPolicy) Validate() error {
+	for _, repoPattern := range dpp.ProtectedRepos {
+		if _, err := path.Match(repoPattern, ""); err != nil {
+			return errorutils.CheckErrorf("the protected repository pattern '%s' is invalid: %s", repoPattern, err.Error())
+		}
+	}
+	for _, prop := range dpp.ProtectedProps {
+		if key, _, _ := strings.Cut(prop, "="); key == "" {
+			return errorutils.CheckErrorf("the protected property '%s' must be in the form of key=value or key", prop)
+		}
+	}
+	return nil
+}
+
+// protectionReason returns the rule of the policy which protects the artifact, or an empty string if it isn't protected.
+func (dpp *DeletionProtectionPolicy) protectionReason(item *clientutils.ResultItem) string {
+	for _, repoPattern := range dpp.ProtectedRepos {
+		if matched, _ := path.Match(repoPattern, item.Repo); matched {
+			return fmt.Sprintf("the repository matches the protected repositories pattern '%s'", repoPattern)
+		}
+	}
+	for _, prop := range dpp.ProtectedProps {
+		key, value, hasValue := strings.Cut(prop, "=")
+		for _, itemProp := range item.Properties {
+			if itemProp.Key == key && (!hasValue || itemProp.Value == value) {
+				return fmt.Sprintf("the artifact has the protected property '%s'", prop)
+			}
+		}
+	}
+	return ""
+}
+
+// checkDeletionProtection refuses the delete or the move of the artifacts of the spec files, if any of them is protected by the policy.
+// If overrideProtection is set, the operation is allowed once the names of the repositories of the protected artifacts are typed.
+func checkDeletionProtection(serverDetails *config.ServerDetails, specFiles *spec.SpecFiles, operation string, overrideProtection bool) error {
+	policy, err := LoadDeletionProtectionPolicy()
+	if err != nil || policy == nil || (len(policy.ProtectedRepos) == 0 && len(policy.ProtectedProps) == 0) {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	protectedPaths, total, err := policy.findProtectedPaths(servicesManager, specFiles)
+	if err != nil || total == 0 {
+		return err
+	}
+	log.Warn(fmt.Sprintf("The %s includes %d artifacts protected by the deletion protection policy:", operation, total))
+	for _, protected := range protectedPaths {
+		log.Warn(fmt.Sprintf("  %s/%s: %s", protected.repo, protected.path, protected.reason))
+	}
+	if total > len(protectedPaths) {
+		log.Warn(fmt.Sprintf("  and %d more.", total-len(protectedPaths)))
+	}
+	if !overrideProtection {
+		return errorutils.CheckErrorf("the %s was refused, since it includes %d protected artifacts. "+
+			"Review them, and run the command again with --override-protection if the %s is intended", operation, total, operation)
+	}
+	for _, repo := range protectedRepos(protectedPaths) {
+		var confirmation string
+		scanProtectionConfirmation(fmt.Sprintf("Type the name of the protected repository '%s' to confirm the %s", repo, operation), &confirmation, "")
+		if strings.TrimSpace(confirmation) != repo {
+			return errorutils.CheckErrorf("the %s was aborted, since the name of the protected repository '%s' wasn't confirmed", operation, repo)
+		}
+	}
+	log.Warn(fmt.Sprintf("Overriding the deletion protection of %d artifacts.", total))
+	return nil
+}
+
+// findProtectedPaths returns the first protected artifacts which the spec files match, and the total number of the protected artifacts.
+func (dpp *DeletionProtectionPolicy) findProtectedPaths(servicesManager artifactory.ArtifactoryServicesManager, specFiles *spec.SpecFiles) (protectedPaths []protectedPath, total int, err error) {
+	// The repositories of all the protected artifacts must be confirmed, so one artifact of each protected repository is kept.
+	listedRepos := make(map[string]bool)
+	for i := 0; i < len(specFiles.Files); i++ {
+		searchParams := services.NewSearchParams()
+		if searchParams.CommonParams, err = specFiles.Get(i).ToCommonParams(); err != nil {
+			return
+		}
+		if searchParams.Recursive, err = specFiles.Get(i).IsRecursive(true); err != nil {
+			return
+		}
+		var fileTotal int
+		if protectedPaths, fileTotal, err = dpp.searchProtectedPaths(servicesManager, searchParams, protectedPaths, listedRepos); err != nil {
+			return
+		}
+		total += fileTotal
+	}
+	return
+}
+
+func (dpp *DeletionProtectionPolicy) searchProtectedPaths(servicesManager artifactory.ArtifactoryServicesManager, searchParams services.SearchParams,
+	protectedPaths []protectedPath, listedRepos map[string]bool) (_ []protectedPath, total int, err error) {
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	for item := new(clientutils.ResultItem); reader.NextRecord(item) == nil; item = new(clientutils.ResultItem) {
+		reason := dpp.protectionReason(item)
+		if reason == "" {
+			continue
+		}
+		total++
+		if len(protectedPaths) < maxListedProtectedPaths || !listedRepos[item.Repo] {
+			protectedPaths = append(protectedPaths, protectedPath{repo: item.Repo, path: strings.TrimPrefix(path.Join(item.Path, item.Name), "./"), reason: reason})
+			listedRepos[item.Repo] = true
+		}
+	}
+	return protectedPaths, total, reader.GetError()
+}
+
+func protectedRepos(protectedPaths []protectedPath) []string {
+	var repos []string
+	for _, protected := range protectedPaths {
+		if !slices.Contains(repos, protected.repo) {
+			repos = append(repos, protected.repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos
+}
@@ -0,0 +1,92 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProtectionPolicy(t *testing.T, content string) {
+	policyPath := filepath.Join(t.TempDir(), deletionProtectionPolicyFile)
+	require.NoError(t, os.WriteFile(policyPath, []byte(content), 0644))
+	t.Setenv(DeletionProtectionPolicyEnv, policyPath)
+}
+
+func TestLoadDeletionProtectionPolicy(t *testing.T) {
+	// Without a policy file, nothing is protected.
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	policy, err := LoadDeletionProtectionPolicy()
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	writeProtectionPolicy(t, `{"protectedRepos":["*-release-local"],"protectedProps":["retention=keep","legal-hold"]}`)
+	policy, err = LoadDeletionProtectionPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, &DeletionProtectionPolicy{ProtectedRepos: []string{"*-release-local"}, ProtectedProps: []string{"retention=keep", "legal-hold"}}, policy)
+
+	writeProtectionPolicy(t, `{"protectedRepos":["[release"]}`)
+	_, err = LoadDeletionProtectionPolicy()
+	assert.ErrorContains(t, err, "the protected repository pattern '[release' is invalid")
+
+	writeProtectionPolicy(t, `{"protectedProps":["=keep"]}`)
+	_, err = LoadDeletionProtectionPolicy()
+	assert.ErrorContains(t, err, "must be in the form of key=value or key")
+}
+
+func TestProtectionReason(t *testing.T) {
+	policy := &DeletionProtectionPolicy{ProtectedRepos: []string{"*-release-local"}, ProtectedProps: []string{"retention=keep", "legal-hold"}}
+	assert.Contains(t, policy.protectionReason(&clientutils.ResultItem{Repo: "libs-release-local"}), "'*-release-local'")
+	assert.Contains(t, policy.protectionReason(&clientutils.ResultItem{Repo: "libs-snapshot-local", Properties: []clientutils.Property{{Key: "retention", Value: "keep"}}}), "'retention=keep'")
+	assert.Contains(t, policy.protectionReason(&clientutils.ResultItem{Repo: "libs-snapshot-local", Properties: []clientutils.Property{{Key: "legal-hold", Value: "case-17"}}}), "'legal-hold'")
+	assert.Empty(t, policy.protectionReason(&clientutils.ResultItem{Repo: "libs-snapshot-local", Properties: []clientutils.Property{{Key: "retention", Value: "30d"}}}))
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/search/aql":
+			_, _ = w.Write([]byte(`{"results":[
+{"repo":"libs-snapshot-local","path":"org/app/1.0-SNAPSHOT","name":"app-1.0-20240101.jar","type":"file"},
+{"repo":"libs-snapshot-local","path":"org/app/1.0-SNAPSHOT","name":"app-1.0-20240102.jar","type":"file","properties":[{"key":"retention","value":"keep"}]}
+],"range":{"start_pos":0,"end_pos":2,"total":2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+	deleteSpec := spec.NewBuilder().Pattern("libs-snapshot-local/org/app/").BuildSpec()
+	previousScan := scanProtectionConfirmation
+	defer func() {
+		scanProtectionConfirmation = previousScan
+	}()
+	var confirmation string
+	scanProtectionConfirmation = func(_ string, scanInto *string, _ string) {
+		*scanInto = confirmation
+	}
+
+	writeProtectionPolicy(t, `{"protectedProps":["legal-hold"]}`)
+	assert.NoError(t, checkDeletionProtection(serverDetails, deleteSpec, "delete", false))
+
+	writeProtectionPolicy(t, `{"protectedProps":["retention=keep"]}`)
+	err := checkDeletionProtection(serverDetails, deleteSpec, "delete", false)
+	assert.ErrorContains(t, err, "the delete was refused, since it includes 1 protected artifacts")
+
+	// The override must be confirmed by typing the name of the repository.
+	confirmation = "libs-release-local"
+	err = checkDeletionProtection(serverDetails, deleteSpec, "delete", true)
+	assert.ErrorContains(t, err, "the name of the protected repository 'libs-snapshot-local' wasn't confirmed")
+	confirmation = "libs-snapshot-local"
+	assert.NoError(t, checkDeletionProtection(serverDetails, deleteSpec, "delete", true))
+}
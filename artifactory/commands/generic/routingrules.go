@@ -0,0 +1,76 @@
+package generic
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// UploadRoutingRules maps local file patterns to the Artifactory targets the matching files should be uploaded to.
+// This allows a single upload to route files to different repositories, for example *.deb files to a Debian
+// repository and *.rpm files to an RPM repository, with a combined summary and build-info.
+type UploadRoutingRules struct {
+	Rules []UploadRoutingRule `json:"rules"`
+}
+
+type UploadRoutingRule struct {
+	// Local file system path pattern, which may include the * and the ? wildcards.
+	Pattern string `json:"pattern"`
+	// Target path in Artifactory in the following format: <repository name>/<repository path>.
+	Target string `json:"target"`
+	// Semicolon-separated properties in the form of "key1=value1;key2=value2;...", set on the uploaded files.
+	Props      string   `json:"props,omitempty"`
+	Exclusions []string `json:"exclusions,omitempty"`
+}
+
+func ReadUploadRoutingRules(rulesFilePath string) (*UploadRoutingRules, error) {
+	content, err := os.ReadFile(rulesFilePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the routing rules file: %s", err.Error())
+	}
+	rules := new(UploadRoutingRules)
+	if err = json.Unmarshal(content, rules); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the routing rules file '%s': %s", rulesFilePath, err.Error())
+	}
+	return rules, rules.Validate()
+}
+
+func (urr *UploadRoutingRules) Validate() error {
+	if len(urr.Rules) == 0 {
+		return errorutils.CheckErrorf("the routing rules file must include at least one rule")
+	}
+	for i, rule := range urr.Rules {
+		if rule.Pattern == "" || rule.Target == "" {
+			return errorutils.CheckErrorf("routing rule #%d must include both a pattern and a target", i+1)
+		}
+	}
+	return nil
+}
+
+// ToSpec converts the routing rules to an upload spec, where each rule is a separate spec file based on template.
+// A file is uploaded according to the first rule it matches, so the patterns of all preceding rules are
+// added to the exclusions of each rule.
+func (urr *UploadRoutingRules) ToSpec(template spec.File) (*spec.SpecFiles, error) {
+	if regexp, err := template.IsRegexp(false); err != nil || regexp {
+		return nil, errorutils.CheckErrorf("routing rules support wildcard patterns only, and cannot be used with the regexp option")
+	}
+	if ant, err := template.IsAnt(false); err != nil || ant {
+		return nil, errorutils.CheckErrorf("routing rules support wildcard patterns only, and cannot be used with the ant option")
+	}
+	routingSpec := new(spec.SpecFiles)
+	var precedingPatterns []string
+	for _, rule := range urr.Rules {
+		file := template
+		file.Pattern = rule.Pattern
+		file.Target = strings.TrimPrefix(rule.Target, "/")
+		file.TargetProps = clientUtils.AddProps(template.TargetProps, rule.Props)
+		file.Exclusions = append(append(append([]string{}, template.Exclusions...), rule.Exclusions...), precedingPatterns...)
+		routingSpec.Files = append(routingSpec.Files, file)
+		precedingPatterns = append(precedingPatterns, rule.Pattern)
+	}
+	return routingSpec, nil
+}
@@ -0,0 +1,135 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUploadRoutingRules(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	assert.NoError(t, os.WriteFile(rulesFile, []byte(`{"rules": [
+		{"pattern": "dist/*.deb", "target": "deb-local/pool/", "props": "deb.distribution=focal"},
+		{"pattern": "dist/*.rpm", "target": "/rpm-local/"}
+	]}`), 0644))
+
+	rules, err := ReadUploadRoutingRules(rulesFile)
+	assert.NoError(t, err)
+	assert.Len(t, rules.Rules, 2)
+	assert.Equal(t, "deb.distribution=focal", rules.Rules[0].Props)
+}
+
+func TestUploadRoutingRules_Validate(t *testing.T) {
+	assert.Error(t, (&UploadRoutingRules{}).Validate())
+	assert.Error(t, (&UploadRoutingRules{Rules: []UploadRoutingRule{{Pattern: "*.deb"}}}).Validate())
+	assert.NoError(t, (&UploadRoutingRules{Rules: []UploadRoutingRule{{Pattern: "*.deb", Target: "deb-local/"}}}).Validate())
+}
+
+func TestUploadRoutingRules_ToSpec(t *testing.T) {
+	rules := &UploadRoutingRules{Rules: []UploadRoutingRule{
+		{Pattern: "dist/*.deb", Target: "deb-local/pool/", Props: "deb.distribution=focal"},
+		{Pattern: "dist/*.rpm", Target: "/rpm-local/"},
+		{Pattern: "dist/*", Target: "generic-local/", Exclusions: []string{"*.tmp"}},
+	}}
+	template := spec.File{Recursive: "true", TargetProps: "team=platform", Exclusions: []string{"*.log"}}
+
+	routingSpec, err := rules.ToSpec(template)
+	assert.NoError(t, err)
+	assert.Len(t, routingSpec.Files, 3)
+
+	assert.Equal(t, "deb-local/pool/", routingSpec.Files[0].Target)
+	assert.Equal(t, "team=platform;deb.distribution=focal", routingSpec.Files[0].TargetProps)
+	assert.Equal(t, []string{"*.log"}, routingSpec.Files[0].Exclusions)
+
+	assert.Equal(t, "rpm-local/", routingSpec.Files[1].Target)
+	assert.Equal(t, "team=platform", routingSpec.Files[1].TargetProps)
+	assert.Equal(t, []string{"*.log", "dist/*.deb"}, routingSpec.Files[1].Exclusions)
+
+	// A file is routed by the first matching rule, so the catch-all rule excludes the patterns of the preceding rules.
+	assert.Equal(t, []string{"*.log", "*.tmp", "dist/*.deb", "dist/*.rpm"}, routingSpec.Files[2].Exclusions)
+	assert.Equal(t, "true", routingSpec.Files[2].Recursive)
+	// The template should not be modified.
+	assert.Equal(t, []string{"*.log"}, template.Exclusions)
+
+	_, err = rules.ToSpec(spec.File{Regexp: "true"})
+	assert.Error(t, err)
+	_, err = rules.ToSpec(spec.File{Ant: "true"})
+	assert.Error(t, err)
+}
+
+// The artifacts uploaded by the routing rules are recorded in the build-info of the project, which is set by --project, or by the
+// JFROG_CLI_BUILD_PROJECT environment variable.
+func TestUploadRoutingRulesBuildInfoProject(t *testing.T) {
+	for _, fromEnv := range []bool{false, true} {
+		t.Run("fromEnv="+strconv.FormatBool(fromEnv), func(t *testing.T) {
+			t.Setenv(coreutils.HomeDir, t.TempDir())
+			var mutex sync.Mutex
+			var uploaded []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				if r.Method == http.MethodPut {
+					uploaded = append(uploaded, strings.Split(r.URL.Path, ";")[0])
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			dir := t.TempDir()
+			for _, name := range []string{"app.deb", "app.rpm"} {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+			}
+			rules := &UploadRoutingRules{Rules: []UploadRoutingRule{
+				{Pattern: filepath.ToSlash(filepath.Join(dir, "*.deb")), Target: "deb-local/pool/"},
+				{Pattern: filepath.ToSlash(filepath.Join(dir, "*.rpm")), Target: "rpm-local/"},
+			}}
+			routingSpec, err := rules.ToSpec(*spec.NewBuilder().Recursive(true).Flat(true).BuildSpec().Get(0))
+			require.NoError(t, err)
+
+			// The build dirs are in the persistent temp dir of the process, so each test uses a build number of its own.
+			buildNumber := strconv.FormatBool(fromEnv)
+			t.Cleanup(func() {
+				assert.NoError(t, build.RemoveBuildDir("routed", buildNumber, "proj1"))
+				assert.NoError(t, build.RemoveBuildDir("routed", buildNumber, ""))
+			})
+			project := "proj1"
+			if fromEnv {
+				t.Setenv(coreutils.Project, "proj1")
+				project = ""
+			}
+			uploadCommand := NewUploadCommand().
+				SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).
+				SetBuildConfiguration(build.NewBuildConfiguration("routed", buildNumber, "", project))
+			uploadCommand.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).SetSpec(routingSpec)
+			require.NoError(t, uploadCommand.Run())
+			assert.ElementsMatch(t, []string{"/artifactory/deb-local/pool/app.deb", "/artifactory/rpm-local/app.rpm"}, uploaded)
+
+			partials, err := build.ReadPartialBuildInfoFiles("routed", buildNumber, "proj1")
+			require.NoError(t, err)
+			var artifacts []string
+			for _, partial := range partials {
+				for _, artifact := range partial.Artifacts {
+					artifacts = append(artifacts, artifact.Path)
+				}
+			}
+			assert.ElementsMatch(t, []string{"pool/app.deb", "app.rpm"}, artifacts)
+			// Nothing is recorded in the build-info without the project.
+			partials, err = build.ReadPartialBuildInfoFiles("routed", buildNumber, "")
+			require.NoError(t, err)
+			assert.Empty(t, partials)
+		})
+	}
+}
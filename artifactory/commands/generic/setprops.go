@@ -1,9 +1,7 @@
 package generic
 
 import (
-	"errors"
-
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 )
 
 type SetPropsCommand struct {
@@ -28,32 +26,6 @@ func (setProps *SetPropsCommand) CommandName() string {
 	return "rt_set_properties"
 }
 
-func (setProps *SetPropsCommand) Run() (err error) {
-	serverDetails, err := setProps.ServerDetails()
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	servicesManager, err := createPropsServiceManager(setProps.threads, setProps.retries, setProps.retryWaitTimeMilliSecs, serverDetails)
-	if err != nil {
-		return err
-	}
-
-	reader, err := searchItems(setProps.Spec(), servicesManager)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		err = errors.Join(err, reader.Close())
-	}()
-	propsParams := GetPropsParams(reader, setProps.props, setProps.repoOnly)
-	success, err := servicesManager.SetProps(propsParams)
-
-	result := setProps.Result()
-	result.SetSuccessCount(success)
-	totalLength, totalLengthErr := reader.Length()
-	result.SetFailCount(totalLength - success)
-	if totalLengthErr != nil {
-		return totalLengthErr
-	}
-	return err
+func (setProps *SetPropsCommand) Run() error {
+	return setProps.run("Set properties", artifactory.ArtifactoryServicesManager.SetProps)
 }
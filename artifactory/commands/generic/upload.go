@@ -8,7 +8,11 @@ import (
 
 	buildInfo "github.com/jfrog/build-info-go/entities"
 	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/checkpoint"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/concurrency"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -28,6 +32,9 @@ type UploadCommand struct {
 	uploadConfiguration *utils.UploadConfiguration
 	buildConfiguration  *build.BuildConfiguration
 	progress            ioUtils.ProgressMgr
+	validationReport    *ValidationReportOptions
+	resume              bool
+	extractProps        bool
 }
 
 func NewUploadCommand() *UploadCommand {
@@ -48,6 +55,16 @@ func (uc *UploadCommand) SetUploadConfiguration(uploadConfiguration *utils.Uploa
 	return uc
 }
 
+func (uc *UploadCommand) SetValidationReport(validationReport *ValidationReportOptions) *UploadCommand {
+	uc.validationReport = validationReport
+	return uc
+}
+
+func (uc *UploadCommand) SetResume(resume bool) *UploadCommand {
+	uc.resume = resume
+	return uc
+}
+
 func (uc *UploadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	uc.progress = progress
 }
@@ -71,6 +88,7 @@ func (uc *UploadCommand) Run() error {
 // Uploads the artifacts in the specified local path pattern to the specified target path.
 // Returns the total number of artifacts successfully uploaded.
 func (uc *UploadCommand) upload() (err error) {
+	startedAt := time.Now()
 	// Init progress bar if needed
 	if uc.progress != nil {
 		uc.progress.SetHeadlineMsg("Uploading")
@@ -92,7 +110,14 @@ func (uc *UploadCommand) upload() (err error) {
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	servicesManager, err := utils.CreateUploadServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.retries, uc.retryWaitTimeMilliSecs, uc.DryRun(), uc.progress)
+	threads, releaseThreads, err := concurrency.Acquire(serverproxy.OriginalUrl(serverDetails.GetArtifactoryUrl()), uc.uploadConfiguration.Threads)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, releaseThreads())
+	}()
+	servicesManager, err := uc.createTransferServiceManager(threads, uc.progress)
 	if err != nil {
 		return
 	}
@@ -112,6 +137,18 @@ func (uc *UploadCommand) upload() (err error) {
 		}
 	}
 
+	// Resuming requires the checkpoint to be loaded before the spec is modified below.
+	var uploadCheckpoint *checkpoint.Checkpoint
+	resume := uc.resume && !uc.DryRun()
+	if resume {
+		if uc.syncDelete() || toCollect {
+			return errorutils.CheckErrorf("the --resume option is not supported with --sync-deletes or build-info collection")
+		}
+		if uploadCheckpoint, err = uc.loadUploadCheckpoint(); err != nil {
+			return
+		}
+	}
+
 	var errorOccurred = false
 	var uploadParamsArray []services.UploadParams
 	// Create UploadParams for all File-Spec groups.
@@ -130,15 +167,26 @@ func (uc *UploadCommand) upload() (err error) {
 		}
 		uploadParamsArray = append(uploadParamsArray, uploadParams)
 	}
+	extractProps := uc.extractProps && !uc.DryRun()
+	if extractProps {
+		if err = validateExtractPropsUpload(uploadParamsArray); err != nil {
+			return
+		}
+	}
 
 	// Perform upload.
 	// In case of build-info collection or a detailed summary request, we use the upload service which provides results file reader,
 	// otherwise we use the upload service which provides only general counters.
 	var successCount, failCount int
 	var artifactsDetailsReader *content.ContentReader = nil
-	if uc.DetailedSummary() || toCollect {
+	createReport := uc.validationReport != nil && !uc.DryRun()
+	if uc.DetailedSummary() || toCollect || createReport || resume || extractProps || jobsummary.IsEnabled() {
 		var summary *rtServicesUtils.OperationSummary
-		summary, err = servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+		if resume {
+			summary, err = uc.uploadWithCheckpoint(servicesManager, uploadCheckpoint, uploadParamsArray)
+		} else {
+			summary, err = servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+		}
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -146,6 +194,22 @@ func (uc *UploadCommand) upload() (err error) {
 		if summary != nil {
 			artifactsDetailsReader = summary.ArtifactsDetailsReader
 			defer ioutils.Close(artifactsDetailsReader, &err)
+			if summary.TransferDetailsReader, err = serverproxy.RestoreTransferDetails(summary.TransferDetailsReader); err != nil {
+				return
+			}
+			if extractProps {
+				if _, propsErr := setExtractedProps(servicesManager, summary.TransferDetailsReader); propsErr != nil {
+					errorOccurred = true
+					log.Error(propsErr)
+				}
+			}
+			if createReport {
+				if reportErr := createValidationReport("upload", startedAt, summary, uc.validationReport, serverDetails, uc.retries, uc.retryWaitTimeMilliSecs); reportErr != nil {
+					errorOccurred = true
+					log.Error(reportErr)
+				}
+			}
+			jobsummary.RecordTransferDetails("Files uploaded to Artifactory", summary.TransferDetailsReader)
 			// If 'detailed summary' was requested, then the reader should not be closed here.
 			// It will be closed after it will be used to generate the summary.
 			if uc.DetailedSummary() {
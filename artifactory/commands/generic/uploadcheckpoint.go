@@ -0,0 +1,210 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/checkpoint"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The number of files uploaded between two updates of the checkpoint.
+const uploadCheckpointBatchSize = 1000
+
+type pendingUpload struct {
+	paramsIndex int
+	artifact    clientUtils.Artifact
+}
+
+// loadUploadCheckpoint loads the checkpoint of a previous interrupted run of the same upload.
+// It must be called before the spec is modified by the upload.
+func (uc *UploadCommand) loadUploadCheckpoint() (*checkpoint.Checkpoint, error) {
+	identity, err := json.Marshal(struct {
+		Url  string `json:"url"`
+		Spec any    `json:"spec"`
+	}{uc.serverDetails.ArtifactoryUrl, uc.Spec()})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return checkpoint.Load(uc.CommandName(), identity)
+}
+
+func validateResumableUpload(uploadParamsArray []services.UploadParams) error {
+	for _, uploadParams := range uploadParamsArray {
+		if uploadParams.Archive != "" {
+			return errorutils.CheckErrorf("the --resume option is not supported when uploading archives")
+		}
+		if uploadParams.IncludeDirs {
+			return errorutils.CheckErrorf("the --resume option is not supported with --include-dirs")
+		}
+	}
+	return nil
+}
+
+// uploadWithCheckpoint uploads the files in batches, recording the uploaded files in the checkpoint.
+// Files uploaded by a previous run of the checkpoint are skipped. When the command is interrupted, the checkpoint
+// is saved with the in-flight and pending files, so that a subsequent run with --resume continues from it.
+// The checkpoint is removed once all the files are uploaded successfully.
+func (uc *UploadCommand) uploadWithCheckpoint(servicesManager artifactory.ArtifactoryServicesManager, uploadCheckpoint *checkpoint.Checkpoint,
+	uploadParamsArray []services.UploadParams) (summary *rtServicesUtils.OperationSummary, err error) {
+	if err = validateResumableUpload(uploadParamsArray); err != nil {
+		return
+	}
+	pending, err := collectPendingUploads(uploadCheckpoint, uploadParamsArray, servicesManager.FileInfo)
+	if err != nil {
+		return
+	}
+	if !uploadCheckpoint.IsEmpty() {
+		log.Info("Resuming the upload using the checkpoint " + uploadCheckpoint.Path() + ". " +
+			strconv.Itoa(len(uploadCheckpoint.Completed)) + " files were already uploaded, " + strconv.Itoa(len(pending)) + " files are left to upload.")
+	}
+
+	stop := checkpoint.OnInterrupt(uploadCheckpoint.Save)
+	defer stop()
+	summary = &rtServicesUtils.OperationSummary{}
+	var transferDetailsReaders, artifactsDetailsReaders []*content.ContentReader
+	defer func() {
+		summary.TransferDetailsReader, summary.ArtifactsDetailsReader, err = mergeSummaryReaders(transferDetailsReaders, artifactsDetailsReaders, err)
+		if summary.TransferDetailsReader == nil {
+			summary = nil
+		}
+	}()
+	var failed []string
+	for start := 0; start < len(pending); start += uploadCheckpointBatchSize {
+		end := min(start+uploadCheckpointBatchSize, len(pending))
+		batch := pending[start:end]
+		batchParams := make([]services.UploadParams, 0, len(batch))
+		for _, file := range batch {
+			batchParams = append(batchParams, newSingleFileUploadParams(uploadParamsArray[file.paramsIndex], file.artifact))
+		}
+		uploadCheckpoint.Update(nil, targetPaths(batch), slices.Concat(failed, targetPaths(pending[end:])))
+
+		batchSummary, batchErr := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, batchParams...)
+		if batchErr != nil {
+			err = errors.Join(err, batchErr)
+			log.Error(batchErr)
+		}
+		if batchSummary == nil {
+			failed = append(failed, targetPaths(batch)...)
+			summary.TotalFailed += len(batch)
+			continue
+		}
+		transferDetailsReaders = append(transferDetailsReaders, batchSummary.TransferDetailsReader)
+		artifactsDetailsReaders = append(artifactsDetailsReaders, batchSummary.ArtifactsDetailsReader)
+		summary.TotalSucceeded += batchSummary.TotalSucceeded
+		summary.TotalFailed += batchSummary.TotalFailed
+
+		uploaded, readErr := readUploadedTargetPaths(batchSummary.TransferDetailsReader)
+		if readErr != nil {
+			err = errors.Join(err, readErr)
+			return
+		}
+		var completed []string
+		for _, targetPath := range targetPaths(batch) {
+			if uploaded[targetPath] {
+				completed = append(completed, targetPath)
+			} else {
+				failed = append(failed, targetPath)
+			}
+		}
+		uploadCheckpoint.Update(completed, nil, slices.Concat(failed, targetPaths(pending[end:])))
+	}
+	stop()
+
+	if err == nil && len(failed) == 0 {
+		err = uploadCheckpoint.Remove()
+		return
+	}
+	if saveErr := uploadCheckpoint.Save(); saveErr != nil {
+		err = errors.Join(err, saveErr)
+		return
+	}
+	log.Info("Run the command again with --resume to upload the remaining files. The checkpoint was saved to", uploadCheckpoint.Path())
+	return
+}
+
+// collectPendingUploads collects the files of the upload params, which were not uploaded according to the checkpoint.
+// The files which were uploaded according to the checkpoint are uploaded again, if their checksums don't match the checksums
+// of their artifacts, such as when the local files changed since, or when the artifacts were modified or removed.
+func collectPendingUploads(uploadCheckpoint *checkpoint.Checkpoint, uploadParamsArray []services.UploadParams, getFileInfo fileInfoGetter) ([]pendingUpload, error) {
+	var pending []pendingUpload
+	vcsCache := clientUtils.NewVcsDetails()
+	for i, uploadParams := range uploadParamsArray {
+		// The collection modifies the params, so it's done on a copy.
+		paramsCopy := services.DeepCopyUploadParams(&uploadParams)
+		err := services.CollectFilesForUpload(paramsCopy, nil, vcsCache, func(data services.UploadData) {
+			if uploadCheckpoint.IsCompleted(data.Artifact.TargetPath) {
+				verifyErr := verifyArtifactChecksums(getFileInfo, data.Artifact.LocalPath, data.Artifact.TargetPath)
+				if verifyErr == nil {
+					return
+				}
+				log.Warn("Uploading", data.Artifact.LocalPath, "again, since the artifact uploaded by the previous run couldn't be verified:", verifyErr.Error())
+			}
+			pending = append(pending, pendingUpload{paramsIndex: i, artifact: data.Artifact})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+// newSingleFileUploadParams creates upload params which upload the collected file only.
+func newSingleFileUploadParams(uploadParams services.UploadParams, artifact clientUtils.Artifact) services.UploadParams {
+	fileParams := services.DeepCopyUploadParams(&uploadParams)
+	fileParams.Pattern = artifact.LocalPath
+	fileParams.Target = artifact.TargetPath
+	fileParams.Exclusions = nil
+	fileParams.Regexp = false
+	fileParams.Ant = false
+	fileParams.Recursive = false
+	fileParams.Flat = true
+	return fileParams
+}
+
+func targetPaths(files []pendingUpload) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.artifact.TargetPath)
+	}
+	return paths
+}
+
+func readUploadedTargetPaths(transferDetailsReader *content.ContentReader) (map[string]bool, error) {
+	uploaded := make(map[string]bool)
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		uploaded[item.TargetPath] = true
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	return uploaded, err
+}
+
+// mergeSummaryReaders merges the readers of the batches into a single transfer details reader and a single artifacts details reader.
+func mergeSummaryReaders(transferDetailsReaders, artifactsDetailsReaders []*content.ContentReader, err error) (transferDetailsReader, artifactsDetailsReader *content.ContentReader, mergeErr error) {
+	mergeErr = err
+	defer func() {
+		for _, reader := range append(transferDetailsReaders, artifactsDetailsReaders...) {
+			mergeErr = errors.Join(mergeErr, reader.Close())
+		}
+	}()
+	transferDetailsReader, err = content.MergeReaders(transferDetailsReaders, content.DefaultKey)
+	if err != nil {
+		mergeErr = errors.Join(mergeErr, err)
+		return
+	}
+	artifactsDetailsReader, err = content.MergeReaders(artifactsDetailsReaders, content.DefaultKey)
+	if err != nil {
+		mergeErr = errors.Join(mergeErr, err, transferDetailsReader.Close())
+		transferDetailsReader = nil
+	}
+	return
+}
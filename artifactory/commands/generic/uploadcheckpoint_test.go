@@ -0,0 +1,57 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/checkpoint"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectPendingUploads(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	for _, name := range []string{"a.txt", "b.txt", filepath.Join("sub", "c.txt")} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = filepath.Join(dir, "(*)")
+	uploadParams.Target = "repo/files/{1}"
+	uploadParams.Recursive = true
+	uploadParams.Flat = true
+
+	uploadCheckpoint, err := checkpoint.Load("rt_upload", []byte("test"))
+	require.NoError(t, err)
+	// The artifact of b.txt was modified since the previous run, so it's uploaded again.
+	uploadCheckpoint.Update([]string{"repo/files/a.txt", "repo/files/b.txt"}, nil, nil)
+	getFileInfo := newFileInfoGetter(map[string]string{"repo/files/a.txt": sha256Of("a.txt"), "repo/files/b.txt": sha256Of("modified")})
+	pending, err := collectPendingUploads(uploadCheckpoint, []services.UploadParams{uploadParams}, getFileInfo)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"repo/files/b.txt", "repo/files/sub/c.txt"}, targetPaths(pending))
+	// The collection doesn't modify the original params.
+	assert.Equal(t, "repo/files/{1}", uploadParams.Target)
+
+	for _, file := range pending {
+		fileParams := newSingleFileUploadParams(uploadParams, file.artifact)
+		assert.Equal(t, file.artifact.LocalPath, fileParams.Pattern)
+		assert.Equal(t, file.artifact.TargetPath, fileParams.Target)
+		assert.False(t, fileParams.Recursive)
+		var collected []string
+		require.NoError(t, services.CollectFilesForUpload(fileParams, nil, nil, func(data services.UploadData) {
+			collected = append(collected, data.Artifact.TargetPath)
+		}))
+		assert.Equal(t, []string{file.artifact.TargetPath}, collected)
+	}
+}
+
+func TestValidateResumableUpload(t *testing.T) {
+	uploadParams := services.NewUploadParams()
+	assert.NoError(t, validateResumableUpload([]services.UploadParams{uploadParams}))
+	uploadParams.Archive = "zip"
+	assert.Error(t, validateResumableUpload([]services.UploadParams{uploadParams}))
+}
@@ -0,0 +1,113 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/fileprops"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// SetExtractProps sets whether the uploaded files are stamped with the properties extracted from their metadata,
+// such as the name and the version of npm packages and Python wheels, the manifest attributes of JARs and the version info of executables.
+func (uc *UploadCommand) SetExtractProps(extractProps bool) *UploadCommand {
+	uc.extractProps = extractProps
+	return uc
+}
+
+func validateExtractPropsUpload(uploadParamsArray []services.UploadParams) error {
+	for _, uploadParams := range uploadParamsArray {
+		if uploadParams.Archive != "" || uploadParams.ExplodeArchive {
+			return errorutils.CheckErrorf("the --extract-props option is not supported when uploading or exploding archives")
+		}
+	}
+	return nil
+}
+
+// readUploadedFiles returns the uploaded files from the transfer details, and resets the reader for its other uses.
+func readUploadedFiles(transferDetailsReader *content.ContentReader) ([]clientUtils.FileTransferDetails, error) {
+	var uploaded []clientUtils.FileTransferDetails
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		uploaded = append(uploaded, *item)
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	return uploaded, err
+}
+
+// groupExtractedProps extracts the properties of the uploaded files, and groups their target paths by the properties.
+// Files whose properties can't be extracted are skipped with a warning, since the files themselves were uploaded successfully.
+func groupExtractedProps(uploaded []clientUtils.FileTransferDetails) map[string][]string {
+	targetsByProps := make(map[string][]string)
+	for _, file := range uploaded {
+		props, err := fileprops.Extract(file.SourcePath)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Failed to extract the properties of %s: %s", file.SourcePath, err.Error()))
+			continue
+		}
+		if len(props) == 0 {
+			continue
+		}
+		propsString := fileprops.ToPropsString(props)
+		log.Debug(fmt.Sprintf("Extracted the properties of %s: %s", file.SourcePath, propsString))
+		targetsByProps[propsString] = append(targetsByProps[propsString], file.TargetPath)
+	}
+	return targetsByProps
+}
+
+// setExtractedProps sets the properties extracted from the uploaded files on their targets, with a single request
+// for the files with the same properties. Returns the number of files whose properties were set.
+func setExtractedProps(servicesManager artifactory.ArtifactoryServicesManager, transferDetailsReader *content.ContentReader) (success int, err error) {
+	uploaded, err := readUploadedFiles(transferDetailsReader)
+	if err != nil {
+		return
+	}
+	targetsByProps := groupExtractedProps(uploaded)
+	propsStrings := make([]string, 0, len(targetsByProps))
+	for propsString := range targetsByProps {
+		propsStrings = append(propsStrings, propsString)
+	}
+	sort.Strings(propsStrings)
+	for _, propsString := range propsStrings {
+		reader, readerErr := createTargetsReader(targetsByProps[propsString])
+		if readerErr != nil {
+			return success, errors.Join(err, readerErr)
+		}
+		count, setErr := servicesManager.SetProps(services.PropsParams{Reader: reader, Props: propsString})
+		success += count
+		err = errors.Join(err, setErr, reader.Close())
+	}
+	if success > 0 {
+		log.Info(fmt.Sprintf("Set the extracted properties on %d uploaded files.", success))
+	}
+	return
+}
+
+// createTargetsReader returns a reader of the items of the target paths, which are in the form of <repo>/<path>.
+func createTargetsReader(targets []string) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		repo, itemPath, _ := strings.Cut(target, "/")
+		dir, name := path.Split(itemPath)
+		if dir = strings.TrimSuffix(dir, "/"); dir == "" {
+			dir = "."
+		}
+		writer.Write(rtServicesUtils.ResultItem{Repo: repo, Path: dir, Name: name, Type: "file"})
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
@@ -0,0 +1,91 @@
+package generic
+
+import (
+	"archive/zip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestJar(t *testing.T, jarPath, version string) {
+	jarFile, err := os.Create(jarPath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(jarFile)
+	manifest, err := zipWriter.Create("META-INF/MANIFEST.MF")
+	require.NoError(t, err)
+	_, err = manifest.Write([]byte("Manifest-Version: 1.0\nImplementation-Version: " + version + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, jarFile.Close())
+}
+
+func TestSetExtractedProps(t *testing.T) {
+	var mutex sync.Mutex
+	propsByPath := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		assert.Equal(t, http.MethodPut, r.Method)
+		propsByPath[r.URL.Path] = r.URL.Query().Get("properties")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for name, version := range map[string]string{"a.jar": "1.0", "b.jar": "1.0", "c.jar": "2.0"} {
+		writeTestJar(t, filepath.Join(dir, name), version)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, uploaded := range []clientUtils.FileTransferDetails{
+		{SourcePath: filepath.Join(dir, "a.jar"), TargetPath: "libs-local/org/a.jar"},
+		{SourcePath: filepath.Join(dir, "b.jar"), TargetPath: "libs-local/b.jar"},
+		{SourcePath: filepath.Join(dir, "c.jar"), TargetPath: "libs-local/org/c.jar"},
+		{SourcePath: filepath.Join(dir, "notes.txt"), TargetPath: "libs-local/notes.txt"},
+	} {
+		writer.Write(uploaded)
+	}
+	require.NoError(t, writer.Close())
+	transferDetailsReader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, transferDetailsReader.Close())
+	}()
+
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+	success, err := setExtractedProps(servicesManager, transferDetailsReader)
+	require.NoError(t, err)
+	assert.Equal(t, 3, success)
+	assert.Equal(t, map[string]string{
+		"/artifactory/api/storage/libs-local/org/a.jar": "jar.Implementation-Version=1.0",
+		"/artifactory/api/storage/libs-local/b.jar":     "jar.Implementation-Version=1.0",
+		"/artifactory/api/storage/libs-local/org/c.jar": "jar.Implementation-Version=2.0",
+	}, propsByPath)
+
+	// The reader is reset for the other uses of the transfer details.
+	length, err := transferDetailsReader.Length()
+	require.NoError(t, err)
+	assert.Equal(t, 4, length)
+}
+
+func TestValidateExtractPropsUpload(t *testing.T) {
+	assert.NoError(t, validateExtractPropsUpload([]services.UploadParams{services.NewUploadParams()}))
+	archiveParams := services.NewUploadParams()
+	archiveParams.Archive = "zip"
+	assert.ErrorContains(t, validateExtractPropsUpload([]services.UploadParams{archiveParams}), "--extract-props")
+	explodeParams := services.NewUploadParams()
+	explodeParams.ExplodeArchive = true
+	assert.ErrorContains(t, validateExtractPropsUpload([]services.UploadParams{explodeParams}), "--extract-props")
+}
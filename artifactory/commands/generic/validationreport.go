@@ -0,0 +1,243 @@
+package generic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/cryptox"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/sign"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// ValidationReportPayloadType is the DSSE payload type of signed transfer validation reports.
+	ValidationReportPayloadType = "application/vnd.jfrog.transfer-validation-report+json"
+
+	ValidationResultVerified   = "verified"
+	ValidationResultMismatch   = "mismatch"
+	ValidationResultMissing    = "missing"
+	ValidationResultUnverified = "unverified"
+)
+
+// ValidationReportOptions configure the transfer validation report created by the upload and download commands.
+type ValidationReportOptions struct {
+	// Local path to which the report is saved.
+	Path string
+	// Optional path to a PEM private key (RSA, ECDSA or ED25519). When set, the report is saved as a signed DSSE envelope.
+	SigningKey string
+	// Optional Artifactory path in the format of <repository name>/<repository path>, to which the report is uploaded.
+	Target string
+}
+
+// TransferValidationReport is a machine-verifiable record of the integrity of the transferred files.
+// Each file is verified by comparing the checksums calculated locally with the checksums reported by Artifactory.
+type TransferValidationReport struct {
+	Operation   string          `json:"operation"`
+	StartedAt   string          `json:"startedAt"`
+	CompletedAt string          `json:"completedAt"`
+	Verified    bool            `json:"verified"`
+	FailedCount int             `json:"failedCount"`
+	Files       []ValidatedFile `json:"files"`
+}
+
+type ValidatedFile struct {
+	LocalPath            string             `json:"localPath"`
+	ArtifactoryPath      string             `json:"artifactoryPath"`
+	Size                 int64              `json:"size"`
+	LocalChecksums       buildInfo.Checksum `json:"localChecksums"`
+	ArtifactoryChecksums buildInfo.Checksum `json:"artifactoryChecksums"`
+	Result               string             `json:"result"`
+	VerifiedAt           string             `json:"verifiedAt"`
+}
+
+func newTransferValidationReport(operation string, startedAt time.Time) *TransferValidationReport {
+	return &TransferValidationReport{Operation: operation, StartedAt: startedAt.UTC().Format(time.RFC3339), Files: []ValidatedFile{}}
+}
+
+// addUploadedFiles verifies the uploaded files of the transfer details reader against the SHA-256 returned by Artifactory.
+func (tvr *TransferValidationReport) addUploadedFiles(transferDetailsReader *content.ContentReader) error {
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		tvr.addFile(item.SourcePath, item.TargetPath, buildInfo.Checksum{Sha256: item.Sha256})
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	return err
+}
+
+// addDownloadedFiles verifies the downloaded files of the transfer details reader against the checksums of the artifacts details reader.
+func (tvr *TransferValidationReport) addDownloadedFiles(transferDetailsReader, artifactsDetailsReader *content.ContentReader) error {
+	checksums := make(map[string]buildInfo.Checksum)
+	for item := new(rtServicesUtils.ArtifactDetails); artifactsDetailsReader.NextRecord(item) == nil; item = new(rtServicesUtils.ArtifactDetails) {
+		checksums[item.ArtifactoryPath] = item.Checksums
+	}
+	if err := artifactsDetailsReader.GetError(); err != nil {
+		return err
+	}
+	artifactsDetailsReader.Reset()
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		tvr.addFile(item.TargetPath, item.SourcePath, checksums[item.SourcePath])
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	return err
+}
+
+func (tvr *TransferValidationReport) addFile(localPath, artifactoryPath string, artifactoryChecksums buildInfo.Checksum) {
+	file := ValidatedFile{LocalPath: localPath, ArtifactoryPath: artifactoryPath, ArtifactoryChecksums: artifactoryChecksums}
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		log.Debug("Couldn't calculate the checksums of", localPath+":", err.Error())
+		file.Result = ValidationResultMissing
+	} else {
+		file.Size = details.Size
+		file.LocalChecksums = details.Checksum
+		file.Result = compareChecksums(details.Checksum, artifactoryChecksums)
+	}
+	file.VerifiedAt = time.Now().UTC().Format(time.RFC3339)
+	tvr.Files = append(tvr.Files, file)
+}
+
+// compareChecksums compares each of the checksums reported by Artifactory with the local one.
+func compareChecksums(local, artifactory buildInfo.Checksum) string {
+	compared := false
+	for _, pair := range [][2]string{{local.Sha256, artifactory.Sha256}, {local.Sha1, artifactory.Sha1}, {local.Md5, artifactory.Md5}} {
+		if pair[1] == "" {
+			continue
+		}
+		if pair[0] != pair[1] {
+			return ValidationResultMismatch
+		}
+		compared = true
+	}
+	if !compared {
+		return ValidationResultUnverified
+	}
+	return ValidationResultVerified
+}
+
+// createValidationReport verifies the transferred files of the summary, and saves the report according to the options.
+func createValidationReport(operation string, startedAt time.Time, summary *rtServicesUtils.OperationSummary, options *ValidationReportOptions,
+	serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) (err error) {
+	report := newTransferValidationReport(operation, startedAt)
+	if operation == "download" {
+		err = report.addDownloadedFiles(summary.TransferDetailsReader, summary.ArtifactsDetailsReader)
+	} else {
+		err = report.addUploadedFiles(summary.TransferDetailsReader)
+	}
+	if err != nil {
+		return
+	}
+	report.complete(summary.TotalFailed)
+	return report.save(options, serverDetails, retries, retryWaitMilliSecs)
+}
+
+func (tvr *TransferValidationReport) complete(failedCount int) {
+	tvr.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	tvr.FailedCount = failedCount
+	tvr.Verified = failedCount == 0
+	for _, file := range tvr.Files {
+		if file.Result != ValidationResultVerified {
+			tvr.Verified = false
+		}
+	}
+}
+
+// save writes the report to the local path of the options, signing it if a signing key was provided,
+// and uploads it to the target of the options, if provided.
+func (tvr *TransferValidationReport) save(options *ValidationReportOptions, serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) error {
+	payload, err := json.MarshalIndent(tvr, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if options.SigningKey != "" {
+		if payload, err = signValidationReport(payload, options.SigningKey); err != nil {
+			return err
+		}
+	}
+	if err = os.WriteFile(options.Path, payload, 0644); err != nil {
+		return errorutils.CheckErrorf("failed to write the validation report: %s", err.Error())
+	}
+	if tvr.Verified {
+		log.Info("All", len(tvr.Files), "transferred files were verified. The validation report was saved to", options.Path)
+	} else {
+		log.Warn("Not all the transferred files were verified. Review the validation report saved to", options.Path)
+	}
+	if options.Target == "" {
+		return nil
+	}
+	return deployValidationReport(options, serverDetails, retries, retryWaitMilliSecs)
+}
+
+func deployValidationReport(options *ValidationReportOptions, serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(options.Path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = clientUtils.ConvertLocalPatternToRegexp(absPath, clientUtils.RegExp)
+	uploadParams.Regexp = true
+	uploadParams.Target = options.Target
+	uploadParams.Flat = true
+	_, failed, err := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, uploadParams)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to upload the validation report to '%s'", options.Target)
+	}
+	log.Info("The validation report was uploaded to", options.Target)
+	return nil
+}
+
+// signValidationReport wraps the report in a DSSE envelope, signed using the private key at keyPath.
+func signValidationReport(payload []byte, keyPath string) ([]byte, error) {
+	keyContent, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the validation report signing key: %s", err.Error())
+	}
+	privateKey, err := cryptox.ReadKey(keyContent)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to load the validation report signing key: %s", err.Error())
+	}
+	var signer dsse.Signer
+	switch privateKey.KeyType {
+	case cryptox.ECDSAKeyType:
+		signer, err = cryptox.NewECDSASignerVerifierFromSSLibKey(privateKey)
+	case cryptox.RSAKeyType:
+		signer, err = cryptox.NewRSAPSSSignerVerifierFromSSLibKey(privateKey)
+	case cryptox.ED25519KeyType:
+		signer, err = cryptox.NewED25519SignerVerifierFromSSLibKey(privateKey)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported validation report signing key type: %s", privateKey.KeyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	envelopeSigner, err := sign.NewEnvelopeSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := envelopeSigner.SignPayload(ValidationReportPayloadType, payload)
+	if err != nil {
+		return nil, err
+	}
+	signedReport, err := json.MarshalIndent(envelope, "", "  ")
+	return signedReport, errorutils.CheckError(err)
+}
@@ -0,0 +1,135 @@
+package generic
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/dsse"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDetailsReader(t *testing.T, items ...interface{}) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, item := range items {
+		writer.Write(item)
+	}
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	t.Cleanup(func() { assert.NoError(t, reader.Close()) })
+	return reader
+}
+
+func createLocalFile(t *testing.T, dir, name, fileContent string) (path, sha256Hex string) {
+	path = filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(fileContent), 0644))
+	sum := sha256.Sum256([]byte(fileContent))
+	return path, hex.EncodeToString(sum[:])
+}
+
+func TestValidationReportUploadedFiles(t *testing.T) {
+	dir := t.TempDir()
+	goodPath, goodSha256 := createLocalFile(t, dir, "good.txt", "good")
+	badPath, _ := createLocalFile(t, dir, "bad.txt", "bad")
+	reader := createDetailsReader(t,
+		clientUtils.FileTransferDetails{SourcePath: goodPath, TargetPath: "repo/good.txt", Sha256: goodSha256},
+		clientUtils.FileTransferDetails{SourcePath: badPath, TargetPath: "repo/bad.txt", Sha256: goodSha256},
+		clientUtils.FileTransferDetails{SourcePath: filepath.Join(dir, "missing.txt"), TargetPath: "repo/missing.txt", Sha256: goodSha256},
+	)
+
+	report := newTransferValidationReport("upload", time.Now())
+	require.NoError(t, report.addUploadedFiles(reader))
+	report.complete(0)
+	require.Len(t, report.Files, 3)
+	assert.Equal(t, ValidationResultVerified, report.Files[0].Result)
+	assert.Equal(t, int64(4), report.Files[0].Size)
+	assert.Equal(t, goodSha256, report.Files[0].LocalChecksums.Sha256)
+	assert.Equal(t, ValidationResultMismatch, report.Files[1].Result)
+	assert.Equal(t, ValidationResultMissing, report.Files[2].Result)
+	assert.False(t, report.Verified)
+
+	// The reader is reset, so that it can be used for the command summary.
+	count := 0
+	for item := new(clientUtils.FileTransferDetails); reader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestValidationReportDownloadedFiles(t *testing.T) {
+	dir := t.TempDir()
+	localPath, _ := createLocalFile(t, dir, "file.txt", "content")
+	localDetails, err := fileutils.GetFileDetails(localPath, true)
+	require.NoError(t, err)
+	transferDetails := createDetailsReader(t, clientUtils.FileTransferDetails{SourcePath: "repo/dir/file.txt", TargetPath: localPath})
+	artifactsDetails := createDetailsReader(t, rtServicesUtils.ArtifactDetails{
+		ArtifactoryPath: "repo/dir/file.txt",
+		Checksums:       buildInfo.Checksum{Sha1: localDetails.Checksum.Sha1, Md5: localDetails.Checksum.Md5},
+	})
+
+	report := newTransferValidationReport("download", time.Now())
+	require.NoError(t, report.addDownloadedFiles(transferDetails, artifactsDetails))
+	report.complete(0)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, ValidationResultVerified, report.Files[0].Result)
+	assert.Equal(t, "repo/dir/file.txt", report.Files[0].ArtifactoryPath)
+	assert.True(t, report.Verified)
+
+	report.complete(1)
+	assert.False(t, report.Verified)
+}
+
+func TestCompareChecksums(t *testing.T) {
+	local := buildInfo.Checksum{Sha256: "a", Sha1: "b", Md5: "c"}
+	assert.Equal(t, ValidationResultVerified, compareChecksums(local, buildInfo.Checksum{Sha1: "b"}))
+	assert.Equal(t, ValidationResultMismatch, compareChecksums(local, buildInfo.Checksum{Sha256: "a", Md5: "x"}))
+	assert.Equal(t, ValidationResultUnverified, compareChecksums(local, buildInfo.Checksum{}))
+}
+
+func TestSaveSignedValidationReport(t *testing.T) {
+	dir := t.TempDir()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	report := newTransferValidationReport("upload", time.Now())
+	report.complete(0)
+	reportPath := filepath.Join(dir, "report.json")
+	require.NoError(t, report.save(&ValidationReportOptions{Path: reportPath, SigningKey: keyPath}, nil, 0, 0))
+
+	reportContent, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	envelope := new(dsse.Envelope)
+	require.NoError(t, json.Unmarshal(reportContent, envelope))
+	assert.Equal(t, ValidationReportPayloadType, envelope.PayloadType)
+	require.Len(t, envelope.Signatures, 1)
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	require.NoError(t, err)
+	signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, dsse.PAE(envelope.PayloadType, payload), signature))
+
+	savedReport := new(TransferValidationReport)
+	require.NoError(t, json.Unmarshal(payload, savedReport))
+	assert.Equal(t, "upload", savedReport.Operation)
+	assert.True(t, savedReport.Verified)
+}
@@ -0,0 +1,151 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The section of the Go configuration with the Go environment, which its commands run with. For example:
+	//
+	//	go:
+	//	  private: github.com/acme/*
+	//	  noSumDb: github.com/partner/*
+	//	  flags: -mod=mod
+	//	  sumDbProxy: true
+	goEnvConfigKey = "go"
+	// The checksum database which Artifactory proxies, under the Go API of its Go repositories.
+	goSumDbName = "sum.golang.org"
+)
+
+// GoEnvConfig is the Go environment of the Go configuration, which is set while the Go commands run.
+type GoEnvConfig struct {
+	// The GOPRIVATE patterns of the modules which are private, so they aren't fetched through the proxy or checked against the checksum database.
+	Private string `yaml:"private,omitempty" mapstructure:"private"`
+	// The GONOSUMDB patterns of the modules which aren't checked against the checksum database.
+	NoSumDb string `yaml:"noSumDb,omitempty" mapstructure:"noSumDb"`
+	// The GOFLAGS, which are added to the GOFLAGS of the environment.
+	Flags string `yaml:"flags,omitempty" mapstructure:"flags"`
+	// Set to true to use the checksum database proxied by the resolution repository as GOSUMDB, and to verify the go.sum of the project
+	// against it when the build-info is collected.
+	SumDbProxy bool `yaml:"sumDbProxy,omitempty" mapstructure:"sumDbProxy"`
+}
+
+func (gec *GoEnvConfig) isEmpty() bool {
+	return *gec == GoEnvConfig{}
+}
+
+// WriteGoEnvConfig writes the Go environment to the Go configuration created by 'jf go-config', keeping the rest of the configuration.
+// An empty Go environment removes it from the configuration.
+func WriteGoEnvConfig(global bool, goEnv GoEnvConfig) error {
+	projectDir, err := utils.GetProjectDir(global)
+	if err != nil {
+		return err
+	}
+	configFilePath := filepath.Join(projectDir, project.Go.String()+".yaml")
+	content, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read the Go configuration %s, which is created by 'jf go-config': %s", configFilePath, err.Error())
+	}
+	if content, err = setGoEnvConfig(content, goEnv); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.WriteFile(configFilePath, content, 0644))
+}
+
+// setGoEnvConfig sets the Go environment section of the YAML content of the configuration, in place of the existing one.
+func setGoEnvConfig(content []byte, goEnv GoEnvConfig) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Go configuration: %s", err.Error())
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errorutils.CheckErrorf("the Go configuration isn't a YAML mapping")
+	}
+	root := document.Content[0]
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value == goEnvConfigKey {
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+			break
+		}
+	}
+	if !goEnv.isEmpty() {
+		goEnvNode := new(yaml.Node)
+		if err := goEnvNode.Encode(goEnv); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: goEnvConfigKey}, goEnvNode)
+	}
+	content, err := yaml.Marshal(&document)
+	return content, errorutils.CheckError(err)
+}
+
+func readGoEnvConfig(vConfig *viper.Viper) (*GoEnvConfig, error) {
+	goEnv := new(GoEnvConfig)
+	if !vConfig.IsSet(goEnvConfigKey) {
+		return goEnv, nil
+	}
+	if err := vConfig.UnmarshalKey(goEnvConfigKey, goEnv); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the Go environment of the Go configuration: %s", err.Error())
+	}
+	return goEnv, nil
+}
+
+// environment returns the variables of the Go environment. The GOFLAGS are added to the GOFLAGS which are already set.
+// If the checksum database is proxied, GOSUMDB is set to the checksum database of the resolution repository, with the key of sum.golang.org.
+func (gec *GoEnvConfig) environment(sumDbUrl string) map[string]string {
+	env := make(map[string]string)
+	if gec.Private != "" {
+		env["GOPRIVATE"] = gec.Private
+	}
+	if gec.NoSumDb != "" {
+		env["GONOSUMDB"] = gec.NoSumDb
+	}
+	if gec.Flags != "" {
+		env["GOFLAGS"] = strings.TrimSpace(os.Getenv("GOFLAGS") + " " + gec.Flags)
+	}
+	if gec.SumDbProxy {
+		env["GOSUMDB"] = goSumDbName + " " + sumDbUrl
+	}
+	return env
+}
+
+// setGoEnv sets the variables of the Go environment, and returns a function which restores their previous values.
+func setGoEnv(env map[string]string) (restore func() error, err error) {
+	previous := make(map[string]*string, len(env))
+	restore = func() error {
+		for name, value := range previous {
+			var err error
+			if value == nil {
+				err = os.Unsetenv(name)
+			} else {
+				err = os.Setenv(name, *value)
+			}
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+		}
+		return nil
+	}
+	for name, value := range env {
+		if oldValue, exists := os.LookupEnv(name); exists {
+			previous[name] = &oldValue
+		} else {
+			previous[name] = nil
+		}
+		log.Debug(fmt.Sprintf("Setting %s for the Go command.", name))
+		if err = os.Setenv(name, value); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return restore, nil
+}
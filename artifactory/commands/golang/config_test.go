@@ -0,0 +1,63 @@
+package golang
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoConfig = `version: 1
+type: go
+resolver:
+    repo: go-virtual
+    serverId: acme
+`
+
+func TestSetGoEnvConfig(t *testing.T) {
+	goEnv := GoEnvConfig{Private: "github.com/acme/*", Flags: "-mod=mod", SumDbProxy: true}
+	content, err := setGoEnvConfig([]byte(testGoConfig), goEnv)
+	require.NoError(t, err)
+	assert.Equal(t, testGoConfig+"go:\n    private: github.com/acme/*\n    flags: -mod=mod\n    sumDbProxy: true\n", string(content))
+
+	// The Go environment is read back from the configuration, and replaced when it's written again.
+	vConfig := viper.New()
+	vConfig.SetConfigType("yaml")
+	require.NoError(t, vConfig.ReadConfig(bytes.NewReader(content)))
+	readGoEnv, err := readGoEnvConfig(vConfig)
+	require.NoError(t, err)
+	assert.Equal(t, goEnv, *readGoEnv)
+
+	content, err = setGoEnvConfig(content, GoEnvConfig{NoSumDb: "github.com/partner/*"})
+	require.NoError(t, err)
+	assert.Equal(t, testGoConfig+"go:\n    noSumDb: github.com/partner/*\n", string(content))
+
+	content, err = setGoEnvConfig(content, GoEnvConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, testGoConfig, string(content))
+}
+
+func TestSetGoEnv(t *testing.T) {
+	t.Setenv("GOFLAGS", "-trimpath")
+	t.Setenv("GOPRIVATE", "github.com/other/*")
+	require.NoError(t, os.Unsetenv("GOSUMDB"))
+	goEnv := &GoEnvConfig{Private: "github.com/acme/*", Flags: "-mod=mod", SumDbProxy: true}
+	env := goEnv.environment("https://acme.jfrog.io/artifactory/api/go/go-virtual/sumdb/sum.golang.org")
+	assert.Equal(t, map[string]string{
+		"GOPRIVATE": "github.com/acme/*",
+		"GOFLAGS":   "-trimpath -mod=mod",
+		"GOSUMDB":   "sum.golang.org https://acme.jfrog.io/artifactory/api/go/go-virtual/sumdb/sum.golang.org",
+	}, env)
+
+	restore, err := setGoEnv(env)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/acme/*", os.Getenv("GOPRIVATE"))
+	require.NoError(t, restore())
+	assert.Equal(t, "github.com/other/*", os.Getenv("GOPRIVATE"))
+	assert.Equal(t, "-trimpath", os.Getenv("GOFLAGS"))
+	_, sumDbSet := os.LookupEnv("GOSUMDB")
+	assert.False(t, sumDbSet)
+}
@@ -31,6 +31,8 @@ type GoCommand struct {
 	resolverParams     *project.RepositoryConfig
 	configFilePath     string
 	noFallback         bool
+	goEnv              *GoEnvConfig
+	vendorReportPath   string
 }
 
 func NewGoCommand() *GoCommand {
@@ -106,6 +108,12 @@ func (gc *GoCommand) Run() error {
 		}
 	}
 
+	// Extract the Go environment.
+	gc.goEnv, err = readGoEnvConfig(vConfig)
+	if err != nil {
+		return err
+	}
+
 	// Extract build info information from the args.
 	gc.goArg, gc.buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(gc.goArg)
 	if err != nil {
@@ -117,6 +125,17 @@ func (gc *GoCommand) Run() error {
 	if err != nil {
 		return err
 	}
+
+	// Extract the path of the integrity report from the args of the vendor command.
+	if gc.isVendorCommand() {
+		gc.goArg, gc.vendorReportPath, err = coreutils.ExtractStringOptionFromArgs(gc.goArg, vendorReportOption)
+		if err != nil {
+			return err
+		}
+		if gc.vendorReportPath == "" {
+			gc.vendorReportPath = defaultVendorReport
+		}
+	}
 	return gc.run()
 }
 
@@ -153,17 +172,42 @@ func (gc *GoCommand) run() (err error) {
 	if err != nil {
 		return
 	}
-	// If noFallback=false, missing packages will be fetched directly from VCS
-	repoUrl, err := GetArtifactoryRemoteRepoUrl(resolverDetails, gc.resolverParams.TargetRepo(), GoProxyUrlParams{Direct: !gc.noFallback})
+	// If noFallback=false, missing packages will be fetched directly from VCS.
+	// The vendor command resolves all the modules from Artifactory.
+	repoUrl, err := GetArtifactoryRemoteRepoUrl(resolverDetails, gc.resolverParams.TargetRepo(), GoProxyUrlParams{Direct: !gc.noFallback && !gc.isVendorCommand()})
+	if err != nil {
+		return
+	}
+
+	restoreGoEnv, err := gc.setGoEnv(resolverDetails)
 	if err != nil {
 		return
 	}
+	defer func() {
+		err = errors.Join(err, restoreGoEnv())
+	}()
 
-	err = biutils.RunGo(gc.goArg, repoUrl)
+	goArg := gc.goArg
+	if gc.isVendorCommand() {
+		goArg = append([]string{"mod", "vendor"}, gc.goArg[1:]...)
+		var restoreVendorEnv func() error
+		if restoreVendorEnv, err = setGoEnv(vendorEnvironment()); err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, restoreVendorEnv())
+		}()
+	}
+	err = biutils.RunGo(goArg, repoUrl)
 	if errorutils.CheckError(err) != nil {
 		err = coreutils.ConvertExitCodeError(err)
 		return
 	}
+	if gc.isVendorCommand() {
+		if err = gc.reportVendoredModules(goBuildInfo); err != nil {
+			return
+		}
+	}
 
 	if goBuildInfo != nil {
 		// Need to collect build info
@@ -200,12 +244,61 @@ func (gc *GoCommand) run() (err error) {
 		if gc.buildConfiguration.GetModule() != "" {
 			goModule.SetName(gc.buildConfiguration.GetModule())
 		}
-		err = errorutils.CheckError(goModule.CalcDependencies())
+		if err = errorutils.CheckError(goModule.CalcDependencies()); err != nil {
+			return
+		}
+		if gc.goEnv != nil && gc.goEnv.SumDbProxy && tempDirPath == "" {
+			err = gc.verifyModuleSums(resolverDetails)
+		}
 	}
 
 	return
 }
 
+func (gc *GoCommand) isVendorCommand() bool {
+	return len(gc.goArg) > 0 && gc.goArg[0] == vendorCommand
+}
+
+// reportVendoredModules writes the integrity report of the vendor directory, and adds it to the build-info if it's collected.
+func (gc *GoCommand) reportVendoredModules(goBuildInfo *build.Build) error {
+	projectDir, err := getProjectRoot()
+	if err != nil {
+		return err
+	}
+	report, err := createVendorReport(projectDir, gc.resolverParams.TargetRepo())
+	if err != nil {
+		return err
+	}
+	return writeVendorReport(report, gc.vendorReportPath, goBuildInfo)
+}
+
+// setGoEnv sets the Go environment of the configuration while the Go command runs, and returns a function which restores it.
+func (gc *GoCommand) setGoEnv(resolverDetails *config.ServerDetails) (restore func() error, err error) {
+	if gc.goEnv == nil || gc.goEnv.isEmpty() {
+		return func() error { return nil }, nil
+	}
+	repoUrl, err := GetArtifactoryRemoteRepoUrl(resolverDetails, gc.resolverParams.TargetRepo(), GoProxyUrlParams{})
+	if err != nil {
+		return nil, err
+	}
+	return setGoEnv(gc.goEnv.environment(getSumDbUrl(repoUrl)))
+}
+
+// verifyModuleSums verifies the go.sum of the project against the checksum database proxied by the resolution repository.
+// The modules which Go doesn't check against the checksum database, by GONOSUMDB or GOPRIVATE, aren't verified.
+func (gc *GoCommand) verifyModuleSums(resolverDetails *config.ServerDetails) error {
+	details, err := resolverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	noSumDbPatterns := os.Getenv("GONOSUMDB")
+	if noSumDbPatterns == "" {
+		noSumDbPatterns = os.Getenv("GOPRIVATE")
+	}
+	sumDbUrl := getSumDbUrl(rtutils.AddTrailingSlashIfNeeded(details.GetUrl()) + "api/go/" + gc.resolverParams.TargetRepo())
+	return verifyModuleSums("go.sum", sumDbUrl, noSumDbPatterns, details)
+}
+
 // copyGoPackageFiles copies the package files from the go mod cache directory to the given destPath.
 // The path to those cache files is retrieved using the supplied package name and Artifactory details.
 func copyGoPackageFiles(destPath, packageName, rtTargetRepo string, authArtDetails auth.ServiceDetails) error {
@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"os"
 	"os/exec"
 
 	"github.com/jfrog/build-info-go/build"
@@ -8,8 +9,11 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"golang.org/x/mod/module"
 )
 
 const minSupportedArtifactoryVersion = "6.2.0"
@@ -19,6 +23,7 @@ type GoPublishCommandArgs struct {
 	version            string
 	detailedSummary    bool
 	excludedPatterns   []string
+	allModules         bool
 	result             *commandutils.Result
 	project.RepositoryConfig
 }
@@ -110,33 +115,97 @@ func (gpc *GoPublishCommand) Run() error {
 		}
 	}
 
-	// Publish the package to Artifactory.
-	summary, artifacts, err := publishPackage(gpc.version, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), serviceManager)
+	modules, err := gpc.getModules()
 	if err != nil {
 		return err
 	}
-	result := gpc.Result()
-	result.SetSuccessCount(summary.TotalSucceeded)
-	result.SetFailCount(summary.TotalFailed)
-	if gpc.detailedSummary {
-		result.SetReader(summary.TransferDetailsReader)
+	if len(modules) > 1 && gpc.buildConfiguration.GetModule() != "" {
+		return errorutils.CheckErrorf("the build-info module name can't be set when publishing %d Go modules, since each of them is published as its own build-info module", len(modules))
 	}
-	// Publish the build-info to Artifactory
-	if collectBuildInfo {
-		goModule, err := goBuild.AddGoModule("")
+	for _, goModule := range modules {
+		// Publish the package to Artifactory.
+		summary, artifacts, err := publishPackage(goModule, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), serviceManager)
 		if err != nil {
-			return errorutils.CheckError(err)
+			return err
+		}
+		if err = gpc.addSummary(summary); err != nil {
+			return err
+		}
+		// Publish the build-info to Artifactory
+		if collectBuildInfo {
+			goBuildModule, err := goBuild.AddGoModule(goModule.dir)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			goBuildModule.SetName(goModule.path)
+			if gpc.buildConfiguration.GetModule() != "" {
+				goBuildModule.SetName(gpc.buildConfiguration.GetModule())
+			}
+			err = goBuildModule.AddArtifacts(artifacts...)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
 		}
-		if gpc.buildConfiguration.GetModule() != "" {
-			goModule.SetName(gpc.buildConfiguration.GetModule())
+	}
+	return nil
+}
+
+// getModules returns the modules to publish, with their versions.
+// Without the all-modules option, the module of the project in the current directory is published. Otherwise, all the modules
+// under the current directory are published.
+// If the version isn't set, each module is published with the version of its current Git commit.
+func (gpc *GoPublishCommand) getModules() (modules []goModule, err error) {
+	if gpc.allModules {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if modules, err = findModules(wd); err != nil {
+			return nil, err
 		}
-		err = goModule.AddArtifacts(artifacts...)
+	} else {
+		projectPath, err := getProjectRoot()
 		if err != nil {
-			return errorutils.CheckError(err)
+			return nil, err
+		}
+		moduleName, err := GetModuleName(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		modules = []goModule{{dir: projectPath, path: moduleName}}
+	}
+	for i := range modules {
+		modules[i].version = gpc.version
+		if modules[i].version == "" {
+			if modules[i].version, err = pseudoVersion(modules[i].dir, modules[i].path); err != nil {
+				return nil, err
+			}
+		}
+		if err = module.Check(modules[i].path, modules[i].version); err != nil {
+			return nil, errorutils.CheckError(err)
 		}
 	}
+	return modules, nil
+}
 
-	return err
+// addSummary adds the summary of a published module to the result of the command.
+func (gpc *GoPublishCommand) addSummary(summary *servicesutils.OperationSummary) error {
+	result := gpc.Result()
+	result.SetSuccessCount(result.SuccessCount() + summary.TotalSucceeded)
+	result.SetFailCount(result.FailCount() + summary.TotalFailed)
+	if !gpc.detailedSummary {
+		return nil
+	}
+	if result.Reader() == nil {
+		result.SetReader(summary.TransferDetailsReader)
+		return nil
+	}
+	reader, err := content.MergeReaders([]*content.ContentReader{result.Reader(), summary.TransferDetailsReader}, content.DefaultKey)
+	if err != nil {
+		return err
+	}
+	result.SetReader(reader)
+	return nil
 }
 
 func (gpc *GoPublishCommandArgs) Result() *commandutils.Result {
@@ -153,6 +222,11 @@ func (gpc *GoPublishCommandArgs) SetBuildConfiguration(buildConfiguration *build
 	return gpc
 }
 
+func (gpc *GoPublishCommandArgs) SetAllModules(allModules bool) *GoPublishCommandArgs {
+	gpc.allModules = allModules
+	return gpc
+}
+
 func (gpc *GoPublishCommandArgs) SetDetailedSummary(detailedSummary bool) *GoPublishCommandArgs {
 	gpc.detailedSummary = detailedSummary
 	return gpc
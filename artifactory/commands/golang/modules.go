@@ -0,0 +1,157 @@
+package golang
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// goModule is a Go module of the project, which is published separately.
+type goModule struct {
+	// The directory of the go.mod file of the module.
+	dir string
+	// The module path, as declared in the go.mod file.
+	path    string
+	version string
+}
+
+// findModules returns the modules of the repository under root, which are the directories containing a go.mod file.
+// As the go command does, vendor and testdata directories, and directories whose name starts with '.' or '_', are skipped.
+func findModules(root string) (modules []goModule, err error) {
+	err = filepath.WalkDir(root, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		name := entry.Name()
+		if filePath != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		content, err := os.ReadFile(filepath.Join(filePath, "go.mod"))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		modulePath := modfile.ModulePath(content)
+		if modulePath == "" {
+			return errorutils.CheckErrorf("the go.mod file in %s doesn't declare a module path", filePath)
+		}
+		modules = append(modules, goModule{dir: filePath, path: modulePath})
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(modules) == 0 {
+		return nil, errorutils.CheckErrorf("could not find a go.mod file in %s", root)
+	}
+	return modules, nil
+}
+
+// pseudoVersion returns the version of the module at the current Git commit, as the go command computes it.
+// If the commit is tagged with a release of the module, the tag's version is returned. Otherwise, a pseudo-version based on
+// the latest release tag of the module before the commit is returned. The tags of a module in a subdirectory of the
+// repository are prefixed with the subdirectory, such as sub/v1.2.0.
+func pseudoVersion(dir, modulePath string) (string, error) {
+	repoRoot, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	tagPrefix, err := moduleTagPrefix(repoRoot, dir)
+	if err != nil {
+		return "", err
+	}
+	commit, err := runGit(dir, "log", "-1", "--format=%H %ct")
+	if err != nil {
+		return "", err
+	}
+	revision, commitTime, err := parseCommit(commit)
+	if err != nil {
+		return "", err
+	}
+	_, pathMajor, _ := module.SplitPathVersion(modulePath)
+	major := module.PathMajorPrefix(pathMajor)
+	olderTag := latestTag(dir, tagPrefix, major)
+	if olderTag != "" {
+		// A tagged commit is published with the version of the tag.
+		if tagCommit, err := runGit(dir, "rev-list", "-n", "1", olderTag); err == nil && tagCommit == revision {
+			return strings.TrimPrefix(olderTag, tagPrefix), nil
+		}
+	}
+	return module.PseudoVersion(major, strings.TrimPrefix(olderTag, tagPrefix), commitTime, revision[:12]), nil
+}
+
+// moduleTagPrefix returns the prefix of the release tags of the module in dir, which is its path relative to the repository root.
+func moduleTagPrefix(repoRoot, dir string) (string, error) {
+	// The repository root is returned by Git with its symbolic links resolved.
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	relPath, err := filepath.Rel(repoRoot, resolvedDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if relPath == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(relPath) + "/", nil
+}
+
+// latestTag returns the latest release tag of the module before the current commit, or an empty string if there's none.
+// The tags of a module without a major version suffix are v0 or v1 tags.
+func latestTag(dir, tagPrefix, major string) string {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if major != "" {
+		args = append(args, "--match", tagPrefix+major+".*")
+	} else {
+		args = append(args, "--match", tagPrefix+"v0.*", "--match", tagPrefix+"v1.*")
+	}
+	tag, err := runGit(dir, args...)
+	if err != nil || !semver.IsValid(strings.TrimPrefix(tag, tagPrefix)) {
+		log.Debug("No release tag of the module was found in", dir)
+		return ""
+	}
+	return tag
+}
+
+// parseCommit parses the output of git log --format="%H %ct", which is the hash of the commit and its Unix time.
+func parseCommit(commit string) (revision string, commitTime time.Time, err error) {
+	revision, unixTime, found := strings.Cut(commit, " ")
+	if !found {
+		return "", time.Time{}, errorutils.CheckErrorf("unexpected output of git log: %s", commit)
+	}
+	seconds, err := strconv.ParseInt(unixTime, 10, 64)
+	if err != nil {
+		return "", time.Time{}, errorutils.CheckErrorf("unexpected output of git log: %s", commit)
+	}
+	return revision, time.Unix(seconds, 0).UTC(), nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", errorutils.CheckErrorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
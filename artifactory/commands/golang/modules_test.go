@@ -0,0 +1,91 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGoMod(t *testing.T, dir, modulePath string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+modulePath+"\n\ngo 1.22\n"), 0644))
+}
+
+func TestFindModules(t *testing.T) {
+	root := t.TempDir()
+	writeGoMod(t, root, "github.com/acme/repo")
+	writeGoMod(t, filepath.Join(root, "sub"), "github.com/acme/repo/sub/v2")
+	writeGoMod(t, filepath.Join(root, "tools", "lint"), "github.com/acme/repo/tools/lint")
+	// The modules in vendor, testdata and hidden directories aren't published.
+	writeGoMod(t, filepath.Join(root, "vendor", "github.com", "other", "lib"), "github.com/other/lib")
+	writeGoMod(t, filepath.Join(root, "sub", "testdata", "example"), "example.com/example")
+	writeGoMod(t, filepath.Join(root, ".cache", "mod"), "example.com/cached")
+	writeGoMod(t, filepath.Join(root, "_archive"), "example.com/archive")
+
+	modules, err := findModules(root)
+	require.NoError(t, err)
+	assert.Equal(t, []goModule{
+		{dir: root, path: "github.com/acme/repo"},
+		{dir: filepath.Join(root, "sub"), path: "github.com/acme/repo/sub/v2"},
+		{dir: filepath.Join(root, "tools", "lint"), path: "github.com/acme/repo/tools/lint"},
+	}, modules)
+
+	_, err = findModules(t.TempDir())
+	assert.ErrorContains(t, err, "could not find a go.mod file")
+}
+
+func TestPseudoVersion(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE=2026-01-02T03:04:05Z", "GIT_AUTHOR_DATE=2026-01-02T03:04:05Z")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	writeGoMod(t, root, "github.com/acme/repo")
+	subDir := filepath.Join(root, "sub")
+	writeGoMod(t, subDir, "github.com/acme/repo/sub/v2")
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	revision, err := runGit(root, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	shortRevision := revision[:12]
+
+	// Without release tags, the pseudo-versions are based on the major version of the module path.
+	version, err := pseudoVersion(root, "github.com/acme/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.0.0-20260102030405-"+shortRevision, version)
+	version, err = pseudoVersion(subDir, "github.com/acme/repo/sub/v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0-20260102030405-"+shortRevision, version)
+
+	// A tagged commit is published with the version of its tag, and the next commits with pseudo-versions after it.
+	git("tag", "v1.2.3")
+	git("tag", "sub/v2.1.0-rc.1")
+	version, err = pseudoVersion(root, "github.com/acme/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", version)
+	version, err = pseudoVersion(subDir, "github.com/acme/repo/sub/v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.1.0-rc.1", version)
+
+	git("commit", "-q", "--allow-empty", "-m", "next")
+	revision, err = runGit(root, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	shortRevision = revision[:12]
+	version, err = pseudoVersion(root, "github.com/acme/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.4-0.20260102030405-"+shortRevision, version)
+	version, err = pseudoVersion(subDir, "github.com/acme/repo/sub/v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.1.0-rc.1.0.20260102030405-"+shortRevision, version)
+}
@@ -27,20 +27,10 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-// Publish go project to Artifactory.
-func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
-	projectPath, err := getProjectRoot()
-	if err != nil {
-		return nil, nil, errorutils.CheckError(err)
-	}
-
-	// Read module name
-	moduleName, err := GetModuleName(projectPath)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Info("Publishing", moduleName, "to", targetRepo)
+// Publish a module of the go project to Artifactory.
+func publishPackage(goModule goModule, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
+	projectPath, moduleName, packageVersion := goModule.dir, goModule.path, goModule.version
+	log.Info("Publishing", moduleName+"@"+packageVersion, "to", targetRepo)
 	filePathInRepo := path.Join(moduleName, "@v", packageVersion)
 	collectBuildInfo := len(buildName) > 0 && len(buildNumber) > 0
 	modContent, modArtifact, err := readModFile(packageVersion, projectPath, targetRepo, filePathInRepo+".mod", collectBuildInfo)
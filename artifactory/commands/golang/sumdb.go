@@ -0,0 +1,120 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+)
+
+// getSumDbUrl returns the URL of the checksum database proxied by the Go API of the repository.
+func getSumDbUrl(repoApiUrl string) string {
+	return strings.TrimSuffix(repoApiUrl, "/") + "/sumdb/" + goSumDbName
+}
+
+// readGoSum returns the checksums of the go.sum file, by the module versions, such as github.com/acme/lib@v1.0.0, and the go.mod
+// files of the module versions, such as github.com/acme/lib@v1.0.0/go.mod. It returns nil if the go.sum file doesn't exist.
+func readGoSum(goSumPath string) (map[string]string, error) {
+	content, err := os.ReadFile(goSumPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return parseGoSumLines(content), nil
+}
+
+// parseGoSumLines parses the "<module> <version> <hash>" lines of a go.sum file, or of a lookup of the checksum database.
+func parseGoSumLines(content []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || !strings.HasPrefix(fields[2], "h1:") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+	return sums
+}
+
+// verifyModuleSums verifies the checksums of the go.sum file against the checksum database proxied by Artifactory.
+// Module versions which match the GONOSUMDB patterns, or which the checksum database doesn't have, aren't verified.
+func verifyModuleSums(goSumPath, sumDbUrl, noSumDbPatterns string, details auth.ServiceDetails) error {
+	goSums, err := readGoSum(goSumPath)
+	if err != nil || len(goSums) == 0 {
+		return err
+	}
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return err
+	}
+	// A lookup of a module version returns the checksums of both the module and its go.mod file.
+	moduleVersions := make(map[string]bool)
+	for moduleVersion := range goSums {
+		moduleVersions[strings.TrimSuffix(moduleVersion, "/go.mod")] = true
+	}
+	sortedModuleVersions := make([]string, 0, len(moduleVersions))
+	for moduleVersion := range moduleVersions {
+		sortedModuleVersions = append(sortedModuleVersions, moduleVersion)
+	}
+	sort.Strings(sortedModuleVersions)
+	log.Info(fmt.Sprintf("Verifying the checksums of %d module versions of %s against the checksum database of Artifactory...", len(sortedModuleVersions), goSumPath))
+	var mismatches []string
+	for _, moduleVersion := range sortedModuleVersions {
+		modulePath, version, _ := strings.Cut(moduleVersion, "@")
+		if module.MatchPrefixPatterns(noSumDbPatterns, modulePath) {
+			continue
+		}
+		dbSums, err := lookupModuleSums(client, sumDbUrl, modulePath, version, details)
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{moduleVersion, moduleVersion + "/go.mod"} {
+			goSum, inGoSum := goSums[key]
+			dbSum, inDb := dbSums[key]
+			if inGoSum && inDb && goSum != dbSum {
+				mismatches = append(mismatches, fmt.Sprintf("%s: %s in go.sum, %s in the checksum database", key, goSum, dbSum))
+			}
+		}
+	}
+	if len(mismatches) > 0 {
+		return errorutils.CheckErrorf("the checksums of %d modules in %s don't match the checksum database of Artifactory:\n%s",
+			len(mismatches), goSumPath, strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+// lookupModuleSums returns the checksums of the module version in the checksum database, or nil if the database doesn't have it.
+func lookupModuleSums(client *httpclient.HttpClient, sumDbUrl, modulePath, version string, details auth.ServiceDetails) (map[string]string, error) {
+	escapedPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	resp, body, _, err := client.SendGet(sumDbUrl+"/lookup/"+escapedPath+"@"+escapedVersion, true, details.CreateHttpClientDetails(), "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		log.Debug(fmt.Sprintf("The checksum database doesn't have %s@%s.", modulePath, version))
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return parseGoSumLines(body), nil
+}
@@ -0,0 +1,45 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyModuleSums(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/go/go-virtual/sumdb/sum.golang.org/lookup/github.com/!burnt!sushi/toml@v1.3.2":
+			_, _ = w.Write([]byte("12345\ngithub.com/BurntSushi/toml v1.3.2 h1:toml=\ngithub.com/BurntSushi/toml v1.3.2/go.mod h1:tomlmod=\n\ngo.sum database tree\n"))
+		case "/artifactory/api/go/go-virtual/sumdb/sum.golang.org/lookup/golang.org/x/mod@v0.14.0":
+			_, _ = w.Write([]byte("12346\ngolang.org/x/mod v0.14.0 h1:mod=\ngolang.org/x/mod v0.14.0/go.mod h1:modmod=\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	details, err := (&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}).CreateArtAuthConfig()
+	require.NoError(t, err)
+	sumDbUrl := getSumDbUrl(server.URL + "/artifactory/api/go/go-virtual")
+	goSumPath := filepath.Join(t.TempDir(), "go.sum")
+	goSum := "github.com/BurntSushi/toml v1.3.2 h1:toml=\ngithub.com/BurntSushi/toml v1.3.2/go.mod h1:tomlmod=\n" +
+		"golang.org/x/mod v0.14.0/go.mod h1:modmod=\n" +
+		// The checksum database doesn't have the private modules.
+		"github.com/acme/lib v1.0.0 h1:private=\n"
+	require.NoError(t, os.WriteFile(goSumPath, []byte(goSum), 0644))
+	assert.NoError(t, verifyModuleSums(goSumPath, sumDbUrl, "", details))
+
+	require.NoError(t, os.WriteFile(goSumPath, []byte(goSum+"golang.org/x/mod v0.14.0 h1:tampered=\n"), 0644))
+	err = verifyModuleSums(goSumPath, sumDbUrl, "", details)
+	assert.ErrorContains(t, err, "golang.org/x/mod@v0.14.0: h1:tampered= in go.sum, h1:mod= in the checksum database")
+	assert.NoError(t, verifyModuleSums(goSumPath, sumDbUrl, "golang.org/x", details))
+
+	// A project without a go.sum has nothing to verify.
+	assert.NoError(t, verifyModuleSums(filepath.Join(t.TempDir(), "go.sum"), sumDbUrl, "", details))
+}
@@ -0,0 +1,126 @@
+package golang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The JFrog CLI command which populates the vendor directory, by running go mod vendor.
+	vendorCommand = "vendor"
+	// The option of the vendor command which sets the path of the integrity report.
+	vendorReportOption   = "report"
+	defaultVendorReport  = "go-vendor-report.json"
+	vendorModulesTxtPath = "vendor/modules.txt"
+	// VendorReportProp is the build-info property of the integrity report of the vendor directory.
+	VendorReportProp = "buildInfo.go.vendorReport"
+)
+
+// VendorReport is the integrity report of the modules in the vendor directory.
+type VendorReport struct {
+	Modules []VendoredModule `json:"modules"`
+}
+
+// VendoredModule is a module copied to the vendor directory.
+type VendoredModule struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	// The h1 hash of the module's content, which the go command verified when downloading it.
+	H1 string `json:"h1"`
+	// The Artifactory repository which the module was resolved from.
+	Repository string `json:"repository"`
+}
+
+// vendorEnvironment returns the environment of go mod vendor, which doesn't fetch any module directly from its VCS, including
+// the private modules, so that all the vendored modules are resolved from Artifactory.
+func vendorEnvironment() map[string]string {
+	return map[string]string{"GONOPROXY": "none"}
+}
+
+// createVendorReport creates the integrity report of the vendor directory of the project in projectDir, from the modules listed
+// by vendor/modules.txt and their hashes in go.sum. Modules replaced by local directories aren't resolved, so they aren't reported.
+func createVendorReport(projectDir, repository string) (*VendorReport, error) {
+	modules, err := readVendoredModules(filepath.Join(projectDir, filepath.FromSlash(vendorModulesTxtPath)))
+	if err != nil {
+		return nil, err
+	}
+	goSum, err := readGoSum(filepath.Join(projectDir, "go.sum"))
+	if err != nil {
+		return nil, err
+	}
+	report := &VendorReport{Modules: []VendoredModule{}}
+	for _, vendored := range modules {
+		h1 := goSum[vendored.Module+"@"+vendored.Version]
+		if h1 == "" {
+			return nil, errorutils.CheckErrorf("the go.sum file has no hash of the vendored module %s@%s", vendored.Module, vendored.Version)
+		}
+		vendored.H1, vendored.Repository = h1, repository
+		report.Modules = append(report.Modules, vendored)
+	}
+	return report, nil
+}
+
+// readVendoredModules reads the modules listed by vendor/modules.txt, with the modules replacing them, if any.
+// The module lines are in the forms of:
+//
+//	# example.com/module v1.2.3
+//	# example.com/module v1.2.3 => example.com/fork v1.2.4
+//	# example.com/module v1.2.3 => ./local
+//	# example.com/unused => example.com/fork v1.2.4
+func readVendoredModules(modulesTxtPath string) (modules []VendoredModule, err error) {
+	file, err := os.Open(modulesTxtPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the vendored modules: %s", err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, isModule := strings.CutPrefix(scanner.Text(), "# ")
+		if !isModule {
+			continue
+		}
+		module, replacement, replaced := strings.Cut(line, "=>")
+		fields := strings.Fields(module)
+		// The replacements without a version are listed too, though no module of the build list was replaced by them.
+		if replaced && len(fields) == 2 {
+			fields = strings.Fields(replacement)
+		}
+		if len(fields) != 2 {
+			log.Debug("Skipping the vendored module without a resolved version:", line)
+			continue
+		}
+		modules = append(modules, VendoredModule{Module: fields[0], Version: fields[1]})
+	}
+	return modules, errorutils.CheckError(scanner.Err())
+}
+
+// writeVendorReport writes the integrity report to reportPath, and adds it to the build-info as the VendorReportProp property.
+func writeVendorReport(report *VendorReport, reportPath string, goBuild *build.Build) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(reportPath, append(content, '\n'), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The integrity report of the", len(report.Modules), "vendored modules was written to", reportPath)
+	if goBuild == nil {
+		return nil
+	}
+	compactContent, err := json.Marshal(report)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(goBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: buildinfo.Env{VendorReportProp: string(compactContent)}}))
+}
@@ -0,0 +1,53 @@
+package golang
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModulesTxt = `# github.com/BurntSushi/toml v1.3.2
+## explicit; go 1.16
+github.com/BurntSushi/toml
+# golang.org/x/mod v0.14.0 => golang.org/x/mod v0.15.0
+## explicit; go 1.18
+golang.org/x/mod/semver
+# github.com/acme/lib v1.0.0 => ../lib
+## explicit
+github.com/acme/lib
+# golang.org/x/net => golang.org/x/net v0.20.0
+`
+
+func TestCreateVendorReport(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "vendor"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "vendor", "modules.txt"), []byte(testModulesTxt), 0644))
+	goSum := "github.com/BurntSushi/toml v1.3.2 h1:toml=\ngithub.com/BurntSushi/toml v1.3.2/go.mod h1:tomlmod=\n" +
+		"golang.org/x/mod v0.15.0 h1:mod=\n"
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.sum"), []byte(goSum), 0644))
+
+	report, err := createVendorReport(projectDir, "go-virtual")
+	require.NoError(t, err)
+	// The modules replaced by local directories aren't resolved from Artifactory, and the unused replacements aren't vendored.
+	assert.Equal(t, []VendoredModule{
+		{Module: "github.com/BurntSushi/toml", Version: "v1.3.2", H1: "h1:toml=", Repository: "go-virtual"},
+		{Module: "golang.org/x/mod", Version: "v0.15.0", H1: "h1:mod=", Repository: "go-virtual"},
+	}, report.Modules)
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeVendorReport(report, reportPath, nil))
+	content, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	writtenReport := new(VendorReport)
+	require.NoError(t, json.Unmarshal(content, writtenReport))
+	assert.Equal(t, report, writtenReport)
+
+	// A vendored module without a hash fails the report.
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.sum"), []byte("golang.org/x/mod v0.15.0 h1:mod=\n"), 0644))
+	_, err = createVendorReport(projectDir, "go-virtual")
+	assert.ErrorContains(t, err, "github.com/BurntSushi/toml@v1.3.2")
+}
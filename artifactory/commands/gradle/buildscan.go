@@ -0,0 +1,65 @@
+package gradle
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// BuildScanUrlProp is the build-info property of the URL of the Build Scan published to Develocity (formerly Gradle Enterprise) by the build.
+const BuildScanUrlProp = "buildInfo.gradle.buildScanUrl"
+
+var (
+	// Develocity and the Gradle Enterprise plugin print the URL in the line after this one.
+	publishingBuildScanPattern = regexp.MustCompile(`(?i)^publishing build scan`)
+	buildScanUrlPattern        = regexp.MustCompile(`^https?://\S+$`)
+)
+
+// captureBuildScanUrl runs the build while copying its standard output, and returns the URL of the Build Scan the build published, if any.
+// Since Gradle's standard output is then a pipe, Gradle uses its plain console unless --console is set.
+func captureBuildScanUrl(runBuild func() error) (buildScanUrl string, err error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	originalStdout := os.Stdout
+	os.Stdout = writer
+	scanned := make(chan string)
+	go func() {
+		scanned <- scanBuildScanUrl(io.TeeReader(reader, originalStdout))
+	}()
+	err = runBuild()
+	os.Stdout = originalStdout
+	if closeErr := writer.Close(); err == nil {
+		err = errorutils.CheckError(closeErr)
+	}
+	buildScanUrl = <-scanned
+	if closeErr := reader.Close(); err == nil {
+		err = errorutils.CheckError(closeErr)
+	}
+	return
+}
+
+// scanBuildScanUrl reads the build output until its end, and returns the URL of the last Build Scan it published.
+func scanBuildScanUrl(output io.Reader) (buildScanUrl string) {
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	publishing := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case publishingBuildScanPattern.MatchString(line):
+			publishing = true
+		case publishing && buildScanUrlPattern.MatchString(line):
+			buildScanUrl = line
+			publishing = false
+		}
+	}
+	// Keep copying the output if a line was too long to be scanned.
+	_, _ = io.Copy(io.Discard, output)
+	return
+}
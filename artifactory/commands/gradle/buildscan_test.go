@@ -0,0 +1,39 @@
+package gradle
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanBuildScanUrl(t *testing.T) {
+	output := `> Task :app:build
+
+BUILD SUCCESSFUL in 3s
+8 actionable tasks: 8 executed
+
+Publishing Build Scan to Develocity...
+https://develocity.example.com/s/abcdef123456
+`
+	assert.Equal(t, "https://develocity.example.com/s/abcdef123456", scanBuildScanUrl(strings.NewReader(output)))
+	// A URL which isn't printed after publishing a Build Scan is ignored.
+	assert.Empty(t, scanBuildScanUrl(strings.NewReader("Downloading https://services.gradle.org/distributions/gradle-8.5-bin.zip\nhttps://example.com/other\n")))
+	// Lines longer than the scanner buffer don't stop the output from being read.
+	assert.Empty(t, scanBuildScanUrl(strings.NewReader(strings.Repeat("x", 2*1024*1024)+"\nPublishing build scan...\n")))
+}
+
+func TestCaptureBuildScanUrl(t *testing.T) {
+	buildScanUrl, err := captureBuildScanUrl(func() error {
+		_, err := fmt.Fprintln(os.Stdout, "Publishing build scan...\nhttps://gradle.com/s/abc123")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://gradle.com/s/abc123", buildScanUrl)
+
+	_, err = captureBuildScanUrl(func() error { return assert.AnError })
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,65 @@
+package gradle
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DeploymentPlanItem is an artifact that would have been deployed by the build.
+type DeploymentPlanItem struct {
+	TargetRepository string `json:"targetRepository" col-name:"Target Repository"`
+	// The path of the artifact in the target repository.
+	Path       string `json:"path" col-name:"Path"`
+	SourcePath string `json:"sourcePath" col-name:"Source Path"`
+	Sha256     string `json:"sha256" col-name:"Sha256"`
+}
+
+// printDeploymentPlan prints the artifacts of the deployable artifacts file, which would have been deployed by the dry run.
+func (gc *GradleCommand) printDeploymentPlan() error {
+	plan, err := readDeploymentPlan(gc.result.Reader())
+	if err != nil {
+		return err
+	}
+	// The reader is still used by the detailed summary, if requested.
+	if gc.IsDetailedSummary() {
+		gc.result.Reader().Reset()
+	} else if err = gc.result.Reader().Close(); err != nil {
+		return err
+	}
+	log.Info("Dry run: no artifacts were deployed.", len(plan), "artifacts would have been deployed.")
+	switch gc.scanOutputFormat {
+	case format.Json:
+		data, err := json.Marshal(plan)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(data))
+		return nil
+	case format.Table, format.None:
+		return coreutils.PrintTable(plan, "Deployment plan", "No artifacts would have been deployed", false)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for the deployment plan. Acceptable values are: json, table", gc.scanOutputFormat)
+	}
+}
+
+// readDeploymentPlan reads the artifacts from the deployable artifacts file, after it was converted to file transfer details.
+func readDeploymentPlan(reader *content.ContentReader) ([]DeploymentPlanItem, error) {
+	plan := []DeploymentPlanItem{}
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		// The target path is <repository>/<path>.
+		repo, artifactPath, _ := strings.Cut(transferDetails.TargetPath, "/")
+		plan = append(plan, DeploymentPlanItem{TargetRepository: repo, Path: artifactPath, SourcePath: transferDetails.SourcePath, Sha256: transferDetails.Sha256})
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	return plan, nil
+}
@@ -0,0 +1,41 @@
+package gradle
+
+import (
+	"path/filepath"
+	"testing"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDeploymentPlan(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "deployable-artifacts.json")
+	require.NoError(t, clientutils.SaveFileTransferDetailsInFile(filePath, &[]clientutils.FileTransferDetails{
+		{SourcePath: "build/libs/app-1.0.jar", TargetPath: "libs-release-local/org/app/1.0/app-1.0.jar", RtUrl: "https://acme.jfrog.io/artifactory/", Sha256: "jar"},
+		{SourcePath: "build/publications/mavenJava/pom-default.xml", TargetPath: "libs-release-local/org/app/1.0/app-1.0.pom", Sha256: "pom"},
+	}))
+	reader := content.NewContentReader(filePath, "files")
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	plan, err := readDeploymentPlan(reader)
+	require.NoError(t, err)
+	assert.Equal(t, []DeploymentPlanItem{
+		{TargetRepository: "libs-release-local", Path: "org/app/1.0/app-1.0.jar", SourcePath: "build/libs/app-1.0.jar", Sha256: "jar"},
+		{TargetRepository: "libs-release-local", Path: "org/app/1.0/app-1.0.pom", SourcePath: "build/publications/mavenJava/pom-default.xml", Sha256: "pom"},
+	}, plan)
+
+	// The reader is reset, so that it can still be used by the detailed summary.
+	length, err := reader.Length()
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+}
+
+func TestShouldCreateBuildArtifactsFileOnDryRun(t *testing.T) {
+	gc := NewGradleCommand().SetDryRun(true)
+	gc.deploymentDisabled = true
+	assert.True(t, gc.shouldCreateBuildArtifactsFile())
+}
@@ -0,0 +1,108 @@
+package gradle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ExtractorsDirEnv is a directory of pre-provisioned Gradle build-info extractor jars, used instead of downloading them.
+// It has the layout of the extractors directory of the CLI: <version>/build-info-extractor-gradle-<version>-uber.jar.
+const ExtractorsDirEnv = "JFROG_CLI_EXTRACTORS_DIR"
+
+const extractorJarPattern = "build-info-extractor-gradle-*-uber.jar"
+
+// The checksum files, which may be provisioned next to the jars, by their extension.
+var extractorChecksumFiles = []struct {
+	extension string
+	algorithm crypto.Algorithm
+}{
+	{".sha256", crypto.SHA256},
+	{".sha1", crypto.SHA1},
+}
+
+// getGradleDependencyLocalPath returns the directory of the Gradle extractor jars, and the function which downloads a missing jar into it.
+// When extractorPath or the JFROG_CLI_EXTRACTORS_DIR environment variable is set, the jars are taken from that directory
+// only, and their checksums are verified against the checksum files next to them.
+func getGradleDependencyLocalPath(extractorPath string) (localPath string, downloadExtractor func(downloadTo, downloadFrom string) error, err error) {
+	if extractorPath == "" {
+		extractorPath = os.Getenv(ExtractorsDirEnv)
+	}
+	if extractorPath == "" {
+		dependenciesPath, err := config.GetJfrogDependenciesPath()
+		if err != nil {
+			return "", nil, err
+		}
+		return filepath.Join(dependenciesPath, "gradle"), dependencies.DownloadExtractor, nil
+	}
+	exists, err := fileutils.IsDirExists(extractorPath, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if !exists {
+		return "", nil, errorutils.CheckErrorf("the Gradle extractors directory %s doesn't exist", extractorPath)
+	}
+	if err = verifyExtractorJars(extractorPath); err != nil {
+		return "", nil, err
+	}
+	log.Debug("Using the Gradle build-info extractor from", extractorPath)
+	return extractorPath, func(downloadTo, downloadFrom string) error {
+		return errorutils.CheckErrorf("the Gradle build-info extractor %s was not found. Download it from %s and place it at this path, "+
+			"or unset the extractors directory to download it automatically", downloadTo, "https://releases.jfrog.io/artifactory/oss-release-local/"+downloadFrom)
+	}, nil
+}
+
+// verifyExtractorJars verifies the checksums of the extractor jars in the directory, which have checksum files next to them.
+func verifyExtractorJars(extractorsDir string) error {
+	jars, err := filepath.Glob(filepath.Join(extractorsDir, "*", extractorJarPattern))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, jar := range jars {
+		verified, err := verifyExtractorJarChecksum(jar)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			log.Warn(fmt.Sprintf("The checksum of %s can't be verified, since no %s or %s file was found next to it.", jar, extractorChecksumFiles[0].extension, extractorChecksumFiles[1].extension))
+		}
+	}
+	return nil
+}
+
+// verifyExtractorJarChecksum compares the checksum of the jar with the first checksum file found next to it.
+// Returns false if there is no checksum file.
+func verifyExtractorJarChecksum(jar string) (bool, error) {
+	for _, checksumFile := range extractorChecksumFiles {
+		expected, err := os.ReadFile(jar + checksumFile.extension)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return false, errorutils.CheckError(err)
+		}
+		// The checksum may be followed by the file name.
+		fields := strings.Fields(string(expected))
+		if len(fields) == 0 {
+			return false, errorutils.CheckErrorf("the checksum file %s is empty", jar+checksumFile.extension)
+		}
+		checksums, err := crypto.GetFileChecksums(jar, checksumFile.algorithm)
+		if err != nil {
+			return false, errorutils.CheckError(err)
+		}
+		if !strings.EqualFold(fields[0], checksums[checksumFile.algorithm]) {
+			return false, errorutils.CheckErrorf("the checksum of the Gradle build-info extractor %s doesn't match %s. Expected %s, but got %s",
+				jar, jar+checksumFile.extension, fields[0], checksums[checksumFile.algorithm])
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,66 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of "extractor"
+const extractorContentSha256 = "7488d73850bf2009e6762d270ba53bb4e2f88ec77d3dcebeeed21ebac43dca24"
+
+func createExtractorJar(t *testing.T, extractorsDir, version string) string {
+	jarDir := filepath.Join(extractorsDir, version)
+	require.NoError(t, os.MkdirAll(jarDir, 0755))
+	jar := filepath.Join(jarDir, "build-info-extractor-gradle-"+version+"-uber.jar")
+	require.NoError(t, os.WriteFile(jar, []byte("extractor"), 0644))
+	return jar
+}
+
+func TestGetGradleDependencyLocalPathFromExtractorsDir(t *testing.T) {
+	extractorsDir := t.TempDir()
+	jar := createExtractorJar(t, extractorsDir, "5.2.5")
+	require.NoError(t, os.WriteFile(jar+".sha256", []byte(extractorContentSha256+"  build-info-extractor-gradle-5.2.5-uber.jar\n"), 0644))
+
+	t.Run("flag", func(t *testing.T) {
+		t.Setenv(ExtractorsDirEnv, "")
+		localPath, downloadExtractor, err := getGradleDependencyLocalPath(extractorsDir)
+		require.NoError(t, err)
+		assert.Equal(t, extractorsDir, localPath)
+		// Missing jars are never downloaded.
+		assert.ErrorContains(t, downloadExtractor(filepath.Join(extractorsDir, "4.35.5", "jar"), "org/jfrog/jar"), "was not found")
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv(ExtractorsDirEnv, extractorsDir)
+		localPath, _, err := getGradleDependencyLocalPath("")
+		require.NoError(t, err)
+		assert.Equal(t, extractorsDir, localPath)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, _, err := getGradleDependencyLocalPath(filepath.Join(extractorsDir, "missing"))
+		assert.ErrorContains(t, err, "doesn't exist")
+	})
+}
+
+func TestVerifyExtractorJars(t *testing.T) {
+	t.Run("checksum mismatch", func(t *testing.T) {
+		extractorsDir := t.TempDir()
+		jar := createExtractorJar(t, extractorsDir, "5.2.5")
+		require.NoError(t, os.WriteFile(jar+".sha1", []byte("0000000000000000000000000000000000000000"), 0644))
+		assert.ErrorContains(t, verifyExtractorJars(extractorsDir), "doesn't match")
+	})
+
+	t.Run("no checksum file", func(t *testing.T) {
+		extractorsDir := t.TempDir()
+		jar := createExtractorJar(t, extractorsDir, "5.2.5")
+		assert.NoError(t, verifyExtractorJars(extractorsDir))
+		verified, err := verifyExtractorJarChecksum(jar)
+		assert.NoError(t, err)
+		assert.False(t, verified)
+	})
+}
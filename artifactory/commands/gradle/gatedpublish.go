@@ -0,0 +1,93 @@
+package gradle
+
+import (
+	"errors"
+	"os"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	buildinfocmd "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// GateStatusProp is the build-info property of the result of the Xray scan of the artifacts, when the build-info is published
+	// after the scan. Its value is GateStatusPassed or GateStatusBlocked.
+	GateStatusProp = "buildInfo.gate.status"
+	// GateReasonProp is the build-info property of the reason the artifacts weren't deployed, when the scan blocked them.
+	GateReasonProp    = "buildInfo.gate.reason"
+	GateStatusPassed  = "passed"
+	GateStatusBlocked = "blocked"
+)
+
+// SetPublishBuildInfo publishes the build-info after the Xray scan of a conditional upload. If the scan passes, the artifacts are
+// deployed, and then the build-info is published. If the scan fails the build, the build-info is published without deploying
+// the artifacts, with its GateStatusProp property set to GateStatusBlocked. Any other error, such as a failure to run the scan,
+// fails the command without publishing the build-info.
+func (gc *GradleCommand) SetPublishBuildInfo(publishBuildInfo bool) *GradleCommand {
+	gc.publishBuildInfo = publishBuildInfo
+	return gc
+}
+
+func (gc *GradleCommand) validatePublishBuildInfo() error {
+	if !gc.publishBuildInfo {
+		return nil
+	}
+	if !gc.IsXrayScan() {
+		return errorutils.CheckErrorf("the --publish-build-info option can only be used with the --scan option")
+	}
+	isCollect, err := gc.configuration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if !isCollect {
+		return errorutils.CheckErrorf("the --publish-build-info option requires the --build-name and --build-number options")
+	}
+	return nil
+}
+
+// publishGatedBuildInfo adds the result of the scan to the build-info, and publishes it.
+// scanErr is the error which prevented the deployment of the artifacts, or nil if they were deployed.
+func (gc *GradleCommand) publishGatedBuildInfo(scanErr error) error {
+	if err := saveGateStatus(gc.configuration, scanErr); err != nil {
+		return err
+	}
+	if scanErr != nil {
+		log.Info("The artifacts were blocked by the Xray scan. Publishing the build-info as blocked...")
+	}
+	publishConfig := &biconf.Configuration{BuildUrl: os.Getenv(coreutils.BuildUrl), EnvInclude: "*", EnvExclude: os.Getenv(coreutils.EnvExclude)}
+	if publishConfig.EnvExclude == "" {
+		publishConfig.EnvExclude = buildinfocmd.DefaultEnvExclude
+	}
+	return buildinfocmd.NewBuildPublishCommand().SetServerDetails(gc.serverDetails).SetBuildConfiguration(gc.configuration).SetConfig(publishConfig).Run()
+}
+
+// isGateFailure returns whether err is the error of a scan which found violations that fail the build, rather than a failure
+// to read the artifacts or to run the scan.
+func isGateFailure(err error) bool {
+	var cliErr coreutils.CliError
+	return errors.As(err, &cliErr) && cliErr.ExitCode == coreutils.ExitCodeVulnerableBuild
+}
+
+func saveGateStatus(configuration *build.BuildConfiguration, scanErr error) error {
+	buildName, err := configuration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := configuration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	gatedBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, configuration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	env := buildinfo.Env{GateStatusProp: GateStatusPassed}
+	if scanErr != nil {
+		env = buildinfo.Env{GateStatusProp: GateStatusBlocked, GateReasonProp: scanErr.Error()}
+	}
+	return errorutils.CheckError(gatedBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: env}))
+}
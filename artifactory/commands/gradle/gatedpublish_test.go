@@ -0,0 +1,48 @@
+package gradle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePublishBuildInfo(t *testing.T) {
+	gc := NewGradleCommand().SetConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	assert.NoError(t, gc.validatePublishBuildInfo())
+	gc.SetPublishBuildInfo(true)
+	assert.ErrorContains(t, gc.validatePublishBuildInfo(), "--scan")
+	gc.SetXrayScan(true)
+	assert.ErrorContains(t, gc.validatePublishBuildInfo(), "--build-name")
+	gc.SetConfiguration(build.NewBuildConfiguration("gradle-gated-publish", "1", "", ""))
+	assert.NoError(t, gc.validatePublishBuildInfo())
+}
+
+func TestSaveGateStatus(t *testing.T) {
+	configuration := build.NewBuildConfiguration("gradle-gate-status", "1", "", "")
+	buildDir, err := build.GetBuildDir("gradle-gate-status", "1", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(buildDir))
+	}()
+
+	require.NoError(t, saveGateStatus(configuration, errors.New("violations were found")))
+	partials, err := build.ReadPartialBuildInfoFiles("gradle-gate-status", "1", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, buildinfo.Env{GateStatusProp: GateStatusBlocked, GateReasonProp: "violations were found"}, partials[0].Env)
+}
+
+func TestIsGateFailure(t *testing.T) {
+	failBuildErr := coreutils.CliError{ExitCode: coreutils.ExitCodeVulnerableBuild, ErrorMsg: "violations were found"}
+	assert.True(t, isGateFailure(failBuildErr))
+	assert.True(t, isGateFailure(fmt.Errorf("scan failed: %w", failBuildErr)))
+	assert.False(t, isGateFailure(coreutils.CliError{ExitCode: coreutils.ExitCodeError, ErrorMsg: "the scan failed"}))
+	assert.False(t, isGateFailure(errors.New("the Xray scan isn't available in this build of JFrog CLI")))
+}
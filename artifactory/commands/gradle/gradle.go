@@ -1,19 +1,24 @@
 package gradle
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
+	buildinfo "github.com/jfrog/build-info-go/entities"
 	buildinfoflexpack "github.com/jfrog/build-info-go/flexpack/gradle"
 	flexpackgradle "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/flexpack/gradle"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
 	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -21,7 +26,6 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -33,13 +37,17 @@ import (
 //go:embed resources/jfrog.init.gradle
 var gradleInitScript string
 
+//go:embed resources/jfrog.init.gradle.kts
+var kotlinGradleInitScript string
+
 const (
 	usePlugin  = "useplugin"
 	useWrapper = "usewrapper"
 
-	UserHomeEnv    = "GRADLE_USER_HOME"
-	InitScriptName = "jfrog.init.gradle"
-	javaUserHome   = "user.home"
+	UserHomeEnv          = "GRADLE_USER_HOME"
+	InitScriptName       = "jfrog.init.gradle"
+	KotlinInitScriptName = "jfrog.init.gradle.kts"
+	javaUserHome         = "user.home"
 )
 
 type GradleCommand struct {
@@ -53,12 +61,42 @@ type GradleCommand struct {
 	scanOutputFormat   format.OutputFormat
 	result             *commandsutils.Result
 	deploymentDisabled bool
+	// Collect the build info of the builds included by the settings file, in addition to the root build.
+	includeCompositeBuilds bool
+	// Record the resolved dependency graph of the Gradle configurations in the build info.
+	dependencyGraph bool
+	// Directory of pre-provisioned build-info extractor jars, used instead of downloading the extractor.
+	extractorPath string
+	// The publications whose artifacts are deployed. All the artifacts are still listed in the summary and the build info.
+	publications publicationFilter
+	// Run the build with the deployment disabled, and print the artifacts that would have been deployed.
+	dryRun bool
+	// Semicolon-separated key=value properties, attached to the deployed artifacts.
+	targetProps string
+	// The number of times a failed deployment request is retried. If negative, the extractor's default is used.
+	retries            int
+	retryWaitMilliSecs int
+	// The deployable artifacts file of a failed deployment, whose remaining artifacts are deployed instead of running the build.
+	resumeFrom string
+	// Skip the deployment of the artifacts which are already in Artifactory with the same sha256.
+	skipIdentical bool
+	// The detailed summary printed when the JSON format is requested.
+	deploymentSummary *DeploymentSummary
 	// File path for Gradle extractor in which all build's artifacts details will be listed at the end of the build.
 	buildArtifactsDetailsFile string
+	// The build is stopped when the context is canceled, or after the timeout, if positive.
+	ctx     context.Context
+	timeout time.Duration
+	// The deployment repositories of the modules, read from the deployer section of the configuration.
+	moduleRepos []moduleRepo
+	// Arguments passed to Gradle as is, after the tasks. See SplitPassthroughArgs.
+	passthroughArgs []string
+	// Publish the build-info after the Xray scan of the conditional upload, marked with the result of the scan.
+	publishBuildInfo bool
 }
 
 func NewGradleCommand() *GradleCommand {
-	return &GradleCommand{}
+	return &GradleCommand{retries: -1}
 }
 
 // Returns the ServerDetails. The information returns from the config file provided.
@@ -93,9 +131,31 @@ func (gc *GradleCommand) init() (vConfig *viper.Viper, err error) {
 		err = errorutils.CheckErrorf("Conditional upload can only be performed if deployer is set in the config")
 		return
 	}
+	if gc.dryRun {
+		if gc.IsXrayScan() {
+			err = errorutils.CheckErrorf("the --dry-run and --scan options can't be used together")
+			return
+		}
+		if !vConfig.IsSet("deployer") {
+			err = errorutils.CheckErrorf("a deployment plan can only be printed if deployer is set in the config")
+			return
+		}
+	}
+	if err = gc.validateSkipIdentical(); err != nil {
+		return
+	}
+	if err = gc.validatePublishBuildInfo(); err != nil {
+		return
+	}
+	if gc.moduleRepos, err = readModuleRepos(vConfig); err != nil {
+		return
+	}
+	if err = gc.validateModuleRepos(); err != nil {
+		return
+	}
 	// Gradle extractor is needed to run, in order to get the details of the build's artifacts.
 	// Gradle's extractor deploy build artifacts. This should be disabled since there is no intent to deploy anything or deploy upon Xray scan results.
-	gc.deploymentDisabled = gc.IsXrayScan() || !vConfig.IsSet("deployer")
+	gc.deploymentDisabled = gc.IsXrayScan() || gc.dryRun || !vConfig.IsSet("deployer")
 	if gc.shouldCreateBuildArtifactsFile() {
 		// Created a file that will contain all the details about the build's artifacts
 		tempFile, err := fileutils.CreateTempFile()
@@ -112,10 +172,15 @@ func (gc *GradleCommand) init() (vConfig *viper.Viper, err error) {
 }
 
 // Gradle extractor generates the details of the build's artifacts.
-// This is required for Xray scan and for the detailed summary.
-// We can either scan or print the generated artifacts.
+// This is required for Xray scan, for the detailed summary and for the job summary.
+// We can either scan, print the generated artifacts or print the deployment plan of a dry run.
+func (gc *GradleCommand) isBuildArtifactsDetailsRequired() bool {
+	return ((gc.IsDetailedSummary() || jobsummary.IsEnabled()) && !gc.deploymentDisabled) || gc.IsXrayScan() || gc.dryRun
+}
+
+// When deploying, the file is created anyway, so that a failed deployment can be resumed.
 func (gc *GradleCommand) shouldCreateBuildArtifactsFile() bool {
-	return (gc.IsDetailedSummary() && !gc.deploymentDisabled) || gc.IsXrayScan()
+	return gc.isBuildArtifactsDetailsRequired() || !gc.deploymentDisabled
 }
 
 func (gc *GradleCommand) Run() error {
@@ -123,15 +188,52 @@ func (gc *GradleCommand) Run() error {
 		return gc.runWithGradleNative()
 	}
 
+	if gc.resumeFrom != "" {
+		return gc.resumeDeployment()
+	}
 	vConfig, err := gc.init()
 	if err != nil {
 		return err
 	}
-	err = runGradle(vConfig, gc.tasks, gc.buildArtifactsDetailsFile, gc.configuration, gc.threads, gc.IsXrayScan())
+	ctx, cancel := artifactoryutils.NewBuildContext(gc.ctx, gc.timeout)
+	defer cancel()
+	gradleArgs, err := gc.gradleArgs()
 	if err != nil {
 		return err
 	}
-	if gc.buildArtifactsDetailsFile != "" {
+	buildScanUrl, err := runGradle(ctx, vConfig, formatExtractorArgs(gradleArgs), gc.buildArtifactsDetailsFile, gc.configuration, gc.threads, gc.IsXrayScan() || gc.dryRun || gc.skipIdentical || len(gc.moduleRepos) > 0, gc.extractorPath, gc.publications, gc.targetProps, gc.retries)
+	if err != nil {
+		gc.saveIncompleteDeployment()
+		if ctx.Err() != nil {
+			err = errors.Join(err, artifactoryutils.SaveStoppedBuildInfo(gc.configuration, err))
+		}
+		return err
+	}
+	if buildScanUrl != "" {
+		log.Info("Build Scan:", buildScanUrl)
+		jobsummary.RecordBuildScan(buildScanUrl)
+	}
+	if len(gc.moduleRepos) > 0 && gc.buildArtifactsDetailsFile != "" {
+		if err = gc.routeDeployableArtifacts(); err != nil {
+			return err
+		}
+	}
+	if gc.skipIdentical && !gc.deploymentDisabled {
+		if err = gc.deploySkippingIdentical(vConfig); err != nil {
+			return err
+		}
+	} else if len(gc.moduleRepos) > 0 && !gc.deploymentDisabled {
+		if err = gc.deployRoutedArtifacts(vConfig); err != nil {
+			return err
+		}
+	}
+	if gc.buildArtifactsDetailsFile != "" && gc.isBuildArtifactsDetailsRequired() {
+		if gc.isJsonDetailedSummary() {
+			gc.deploymentSummary, err = createDeploymentSummary(gc.buildArtifactsDetailsFile, vConfig.GetString(build.DeployerPrefix+build.Repo))
+			if err != nil {
+				return err
+			}
+		}
 		err = gc.unmarshalDeployableArtifacts(gc.buildArtifactsDetailsFile)
 		if err != nil {
 			return err
@@ -139,12 +241,23 @@ func (gc *GradleCommand) Run() error {
 		if gc.IsXrayScan() {
 			return gc.conditionalUpload()
 		}
+		if gc.dryRun {
+			return gc.printDeploymentPlan()
+		}
+		jobsummary.RecordTransferDetails("Gradle artifacts deployed to Artifactory", gc.result.Reader())
+		// Without a detailed summary, the reader was only created for the job summary.
+		if !gc.IsDetailedSummary() {
+			return gc.result.Reader().Close()
+		}
+		if gc.deploymentSummary != nil {
+			return gc.printDeploymentSummary()
+		}
 	}
 	return nil
 }
 
 func (gc *GradleCommand) unmarshalDeployableArtifacts(filesPath string) error {
-	result, err := commandsutils.UnmarshalDeployableArtifacts(filesPath, gc.configPath, gc.IsXrayScan())
+	result, err := commandsutils.UnmarshalDeployableArtifacts(filesPath, gc.configPath, gc.IsXrayScan() || gc.dryRun)
 	if err != nil {
 		return err
 	}
@@ -155,6 +268,26 @@ func (gc *GradleCommand) unmarshalDeployableArtifacts(filesPath string) error {
 // runWithGradleNative executes Gradle using FlexPack for dependency resolution and build info collection
 func (gc *GradleCommand) runWithGradleNative() error {
 	log.Debug("Gradle native implementation activated")
+	if !gc.publications.isEmpty() {
+		log.Warn("The --include-publications and --exclude-publications options are applied by the Gradle build-info extractor, and are ignored by the native Gradle implementation.")
+	}
+	if gc.targetProps != "" {
+		log.Warn("The --target-props option is applied by the Gradle build-info extractor, and is ignored by the native Gradle implementation.")
+	}
+	if gc.resumeFrom != "" {
+		return errorutils.CheckErrorf("the --resume-from option isn't supported by the native Gradle implementation")
+	}
+	if gc.dryRun {
+		return errorutils.CheckErrorf("the --dry-run option isn't supported by the native Gradle implementation")
+	}
+	if gc.skipIdentical {
+		return errorutils.CheckErrorf("the --skip-identical option isn't supported by the native Gradle implementation")
+	}
+
+	gradleArgs, err := gc.gradleArgs()
+	if err != nil {
+		return err
+	}
 
 	// Get working directory - default to current directory
 	workingDir, err := os.Getwd()
@@ -164,7 +297,7 @@ func (gc *GradleCommand) runWithGradleNative() error {
 
 	// Check if a build file is specified via -b or --build-file flag
 	flexpackWorkingDir := workingDir
-	if buildFilePath := extractBuildFilePath(gc.tasks); buildFilePath != "" {
+	if buildFilePath := extractBuildFilePath(gradleArgs); buildFilePath != "" {
 		buildFileDir := filepath.Dir(buildFilePath)
 		if filepath.IsAbs(buildFileDir) {
 			flexpackWorkingDir = buildFileDir
@@ -179,10 +312,15 @@ func (gc *GradleCommand) runWithGradleNative() error {
 		return fmt.Errorf("failed to find Gradle executable: %w", err)
 	}
 
-	cmd := exec.Command(gradleExecPath, gc.tasks...)
+	ctx, cancel := artifactoryutils.NewBuildContext(gc.ctx, gc.timeout)
+	defer cancel()
+	cmd := exec.Command(gradleExecPath, gradleArgs...)
 	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-	if err = cmd.Run(); err != nil {
+	if err = artifactoryutils.RunWithContext(ctx, cmd.Run); err != nil {
 		log.Error("Failed to execute Gradle command: " + err.Error())
+		if ctx.Err() != nil {
+			return errors.Join(err, artifactoryutils.SaveStoppedBuildInfo(gc.configuration, err))
+		}
 		return errorutils.CheckError(err)
 	}
 
@@ -199,7 +337,8 @@ func (gc *GradleCommand) runWithGradleNative() error {
 			}
 
 			// Call FlexPack collection using the flexpack working directory
-			if err := flexpackgradle.CollectGradleBuildInfoWithFlexPack(flexpackWorkingDir, buildName, buildNumber, gc.tasks, gc.configuration, gc.serverDetails); err != nil {
+			collectOptions := flexpackgradle.GradleCollectOptions{IncludeCompositeBuilds: gc.includeCompositeBuilds, DependencyGraph: gc.dependencyGraph}
+			if err := flexpackgradle.CollectGradleBuildInfoWithOptions(flexpackWorkingDir, buildName, buildNumber, gradleArgs, gc.configuration, gc.serverDetails, collectOptions); err != nil {
 				log.Warn("Failed to collect Gradle build info with Flexpack:")
 			}
 		}
@@ -248,7 +387,8 @@ func (gc *GradleCommand) conditionalUpload() error {
 	if err != nil {
 		return err
 	}
-	binariesSpecFile, pomSpecFile, err := commandsutils.ScanDeployableArtifacts(gc.result, gc.serverDetails, gc.threads, gc.scanOutputFormat)
+	specFiles, err := scanDeployableArtifacts(gc.result, gc.serverDetails, gc.threads, gc.scanOutputFormat)
+	jobsummary.RecordScanGate("the Gradle build artifacts", err)
 	// If the detailed summary wasn't requested, the reader should be closed here.
 	// (otherwise it will be closed by the detailed summary print method)
 	if !gc.detailedSummary {
@@ -260,30 +400,32 @@ func (gc *GradleCommand) conditionalUpload() error {
 		gc.result.Reader().Reset()
 	}
 	if err != nil {
+		if gc.publishBuildInfo && isGateFailure(err) {
+			return errors.Join(err, gc.publishGatedBuildInfo(err))
+		}
 		return err
 	}
-	// The case scan failed
-	if binariesSpecFile == nil {
-		return nil
-	}
-	// First upload binaries
-	if len(binariesSpecFile.Files) > 0 {
+	// First upload binaries, then the Gradle Module Metadata files and the POMs, and only then publish the build-info, so that
+	// a published build-info which passed the scan always refers to deployed artifacts.
+	for _, specFile := range specFiles.inDeploymentOrder() {
+		if len(specFile.Files) == 0 {
+			continue
+		}
 		uploadCmd := generic.NewUploadCommand()
 		uploadConfiguration := new(utils.UploadConfiguration)
 		uploadConfiguration.Threads = gc.threads
-		uploadCmd.SetUploadConfiguration(uploadConfiguration).SetBuildConfiguration(gc.configuration).SetSpec(binariesSpecFile).SetServerDetails(gc.serverDetails)
-		err = uploadCmd.Run()
-		if err != nil {
+		uploadCmd.SetUploadConfiguration(uploadConfiguration).SetBuildConfiguration(gc.configuration).SetSpec(specFile).SetServerDetails(gc.serverDetails)
+		if err = uploadCmd.Run(); err != nil {
 			return err
 		}
+		if failCount := uploadCmd.Result().FailCount(); failCount > 0 && gc.publishBuildInfo {
+			return errorutils.CheckErrorf("failed to deploy %d of the Gradle build artifacts. The build-info wasn't published", failCount)
+		}
 	}
-	if len(pomSpecFile.Files) > 0 {
-		// Then Upload pom.xml's
-		uploadCmd := generic.NewUploadCommand()
-		uploadCmd.SetBuildConfiguration(gc.configuration).SetSpec(pomSpecFile).SetServerDetails(gc.serverDetails)
-		err = uploadCmd.Run()
+	if gc.publishBuildInfo {
+		return gc.publishGatedBuildInfo(nil)
 	}
-	return err
+	return nil
 }
 
 func (gc *GradleCommand) CommandName() string {
@@ -305,6 +447,11 @@ func (gc *GradleCommand) SetTasks(tasks []string) *GradleCommand {
 	return gc
 }
 
+func (gc *GradleCommand) SetPassthroughArgs(passthroughArgs []string) *GradleCommand {
+	gc.passthroughArgs = passthroughArgs
+	return gc
+}
+
 func (gc *GradleCommand) SetThreads(threads int) *GradleCommand {
 	gc.threads = threads
 	return gc
@@ -319,6 +466,63 @@ func (gc *GradleCommand) IsDetailedSummary() bool {
 	return gc.detailedSummary
 }
 
+func (gc *GradleCommand) SetIncludeCompositeBuilds(includeCompositeBuilds bool) *GradleCommand {
+	gc.includeCompositeBuilds = includeCompositeBuilds
+	return gc
+}
+
+func (gc *GradleCommand) SetDependencyGraph(dependencyGraph bool) *GradleCommand {
+	gc.dependencyGraph = dependencyGraph
+	return gc
+}
+
+func (gc *GradleCommand) SetExtractorPath(extractorPath string) *GradleCommand {
+	gc.extractorPath = extractorPath
+	return gc
+}
+
+// SetIncludePublications sets the names or wildcard patterns of the publications to deploy. If empty, all the publications are deployed.
+func (gc *GradleCommand) SetIncludePublications(includePublications []string) *GradleCommand {
+	gc.publications.include = includePublications
+	return gc
+}
+
+// SetExcludePublications sets the names or wildcard patterns of the publications not to deploy.
+func (gc *GradleCommand) SetExcludePublications(excludePublications []string) *GradleCommand {
+	gc.publications.exclude = excludePublications
+	return gc
+}
+
+// SetDryRun runs the build without deploying its artifacts, and prints the artifacts that would have been deployed,
+// in the format set by SetScanOutputFormat.
+func (gc *GradleCommand) SetDryRun(dryRun bool) *GradleCommand {
+	gc.dryRun = dryRun
+	return gc
+}
+
+// SetTargetProps sets the properties to attach to the deployed artifacts, in the form of "key1=value1;key2=value2;...".
+// They override the values of the same keys in the deployer.props of the Gradle configuration.
+func (gc *GradleCommand) SetTargetProps(targetProps string) *GradleCommand {
+	gc.targetProps = targetProps
+	return gc
+}
+
+func (gc *GradleCommand) IsDryRun() bool {
+	return gc.dryRun
+}
+
+// SetContext sets the context of the build. The build is stopped, and its processes are terminated, when the context is canceled.
+func (gc *GradleCommand) SetContext(ctx context.Context) *GradleCommand {
+	gc.ctx = ctx
+	return gc
+}
+
+// SetTimeout sets the duration after which the build is stopped. The build-info collected until then is kept.
+func (gc *GradleCommand) SetTimeout(timeout time.Duration) *GradleCommand {
+	gc.timeout = timeout
+	return gc
+}
+
 func (gc *GradleCommand) SetXrayScan(xrayScan bool) *GradleCommand {
 	gc.xrayScan = xrayScan
 	return gc
@@ -328,6 +532,8 @@ func (gc *GradleCommand) IsXrayScan() bool {
 	return gc.xrayScan
 }
 
+// SetScanOutputFormat sets the format of the scan results and the dry run deployment plan. Without a scan or a dry run,
+// the JSON format prints the detailed summary as a JSON document, which is also available by DeploymentSummary.
 func (gc *GradleCommand) SetScanOutputFormat(format format.OutputFormat) *GradleCommand {
 	gc.scanOutputFormat = format
 	return gc
@@ -352,25 +558,97 @@ func (gc *GradleCommand) getBuildNameAndNumber() (buildName, buildNumber string,
 	return
 }
 
-type InitScriptAuthConfig struct {
+// InitScriptRepoScope is the kind of artifacts a repository of the init script resolves.
+type InitScriptRepoScope string
+
+const (
+	RepoScopeAll          InitScriptRepoScope = ""
+	RepoScopePlugins      InitScriptRepoScope = "plugins"
+	RepoScopeDependencies InitScriptRepoScope = "dependencies"
+)
+
+// InitScriptRepository is a resolution repository of the init script.
+type InitScriptRepository struct {
+	RepoName string
+	// The repository resolves the Gradle plugins, the project dependencies, or both if empty.
+	Scope InitScriptRepoScope
+	// The URL and credentials of the Artifactory server of the repository, if it's not the resolver's server.
+	// If ArtifactoryURL is empty, the resolver's URL and credentials are used.
 	ArtifactoryURL         string
-	GradleRepoName         string
 	ArtifactoryUsername    string
 	ArtifactoryAccessToken string
 }
 
+type InitScriptAuthConfig struct {
+	ArtifactoryURL string
+	// The repository which resolves both the Gradle plugins and the project dependencies. Ignored if Repositories is set.
+	GradleRepoName string
+	// The resolution repositories, in the order Gradle searches them. The repositories which resolve plugins are added
+	// to the pluginManagement repositories, before the Gradle Plugin Portal.
+	Repositories           []InitScriptRepository
+	ArtifactoryUsername    string
+	ArtifactoryAccessToken string
+	// The deployer configuration is used by the publishing repository of projects applying the maven-publish plugin.
+	// If DeployerURL is empty, the resolver's URL and credentials are used. If GradleDeployRepoName is empty,
+	// the projects publish to the resolution repository.
+	DeployerURL          string
+	GradleDeployRepoName string
+	DeployerUsername     string
+	DeployerAccessToken  string
+	// If BuildCacheRepoName is set, the remote HTTP build cache stores the task outputs in this generic repository,
+	// using the resolver's credentials. Pushing to the cache is usually enabled on CI only.
+	BuildCacheRepoName string
+	BuildCachePush     bool
+	// If Mirror is set, the repositories declared by the build are removed, so that the resolution repositories mirror
+	// all of them, as a Maven mirrorOf "*". The Gradle Plugin Portal isn't used as a fallback for the plugins either.
+	Mirror bool
+	// The language of the init script. Groovy is used if empty.
+	Dsl InitScriptDsl
+}
+
 // GenerateInitScript generates a Gradle init script with the provided authentication configuration.
 func GenerateInitScript(config InitScriptAuthConfig) (string, error) {
-	tmpl, err := template.New("gradleTemplate").Parse(gradleInitScript)
+	initScriptTemplate := gradleInitScript
+	switch config.Dsl {
+	case "", GroovyDsl:
+	case KotlinDsl:
+		initScriptTemplate = kotlinGradleInitScript
+	default:
+		return "", fmt.Errorf("unsupported Gradle init script DSL: %s. The supported DSLs are %s and %s", config.Dsl, GroovyDsl, KotlinDsl)
+	}
+	tmpl, err := template.New("gradleTemplate").Funcs(template.FuncMap{"kotlinString": kotlinString}).Parse(initScriptTemplate)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse Gradle init script template: %s", err)
 	}
 
 	// Remove possible trailing slashes from the Artifactory URL to avoid double slashes in the generated script
 	config.ArtifactoryURL = strings.TrimSuffix(config.ArtifactoryURL, "/")
+	templateData := initScriptTemplateData{InitScriptAuthConfig: config}
+	templateData.PluginRepos, templateData.DependencyRepos = config.resolutionRepos()
+	if len(templateData.PluginRepos) == 0 && len(templateData.DependencyRepos) == 0 {
+		return "", fmt.Errorf("no Gradle resolution repository was provided")
+	}
+	if templateData.GradleRepoName == "" && len(templateData.DependencyRepos) > 0 {
+		templateData.GradleRepoName = templateData.DependencyRepos[0].RepoName
+	}
+	if templateData.DeployerURL == "" {
+		// The projects publish to the server of the resolution repository they publish to by default.
+		deployServer := initScriptRepo{URL: templateData.ArtifactoryURL, Username: templateData.ArtifactoryUsername, AccessToken: templateData.ArtifactoryAccessToken}
+		if templateData.GradleDeployRepoName == "" && len(templateData.DependencyRepos) > 0 && templateData.DependencyRepos[0].RepoName == templateData.GradleRepoName {
+			deployServer = templateData.DependencyRepos[0]
+		}
+		templateData.DeployerURL = deployServer.URL
+		if templateData.DeployerUsername == "" && templateData.DeployerAccessToken == "" {
+			templateData.DeployerUsername, templateData.DeployerAccessToken = deployServer.Username, deployServer.AccessToken
+		}
+	}
+	templateData.DeployerURL = strings.TrimSuffix(templateData.DeployerURL, "/")
+	if templateData.GradleDeployRepoName == "" {
+		templateData.GradleDeployRepoName = templateData.GradleRepoName
+	}
 	var result strings.Builder
 	// Create a string from the template with the provided configuration
-	err = tmpl.Execute(&result, config)
+	err = tmpl.Execute(&result, templateData)
 	if err != nil {
 		return "", fmt.Errorf("failed to write auth configuration into the init script template: %s", err)
 	}
@@ -378,11 +656,85 @@ func GenerateInitScript(config InitScriptAuthConfig) (string, error) {
 	return result.String(), nil
 }
 
+type initScriptTemplateData struct {
+	InitScriptAuthConfig
+	PluginRepos     []initScriptRepo
+	DependencyRepos []initScriptRepo
+}
+
+// initScriptRepo is a Maven repository of the init script.
+type initScriptRepo struct {
+	// The name of the Maven repository in the build, which is unique.
+	Name        string
+	RepoName    string
+	URL         string
+	Username    string
+	AccessToken string
+}
+
+// resolutionRepos returns the repositories which resolve the Gradle plugins and the ones which resolve the project dependencies, in order.
+func (config InitScriptAuthConfig) resolutionRepos() (pluginRepos, dependencyRepos []initScriptRepo) {
+	repositories := config.Repositories
+	if len(repositories) == 0 && config.GradleRepoName != "" {
+		repositories = []InitScriptRepository{{RepoName: config.GradleRepoName}}
+	}
+	for _, repository := range repositories {
+		repo := initScriptRepo{RepoName: repository.RepoName, URL: config.ArtifactoryURL, Username: config.ArtifactoryUsername, AccessToken: config.ArtifactoryAccessToken}
+		if repository.ArtifactoryURL != "" {
+			repo.URL = strings.TrimSuffix(repository.ArtifactoryURL, "/")
+			repo.Username, repo.AccessToken = repository.ArtifactoryUsername, repository.ArtifactoryAccessToken
+		}
+		if repository.Scope != RepoScopeDependencies {
+			pluginRepos = append(pluginRepos, repo)
+		}
+		if repository.Scope != RepoScopePlugins {
+			dependencyRepos = append(dependencyRepos, repo)
+		}
+	}
+	setInitScriptRepoNames(pluginRepos)
+	setInitScriptRepoNames(dependencyRepos)
+	return
+}
+
+// setInitScriptRepoNames sets the names of the Maven repositories, which must be unique. The same repository name may be used
+// by different servers, so a counter is added to the names which are already taken.
+func setInitScriptRepoNames(repos []initScriptRepo) {
+	names := make(map[string]bool, len(repos))
+	for i := range repos {
+		name := "Artifactory"
+		if i > 0 {
+			name += "-" + repos[i].RepoName
+		}
+		for count := 2; names[name]; count++ {
+			name = fmt.Sprintf("Artifactory-%s-%d", repos[i].RepoName, count)
+		}
+		names[name] = true
+		repos[i].Name = name
+	}
+}
+
 // WriteInitScript writes the Gradle init script to the Gradle user home `init.d` directory,
 // which stores initialization scripts. The final path should be `$GRADLE_USER_HOME/init.d/jfrog.init.gradle`.
+// The script is written as a managed block, so the content the user added around it is kept.
 // More info on how Gradle invokes these init scripts can be found here:
 // https://docs.gradle.org/current/userguide/init_scripts.html#sec:using_an_init_script
 func WriteInitScript(initScript string) error {
+	return WriteInitScriptWithDsl(initScript, GroovyDsl)
+}
+
+// WriteInitScriptWithDsl writes the Gradle init script of the DSL to the Gradle user home `init.d` directory.
+// The file name of a Kotlin script is `jfrog.init.gradle.kts`.
+func WriteInitScriptWithDsl(initScript string, dsl InitScriptDsl) error {
+	initScriptsDir := UserInitScriptsDir()
+	if err := os.MkdirAll(initScriptsDir, 0755); err != nil { // #nosec G703 -- path sanitized with filepath.Clean
+		return fmt.Errorf("failed to create Gradle init.d directory: %w", err)
+	}
+	_, err := writeInitScriptToDir(initScriptsDir, initScript, dsl)
+	return err
+}
+
+// UserInitScriptsDir returns the `init.d` directory of the Gradle user home, which Gradle loads the init scripts from.
+func UserInitScriptsDir() string {
 	gradleHome := os.Getenv(UserHomeEnv)
 	if gradleHome == "" {
 		// Try Java's user.home first (fixes container issue where $HOME != user.home)
@@ -396,16 +748,7 @@ func WriteInitScript(initScript string) error {
 	}
 	// Sanitize the path to prevent directory traversal attacks
 	gradleHome = filepath.Clean(gradleHome)
-
-	initScriptsDir := filepath.Clean(filepath.Join(gradleHome, "init.d"))
-	if err := os.MkdirAll(initScriptsDir, 0755); err != nil { // #nosec G703 -- path sanitized with filepath.Clean
-		return fmt.Errorf("failed to create Gradle init.d directory: %w", err)
-	}
-	jfrogInitScriptPath := filepath.Clean(filepath.Join(initScriptsDir, InitScriptName))
-	if err := os.WriteFile(jfrogInitScriptPath, []byte(initScript), 0644); err != nil { // #nosec G703 -- path sanitized with filepath.Clean
-		return fmt.Errorf("failed to write Gradle init script to %s: %w", jfrogInitScriptPath, err)
-	}
-	return nil
+	return filepath.Clean(filepath.Join(gradleHome, "init.d"))
 }
 
 // GetJavaUserHome queries Java for its user.home system property.
@@ -434,45 +777,59 @@ func parseUserHomeFromJavaOutput(output string) (string, error) {
 	return "", fmt.Errorf("user.home not found in java output")
 }
 
-func runGradle(vConfig *viper.Viper, tasks []string, deployableArtifactsFile string, configuration *build.BuildConfiguration, threads int, disableDeploy bool) error {
+// runGradle runs the build with the build-info extractor. When the build-info is collected, the URL of the Build Scan the build
+// published is returned, and added to the build-info properties.
+func runGradle(ctx context.Context, vConfig *viper.Viper, tasks []string, deployableArtifactsFile string, configuration *build.BuildConfiguration, threads int, disableDeploy bool, extractorPath string, publications publicationFilter, targetProps string, retries int) (buildScanUrl string, err error) {
 	buildInfoService := build.CreateBuildInfoService()
 	buildName, err := configuration.GetBuildName()
 	if err != nil {
-		return err
+		return
 	}
 	buildNumber, err := configuration.GetBuildNumber()
 	if err != nil {
-		return err
+		return
 	}
 	gradleBuild, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, configuration.GetProject())
 	if err != nil {
-		return errorutils.CheckError(err)
+		err = errorutils.CheckError(err)
+		return
 	}
 	gradleModule, err := gradleBuild.AddGradleModule("")
 	if err != nil {
-		return errorutils.CheckError(err)
+		err = errorutils.CheckError(err)
+		return
 	}
-	props, wrapper, plugin, err := createGradleRunConfig(vConfig, deployableArtifactsFile, threads, disableDeploy)
+	props, wrapper, plugin, err := createGradleRunConfig(vConfig, deployableArtifactsFile, threads, disableDeploy, publications, targetProps, retries)
 	if err != nil {
-		return err
+		return
 	}
-	dependencyLocalPath, err := getGradleDependencyLocalPath()
+	dependencyLocalPath, downloadExtractor, err := getGradleDependencyLocalPath(extractorPath)
 	if err != nil {
-		return err
+		return
 	}
-	gradleModule.SetExtractorDetails(dependencyLocalPath, filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.PropertiesTempPath), tasks, wrapper, plugin, dependencies.DownloadExtractor, props)
-	return coreutils.ConvertExitCodeError(gradleModule.CalcDependencies())
-}
-
-func getGradleDependencyLocalPath() (string, error) {
-	dependenciesPath, err := config.GetJfrogDependenciesPath()
+	gradleModule.SetExtractorDetails(dependencyLocalPath, filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.PropertiesTempPath), tasks, wrapper, plugin, downloadExtractor, props)
+	isCollect, err := configuration.IsCollectBuildInfo()
 	if err != nil {
-		return "", err
+		return
 	}
-	return filepath.Join(dependenciesPath, "gradle"), nil
+	runBuild := func() error {
+		return artifactoryutils.RunWithContext(ctx, gradleModule.CalcDependencies)
+	}
+	if !isCollect {
+		err = coreutils.ConvertExitCodeError(runBuild())
+		return
+	}
+	if buildScanUrl, err = captureBuildScanUrl(runBuild); err != nil {
+		err = coreutils.ConvertExitCodeError(err)
+		return
+	}
+	if buildScanUrl != "" {
+		err = errorutils.CheckError(gradleBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: buildinfo.Env{BuildScanUrlProp: buildScanUrl}}))
+	}
+	return
 }
 
-func createGradleRunConfig(vConfig *viper.Viper, deployableArtifactsFile string, threads int, disableDeploy bool) (props map[string]string, wrapper, plugin bool, err error) {
+func createGradleRunConfig(vConfig *viper.Viper, deployableArtifactsFile string, threads int, disableDeploy bool, publications publicationFilter, targetProps string, retries int) (props map[string]string, wrapper, plugin bool, err error) {
 	wrapper = vConfig.GetBool(useWrapper)
 	if threads > 0 {
 		vConfig.Set(build.ForkCount, threads)
@@ -493,6 +850,11 @@ func createGradleRunConfig(vConfig *viper.Viper, deployableArtifactsFile string,
 		// Save the path to a temp file, where buildinfo project will write the deployable artifacts details.
 		props[build.DeployableArtifacts] = fmt.Sprint(vConfig.Get(build.DeployableArtifacts))
 	}
+	publications.setProps(props)
+	if err = setTargetProps(props, vConfig, targetProps); err != nil {
+		return
+	}
+	setRetriesProp(props, retries)
 	plugin = vConfig.GetBool(usePlugin)
 	return
 }
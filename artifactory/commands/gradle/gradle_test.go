@@ -52,6 +52,65 @@ func TestGenerateInitScript(t *testing.T) {
 	assert.Contains(t, script, "gradleVersion >= GradleVersion.version")
 }
 
+func TestGenerateInitScriptWithDeployer(t *testing.T) {
+	// The deployer defaults to the resolver.
+	script, err := GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL:         "https://example.com/artifactory/",
+		GradleRepoName:         "gradle-virtual",
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "token",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def deployerUrl = 'https://example.com/artifactory'")
+	assert.Contains(t, script, "def gradleDeployRepoName = 'gradle-virtual'")
+	assert.Contains(t, script, "def deployerUsername = 'user'")
+
+	script, err = GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL:         "https://example.com/artifactory",
+		GradleRepoName:         "gradle-virtual",
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "token",
+		DeployerURL:            "http://deploy.example.com/artifactory/",
+		GradleDeployRepoName:   "gradle-local",
+		DeployerUsername:       "deployer",
+		DeployerAccessToken:    "deployer-token",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def gradleRepoName = 'gradle-virtual'")
+	assert.Contains(t, script, "def deployerUrl = 'http://deploy.example.com/artifactory'")
+	assert.Contains(t, script, "def gradleDeployRepoName = 'gradle-local'")
+	assert.Contains(t, script, "def deployerUsername = 'deployer'")
+	assert.Contains(t, script, "def deployerAccessToken = 'deployer-token'")
+	assert.Contains(t, script, `url = uri("${deployerUrl}/${gradleDeployRepoName}")`)
+}
+
+func TestGenerateKotlinInitScript(t *testing.T) {
+	script, err := GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL:         "https://example.com/artifactory/",
+		Repositories:           []InitScriptRepository{{RepoName: "gradle-plugins", Scope: RepoScopePlugins}, {RepoName: "gradle-virtual"}},
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "pa$sword",
+		BuildCacheRepoName:     "gradle-cache",
+		Dsl:                    KotlinDsl,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, script, `val artifactoryUrl = "https://example.com/artifactory"`)
+	assert.Contains(t, script, `val pluginRepos = listOf<ArtifactoryRepo>(ArtifactoryRepo("Artifactory", "gradle-plugins", "https://example.com/artifactory", "user", "pa\$sword"), ArtifactoryRepo("Artifactory-gradle-virtual", "gradle-virtual", "https://example.com/artifactory", "user", "pa\$sword"))`)
+	assert.Contains(t, script, `val dependencyRepos = listOf<ArtifactoryRepo>(ArtifactoryRepo("Artifactory", "gradle-virtual", "https://example.com/artifactory", "user", "pa\$sword"))`)
+	assert.Contains(t, script, "val mirror = false")
+	assert.Contains(t, script, `val artifactoryAccessToken = "pa\$sword"`)
+	assert.Contains(t, script, `val deployerUrl = "https://example.com/artifactory"`)
+	assert.Contains(t, script, `val gradleDeployRepoName = "gradle-virtual"`)
+	assert.Contains(t, script, `val buildCacheRepoName = "gradle-cache"`)
+	assert.Contains(t, script, "remote(HttpBuildCache::class)")
+	assert.Contains(t, script, "configure<PublishingExtension>")
+	assert.Contains(t, script, "isAllowInsecureProtocol = true")
+	assert.NotContains(t, script, "def ")
+
+	_, err = GenerateInitScript(InitScriptAuthConfig{ArtifactoryURL: "https://example.com/artifactory", GradleRepoName: "gradle-virtual", Dsl: "scala"})
+	assert.ErrorContains(t, err, "unsupported Gradle init script DSL")
+}
+
 func TestWriteInitScript(t *testing.T) {
 	// Set up a temporary directory for testing
 	tempDir := t.TempDir()
@@ -66,7 +125,7 @@ func TestWriteInitScript(t *testing.T) {
 	expectedPath := filepath.Join(tempDir, "init.d", InitScriptName)
 	content, err := os.ReadFile(expectedPath)
 	assert.NoError(t, err)
-	assert.Equal(t, initScript, string(content))
+	assert.Equal(t, managedInitScript(initScript), string(content))
 }
 
 // TestExtractBuildFilePath tests extraction of build file path from Gradle arguments
@@ -276,7 +335,7 @@ func TestWriteInitScriptUsesJavaUserHome(t *testing.T) {
 	// Should exist in Java user.home (the correct location)
 	content, err := os.ReadFile(expectedPath)
 	assert.NoError(t, err, "Init script should be written to Java user.home: %s", expectedPath)
-	assert.Equal(t, initScript, string(content))
+	assert.Equal(t, managedInitScript(initScript), string(content))
 
 	// Should NOT exist in fake $HOME (this was the bug!)
 	_, err = os.Stat(wrongPath)
@@ -315,7 +374,7 @@ func TestWriteInitScriptFallsBackToHome(t *testing.T) {
 	fallbackPath := filepath.Join(tempDir, ".gradle", "init.d", InitScriptName)
 	content, err := os.ReadFile(fallbackPath)
 	assert.NoError(t, err, "Init script should be written to $HOME fallback: %s", fallbackPath)
-	assert.Equal(t, initScript, string(content))
+	assert.Equal(t, managedInitScript(initScript), string(content))
 }
 
 // TestExtractBuildFilePathWindowsPaths tests Windows-style paths if on Windows
@@ -348,3 +407,91 @@ func TestExtractBuildFilePathWindowsPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateInitScriptWithRepositories(t *testing.T) {
+	script, err := GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL: "https://example.com/artifactory",
+		Repositories: []InitScriptRepository{
+			{RepoName: "gradle-plugins-remote", Scope: RepoScopePlugins},
+			{RepoName: "libs-release", Scope: RepoScopeDependencies},
+			{RepoName: "libs-snapshot"},
+		},
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "token",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def pluginRepos = [[name: 'Artifactory', repoName: 'gradle-plugins-remote', url: 'https://example.com/artifactory', username: 'user', password: 'token'], "+
+		"[name: 'Artifactory-libs-snapshot', repoName: 'libs-snapshot', url: 'https://example.com/artifactory', username: 'user', password: 'token']]")
+	assert.Contains(t, script, "def dependencyRepos = [[name: 'Artifactory', repoName: 'libs-release', url: 'https://example.com/artifactory', username: 'user', password: 'token'], "+
+		"[name: 'Artifactory-libs-snapshot', repoName: 'libs-snapshot', url: 'https://example.com/artifactory', username: 'user', password: 'token']]")
+	// The first dependencies repository is the default deployment repository.
+	assert.Contains(t, script, "def gradleRepoName = 'libs-release'")
+	assert.Contains(t, script, "def gradleDeployRepoName = 'libs-release'")
+	assert.Contains(t, script, "configureMavenRepos(it, pluginRepos,")
+	assert.Contains(t, script, "configureMavenRepos(it, dependencyRepos,")
+
+	// GradleRepoName resolves both the plugins and the dependencies.
+	script, err = GenerateInitScript(InitScriptAuthConfig{ArtifactoryURL: "https://example.com/artifactory", GradleRepoName: "gradle-virtual"})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def pluginRepos = [[name: 'Artifactory', repoName: 'gradle-virtual', url: 'https://example.com/artifactory', username: '', password: '']]")
+	assert.Contains(t, script, "def dependencyRepos = [[name: 'Artifactory', repoName: 'gradle-virtual', url: 'https://example.com/artifactory', username: '', password: '']]")
+
+	_, err = GenerateInitScript(InitScriptAuthConfig{ArtifactoryURL: "https://example.com/artifactory"})
+	assert.Error(t, err)
+}
+
+func TestGenerateInitScriptWithMultipleServers(t *testing.T) {
+	script, err := GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL: "https://example.com/artifactory",
+		Repositories: []InitScriptRepository{
+			{RepoName: "libs-release", Scope: RepoScopeDependencies, ArtifactoryURL: "https://other.example.com/artifactory/", ArtifactoryUsername: "other-user", ArtifactoryAccessToken: "other-token"},
+			{RepoName: "libs-release", Scope: RepoScopeDependencies},
+		},
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "token",
+		Mirror:                 true,
+	})
+	assert.NoError(t, err)
+	// The same repository name on different servers gets unique Maven repository names.
+	assert.Contains(t, script, "def dependencyRepos = [[name: 'Artifactory', repoName: 'libs-release', url: 'https://other.example.com/artifactory', username: 'other-user', password: 'other-token'], "+
+		"[name: 'Artifactory-libs-release', repoName: 'libs-release', url: 'https://example.com/artifactory', username: 'user', password: 'token']]")
+	assert.Contains(t, script, "def pluginRepos = []")
+	// The projects publish to the server of the first dependencies repository by default.
+	assert.Contains(t, script, "def deployerUrl = 'https://other.example.com/artifactory'")
+	assert.Contains(t, script, "def deployerUsername = 'other-user'")
+	assert.Contains(t, script, "def gradleDeployRepoName = 'libs-release'")
+	assert.Contains(t, script, "def mirror = true")
+	assert.Contains(t, script, "removeOtherRepos(it, dependencyRepos)")
+}
+
+func TestSetInitScriptRepoNames(t *testing.T) {
+	repos := []initScriptRepo{{RepoName: "libs"}, {RepoName: "libs"}, {RepoName: "libs"}, {RepoName: "plugins"}}
+	setInitScriptRepoNames(repos)
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	assert.Equal(t, []string{"Artifactory", "Artifactory-libs", "Artifactory-libs-2", "Artifactory-plugins"}, names)
+}
+
+func TestGenerateInitScriptWithBuildCache(t *testing.T) {
+	script, err := GenerateInitScript(InitScriptAuthConfig{
+		ArtifactoryURL:         "https://example.com/artifactory/",
+		GradleRepoName:         "gradle-virtual",
+		ArtifactoryUsername:    "user",
+		ArtifactoryAccessToken: "token",
+		BuildCacheRepoName:     "gradle-build-cache",
+		BuildCachePush:         true,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def buildCacheRepoName = 'gradle-build-cache'")
+	assert.Contains(t, script, "def buildCachePush = true")
+	assert.Contains(t, script, "remote(HttpBuildCache)")
+	assert.Contains(t, script, `url = uri("${artifactoryUrl}/${buildCacheRepoName}/")`)
+
+	// Without a build cache repository, the remote build cache isn't configured.
+	script, err = GenerateInitScript(InitScriptAuthConfig{ArtifactoryURL: "https://example.com/artifactory", GradleRepoName: "gradle-virtual"})
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def buildCacheRepoName = ''")
+	assert.Contains(t, script, "def buildCachePush = false")
+}
@@ -0,0 +1,130 @@
+package gradle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The generated init script is written between these markers. The content outside them is kept when the script is written again.
+	initScriptBeginMarker = "// BEGIN JFROG CLI MANAGED BLOCK. The content of this block is overwritten by 'jf setup gradle'."
+	initScriptEndMarker   = "// END JFROG CLI MANAGED BLOCK"
+	// An existing init script, which wasn't written by the JFrog CLI, is backed up to a file with this suffix before it's replaced.
+	InitScriptBackupSuffix = ".bak"
+	// ProjectInitScriptsDir is the directory of the project init scripts, relative to the project root.
+	ProjectInitScriptsDir = "gradle/init.d"
+)
+
+// InitScriptDsl is the language of the Gradle init script.
+type InitScriptDsl string
+
+const (
+	GroovyDsl InitScriptDsl = "groovy"
+	// Some environments only allow Kotlin init scripts. Kotlin init scripts are supported by Gradle 6.0 and above.
+	KotlinDsl InitScriptDsl = "kotlin"
+)
+
+// InitScriptName returns the file name of the init script of the DSL.
+func (dsl InitScriptDsl) InitScriptName() string {
+	if dsl == KotlinDsl {
+		return KotlinInitScriptName
+	}
+	return InitScriptName
+}
+
+// DetectInitScriptDsl returns the DSL of the init script which the JFrog CLI already wrote to the directory, so that the script is
+// written again in the same language. If the directory has no Kotlin init script, Groovy is used.
+func DetectInitScriptDsl(initScriptsDir string) InitScriptDsl {
+	if fileExists(filepath.Join(initScriptsDir, KotlinInitScriptName)) && !fileExists(filepath.Join(initScriptsDir, InitScriptName)) {
+		return KotlinDsl
+	}
+	return GroovyDsl
+}
+
+// ProjectInitScriptsDirPath returns the directory of the init scripts of the project.
+func ProjectInitScriptsDirPath(projectDir string) string {
+	return filepath.Clean(filepath.Join(projectDir, filepath.FromSlash(ProjectInitScriptsDir)))
+}
+
+// WriteProjectInitScript writes the Gradle init script of the DSL to the `gradle/init.d` directory of the project, so that projects
+// using different servers don't overwrite each other's script in the Gradle user home. Gradle doesn't load the project init scripts
+// automatically, so the script should be applied by the --init-script option. The path of the script is returned.
+func WriteProjectInitScript(initScript, projectDir string, dsl InitScriptDsl) (string, error) {
+	initScriptsDir := ProjectInitScriptsDirPath(projectDir)
+	if err := os.MkdirAll(initScriptsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create the Gradle init script directory of the project: %w", err)
+	}
+	return writeInitScriptToDir(initScriptsDir, initScript, dsl)
+}
+
+// writeInitScriptToDir writes the init script of the DSL to the directory, and returns its path.
+// Gradle would load the init scripts of both DSLs, so a warning is logged if the directory also has the script of the other DSL.
+func writeInitScriptToDir(initScriptsDir, initScript string, dsl InitScriptDsl) (string, error) {
+	initScriptPath := filepath.Join(initScriptsDir, dsl.InitScriptName())
+	if err := writeManagedInitScript(initScriptPath, initScript); err != nil {
+		return "", err
+	}
+	otherInitScriptPath := filepath.Join(initScriptsDir, KotlinInitScriptName)
+	if dsl == KotlinDsl {
+		otherInitScriptPath = filepath.Join(initScriptsDir, InitScriptName)
+	}
+	if fileExists(otherInitScriptPath) {
+		log.Warn(fmt.Sprintf("The Gradle init script %s configures Artifactory too, and is applied in addition to %s. Remove it if it was written by a previous 'jf setup gradle'.", otherInitScriptPath, initScriptPath))
+	}
+	return initScriptPath, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// kotlinString quotes the value as a Kotlin string literal, in which '$' would otherwise start a string template.
+func kotlinString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`).Replace(value) + `"`
+}
+
+// writeManagedInitScript writes the init script as the managed block of the file. If the file already has a managed block,
+// only the block is replaced. Otherwise, an existing file is backed up before it's replaced.
+func writeManagedInitScript(initScriptPath, initScript string) error {
+	existing, err := os.ReadFile(initScriptPath) // #nosec G304 -- path sanitized by the callers
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read the Gradle init script %s: %w", initScriptPath, err)
+	}
+	merged, hasManagedBlock := mergeManagedInitScript(string(existing), initScript)
+	if len(existing) > 0 && !hasManagedBlock {
+		backupPath := initScriptPath + InitScriptBackupSuffix
+		if err = os.WriteFile(backupPath, existing, 0644); err != nil { // #nosec G703 -- path sanitized by the callers
+			return fmt.Errorf("failed to back up the Gradle init script to %s: %w", backupPath, err)
+		}
+		log.Warn(fmt.Sprintf("The existing Gradle init script %s was backed up to %s. Content added outside the JFrog CLI managed block is kept when the script is written again.", initScriptPath, backupPath))
+	}
+	if err = os.WriteFile(initScriptPath, []byte(merged), 0644); err != nil { // #nosec G703 -- path sanitized by the callers
+		return fmt.Errorf("failed to write Gradle init script to %s: %w", initScriptPath, err)
+	}
+	return nil
+}
+
+// mergeManagedInitScript replaces the managed block of the existing script with the init script, and keeps the content around it.
+// If the existing script has no managed block, the result is only the managed block, and hasManagedBlock is false.
+func mergeManagedInitScript(existing, initScript string) (merged string, hasManagedBlock bool) {
+	block := initScriptBeginMarker + "\n" + strings.TrimSuffix(initScript, "\n") + "\n" + initScriptEndMarker + "\n"
+	begin := strings.Index(existing, initScriptBeginMarker)
+	if begin < 0 {
+		return block, false
+	}
+	end := strings.Index(existing[begin:], initScriptEndMarker)
+	if end < 0 {
+		return block, false
+	}
+	end += begin + len(initScriptEndMarker)
+	// The new block ends with a line break.
+	if strings.HasPrefix(existing[end:], "\n") {
+		end++
+	}
+	return existing[:begin] + block + existing[end:], true
+}
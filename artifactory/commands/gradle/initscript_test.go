@@ -0,0 +1,93 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// managedInitScript returns the content of a new init script file.
+func managedInitScript(initScript string) string {
+	return initScriptBeginMarker + "\n" + initScript + "\n" + initScriptEndMarker + "\n"
+}
+
+func TestMergeManagedInitScript(t *testing.T) {
+	merged, hasManagedBlock := mergeManagedInitScript("", "new script")
+	assert.False(t, hasManagedBlock)
+	assert.Equal(t, managedInitScript("new script"), merged)
+
+	// The content around the managed block is kept.
+	existing := "// user content before\n" + managedInitScript("old script") + "allprojects { println 'user content after' }\n"
+	merged, hasManagedBlock = mergeManagedInitScript(existing, "new script\n")
+	assert.True(t, hasManagedBlock)
+	assert.Equal(t, "// user content before\n"+managedInitScript("new script")+"allprojects { println 'user content after' }\n", merged)
+
+	// A block without an end marker isn't merged.
+	_, hasManagedBlock = mergeManagedInitScript(initScriptBeginMarker+"\nold script\n", "new script")
+	assert.False(t, hasManagedBlock)
+}
+
+func TestWriteInitScriptBacksUpUserScript(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv(UserHomeEnv, tempDir)
+	initScriptPath := filepath.Join(tempDir, "init.d", InitScriptName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(initScriptPath), 0755))
+	require.NoError(t, os.WriteFile(initScriptPath, []byte("user script"), 0644))
+
+	require.NoError(t, WriteInitScript("first script"))
+	backup, err := os.ReadFile(initScriptPath + InitScriptBackupSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, "user script", string(backup))
+
+	// Add user content after the managed block, and write the script again.
+	content, err := os.ReadFile(initScriptPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(initScriptPath, append(content, []byte("user addition\n")...), 0644))
+	require.NoError(t, os.Remove(initScriptPath+InitScriptBackupSuffix))
+	require.NoError(t, WriteInitScript("second script"))
+
+	content, err = os.ReadFile(initScriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, managedInitScript("second script")+"user addition\n", string(content))
+	// A managed script isn't backed up.
+	assert.NoFileExists(t, initScriptPath+InitScriptBackupSuffix)
+}
+
+func TestWriteProjectInitScript(t *testing.T) {
+	projectDir := t.TempDir()
+	t.Setenv(UserHomeEnv, t.TempDir())
+	initScriptPath, err := WriteProjectInitScript("project script", projectDir, GroovyDsl)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectDir, "gradle", "init.d", InitScriptName), initScriptPath)
+	content, err := os.ReadFile(initScriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, managedInitScript("project script"), string(content))
+}
+
+func TestWriteKotlinInitScript(t *testing.T) {
+	gradleHome := t.TempDir()
+	t.Setenv(UserHomeEnv, gradleHome)
+	initScriptsDir := filepath.Join(gradleHome, "init.d")
+	assert.Equal(t, GroovyDsl, DetectInitScriptDsl(initScriptsDir))
+
+	require.NoError(t, WriteInitScriptWithDsl("kotlin script", KotlinDsl))
+	content, err := os.ReadFile(filepath.Join(initScriptsDir, KotlinInitScriptName))
+	require.NoError(t, err)
+	assert.Equal(t, managedInitScript("kotlin script"), string(content))
+	assert.NoFileExists(t, filepath.Join(initScriptsDir, InitScriptName))
+	// The script is written again in Kotlin.
+	assert.Equal(t, KotlinDsl, DetectInitScriptDsl(initScriptsDir))
+
+	projectDir := t.TempDir()
+	initScriptPath, err := WriteProjectInitScript("kotlin script", projectDir, KotlinDsl)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectDir, "gradle", "init.d", KotlinInitScriptName), initScriptPath)
+}
+
+func TestKotlinString(t *testing.T) {
+	assert.Equal(t, `"token"`, kotlinString("token"))
+	assert.Equal(t, `"pa\$\$w\"o\\rd\n"`, kotlinString("pa$$w\"o\\rd\n"))
+}
@@ -0,0 +1,55 @@
+package gradle
+
+import (
+	"strings"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// deployableSpecFiles are the spec files of the artifacts of a conditional upload, in the order they're deployed.
+// The Gradle Module Metadata files are deployed after the binaries they describe, and before the POMs, since Gradle
+// consumers look for the metadata of the components whose POMs mark them as published with it.
+type deployableSpecFiles struct {
+	binaries       *spec.SpecFiles
+	moduleMetadata *spec.SpecFiles
+	poms           *spec.SpecFiles
+}
+
+func (dsf *deployableSpecFiles) inDeploymentOrder() []*spec.SpecFiles {
+	return []*spec.SpecFiles{dsf.binaries, dsf.moduleMetadata, dsf.poms}
+}
+
+// scanDeployableArtifacts splits the deployable artifacts by their category, and scans the binaries by Xray. The POMs and the
+// Gradle Module Metadata files aren't scanned.
+func scanDeployableArtifacts(deployableArtifacts *commandsutils.Result, serverDetails *config.ServerDetails, threads int, scanOutputFormat format.OutputFormat) (*deployableSpecFiles, error) {
+	specFiles := &deployableSpecFiles{binaries: &spec.SpecFiles{}, moduleMetadata: &spec.SpecFiles{}, poms: &spec.SpecFiles{}}
+	reader := deployableArtifacts.Reader()
+	reader.Reset()
+	for item := new(clientutils.FileTransferDetails); reader.NextRecord(item) == nil; item = new(clientutils.FileTransferDetails) {
+		file := spec.File{Pattern: item.SourcePath, Target: strings.TrimPrefix(item.TargetPath, serverDetails.ArtifactoryUrl)}
+		switch artifactoryutils.GetDeploymentCategory(item.TargetPath) {
+		case artifactoryutils.PomCategory:
+			specFiles.poms.Files = append(specFiles.poms.Files, file)
+		case artifactoryutils.GradleModuleMetadataCategory:
+			specFiles.moduleMetadata.Files = append(specFiles.moduleMetadata.Files, file)
+		default:
+			specFiles.binaries.Files = append(specFiles.binaries.Files, file)
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	if commandsutils.ConditionalUploadScanFunc == nil {
+		return nil, errorutils.CheckErrorf("the Xray scan isn't available in this build of JFrog CLI")
+	}
+	if err := commandsutils.ConditionalUploadScanFunc(serverDetails, specFiles.binaries, threads, scanOutputFormat); err != nil {
+		return nil, err
+	}
+	return specFiles, nil
+}
@@ -0,0 +1,53 @@
+package gradle
+
+import (
+	"path/filepath"
+	"testing"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanDeployableArtifacts(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "deployable-artifacts.json")
+	require.NoError(t, clientutils.SaveFileTransferDetailsInFile(filePath, &[]clientutils.FileTransferDetails{
+		{SourcePath: "build/publications/mavenJava/pom-default.xml", TargetPath: "https://acme.jfrog.io/artifactory/gradle-local/org/app/1.0/app-1.0.pom"},
+		{SourcePath: "build/publications/mavenJava/module.json", TargetPath: "https://acme.jfrog.io/artifactory/gradle-local/org/app/1.0/app-1.0.module"},
+		{SourcePath: "build/libs/app-1.0.jar", TargetPath: "https://acme.jfrog.io/artifactory/gradle-local/org/app/1.0/app-1.0.jar"},
+	}))
+	result := new(commandsutils.Result)
+	result.SetReader(content.NewContentReader(filePath, "files"))
+	defer func() {
+		assert.NoError(t, result.Reader().Close())
+	}()
+	previousScanFunc := commandsutils.ConditionalUploadScanFunc
+	defer func() {
+		commandsutils.ConditionalUploadScanFunc = previousScanFunc
+	}()
+	var scanned []spec.File
+	commandsutils.ConditionalUploadScanFunc = func(_ *config.ServerDetails, fileSpec *spec.SpecFiles, _ int, _ format.OutputFormat) error {
+		scanned = fileSpec.Files
+		return nil
+	}
+
+	specFiles, err := scanDeployableArtifacts(result, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, 3, format.None)
+	require.NoError(t, err)
+	jar := spec.File{Pattern: "build/libs/app-1.0.jar", Target: "gradle-local/org/app/1.0/app-1.0.jar"}
+	// Only the binaries are scanned.
+	assert.Equal(t, []spec.File{jar}, scanned)
+	var deploymentOrder []spec.File
+	for _, specFile := range specFiles.inDeploymentOrder() {
+		deploymentOrder = append(deploymentOrder, specFile.Files...)
+	}
+	assert.Equal(t, []spec.File{
+		jar,
+		{Pattern: "build/publications/mavenJava/module.json", Target: "gradle-local/org/app/1.0/app-1.0.module"},
+		{Pattern: "build/publications/mavenJava/pom-default.xml", Target: "gradle-local/org/app/1.0/app-1.0.pom"},
+	}, deploymentOrder)
+}
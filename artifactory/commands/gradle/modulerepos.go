@@ -0,0 +1,128 @@
+package gradle
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The modules section of the deployer of the Gradle configuration, which maps patterns of the modules to the repositories
+// their artifacts are deployed to, instead of the repository of the deployer. For example:
+//
+//	deployer:
+//	  repo: gradle-release-local
+//	  modules:
+//	    - pattern: ":libs:*"
+//	      repo: libs-release-local
+//	    - pattern: ":apps:*"
+//	      repo: apps-release-local
+const deployerModulesConfig = build.DeployerPrefix + "modules"
+
+// moduleRepo maps the modules whose names match the pattern to a deployment repository. The pattern may include the * and ?
+// wildcards, and is matched against the module names, ignoring the leading colon of the Gradle project paths.
+type moduleRepo struct {
+	Pattern string `mapstructure:"pattern"`
+	Repo    string `mapstructure:"repo"`
+}
+
+func readModuleRepos(vConfig *viper.Viper) ([]moduleRepo, error) {
+	if !vConfig.IsSet(deployerModulesConfig) {
+		return nil, nil
+	}
+	var moduleRepos []moduleRepo
+	if err := vConfig.UnmarshalKey(deployerModulesConfig, &moduleRepos); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the modules of the deployer in the Gradle configuration: %s", err.Error())
+	}
+	for _, moduleRepo := range moduleRepos {
+		if moduleRepo.Pattern == "" || moduleRepo.Repo == "" {
+			return nil, errorutils.CheckErrorf("each module of the deployer in the Gradle configuration must have a pattern and a repo")
+		}
+		if _, err := path.Match(strings.TrimPrefix(moduleRepo.Pattern, ":"), ""); err != nil {
+			return nil, errorutils.CheckErrorf("invalid module pattern '%s' of the deployer in the Gradle configuration: %s", moduleRepo.Pattern, err.Error())
+		}
+	}
+	return moduleRepos, nil
+}
+
+func (gc *GradleCommand) validateModuleRepos() error {
+	if len(gc.moduleRepos) == 0 {
+		return nil
+	}
+	if !gc.publications.isEmpty() {
+		return errorutils.CheckErrorf("the modules of the deployer can't be used with the --include-publications or --exclude-publications options")
+	}
+	return nil
+}
+
+// moduleRepoOf returns the repository of the first pattern which matches the module, or an empty string if none matches.
+func moduleRepoOf(moduleRepos []moduleRepo, moduleName string) string {
+	moduleName = strings.TrimPrefix(moduleName, ":")
+	for _, moduleRepo := range moduleRepos {
+		if matched, _ := path.Match(strings.TrimPrefix(moduleRepo.Pattern, ":"), moduleName); matched {
+			return moduleRepo.Repo
+		}
+	}
+	return ""
+}
+
+// routeModules sets the target repository of the artifacts of the modules which match a pattern, and returns the number of their artifacts.
+func routeModules(modules map[string][]clientutils.DeployableArtifactDetails, moduleRepos []moduleRepo) (routed int) {
+	for moduleName, artifacts := range modules {
+		repo := moduleRepoOf(moduleRepos, moduleName)
+		if repo == "" {
+			continue
+		}
+		for i := range artifacts {
+			artifacts[i].TargetRepository = repo
+			routed++
+		}
+	}
+	return
+}
+
+// routeDeployableArtifacts sets the target repositories of the modules in the deployable artifacts file. The extractor then only
+// lists the artifacts, which are deployed after the build, so that the summary and the deployment plan show the same repositories.
+func (gc *GradleCommand) routeDeployableArtifacts() error {
+	modules, err := artifactoryutils.ReadDeployableArtifacts(gc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Deploying %d artifacts to the repositories of their modules.", routeModules(modules, gc.moduleRepos)))
+	return artifactoryutils.WriteDeployableArtifacts(gc.buildArtifactsDetailsFile, modules)
+}
+
+// deployRoutedArtifacts deploys the artifacts listed by the extractor to the repositories of their modules, and updates the
+// deployable artifacts file with the deployed artifacts.
+func (gc *GradleCommand) deployRoutedArtifacts(vConfig *viper.Viper) (err error) {
+	if _, err := gc.ServerDetails(); err != nil {
+		return err
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(gc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	if !hasPendingArtifacts(modules) {
+		return nil
+	}
+	operationSummary, pending, err := gc.uploadPendingArtifacts(vConfig, modules)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(operationSummary.TransferDetailsReader, &err)
+	if err = artifactoryutils.WriteDeployableArtifacts(gc.buildArtifactsDetailsFile, modules); err != nil {
+		return err
+	}
+	if operationSummary.TotalFailed > 0 {
+		gc.saveIncompleteDeployment()
+		return errorutils.CheckErrorf("failed to deploy %d of the %d artifacts", operationSummary.TotalFailed, pending)
+	}
+	return nil
+}
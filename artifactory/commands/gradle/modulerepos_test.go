@@ -0,0 +1,65 @@
+package gradle
+
+import (
+	"strings"
+	"testing"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTestGradleConfig(t *testing.T, config string) *viper.Viper {
+	vConfig := viper.New()
+	vConfig.SetConfigType("yaml")
+	require.NoError(t, vConfig.ReadConfig(strings.NewReader(config)))
+	return vConfig
+}
+
+func TestReadModuleRepos(t *testing.T) {
+	moduleRepos, err := readModuleRepos(readTestGradleConfig(t, `
+deployer:
+  repo: gradle-release-local
+  modules:
+    - pattern: ":libs:*"
+      repo: libs-release-local
+    - pattern: ":apps:*"
+      repo: apps-release-local
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []moduleRepo{{Pattern: ":libs:*", Repo: "libs-release-local"}, {Pattern: ":apps:*", Repo: "apps-release-local"}}, moduleRepos)
+
+	moduleRepos, err = readModuleRepos(readTestGradleConfig(t, "deployer:\n  repo: gradle-release-local\n"))
+	require.NoError(t, err)
+	assert.Empty(t, moduleRepos)
+
+	_, err = readModuleRepos(readTestGradleConfig(t, "deployer:\n  modules:\n    - pattern: \":libs:*\"\n"))
+	assert.ErrorContains(t, err, "must have a pattern and a repo")
+
+	_, err = readModuleRepos(readTestGradleConfig(t, "deployer:\n  modules:\n    - pattern: \":libs:[\"\n      repo: libs-release-local\n"))
+	assert.ErrorContains(t, err, "invalid module pattern")
+}
+
+func TestRouteModules(t *testing.T) {
+	moduleRepos := []moduleRepo{{Pattern: ":libs:*", Repo: "libs-release-local"}, {Pattern: ":apps:*", Repo: "apps-release-local"}, {Pattern: "*", Repo: "other-local"}}
+	assert.Equal(t, "libs-release-local", moduleRepoOf(moduleRepos, ":libs:core"))
+	assert.Equal(t, "libs-release-local", moduleRepoOf(moduleRepos, "libs:core:api"))
+	assert.Equal(t, "apps-release-local", moduleRepoOf(moduleRepos, ":apps:web"))
+	assert.Equal(t, "other-local", moduleRepoOf(moduleRepos, "root"))
+	assert.Empty(t, moduleRepoOf(moduleRepos[:2], "root"))
+
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		":libs:core": {
+			{SourcePath: "/build/libs/core/core-1.0.jar", ArtifactDest: "org/core/1.0/core-1.0.jar", TargetRepository: "gradle-release-local"},
+			{SourcePath: "/build/libs/core/core-1.0.pom", ArtifactDest: "org/core/1.0/core-1.0.pom"},
+		},
+		":tools": {
+			{SourcePath: "/build/tools/tools-1.0.jar", ArtifactDest: "org/tools/1.0/tools-1.0.jar"},
+		},
+	}
+	assert.Equal(t, 2, routeModules(modules, moduleRepos[:2]))
+	assert.Equal(t, "libs-release-local", modules[":libs:core"][0].TargetRepository)
+	assert.Equal(t, "libs-release-local", modules[":libs:core"][1].TargetRepository)
+	assert.Empty(t, modules[":tools"][0].TargetRepository)
+}
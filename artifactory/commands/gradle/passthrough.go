@@ -0,0 +1,70 @@
+package gradle
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The separator after which the arguments are passed to Gradle as is, without being parsed as JFrog CLI options or tasks.
+// For example, 'jf gradle clean build -- --scan --offline' runs a Gradle Build Scan, rather than a JFrog Xray scan.
+const PassthroughSeparator = "--"
+
+// The Gradle options which would replace the options set by JFrog CLI to run the build-info extractor.
+var reservedPassthroughOptions = []string{"-I", "--init-script", "-DBUILDINFO_PROPFILE", "--system-prop=BUILDINFO_PROPFILE"}
+
+// The characters which cmd.exe interprets in the arguments of the gradle.bat and gradlew.bat scripts, even when the argument is quoted.
+const windowsUnsafeArgChars = "\"%!^&|<>\r\n"
+
+// SplitPassthroughArgs splits the command's arguments at the first passthrough separator.
+// The arguments before the separator are parsed by JFrog CLI, and the arguments after it are passed to Gradle.
+func SplitPassthroughArgs(args []string) (cliArgs, passthroughArgs []string) {
+	index := slices.Index(args, PassthroughSeparator)
+	if index == -1 {
+		return args, nil
+	}
+	return args[:index], args[index+1:]
+}
+
+// gradleArgs returns the tasks and the options of the Gradle invocation. The passthrough arguments are vetted, and follow the tasks.
+func (gc *GradleCommand) gradleArgs() ([]string, error) {
+	tasks, passthroughArgs := SplitPassthroughArgs(gc.tasks)
+	passthroughArgs = append(slices.Clone(passthroughArgs), gc.passthroughArgs...)
+	if err := validatePassthroughArgs(passthroughArgs, coreutils.IsWindows()); err != nil {
+		return nil, err
+	}
+	return append(slices.Clone(tasks), passthroughArgs...), nil
+}
+
+func validatePassthroughArgs(args []string, windows bool) error {
+	for _, arg := range args {
+		for _, option := range reservedPassthroughOptions {
+			if arg == option || strings.HasPrefix(arg, option+"=") || (strings.HasPrefix(option, "-D") && strings.HasPrefix(arg, option)) {
+				return errorutils.CheckErrorf("the '%s' Gradle option is set by JFrog CLI, and can't be passed after '%s'", option, PassthroughSeparator)
+			}
+		}
+		if windows && strings.ContainsAny(arg, windowsUnsafeArgChars) {
+			return errorutils.CheckErrorf("the '%s' Gradle argument contains characters which can't be safely passed to Gradle on Windows. "+
+				"Set it in the gradle.properties file instead", arg)
+		}
+	}
+	return nil
+}
+
+// formatExtractorArgs prepares the arguments to run with the build-info extractor.
+// The extractor wraps the values of the -D and -P options which contain spaces with single quotes, which aren't removed when Gradle
+// isn't run by a shell. The equivalent long options are passed as is, and are quoted by the operating system if needed.
+func formatExtractorArgs(args []string) []string {
+	formatted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-D") && strings.Contains(arg, "=") && strings.Contains(arg, " ") {
+			arg = "--system-prop=" + strings.TrimPrefix(arg, "-D")
+		} else if strings.HasPrefix(arg, "-P") && strings.Contains(arg, "=") && strings.Contains(arg, " ") {
+			arg = "--project-prop=" + strings.TrimPrefix(arg, "-P")
+		}
+		formatted = append(formatted, arg)
+	}
+	return formatted
+}
@@ -0,0 +1,46 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPassthroughArgs(t *testing.T) {
+	cliArgs, passthroughArgs := SplitPassthroughArgs([]string{"clean", "build", "--build-name=app", "--", "--scan", "--", "--offline"})
+	assert.Equal(t, []string{"clean", "build", "--build-name=app"}, cliArgs)
+	assert.Equal(t, []string{"--scan", "--", "--offline"}, passthroughArgs)
+
+	cliArgs, passthroughArgs = SplitPassthroughArgs([]string{"clean", "build"})
+	assert.Equal(t, []string{"clean", "build"}, cliArgs)
+	assert.Nil(t, passthroughArgs)
+}
+
+func TestGradleArgs(t *testing.T) {
+	gradleCommand := NewGradleCommand().SetTasks([]string{"clean", "build", "--", "--no-daemon"}).SetPassthroughArgs([]string{"--scan", "--offline"})
+	gradleArgs, err := gradleCommand.gradleArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clean", "build", "--no-daemon", "--scan", "--offline"}, gradleArgs)
+
+	_, err = gradleCommand.SetPassthroughArgs([]string{"--init-script", "other.gradle"}).gradleArgs()
+	assert.ErrorContains(t, err, "'--init-script' Gradle option is set by JFrog CLI")
+}
+
+func TestValidatePassthroughArgs(t *testing.T) {
+	assert.NoError(t, validatePassthroughArgs([]string{"--scan", "-Pmessage=a b", "--console=plain"}, true))
+	for _, arg := range []string{"-I", "--init-script=other.gradle", "-DBUILDINFO_PROPFILE=/tmp/props", "--system-prop=BUILDINFO_PROPFILE=/tmp/props"} {
+		assert.Error(t, validatePassthroughArgs([]string{arg}, false), arg)
+	}
+	// cmd.exe interprets these characters in the arguments of the Gradle scripts on Windows.
+	for _, arg := range []string{"-Pa=b&calc", `-Pmessage="quoted"`, "-Ppath=%PATH%", "-Pa=b|c"} {
+		assert.NoError(t, validatePassthroughArgs([]string{arg}, false), arg)
+		assert.Error(t, validatePassthroughArgs([]string{arg}, true), arg)
+	}
+}
+
+func TestFormatExtractorArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"build", "--system-prop=message=a b", "--project-prop=title=a b", "-Dkey=value", "-Pkey=value", "-Dflag", "--scan"},
+		formatExtractorArgs([]string{"build", "-Dmessage=a b", "-Ptitle=a b", "-Dkey=value", "-Pkey=value", "-Dflag", "--scan"}))
+}
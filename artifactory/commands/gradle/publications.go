@@ -0,0 +1,56 @@
+package gradle
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+)
+
+// Extractor properties, which limit the deployment to the artifacts of the matching publications.
+// The values are comma-separated publication names, which may include the '*' and '?' wildcards.
+const (
+	includePublicationsProp = "publish.includePublications"
+	excludePublicationsProp = "publish.excludePublications"
+	// Keeps the artifacts of the filtered out publications in the build info and in the deployable artifacts file,
+	// so that they are still listed in the detailed summary.
+	filterExcludedArtifactsProp = "publish." + build.FilterExcludedArtifactsFromBuild
+)
+
+// publicationFilter selects the Gradle publications whose artifacts are deployed.
+type publicationFilter struct {
+	include []string
+	exclude []string
+}
+
+func (pf publicationFilter) isEmpty() bool {
+	return len(pf.include) == 0 && len(pf.exclude) == 0
+}
+
+// setProps adds the filter to the extractor properties.
+func (pf publicationFilter) setProps(props map[string]string) {
+	if pf.isEmpty() {
+		return
+	}
+	setExtractorProp(props, includePublicationsProp, joinPublicationPatterns(pf.include))
+	setExtractorProp(props, excludePublicationsProp, joinPublicationPatterns(pf.exclude))
+	setExtractorProp(props, filterExcludedArtifactsProp, "false")
+}
+
+// setExtractorProp sets the property, and its deprecated 'artifactory.' prefixed key, like the properties created from the build config.
+func setExtractorProp(props map[string]string, key, value string) {
+	if value == "" {
+		return
+	}
+	props[key] = value
+	props["artifactory."+key] = value
+}
+
+func joinPublicationPatterns(patterns []string) string {
+	var trimmed []string
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			trimmed = append(trimmed, pattern)
+		}
+	}
+	return strings.Join(trimmed, ",")
+}
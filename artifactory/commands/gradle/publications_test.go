@@ -0,0 +1,26 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicationFilterSetProps(t *testing.T) {
+	props := map[string]string{}
+	publicationFilter{}.setProps(props)
+	assert.Empty(t, props)
+
+	publicationFilter{include: []string{"mavenJava", " lib* "}}.setProps(props)
+	assert.Equal(t, map[string]string{
+		includePublicationsProp:                      "mavenJava,lib*",
+		"artifactory." + includePublicationsProp:     "mavenJava,lib*",
+		filterExcludedArtifactsProp:                  "false",
+		"artifactory." + filterExcludedArtifactsProp: "false",
+	}, props)
+
+	props = map[string]string{}
+	publicationFilter{exclude: []string{"*Test"}}.setProps(props)
+	assert.Equal(t, "*Test", props[excludePublicationsProp])
+	assert.NotContains(t, props, includePublicationsProp)
+}
@@ -0,0 +1,117 @@
+import org.gradle.api.artifacts.dsl.RepositoryHandler
+import org.gradle.api.publish.PublishingExtension
+import org.gradle.caching.http.HttpBuildCache
+import org.gradle.util.GradleVersion
+
+data class ArtifactoryRepo(val name: String, val repoName: String, val url: String, val username: String, val password: String)
+
+val artifactoryUrl = {{ kotlinString .ArtifactoryURL }}
+val pluginRepos = listOf<ArtifactoryRepo>({{ range $i, $repo := .PluginRepos }}{{ if $i }}, {{ end }}ArtifactoryRepo({{ kotlinString $repo.Name }}, {{ kotlinString $repo.RepoName }}, {{ kotlinString $repo.URL }}, {{ kotlinString $repo.Username }}, {{ kotlinString $repo.AccessToken }}){{ end }})
+val dependencyRepos = listOf<ArtifactoryRepo>({{ range $i, $repo := .DependencyRepos }}{{ if $i }}, {{ end }}ArtifactoryRepo({{ kotlinString $repo.Name }}, {{ kotlinString $repo.RepoName }}, {{ kotlinString $repo.URL }}, {{ kotlinString $repo.Username }}, {{ kotlinString $repo.AccessToken }}){{ end }})
+val artifactoryUsername = {{ kotlinString .ArtifactoryUsername }}
+val artifactoryAccessToken = {{ kotlinString .ArtifactoryAccessToken }}
+val deployerUrl = {{ kotlinString .DeployerURL }}
+val gradleDeployRepoName = {{ kotlinString .GradleDeployRepoName }}
+val deployerUsername = {{ kotlinString .DeployerUsername }}
+val deployerAccessToken = {{ kotlinString .DeployerAccessToken }}
+val buildCacheRepoName = {{ kotlinString .BuildCacheRepoName }}
+val buildCachePush = {{ .BuildCachePush }}
+val mirror = {{ .Mirror }}
+val gradleVersion = GradleVersion.current()
+val insecureProtocolSupported = gradleVersion >= GradleVersion.version("6.2")
+val allowInsecure = insecureProtocolSupported && artifactoryUrl.startsWith("http://")
+val allowInsecureDeploy = insecureProtocolSupported && deployerUrl.startsWith("http://")
+
+// Adds the repositories in order, so that Gradle searches them in this order
+fun configureMavenRepos(repositories: RepositoryHandler, repos: List<ArtifactoryRepo>, insecureProtocolSupported: Boolean) {
+    repos.forEach { repo ->
+        repositories.maven {
+            name = repo.name
+            url = uri("${repo.url}/${repo.repoName}")
+            credentials {
+                username = repo.username
+                password = repo.password
+            }
+            // This is used when Artifactory is running in HTTP mode
+            if (insecureProtocolSupported && repo.url.startsWith("http://")) {
+                isAllowInsecureProtocol = true
+            }
+        }
+    }
+}
+
+// Removes the repositories declared by the build, including the ones it declares later, so that only the Artifactory repositories are used
+fun removeOtherRepos(repositories: RepositoryHandler, repos: List<ArtifactoryRepo>) {
+    val repoNames = repos.map { it.name }
+    repositories.all {
+        val repo = this
+        if (!repoNames.contains(repo.name)) {
+            repositories.remove(repo)
+        }
+    }
+}
+
+// Configure the pluginManagement repositories
+settingsEvaluated {
+    pluginManagement {
+        repositories {
+            if (mirror) {
+                removeOtherRepos(this, pluginRepos)
+            }
+            configureMavenRepos(this, pluginRepos, insecureProtocolSupported)
+            if (!mirror) {
+                gradlePluginPortal() // Fallback to Gradle Plugin Portal
+            }
+        }
+    }
+    // Configure the remote build cache
+    if (buildCacheRepoName.isNotEmpty()) {
+        buildCache {
+            remote(HttpBuildCache::class) {
+                url = uri("$artifactoryUrl/$buildCacheRepoName/")
+                credentials {
+                    username = artifactoryUsername
+                    password = artifactoryAccessToken
+                }
+                isPush = buildCachePush
+                // This is used when Artifactory is running in HTTP mode
+                if (allowInsecure) {
+                    isAllowInsecureProtocol = true
+                }
+            }
+        }
+    }
+}
+
+// Configure the project repositories
+allprojects {
+    val project = this
+    project.repositories {
+        if (mirror) {
+            removeOtherRepos(this, dependencyRepos)
+        }
+        configureMavenRepos(this, dependencyRepos, insecureProtocolSupported)
+    }
+
+    // Configure publishing to the deployer repository for projects that apply maven-publish plugin
+    project.plugins.withId("maven-publish") {
+        project.extensions.configure<PublishingExtension> {
+            repositories {
+                // Clear any existing repositories to ensure Artifactory is the only publishing destination
+                clear()
+                maven {
+                    name = "Artifactory"
+                    url = uri("$deployerUrl/$gradleDeployRepoName")
+                    credentials {
+                        username = deployerUsername
+                        password = deployerAccessToken
+                    }
+                    // This is used when Artifactory is running in HTTP mode
+                    if (allowInsecureDeploy) {
+                        isAllowInsecureProtocol = true
+                    }
+                }
+            }
+        }
+    }
+}
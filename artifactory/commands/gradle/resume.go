@@ -0,0 +1,201 @@
+package gradle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// The number of times the extractor retries a failed deployment request.
+	connectionRetriesProp = "connectionRetries"
+	// The directory under the CLI's persistent temp directory, where the deployable artifacts files of the failed deployments are kept.
+	incompleteDeploymentsDir = "gradle-deployments"
+)
+
+// SetRetries sets the number of times a failed deployment request is retried. If negative, the extractor's default is used.
+func (gc *GradleCommand) SetRetries(retries int) *GradleCommand {
+	gc.retries = retries
+	return gc
+}
+
+// SetRetryWaitMilliSecs sets the time to wait between the retries of the artifacts uploaded by --resume-from.
+func (gc *GradleCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *GradleCommand {
+	gc.retryWaitMilliSecs = retryWaitMilliSecs
+	return gc
+}
+
+// SetResumeFrom sets the deployable artifacts file of a previous build, whose deployment failed. Instead of running the build,
+// the artifacts the file lists as not deployed are uploaded, and the file is updated with the artifacts which were deployed.
+func (gc *GradleCommand) SetResumeFrom(resumeFrom string) *GradleCommand {
+	gc.resumeFrom = resumeFrom
+	return gc
+}
+
+func setRetriesProp(props map[string]string, retries int) {
+	if retries >= 0 {
+		setExtractorProp(props, connectionRetriesProp, strconv.Itoa(retries))
+	}
+}
+
+// saveIncompleteDeployment keeps the deployable artifacts file of a failed build, if some of its artifacts weren't deployed,
+// so that their deployment can be resumed by --resume-from.
+func (gc *GradleCommand) saveIncompleteDeployment() {
+	if gc.deploymentDisabled || gc.buildArtifactsDetailsFile == "" {
+		return
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(gc.buildArtifactsDetailsFile)
+	if err != nil {
+		log.Debug("Couldn't read the deployable artifacts of the failed build:", err.Error())
+		return
+	}
+	if !hasPendingArtifacts(modules) {
+		return
+	}
+	if !gc.publications.isEmpty() {
+		log.Warn("The deployment of the build can't be resumed, because the artifacts of the filtered out publications aren't distinguished from the ones which failed to deploy.")
+		return
+	}
+	dir := filepath.Join(coreutils.GetCliPersistentTempDirPath(), incompleteDeploymentsDir)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		log.Debug("Couldn't create the directory of the incomplete deployments:", err.Error())
+		return
+	}
+	resumeFile := filepath.Join(dir, "deployable-artifacts-"+strconv.FormatInt(time.Now().UnixMilli(), 10)+".json")
+	if err = artifactoryutils.WriteDeployableArtifacts(resumeFile, modules); err != nil {
+		log.Debug("Couldn't save the deployable artifacts of the failed build:", err.Error())
+		return
+	}
+	log.Info(fmt.Sprintf("Some of the build artifacts weren't deployed. To deploy them without running the build again, run the same command with --resume-from=%s", resumeFile))
+}
+
+// resumeDeployment uploads the artifacts which weren't deployed by a previous build, using the deployer of the Gradle configuration.
+func (gc *GradleCommand) resumeDeployment() (err error) {
+	vConfig, err := project.ReadConfigFile(gc.configPath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if !vConfig.IsSet("deployer") {
+		return errorutils.CheckErrorf("the deployment can only be resumed if deployer is set in the config")
+	}
+	if _, err = gc.ServerDetails(); err != nil {
+		return err
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(gc.resumeFrom)
+	if err != nil {
+		return err
+	}
+	if !hasPendingArtifacts(modules) {
+		log.Info("All the artifacts listed by", gc.resumeFrom, "were already deployed.")
+		return nil
+	}
+	operationSummary, pending, err := gc.uploadPendingArtifacts(vConfig, modules)
+	if err != nil {
+		return err
+	}
+	result := new(commandsutils.Result)
+	result.SetSuccessCount(operationSummary.TotalSucceeded)
+	result.SetFailCount(operationSummary.TotalFailed)
+	// Without a detailed summary, the reader isn't printed. Otherwise, it's closed with the result, once the result is printed.
+	if gc.IsDetailedSummary() {
+		result.SetReader(operationSummary.TransferDetailsReader)
+	} else {
+		defer ioutils.Close(operationSummary.TransferDetailsReader, &err)
+	}
+	gc.setResult(result)
+	if err = artifactoryutils.WriteDeployableArtifacts(gc.resumeFrom, modules); err != nil {
+		return err
+	}
+	if result.FailCount() > 0 {
+		return errorutils.CheckErrorf("failed to deploy %d of the %d artifacts. To retry, run the command with --resume-from=%s again", result.FailCount(), pending, gc.resumeFrom)
+	}
+	return nil
+}
+
+// uploadPendingArtifacts uploads the artifacts which weren't deployed, using the deployer of the Gradle configuration,
+// and marks the uploaded artifacts as deployed. The number of artifacts which were pending is returned with the summary.
+func (gc *GradleCommand) uploadPendingArtifacts(vConfig *viper.Viper, modules map[string][]clientutils.DeployableArtifactDetails) (operationSummary *servicesutils.OperationSummary, pending int, err error) {
+	targetProps, err := mergeTargetProps(vConfig.GetString(deployerPropsConfig), gc.targetProps)
+	if err != nil {
+		return
+	}
+	uploadParams := createPendingUploadParams(modules, vConfig.GetString(build.DeployerPrefix+build.Repo), targetProps)
+	if gc.configuration != nil {
+		var isCollect bool
+		if isCollect, err = gc.configuration.IsCollectBuildInfo(); err != nil {
+			return
+		}
+		if isCollect {
+			var buildProps string
+			if buildProps, err = build.CreateBuildPropsFromConfiguration(gc.configuration); err != nil {
+				return
+			}
+			for i := range uploadParams {
+				uploadParams[i].BuildProps = buildProps
+			}
+		}
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(gc.serverDetails, false, gc.threads, gc.retries, gc.retryWaitMilliSecs)
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Deploying %d artifacts...", len(uploadParams)))
+	operationSummary, err = servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams...)
+	if err != nil {
+		return
+	}
+	// The transfer details reader is returned to the caller, unless the artifacts can't be marked as deployed.
+	if err = errors.Join(operationSummary.ArtifactsDetailsReader.Close(), artifactoryutils.MarkDeployed(modules, operationSummary.TransferDetailsReader)); err != nil {
+		return nil, 0, errors.Join(err, operationSummary.TransferDetailsReader.Close())
+	}
+	return operationSummary, len(uploadParams), nil
+}
+
+// createPendingUploadParams creates the upload parameters of the artifacts which weren't deployed. defaultRepo is used
+// if the extractor didn't list the target repository of the artifact.
+func createPendingUploadParams(modules map[string][]clientutils.DeployableArtifactDetails, defaultRepo string, targetProps *servicesutils.Properties) []services.UploadParams {
+	var params []services.UploadParams
+	for _, moduleName := range artifactoryutils.SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			if artifact.DeploySucceeded {
+				continue
+			}
+			uploadParams := services.NewUploadParams()
+			uploadParams.Pattern = artifact.SourcePath
+			uploadParams.Target = getTargetPath(artifact, defaultRepo)
+			uploadParams.TargetProps = targetProps
+			uploadParams.Flat = true
+			params = append(params, uploadParams)
+		}
+	}
+	return params
+}
+
+func hasPendingArtifacts(modules map[string][]clientutils.DeployableArtifactDetails) bool {
+	for _, artifacts := range modules {
+		for _, artifact := range artifacts {
+			if !artifact.DeploySucceeded {
+				return true
+			}
+		}
+	}
+	return false
+}
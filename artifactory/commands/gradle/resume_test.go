@@ -0,0 +1,69 @@
+package gradle
+
+import (
+	"testing"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestModules() map[string][]clientutils.DeployableArtifactDetails {
+	return map[string][]clientutils.DeployableArtifactDetails{
+		"lib": {
+			{SourcePath: "/build/lib/lib-1.0.jar", ArtifactDest: "org/lib/1.0/lib-1.0.jar", TargetRepository: "libs-release-local"},
+		},
+		"app": {
+			{SourcePath: "/build/app/app-1.0.jar", ArtifactDest: "org/app/1.0/app-1.0.jar", DeploySucceeded: true},
+			{SourcePath: "/build/app/app-1.0.pom", ArtifactDest: "org/app/1.0/app-1.0.pom"},
+		},
+	}
+}
+
+func TestCreatePendingUploadParams(t *testing.T) {
+	targetProps, err := servicesutils.ParseProperties("env=production")
+	require.NoError(t, err)
+	params := createPendingUploadParams(createTestModules(), "gradle-local", targetProps)
+	require.Len(t, params, 2)
+	assert.Equal(t, "/build/app/app-1.0.pom", params[0].Pattern)
+	// The deployment repository of the configuration is used if the extractor didn't list the target repository.
+	assert.Equal(t, "gradle-local/org/app/1.0/app-1.0.pom", params[0].Target)
+	assert.Equal(t, "/build/lib/lib-1.0.jar", params[1].Pattern)
+	assert.Equal(t, "libs-release-local/org/lib/1.0/lib-1.0.jar", params[1].Target)
+	for _, uploadParams := range params {
+		assert.True(t, uploadParams.Flat)
+		assert.Equal(t, targetProps, uploadParams.TargetProps)
+	}
+}
+
+func TestMarkDeployed(t *testing.T) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "/build/lib/lib-1.0.jar", TargetPath: "libs-release-local/org/lib/1.0/lib-1.0.jar"})
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	modules := createTestModules()
+	assert.True(t, hasPendingArtifacts(modules))
+	require.NoError(t, artifactoryutils.MarkDeployed(modules, reader))
+	assert.True(t, modules["lib"][0].DeploySucceeded)
+	assert.False(t, modules["app"][1].DeploySucceeded)
+	assert.True(t, hasPendingArtifacts(modules))
+
+	modules["app"][1].DeploySucceeded = true
+	assert.False(t, hasPendingArtifacts(modules))
+}
+
+func TestSetRetriesProp(t *testing.T) {
+	props := map[string]string{}
+	setRetriesProp(props, -1)
+	assert.Empty(t, props)
+	setRetriesProp(props, 5)
+	assert.Equal(t, map[string]string{"connectionRetries": "5", "artifactory.connectionRetries": "5"}, props)
+}
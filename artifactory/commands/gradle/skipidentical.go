@@ -0,0 +1,108 @@
+package gradle
+
+import (
+	"fmt"
+	"path"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// SetSkipIdentical skips the deployment of the artifacts whose target path in Artifactory already has an artifact with the same sha256.
+// The extractor then only lists the artifacts, and the other artifacts are uploaded after the build.
+// The properties of the skipped artifacts aren't updated.
+func (gc *GradleCommand) SetSkipIdentical(skipIdentical bool) *GradleCommand {
+	gc.skipIdentical = skipIdentical
+	return gc
+}
+
+func (gc *GradleCommand) validateSkipIdentical() error {
+	if !gc.skipIdentical {
+		return nil
+	}
+	if gc.IsXrayScan() || gc.dryRun {
+		return errorutils.CheckErrorf("the --skip-identical option can't be used with the --scan or --dry-run options")
+	}
+	if !gc.publications.isEmpty() {
+		return errorutils.CheckErrorf("the --skip-identical option can't be used with the --include-publications or --exclude-publications options")
+	}
+	return nil
+}
+
+// deploySkippingIdentical deploys the artifacts listed by the extractor which aren't in Artifactory yet, or whose content changed,
+// and updates the deployable artifacts file with the artifacts which are deployed, including the skipped ones.
+func (gc *GradleCommand) deploySkippingIdentical(vConfig *viper.Viper) (err error) {
+	if _, err := gc.ServerDetails(); err != nil {
+		return err
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(gc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	defaultRepo := vConfig.GetString(build.DeployerPrefix + build.Repo)
+	servicesManager, err := utils.CreateServiceManager(gc.serverDetails, gc.retries, gc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	existing, err := artifactoryutils.GetArtifactsSha256(servicesManager, getTargetPaths(modules, defaultRepo))
+	if err != nil {
+		return err
+	}
+	skipped := markIdentical(modules, defaultRepo, existing)
+	log.Info(fmt.Sprintf("Skipping the deployment of %d artifacts, which are identical to the ones in Artifactory.", skipped))
+	if !hasPendingArtifacts(modules) {
+		return artifactoryutils.WriteDeployableArtifacts(gc.buildArtifactsDetailsFile, modules)
+	}
+	operationSummary, pending, err := gc.uploadPendingArtifacts(vConfig, modules)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(operationSummary.TransferDetailsReader, &err)
+	if err = artifactoryutils.WriteDeployableArtifacts(gc.buildArtifactsDetailsFile, modules); err != nil {
+		return err
+	}
+	if operationSummary.TotalFailed > 0 {
+		gc.saveIncompleteDeployment()
+		return errorutils.CheckErrorf("failed to deploy %d of the %d changed artifacts", operationSummary.TotalFailed, pending)
+	}
+	return nil
+}
+
+func getTargetPath(artifact clientutils.DeployableArtifactDetails, defaultRepo string) string {
+	repo := artifact.TargetRepository
+	if repo == "" {
+		repo = defaultRepo
+	}
+	return path.Join(repo, artifact.ArtifactDest)
+}
+
+func getTargetPaths(modules map[string][]clientutils.DeployableArtifactDetails, defaultRepo string) []string {
+	var targetPaths []string
+	for _, moduleName := range artifactoryutils.SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			targetPaths = append(targetPaths, getTargetPath(artifact, defaultRepo))
+		}
+	}
+	return targetPaths
+}
+
+// markIdentical marks the artifacts whose target path has the same sha256 as deployed, and returns their number.
+// Artifacts without a sha256 are always deployed.
+func markIdentical(modules map[string][]clientutils.DeployableArtifactDetails, defaultRepo string, existing map[string]string) (identical int) {
+	for _, artifacts := range modules {
+		for i := range artifacts {
+			sha256 := existing[getTargetPath(artifacts[i], defaultRepo)]
+			if artifacts[i].Sha256 != "" && sha256 == artifacts[i].Sha256 {
+				artifacts[i].DeploySucceeded = true
+				identical++
+			}
+		}
+	}
+	return
+}
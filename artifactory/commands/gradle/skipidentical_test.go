@@ -0,0 +1,48 @@
+package gradle
+
+import (
+	"testing"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkIdentical(t *testing.T) {
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		"lib": {
+			{SourcePath: "/build/lib/lib-1.0.jar", ArtifactDest: "org/lib/1.0/lib-1.0.jar", Sha256: "aaa", TargetRepository: "libs-release-local"},
+			{SourcePath: "/build/lib/lib-1.0.pom", ArtifactDest: "org/lib/1.0/lib-1.0.pom", Sha256: "bbb"},
+		},
+		"app": {
+			{SourcePath: "/build/app/app-1.0.jar", ArtifactDest: "org/app/1.0/app-1.0.jar", Sha256: "ccc"},
+			{SourcePath: "/build/app/app-1.0.module", ArtifactDest: "org/app/1.0/app-1.0.module"},
+		},
+	}
+	assert.Equal(t, []string{
+		"gradle-local/org/app/1.0/app-1.0.jar",
+		"gradle-local/org/app/1.0/app-1.0.module",
+		"libs-release-local/org/lib/1.0/lib-1.0.jar",
+		"gradle-local/org/lib/1.0/lib-1.0.pom",
+	}, getTargetPaths(modules, "gradle-local"))
+
+	existing := map[string]string{
+		"libs-release-local/org/lib/1.0/lib-1.0.jar": "aaa",
+		// The content of the artifact changed.
+		"gradle-local/org/lib/1.0/lib-1.0.pom": "xxx",
+		// An artifact without a sha256 is deployed.
+		"gradle-local/org/app/1.0/app-1.0.module": "",
+	}
+	assert.Equal(t, 1, markIdentical(modules, "gradle-local", existing))
+	assert.True(t, modules["lib"][0].DeploySucceeded)
+	assert.False(t, modules["lib"][1].DeploySucceeded)
+	assert.False(t, modules["app"][0].DeploySucceeded)
+	assert.False(t, modules["app"][1].DeploySucceeded)
+}
+
+func TestValidateSkipIdentical(t *testing.T) {
+	assert.NoError(t, NewGradleCommand().SetXrayScan(true).validateSkipIdentical())
+	assert.NoError(t, NewGradleCommand().SetSkipIdentical(true).validateSkipIdentical())
+	assert.Error(t, NewGradleCommand().SetSkipIdentical(true).SetDryRun(true).validateSkipIdentical())
+	assert.Error(t, NewGradleCommand().SetSkipIdentical(true).SetXrayScan(true).validateSkipIdentical())
+	assert.Error(t, NewGradleCommand().SetSkipIdentical(true).SetIncludePublications([]string{"maven"}).validateSkipIdentical())
+}
@@ -0,0 +1,44 @@
+package gradle
+
+import (
+	"encoding/json"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DeploymentSummary is the detailed summary of the build's artifacts, printed when the JSON format is requested.
+type DeploymentSummary = artifactoryutils.DeploymentSummary
+
+type DeploymentSummaryFile = artifactoryutils.DeploymentSummaryFile
+
+// isJsonDetailedSummary returns true if the detailed summary should be printed as a JSON document. When scanning the build's artifacts,
+// the format applies to the scan results.
+func (gc *GradleCommand) isJsonDetailedSummary() bool {
+	return gc.IsDetailedSummary() && gc.scanOutputFormat == format.Json && !gc.IsXrayScan() && !gc.dryRun
+}
+
+// DeploymentSummary returns the JSON detailed summary of the build's artifacts, if it was printed.
+func (gc *GradleCommand) DeploymentSummary() *DeploymentSummary {
+	return gc.deploymentSummary
+}
+
+func (gc *GradleCommand) printDeploymentSummary() error {
+	data, err := json.Marshal(gc.deploymentSummary)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(data))
+	return nil
+}
+
+// createDeploymentSummary creates the detailed summary of the deployable artifacts file written by the Gradle extractor.
+// defaultRepo is the deployment repository of the project configuration, used if the extractor didn't list the target repository.
+func createDeploymentSummary(deployableArtifactsFile, defaultRepo string) (*DeploymentSummary, error) {
+	return artifactoryutils.CreateDeploymentSummary(deployableArtifactsFile, func(clientutils.DeployableArtifactDetails) string {
+		return defaultRepo
+	})
+}
@@ -0,0 +1,58 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils/summary"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDeploymentSummary(t *testing.T) {
+	dir := t.TempDir()
+	jarPath := filepath.Join(dir, "app-1.0.jar")
+	require.NoError(t, os.WriteFile(jarPath, []byte("jar"), 0644))
+	missingPath := filepath.Join(dir, "app-1.0.pom")
+	moduleMetadataPath := filepath.Join(dir, "module.json")
+	deployableArtifacts := `{
+  "app": [
+    {"sourcePath": "` + filepath.ToSlash(jarPath) + `", "artifactDest": "org/app/1.0/app-1.0.jar", "sha256": "0163f1eea7894350060624d315234d40c508ab251ba121714e234503045faadd", "deploySucceeded": true, "targetRepository": "libs-release-local"},
+    {"sourcePath": "` + filepath.ToSlash(missingPath) + `", "artifactDest": "org/app/1.0/app-1.0.pom", "sha256": "pom"},
+    {"sourcePath": "` + filepath.ToSlash(moduleMetadataPath) + `", "artifactDest": "org/app/1.0/app-1.0.module", "sha256": "module", "deploySucceeded": true}
+  ]
+}`
+	deployableArtifactsFile := filepath.Join(dir, "deployable-artifacts.json")
+	require.NoError(t, os.WriteFile(deployableArtifactsFile, []byte(deployableArtifacts), 0644))
+
+	deploymentSummary, err := createDeploymentSummary(deployableArtifactsFile, "gradle-local")
+	require.NoError(t, err)
+	assert.Equal(t, summary.Failure, deploymentSummary.Status)
+	assert.Equal(t, &summary.Totals{Success: 2, Failure: 1}, deploymentSummary.Totals)
+	assert.Equal(t, []DeploymentSummaryFile{
+		{
+			Path:     filepath.ToSlash(jarPath),
+			Target:   "libs-release-local/org/app/1.0/app-1.0.jar",
+			Category: "artifact",
+			Sha1:     "f92e777f4341930bad9b2422283c4680d00dbc06",
+			Sha256:   "0163f1eea7894350060624d315234d40c508ab251ba121714e234503045faadd",
+			Md5:      "68995fcbf432492d15484d04a9d2ac40",
+			Size:     3,
+			Deployed: true,
+		},
+		// The checksums of artifacts which are missing locally are the ones listed by the extractor.
+		{Path: filepath.ToSlash(missingPath), Target: "gradle-local/org/app/1.0/app-1.0.pom", Category: "pom", Sha256: "pom"},
+		{Path: filepath.ToSlash(moduleMetadataPath), Target: "gradle-local/org/app/1.0/app-1.0.module", Category: "gradle-module-metadata", Sha256: "module", Deployed: true},
+	}, deploymentSummary.Files)
+}
+
+func TestIsJsonDetailedSummary(t *testing.T) {
+	gc := NewGradleCommand().SetDetailedSummary(true).SetScanOutputFormat(format.Json)
+	assert.True(t, gc.isJsonDetailedSummary())
+	// The format applies to the scan results.
+	assert.False(t, gc.SetXrayScan(true).isJsonDetailedSummary())
+	assert.False(t, NewGradleCommand().SetDetailedSummary(true).SetScanOutputFormat(format.Table).isJsonDetailedSummary())
+	assert.False(t, NewGradleCommand().SetScanOutputFormat(format.Json).isJsonDetailedSummary())
+}
@@ -0,0 +1,54 @@
+package gradle
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/spf13/viper"
+)
+
+const (
+	// The extractor attaches the properties with this prefix to the deployed artifacts, as it does with the build name and number.
+	deployPropPrefix = "deploy."
+	// The properties of the deployer section of the Gradle configuration, in the form of "key1=value1;key2=value2;...".
+	deployerPropsConfig = build.DeployerPrefix + "props"
+)
+
+// setTargetProps adds the properties to attach to the deployed artifacts to the extractor properties. The properties are read from the
+// deployer section of the configuration, and from targetProps, which overrides the values of the same keys.
+func setTargetProps(props map[string]string, vConfig *viper.Viper, targetProps string) error {
+	properties, err := mergeTargetProps(vConfig.GetString(deployerPropsConfig), targetProps)
+	if err != nil {
+		return err
+	}
+	for key, values := range properties.ToMap() {
+		setExtractorProp(props, deployPropPrefix+key, strings.Join(values, ","))
+	}
+	return nil
+}
+
+// mergeTargetProps parses the deployer properties of the configuration and the target properties, whose values override
+// the values of the same keys.
+func mergeTargetProps(deployerProps, targetProps string) (*utils.Properties, error) {
+	merged := make(map[string][]string)
+	for _, propsStr := range []string{deployerProps, targetProps} {
+		if propsStr == "" {
+			continue
+		}
+		properties, err := utils.ParseProperties(propsStr)
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range properties.ToMap() {
+			merged[key] = values
+		}
+	}
+	properties := utils.NewProperties()
+	for key, values := range merged {
+		for _, value := range values {
+			properties.AddProperty(key, value)
+		}
+	}
+	return properties, nil
+}
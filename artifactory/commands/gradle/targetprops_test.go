@@ -0,0 +1,30 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTargetProps(t *testing.T) {
+	vConfig := viper.New()
+	props := map[string]string{}
+	require.NoError(t, setTargetProps(props, vConfig, ""))
+	assert.Empty(t, props)
+
+	vConfig.Set(deployerPropsConfig, "team=platform;env=staging")
+	require.NoError(t, setTargetProps(props, vConfig, "env=production;os=linux,darwin"))
+	assert.Equal(t, map[string]string{
+		"deploy.team":             "platform",
+		"artifactory.deploy.team": "platform",
+		// The target props override the configuration.
+		"deploy.env":             "production",
+		"artifactory.deploy.env": "production",
+		"deploy.os":              "linux,darwin",
+		"artifactory.deploy.os":  "linux,darwin",
+	}, props)
+
+	assert.Error(t, setTargetProps(map[string]string{}, viper.New(), "invalid"))
+}
@@ -0,0 +1,55 @@
+package gradle
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// VerifyResolution checks that Gradle can resolve from the repositories of the init script, by requesting the URLs the init script
+// configures with the credentials it configures. A broken setup is reported with a hint to fix it, rather than failing the first build.
+func VerifyResolution(serverDetails *config.ServerDetails, repoNames []string, username, password string) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	artifactoryUrl := strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/")
+	for _, repoName := range repoNames {
+		repoUrl := artifactoryUrl + "/" + repoName + "/"
+		log.Debug("Verifying the Gradle resolution from", repoUrl)
+		resp, _, _, err := servicesManager.Client().SendGet(repoUrl, true, &httputils.HttpClientDetails{User: username, Password: password})
+		if err != nil {
+			return errorutils.CheckErrorf("failed to connect to %s: %s\nCheck the Artifactory URL of the server configuration, and the proxy and the certificates of this machine.", repoUrl, err.Error())
+		}
+		if err = resolutionError(resp.StatusCode, repoName, artifactoryUrl, username); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Verified that Gradle can resolve from the '%s' repository.", repoName))
+	}
+	return nil
+}
+
+// resolutionError returns the error of a failed resolution, with a hint to fix it, or nil if the resolution succeeded.
+func resolutionError(statusCode int, repoName, artifactoryUrl, username string) error {
+	switch {
+	case statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices:
+		return nil
+	case statusCode == http.StatusUnauthorized:
+		return errorutils.CheckErrorf("Artifactory rejected the credentials of the Gradle init script when resolving from the '%s' repository.\n"+
+			"Check that the access token or the password of the server configuration is valid and hasn't expired, and run the setup again.", repoName)
+	case statusCode == http.StatusForbidden:
+		return errorutils.CheckErrorf("the user '%s' isn't allowed to resolve from the '%s' repository.\n"+
+			"Ask an Artifactory administrator to grant the user the read permission on the repository.", username, repoName)
+	case statusCode == http.StatusNotFound:
+		return errorutils.CheckErrorf("the '%s' repository wasn't found in %s.\n"+
+			"Check the name of the repository, and that it's a Gradle or a Maven repository which the user can access.", repoName, artifactoryUrl)
+	default:
+		return errorutils.CheckErrorf("resolving from the '%s' repository failed with status %d.", repoName, statusCode)
+	}
+}
@@ -0,0 +1,53 @@
+package gradle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyResolution(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		switch {
+		case !ok || password != "valid-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/artifactory/gradle-virtual/":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/artifactory/restricted/" && username == "reader":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+
+	tests := []struct {
+		name     string
+		repoName string
+		password string
+		errorMsg string
+	}{
+		{"resolved", "gradle-virtual", "valid-token", ""},
+		{"invalid credentials", "gradle-virtual", "expired-token", "rejected the credentials"},
+		{"no permission", "restricted", "valid-token", "isn't allowed to resolve from the 'restricted' repository"},
+		{"missing repository", "gradle-typo", "valid-token", "the 'gradle-typo' repository wasn't found"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyResolution(serverDetails, []string{test.repoName}, "reader", test.password)
+			if test.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.errorMsg)
+			}
+		})
+	}
+
+	err := VerifyResolution(&config.ServerDetails{ArtifactoryUrl: "http://127.0.0.1:1/artifactory/"}, []string{"gradle-virtual"}, "reader", "valid-token")
+	assert.ErrorContains(t, err, "failed to connect to http://127.0.0.1:1/artifactory/gradle-virtual/")
+}
@@ -0,0 +1,170 @@
+package mvn
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The Maven extractor deploys the artifacts one by one. With more threads, the extractor only lists the artifacts,
+// and the CLI deploys them concurrently after the build.
+func (mc *MvnCommand) isParallelDeployment() bool {
+	return !mc.deploymentDisabled && mc.threads > 1
+}
+
+// isCliDeployment returns true if the CLI deploys the artifacts listed by the extractor after the build, either concurrently,
+// or without the artifacts excluded by SetExcludeArtifacts.
+func (mc *MvnCommand) isCliDeployment() bool {
+	return mc.isParallelDeployment() || (!mc.deploymentDisabled && len(mc.artifactExclusions) > 0)
+}
+
+// deploymentRepo returns the repository to which the artifacts, for which the extractor didn't list the target repository,
+// are deployed: the snapshot repository of the configuration for snapshot versions, and the release repository otherwise.
+func deploymentRepo(vConfig *viper.Viper) func(artifact clientutils.DeployableArtifactDetails) string {
+	snapshotRepo := vConfig.GetString(build.DeployerPrefix + build.SnapshotRepo)
+	releaseRepo := vConfig.GetString(build.DeployerPrefix + build.ReleaseRepo)
+	return func(artifact clientutils.DeployableArtifactDetails) string {
+		if artifact.TargetRepository != "" {
+			return artifact.TargetRepository
+		}
+		if snapshotRepo != "" && strings.Contains(artifact.ArtifactDest, "-SNAPSHOT") {
+			return snapshotRepo
+		}
+		return releaseRepo
+	}
+}
+
+// deployArtifacts deploys the artifacts listed in the deployable artifacts file, uploading up to mc.threads artifacts concurrently.
+// The artifacts of all the modules are deployed first, and then the POMs of the modules whose artifacts were all deployed, so that
+// a module's POM is never in Artifactory without its artifacts. The deployable artifacts file is updated with the deployed artifacts.
+func (mc *MvnCommand) deployArtifacts(vConfig *viper.Viper) error {
+	if _, err := mc.ServerDetails(); err != nil {
+		return err
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(mc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	buildProps, err := mc.createBuildProps()
+	if err != nil {
+		return err
+	}
+	threads := max(mc.threads, 1)
+	servicesManager, err := utils.CreateServiceManagerWithThreads(mc.serverDetails, false, threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	getRepo := deploymentRepo(vConfig)
+	artifacts, poms := splitPoms(modules)
+	log.Info(fmt.Sprintf("Deploying %d artifacts of %d modules using %d threads...", len(artifacts)+len(poms), len(modules), threads))
+	if err = uploadDeployableArtifacts(servicesManager, modules, artifacts, getRepo, buildProps); err != nil {
+		return err
+	}
+	if err = uploadDeployableArtifacts(servicesManager, modules, completedModulePoms(modules), getRepo, buildProps); err != nil {
+		return err
+	}
+	if err = artifactoryutils.WriteDeployableArtifacts(mc.buildArtifactsDetailsFile, modules); err != nil {
+		return err
+	}
+	total, failed := 0, 0
+	for _, moduleArtifacts := range modules {
+		for _, artifact := range moduleArtifacts {
+			total++
+			if !artifact.DeploySucceeded {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to deploy %d of the %d Maven artifacts", failed, total)
+	}
+	return nil
+}
+
+// createBuildProps returns the build properties attached to the deployed artifacts, if the build-info is collected.
+func (mc *MvnCommand) createBuildProps() (string, error) {
+	if mc.configuration == nil {
+		return "", nil
+	}
+	isCollect, err := mc.configuration.IsCollectBuildInfo()
+	if err != nil || !isCollect {
+		return "", err
+	}
+	return build.CreateBuildPropsFromConfiguration(mc.configuration)
+}
+
+func isPom(artifact clientutils.DeployableArtifactDetails) bool {
+	return strings.HasSuffix(artifact.ArtifactDest, ".pom")
+}
+
+// splitPoms splits the artifacts of the modules into their POMs and the other artifacts, in the order of the modules.
+func splitPoms(modules map[string][]clientutils.DeployableArtifactDetails) (artifacts, poms []clientutils.DeployableArtifactDetails) {
+	for _, moduleName := range artifactoryutils.SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			if isPom(artifact) {
+				poms = append(poms, artifact)
+			} else {
+				artifacts = append(artifacts, artifact)
+			}
+		}
+	}
+	return
+}
+
+// completedModulePoms returns the POMs of the modules whose other artifacts were all deployed.
+func completedModulePoms(modules map[string][]clientutils.DeployableArtifactDetails) (poms []clientutils.DeployableArtifactDetails) {
+	for _, moduleName := range artifactoryutils.SortedModuleNames(modules) {
+		completed := true
+		var modulePoms []clientutils.DeployableArtifactDetails
+		for _, artifact := range modules[moduleName] {
+			if isPom(artifact) {
+				modulePoms = append(modulePoms, artifact)
+			} else if !artifact.DeploySucceeded {
+				completed = false
+			}
+		}
+		if !completed {
+			if len(modulePoms) > 0 {
+				log.Warn("Skipping the deployment of the POM of the", moduleName, "module, because some of its artifacts weren't deployed.")
+			}
+			continue
+		}
+		poms = append(poms, modulePoms...)
+	}
+	return
+}
+
+// uploadDeployableArtifacts uploads the artifacts concurrently, and marks the uploaded artifacts of the modules as deployed.
+func uploadDeployableArtifacts(servicesManager artifactory.ArtifactoryServicesManager, modules map[string][]clientutils.DeployableArtifactDetails,
+	artifacts []clientutils.DeployableArtifactDetails, getRepo func(artifact clientutils.DeployableArtifactDetails) string, buildProps string) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	var params []services.UploadParams
+	for _, artifact := range artifacts {
+		uploadParams := services.NewUploadParams()
+		uploadParams.Pattern = artifact.SourcePath
+		uploadParams.Target = path.Join(getRepo(artifact), artifact.ArtifactDest)
+		uploadParams.BuildProps = buildProps
+		uploadParams.Flat = true
+		params = append(params, uploadParams)
+	}
+	operationSummary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, params...)
+	if operationSummary == nil {
+		return err
+	}
+	// The artifacts which failed to upload are logged, and are counted by the caller.
+	err = artifactoryutils.MarkDeployed(modules, operationSummary.TransferDetailsReader)
+	return errors.Join(err, operationSummary.ArtifactsDetailsReader.Close(), operationSummary.TransferDetailsReader.Close())
+}
@@ -0,0 +1,97 @@
+package mvn
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployArtifacts(t *testing.T) {
+	var mutex sync.Mutex
+	var deployed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/lib-1.0.jar") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mutex.Lock()
+		deployed = append(deployed, strings.TrimPrefix(r.URL.Path, "/artifactory/"))
+		mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sourcePath := func(name string) string {
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(name), 0644))
+		return filePath
+	}
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		"org:app": {
+			{SourcePath: sourcePath("app-1.0.pom"), ArtifactDest: "org/app/1.0/app-1.0.pom"},
+			{SourcePath: sourcePath("app-1.0.jar"), ArtifactDest: "org/app/1.0/app-1.0.jar"},
+			{SourcePath: sourcePath("app-1.0-sources.jar"), ArtifactDest: "org/app/1.0/app-1.0-sources.jar"},
+		},
+		"org:lib": {
+			{SourcePath: sourcePath("lib-1.0.jar"), ArtifactDest: "org/lib/1.0/lib-1.0.jar"},
+			{SourcePath: sourcePath("lib-1.0.pom"), ArtifactDest: "org/lib/1.0/lib-1.0.pom"},
+		},
+		"org:snapshot": {
+			{SourcePath: sourcePath("snapshot-1.0-SNAPSHOT.pom"), ArtifactDest: "org/snapshot/1.0-SNAPSHOT/snapshot-1.0-SNAPSHOT.pom", TargetRepository: "custom-local"},
+		},
+	}
+	deployableArtifactsFile := filepath.Join(dir, "deployable-artifacts.json")
+	require.NoError(t, artifactoryutils.WriteDeployableArtifacts(deployableArtifactsFile, modules))
+
+	vConfig := viper.New()
+	vConfig.Set(build.DeployerPrefix+build.ReleaseRepo, "libs-release-local")
+	vConfig.Set(build.DeployerPrefix+build.SnapshotRepo, "libs-snapshot-local")
+	mc := NewMvnCommand().SetThreads(3).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+	mc.buildArtifactsDetailsFile = deployableArtifactsFile
+	assert.True(t, mc.isParallelDeployment())
+
+	err := mc.deployArtifacts(vConfig)
+	assert.ErrorContains(t, err, "failed to deploy 2 of the 6 Maven artifacts")
+
+	// The POM of a module is deployed after its artifacts, and isn't deployed if one of them failed.
+	require.Len(t, deployed, 4)
+	assert.ElementsMatch(t, []string{"libs-release-local/org/app/1.0/app-1.0.jar", "libs-release-local/org/app/1.0/app-1.0-sources.jar"}, deployed[:2])
+	assert.ElementsMatch(t, []string{"libs-release-local/org/app/1.0/app-1.0.pom", "custom-local/org/snapshot/1.0-SNAPSHOT/snapshot-1.0-SNAPSHOT.pom"}, deployed[2:])
+
+	modules, err = artifactoryutils.ReadDeployableArtifacts(deployableArtifactsFile)
+	require.NoError(t, err)
+	for _, artifact := range modules["org:app"] {
+		assert.True(t, artifact.DeploySucceeded, artifact.ArtifactDest)
+	}
+	for _, artifact := range modules["org:lib"] {
+		assert.False(t, artifact.DeploySucceeded, artifact.ArtifactDest)
+	}
+	assert.True(t, modules["org:snapshot"][0].DeploySucceeded)
+}
+
+func TestIsParallelDeployment(t *testing.T) {
+	mc := NewMvnCommand().SetThreads(1)
+	assert.False(t, mc.isParallelDeployment())
+	mc.SetThreads(3)
+	mc.deploymentDisabled = true
+	assert.False(t, mc.isParallelDeployment())
+}
@@ -0,0 +1,157 @@
+package mvn
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// DeployArtifactsGoal is the goal of 'jf mvn deploy-artifacts --from-file=<path>', which deploys the artifacts listed by the
+// deployable artifacts file of a previous build, instead of running a build.
+const DeployArtifactsGoal = "deploy-artifacts"
+
+// SetDeployableArtifactsOutput sets the file to which the artifacts of the build are listed, instead of being deployed.
+// The artifacts can then be deployed by SetDeployFromFile, for example by a later stage of a CI pipeline, which has the
+// artifacts in the paths the build wrote them to.
+func (mc *MvnCommand) SetDeployableArtifactsOutput(deployableArtifactsOutput string) *MvnCommand {
+	mc.deployableArtifactsOutput = deployableArtifactsOutput
+	return mc
+}
+
+// SetDeployFromFile sets the deployable artifacts file of a previous build. Instead of running a build, the artifacts the file
+// lists as not deployed are deployed, using the deployer of the Maven configuration, and the file is updated with the deployed artifacts.
+func (mc *MvnCommand) SetDeployFromFile(deployFromFile string) *MvnCommand {
+	mc.deployFromFile = deployFromFile
+	return mc
+}
+
+func (mc *MvnCommand) validateDeployableArtifactsOutput(vConfig *viper.Viper) error {
+	if mc.deployableArtifactsOutput == "" {
+		return nil
+	}
+	if mc.IsXrayScan() {
+		return errorutils.CheckErrorf("the --save-deployable-artifacts and --scan options can't be used together. Scan the artifacts when deploying them by '%s'", DeployArtifactsGoal)
+	}
+	if !vConfig.IsSet("deployer") {
+		return errorutils.CheckErrorf("the deployable artifacts can only be saved if deployer is set in the config")
+	}
+	return nil
+}
+
+// runDeployFromFile deploys the artifacts the deployable artifacts file lists as not deployed, or scans them by Xray and deploys
+// them if the scan passes.
+func (mc *MvnCommand) runDeployFromFile() error {
+	if len(mc.goals) > 0 && (len(mc.goals) != 1 || mc.goals[0] != DeployArtifactsGoal) {
+		return errorutils.CheckErrorf("the artifacts listed by --from-file are deployed instead of running a build, so Maven goals can't be provided")
+	}
+	vConfig, err := build.ReadMavenConfig(mc.configPath, nil)
+	if err != nil {
+		return err
+	}
+	if !vConfig.IsSet("deployer") {
+		return errorutils.CheckErrorf("the artifacts of a deployable artifacts file can only be deployed if deployer is set in the config")
+	}
+	if _, err = mc.ServerDetails(); err != nil {
+		return err
+	}
+	modules, err := artifactoryutils.ReadDeployableArtifacts(mc.deployFromFile)
+	if err != nil {
+		return err
+	}
+	pending, err := pendingArtifacts(modules)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		log.Info("All the artifacts listed by", mc.deployFromFile, "were already deployed.")
+		return nil
+	}
+	// The result of the command is read from the deployable artifacts file, which is then overwritten. The pending artifacts are
+	// therefore deployed from a copy of the file, and the deployed ones are marked in the original file.
+	tempFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return err
+	}
+	mc.buildArtifactsDetailsFile = tempFile.Name()
+	if err = errorutils.CheckError(tempFile.Close()); err != nil {
+		return err
+	}
+	if err = artifactoryutils.WriteDeployableArtifacts(mc.buildArtifactsDetailsFile, pending); err != nil {
+		return err
+	}
+	if !mc.IsXrayScan() {
+		err = mc.deployArtifacts(vConfig)
+		if markErr := mc.markDeployedInFile(modules); markErr != nil {
+			return errors.Join(err, markErr)
+		}
+		if err != nil {
+			return errorutils.CheckErrorf("%s\nTo retry the deployment of the remaining artifacts, run 'jf mvn %s --from-file=%s' again.",
+				err.Error(), DeployArtifactsGoal, mc.deployFromFile)
+		}
+	}
+	return mc.processDeployableArtifacts(vConfig)
+}
+
+// pendingArtifacts returns the artifacts of the modules which weren't deployed. The artifacts are deployed from the paths the build
+// wrote them to, so they must still be there.
+func pendingArtifacts(modules map[string][]clientutils.DeployableArtifactDetails) (map[string][]clientutils.DeployableArtifactDetails, error) {
+	pending := make(map[string][]clientutils.DeployableArtifactDetails)
+	var missing []string
+	for _, moduleName := range artifactoryutils.SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			if artifact.DeploySucceeded {
+				continue
+			}
+			exists, err := fileutils.IsFileExists(filepath.FromSlash(artifact.SourcePath), false)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			if !exists {
+				missing = append(missing, artifact.SourcePath)
+			}
+			pending[moduleName] = append(pending[moduleName], artifact)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errorutils.CheckErrorf("%d of the artifacts to deploy weren't found, such as %s. "+
+			"The artifacts are deployed from the paths the build wrote them to, so they must be kept or restored there", len(missing), missing[0])
+	}
+	return pending, nil
+}
+
+// markDeployedInFile marks the artifacts deployed from the copy of the deployable artifacts file in the original file.
+func (mc *MvnCommand) markDeployedInFile(modules map[string][]clientutils.DeployableArtifactDetails) error {
+	pending, err := artifactoryutils.ReadDeployableArtifacts(mc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	markDeployedArtifacts(modules, pending)
+	return artifactoryutils.WriteDeployableArtifacts(mc.deployFromFile, modules)
+}
+
+// markDeployedArtifacts marks the artifacts of the modules which were deployed from the pending artifacts.
+func markDeployedArtifacts(modules, pending map[string][]clientutils.DeployableArtifactDetails) {
+	deployed := make(map[string]bool)
+	for _, artifacts := range pending {
+		for _, artifact := range artifacts {
+			if artifact.DeploySucceeded {
+				deployed[fmt.Sprintf("%s|%s", artifact.SourcePath, artifact.ArtifactDest)] = true
+			}
+		}
+	}
+	for _, artifacts := range modules {
+		for i := range artifacts {
+			if deployed[fmt.Sprintf("%s|%s", artifacts[i].SourcePath, artifacts[i].ArtifactDest)] {
+				artifacts[i].DeploySucceeded = true
+			}
+		}
+	}
+}
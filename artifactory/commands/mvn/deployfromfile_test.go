@@ -0,0 +1,59 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingArtifacts(t *testing.T) {
+	targetDir := t.TempDir()
+	jar := filepath.Join(targetDir, "app-1.0.jar")
+	pom := filepath.Join(targetDir, "app-1.0.pom")
+	require.NoError(t, os.WriteFile(jar, []byte("jar"), 0644))
+	require.NoError(t, os.WriteFile(pom, []byte("pom"), 0644))
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		"org.acme:app:1.0": {
+			{SourcePath: jar, ArtifactDest: "org/acme/app/1.0/app-1.0.jar"},
+			{SourcePath: pom, ArtifactDest: "org/acme/app/1.0/app-1.0.pom"},
+		},
+		"org.acme:lib:1.0": {
+			{SourcePath: filepath.Join(targetDir, "deleted.jar"), ArtifactDest: "org/acme/lib/1.0/lib-1.0.jar", DeploySucceeded: true},
+		},
+	}
+
+	pending, err := pendingArtifacts(modules)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]clientutils.DeployableArtifactDetails{"org.acme:app:1.0": modules["org.acme:app:1.0"]}, pending)
+
+	require.NoError(t, os.Remove(jar))
+	_, err = pendingArtifacts(modules)
+	assert.ErrorContains(t, err, "1 of the artifacts to deploy weren't found, such as "+jar)
+}
+
+func TestMarkDeployedArtifacts(t *testing.T) {
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		"org.acme:app:1.0": {
+			{SourcePath: "/target/app-1.0.jar", ArtifactDest: "org/acme/app/1.0/app-1.0.jar"},
+			{SourcePath: "/target/app-1.0.pom", ArtifactDest: "org/acme/app/1.0/app-1.0.pom"},
+		},
+	}
+	pending := map[string][]clientutils.DeployableArtifactDetails{
+		"org.acme:app:1.0": {
+			{SourcePath: "/target/app-1.0.jar", ArtifactDest: "org/acme/app/1.0/app-1.0.jar", DeploySucceeded: true},
+			{SourcePath: "/target/app-1.0.pom", ArtifactDest: "org/acme/app/1.0/app-1.0.pom"},
+		},
+	}
+	markDeployedArtifacts(modules, pending)
+	assert.True(t, modules["org.acme:app:1.0"][0].DeploySucceeded)
+	assert.False(t, modules["org.acme:app:1.0"][1].DeploySucceeded)
+}
+
+func TestRunDeployFromFileWithGoals(t *testing.T) {
+	err := NewMvnCommand().SetDeployFromFile("deployable-artifacts.json").SetGoals([]string{"clean", "install"}).runDeployFromFile()
+	assert.ErrorContains(t, err, "Maven goals can't be provided")
+}
@@ -0,0 +1,327 @@
+package mvn
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	effectivePomGoal   = "help:effective-pom"
+	effectivePomSuffix = "-effective-pom.xml"
+)
+
+// effectivePom holds the effective POM of a single module, as generated by the maven-help-plugin.
+type effectivePom struct {
+	GroupId    string
+	ArtifactId string
+	Version    string
+	Content    []byte
+}
+
+// ModuleId returns the module's ID in the same format used by the Maven build-info extractor.
+func (ep *effectivePom) ModuleId() string {
+	return ep.GroupId + ":" + ep.ArtifactId + ":" + ep.Version
+}
+
+// FileName returns the name under which the effective POM is deployed, next to the module's artifacts.
+func (ep *effectivePom) FileName() string {
+	return ep.ArtifactId + "-" + ep.Version + effectivePomSuffix
+}
+
+// RepoPath returns the effective POM's path inside the deployment repository, following the Maven layout.
+func (ep *effectivePom) RepoPath() string {
+	return path.Join(strings.ReplaceAll(ep.GroupId, ".", "/"), ep.ArtifactId, ep.Version, ep.FileName())
+}
+
+type effectivePomProject struct {
+	XMLName    xml.Name
+	Attrs      []xml.Attr `xml:",any,attr"`
+	GroupId    string     `xml:"groupId"`
+	ArtifactId string     `xml:"artifactId"`
+	Version    string     `xml:"version"`
+	InnerXml   []byte     `xml:",innerxml"`
+}
+
+type effectivePomProjects struct {
+	Projects []effectivePomProject `xml:"project"`
+}
+
+// createEffectivePomOutputFile creates the file to which the maven-help-plugin writes the effective POM.
+func createEffectivePomOutputFile() (string, error) {
+	tempFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return "", err
+	}
+	if err = tempFile.Close(); errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	// If this is a Windows machine there is a need to modify the path to match Java syntax with double \\
+	return ioutils.DoubleWinPathSeparator(tempFile.Name()), nil
+}
+
+// Maven options whose value is the next argument, rather than a part of the option itself.
+var mvnOptionsWithValue = map[string]bool{
+	"-f": true, "--file": true, "-s": true, "--settings": true, "-gs": true, "--global-settings": true,
+	"-t": true, "--toolchains": true, "-gt": true, "--global-toolchains": true, "-P": true, "--activate-profiles": true,
+	"-pl": true, "--projects": true, "-rf": true, "--resume-from": true, "-T": true, "--threads": true,
+	"-b": true, "--builder": true, "-D": true, "--define": true, "-l": true, "--log-file": true,
+}
+
+// effectivePomArgs returns the arguments of the Maven invocation which writes the effective POMs to the output file.
+// The effective POMs are generated by an invocation of their own, with the options of the build but without its goals,
+// since the output parameter of the maven-help-plugin is set by a user property, which applies to all the plugins of
+// an invocation. The log file of the build isn't passed, so that it isn't overwritten.
+func effectivePomArgs(goals []string, outputFile string) []string {
+	var args []string
+	for i := 0; i < len(goals); i++ {
+		if !strings.HasPrefix(goals[i], "-") {
+			continue
+		}
+		option := goals[i]
+		hasValue := mvnOptionsWithValue[option] && i+1 < len(goals)
+		if option == "-l" || option == "--log-file" || strings.HasPrefix(option, "--log-file=") {
+			if hasValue {
+				i++
+			}
+			continue
+		}
+		args = append(args, option)
+		if hasValue {
+			i++
+			args = append(args, goals[i])
+		}
+	}
+	return append(args, effectivePomGoal, "-Doutput="+outputFile)
+}
+
+// generateEffectivePoms runs the maven-help-plugin after the build, to write the effective POMs of the build's modules to
+// the effective POM file. The invocation resolves from the same repositories as the build, and neither deploys nor
+// collects the build-info.
+func (mc *MvnCommand) generateEffectivePoms(goals []string) error {
+	// The configuration is read again, since it's modified by the build's invocation.
+	vConfig, err := build.ReadMavenConfig(mc.configPath, nil)
+	if err != nil {
+		return err
+	}
+	log.Info("Generating the effective POMs of the modules...")
+	mvnParams := NewMvnUtils().
+		SetConfigPath(mc.configPath).
+		SetConfig(vConfig).
+		SetBuildConf(build.NewBuildConfiguration("", "", "", "")).
+		SetGoals(effectivePomArgs(goals, mc.effectivePomFile)).
+		SetInsecureTls(mc.insecureTls).
+		SetDisableDeploy(true).
+		SetUseMvnd(mc.useMvnd)
+	return mc.runMvn(mvnParams)
+}
+
+// parseEffectivePoms splits the output of the maven-help-plugin into the effective POMs of the modules.
+// For a single module build the output root is a <project> element, and for a multi-module build it is
+// a <projects> element wrapping a <project> element per module.
+func parseEffectivePoms(content []byte) ([]effectivePom, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the effective POM: %s", err.Error())
+	}
+	var projects []effectivePomProject
+	switch root.XMLName.Local {
+	case "project":
+		project := effectivePomProject{}
+		if err := xml.Unmarshal(content, &project); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the effective POM: %s", err.Error())
+		}
+		projects = append(projects, project)
+	case "projects":
+		wrapper := effectivePomProjects{}
+		if err := xml.Unmarshal(content, &wrapper); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the effective POM: %s", err.Error())
+		}
+		projects = wrapper.Projects
+	default:
+		return nil, errorutils.CheckErrorf("unexpected root element '%s' in the effective POM", root.XMLName.Local)
+	}
+
+	var poms []effectivePom
+	for _, project := range projects {
+		if project.GroupId == "" || project.ArtifactId == "" || project.Version == "" {
+			log.Debug("Skipping an effective POM with incomplete coordinates: " + project.GroupId + ":" + project.ArtifactId + ":" + project.Version)
+			continue
+		}
+		poms = append(poms, effectivePom{
+			GroupId:    project.GroupId,
+			ArtifactId: project.ArtifactId,
+			Version:    project.Version,
+			Content:    project.toXml(),
+		})
+	}
+	return poms, nil
+}
+
+// toXml rebuilds a standalone XML document of the project, preserving its namespace declarations.
+func (p *effectivePomProject) toXml() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<project")
+	for _, attr := range p.Attrs {
+		name := attr.Name.Local
+		if attr.Name.Space != "" {
+			// Attributes such as xmlns:xsi and xsi:schemaLocation are reported with their namespace URL as the space.
+			name = namespacePrefix(attr.Name.Space, p.Attrs) + ":" + name
+		}
+		buf.WriteString(" " + name + "=\"")
+		_ = xml.EscapeText(&buf, []byte(attr.Value))
+		buf.WriteString("\"")
+	}
+	if p.XMLName.Space != "" && !hasDefaultNamespace(p.Attrs) {
+		buf.WriteString(" xmlns=\"" + p.XMLName.Space + "\"")
+	}
+	buf.WriteString(">")
+	buf.Write(p.InnerXml)
+	buf.WriteString("</project>\n")
+	return buf.Bytes()
+}
+
+func namespacePrefix(space string, attrs []xml.Attr) string {
+	if space == "xmlns" {
+		return space
+	}
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" && attr.Value == space {
+			return attr.Name.Local
+		}
+	}
+	return space
+}
+
+func hasDefaultNamespace(attrs []xml.Attr) bool {
+	for _, attr := range attrs {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			return true
+		}
+	}
+	return false
+}
+
+// deployEffectivePoms deploys the captured effective POMs next to the artifacts of their modules,
+// and adds them to the modules' artifacts in the build-info.
+func (mc *MvnCommand) deployEffectivePoms(vConfig *viper.Viper, buildInfoFilePath string) error {
+	content, err := os.ReadFile(mc.effectivePomFile)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read the effective POM file: %s", err.Error())
+	}
+	// The file remains empty if the maven-help-plugin found no modules to write the effective POMs of.
+	if len(content) == 0 {
+		log.Warn("No effective POM was generated by the build.")
+		return nil
+	}
+	poms, err := parseEffectivePoms(content)
+	if err != nil {
+		return err
+	}
+	if mc.deploymentDisabled {
+		log.Warn("The effective POMs were captured but not deployed, since the build does not deploy artifacts to Artifactory.")
+		return nil
+	}
+
+	snapshotRepository := vConfig.GetString(build.DeployerPrefix + build.SnapshotRepo)
+	releaseRepository := vConfig.GetString(build.DeployerPrefix + build.ReleaseRepo)
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(tempDir); e != nil {
+			log.Debug("Failed to remove the effective POMs temp directory: " + e.Error())
+		}
+	}()
+	uploadSpec := new(spec.SpecFiles)
+	artifactsByModule := make(map[string]entities.Artifact, len(poms))
+	for _, pom := range poms {
+		localPath := filepath.Join(tempDir, pom.FileName())
+		if err = os.WriteFile(localPath, pom.Content, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+		artifact := entities.Artifact{Name: pom.FileName(), Type: "xml", Path: pom.RepoPath()}
+		updateArtifactRepo(&artifact, snapshotRepository, releaseRepository)
+		details, err := fileutils.GetFileDetails(localPath, true)
+		if err != nil {
+			return err
+		}
+		artifact.Checksum = details.Checksum
+		artifactsByModule[pom.ModuleId()] = artifact
+		uploadSpec.Files = append(uploadSpec.Files, spec.File{
+			Pattern: localPath,
+			Target:  artifact.OriginalDeploymentRepo + "/" + artifact.Path,
+			Flat:    "true",
+		})
+	}
+
+	serverDetails, err := build.GetServerDetails(vConfig)
+	if err != nil {
+		return err
+	}
+	log.Info("Deploying the effective POMs of", len(poms), "modules...")
+	uploadCmd := generic.NewUploadCommand()
+	uploadConfiguration := new(utils.UploadConfiguration)
+	uploadConfiguration.Threads = mc.threads
+	// The effective POMs are added to the Maven modules in the build-info below, rather than by the upload command.
+	uploadCmd.SetUploadConfiguration(uploadConfiguration).SetSpec(uploadSpec).SetServerDetails(serverDetails)
+	if err = uploadCmd.Run(); err != nil {
+		return err
+	}
+	if uploadCmd.Result().FailCount() > 0 {
+		return errorutils.CheckErrorf("failed to deploy %d of the effective POMs", uploadCmd.Result().FailCount())
+	}
+	return addEffectivePomsToBuildInfo(buildInfoFilePath, artifactsByModule)
+}
+
+// addEffectivePomsToBuildInfo adds the deployed effective POMs to the artifacts of their modules in the build-info temp file.
+func addEffectivePomsToBuildInfo(buildInfoFilePath string, artifactsByModule map[string]entities.Artifact) error {
+	if buildInfoFilePath == "" {
+		return nil
+	}
+	exists, err := fileutils.IsFileExists(buildInfoFilePath, false)
+	if err != nil || !exists {
+		return err
+	}
+	content, err := os.ReadFile(buildInfoFilePath)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to read build info file: %s", err.Error())
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	buildInfo := new(entities.BuildInfo)
+	if err = json.Unmarshal(content, &buildInfo); err != nil {
+		return errorutils.CheckErrorf("failed to parse build info file: %s", err.Error())
+	}
+	for moduleIndex := range buildInfo.Modules {
+		currModule := &buildInfo.Modules[moduleIndex]
+		if artifact, ok := artifactsByModule[currModule.Id]; ok {
+			currModule.Artifacts = append(currModule.Artifacts, artifact)
+		}
+	}
+	newBuildInfo, err := json.Marshal(buildInfo)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to marshal build info: %s", err.Error())
+	}
+	return os.WriteFile(buildInfoFilePath, newBuildInfo, 0644)
+}
@@ -0,0 +1,153 @@
+package mvn
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The excludeArtifacts list of the deployer of the Maven configuration, with the patterns of the artifacts which aren't deployed,
+// in addition to the patterns of SetExcludeArtifacts. For example:
+//
+//	deployer:
+//	  releaseRepo: libs-release-local
+//	  excludeArtifacts:
+//	    - tests
+//	    - javadoc
+//	    - "*:zip"
+const deployerExcludeArtifactsConfig = build.DeployerPrefix + "excludeArtifacts"
+
+// Matches the timestamp and the build number of the unique versions of the snapshot artifacts, such as -20240131.120000-1.
+var snapshotTimestampPattern = regexp.MustCompile(`^-\d{8}\.\d{6}-\d+`)
+
+// artifactPattern matches the artifacts by their classifiers and extensions. The patterns may include the * and ? wildcards.
+type artifactPattern struct {
+	classifier string
+	extension  string
+}
+
+// SetExcludeArtifacts sets the patterns of the attached artifacts which aren't deployed, in the form of <classifier>[:<extension>],
+// such as tests (the test-jar artifacts), javadoc, sources or *:zip. An empty classifier matches the main artifacts of the modules, so :war
+// excludes the WAR files of the modules, but not the WARs attached with a classifier. The excluded artifacts are listed in the detailed summary as filtered.
+func (mc *MvnCommand) SetExcludeArtifacts(excludeArtifacts []string) *MvnCommand {
+	mc.excludeArtifacts = excludeArtifacts
+	return mc
+}
+
+// readArtifactExclusions returns the patterns of the artifacts excluded from the deployment by SetExcludeArtifacts and by the configuration.
+func (mc *MvnCommand) readArtifactExclusions(vConfig *viper.Viper) ([]artifactPattern, error) {
+	patterns := append(append([]string{}, mc.excludeArtifacts...), vConfig.GetStringSlice(deployerExcludeArtifactsConfig)...)
+	return parseArtifactPatterns(patterns)
+}
+
+func parseArtifactPatterns(patterns []string) ([]artifactPattern, error) {
+	var artifactPatterns []artifactPattern
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		classifier, extension, hasExtension := strings.Cut(pattern, ":")
+		if !hasExtension {
+			extension = "*"
+		}
+		if extension == "" {
+			return nil, errorutils.CheckErrorf("invalid artifacts pattern '%s'. Use the form <classifier>[:<extension>]", pattern)
+		}
+		for _, part := range []string{classifier, extension} {
+			if _, err := path.Match(part, ""); err != nil {
+				return nil, errorutils.CheckErrorf("invalid artifacts pattern '%s': %s", pattern, err.Error())
+			}
+		}
+		artifactPatterns = append(artifactPatterns, artifactPattern{classifier: classifier, extension: extension})
+	}
+	return artifactPatterns, nil
+}
+
+func (ap artifactPattern) matches(classifier, extension string) bool {
+	classifierMatched, _ := path.Match(ap.classifier, classifier)
+	extensionMatched, _ := path.Match(ap.extension, extension)
+	return classifierMatched && extensionMatched
+}
+
+// artifactClassifierAndExtension returns the classifier and the extension of an artifact from its deployment path, which ends with
+// <artifactId>/<version>/<artifactId>-<version>[-<classifier>].<extension>.
+func artifactClassifierAndExtension(artifactDest string) (classifier, extension string) {
+	versionDir, fileName := path.Split(artifactDest)
+	version := path.Base(versionDir)
+	artifactId := path.Base(path.Dir(strings.TrimSuffix(versionDir, "/")))
+	rest, found := strings.CutPrefix(fileName, artifactId+"-"+version)
+	if !found && strings.HasSuffix(version, "-SNAPSHOT") {
+		if rest, found = strings.CutPrefix(fileName, artifactId+"-"+strings.TrimSuffix(version, "-SNAPSHOT")); found {
+			timestamp := snapshotTimestampPattern.FindString(rest)
+			rest, found = rest[len(timestamp):], timestamp != ""
+		}
+	}
+	if !found {
+		// The file doesn't follow the Maven layout, so only its extension is known.
+		if dot := strings.LastIndex(fileName, "."); dot >= 0 {
+			return "", fileName[dot+1:]
+		}
+		return "", ""
+	}
+	if classifierAndExtension, hasClassifier := strings.CutPrefix(rest, "-"); hasClassifier {
+		classifier, extension, _ = strings.Cut(classifierAndExtension, ".")
+		return
+	}
+	return "", strings.TrimPrefix(rest, ".")
+}
+
+func isExcludedArtifact(artifact clientutils.DeployableArtifactDetails, exclusions []artifactPattern) bool {
+	classifier, extension := artifactClassifierAndExtension(artifact.ArtifactDest)
+	for _, exclusion := range exclusions {
+		if exclusion.matches(classifier, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitExcludedArtifacts removes the excluded artifacts from the modules, and returns them.
+func splitExcludedArtifacts(modules map[string][]clientutils.DeployableArtifactDetails, exclusions []artifactPattern) (excluded map[string][]clientutils.DeployableArtifactDetails) {
+	excluded = make(map[string][]clientutils.DeployableArtifactDetails)
+	for moduleName, artifacts := range modules {
+		var kept []clientutils.DeployableArtifactDetails
+		for _, artifact := range artifacts {
+			if isExcludedArtifact(artifact, exclusions) {
+				excluded[moduleName] = append(excluded[moduleName], artifact)
+			} else {
+				kept = append(kept, artifact)
+			}
+		}
+		if len(kept) == 0 {
+			delete(modules, moduleName)
+		} else {
+			modules[moduleName] = kept
+		}
+	}
+	return
+}
+
+// filterDeployableArtifacts removes the excluded artifacts from the deployable artifacts file written by the extractor, before they're
+// deployed, scanned or saved. The excluded artifacts are kept for the detailed summary.
+func (mc *MvnCommand) filterDeployableArtifacts() error {
+	modules, err := artifactoryutils.ReadDeployableArtifacts(mc.buildArtifactsDetailsFile)
+	if err != nil {
+		return err
+	}
+	mc.excludedArtifacts = splitExcludedArtifacts(modules, mc.artifactExclusions)
+	for _, moduleName := range artifactoryutils.SortedModuleNames(mc.excludedArtifacts) {
+		for _, artifact := range mc.excludedArtifacts[moduleName] {
+			log.Info(fmt.Sprintf("Excluding %s of the %s module from the deployment.", artifact.ArtifactDest, moduleName))
+		}
+	}
+	return artifactoryutils.WriteDeployableArtifacts(mc.buildArtifactsDetailsFile, modules)
+}
@@ -0,0 +1,93 @@
+package mvn
+
+import (
+	"path/filepath"
+	"testing"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArtifactPatterns(t *testing.T) {
+	patterns, err := parseArtifactPatterns([]string{"tests", " javadoc ", "*:zip", ":war", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []artifactPattern{{classifier: "tests", extension: "*"}, {classifier: "javadoc", extension: "*"}, {classifier: "*", extension: "zip"}, {classifier: "", extension: "war"}}, patterns)
+
+	_, err = parseArtifactPatterns([]string{"sources:"})
+	assert.ErrorContains(t, err, "invalid artifacts pattern 'sources:'")
+	_, err = parseArtifactPatterns([]string{"tests[:jar"})
+	assert.ErrorContains(t, err, "invalid artifacts pattern 'tests[:jar'")
+}
+
+func TestArtifactClassifierAndExtension(t *testing.T) {
+	tests := []struct {
+		artifactDest string
+		classifier   string
+		extension    string
+	}{
+		{"org/acme/app/1.0/app-1.0.jar", "", "jar"},
+		{"org/acme/app/1.0/app-1.0-tests.jar", "tests", "jar"},
+		{"org/acme/app/1.0/app-1.0-dist.tar.gz", "dist", "tar.gz"},
+		{"org/acme/app/1.0-SNAPSHOT/app-1.0-SNAPSHOT-sources.jar", "sources", "jar"},
+		{"org/acme/app/1.0-SNAPSHOT/app-1.0-20240131.120000-1-javadoc.jar", "javadoc", "jar"},
+		{"org/acme/app/1.0/other.zip", "", "zip"},
+	}
+	for _, test := range tests {
+		t.Run(test.artifactDest, func(t *testing.T) {
+			classifier, extension := artifactClassifierAndExtension(test.artifactDest)
+			assert.Equal(t, test.classifier, classifier)
+			assert.Equal(t, test.extension, extension)
+		})
+	}
+}
+
+func TestFilterDeployableArtifacts(t *testing.T) {
+	modules := map[string][]clientutils.DeployableArtifactDetails{
+		"org.acme:app:1.0": {
+			{SourcePath: "/target/app-1.0.pom", ArtifactDest: "org/acme/app/1.0/app-1.0.pom"},
+			{SourcePath: "/target/app-1.0.war", ArtifactDest: "org/acme/app/1.0/app-1.0.war"},
+			{SourcePath: "/target/app-1.0-tests.jar", ArtifactDest: "org/acme/app/1.0/app-1.0-tests.jar"},
+			{SourcePath: "/target/app-1.0-javadoc.jar", ArtifactDest: "org/acme/app/1.0/app-1.0-javadoc.jar"},
+		},
+	}
+	deployableArtifactsFile := filepath.Join(t.TempDir(), "deployable-artifacts.json")
+	require.NoError(t, artifactoryutils.WriteDeployableArtifacts(deployableArtifactsFile, modules))
+
+	vConfig := viper.New()
+	vConfig.Set(deployerExcludeArtifactsConfig, []string{"javadoc"})
+	mc := NewMvnCommand().SetExcludeArtifacts([]string{"tests"})
+	var err error
+	mc.artifactExclusions, err = mc.readArtifactExclusions(vConfig)
+	require.NoError(t, err)
+	mc.buildArtifactsDetailsFile = deployableArtifactsFile
+	require.NoError(t, mc.filterDeployableArtifacts())
+
+	filtered, err := artifactoryutils.ReadDeployableArtifacts(deployableArtifactsFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]clientutils.DeployableArtifactDetails{"org.acme:app:1.0": modules["org.acme:app:1.0"][:2]}, filtered)
+	assert.Equal(t, map[string][]clientutils.DeployableArtifactDetails{"org.acme:app:1.0": modules["org.acme:app:1.0"][2:]}, mc.excludedArtifacts)
+
+	// The excluded artifacts are listed in the detailed summary as filtered, and aren't counted as failures.
+	vConfig.Set(build.DeployerPrefix+build.ReleaseRepo, "libs-release-local")
+	summary, err := createDeploymentSummary(deployableArtifactsFile, vConfig)
+	require.NoError(t, err)
+	summary.AddFiltered(mc.excludedArtifacts, deploymentRepo(vConfig))
+	require.Len(t, summary.Files, 4)
+	assert.False(t, summary.Files[1].Filtered)
+	assert.True(t, summary.Files[2].Filtered)
+	assert.Equal(t, "libs-release-local/org/acme/app/1.0/app-1.0-tests.jar", summary.Files[2].Target)
+	assert.Equal(t, 2, summary.Totals.Failure)
+}
+
+func TestIsCliDeployment(t *testing.T) {
+	mc := NewMvnCommand()
+	assert.False(t, mc.isCliDeployment())
+	mc.artifactExclusions = []artifactPattern{{classifier: "tests", extension: "*"}}
+	assert.True(t, mc.isCliDeployment())
+	mc.deploymentDisabled = true
+	assert.False(t, mc.isCliDeployment())
+}
@@ -0,0 +1,114 @@
+package mvn
+
+import (
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/toolchain"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// JdkProvisioning is a JDK distribution in Artifactory, which the builds use from the Maven toolchains.
+type JdkProvisioning struct {
+	// The path of the JDK archive in Artifactory, in the form of <repository>/<path>.
+	Path string
+	// The version the toolchain provides, such as 17, which the maven-toolchains-plugin and the toolchains aware plugins require.
+	Version string
+	// The vendor the toolchain provides, such as temurin. Optional.
+	Vendor string
+}
+
+// SetJdk sets the JDK distribution in Artifactory, which is downloaded and cached, and is the JDK toolchain of the build,
+// so that a hermetic build is fully served from Artifactory.
+func (mc *MvnCommand) SetJdk(jdk *JdkProvisioning) *MvnCommand {
+	mc.jdk = jdk
+	return mc
+}
+
+// provisionJdkToolchains provisions the JDK from the Artifactory server of the resolver, and returns the path of the toolchains file of the JDK.
+func (mc *MvnCommand) provisionJdkToolchains(vConfig *viper.Viper) (string, error) {
+	if err := mc.jdk.validate(); err != nil {
+		return "", err
+	}
+	serverDetails, err := mc.ServerDetails()
+	if err != nil {
+		return "", err
+	}
+	if vConfig.IsSet(build.ResolverPrefix + build.ServerId) {
+		if serverDetails, err = config.GetSpecificConfig(vConfig.GetString(build.ResolverPrefix+build.ServerId), true, true); err != nil {
+			return "", err
+		}
+	}
+	if serverDetails == nil {
+		return "", errorutils.CheckErrorf("the JDK can only be provisioned if resolver or deployer is set in the config")
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return "", err
+	}
+	cacheDir, err := toolchain.JdksCacheDir()
+	if err != nil {
+		return "", err
+	}
+	jdkHome, err := toolchain.ProvisionJdk(servicesManager, mc.jdk.Path, cacheDir)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Using the JDK", mc.jdk.Path, "from", jdkHome, "as the toolchain of the build.")
+	return writeToolchainsXml(jdkHome, mc.jdk)
+}
+
+// writeToolchainsXml writes the Maven toolchains file of the JDK to a temp file, whose path is returned.
+func writeToolchainsXml(jdkHome string, jdk *JdkProvisioning) (toolchainsPath string, err error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	toolchain := doc.CreateElement("toolchains").CreateElement("toolchain")
+	toolchain.CreateElement("type").SetText("jdk")
+	provides := toolchain.CreateElement("provides")
+	provides.CreateElement("version").SetText(jdk.Version)
+	if jdk.Vendor != "" {
+		provides.CreateElement("vendor").SetText(jdk.Vendor)
+	}
+	toolchain.CreateElement("configuration").CreateElement("jdkHome").SetText(jdkHome)
+	doc.Indent(2)
+
+	toolchainsFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := toolchainsFile.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	if _, err = doc.WriteTo(toolchainsFile); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return toolchainsFile.Name(), nil
+}
+
+// toolchainsGoals returns the goals of the build with the toolchains file, as the global toolchains, so that the toolchains of the user are also used.
+func toolchainsGoals(goals []string, toolchainsPath string) ([]string, error) {
+	for _, goal := range goals {
+		if goal == "-gt" || goal == "--global-toolchains" || strings.HasPrefix(goal, "--global-toolchains=") {
+			return nil, errorutils.CheckErrorf("the global toolchains file can't be provided with a JDK from Artifactory, which is the global toolchain of the build")
+		}
+	}
+	return append([]string{"--global-toolchains", toolchainsPath}, goals...), nil
+}
+
+func (jdk *JdkProvisioning) validate() error {
+	if jdk.Path == "" || jdk.Version == "" {
+		return errorutils.CheckErrorf("the path of the JDK archive in Artifactory and the version it provides are mandatory")
+	}
+	if len(strings.SplitN(strings.Trim(jdk.Path, "/"), "/", 2)) != 2 {
+		return errorutils.CheckErrorf("invalid JDK path '%s'. Use the form <repository>/<path to the archive>", jdk.Path)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package mvn
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteToolchainsXml(t *testing.T) {
+	toolchainsPath, err := writeToolchainsXml("/cache/jdks/abc/jdk-17", &JdkProvisioning{Path: "jdks-local/jdk-17.tar.gz", Version: "17", Vendor: "temurin"})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove(toolchainsPath))
+	}()
+	content, err := os.ReadFile(toolchainsPath)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<toolchains>
+  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>17</version>
+      <vendor>temurin</vendor>
+    </provides>
+    <configuration>
+      <jdkHome>/cache/jdks/abc/jdk-17</jdkHome>
+    </configuration>
+  </toolchain>
+</toolchains>
+`, string(content))
+}
+
+func TestToolchainsGoals(t *testing.T) {
+	goals, err := toolchainsGoals([]string{"clean", "install"}, "/tmp/toolchains.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--global-toolchains", "/tmp/toolchains.xml", "clean", "install"}, goals)
+
+	_, err = toolchainsGoals([]string{"-gt", "other.xml", "install"}, "/tmp/toolchains.xml")
+	assert.ErrorContains(t, err, "the global toolchains file can't be provided")
+}
+
+func TestJdkProvisioningValidate(t *testing.T) {
+	assert.NoError(t, (&JdkProvisioning{Path: "jdks-local/jdk-17.tar.gz", Version: "17"}).validate())
+	assert.ErrorContains(t, (&JdkProvisioning{Path: "jdks-local/jdk-17.tar.gz"}).validate(), "are mandatory")
+	assert.ErrorContains(t, (&JdkProvisioning{Path: "jdk-17.tar.gz", Version: "17"}).validate(), "invalid JDK path")
+}
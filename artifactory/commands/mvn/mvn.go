@@ -1,13 +1,19 @@
 package mvn
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
 	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -15,6 +21,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -35,6 +42,25 @@ type MvnCommand struct {
 	deploymentDisabled bool
 	// File path for Maven extractor in which all build's artifacts details will be listed at the end of the build.
 	buildArtifactsDetailsFile string
+	captureEffectivePom       bool
+	useMvnd                   bool
+	// File path to which the maven-help-plugin writes the effective POMs of the build's modules.
+	effectivePomFile string
+	// The detailed summary printed when the JSON format is requested.
+	deploymentSummary *artifactoryutils.DeploymentSummary
+	// The build is stopped when the context is canceled, or after the timeout, if positive.
+	ctx     context.Context
+	timeout time.Duration
+	// File to which the build's artifacts are listed instead of being deployed, for a later deployment from the file.
+	deployableArtifactsOutput string
+	// Deployable artifacts file of a previous build, whose artifacts are deployed instead of running a build.
+	deployFromFile string
+	// The JDK in Artifactory, which is the toolchain of the build.
+	jdk *JdkProvisioning
+	// Patterns of the attached artifacts which aren't deployed, and the artifacts they excluded from the build, by their modules.
+	excludeArtifacts   []string
+	artifactExclusions []artifactPattern
+	excludedArtifacts  map[string][]clientutils.DeployableArtifactDetails
 }
 
 func NewMvnCommand() *MvnCommand {
@@ -61,6 +87,8 @@ func (mc *MvnCommand) SetGoals(goals []string) *MvnCommand {
 	return mc
 }
 
+// SetThreads sets the number of artifacts deployed concurrently. With more than one thread, the artifacts are deployed
+// by the CLI after the build, instead of by the Maven extractor.
 func (mc *MvnCommand) SetThreads(threads int) *MvnCommand {
 	mc.threads = threads
 	return mc
@@ -89,11 +117,40 @@ func (mc *MvnCommand) IsXrayScan() bool {
 	return mc.xrayScan
 }
 
+// SetScanOutputFormat sets the format of the scan results. Without a scan, the JSON format prints the detailed summary
+// as a JSON document, which is also available by DeploymentSummary.
 func (mc *MvnCommand) SetScanOutputFormat(format format.OutputFormat) *MvnCommand {
 	mc.scanOutputFormat = format
 	return mc
 }
 
+func (mc *MvnCommand) SetCaptureEffectivePom(captureEffectivePom bool) *MvnCommand {
+	mc.captureEffectivePom = captureEffectivePom
+	return mc
+}
+
+func (mc *MvnCommand) IsCaptureEffectivePom() bool {
+	return mc.captureEffectivePom
+}
+
+// SetUseMvnd sets the build to run by the Maven Daemon (mvnd) instead of mvn.
+func (mc *MvnCommand) SetUseMvnd(useMvnd bool) *MvnCommand {
+	mc.useMvnd = useMvnd
+	return mc
+}
+
+// SetContext sets the context of the build. The build is stopped, and its processes are terminated, when the context is canceled.
+func (mc *MvnCommand) SetContext(ctx context.Context) *MvnCommand {
+	mc.ctx = ctx
+	return mc
+}
+
+// SetTimeout sets the duration after which the build is stopped. The build-info collected until then is kept.
+func (mc *MvnCommand) SetTimeout(timeout time.Duration) *MvnCommand {
+	mc.timeout = timeout
+	return mc
+}
+
 func (mc *MvnCommand) Result() *commandsutils.Result {
 	return mc.result
 }
@@ -113,16 +170,28 @@ func (mc *MvnCommand) init() (vConfig *viper.Viper, err error) {
 		err = errorutils.CheckErrorf("Conditional upload can only be performed if deployer is set in the config")
 		return
 	}
+	if err = mc.validateDeployableArtifactsOutput(vConfig); err != nil {
+		return
+	}
+	if mc.artifactExclusions, err = mc.readArtifactExclusions(vConfig); err != nil {
+		return
+	}
 	// Maven's extractor deploys build artifacts. This should be disabled since there is no intent to deploy anything or deploy upon Xray scan results.
 	// Deployment is enabled only for "install" and "deploy" goals when deployer is configured.
-	mc.deploymentDisabled = mc.IsXrayScan() || !vConfig.IsSet("deployer") || !mc.isDeploymentRequested()
+	mc.deploymentDisabled = mc.IsXrayScan() || mc.deployableArtifactsOutput != "" || !vConfig.IsSet("deployer") || !mc.isDeploymentRequested()
 
 	// Warn if deployer is configured but Maven goal does not trigger deployment
-	if vConfig.IsSet("deployer") && !mc.IsXrayScan() && mc.deploymentDisabled {
+	if vConfig.IsSet("deployer") && !mc.IsXrayScan() && !mc.isDeploymentRequested() {
 		log.Warn("Deployer repository is configured but Maven goal does not trigger deployment. Only 'install' and 'deploy' goals (including deploy:deploy-file) will deploy artifacts to Artifactory.")
 	}
 
-	if mc.shouldCreateBuildArtifactsFile() {
+	if mc.deployableArtifactsOutput != "" {
+		// The extractor lists the artifacts to the output file, instead of to a temp file.
+		if mc.buildArtifactsDetailsFile, err = filepath.Abs(mc.deployableArtifactsOutput); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		mc.buildArtifactsDetailsFile = ioutils.DoubleWinPathSeparator(mc.buildArtifactsDetailsFile)
+	} else if mc.shouldCreateBuildArtifactsFile() {
 		// Created a file that will contain all the details about the build's artifacts
 		tempFile, err := fileutils.CreateTempFile()
 		if err != nil {
@@ -134,6 +203,12 @@ func (mc *MvnCommand) init() (vConfig *viper.Viper, err error) {
 			return nil, err
 		}
 	}
+
+	if mc.IsCaptureEffectivePom() {
+		if mc.effectivePomFile, err = createEffectivePomOutputFile(); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
@@ -167,10 +242,10 @@ func (mc *MvnCommand) isDeploymentRequested() bool {
 }
 
 // Maven extractor generates the details of the build's artifacts.
-// This is required for Xray scan and for the detailed summary.
-// We can either scan or print the generated artifacts.
+// This is required for Xray scan, for the detailed summary and for the job summary.
+// We can either scan, deploy or print the generated artifacts.
 func (mc *MvnCommand) shouldCreateBuildArtifactsFile() bool {
-	return (mc.IsDetailedSummary() && !mc.deploymentDisabled) || mc.IsXrayScan()
+	return ((mc.IsDetailedSummary() || jobsummary.IsEnabled()) && !mc.deploymentDisabled) || mc.IsXrayScan() || mc.isCliDeployment()
 }
 
 func (mc *MvnCommand) Run() error {
@@ -183,7 +258,10 @@ func (mc *MvnCommand) Run() error {
 			SetConfigPath(mc.configPath).
 			SetGoals(mc.goals).
 			SetBuildConf(mc.configuration)
-		return RunMvn(mvnParams)
+		return mc.runMvn(mvnParams)
+	}
+	if mc.deployFromFile != "" {
+		return mc.runDeployFromFile()
 	}
 
 	vConfig, err := mc.init()
@@ -191,18 +269,44 @@ func (mc *MvnCommand) Run() error {
 		return err
 	}
 
+	goals := mc.goals
+	if mc.jdk != nil {
+		toolchainsPath, err := mc.provisionJdkToolchains(vConfig)
+		if err != nil {
+			return err
+		}
+		if goals, err = toolchainsGoals(goals, toolchainsPath); err != nil {
+			return err
+		}
+	}
 	mvnParams := NewMvnUtils().
 		SetConfigPath(mc.configPath).
 		SetConfig(vConfig).
 		SetBuildArtifactsDetailsFile(mc.buildArtifactsDetailsFile).
 		SetBuildConf(mc.configuration).
-		SetGoals(mc.goals).
+		SetGoals(goals).
 		SetInsecureTls(mc.insecureTls).
-		SetDisableDeploy(mc.deploymentDisabled).
-		SetThreads(mc.threads)
-	if err = RunMvn(mvnParams); err != nil {
+		SetDisableDeploy(mc.deploymentDisabled || mc.isCliDeployment()).
+		SetThreads(mc.threads).
+		SetUseMvnd(mc.useMvnd)
+	if err = mc.runMvn(mvnParams); err != nil {
 		return err
 	}
+	if mc.effectivePomFile != "" {
+		if err = mc.generateEffectivePoms(goals); err != nil {
+			return err
+		}
+	}
+	if len(mc.artifactExclusions) > 0 && mc.buildArtifactsDetailsFile != "" {
+		if err = mc.filterDeployableArtifacts(); err != nil {
+			return err
+		}
+	}
+	if mc.isCliDeployment() {
+		if err = mc.deployArtifacts(vConfig); err != nil {
+			return err
+		}
+	}
 
 	if mc.configuration != nil {
 		isCollectedBuildInfo, err := mc.configuration.IsCollectBuildInfo()
@@ -216,19 +320,60 @@ func (mc *MvnCommand) Run() error {
 		}
 	}
 
+	if mc.effectivePomFile != "" {
+		if err = mc.deployEffectivePoms(vConfig, mvnParams.GetBuildInfoFilePath()); err != nil {
+			return err
+		}
+	}
+
+	if mc.deployableArtifactsOutput != "" {
+		log.Info(fmt.Sprintf("The artifacts of the build were listed in %s. To deploy them, run 'jf mvn %s --from-file=%s'.", mc.deployableArtifactsOutput, DeployArtifactsGoal, mc.deployableArtifactsOutput))
+		return nil
+	}
 	if mc.buildArtifactsDetailsFile == "" {
 		return nil
 	}
+	return mc.processDeployableArtifacts(vConfig)
+}
 
+// processDeployableArtifacts reads the artifacts listed by the deployable artifacts file to the result of the command, and either
+// deploys them upon the Xray scan results, or records and prints their deployment.
+func (mc *MvnCommand) processDeployableArtifacts(vConfig *viper.Viper) (err error) {
+	if mc.isJsonDetailedSummary() {
+		if mc.deploymentSummary, err = createDeploymentSummary(mc.buildArtifactsDetailsFile, vConfig); err != nil {
+			return err
+		}
+		mc.deploymentSummary.AddFiltered(mc.excludedArtifacts, deploymentRepo(vConfig))
+	}
 	if err = mc.unmarshalDeployableArtifacts(mc.buildArtifactsDetailsFile); err != nil {
 		return err
 	}
 	if mc.IsXrayScan() {
 		return mc.conditionalUpload()
 	}
+	jobsummary.RecordTransferDetails("Maven artifacts deployed to Artifactory", mc.result.Reader())
+	// Without a detailed summary, the reader was only created for the job summary.
+	if !mc.IsDetailedSummary() {
+		return mc.result.Reader().Close()
+	}
+	if mc.deploymentSummary != nil {
+		return mc.printDeploymentSummary()
+	}
 	return nil
 }
 
+// runMvn runs the build until it completes, or until it's stopped by the context or the timeout. The reason a build was
+// stopped is added to its build-info.
+func (mc *MvnCommand) runMvn(mvnParams *MvnUtils) error {
+	ctx, cancel := artifactoryutils.NewBuildContext(mc.ctx, mc.timeout)
+	defer cancel()
+	err := RunMvn(mvnParams.SetContext(ctx))
+	if err != nil && ctx.Err() != nil {
+		err = errors.Join(err, artifactoryutils.SaveStoppedBuildInfo(mc.configuration, err))
+	}
+	return err
+}
+
 // Returns the ServerDetails. The information returns from the config file provided.
 func (mc *MvnCommand) ServerDetails() (*config.ServerDetails, error) {
 	// Get the serverDetails from the config file.
@@ -267,6 +412,7 @@ func (mc *MvnCommand) conditionalUpload() error {
 		return err
 	}
 	binariesSpecFile, pomSpecFile, err := commandsutils.ScanDeployableArtifacts(mc.result, mc.serverDetails, mc.threads, mc.scanOutputFormat)
+	jobsummary.RecordScanGate("the Maven build artifacts", err)
 	// If the detailed summary wasn't requested, the reader should be closed here.
 	// (otherwise it will be closed by the detailed summary print method)
 	if !mc.IsDetailedSummary() {
@@ -206,3 +206,72 @@ func TestUpdateBuildInfoArtifactsWithTargetRepo(t *testing.T) {
 	assert.Equal(t, "releases", artifacts[0].OriginalDeploymentRepo)
 	assert.Equal(t, "releases", artifacts[1].OriginalDeploymentRepo)
 }
+
+func TestParseEffectivePoms(t *testing.T) {
+	singleModule := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>org.jfrog.test</groupId>
+  <artifactId>single</artifactId>
+  <version>1.0.0</version>
+</project>`
+	multiModule := `<?xml version="1.0" encoding="UTF-8"?>
+<projects>
+  <project xmlns="http://maven.apache.org/POM/4.0.0">
+    <groupId>org.jfrog.test</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </project>
+  <project xmlns="http://maven.apache.org/POM/4.0.0">
+    <parent>
+      <groupId>org.jfrog.test</groupId>
+      <artifactId>parent</artifactId>
+      <version>1.0-SNAPSHOT</version>
+    </parent>
+    <groupId>org.jfrog.test</groupId>
+    <artifactId>child</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </project>
+</projects>`
+
+	poms, err := parseEffectivePoms([]byte(singleModule))
+	assert.NoError(t, err)
+	assert.Len(t, poms, 1)
+	assert.Equal(t, "org.jfrog.test:single:1.0.0", poms[0].ModuleId())
+	assert.Equal(t, "org/jfrog/test/single/1.0.0/single-1.0.0-effective-pom.xml", poms[0].RepoPath())
+	assert.Contains(t, string(poms[0].Content), `xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"`)
+	assert.Contains(t, string(poms[0].Content), `xsi:schemaLocation=`)
+	// The rebuilt document should be parsable and keep the module's coordinates.
+	reparsed, err := parseEffectivePoms(poms[0].Content)
+	assert.NoError(t, err)
+	assert.Equal(t, poms[0].ModuleId(), reparsed[0].ModuleId())
+
+	poms, err = parseEffectivePoms([]byte(multiModule))
+	assert.NoError(t, err)
+	assert.Len(t, poms, 2)
+	assert.Equal(t, "org.jfrog.test:parent:1.0-SNAPSHOT", poms[0].ModuleId())
+	assert.Equal(t, "org.jfrog.test:child:1.0-SNAPSHOT", poms[1].ModuleId())
+	assert.Equal(t, "child-1.0-SNAPSHOT-effective-pom.xml", poms[1].FileName())
+
+	_, err = parseEffectivePoms([]byte("<settings></settings>"))
+	assert.Error(t, err)
+}
+
+func TestEffectivePomArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		goals    []string
+		expected []string
+	}{
+		{name: "goals only", goals: []string{"clean", "install"}, expected: []string{}},
+		{name: "options", goals: []string{"clean", "-Pci", "install", "-DskipTests", "-U"}, expected: []string{"-Pci", "-DskipTests", "-U"}},
+		{name: "option values", goals: []string{"-f", "sub/pom.xml", "deploy", "-pl", "core", "-D", "a=b", "--settings=s.xml"}, expected: []string{"-f", "sub/pom.xml", "-pl", "core", "-D", "a=b", "--settings=s.xml"}},
+		{name: "log file", goals: []string{"install", "-l", "build.log", "--log-file=other.log", "-B"}, expected: []string{"-B"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := effectivePomArgs(test.goals, "/tmp/effective.xml")
+			assert.Equal(t, append(test.expected, "help:effective-pom", "-Doutput=/tmp/effective.xml"), args)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package mvn
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/build-info-go/build"
+	biutils "github.com/jfrog/build-info-go/utils"
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+)
+
+const (
+	mvndExecutable = "mvnd"
+	mvndHomeEnv    = "MVND_HOME"
+	// The key of the Maven configuration which runs the builds by mvnd, like useWrapper runs them by the Maven wrapper.
+	useMvndConfigKey = "useMvnd"
+	// The mvnd configuration of a project, which indicates that the project is built by mvnd.
+	mvndPropertiesFile = "mvnd.properties"
+)
+
+// findMvnd returns the path of the mvnd executable, from the bin directory of MVND_HOME, or from the PATH.
+func findMvnd() (string, error) {
+	executable := mvndExecutable
+	if coreutils.IsWindows() {
+		executable += ".cmd"
+	}
+	if mvndHome := os.Getenv(mvndHomeEnv); mvndHome != "" {
+		mvndPath := filepath.Join(mvndHome, "bin", executable)
+		exists, err := fileutils.IsFileExists(mvndPath, false)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		if !exists {
+			return "", errorutils.CheckErrorf("mvnd wasn't found in %s. Check that the %s environment variable is the mvnd installation directory, which includes the bin directory.", mvndPath, mvndHomeEnv)
+		}
+		return mvndPath, nil
+	}
+	mvndPath, err := exec.LookPath(mvndExecutable)
+	if err != nil {
+		return "", errorutils.CheckErrorf("mvnd wasn't found in the PATH. Either add it to the PATH, or set the %s environment variable to the mvnd installation directory: %s", mvndHomeEnv, err.Error())
+	}
+	return mvndPath, nil
+}
+
+// isMvndProject returns true if the project in the root directory configures mvnd.
+func isMvndProject(projectRoot string) bool {
+	if projectRoot == "" {
+		return false
+	}
+	exists, err := fileutils.IsFileExists(filepath.Join(projectRoot, ".mvn", mvndPropertiesFile), false)
+	return err == nil && exists
+}
+
+// createMvndCmd returns the mvnd command of the goals. The build-info extractor is loaded as a Maven core extension, which mvnd
+// keeps in its daemons, and its properties file is passed to each build, so the daemons are reused by the following builds.
+func createMvndCmd(mvndPath, extractorJar, extractorProps string, mvnOpts, goals []string) *exec.Cmd {
+	args := []string{"-Dmaven.ext.class.path=" + extractorJar, "-DbuildInfoConfig.propertiesFile=" + extractorProps}
+	args = append(args, mvnOpts...)
+	args = append(args, goals...)
+	return exec.Command(mvndPath, args...)
+}
+
+// runMvnd runs the goals by mvnd, with the build-info extractor which the mvn command runs with. The extractor collects the
+// build-info to the returned file, and lists the deployable artifacts as it does with mvn.
+func (mu *MvnUtils) runMvnd(buildsDirPath, buildName, buildNumber string, buildTimestamp time.Time, extractorDir string, props map[string]string) (buildInfoFilePath string, err error) {
+	mvndPath, err := findMvnd()
+	if err != nil {
+		return "", err
+	}
+	extractorJarName := fmt.Sprintf(build.MavenExtractorFileName, build.MavenExtractorDependencyVersion)
+	extractorRemotePath := fmt.Sprintf(build.MavenExtractorRemotePath, build.MavenExtractorDependencyVersion)
+	if err = biutils.DownloadDependencies(extractorDir, extractorJarName, extractorRemotePath, dependencies.DownloadExtractor, log.Logger); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	buildInfoFile, err := biutils.CreateTempBuildFile(buildName, buildNumber, mu.buildConf.GetProject(), buildsDirPath, log.Logger)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if err = buildInfoFile.Close(); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	// The extractor runs on Java, which requires the Windows path separators to be escaped.
+	buildInfoFilePath = biutils.DoubleWinPathSeparator(buildInfoFile.Name())
+	extractorProps, err := biutils.CreateExtractorPropsFile(filepath.Join(coreutils.GetCliPersistentTempDirPath(), buildUtils.PropertiesTempPath),
+		buildInfoFilePath, buildName, buildNumber, buildTimestamp, mu.buildConf.GetProject(), props)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Remove(extractorProps)))
+	}()
+
+	// The Maven options of MAVEN_OPTS are the options of the daemons, which mvnd reads by itself.
+	var mvnOpts []string
+	if v, ok := props["buildInfoConfig.artifactoryResolutionEnabled"]; ok {
+		mvnOpts = append(mvnOpts, "-DbuildInfoConfig.artifactoryResolutionEnabled="+v)
+	}
+	cmd := createMvndCmd(mvndPath, biutils.DoubleWinPathSeparator(filepath.Join(extractorDir, extractorJarName)), extractorProps, mvnOpts, mu.goals)
+	errBuffer := bytes.NewBuffer([]byte{})
+	cmd.Stderr = io.MultiWriter(os.Stderr, errBuffer)
+	cmd.Stdout = os.Stderr
+	if mu.outputWriter != nil {
+		cmd.Stdout = mu.outputWriter
+	}
+	log.Info("Running mvnd command:", strings.Join(cmd.Args, " "))
+	if err = artifactoryutils.RunWithContext(mu.ctx, cmd.Run); err != nil {
+		if biutils.IsForbiddenOutput(biutils.Maven, errBuffer.String()) {
+			err = errors.Join(biutils.NewForbiddenError(), err)
+		}
+		return "", coreutils.ConvertExitCodeError(err)
+	}
+	return buildInfoFilePath, nil
+}
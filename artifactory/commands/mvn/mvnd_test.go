@@ -0,0 +1,51 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMvnd(t *testing.T) {
+	mvndHome := t.TempDir()
+	t.Setenv(mvndHomeEnv, mvndHome)
+	_, err := findMvnd()
+	assert.ErrorContains(t, err, "mvnd wasn't found in "+filepath.Join(mvndHome, "bin"))
+
+	executable := mvndExecutable
+	if coreutils.IsWindows() {
+		executable += ".cmd"
+	}
+	mvndPath := filepath.Join(mvndHome, "bin", executable)
+	require.NoError(t, os.MkdirAll(filepath.Dir(mvndPath), 0755))
+	require.NoError(t, os.WriteFile(mvndPath, []byte{}, 0755))
+	foundPath, err := findMvnd()
+	require.NoError(t, err)
+	assert.Equal(t, mvndPath, foundPath)
+}
+
+func TestIsMvndProject(t *testing.T) {
+	projectRoot := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(projectRoot, ".mvn"), 0755))
+	assert.False(t, isMvndProject(projectRoot))
+	assert.False(t, isMvndProject(""))
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectRoot, ".mvn", mvndPropertiesFile), []byte("mvnd.threads=4\n"), 0644))
+	assert.True(t, isMvndProject(projectRoot))
+}
+
+func TestCreateMvndCmd(t *testing.T) {
+	cmd := createMvndCmd("/opt/mvnd/bin/mvnd", "/deps/maven/extractor-uber.jar", "/tmp/props/buildinfo.properties",
+		[]string{"-DbuildInfoConfig.artifactoryResolutionEnabled=true"}, []string{"clean", "install", "-DskipTests"})
+	assert.Equal(t, []string{
+		"/opt/mvnd/bin/mvnd",
+		"-Dmaven.ext.class.path=/deps/maven/extractor-uber.jar",
+		"-DbuildInfoConfig.propertiesFile=/tmp/props/buildinfo.properties",
+		"-DbuildInfoConfig.artifactoryResolutionEnabled=true",
+		"clean", "install", "-DskipTests",
+	}, cmd.Args)
+}
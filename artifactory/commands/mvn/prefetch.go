@@ -0,0 +1,355 @@
+package mvn
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	PrefetchStatusFetched = "fetched"
+	PrefetchStatusFailed  = "failed"
+
+	defaultPrefetchThreads = 3
+)
+
+// The file extensions of the Maven packaging types, which differ from the type.
+var packagingExtensions = map[string]string{
+	"bundle":       "jar",
+	"maven-plugin": "jar",
+	"ejb":          "jar",
+	"test-jar":     "jar",
+	"java-source":  "jar",
+	"javadoc":      "jar",
+}
+
+// MavenCoordinate is a dependency listed by a Gradle lockfile or by the Maven dependency list.
+type MavenCoordinate struct {
+	GroupId    string
+	ArtifactId string
+	Version    string
+	// The type of the dependency, such as jar or pom. Empty if unknown, in which case it's read from the packaging of the pom.
+	Type       string
+	Classifier string
+}
+
+func (mc MavenCoordinate) String() string {
+	coordinate := mc.GroupId + ":" + mc.ArtifactId + ":" + mc.Version
+	if mc.Classifier != "" {
+		coordinate += ":" + mc.Classifier
+	}
+	return coordinate
+}
+
+// filePath returns the path of the file of the coordinate with the extension, in the Maven repository layout.
+func (mc MavenCoordinate) filePath(extension, classifier string) string {
+	fileName := mc.ArtifactId + "-" + mc.Version
+	if classifier != "" {
+		fileName += "-" + classifier
+	}
+	return path.Join(strings.ReplaceAll(mc.GroupId, ".", "/"), mc.ArtifactId, mc.Version, fileName+"."+extension)
+}
+
+type PrefetchItem struct {
+	Coordinate string `json:"coordinate"`
+	Path       string `json:"path"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+type PrefetchResult struct {
+	Repository string         `json:"repository"`
+	Files      []PrefetchItem `json:"files"`
+	Fetched    int            `json:"fetched"`
+	Failed     int            `json:"failed"`
+}
+
+// DependenciesPrefetchCommand downloads the dependencies listed by Gradle lockfiles or Maven dependency lists through
+// an Artifactory repository, without running a build. Downloading through a remote repository caches the dependencies,
+// so that the first build which uses the repository doesn't wait for them to be fetched from the remote registry.
+type DependenciesPrefetchCommand struct {
+	serverDetails     *config.ServerDetails
+	repo              string
+	dependenciesFiles []string
+	threads           int
+	result            *PrefetchResult
+}
+
+func NewDependenciesPrefetchCommand() *DependenciesPrefetchCommand {
+	return &DependenciesPrefetchCommand{threads: defaultPrefetchThreads}
+}
+
+func (dpc *DependenciesPrefetchCommand) SetServerDetails(serverDetails *config.ServerDetails) *DependenciesPrefetchCommand {
+	dpc.serverDetails = serverDetails
+	return dpc
+}
+
+func (dpc *DependenciesPrefetchCommand) SetRepo(repo string) *DependenciesPrefetchCommand {
+	dpc.repo = repo
+	return dpc
+}
+
+// SetDependenciesFiles sets the Gradle lockfiles and the outputs of 'mvn dependency:list' to read the dependencies from.
+func (dpc *DependenciesPrefetchCommand) SetDependenciesFiles(dependenciesFiles []string) *DependenciesPrefetchCommand {
+	dpc.dependenciesFiles = dependenciesFiles
+	return dpc
+}
+
+func (dpc *DependenciesPrefetchCommand) SetThreads(threads int) *DependenciesPrefetchCommand {
+	dpc.threads = threads
+	return dpc
+}
+
+func (dpc *DependenciesPrefetchCommand) Result() *PrefetchResult {
+	return dpc.result
+}
+
+func (dpc *DependenciesPrefetchCommand) CommandName() string {
+	return "rt_dependencies_prefetch"
+}
+
+func (dpc *DependenciesPrefetchCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dpc.serverDetails, nil
+}
+
+func (dpc *DependenciesPrefetchCommand) Run() error {
+	coordinates, err := ReadDependenciesFiles(dpc.dependenciesFiles)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(dpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Prefetching %d dependencies through %s...", len(coordinates), dpc.repo))
+	readFile := func(filePath string) (io.ReadCloser, error) {
+		return servicesManager.ReadRemoteFile(dpc.repo + "/" + filePath)
+	}
+	dpc.result = FetchDependencies(dpc.repo, coordinates, readFile, dpc.threads)
+	log.Info(fmt.Sprintf("Fetched %d files, %d failed.", dpc.result.Fetched, dpc.result.Failed))
+	if dpc.result.Failed > 0 {
+		return errorutils.CheckErrorf("failed to prefetch %d files through %s", dpc.result.Failed, dpc.repo)
+	}
+	return nil
+}
+
+// ReadDependenciesFiles returns the dependencies listed by the Gradle lockfiles and the Maven dependency lists, without duplicates.
+func ReadDependenciesFiles(dependenciesFiles []string) ([]MavenCoordinate, error) {
+	var coordinates []MavenCoordinate
+	for _, dependenciesFile := range dependenciesFiles {
+		fileCoordinates, err := readDependenciesFile(dependenciesFile)
+		if err != nil {
+			return nil, err
+		}
+		coordinates = append(coordinates, fileCoordinates...)
+	}
+	return uniqueCoordinates(coordinates), nil
+}
+
+func readDependenciesFile(dependenciesFile string) ([]MavenCoordinate, error) {
+	file, err := os.Open(dependenciesFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.Warn("Failed to close", dependenciesFile+":", closeErr.Error())
+		}
+	}()
+	coordinates, err := parseDependencies(file)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the dependencies of %s: %s", dependenciesFile, err.Error())
+	}
+	log.Debug(fmt.Sprintf("Found %d dependencies in %s", len(coordinates), dependenciesFile))
+	return coordinates, nil
+}
+
+// parseDependencies parses the lines of a Gradle lockfile, such as "org.slf4j:slf4j-api:2.0.9=compileClasspath",
+// or of the output of 'mvn dependency:list', such as "[INFO]    org.slf4j:slf4j-api:jar:2.0.9:compile".
+// Lines which aren't dependencies are skipped.
+func parseDependencies(reader io.Reader) ([]MavenCoordinate, error) {
+	var coordinates []MavenCoordinate
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "[INFO]"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if lockfileEntry, _, isLockfileEntry := strings.Cut(line, "="); isLockfileEntry {
+			line = lockfileEntry
+		}
+		// The dependency may be followed by the module name, such as "-- module org.slf4j".
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if coordinate, ok := parseCoordinate(fields[0]); ok {
+			coordinates = append(coordinates, coordinate)
+		}
+	}
+	return coordinates, errorutils.CheckError(scanner.Err())
+}
+
+// parseCoordinate parses group:artifact:version, or group:artifact:type[:classifier]:version[:scope].
+func parseCoordinate(value string) (MavenCoordinate, bool) {
+	parts := strings.Split(value, ":")
+	for _, part := range parts {
+		if part == "" {
+			return MavenCoordinate{}, false
+		}
+	}
+	coordinate := MavenCoordinate{GroupId: parts[0]}
+	switch len(parts) {
+	case 3:
+		coordinate.ArtifactId, coordinate.Version = parts[1], parts[2]
+	case 4, 5:
+		coordinate.ArtifactId, coordinate.Type, coordinate.Version = parts[1], parts[2], parts[3]
+	case 6:
+		coordinate.ArtifactId, coordinate.Type, coordinate.Classifier, coordinate.Version = parts[1], parts[2], parts[3], parts[4]
+	default:
+		return MavenCoordinate{}, false
+	}
+	return coordinate, true
+}
+
+func uniqueCoordinates(coordinates []MavenCoordinate) []MavenCoordinate {
+	unique := make(map[MavenCoordinate]bool)
+	var result []MavenCoordinate
+	for _, coordinate := range coordinates {
+		if !unique[coordinate] {
+			unique[coordinate] = true
+			result = append(result, coordinate)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+// FetchDependencies reads the pom and the artifact of each coordinate from the repository, using readFile.
+// The content of the artifacts is discarded by FetchDependencies, so callers which keep it should copy it in readFile.
+func FetchDependencies(repo string, coordinates []MavenCoordinate, readFile func(filePath string) (io.ReadCloser, error), threads int) *PrefetchResult {
+	if threads < 1 {
+		threads = 1
+	}
+	// Each task writes the files of its coordinate, so no locking is needed.
+	files := make([][]PrefetchItem, len(coordinates))
+	runner := parallel.NewRunner(threads, uint(len(coordinates)), false)
+	go func() {
+		defer runner.Done()
+		for i, coordinate := range coordinates {
+			_, _ = runner.AddTask(func(int) error {
+				files[i] = prefetchCoordinate(coordinate, readFile)
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+
+	result := &PrefetchResult{Repository: repo, Files: []PrefetchItem{}}
+	for _, coordinateFiles := range files {
+		for _, file := range coordinateFiles {
+			if file.Status == PrefetchStatusFetched {
+				result.Fetched++
+			} else {
+				result.Failed++
+			}
+			result.Files = append(result.Files, file)
+		}
+	}
+	return result
+}
+
+type pomPackaging struct {
+	Packaging string `xml:"packaging"`
+}
+
+// prefetchCoordinate downloads the pom of the coordinate, and then its artifact, unless it's a pom only dependency.
+func prefetchCoordinate(coordinate MavenCoordinate, readFile func(filePath string) (io.ReadCloser, error)) []PrefetchItem {
+	pomPath := coordinate.filePath("pom", "")
+	pom, err := fetchFile(pomPath, readFile, true)
+	items := []PrefetchItem{newPrefetchItem(coordinate, pomPath, err)}
+	if err != nil {
+		return items
+	}
+	artifactType := coordinate.Type
+	if artifactType == "" {
+		// The packaging defaults to jar.
+		packaging := pomPackaging{Packaging: "jar"}
+		if err = xml.Unmarshal(pom, &packaging); err != nil {
+			log.Debug(fmt.Sprintf("Failed to read the packaging of %s, assuming jar: %s", pomPath, err.Error()))
+		}
+		artifactType = strings.TrimSpace(packaging.Packaging)
+	}
+	if artifactType == "pom" {
+		return items
+	}
+	extension, classifier := artifactType, coordinate.Classifier
+	if packagingExtension, ok := packagingExtensions[artifactType]; ok {
+		extension = packagingExtension
+	}
+	if artifactType == "test-jar" && classifier == "" {
+		classifier = "tests"
+	}
+	artifactPath := coordinate.filePath(extension, classifier)
+	_, err = fetchFile(artifactPath, readFile, false)
+	return append(items, newPrefetchItem(coordinate, artifactPath, err))
+}
+
+// fetchFile downloads the file. Its content is returned if keepContent is set, and discarded otherwise.
+func fetchFile(filePath string, readFile func(filePath string) (io.ReadCloser, error), keepContent bool) (content []byte, err error) {
+	log.Debug("Fetching", filePath)
+	reader, err := readFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	if keepContent {
+		content, err = io.ReadAll(reader)
+	} else {
+		_, err = io.Copy(io.Discard, reader)
+	}
+	return content, errorutils.CheckError(err)
+}
+
+func newPrefetchItem(coordinate MavenCoordinate, filePath string, err error) PrefetchItem {
+	item := PrefetchItem{Coordinate: coordinate.String(), Path: filePath, Status: PrefetchStatusFetched}
+	if err != nil {
+		item.Status, item.Error = PrefetchStatusFailed, err.Error()
+		log.Warn(fmt.Sprintf("Failed to fetch %s: %s", filePath, err.Error()))
+	}
+	return item
+}
+
+type prefetchRow struct {
+	Coordinate string `col-name:"Coordinate"`
+	Path       string `col-name:"Path"`
+	Status     string `col-name:"Status"`
+	Error      string `col-name:"Error" omitempty:"true"`
+}
+
+// PrintPrefetchTable prints the fetched files of the result as a table.
+func PrintPrefetchTable(result *PrefetchResult) error {
+	rows := make([]prefetchRow, 0, len(result.Files))
+	for _, file := range result.Files {
+		rows = append(rows, prefetchRow(file))
+	}
+	return coreutils.PrintTable(rows, "Dependencies prefetched through "+result.Repository, "No dependencies found", false)
+}
@@ -0,0 +1,88 @@
+package mvn
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependencies(t *testing.T) {
+	gradleLockfile := `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.google.guava:guava:32.1.3-jre=compileClasspath,runtimeClasspath
+org.junit:junit-bom:5.10.0=testCompileClasspath
+empty=annotationProcessor
+`
+	coordinates, err := parseDependencies(strings.NewReader(gradleLockfile))
+	require.NoError(t, err)
+	assert.Equal(t, []MavenCoordinate{
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "32.1.3-jre"},
+		{GroupId: "org.junit", ArtifactId: "junit-bom", Version: "5.10.0"},
+	}, coordinates)
+
+	mavenDependencyList := `[INFO] --- dependency:3.6.1:list (default-cli) @ app ---
+[INFO]
+[INFO] The following files have been resolved:
+[INFO]    org.slf4j:slf4j-api:jar:2.0.9:compile -- module org.slf4j
+[INFO]    io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.100.Final:runtime
+[INFO]    com.example:fixtures:test-jar:1.0:test
+[INFO]
+`
+	coordinates, err = parseDependencies(strings.NewReader(mavenDependencyList))
+	require.NoError(t, err)
+	assert.Equal(t, []MavenCoordinate{
+		{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Type: "jar", Version: "2.0.9"},
+		{GroupId: "io.netty", ArtifactId: "netty-transport-native-epoll", Type: "jar", Classifier: "linux-x86_64", Version: "4.1.100.Final"},
+		{GroupId: "com.example", ArtifactId: "fixtures", Type: "test-jar", Version: "1.0"},
+	}, coordinates)
+}
+
+func TestFetchDependencies(t *testing.T) {
+	repoFiles := map[string]string{
+		"com/google/guava/guava/32.1.3-jre/guava-32.1.3-jre.pom":                                                          "<project><packaging>bundle</packaging></project>",
+		"com/google/guava/guava/32.1.3-jre/guava-32.1.3-jre.jar":                                                          "jar",
+		"org/junit/junit-bom/5.10.0/junit-bom-5.10.0.pom":                                                                 "<project><packaging>pom</packaging></project>",
+		"io/netty/netty-transport-native-epoll/4.1.100.Final/netty-transport-native-epoll-4.1.100.Final.pom":              "<project/>",
+		"io/netty/netty-transport-native-epoll/4.1.100.Final/netty-transport-native-epoll-4.1.100.Final-linux-x86_64.jar": "jar",
+		"com/example/fixtures/1.0/fixtures-1.0.pom":                                                                       "<project/>",
+	}
+	var lock sync.Mutex
+	var fetched []string
+	readFile := func(filePath string) (io.ReadCloser, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		fetched = append(fetched, filePath)
+		fileContent, ok := repoFiles[filePath]
+		if !ok {
+			return nil, errors.New("404 Not Found")
+		}
+		return io.NopCloser(strings.NewReader(fileContent)), nil
+	}
+	coordinates := uniqueCoordinates([]MavenCoordinate{
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "32.1.3-jre"},
+		{GroupId: "org.junit", ArtifactId: "junit-bom", Version: "5.10.0"},
+		{GroupId: "io.netty", ArtifactId: "netty-transport-native-epoll", Type: "jar", Classifier: "linux-x86_64", Version: "4.1.100.Final"},
+		{GroupId: "com.example", ArtifactId: "fixtures", Type: "test-jar", Version: "1.0"},
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "32.1.3-jre"},
+	})
+	result := FetchDependencies("maven-remote", coordinates, readFile, 2)
+
+	assert.Equal(t, "maven-remote", result.Repository)
+	assert.Equal(t, 6, result.Fetched)
+	assert.Equal(t, 1, result.Failed)
+	assert.Len(t, fetched, 7)
+	// The pom only dependency has no artifact, and the test-jar has the tests classifier.
+	assert.NotContains(t, fetched, "org/junit/junit-bom/5.10.0/junit-bom-5.10.0.jar")
+	assert.Equal(t, PrefetchItem{
+		Coordinate: "com.example:fixtures:1.0",
+		Path:       "com/example/fixtures/1.0/fixtures-1.0-tests.jar",
+		Status:     PrefetchStatusFailed,
+		Error:      "404 Not Found",
+	}, result.Files[1])
+}
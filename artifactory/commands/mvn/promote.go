@@ -0,0 +1,486 @@
+package mvn
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	snapshotSuffix = "-SNAPSHOT"
+
+	// The properties of the release build-info, which link it to the snapshot build it was promoted from.
+	SnapshotBuildNameProp   = "snapshot.build.name"
+	SnapshotBuildNumberProp = "snapshot.build.number"
+)
+
+// The version part of the file names of the snapshot artifacts: SNAPSHOT, or the timestamp and build number of a unique snapshot.
+const snapshotFileVersionPattern = `-(?:SNAPSHOT|\d{8}\.\d{6}-\d+)`
+
+type PromotedArtifact struct {
+	Module string `json:"module"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type MvnPromoteResult struct {
+	SourceBuild string             `json:"sourceBuild"`
+	TargetBuild string             `json:"targetBuild"`
+	DryRun      bool               `json:"dryRun"`
+	Artifacts   []PromotedArtifact `json:"artifacts"`
+}
+
+type promotedArtifactRow struct {
+	Module string `col-name:"Module"`
+	Source string `col-name:"Source"`
+	Target string `col-name:"Target"`
+}
+
+// promotedModule is a Maven module of the snapshot build, with the release version it's promoted to.
+type promotedModule struct {
+	MavenCoordinate
+	releaseVersion string
+	module         buildinfo.Module
+}
+
+func (pm promotedModule) releaseCoordinate() MavenCoordinate {
+	return MavenCoordinate{GroupId: pm.GroupId, ArtifactId: pm.ArtifactId, Version: pm.releaseVersion}
+}
+
+// releaseArtifactPath returns the path of the release artifact of a snapshot artifact of the module, in the Maven repository layout.
+func (pm promotedModule) releaseArtifactPath(artifactName string) (string, error) {
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(pm.ArtifactId+"-"+strings.TrimSuffix(pm.Version, snapshotSuffix)) + snapshotFileVersionPattern + "([-.].*)$")
+	match := pattern.FindStringSubmatch(artifactName)
+	if match == nil {
+		return "", errorutils.CheckErrorf("the name of the artifact %s of the module %s doesn't match the snapshot version %s", artifactName, pm.String(), pm.Version)
+	}
+	// The classifier and the extension follow the version.
+	fileName := pm.ArtifactId + "-" + pm.releaseVersion + match[1]
+	return path.Join(strings.ReplaceAll(pm.GroupId, ".", "/"), pm.ArtifactId, pm.releaseVersion, fileName), nil
+}
+
+// promotedArtifact is an artifact of the snapshot build, and the path of its release artifact.
+type promotedArtifact struct {
+	module      *promotedModule
+	artifact    buildinfo.Artifact
+	source      string
+	releasePath string
+}
+
+// MvnPromoteCommand promotes the Maven snapshot artifacts of a published build to a release version. The artifacts are deployed
+// to a release repository with the release version in their paths and in their POMs, and a release build-info, which links back
+// to the snapshot build, is published.
+type MvnPromoteCommand struct {
+	serverDetails     *config.ServerDetails
+	buildName         string
+	buildNumber       string
+	project           string
+	releaseRepo       string
+	releaseVersion    string
+	targetBuildName   string
+	targetBuildNumber string
+	threads           int
+	dryRun            bool
+	result            *MvnPromoteResult
+}
+
+func NewMvnPromoteCommand() *MvnPromoteCommand {
+	return &MvnPromoteCommand{threads: 3}
+}
+
+func (mpc *MvnPromoteCommand) SetServerDetails(serverDetails *config.ServerDetails) *MvnPromoteCommand {
+	mpc.serverDetails = serverDetails
+	return mpc
+}
+
+// SetBuild sets the published snapshot build to promote.
+func (mpc *MvnPromoteCommand) SetBuild(buildName, buildNumber string) *MvnPromoteCommand {
+	mpc.buildName, mpc.buildNumber = buildName, buildNumber
+	return mpc
+}
+
+func (mpc *MvnPromoteCommand) SetProject(project string) *MvnPromoteCommand {
+	mpc.project = project
+	return mpc
+}
+
+func (mpc *MvnPromoteCommand) SetReleaseRepo(releaseRepo string) *MvnPromoteCommand {
+	mpc.releaseRepo = releaseRepo
+	return mpc
+}
+
+// SetReleaseVersion sets the version of all the promoted modules. If empty, the -SNAPSHOT suffix is removed from the version of each module.
+func (mpc *MvnPromoteCommand) SetReleaseVersion(releaseVersion string) *MvnPromoteCommand {
+	mpc.releaseVersion = releaseVersion
+	return mpc
+}
+
+// SetTargetBuild sets the release build-info. The name of the snapshot build and the release version are used if empty.
+func (mpc *MvnPromoteCommand) SetTargetBuild(buildName, buildNumber string) *MvnPromoteCommand {
+	mpc.targetBuildName, mpc.targetBuildNumber = buildName, buildNumber
+	return mpc
+}
+
+func (mpc *MvnPromoteCommand) SetThreads(threads int) *MvnPromoteCommand {
+	mpc.threads = threads
+	return mpc
+}
+
+// SetDryRun only lists the release artifacts, without deploying them.
+func (mpc *MvnPromoteCommand) SetDryRun(dryRun bool) *MvnPromoteCommand {
+	mpc.dryRun = dryRun
+	return mpc
+}
+
+func (mpc *MvnPromoteCommand) Result() *MvnPromoteResult {
+	return mpc.result
+}
+
+func (mpc *MvnPromoteCommand) CommandName() string {
+	return "rt_mvn_promote"
+}
+
+func (mpc *MvnPromoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return mpc.serverDetails, nil
+}
+
+func (mpc *MvnPromoteCommand) Run() (err error) {
+	if mpc.buildName == "" || mpc.buildNumber == "" || mpc.releaseRepo == "" {
+		return errorutils.CheckErrorf("the build name, the build number and the release repository are mandatory")
+	}
+	if strings.HasSuffix(mpc.releaseVersion, snapshotSuffix) {
+		return errorutils.CheckErrorf("the release version %s can't be a snapshot version", mpc.releaseVersion)
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(mpc.serverDetails, false, mpc.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: mpc.buildName, BuildNumber: mpc.buildNumber, ProjectKey: mpc.project})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s was not found", mpc.buildName, mpc.buildNumber)
+	}
+	modules, err := newPromotedModules(&publishedBuildInfo.BuildInfo, mpc.releaseVersion)
+	if err != nil {
+		return err
+	}
+	if err = mpc.setTargetBuildDefaults(modules); err != nil {
+		return err
+	}
+	artifacts, err := mpc.findSnapshotArtifacts(servicesManager, modules)
+	if err != nil {
+		return err
+	}
+	mpc.result = &MvnPromoteResult{SourceBuild: mpc.buildName + "/" + mpc.buildNumber, TargetBuild: mpc.targetBuildName + "/" + mpc.targetBuildNumber, DryRun: mpc.dryRun}
+	for _, artifact := range artifacts {
+		mpc.result.Artifacts = append(mpc.result.Artifacts, PromotedArtifact{Module: artifact.module.String(), Source: artifact.source, Target: path.Join(mpc.releaseRepo, artifact.releasePath)})
+	}
+	if mpc.dryRun {
+		return nil
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	started := time.Now()
+	releaseArtifacts, err := mpc.prepareReleaseArtifacts(servicesManager, tempDir, modules, artifacts)
+	if err != nil {
+		return err
+	}
+	if err = mpc.deployReleaseArtifacts(servicesManager, tempDir, artifacts, started); err != nil {
+		return err
+	}
+	releaseBuildInfo := mpc.createReleaseBuildInfo(&publishedBuildInfo.BuildInfo, modules, releaseArtifacts, started)
+	log.Info(fmt.Sprintf("Publishing the release build-info %s/%s...", mpc.targetBuildName, mpc.targetBuildNumber))
+	_, err = servicesManager.PublishBuildInfo(releaseBuildInfo, mpc.project)
+	return err
+}
+
+// newPromotedModules returns the Maven modules of the build which have a snapshot version.
+func newPromotedModules(buildInfo *buildinfo.BuildInfo, releaseVersion string) ([]*promotedModule, error) {
+	var modules []*promotedModule
+	for _, module := range buildInfo.Modules {
+		if module.Type != buildinfo.Maven {
+			continue
+		}
+		coordinate := strings.Split(module.Id, ":")
+		if len(coordinate) != 3 || !strings.HasSuffix(coordinate[2], snapshotSuffix) {
+			log.Warn("Skipping the module", module.Id, "which doesn't have a snapshot version.")
+			continue
+		}
+		moduleReleaseVersion := releaseVersion
+		if moduleReleaseVersion == "" {
+			moduleReleaseVersion = strings.TrimSuffix(coordinate[2], snapshotSuffix)
+		}
+		modules = append(modules, &promotedModule{
+			MavenCoordinate: MavenCoordinate{GroupId: coordinate[0], ArtifactId: coordinate[1], Version: coordinate[2]},
+			releaseVersion:  moduleReleaseVersion,
+			module:          module,
+		})
+	}
+	if len(modules) == 0 {
+		return nil, errorutils.CheckErrorf("build %s/%s has no Maven modules with a snapshot version", buildInfo.Name, buildInfo.Number)
+	}
+	return modules, nil
+}
+
+// setTargetBuildDefaults sets the name of the release build-info to the name of the snapshot build, and its number to the release version.
+func (mpc *MvnPromoteCommand) setTargetBuildDefaults(modules []*promotedModule) error {
+	if mpc.targetBuildName == "" {
+		mpc.targetBuildName = mpc.buildName
+	}
+	if mpc.targetBuildNumber != "" {
+		return nil
+	}
+	for _, module := range modules {
+		if module.releaseVersion != modules[0].releaseVersion {
+			return errorutils.CheckErrorf("the modules of build %s/%s have different versions, so the number of the release build must be provided", mpc.buildName, mpc.buildNumber)
+		}
+	}
+	mpc.targetBuildNumber = modules[0].releaseVersion
+	return nil
+}
+
+// findSnapshotArtifacts finds the artifacts of the snapshot build in Artifactory, by their checksums, and returns them with their release paths.
+func (mpc *MvnPromoteCommand) findSnapshotArtifacts(servicesManager artifactory.ArtifactoryServicesManager, modules []*promotedModule) (artifacts []promotedArtifact, err error) {
+	reader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &servicesUtils.CommonParams{
+		Pattern: "*", Build: mpc.buildName + "/" + mpc.buildNumber, Project: mpc.project, Recursive: true,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	itemsBySha1 := make(map[string]string)
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		itemsBySha1[item.Actual_Sha1] = item.GetItemRelativePath()
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		for _, artifact := range module.module.Artifacts {
+			source, found := itemsBySha1[artifact.Sha1]
+			if !found {
+				return nil, errorutils.CheckErrorf("the artifact %s of the module %s wasn't found in Artifactory", artifact.Name, module.String())
+			}
+			releasePath, err := module.releaseArtifactPath(artifact.Name)
+			if err != nil {
+				return nil, err
+			}
+			artifacts = append(artifacts, promotedArtifact{module: module, artifact: artifact, source: source, releasePath: releasePath})
+		}
+	}
+	return artifacts, nil
+}
+
+// prepareReleaseArtifacts downloads the snapshot artifacts to their release paths in the directory, and updates the versions of the POMs.
+// Returns the release artifacts of each module, by the module ID.
+func (mpc *MvnPromoteCommand) prepareReleaseArtifacts(servicesManager artifactory.ArtifactoryServicesManager, dir string, modules []*promotedModule,
+	artifacts []promotedArtifact) (map[string][]buildinfo.Artifact, error) {
+	modulesByKey := make(map[string]*promotedModule)
+	for _, module := range modules {
+		modulesByKey[module.GroupId+":"+module.ArtifactId] = module
+	}
+	releaseArtifacts := make(map[string][]buildinfo.Artifact)
+	for _, artifact := range artifacts {
+		localPath := filepath.Join(dir, filepath.FromSlash(artifact.releasePath))
+		if err := downloadFile(servicesManager, artifact.source, localPath); err != nil {
+			return nil, err
+		}
+		if isPomPath(artifact.releasePath) {
+			if err := rewritePomVersions(localPath, modulesByKey); err != nil {
+				return nil, err
+			}
+		}
+		details, err := fileutils.GetFileDetails(localPath, true)
+		if err != nil {
+			return nil, err
+		}
+		releaseArtifact := buildinfo.Artifact{
+			Name:                   path.Base(artifact.releasePath),
+			Type:                   artifact.artifact.Type,
+			Path:                   artifact.releasePath,
+			OriginalDeploymentRepo: mpc.releaseRepo,
+			Checksum:               details.Checksum,
+		}
+		releaseArtifacts[artifact.module.module.Id] = append(releaseArtifacts[artifact.module.module.Id], releaseArtifact)
+	}
+	return releaseArtifacts, nil
+}
+
+func isPomPath(artifactPath string) bool {
+	return strings.HasSuffix(artifactPath, ".pom")
+}
+
+func downloadFile(servicesManager artifactory.ArtifactoryServicesManager, source, localPath string) (err error) {
+	log.Debug("Downloading", source, "to", localPath)
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	remoteFile, err := servicesManager.ReadRemoteFile(source)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(remoteFile.Close()))
+	}()
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(localFile.Close()))
+	}()
+	_, err = io.Copy(localFile, remoteFile)
+	return errorutils.CheckError(err)
+}
+
+// rewritePomVersions replaces the snapshot versions of the promoted modules in the POM with their release versions:
+// the version of the project, the version of its parent, and the versions of the dependencies and the plugins.
+func rewritePomVersions(pomPath string, modules map[string]*promotedModule) error {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromFile(pomPath); err != nil {
+		return errorutils.CheckErrorf("failed to parse the POM %s: %s", pomPath, err.Error())
+	}
+	project := doc.SelectElement("project")
+	if project == nil {
+		return errorutils.CheckErrorf("the POM %s has no project element", pomPath)
+	}
+	parentGroupId := ""
+	if parent := project.SelectElement("parent"); parent != nil {
+		parentGroupId = childText(parent, "groupId")
+		rewriteVersion(parent, parentGroupId, modules)
+	}
+	// The group ID of the project is inherited from its parent if it's omitted.
+	groupId := childText(project, "groupId")
+	if groupId == "" {
+		groupId = parentGroupId
+	}
+	rewriteVersion(project, groupId, modules)
+	for _, dependency := range project.FindElements("//dependencies/dependency") {
+		rewriteVersion(dependency, childText(dependency, "groupId"), modules)
+	}
+	for _, plugin := range project.FindElements("//plugins/plugin") {
+		rewriteVersion(plugin, childText(plugin, "groupId"), modules)
+	}
+	return errorutils.CheckError(doc.WriteToFile(pomPath))
+}
+
+// rewriteVersion replaces the version of the element, if it's the snapshot version of a promoted module.
+func rewriteVersion(element *etree.Element, groupId string, modules map[string]*promotedModule) {
+	module, found := modules[groupId+":"+childText(element, "artifactId")]
+	version := element.SelectElement("version")
+	if !found || version == nil || strings.TrimSpace(version.Text()) != module.Version {
+		return
+	}
+	version.SetText(module.releaseVersion)
+}
+
+// deployReleaseArtifacts uploads the release artifacts to the release repository, with the properties of the release build.
+// The POMs are deployed last, so that a module's POM is never in the release repository without its artifacts.
+func (mpc *MvnPromoteCommand) deployReleaseArtifacts(servicesManager artifactory.ArtifactoryServicesManager, dir string, artifacts []promotedArtifact, started time.Time) error {
+	buildProps := fmt.Sprintf("build.name=%s;build.number=%s;build.timestamp=%s", mpc.targetBuildName, mpc.targetBuildNumber, strconv.FormatInt(started.UnixMilli(), 10))
+	var artifactParams, pomParams []services.UploadParams
+	for _, artifact := range artifacts {
+		uploadParams := services.NewUploadParams()
+		uploadParams.Pattern = filepath.Join(dir, filepath.FromSlash(artifact.releasePath))
+		uploadParams.Target = path.Join(mpc.releaseRepo, artifact.releasePath)
+		uploadParams.BuildProps = buildProps
+		uploadParams.Flat = true
+		if isPomPath(artifact.releasePath) {
+			pomParams = append(pomParams, uploadParams)
+		} else {
+			artifactParams = append(artifactParams, uploadParams)
+		}
+	}
+	log.Info(fmt.Sprintf("Deploying %d release artifacts to %s...", len(artifacts), mpc.releaseRepo))
+	for _, params := range [][]services.UploadParams{artifactParams, pomParams} {
+		if len(params) == 0 {
+			continue
+		}
+		succeeded, failed, err := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, params...)
+		if err != nil {
+			return err
+		}
+		if failed > 0 || succeeded < len(params) {
+			return errorutils.CheckErrorf("failed to deploy %d of the %d release artifacts to %s", len(params)-succeeded, len(params), mpc.releaseRepo)
+		}
+	}
+	return nil
+}
+
+// createReleaseBuildInfo creates the build-info of the release artifacts. The dependencies and the VCS details are those of the snapshot build,
+// and its properties link it to the snapshot build.
+func (mpc *MvnPromoteCommand) createReleaseBuildInfo(snapshotBuildInfo *buildinfo.BuildInfo, modules []*promotedModule,
+	releaseArtifacts map[string][]buildinfo.Artifact, started time.Time) *buildinfo.BuildInfo {
+	releaseBuildInfo := buildinfo.New()
+	releaseBuildInfo.Name = mpc.targetBuildName
+	releaseBuildInfo.Number = mpc.targetBuildNumber
+	releaseBuildInfo.Started = started.Format(buildinfo.TimeFormat)
+	releaseBuildInfo.SetAgentName(coreutils.GetCliUserAgentName())
+	releaseBuildInfo.Agent.Version = coreutils.GetCliUserAgentVersion()
+	releaseBuildInfo.BuildAgent = snapshotBuildInfo.BuildAgent
+	releaseBuildInfo.VcsList = snapshotBuildInfo.VcsList
+	releaseBuildInfo.Properties = buildinfo.Env{SnapshotBuildNameProp: snapshotBuildInfo.Name, SnapshotBuildNumberProp: snapshotBuildInfo.Number}
+	modulesById := make(map[string]*promotedModule)
+	for _, module := range modules {
+		modulesById[module.module.Id] = module
+	}
+	for _, module := range modules {
+		dependencies := make([]buildinfo.Dependency, 0, len(module.module.Dependencies))
+		for _, dependency := range module.module.Dependencies {
+			if dependencyModule, found := modulesById[dependency.Id]; found {
+				dependency.Id = dependencyModule.releaseCoordinate().String()
+			}
+			dependencies = append(dependencies, dependency)
+		}
+		releaseBuildInfo.Modules = append(releaseBuildInfo.Modules, buildinfo.Module{
+			Type:         buildinfo.Maven,
+			Id:           module.releaseCoordinate().String(),
+			Properties:   module.module.Properties,
+			Artifacts:    releaseArtifacts[module.module.Id],
+			Dependencies: dependencies,
+		})
+	}
+	return releaseBuildInfo
+}
+
+func PrintMvnPromoteTable(result *MvnPromoteResult) error {
+	rows := make([]promotedArtifactRow, 0, len(result.Artifacts))
+	for _, artifact := range result.Artifacts {
+		rows = append(rows, promotedArtifactRow(artifact))
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Module < rows[j].Module })
+	title := fmt.Sprintf("Artifacts of build %s promoted to build %s", result.SourceBuild, result.TargetBuild)
+	if result.DryRun {
+		title = fmt.Sprintf("Artifacts of build %s to promote to build %s", result.SourceBuild, result.TargetBuild)
+	}
+	return coreutils.PrintTable(rows, title, "No artifacts found", false)
+}
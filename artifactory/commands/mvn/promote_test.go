@@ -0,0 +1,160 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSnapshotBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "app-build",
+		Number:  "42",
+		VcsList: []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc123"}},
+		Modules: []buildinfo.Module{
+			{Type: buildinfo.Maven, Id: "org.acme:app:1.2-SNAPSHOT", Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.2-20240101.120000-3.jar", Type: "jar"},
+				{Name: "app-1.2-20240101.120000-3-sources.jar", Type: "jar"},
+				{Name: "app-1.2-20240101.120000-3.pom", Type: "pom"},
+			}, Dependencies: []buildinfo.Dependency{{Id: "org.acme:lib:1.2-SNAPSHOT"}, {Id: "junit:junit:4.13"}}},
+			{Type: buildinfo.Maven, Id: "org.acme:lib:1.2-SNAPSHOT", Artifacts: []buildinfo.Artifact{{Name: "lib-1.2-SNAPSHOT.jar", Type: "jar"}}},
+			{Type: buildinfo.Maven, Id: "org.acme:released:1.0"},
+			{Type: buildinfo.Npm, Id: "ui:1.2.0-SNAPSHOT"},
+		},
+	}
+}
+
+func TestNewPromotedModules(t *testing.T) {
+	modules, err := newPromotedModules(newTestSnapshotBuildInfo(), "")
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+	assert.Equal(t, MavenCoordinate{GroupId: "org.acme", ArtifactId: "app", Version: "1.2-SNAPSHOT"}, modules[0].MavenCoordinate)
+	assert.Equal(t, "1.2", modules[0].releaseVersion)
+
+	modules, err = newPromotedModules(newTestSnapshotBuildInfo(), "2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", modules[1].releaseVersion)
+
+	_, err = newPromotedModules(&buildinfo.BuildInfo{Name: "empty", Number: "1"}, "")
+	assert.ErrorContains(t, err, "has no Maven modules with a snapshot version")
+}
+
+func TestReleaseArtifactPath(t *testing.T) {
+	module := promotedModule{MavenCoordinate: MavenCoordinate{GroupId: "org.acme", ArtifactId: "app", Version: "1.2-SNAPSHOT"}, releaseVersion: "1.2"}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"app-1.2-SNAPSHOT.jar", "org/acme/app/1.2/app-1.2.jar"},
+		{"app-1.2-20240101.120000-3.pom", "org/acme/app/1.2/app-1.2.pom"},
+		{"app-1.2-20240101.120000-3-sources.jar", "org/acme/app/1.2/app-1.2-sources.jar"},
+		{"app-1.2-SNAPSHOT.tar.gz", "org/acme/app/1.2/app-1.2.tar.gz"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releasePath, err := module.releaseArtifactPath(test.name)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, releasePath)
+		})
+	}
+	_, err := module.releaseArtifactPath("other-1.2-SNAPSHOT.jar")
+	assert.ErrorContains(t, err, "doesn't match the snapshot version")
+}
+
+func TestRewritePomVersions(t *testing.T) {
+	pomPath := filepath.Join(t.TempDir(), "app.pom")
+	require.NoError(t, os.WriteFile(pomPath, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <groupId>org.acme</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.2-SNAPSHOT</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <version>1.2-SNAPSHOT</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>lib</artifactId>
+      <version>1.2-SNAPSHOT</version>
+    </dependency>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>lib</artifactId>
+      <version>1.2-SNAPSHOT</version>
+    </dependency>
+  </dependencies>
+</project>
+`), 0644))
+	modules := map[string]*promotedModule{}
+	for _, artifactId := range []string{"parent", "app", "lib"} {
+		modules["org.acme:"+artifactId] = &promotedModule{MavenCoordinate: MavenCoordinate{GroupId: "org.acme", ArtifactId: artifactId, Version: "1.2-SNAPSHOT"}, releaseVersion: "1.2"}
+	}
+
+	require.NoError(t, rewritePomVersions(pomPath, modules))
+	content, err := os.ReadFile(pomPath)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <groupId>org.acme</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.2</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <version>1.2</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>lib</artifactId>
+      <version>1.2</version>
+    </dependency>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>lib</artifactId>
+      <version>1.2-SNAPSHOT</version>
+    </dependency>
+  </dependencies>
+</project>
+`, string(content))
+}
+
+func TestCreateReleaseBuildInfo(t *testing.T) {
+	snapshotBuildInfo := newTestSnapshotBuildInfo()
+	modules, err := newPromotedModules(snapshotBuildInfo, "")
+	require.NoError(t, err)
+	mpc := NewMvnPromoteCommand().SetBuild("app-build", "42").SetReleaseRepo("libs-release-local")
+	require.NoError(t, mpc.setTargetBuildDefaults(modules))
+	releaseJar := buildinfo.Artifact{Name: "app-1.2.jar", Path: "org/acme/app/1.2/app-1.2.jar", OriginalDeploymentRepo: "libs-release-local"}
+
+	releaseBuildInfo := mpc.createReleaseBuildInfo(snapshotBuildInfo, modules, map[string][]buildinfo.Artifact{"org.acme:app:1.2-SNAPSHOT": {releaseJar}}, time.Now())
+	assert.Equal(t, "app-build", releaseBuildInfo.Name)
+	assert.Equal(t, "1.2", releaseBuildInfo.Number)
+	assert.Equal(t, buildinfo.Env{SnapshotBuildNameProp: "app-build", SnapshotBuildNumberProp: "42"}, releaseBuildInfo.Properties)
+	assert.Equal(t, snapshotBuildInfo.VcsList, releaseBuildInfo.VcsList)
+	require.Len(t, releaseBuildInfo.Modules, 2)
+	assert.Equal(t, "org.acme:app:1.2", releaseBuildInfo.Modules[0].Id)
+	assert.Equal(t, []buildinfo.Artifact{releaseJar}, releaseBuildInfo.Modules[0].Artifacts)
+	// The dependencies on the promoted modules are on their release versions.
+	assert.Equal(t, []buildinfo.Dependency{{Id: "org.acme:lib:1.2"}, {Id: "junit:junit:4.13"}}, releaseBuildInfo.Modules[0].Dependencies)
+	assert.Equal(t, "org.acme:lib:1.2", releaseBuildInfo.Modules[1].Id)
+}
+
+func TestSetTargetBuildDefaultsWithDifferentVersions(t *testing.T) {
+	buildInfo := newTestSnapshotBuildInfo()
+	buildInfo.Modules[1].Id = "org.acme:lib:2.0-SNAPSHOT"
+	modules, err := newPromotedModules(buildInfo, "")
+	require.NoError(t, err)
+	err = NewMvnPromoteCommand().SetBuild("app-build", "42").setTargetBuildDefaults(modules)
+	assert.ErrorContains(t, err, "the number of the release build must be provided")
+
+	mpc := NewMvnPromoteCommand().SetBuild("app-build", "42").SetTargetBuild("", "7")
+	require.NoError(t, mpc.setTargetBuildDefaults(modules))
+	assert.Equal(t, "app-build", mpc.targetBuildName)
+	assert.Equal(t, "7", mpc.targetBuildNumber)
+}
@@ -0,0 +1,196 @@
+package mvn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beevik/etree"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The mirror and its server are written between these markers, in the mirrors and the servers sections of the settings.
+	// The content outside them is kept when the settings are written again.
+	settingsXmlBeginMarker = "BEGIN JFROG CLI MANAGED BLOCK. The content of this block is overwritten by the JFrog CLI."
+	settingsXmlEndMarker   = "END JFROG CLI MANAGED BLOCK"
+	// An existing settings file, which wasn't written by the JFrog CLI, is backed up to a file with this suffix before it's updated.
+	SettingsXmlBackupSuffix = ".bak"
+	// DefaultSettingsServerId is the ID of the mirror, and of the server of its credentials, if no ID is provided.
+	DefaultSettingsServerId = "artifactory"
+
+	// jfrog-ignore - Maven XML namespace URL, required by specification
+	settingsXmlns = "http://maven.apache.org/SETTINGS/1.2.0"
+)
+
+type SettingsXmlConfig struct {
+	ArtifactoryURL string
+	// The repository which resolves all the dependencies and plugins of the builds, as the mirror of their repositories.
+	RepoName string
+	// The repositories the mirror replaces, in the format of the mirrorOf element. All the repositories are mirrored if empty.
+	MirrorOf string
+	// The ID of the mirror and of the server of its credentials. DefaultSettingsServerId is used if empty.
+	ServerId string
+	// If both the username and the password are empty, the server isn't written and the repository is accessed anonymously.
+	Username string
+	// The password or the access token of the user.
+	Password string
+}
+
+// UserSettingsXmlPath returns the path of the settings file of the user, which Maven reads in addition to the global settings.
+func UserSettingsXmlPath() string {
+	return filepath.Join(clientutils.GetUserHomeDir(), ".m2", "settings.xml")
+}
+
+// CreateMavenSettingsFile configures Artifactory as the mirror of the Maven repositories in the settings file, so that Maven
+// resolves through Artifactory without the build-info extractor. If the file doesn't exist, it's created. Otherwise, only the
+// managed blocks of the mirror and of its server are replaced, and an existing file without managed blocks is backed up first.
+// If settingsXmlPath is empty, the settings file of the user is used.
+func CreateMavenSettingsFile(settingsXmlPath string, config SettingsXmlConfig) error {
+	if config.ArtifactoryURL == "" || config.RepoName == "" {
+		return errorutils.CheckErrorf("the Artifactory URL and the repository are mandatory for configuring the Maven mirror")
+	}
+	if settingsXmlPath == "" {
+		settingsXmlPath = UserSettingsXmlPath()
+	}
+	settingsXmlPath = filepath.Clean(settingsXmlPath)
+	if config.ServerId == "" {
+		config.ServerId = DefaultSettingsServerId
+	}
+	if config.MirrorOf == "" {
+		config.MirrorOf = "*"
+	}
+
+	doc, existing, err := readSettingsXml(settingsXmlPath)
+	if err != nil {
+		return err
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "settings" {
+		return errorutils.CheckErrorf("failed to read %s: the file isn't a Maven settings file", settingsXmlPath)
+	}
+	var server *etree.Element
+	if config.Username != "" || config.Password != "" {
+		server = newSettingsElement("server", config.ServerId, map[string]string{"username": config.Username, "password": config.Password})
+	}
+	mirror := newSettingsElement("mirror", config.ServerId, map[string]string{
+		"name":     "Artifactory",
+		"url":      strings.TrimSuffix(config.ArtifactoryURL, "/") + "/" + config.RepoName,
+		"mirrorOf": config.MirrorOf,
+	})
+	hadServerBlock := replaceManagedElement(getOrCreateChild(root, "servers"), "server", config.ServerId, server)
+	hadMirrorBlock := replaceManagedElement(getOrCreateChild(root, "mirrors"), "mirror", config.ServerId, mirror)
+
+	if len(existing) > 0 && !hadServerBlock && !hadMirrorBlock {
+		backupPath := settingsXmlPath + SettingsXmlBackupSuffix
+		if err = os.WriteFile(backupPath, existing, 0600); err != nil { // #nosec G703 -- path sanitized with filepath.Clean
+			return errorutils.CheckErrorf("failed to back up the Maven settings to %s: %s", backupPath, err.Error())
+		}
+		log.Info(fmt.Sprintf("The existing Maven settings %s were backed up to %s.", settingsXmlPath, backupPath))
+	}
+	doc.Indent(2)
+	content, err := doc.WriteToBytes()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(settingsXmlPath), 0755); err != nil {
+		return errorutils.CheckErrorf("failed to create the directory of %s: %s", settingsXmlPath, err.Error())
+	}
+	// The settings hold the credentials of the server.
+	if err = os.WriteFile(settingsXmlPath, content, 0600); err != nil { // #nosec G703 -- path sanitized with filepath.Clean
+		return errorutils.CheckErrorf("failed to write the Maven settings to %s: %s", settingsXmlPath, err.Error())
+	}
+	return nil
+}
+
+// readSettingsXml reads the settings file, or creates an empty settings document if it doesn't exist.
+// The content of an existing file is returned, so that it can be backed up.
+func readSettingsXml(settingsXmlPath string) (doc *etree.Document, existing []byte, err error) {
+	doc = etree.NewDocument()
+	existing, err = os.ReadFile(settingsXmlPath) // #nosec G304 -- path sanitized with filepath.Clean
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, nil, errorutils.CheckErrorf("failed to read %s: %s", settingsXmlPath, err.Error())
+		}
+		doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+		doc.CreateElement("settings").CreateAttr("xmlns", settingsXmlns)
+		return doc, nil, nil
+	}
+	if err = doc.ReadFromBytes(existing); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to parse %s: %s", settingsXmlPath, err.Error())
+	}
+	return doc, existing, nil
+}
+
+func newSettingsElement(tag, id string, fields map[string]string) *etree.Element {
+	element := etree.NewElement(tag)
+	element.CreateElement("id").SetText(id)
+	// Keep the order of the schema, for readability.
+	for _, name := range []string{"name", "username", "password", "url", "mirrorOf"} {
+		if value, ok := fields[name]; ok {
+			element.CreateElement(name).SetText(value)
+		}
+	}
+	return element
+}
+
+// replaceManagedElement replaces the managed block of the section with a block of the element, and returns true if the section had one.
+// Elements of the same ID outside the block are removed, since Maven would use only one of them.
+// If the element is nil, only the existing block is removed.
+func replaceManagedElement(section *etree.Element, tag, id string, element *etree.Element) (hadManagedBlock bool) {
+	for _, child := range section.SelectElements(tag) {
+		if childText(child, "id") == id {
+			section.RemoveChildAt(child.Index())
+		}
+	}
+	insertAt, end := -1, -1
+	for i, token := range section.Child {
+		comment, ok := token.(*etree.Comment)
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(comment.Data) {
+		case settingsXmlBeginMarker:
+			if insertAt < 0 {
+				insertAt = i
+			}
+		case settingsXmlEndMarker:
+			if insertAt >= 0 && end < 0 {
+				end = i
+			}
+		}
+	}
+	if insertAt >= 0 && end >= 0 {
+		hadManagedBlock = true
+		for i := end; i >= insertAt; i-- {
+			section.RemoveChildAt(i)
+		}
+	} else {
+		insertAt = len(section.Child)
+	}
+	if element == nil {
+		return
+	}
+	for i, token := range []etree.Token{etree.NewComment(" " + settingsXmlBeginMarker + " "), element, etree.NewComment(" " + settingsXmlEndMarker + " ")} {
+		section.InsertChildAt(insertAt+i, token)
+	}
+	return
+}
+
+func getOrCreateChild(parent *etree.Element, tag string) *etree.Element {
+	child := parent.SelectElement(tag)
+	if child == nil {
+		child = parent.CreateElement(tag)
+	}
+	return child
+}
+
+func childText(parent *etree.Element, tag string) string {
+	if child := parent.SelectElement(tag); child != nil {
+		return strings.TrimSpace(child.Text())
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readSettingsXmlForTest(t *testing.T, settingsXmlPath string) *etree.Element {
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromFile(settingsXmlPath))
+	return doc.Root()
+}
+
+func TestCreateMavenSettingsFile(t *testing.T) {
+	settingsXmlPath := filepath.Join(t.TempDir(), ".m2", "settings.xml")
+	config := SettingsXmlConfig{ArtifactoryURL: "https://acme.jfrog.io/artifactory/", RepoName: "maven-virtual", Username: "admin", Password: "p<ss"}
+	require.NoError(t, CreateMavenSettingsFile(settingsXmlPath, config))
+
+	root := readSettingsXmlForTest(t, settingsXmlPath)
+	assert.Equal(t, settingsXmlns, root.SelectAttrValue("xmlns", ""))
+	mirror := root.FindElement("mirrors/mirror")
+	require.NotNil(t, mirror)
+	assert.Equal(t, DefaultSettingsServerId, childText(mirror, "id"))
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/maven-virtual", childText(mirror, "url"))
+	assert.Equal(t, "*", childText(mirror, "mirrorOf"))
+	server := root.FindElement("servers/server")
+	require.NotNil(t, server)
+	assert.Equal(t, DefaultSettingsServerId, childText(server, "id"))
+	assert.Equal(t, "admin", childText(server, "username"))
+	assert.Equal(t, "p<ss", childText(server, "password"))
+
+	info, err := os.Stat(settingsXmlPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	// A file written by the JFrog CLI isn't backed up.
+	assert.NoFileExists(t, settingsXmlPath+SettingsXmlBackupSuffix)
+}
+
+func TestCreateMavenSettingsFileMergesExistingSettings(t *testing.T) {
+	settingsXmlPath := filepath.Join(t.TempDir(), "settings.xml")
+	existing := `<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.2.0">
+  <localRepository>/cache/m2</localRepository>
+  <servers>
+    <server>
+      <id>releases</id>
+      <username>deployer</username>
+    </server>
+    <server>
+      <id>artifactory</id>
+      <username>old</username>
+    </server>
+  </servers>
+</settings>
+`
+	require.NoError(t, os.WriteFile(settingsXmlPath, []byte(existing), 0644))
+	config := SettingsXmlConfig{ArtifactoryURL: "https://acme.jfrog.io/artifactory", RepoName: "maven-virtual", Username: "admin", Password: "token"}
+	require.NoError(t, CreateMavenSettingsFile(settingsXmlPath, config))
+
+	backup, err := os.ReadFile(settingsXmlPath + SettingsXmlBackupSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, existing, string(backup))
+
+	// Writing the settings again only replaces the managed blocks.
+	require.NoError(t, os.Remove(settingsXmlPath+SettingsXmlBackupSuffix))
+	config.RepoName = "maven-remote"
+	config.MirrorOf = "central"
+	require.NoError(t, CreateMavenSettingsFile(settingsXmlPath, config))
+	assert.NoFileExists(t, settingsXmlPath+SettingsXmlBackupSuffix)
+
+	content, err := os.ReadFile(settingsXmlPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), settingsXmlBeginMarker))
+	assert.Equal(t, 2, strings.Count(string(content), settingsXmlEndMarker))
+
+	root := readSettingsXmlForTest(t, settingsXmlPath)
+	assert.Equal(t, "/cache/m2", childText(root, "localRepository"))
+	servers := root.FindElements("servers/server")
+	require.Len(t, servers, 2)
+	assert.Equal(t, "releases", childText(servers[0], "id"))
+	assert.Equal(t, "deployer", childText(servers[0], "username"))
+	assert.Equal(t, DefaultSettingsServerId, childText(servers[1], "id"))
+	assert.Equal(t, "admin", childText(servers[1], "username"))
+	mirrors := root.FindElements("mirrors/mirror")
+	require.Len(t, mirrors, 1)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/maven-remote", childText(mirrors[0], "url"))
+	assert.Equal(t, "central", childText(mirrors[0], "mirrorOf"))
+}
+
+func TestCreateMavenSettingsFileAnonymous(t *testing.T) {
+	settingsXmlPath := filepath.Join(t.TempDir(), "settings.xml")
+	config := SettingsXmlConfig{ArtifactoryURL: "https://acme.jfrog.io/artifactory", RepoName: "maven-virtual", ServerId: "acme", Username: "admin", Password: "token"}
+	require.NoError(t, CreateMavenSettingsFile(settingsXmlPath, config))
+	config.Username, config.Password = "", ""
+	require.NoError(t, CreateMavenSettingsFile(settingsXmlPath, config))
+
+	root := readSettingsXmlForTest(t, settingsXmlPath)
+	assert.Empty(t, root.FindElements("servers/server"))
+	mirror := root.FindElement("mirrors/mirror")
+	require.NotNil(t, mirror)
+	assert.Equal(t, "acme", childText(mirror, "id"))
+}
+
+func TestCreateMavenSettingsFileValidation(t *testing.T) {
+	settingsXmlPath := filepath.Join(t.TempDir(), "settings.xml")
+	assert.Error(t, CreateMavenSettingsFile(settingsXmlPath, SettingsXmlConfig{ArtifactoryURL: "https://acme.jfrog.io/artifactory"}))
+	require.NoError(t, os.WriteFile(settingsXmlPath, []byte("<project/>"), 0644))
+	assert.ErrorContains(t, CreateMavenSettingsFile(settingsXmlPath, SettingsXmlConfig{ArtifactoryURL: "https://acme.jfrog.io/artifactory", RepoName: "maven-virtual"}), "isn't a Maven settings file")
+}
@@ -0,0 +1,38 @@
+package mvn
+
+import (
+	"encoding/json"
+
+	artifactoryutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// isJsonDetailedSummary returns true if the detailed summary should be printed as a JSON document. When scanning the build's artifacts,
+// the format applies to the scan results.
+func (mc *MvnCommand) isJsonDetailedSummary() bool {
+	return mc.IsDetailedSummary() && mc.scanOutputFormat == format.Json && !mc.IsXrayScan()
+}
+
+// DeploymentSummary returns the JSON detailed summary of the build's artifacts, if it was printed.
+func (mc *MvnCommand) DeploymentSummary() *artifactoryutils.DeploymentSummary {
+	return mc.deploymentSummary
+}
+
+func (mc *MvnCommand) printDeploymentSummary() error {
+	data, err := json.Marshal(mc.deploymentSummary)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(data))
+	return nil
+}
+
+// createDeploymentSummary creates the detailed summary of the deployable artifacts file written by the Maven extractor.
+// The artifacts for which the extractor didn't list the target repository are deployed to the snapshot or the release repository of the configuration.
+func createDeploymentSummary(deployableArtifactsFile string, vConfig *viper.Viper) (*artifactoryutils.DeploymentSummary, error) {
+	return artifactoryutils.CreateDeploymentSummary(deployableArtifactsFile, deploymentRepo(vConfig))
+}
@@ -0,0 +1,55 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils/summary"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDeploymentSummary(t *testing.T) {
+	dir := t.TempDir()
+	deployableArtifacts := `{
+  "org:app": [
+    {"sourcePath": "app-1.0.jar", "artifactDest": "org/app/1.0/app-1.0.jar", "sha256": "jar", "deploySucceeded": true, "targetRepository": "libs-custom-local"},
+    {"sourcePath": "app-1.0.pom", "artifactDest": "org/app/1.0/app-1.0.pom", "sha256": "pom", "deploySucceeded": true}
+  ],
+  "org:lib": [
+    {"sourcePath": "lib-2.0-SNAPSHOT.jar", "artifactDest": "org/lib/2.0-SNAPSHOT/lib-2.0-SNAPSHOT.jar", "sha256": "lib"}
+  ]
+}`
+	deployableArtifactsFile := filepath.Join(dir, "deployable-artifacts.json")
+	require.NoError(t, os.WriteFile(deployableArtifactsFile, []byte(deployableArtifacts), 0644))
+	vConfig := viper.New()
+	vConfig.Set(build.DeployerPrefix+build.ReleaseRepo, "libs-release-local")
+	vConfig.Set(build.DeployerPrefix+build.SnapshotRepo, "libs-snapshot-local")
+
+	deploymentSummary, err := createDeploymentSummary(deployableArtifactsFile, vConfig)
+	require.NoError(t, err)
+	assert.Equal(t, summary.Failure, deploymentSummary.Status)
+	assert.Equal(t, &summary.Totals{Success: 2, Failure: 1}, deploymentSummary.Totals)
+	var targets []string
+	for _, file := range deploymentSummary.Files {
+		targets = append(targets, file.Target)
+	}
+	assert.Equal(t, []string{
+		"libs-custom-local/org/app/1.0/app-1.0.jar",
+		"libs-release-local/org/app/1.0/app-1.0.pom",
+		"libs-snapshot-local/org/lib/2.0-SNAPSHOT/lib-2.0-SNAPSHOT.jar",
+	}, targets)
+}
+
+func TestIsJsonDetailedSummary(t *testing.T) {
+	mc := NewMvnCommand().SetDetailedSummary(true).SetScanOutputFormat(format.Json)
+	assert.True(t, mc.isJsonDetailedSummary())
+	// The format applies to the scan results.
+	assert.False(t, mc.SetXrayScan(true).isJsonDetailedSummary())
+	assert.False(t, NewMvnCommand().SetDetailedSummary(true).SetScanOutputFormat(format.Table).isJsonDetailedSummary())
+	assert.False(t, NewMvnCommand().SetScanOutputFormat(format.Json).isJsonDetailedSummary())
+}
@@ -1,6 +1,7 @@
 package mvn
 
 import (
+	"context"
 	"io"
 	"os"
 	"os/exec"
@@ -33,7 +34,10 @@ type MvnUtils struct {
 	threads                   int
 	insecureTls               bool
 	disableDeploy             bool
+	useMvnd                   bool
 	outputWriter              io.Writer
+	// The build is stopped when the context is done.
+	ctx context.Context
 }
 
 func NewMvnUtils() *MvnUtils {
@@ -75,6 +79,16 @@ func (mu *MvnUtils) SetDisableDeploy(disableDeploy bool) *MvnUtils {
 	return mu
 }
 
+func (mu *MvnUtils) SetUseMvnd(useMvnd bool) *MvnUtils {
+	mu.useMvnd = useMvnd
+	return mu
+}
+
+func (mu *MvnUtils) SetContext(ctx context.Context) *MvnUtils {
+	mu.ctx = ctx
+	return mu
+}
+
 func (mu *MvnUtils) SetConfig(vConfig *viper.Viper) *MvnUtils {
 	mu.vConfig = vConfig
 	return mu
@@ -93,7 +107,7 @@ func RunMvn(mu *MvnUtils) error {
 		cmd := exec.Command("mvn", mu.goals...)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
-		err := cmd.Run()
+		err := utils.RunWithContext(mu.ctx, cmd.Run)
 		if err != nil {
 			log.Error("Failed to execute package manager command: " + err.Error())
 			return errorutils.CheckError(err)
@@ -174,6 +188,17 @@ func RunMvn(mu *MvnUtils) error {
 	if err != nil {
 		return err
 	}
+	if mu.useMvnd || mu.vConfig.GetBool(useMvndConfigKey) {
+		if useWrapper {
+			return errorutils.CheckErrorf("the Maven wrapper can't be used with mvnd. Disable either the wrapper or mvnd in the Maven configuration.")
+		}
+		buildsDirPath := filepath.Join(coreutils.GetCliPersistentTempDirPath(), buildInfoService.GetUserSpecificBuildDirName())
+		mu.buildInfoFilePath, err = mu.runMvnd(buildsDirPath, buildName, buildNumber, mvnBuild.GetBuildTimestamp(), dependencyLocalPath, props)
+		return err
+	}
+	if isMvndProject(projectRoot) {
+		log.Info("The project is configured for mvnd, but is built by mvn. Use the --use-mvnd option to build it by mvnd.")
+	}
 	mavenModule.SetExtractorDetails(dependencyLocalPath,
 		filepath.Join(coreutils.GetCliPersistentTempDirPath(), buildUtils.PropertiesTempPath),
 		mu.goals,
@@ -183,7 +208,7 @@ func RunMvn(mu *MvnUtils) error {
 		SetOutputWriter(mu.outputWriter)
 	mavenModule.SetMavenOpts(mvnOpts...)
 	mavenModule.SetRootProjectDir(projectRoot)
-	if err = coreutils.ConvertExitCodeError(mavenModule.CalcDependencies()); err != nil {
+	if err = coreutils.ConvertExitCodeError(utils.RunWithContext(mu.ctx, mavenModule.CalcDependencies)); err != nil {
 		return err
 	}
 	mu.buildInfoFilePath = mavenModule.GetGeneratedBuildInfoPath()
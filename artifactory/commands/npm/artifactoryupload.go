@@ -6,6 +6,7 @@ import (
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory"
@@ -37,8 +38,8 @@ func (nru *npmRtUpload) upload() (err error) {
 	return
 }
 
-func (nru *npmRtUpload) getBuildArtifacts() []buildinfo.Artifact {
-	return ConvertArtifactsDetailsToBuildInfoArtifacts(nru.artifactsDetailsReader, specutils.ConvertArtifactsDetailsToBuildInfoArtifacts)
+func (nru *npmRtUpload) getBuildArtifacts(artifactsDetailsReader []*content.ContentReader) []buildinfo.Artifact {
+	return ConvertArtifactsDetailsToBuildInfoArtifacts(artifactsDetailsReader, specutils.ConvertArtifactsDetailsToBuildInfoArtifacts)
 }
 
 func (nru *npmRtUpload) doDeploy(target string, artDetails *config.ServerDetails, packedFilePath string) error {
@@ -56,7 +57,7 @@ func (nru *npmRtUpload) doDeploy(target string, artDetails *config.ServerDetails
 		return err
 	}
 	var totalFailed int
-	if nru.collectBuildInfo || nru.detailedSummary {
+	if nru.collectBuildInfo || nru.detailedSummary || jobsummary.IsEnabled() {
 		if nru.collectBuildInfo {
 			up.BuildProps, err = nru.getBuildPropsForArtifact()
 			if err != nil {
@@ -68,6 +69,7 @@ func (nru *npmRtUpload) doDeploy(target string, artDetails *config.ServerDetails
 			return err
 		}
 		totalFailed = summary.TotalFailed
+		jobsummary.RecordTransferDetails("npm package published to Artifactory", summary.TransferDetailsReader)
 		if nru.collectBuildInfo {
 			nru.artifactsDetailsReader = append(nru.artifactsDetailsReader, summary.ArtifactsDetailsReader)
 		} else {
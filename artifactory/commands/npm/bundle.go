@@ -0,0 +1,422 @@
+package npm
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The manifest is written to the root of the bundle, next to the lockfile and the tarballs, which are stored by the layout
+	// of the npm registry, <name>/-/<name without scope>-<version>.tgz.
+	BundleManifestFileName = "npm-bundle-manifest.json"
+	bundleManifestVersion  = 1
+	packageLockFileName    = "package-lock.json"
+)
+
+// NpmBundlePackage is a resolved dependency of the bundle, and the path of its tarball in the bundle.
+type NpmBundlePackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// The integrity of the package, as recorded by the lockfile.
+	Integrity string `json:"integrity,omitempty"`
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	Sha1      string `json:"sha1"`
+	Sha256    string `json:"sha256"`
+}
+
+// NpmBundleManifest lists the dependency tarballs of a bundle, so that it can be verified and installed from in an isolated environment.
+type NpmBundleManifest struct {
+	Version int    `json:"version"`
+	Created string `json:"created"`
+	// The repository the tarballs were downloaded through.
+	Repo     string             `json:"repo"`
+	Lockfile string             `json:"lockfile"`
+	Packages []NpmBundlePackage `json:"packages"`
+}
+
+// NpmBundleCommand downloads the tarballs of all the dependencies resolved by the package-lock.json of a project from Artifactory,
+// into a local directory or a zip archive, together with the lockfile and a manifest, for redistribution to air-gapped environments.
+// It runs after 'npm install' or 'npm ci', which resolve and lock the dependencies.
+type NpmBundleCommand struct {
+	serverDetails    *config.ServerDetails
+	repo             string
+	workingDirectory string
+	target           string
+	archive          bool
+	manifest         *NpmBundleManifest
+}
+
+func NewNpmBundleCommand() *NpmBundleCommand {
+	return &NpmBundleCommand{}
+}
+
+func (nbc *NpmBundleCommand) SetServerDetails(serverDetails *config.ServerDetails) *NpmBundleCommand {
+	nbc.serverDetails = serverDetails
+	return nbc
+}
+
+// SetRepo sets the npm repository which the dependencies are downloaded through.
+func (nbc *NpmBundleCommand) SetRepo(repo string) *NpmBundleCommand {
+	nbc.repo = repo
+	return nbc
+}
+
+// SetWorkingDirectory sets the directory of the project, which contains its package-lock.json.
+func (nbc *NpmBundleCommand) SetWorkingDirectory(workingDirectory string) *NpmBundleCommand {
+	nbc.workingDirectory = workingDirectory
+	return nbc
+}
+
+// SetTarget sets the directory, or the zip archive if archive is set, which the bundle is written to.
+func (nbc *NpmBundleCommand) SetTarget(target string) *NpmBundleCommand {
+	nbc.target = target
+	return nbc
+}
+
+func (nbc *NpmBundleCommand) SetArchive(archive bool) *NpmBundleCommand {
+	nbc.archive = archive
+	return nbc
+}
+
+func (nbc *NpmBundleCommand) Manifest() *NpmBundleManifest {
+	return nbc.manifest
+}
+
+func (nbc *NpmBundleCommand) CommandName() string {
+	return "rt_npm_bundle"
+}
+
+func (nbc *NpmBundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nbc.serverDetails, nil
+}
+
+func (nbc *NpmBundleCommand) Run() (err error) {
+	if nbc.repo == "" || nbc.target == "" {
+		return errorutils.CheckErrorf("the repository and the target of the bundle must be provided")
+	}
+	lockfilePath := filepath.Join(nbc.workingDirectory, packageLockFileName)
+	lockfileContent, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errorutils.CheckErrorf("%s was not found. Run 'npm install' or 'npm ci' before creating the bundle", lockfilePath)
+		}
+		return errorutils.CheckError(err)
+	}
+	packages, err := readLockfilePackages(lockfileContent)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(nbc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	bundleDir := nbc.target
+	if nbc.archive {
+		if bundleDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(bundleDir))
+		}()
+	}
+	nbc.manifest = &NpmBundleManifest{Version: bundleManifestVersion, Created: time.Now().UTC().Format(time.RFC3339), Repo: nbc.repo, Lockfile: packageLockFileName, Packages: []NpmBundlePackage{}}
+	log.Info(fmt.Sprintf("Downloading %d dependencies through %s...", len(packages), nbc.repo))
+	for _, lockedPackage := range packages {
+		bundlePackage, err := nbc.downloadTarball(servicesManager, bundleDir, lockedPackage)
+		if err != nil {
+			return err
+		}
+		nbc.manifest.Packages = append(nbc.manifest.Packages, *bundlePackage)
+	}
+	if err = writeBundleFile(bundleDir, packageLockFileName, lockfileContent); err != nil {
+		return err
+	}
+	manifestContent, err := json.MarshalIndent(nbc.manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = writeBundleFile(bundleDir, BundleManifestFileName, manifestContent); err != nil {
+		return err
+	}
+	if !nbc.archive {
+		log.Info(fmt.Sprintf("Bundled %d dependencies in %s.", len(nbc.manifest.Packages), nbc.target))
+		return nil
+	}
+	log.Info(fmt.Sprintf("Writing %d dependencies to %s...", len(nbc.manifest.Packages), nbc.target))
+	return writeBundleArchive(bundleDir, nbc.target, nbc.manifest)
+}
+
+// downloadTarball downloads the tarball of the package into the bundle directory, and verifies it against the integrity of the lockfile.
+// The tarball is downloaded from its resolved URL if it's resolved from the Artifactory server, and through the repository otherwise.
+func (nbc *NpmBundleCommand) downloadTarball(servicesManager artifactory.ArtifactoryServicesManager, bundleDir string, lockedPackage lockfilePackage) (bundlePackage *NpmBundlePackage, err error) {
+	tarballPath := lockedPackage.tarballPath()
+	artifactoryUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl()
+	downloadUrl := lockedPackage.Resolved
+	if !strings.HasPrefix(downloadUrl, artifactoryUrl) {
+		downloadUrl = artifactoryUrl + "api/npm/" + nbc.repo + "/" + tarballPath
+	}
+	log.Debug("Downloading", downloadUrl)
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	reader, resp, err := servicesManager.Client().ReadRemoteFile(downloadUrl, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		downloadErr := errorutils.CheckErrorf("failed to download %s@%s from %s: %s", lockedPackage.Name, lockedPackage.Version, downloadUrl, resp.Status)
+		return nil, errors.Join(downloadErr, errorutils.CheckError(resp.Body.Close()))
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+
+	localPath := filepath.Join(bundleDir, filepath.FromSlash(tarballPath))
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hashes := map[string]hash.Hash{"sha1": sha1.New(), "sha256": sha256.New(), "sha512": sha512.New()}
+	writers := []io.Writer{file}
+	for _, hasher := range hashes {
+		writers = append(writers, hasher)
+	}
+	size, err := io.Copy(io.MultiWriter(writers...), reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	digests := make(map[string][]byte, len(hashes))
+	for algorithm, hasher := range hashes {
+		digests[algorithm] = hasher.Sum(nil)
+	}
+	if err = verifyIntegrity(lockedPackage.Integrity, digests); err != nil {
+		return nil, errorutils.CheckErrorf("the tarball of %s@%s doesn't match the lockfile: %s", lockedPackage.Name, lockedPackage.Version, err.Error())
+	}
+	return &NpmBundlePackage{
+		Name:      lockedPackage.Name,
+		Version:   lockedPackage.Version,
+		Integrity: lockedPackage.Integrity,
+		Path:      tarballPath,
+		Size:      size,
+		Sha1:      hex.EncodeToString(digests["sha1"]),
+		Sha256:    hex.EncodeToString(digests["sha256"]),
+	}, nil
+}
+
+// verifyIntegrity verifies the digests of a tarball against its subresource integrity, such as "sha512-<base64 digest>".
+// The integrity may include several hashes, separated by spaces. Hashes of unsupported algorithms are ignored.
+func verifyIntegrity(integrity string, digests map[string][]byte) error {
+	for _, integrityHash := range strings.Fields(integrity) {
+		algorithm, expected, found := strings.Cut(integrityHash, "-")
+		digest, supported := digests[algorithm]
+		if !found || !supported {
+			continue
+		}
+		// Options may follow the digest, separated by '?'.
+		expected, _, _ = strings.Cut(expected, "?")
+		if actual := base64.StdEncoding.EncodeToString(digest); actual != expected {
+			return fmt.Errorf("expected %s %s, but got %s", algorithm, expected, actual)
+		}
+	}
+	return nil
+}
+
+func writeBundleFile(bundleDir, fileName string, content []byte) error {
+	if err := os.MkdirAll(bundleDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(bundleDir, fileName), content, 0644))
+}
+
+// writeBundleArchive writes the manifest, the lockfile and the tarballs of the bundle directory to a zip archive.
+func writeBundleArchive(bundleDir, archivePath string, manifest *NpmBundleManifest) (err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	writer := zip.NewWriter(archive)
+	entries := []string{BundleManifestFileName, manifest.Lockfile}
+	for _, bundlePackage := range manifest.Packages {
+		entries = append(entries, bundlePackage.Path)
+	}
+	for _, entry := range entries {
+		if err = addBundleFileToArchive(writer, filepath.Join(bundleDir, filepath.FromSlash(entry)), entry); err != nil {
+			return err
+		}
+	}
+	return errorutils.CheckError(writer.Close())
+}
+
+func addBundleFileToArchive(writer *zip.Writer, localPath, archivePath string) (err error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	entryWriter, err := writer.CreateHeader(&zip.FileHeader{Name: archivePath, Method: zip.Store})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = io.Copy(entryWriter, file)
+	return errorutils.CheckError(err)
+}
+
+// lockfilePackage is a package resolved by the lockfile.
+type lockfilePackage struct {
+	Name      string
+	Version   string
+	Resolved  string
+	Integrity string
+}
+
+// tarballPath returns the path of the tarball of the package in the npm registry, <name>/-/<name without scope>-<version>.tgz.
+func (lp lockfilePackage) tarballPath() string {
+	return lp.Name + "/-/" + path.Base(lp.Name) + "-" + lp.Version + ".tgz"
+}
+
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	// The packages of lockfile versions 2 and 3, by their paths, such as node_modules/a/node_modules/b.
+	Packages map[string]packageLockEntry `json:"packages"`
+	// The dependencies tree of lockfile version 1, by the names of the packages.
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+type packageLockEntry struct {
+	Name         string                      `json:"name"`
+	Version      string                      `json:"version"`
+	Resolved     string                      `json:"resolved"`
+	Integrity    string                      `json:"integrity"`
+	Link         bool                        `json:"link"`
+	InBundle     bool                        `json:"inBundle"`
+	Bundled      bool                        `json:"bundled"`
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+// readLockfilePackages returns the registry packages resolved by the lockfile, without duplicates, sorted by their names and versions.
+// Linked packages, such as workspaces, bundled packages and packages which aren't resolved from a registry, are skipped.
+func readLockfilePackages(content []byte) ([]lockfilePackage, error) {
+	lock := new(packageLock)
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", packageLockFileName, err.Error())
+	}
+	unique := make(map[string]lockfilePackage)
+	add := func(name string, entry packageLockEntry) error {
+		if entry.Link || entry.InBundle || entry.Bundled {
+			return nil
+		}
+		// Aliased packages, such as "npm:lodash@4.17.21", are bundled by their real names.
+		if alias, found := strings.CutPrefix(entry.Version, "npm:"); found {
+			scope, aliasedName, version, ok := parsePackageSpec(alias)
+			if !ok {
+				return errorutils.CheckErrorf("invalid version %s of %s in %s", entry.Version, name, packageLockFileName)
+			}
+			name, entry.Version = aliasedName, version
+			if scope != "" {
+				name = "@" + scope + "/" + aliasedName
+			}
+		}
+		if entry.Version == "" || strings.Contains(entry.Version, ":") || (entry.Resolved != "" && !strings.HasPrefix(entry.Resolved, "http")) {
+			log.Warn(fmt.Sprintf("Skipping %s, which isn't resolved from a registry.", name))
+			return nil
+		}
+		lockedPackage := lockfilePackage{Name: name, Version: entry.Version, Resolved: entry.Resolved, Integrity: entry.Integrity}
+		if !isValidBundlePath(lockedPackage.tarballPath()) {
+			return errorutils.CheckErrorf("invalid package %s@%s in %s", name, entry.Version, packageLockFileName)
+		}
+		// A package may be installed in several paths, of which the ones the lockfile records as resolved are preferred.
+		if existing, found := unique[name+"@"+entry.Version]; !found || existing.Resolved == "" {
+			unique[name+"@"+entry.Version] = lockedPackage
+		}
+		return nil
+	}
+	if lock.LockfileVersion >= 2 {
+		for packagePath, entry := range lock.Packages {
+			// The root project, and the workspaces which aren't installed under node_modules.
+			nodeModulesIndex := strings.LastIndex(packagePath, "node_modules/")
+			if nodeModulesIndex == -1 {
+				continue
+			}
+			name := entry.Name
+			if name == "" {
+				name = packagePath[nodeModulesIndex+len("node_modules/"):]
+			}
+			if err := add(name, entry); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var addDependencies func(dependencies map[string]packageLockEntry) error
+		addDependencies = func(dependencies map[string]packageLockEntry) error {
+			for name, entry := range dependencies {
+				if err := add(name, entry); err != nil {
+					return err
+				}
+				if err := addDependencies(entry.Dependencies); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := addDependencies(lock.Dependencies); err != nil {
+			return nil, err
+		}
+	}
+	packages := make([]lockfilePackage, 0, len(unique))
+	for _, lockedPackage := range unique {
+		packages = append(packages, lockedPackage)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+	return packages, nil
+}
+
+// isValidBundlePath returns false for paths which leave the bundle directory.
+func isValidBundlePath(filePath string) bool {
+	if path.IsAbs(filePath) || strings.Contains(filePath, `\`) {
+		return false
+	}
+	for _, element := range strings.Split(filePath, "/") {
+		if element == ".." || element == "." {
+			return false
+		}
+	}
+	return true
+}
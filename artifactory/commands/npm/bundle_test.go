@@ -0,0 +1,133 @@
+package npm
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLockfilePackages(t *testing.T) {
+	lockfile := `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.21", "resolved": "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/lodash/-/lodash-4.17.21.tgz", "integrity": "sha512-aaa"},
+    "node_modules/@jfrog/pkg": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/@jfrog/pkg/-/pkg-1.0.0.tgz"},
+    "node_modules/@jfrog/pkg/node_modules/lodash": {"version": "3.10.1"},
+    "node_modules/other/node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/underscore": {"name": "lodash", "version": "4.0.0"},
+    "node_modules/workspace-a": {"resolved": "packages/a", "link": true},
+    "packages/a": {"name": "workspace-a", "version": "1.0.0"},
+    "node_modules/local": {"version": "1.0.0", "resolved": "file:../local"},
+    "node_modules/bundled": {"version": "1.0.0", "inBundle": true}
+  }
+}`
+	packages, err := readLockfilePackages([]byte(lockfile))
+	require.NoError(t, err)
+	assert.Equal(t, []lockfilePackage{
+		{Name: "@jfrog/pkg", Version: "1.0.0", Resolved: "https://registry.npmjs.org/@jfrog/pkg/-/pkg-1.0.0.tgz"},
+		{Name: "lodash", Version: "3.10.1"},
+		{Name: "lodash", Version: "4.0.0"},
+		{Name: "lodash", Version: "4.17.21", Resolved: "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/lodash/-/lodash-4.17.21.tgz", Integrity: "sha512-aaa"},
+	}, packages)
+	assert.Equal(t, "@jfrog/pkg/-/pkg-1.0.0.tgz", packages[0].tarballPath())
+
+	lockfile = `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "lodash": {"version": "4.17.21", "integrity": "sha512-aaa", "dependencies": {"@jfrog/pkg": {"version": "1.0.0"}}},
+    "underscore": {"version": "npm:@jfrog/pkg@2.0.0"},
+    "git-dep": {"version": "git+https://github.com/jfrog/git-dep.git#abc"}
+  }
+}`
+	packages, err = readLockfilePackages([]byte(lockfile))
+	require.NoError(t, err)
+	assert.Equal(t, []lockfilePackage{
+		{Name: "@jfrog/pkg", Version: "1.0.0"},
+		{Name: "@jfrog/pkg", Version: "2.0.0"},
+		{Name: "lodash", Version: "4.17.21", Integrity: "sha512-aaa"},
+	}, packages)
+
+	_, err = readLockfilePackages([]byte(`{"lockfileVersion": 3, "packages": {"node_modules/../../etc": {"version": "1.0.0"}}}`))
+	assert.Error(t, err)
+}
+
+func TestVerifyIntegrity(t *testing.T) {
+	content := []byte("tarball")
+	sha512Digest := sha512.Sum512(content)
+	sha1Digest := sha1.Sum(content)
+	digests := map[string][]byte{"sha512": sha512Digest[:], "sha1": sha1Digest[:]}
+	sri := "sha512-" + base64.StdEncoding.EncodeToString(sha512Digest[:])
+
+	assert.NoError(t, verifyIntegrity("", digests))
+	assert.NoError(t, verifyIntegrity(sri, digests))
+	assert.NoError(t, verifyIntegrity(sri+"?foo sha1-"+base64.StdEncoding.EncodeToString(sha1Digest[:])+" md5-unsupported", digests))
+	assert.Error(t, verifyIntegrity("sha512-"+base64.StdEncoding.EncodeToString(sha1Digest[:]), digests))
+}
+
+func TestNpmBundle(t *testing.T) {
+	tarball := []byte("lodash tarball")
+	digest := sha512.Sum512(tarball)
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/npm/npm-virtual/lodash/-/lodash-4.17.21.tgz", "/artifactory/api/npm/npm-virtual/@jfrog/pkg/-/pkg-1.0.0.tgz":
+			_, _ = w.Write(tarball)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	projectDir := t.TempDir()
+	lockfile := `{"lockfileVersion": 3, "packages": {
+    "node_modules/lodash": {"version": "4.17.21", "resolved": "` + server.URL + `/artifactory/api/npm/npm-virtual/lodash/-/lodash-4.17.21.tgz", "integrity": "sha512-` + base64.StdEncoding.EncodeToString(digest[:]) + `"},
+    "node_modules/@jfrog/pkg": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/@jfrog/pkg/-/pkg-1.0.0.tgz"}
+  }}`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, packageLockFileName), []byte(lockfile), 0644))
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+
+	bundleDir := filepath.Join(t.TempDir(), "bundle")
+	bundleCommand := NewNpmBundleCommand().SetServerDetails(serverDetails).SetRepo("npm-virtual").SetWorkingDirectory(projectDir).SetTarget(bundleDir)
+	require.NoError(t, bundleCommand.Run())
+	// The packages which aren't resolved from Artifactory are downloaded through the repository.
+	assert.Contains(t, requested, "/artifactory/api/npm/npm-virtual/@jfrog/pkg/-/pkg-1.0.0.tgz")
+	manifest := bundleCommand.Manifest()
+	require.Len(t, manifest.Packages, 2)
+	assert.Equal(t, "npm-virtual", manifest.Repo)
+	assert.Equal(t, "@jfrog/pkg/-/pkg-1.0.0.tgz", manifest.Packages[0].Path)
+	assert.Equal(t, int64(len(tarball)), manifest.Packages[1].Size)
+	for _, fileName := range []string{BundleManifestFileName, packageLockFileName, "lodash/-/lodash-4.17.21.tgz", "@jfrog/pkg/-/pkg-1.0.0.tgz"} {
+		assert.FileExists(t, filepath.Join(bundleDir, filepath.FromSlash(fileName)))
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, bundleCommand.SetTarget(archivePath).SetArchive(true).Run())
+	archive, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, archive.Close())
+	}()
+	var entries []string
+	for _, file := range archive.File {
+		entries = append(entries, file.Name)
+	}
+	assert.Equal(t, []string{BundleManifestFileName, packageLockFileName, "@jfrog/pkg/-/pkg-1.0.0.tgz", "lodash/-/lodash-4.17.21.tgz"}, entries)
+
+	// A tarball which doesn't match the integrity of the lockfile fails the bundle.
+	tarball = []byte("tampered tarball")
+	assert.ErrorContains(t, bundleCommand.SetTarget(filepath.Join(t.TempDir(), "bundle")).SetArchive(false).Run(), "doesn't match the lockfile")
+}
@@ -0,0 +1,201 @@
+package npm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// The actions taken when blocked dependencies are detected.
+	OnBlockedFail     = "fail"
+	OnBlockedAnnotate = "annotate"
+
+	// The build-info environment property which lists the blocked dependencies, when they are annotated.
+	BlockedDependenciesProp = "buildInfo.npm.blockedDependencies"
+
+	// A property value which matches any value of the property.
+	anyPropValue = "*"
+	// The number of dependencies whose properties are fetched by a single AQL query.
+	dependencyPropsAqlBatchSize = 500
+)
+
+// DependencyPropsPolicy decides which dependencies are blocked, by the properties of their packages in Artifactory.
+// A dependency is blocked if one of its packages has a blocked property, or if none of its packages has all the required properties.
+type DependencyPropsPolicy struct {
+	blockedProps  map[string][]string
+	requiredProps map[string][]string
+	onBlocked     string
+}
+
+type BlockedDependency struct {
+	Id     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// ParseDependencyPropsPolicy creates the policy of the properties, in the format of "key1=value1;key2=value2,value3".
+// The "*" value matches any value of the property. Returns nil if no properties are provided.
+func ParseDependencyPropsPolicy(blockedProps, requiredProps, onBlocked string) (*DependencyPropsPolicy, error) {
+	if blockedProps == "" && requiredProps == "" {
+		if onBlocked != "" {
+			return nil, errorutils.CheckErrorf("the --on-blocked option requires the --blocked-props or --required-props options")
+		}
+		return nil, nil
+	}
+	if onBlocked == "" {
+		onBlocked = OnBlockedFail
+	}
+	if onBlocked != OnBlockedFail && onBlocked != OnBlockedAnnotate {
+		return nil, errorutils.CheckErrorf("the --on-blocked option must be '%s' or '%s', but it is '%s'", OnBlockedFail, OnBlockedAnnotate, onBlocked)
+	}
+	policy := &DependencyPropsPolicy{onBlocked: onBlocked}
+	var err error
+	if policy.blockedProps, err = parsePolicyProps(blockedProps); err != nil {
+		return nil, err
+	}
+	if policy.requiredProps, err = parsePolicyProps(requiredProps); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func parsePolicyProps(props string) (map[string][]string, error) {
+	if props == "" {
+		return nil, nil
+	}
+	parsed, err := servicesUtils.ParseProperties(props)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsed.ToMap(), nil
+}
+
+func (dpp *DependencyPropsPolicy) OnBlocked() string {
+	return dpp.onBlocked
+}
+
+// FindBlockedDependencies returns the dependencies blocked by the policy, sorted by their IDs.
+// The packages of the dependencies are found in Artifactory by their sha1 checksums.
+func (dpp *DependencyPropsPolicy) FindBlockedDependencies(servicesManager artifactory.ArtifactoryServicesManager, dependencies []entities.Dependency) ([]BlockedDependency, error) {
+	idsBySha1 := make(map[string][]string)
+	var blocked []BlockedDependency
+	for _, dependency := range dependencies {
+		if dependency.Sha1 == "" {
+			if len(dpp.requiredProps) > 0 {
+				blocked = append(blocked, BlockedDependency{Id: dependency.Id, Reason: "the checksum of the package is unknown"})
+			}
+			continue
+		}
+		if !slices.Contains(idsBySha1[dependency.Sha1], dependency.Id) {
+			idsBySha1[dependency.Sha1] = append(idsBySha1[dependency.Sha1], dependency.Id)
+		}
+	}
+	checksums := make([]string, 0, len(idsBySha1))
+	for sha1 := range idsBySha1 {
+		checksums = append(checksums, sha1)
+	}
+	sort.Strings(checksums)
+	itemsBySha1 := make(map[string][]servicesUtils.ResultItem, len(checksums))
+	for start := 0; start < len(checksums); start += dependencyPropsAqlBatchSize {
+		end := min(start+dependencyPropsAqlBatchSize, len(checksums))
+		results, err := artifactoryUtils.ExecuteAqlQuery(servicesManager, createDependencyPropsAql(checksums[start:end]))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range results {
+			itemsBySha1[item.Actual_Sha1] = append(itemsBySha1[item.Actual_Sha1], item)
+		}
+	}
+	for _, sha1 := range checksums {
+		if reason := dpp.blockReason(itemsBySha1[sha1]); reason != "" {
+			for _, id := range idsBySha1[sha1] {
+				blocked = append(blocked, BlockedDependency{Id: id, Reason: reason})
+			}
+		}
+	}
+	sort.Slice(blocked, func(i, j int) bool {
+		return blocked[i].Id < blocked[j].Id
+	})
+	return blocked, nil
+}
+
+// blockReason returns the reason the packages of a dependency are blocked, or an empty string if they aren't.
+func (dpp *DependencyPropsPolicy) blockReason(items []servicesUtils.ResultItem) string {
+	if len(items) == 0 {
+		if len(dpp.requiredProps) > 0 {
+			return "the package wasn't found in Artifactory"
+		}
+		return ""
+	}
+	hasRequiredProps := len(dpp.requiredProps) == 0
+	for _, item := range items {
+		props := make(map[string][]string)
+		for _, prop := range item.Properties {
+			props[prop.Key] = append(props[prop.Key], prop.Value)
+		}
+		for key, values := range dpp.blockedProps {
+			if value, found := matchProp(props[key], values); found {
+				return fmt.Sprintf("%s has the blocked property %s=%s", item.GetItemRelativePath(), key, value)
+			}
+		}
+		if !hasRequiredProps {
+			hasRequiredProps = hasAllProps(props, dpp.requiredProps)
+		}
+	}
+	if !hasRequiredProps {
+		return "the package is missing the required properties " + formatPolicyProps(dpp.requiredProps)
+	}
+	return ""
+}
+
+// matchProp returns the value of the property which matches the values of the policy.
+func matchProp(propValues, policyValues []string) (string, bool) {
+	for _, value := range propValues {
+		if slices.Contains(policyValues, anyPropValue) || slices.Contains(policyValues, value) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func hasAllProps(props, required map[string][]string) bool {
+	for key, values := range required {
+		if _, found := matchProp(props[key], values); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func formatPolicyProps(props map[string][]string) string {
+	formatted := make([]string, 0, len(props))
+	for key, values := range props {
+		formatted = append(formatted, key+"="+strings.Join(values, ","))
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, ";")
+}
+
+func createDependencyPropsAql(checksums []string) string {
+	criteria := make([]string, 0, len(checksums))
+	for _, sha1 := range checksums {
+		criteria = append(criteria, fmt.Sprintf(`{"actual_sha1":%q}`, sha1))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include("repo","path","name","actual_sha1","property")`, strings.Join(criteria, ","))
+}
+
+// FormatBlockedDependencies returns the blocked dependencies as a single line, in the format of "id (reason), id (reason)".
+func FormatBlockedDependencies(blocked []BlockedDependency) string {
+	formatted := make([]string, 0, len(blocked))
+	for _, dependency := range blocked {
+		formatted = append(formatted, dependency.Id+" ("+dependency.Reason+")")
+	}
+	return strings.Join(formatted, ", ")
+}
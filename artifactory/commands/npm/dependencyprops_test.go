@@ -0,0 +1,85 @@
+package npm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependencyPropsPolicy(t *testing.T) {
+	policy, err := ParseDependencyPropsPolicy("", "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = ParseDependencyPropsPolicy("curation.status=blocked;license=GPL-3.0,AGPL-3.0", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, OnBlockedFail, policy.OnBlocked())
+	assert.Equal(t, map[string][]string{"curation.status": {"blocked"}, "license": {"GPL-3.0", "AGPL-3.0"}}, policy.blockedProps)
+
+	_, err = ParseDependencyPropsPolicy("", "blessed=true", "ignore")
+	assert.ErrorContains(t, err, "must be 'fail' or 'annotate'")
+	_, err = ParseDependencyPropsPolicy("", "", OnBlockedAnnotate)
+	assert.Error(t, err)
+}
+
+func TestExtractPropsPolicy(t *testing.T) {
+	policy, args, err := extractPropsPolicy([]string{"--blocked-props=blocked=*", "--production", "--on-blocked", "annotate"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--production"}, args)
+	require.NotNil(t, policy)
+	assert.Equal(t, OnBlockedAnnotate, policy.OnBlocked())
+}
+
+func TestFindBlockedDependencies(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/search/aql":
+			query, _ := io.ReadAll(r.Body)
+			queries = append(queries, string(query))
+			_, _ = w.Write([]byte(`{"results": [
+  {"repo": "npm-remote-cache", "path": "lodash/-", "name": "lodash-4.17.21.tgz", "actual_sha1": "sha1-lodash", "properties": [{"key": "blessed", "value": "true"}]},
+  {"repo": "npm-remote-cache", "path": "left-pad/-", "name": "left-pad-1.3.0.tgz", "actual_sha1": "sha1-left-pad", "properties": [{"key": "blessed", "value": "true"}, {"key": "curation.status", "value": "blocked"}]},
+  {"repo": "npm-remote-cache", "path": "chalk/-", "name": "chalk-5.0.0.tgz", "actual_sha1": "sha1-chalk"}
+]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	servicesManager, err := rtUtils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+
+	dependencies := []entities.Dependency{
+		{Id: "lodash:4.17.21", Checksum: entities.Checksum{Sha1: "sha1-lodash"}},
+		{Id: "left-pad:1.3.0", Checksum: entities.Checksum{Sha1: "sha1-left-pad"}},
+		{Id: "chalk:5.0.0", Checksum: entities.Checksum{Sha1: "sha1-chalk"}},
+		{Id: "debug:4.3.4", Checksum: entities.Checksum{Sha1: "sha1-debug"}},
+	}
+	policy, err := ParseDependencyPropsPolicy("curation.status=blocked", "", "")
+	require.NoError(t, err)
+	blocked, err := policy.FindBlockedDependencies(servicesManager, dependencies)
+	require.NoError(t, err)
+	assert.Equal(t, []BlockedDependency{{Id: "left-pad:1.3.0", Reason: "npm-remote-cache/left-pad/-/left-pad-1.3.0.tgz has the blocked property curation.status=blocked"}}, blocked)
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], `{"actual_sha1":"sha1-chalk"}`)
+
+	// The dependencies which aren't found in Artifactory don't have the required properties.
+	policy, err = ParseDependencyPropsPolicy("", "blessed=*", OnBlockedAnnotate)
+	require.NoError(t, err)
+	blocked, err = policy.FindBlockedDependencies(servicesManager, dependencies)
+	require.NoError(t, err)
+	assert.Equal(t, []BlockedDependency{
+		{Id: "chalk:5.0.0", Reason: "the package is missing the required properties blessed=*"},
+		{Id: "debug:4.3.4", Reason: "the package wasn't found in Artifactory"},
+	}, blocked)
+}
@@ -0,0 +1,342 @@
+package npm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DistTagAdd    = "add"
+	DistTagRemove = "rm"
+	DistTagList   = "ls"
+
+	DefaultDistTag = "latest"
+	// The property of the package tarball which records its dist-tag, such as npm.dist-tag.latest=true.
+	distTagPropertyPrefix = "npm.dist-tag."
+)
+
+// NpmDistTagResult is the dist-tags of the package after the command ran.
+type NpmDistTagResult struct {
+	Package  string            `json:"package"`
+	Repo     string            `json:"repo"`
+	DistTags map[string]string `json:"distTags"`
+}
+
+// NpmDistTagCommand manages the dist-tags of an npm package in an Artifactory npm repository, using the npm registry API of the repository.
+// The tags are managed in the default deployment repository of a virtual repository, since the dist-tags of a virtual repository
+// are aggregated from its repositories and may be cached.
+// The tagged tarball gets an npm.dist-tag.<tag> property, and the build properties if a build is provided, so that the change is
+// recorded in Artifactory.
+type NpmDistTagCommand struct {
+	serverDetails      *config.ServerDetails
+	action             string
+	repo               string
+	packageSpec        string
+	tag                string
+	buildConfiguration *buildUtils.BuildConfiguration
+	result             *NpmDistTagResult
+}
+
+func NewNpmDistTagCommand() *NpmDistTagCommand {
+	return &NpmDistTagCommand{}
+}
+
+func (ndc *NpmDistTagCommand) SetServerDetails(serverDetails *config.ServerDetails) *NpmDistTagCommand {
+	ndc.serverDetails = serverDetails
+	return ndc
+}
+
+// SetAction sets the action of the command: add, rm or ls.
+func (ndc *NpmDistTagCommand) SetAction(action string) *NpmDistTagCommand {
+	ndc.action = action
+	return ndc
+}
+
+func (ndc *NpmDistTagCommand) SetRepo(repo string) *NpmDistTagCommand {
+	ndc.repo = repo
+	return ndc
+}
+
+// SetPackageSpec sets the package, in the form of <name>@<version> for the add action, and <name> for the others.
+func (ndc *NpmDistTagCommand) SetPackageSpec(packageSpec string) *NpmDistTagCommand {
+	ndc.packageSpec = packageSpec
+	return ndc
+}
+
+func (ndc *NpmDistTagCommand) SetTag(tag string) *NpmDistTagCommand {
+	ndc.tag = tag
+	return ndc
+}
+
+func (ndc *NpmDistTagCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *NpmDistTagCommand {
+	ndc.buildConfiguration = buildConfiguration
+	return ndc
+}
+
+func (ndc *NpmDistTagCommand) Result() *NpmDistTagResult {
+	return ndc.result
+}
+
+func (ndc *NpmDistTagCommand) CommandName() string {
+	return "rt_npm_dist_tag"
+}
+
+func (ndc *NpmDistTagCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ndc.serverDetails, nil
+}
+
+func (ndc *NpmDistTagCommand) Run() error {
+	packageInfo, err := ndc.validate()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(ndc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repo, err := getDistTagsRepo(servicesManager, ndc.repo)
+	if err != nil {
+		return err
+	}
+	fullName := packageInfo.FullName()
+	distTags, err := getDistTags(servicesManager, repo, fullName)
+	if err != nil {
+		return err
+	}
+	switch ndc.action {
+	case DistTagAdd:
+		previousVersion := distTags[ndc.tag]
+		if previousVersion == packageInfo.Version {
+			log.Info(fmt.Sprintf("The dist-tag '%s' of %s is already %s.", ndc.tag, fullName, packageInfo.Version))
+			break
+		}
+		if err = sendDistTagRequest(servicesManager, http.MethodPut, repo, fullName, ndc.tag, packageInfo.Version); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Set the dist-tag '%s' of %s to %s in '%s'.", ndc.tag, fullName, packageInfo.Version, repo))
+		distTags[ndc.tag] = packageInfo.Version
+		err = ndc.recordDistTag(servicesManager, repo, packageInfo, previousVersion)
+	case DistTagRemove:
+		previousVersion, exists := distTags[ndc.tag]
+		if !exists {
+			return errorutils.CheckErrorf("the package %s has no dist-tag '%s' in '%s'", fullName, ndc.tag, repo)
+		}
+		if err = sendDistTagRequest(servicesManager, http.MethodDelete, repo, fullName, ndc.tag, ""); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Removed the dist-tag '%s' of %s from '%s'.", ndc.tag, fullName, repo))
+		delete(distTags, ndc.tag)
+		err = ndc.recordDistTag(servicesManager, repo, packageInfo, previousVersion)
+	}
+	ndc.result = &NpmDistTagResult{Package: fullName, Repo: repo, DistTags: distTags}
+	return err
+}
+
+// validate validates the arguments of the action, and returns the package they refer to.
+func (ndc *NpmDistTagCommand) validate() (*buildutils.PackageInfo, error) {
+	if ndc.repo == "" {
+		return nil, errorutils.CheckErrorf("the npm repository of the package must be provided")
+	}
+	packageInfo := &buildutils.PackageInfo{Name: ndc.packageSpec}
+	switch ndc.action {
+	case DistTagAdd:
+		scope, name, version, ok := parsePackageSpec(ndc.packageSpec)
+		if !ok {
+			return nil, errorutils.CheckErrorf("the package of the add action must be in the form of <name>@<version>, but got '%s'", ndc.packageSpec)
+		}
+		packageInfo = &buildutils.PackageInfo{Name: name, Version: version}
+		if scope != "" {
+			packageInfo.Scope = "@" + scope
+		}
+		if ndc.tag == "" {
+			ndc.tag = DefaultDistTag
+		}
+	case DistTagRemove:
+		if ndc.tag == "" {
+			return nil, errorutils.CheckErrorf("the dist-tag to remove must be provided")
+		}
+	case DistTagList:
+	default:
+		return nil, errorutils.CheckErrorf("unsupported dist-tag action '%s'. Acceptable values are: %s, %s, %s", ndc.action, DistTagAdd, DistTagRemove, DistTagList)
+	}
+	if ndc.action != DistTagAdd {
+		if ndc.packageSpec == "" || strings.LastIndex(ndc.packageSpec, "@") > 0 {
+			return nil, errorutils.CheckErrorf("the package of the %s action must be its name, without a version, but got '%s'", ndc.action, ndc.packageSpec)
+		}
+		if scope, name, found := strings.Cut(ndc.packageSpec, "/"); found && strings.HasPrefix(scope, "@") {
+			packageInfo = &buildutils.PackageInfo{Name: name, Scope: scope}
+		}
+	}
+	if ndc.tag != "" && !isValidDistTag(ndc.tag) {
+		return nil, errorutils.CheckErrorf("invalid dist-tag '%s'. A dist-tag can't be a version, or include spaces or slashes", ndc.tag)
+	}
+	return packageInfo, nil
+}
+
+// isValidDistTag returns false for tags which could be confused with versions, as npm does, and for tags with spaces or slashes.
+func isValidDistTag(tag string) bool {
+	if strings.ContainsAny(tag, "/ ") {
+		return false
+	}
+	withoutPrefix := strings.TrimPrefix(tag, "v")
+	return withoutPrefix == "" || withoutPrefix[0] < '0' || withoutPrefix[0] > '9'
+}
+
+// recordDistTag moves the dist-tag property from the tarball of the previously tagged version to the tarball of the tagged version,
+// with the build properties if a build is provided. Failing to record the dist-tag doesn't fail the command, since the tag was changed.
+func (ndc *NpmDistTagCommand) recordDistTag(servicesManager artifactory.ArtifactoryServicesManager, repo string, packageInfo *buildutils.PackageInfo, previousVersion string) error {
+	tagProperty := distTagPropertyPrefix + ndc.tag
+	if previousVersion != "" {
+		previous := &buildutils.PackageInfo{Name: packageInfo.Name, Scope: packageInfo.Scope, Version: previousVersion}
+		if err := updateTarballProps(servicesManager.DeleteProps, repo+"/"+previous.GetDeployPath(), tagProperty); err != nil {
+			log.Warn(fmt.Sprintf("Unable to remove the %s property from %s@%s: %s", tagProperty, packageInfo.FullName(), previousVersion, err.Error()))
+		}
+	}
+	var props []string
+	if ndc.action == DistTagAdd {
+		props = append(props, tagProperty+"=true")
+	}
+	if ndc.buildConfiguration != nil {
+		collectBuildInfo, err := ndc.buildConfiguration.IsCollectBuildInfo()
+		if err != nil {
+			return err
+		}
+		if collectBuildInfo {
+			buildProps, err := ndc.getBuildProps()
+			if err != nil {
+				return err
+			}
+			props = append(props, buildProps)
+		}
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	// The build properties of a removed tag are set on the version which the tag pointed to.
+	tagged := packageInfo
+	if ndc.action == DistTagRemove {
+		tagged = &buildutils.PackageInfo{Name: packageInfo.Name, Scope: packageInfo.Scope, Version: previousVersion}
+	}
+	if err := updateTarballProps(servicesManager.SetProps, repo+"/"+tagged.GetDeployPath(), strings.Join(props, ";")); err != nil {
+		log.Warn(fmt.Sprintf("Unable to set the properties of %s@%s: %s", tagged.FullName(), tagged.Version, err.Error()))
+	}
+	return nil
+}
+
+func (ndc *NpmDistTagCommand) getBuildProps() (string, error) {
+	buildName, err := ndc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return "", err
+	}
+	buildNumber, err := ndc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return "", err
+	}
+	return buildUtils.CreateBuildProperties(buildName, buildNumber, ndc.buildConfiguration.GetProject())
+}
+
+// getDistTagsRepo returns the repository whose dist-tags are managed. A virtual repository is resolved to its default deployment repository.
+func getDistTagsRepo(servicesManager artifactory.ArtifactoryServicesManager, repo string) (string, error) {
+	repoDetails := &services.VirtualRepositoryBaseParams{}
+	if err := servicesManager.GetRepository(repo, repoDetails); err != nil {
+		return "", err
+	}
+	if repoDetails.Rclass != services.VirtualRepositoryRepoType {
+		return repo, nil
+	}
+	if repoDetails.DefaultDeploymentRepo == "" {
+		return "", errorutils.CheckErrorf("the virtual repository '%s' has no default deployment repository. Configure it, or provide the local repository of the package", repo)
+	}
+	log.Info(fmt.Sprintf("Managing the dist-tags in '%s', the default deployment repository of the virtual repository '%s'.", repoDetails.DefaultDeploymentRepo, repo))
+	return repoDetails.DefaultDeploymentRepo, nil
+}
+
+func getDistTags(servicesManager artifactory.ArtifactoryServicesManager, repo, packageName string) (map[string]string, error) {
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(distTagsUrl(servicesManager, repo, packageName, ""), true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("the package %s was not found in '%s'", packageName, repo)
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	distTags := make(map[string]string)
+	if err = json.Unmarshal(body, &distTags); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the dist-tags of %s: %s", packageName, err.Error())
+	}
+	return distTags, nil
+}
+
+// sendDistTagRequest sets the dist-tag to the version, or deletes it, as the npm dist-tag command does.
+func sendDistTagRequest(servicesManager artifactory.ArtifactoryServicesManager, method, repo, packageName, tag, version string) error {
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	tagUrl := distTagsUrl(servicesManager, repo, packageName, tag)
+	var resp *http.Response
+	var body []byte
+	var err error
+	if method == http.MethodPut {
+		// The body is the version, as a JSON string.
+		var requestBody []byte
+		if requestBody, err = json.Marshal(version); err != nil {
+			return errorutils.CheckError(err)
+		}
+		httpClientDetails.Headers["Content-Type"] = "application/json"
+		resp, body, err = servicesManager.Client().SendPut(tagUrl, requestBody, &httpClientDetails)
+	} else {
+		resp, body, err = servicesManager.Client().SendDelete(tagUrl, nil, &httpClientDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+}
+
+// distTagsUrl returns the URL of the dist-tags of the package in the npm registry API of the repository. The slash of a scoped
+// package is encoded, as the npm client does.
+func distTagsUrl(servicesManager artifactory.ArtifactoryServicesManager, repo, packageName, tag string) string {
+	tagsUrl := fmt.Sprintf("%sapi/npm/%s/-/package/%s/dist-tags", servicesManager.GetConfig().GetServiceDetails().GetUrl(), repo, strings.Replace(packageName, "/", "%2f", 1))
+	if tag != "" {
+		tagsUrl += "/" + tag
+	}
+	return tagsUrl
+}
+
+func updateTarballProps(update func(services.PropsParams) (int, error), tarballPath, props string) (err error) {
+	tarballPath = strings.TrimSuffix(tarballPath, "/")
+	repo, filePath, _ := strings.Cut(tarballPath, "/")
+	dir, name := "", filePath
+	if i := strings.LastIndex(filePath, "/"); i != -1 {
+		dir, name = filePath[:i], filePath[i+1:]
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	writer.Write(specutils.ResultItem{Repo: repo, Path: dir, Name: name, Type: "file"})
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	_, err = update(services.PropsParams{Reader: reader, Props: props})
+	return err
+}
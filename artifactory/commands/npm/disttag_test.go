@@ -0,0 +1,98 @@
+package npm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidDistTag(t *testing.T) {
+	testCases := []struct {
+		tag   string
+		valid bool
+	}{
+		{"latest", true},
+		{"next", true},
+		{"v", true},
+		{"vnext", true},
+		{"1.0.0", false},
+		{"v2", false},
+		{"release/1", false},
+		{"my tag", false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.valid, isValidDistTag(testCase.tag), "Tag:", testCase.tag)
+	}
+}
+
+func TestNpmDistTagValidate(t *testing.T) {
+	packageInfo, err := NewNpmDistTagCommand().SetAction(DistTagAdd).SetRepo("npm-local").SetPackageSpec("@jfrog/pkg@1.2.3").validate()
+	require.NoError(t, err)
+	assert.Equal(t, "@jfrog/pkg", packageInfo.FullName())
+	assert.Equal(t, "1.2.3", packageInfo.Version)
+
+	distTagCommand := NewNpmDistTagCommand().SetAction(DistTagAdd).SetRepo("npm-local").SetPackageSpec("pkg@1.2.3")
+	_, err = distTagCommand.validate()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDistTag, distTagCommand.tag)
+
+	packageInfo, err = NewNpmDistTagCommand().SetAction(DistTagRemove).SetRepo("npm-local").SetPackageSpec("@jfrog/pkg").SetTag("beta").validate()
+	require.NoError(t, err)
+	assert.Equal(t, "@jfrog", packageInfo.Scope)
+	assert.Equal(t, "pkg", packageInfo.Name)
+
+	for _, distTagCommand = range []*NpmDistTagCommand{
+		NewNpmDistTagCommand().SetAction(DistTagAdd).SetRepo("npm-local").SetPackageSpec("pkg"),
+		NewNpmDistTagCommand().SetAction(DistTagRemove).SetRepo("npm-local").SetPackageSpec("pkg"),
+		NewNpmDistTagCommand().SetAction(DistTagList).SetRepo("npm-local").SetPackageSpec("pkg@1.2.3"),
+		NewNpmDistTagCommand().SetAction(DistTagList).SetPackageSpec("pkg"),
+		NewNpmDistTagCommand().SetAction("set").SetRepo("npm-local").SetPackageSpec("pkg"),
+		NewNpmDistTagCommand().SetAction(DistTagAdd).SetRepo("npm-local").SetPackageSpec("pkg@1.2.3").SetTag("1.2.3"),
+	} {
+		_, err = distTagCommand.validate()
+		assert.Error(t, err, "Action:", distTagCommand.action, "Package:", distTagCommand.packageSpec)
+	}
+}
+
+func TestNpmDistTagAdd(t *testing.T) {
+	var taggedVersion string
+	var propsRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case r.URL.Path == "/artifactory/api/repositories/npm-virtual":
+			_, _ = w.Write([]byte(`{"key":"npm-virtual","rclass":"virtual","defaultDeploymentRepo":"npm-local"}`))
+		case r.URL.EscapedPath() == "/artifactory/api/npm/npm-local/-/package/@jfrog%2fpkg/dist-tags" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"latest":"1.0.0"}`))
+		case r.URL.EscapedPath() == "/artifactory/api/npm/npm-local/-/package/@jfrog%2fpkg/dist-tags/latest" && r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			taggedVersion = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			propsRequests = append(propsRequests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	distTagCommand := NewNpmDistTagCommand().SetAction(DistTagAdd).SetRepo("npm-virtual").SetPackageSpec("@jfrog/pkg@2.0.0").
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+	require.NoError(t, distTagCommand.Run())
+	assert.Equal(t, `"2.0.0"`, taggedVersion)
+	assert.Equal(t, &NpmDistTagResult{Package: "@jfrog/pkg", Repo: "npm-local", DistTags: map[string]string{"latest": "2.0.0"}}, distTagCommand.Result())
+	// The tag property is moved from the previous version to the tagged version.
+	require.Len(t, propsRequests, 2)
+	assert.Contains(t, propsRequests[0], "DELETE /artifactory/api/storage/npm-local/@jfrog/pkg/-/@jfrog/pkg-1.0.0.tgz")
+	assert.Contains(t, propsRequests[0], "npm.dist-tag.latest")
+	assert.Contains(t, propsRequests[1], "PUT /artifactory/api/storage/npm-local/@jfrog/pkg/-/@jfrog/pkg-2.0.0.tgz")
+	assert.Contains(t, propsRequests[1], "npm.dist-tag.latest=true")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/jfrog/build-info-go/build"
 	biUtils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/gofrog/version"
 	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -72,9 +73,14 @@ type NpmCommand struct {
 	configFilePath      string
 	collectBuildInfo    bool
 	buildInfoModule     *build.NpmModule
-	installHandler      *NpmInstallStrategy
+	npmBuild            *build.Build
+	// The workspaces of the project, whose dependencies are collected to their own build-info modules.
+	workspaces     []*npmWorkspace
+	installHandler *NpmInstallStrategy
 	// When true, skips the 404 error handling that checks if packages are blocked by curation
 	disableCVSCheck bool
+	// The policy of the Artifactory properties of the installed dependencies, checked after the dependencies are collected.
+	propsPolicy *DependencyPropsPolicy
 }
 
 func NewNpmCommand(cmdName string, collectBuildInfo bool) *NpmCommand {
@@ -128,6 +134,11 @@ func (nc *NpmCommand) SetDisableCVSCheck(disable bool) *NpmCommand {
 	return nc
 }
 
+func (nc *NpmCommand) SetPropsPolicy(propsPolicy *DependencyPropsPolicy) *NpmCommand {
+	nc.propsPolicy = propsPolicy
+	return nc
+}
+
 func (nc *NpmCommand) Init() error {
 	// Read config file.
 	log.Debug("Preparing to read the config file", nc.configFilePath)
@@ -149,11 +160,28 @@ func (nc *NpmCommand) Init() error {
 	if err != nil {
 		return err
 	}
+	propsPolicy, filteredNpmArgs, err := extractPropsPolicy(filteredNpmArgs)
+	if err != nil {
+		return err
+	}
 	nc.SetRepoConfig(repoConfig).SetArgs(filteredNpmArgs).SetBuildConfiguration(buildConfiguration)
-	nc.SetDisableCVSCheck(disableCVSCheck)
+	nc.SetDisableCVSCheck(disableCVSCheck).SetPropsPolicy(propsPolicy)
 	return nil
 }
 
+// extractPropsPolicy removes the --blocked-props, --required-props and --on-blocked flags from the npm args, and returns their policy.
+func extractPropsPolicy(args []string) (propsPolicy *DependencyPropsPolicy, filteredArgs []string, err error) {
+	filteredArgs = args
+	flagValues := make(map[string]string, 3)
+	for _, flagName := range []string{"blocked-props", "required-props", "on-blocked"} {
+		if filteredArgs, flagValues[flagName], err = coreutils.ExtractStringOptionFromArgs(filteredArgs, flagName); err != nil {
+			return
+		}
+	}
+	propsPolicy, err = ParseDependencyPropsPolicy(flagValues["blocked-props"], flagValues["required-props"], flagValues["on-blocked"])
+	return
+}
+
 // Get the repository configuration from the config file.
 // Use the resolver prefix for all commands except for 'dist-tag' which use the deployer prefix.
 func (nc *NpmCommand) getRepoConfig(vConfig *viper.Viper) (repoConfig *project.RepositoryConfig, err error) {
@@ -485,15 +513,21 @@ func (nc *NpmCommand) prepareBuildInfoModule() error {
 		return err
 	}
 	buildInfoService := buildUtils.CreateBuildInfoService()
-	npmBuild, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, nc.buildConfiguration.GetProject())
+	nc.npmBuild, err = buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, nc.buildConfiguration.GetProject())
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	nc.buildInfoModule, err = npmBuild.AddNpmModule(nc.workingDirectory)
+	nc.buildInfoModule, err = nc.npmBuild.AddNpmModule(nc.workingDirectory)
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	nc.buildInfoModule.SetCollectBuildInfo(nc.collectBuildInfo)
+	if nc.collectBuildInfo {
+		if nc.workspaces, err = readWorkspaces(nc.workingDirectory, nc.npmVersion); err != nil {
+			return err
+		}
+	}
+	// The dependencies of the workspaces, and the dependencies checked by the props policy, are calculated by calculateModules after the command runs.
+	nc.buildInfoModule.SetCollectBuildInfo(nc.collectBuildInfo && len(nc.workspaces) == 0 && nc.propsPolicy == nil)
 	if nc.buildConfiguration.GetModule() != "" {
 		nc.buildInfoModule.SetName(nc.buildConfiguration.GetModule())
 	}
@@ -502,7 +536,80 @@ func (nc *NpmCommand) prepareBuildInfoModule() error {
 
 func (nc *NpmCommand) collectDependencies() error {
 	nc.buildInfoModule.SetNpmArgs(append([]string{nc.cmdName}, nc.npmArgs...))
-	return errorutils.CheckError(nc.buildInfoModule.Build())
+	if err := nc.buildInfoModule.Build(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if len(nc.workspaces) == 0 && nc.propsPolicy == nil {
+		return nil
+	}
+	dependencies, modules, err := nc.calculateModules()
+	if err != nil {
+		return err
+	}
+	if nc.propsPolicy != nil {
+		if err = nc.checkDependencyProps(dependencies); err != nil {
+			return err
+		}
+	}
+	if !nc.collectBuildInfo {
+		return nil
+	}
+	return errorutils.CheckError(nc.npmBuild.SaveBuildInfo(&entities.BuildInfo{Modules: modules}))
+}
+
+// calculateModules returns the dependencies of the project, and its build-info modules: the project's root and each of its workspaces.
+// The dependencies are calculated once for the whole project, since npm installs the dependencies of all the workspaces at the root.
+func (nc *NpmCommand) calculateModules() ([]entities.Dependency, []entities.Module, error) {
+	rootModuleId := nc.buildConfiguration.GetModule()
+	if rootModuleId == "" {
+		packageInfo, err := biUtils.ReadPackageInfoFromPackageJsonIfExists(nc.workingDirectory, nc.npmVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		rootModuleId = packageInfo.BuildInfoModuleId()
+	}
+	var npmFlags []string
+	for _, arg := range nc.npmArgs {
+		if strings.HasPrefix(arg, "-") {
+			npmFlags = append(npmFlags, arg)
+		}
+	}
+	dependencies, err := biUtils.CalculateNpmDependenciesList(nc.executablePath, nc.workingDirectory, rootModuleId, biUtils.NpmTreeDepListParam{Args: npmFlags}, true, log.Logger)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	modules := splitWorkspacesDependencies(rootModuleId, dependencies, nc.workspaces)
+	for _, module := range modules[1:] {
+		log.Debug(fmt.Sprintf("Collected %d dependencies of the %s workspace module.", len(module.Dependencies), module.Id))
+	}
+	return dependencies, modules, nil
+}
+
+// checkDependencyProps checks the Artifactory properties of the installed dependencies against the props policy.
+// Blocked dependencies fail the command, or are annotated in the build-info.
+func (nc *NpmCommand) checkDependencyProps(dependencies []entities.Dependency) error {
+	servicesManager, err := rtUtils.CreateServiceManager(nc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Checking the Artifactory properties of %d dependencies...", len(dependencies)))
+	blocked, err := nc.propsPolicy.FindBlockedDependencies(servicesManager, dependencies)
+	if err != nil || len(blocked) == 0 {
+		return err
+	}
+	message := fmt.Sprintf("Found %d blocked dependencies: %s", len(blocked), FormatBlockedDependencies(blocked))
+	if nc.propsPolicy.OnBlocked() == OnBlockedFail {
+		return errorutils.CheckErrorf("%s", message)
+	}
+	log.Warn(message)
+	if !nc.collectBuildInfo {
+		return nil
+	}
+	blockedIds := make([]string, 0, len(blocked))
+	for _, dependency := range blocked {
+		blockedIds = append(blockedIds, dependency.Id)
+	}
+	return errorutils.CheckError(nc.npmBuild.SavePartialBuildInfo(&entities.Partial{Env: entities.Env{BlockedDependenciesProp: strings.Join(blockedIds, ",")}}))
 }
 
 // Gets a config with value which is an array
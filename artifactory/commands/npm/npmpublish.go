@@ -51,8 +51,8 @@ func (npu *npmPublish) upload() (err error) {
 	return
 }
 
-func (npu *npmPublish) getBuildArtifacts() []buildinfo.Artifact {
-	return ConvertArtifactsDetailsToBuildInfoArtifacts(npu.artifactsDetailsReader, utils.ConvertArtifactsSearchDetailsToBuildInfoArtifacts)
+func (npu *npmPublish) getBuildArtifacts(artifactsDetailsReader []*content.ContentReader) []buildinfo.Artifact {
+	return ConvertArtifactsDetailsToBuildInfoArtifacts(artifactsDetailsReader, utils.ConvertArtifactsSearchDetailsToBuildInfoArtifacts)
 }
 
 func (npu *npmPublish) publishPackage(executablePath, filePath string, serverDetails *config.ServerDetails, target string) error {
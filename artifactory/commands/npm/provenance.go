@@ -0,0 +1,345 @@
+package npm
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	inTotoStatementType         = "https://in-toto.io/Statement/v1"
+	slsaProvenancePredicateType = "https://slsa.dev/provenance/v1"
+	// The build types of the npm CLI provenance, for the builds of GitHub Actions workflows and of GitLab CI jobs.
+	githubWorkflowBuildType = "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1"
+	gitlabJobBuildType      = "https://github.com/npm/cli/gitlab/v0alpha1"
+	// The provenance statement is deployed next to the package, with this suffix.
+	provenanceFileSuffix = ".intoto.jsonl"
+)
+
+type inTotoStatement struct {
+	Type          string              `json:"_type"`
+	Subject       []provenanceSubject `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     slsaProvenance      `json:"predicate"`
+}
+
+type provenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type slsaProvenance struct {
+	BuildDefinition slsaBuildDefinition `json:"buildDefinition"`
+	RunDetails      slsaRunDetails      `json:"runDetails"`
+}
+
+type slsaBuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   map[string]any           `json:"externalParameters"`
+	InternalParameters   map[string]any           `json:"internalParameters,omitempty"`
+	ResolvedDependencies []slsaResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type slsaResourceDescriptor struct {
+	Uri    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type slsaRunDetails struct {
+	Builder  slsaBuilder       `json:"builder"`
+	Metadata slsaBuildMetadata `json:"metadata"`
+}
+
+type slsaBuilder struct {
+	Id string `json:"id"`
+}
+
+type slsaBuildMetadata struct {
+	InvocationId string `json:"invocationId"`
+}
+
+// SetProvenance sets whether an SLSA provenance statement is generated for each published package, as npm publish --provenance does.
+// The provenance can only be generated in GitHub Actions workflows and in GitLab CI jobs, which identify the source and the build of the package.
+func (npc *NpmPublishCommand) SetProvenance(provenance bool) *NpmPublishCommand {
+	npc.provenance = provenance
+	return npc
+}
+
+// SetProvenanceKey sets the private key which signs the provenance, when it is also attached to the package as evidence.
+// Without a key, the provenance is only deployed next to the package.
+func (npc *NpmPublishCommand) SetProvenanceKey(keyPath, keyAlias string) *NpmPublishCommand {
+	npc.provenanceKeyPath = keyPath
+	npc.provenanceKeyAlias = keyAlias
+	return npc
+}
+
+// publishProvenance deploys the provenance statement of each published package next to it, as <package>.tgz.intoto.jsonl,
+// and attaches it to the package as evidence if a key is set.
+func (npc *NpmPublishCommand) publishProvenance() error {
+	repo, serverDetails, err := npc.getProvenanceTarget()
+	if err != nil {
+		return err
+	}
+	for _, packedFilePath := range npc.packedFilePaths {
+		if err = npc.readPackageInfoFromTarball(packedFilePath); err != nil {
+			return err
+		}
+		statement, err := createProvenanceStatement(npc.packageInfo, packedFilePath)
+		if err != nil {
+			return err
+		}
+		target := repo + "/" + npc.packageInfo.GetDeployPath()
+		if err = deployProvenance(serverDetails, statement, target+provenanceFileSuffix); err != nil {
+			return err
+		}
+		if npc.provenanceKeyPath != "" {
+			if err = attachProvenanceEvidence(serverDetails, statement, target, packedFilePath, npc.provenanceKeyPath, npc.provenanceKeyAlias); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getProvenanceTarget returns the repository and the server to which the packages were published.
+// With the native npm client, they're resolved from the registry of the npm configuration, like the published packages.
+func (npc *NpmPublishCommand) getProvenanceTarget() (repo string, serverDetails *config.ServerDetails, err error) {
+	if !npc.UseNative() {
+		return npc.repo, npc.serverDetails, nil
+	}
+	if err = npc.readPackageInfoFromTarball(npc.packedFilePaths[0]); err != nil {
+		return
+	}
+	repoConfig, err := npc.getRepoConfig()
+	if err != nil {
+		return
+	}
+	if repo, err = extractRepoName(repoConfig); err != nil {
+		return
+	}
+	serverDetails, err = extractConfigServer(repoConfig)
+	return
+}
+
+// createProvenanceStatement returns the in-toto statement of the SLSA provenance of the package, in the format of the npm CLI provenance.
+func createProvenanceStatement(packageInfo *biutils.PackageInfo, packedFilePath string) (*inTotoStatement, error) {
+	buildDefinition, runDetails, err := getCiBuildDetails()
+	if err != nil {
+		return nil, err
+	}
+	sha512Digest, err := fileDigest(packedFilePath, sha512.New())
+	if err != nil {
+		return nil, err
+	}
+	return &inTotoStatement{
+		Type:          inTotoStatementType,
+		Subject:       []provenanceSubject{{Name: packagePurl(packageInfo), Digest: map[string]string{"sha512": sha512Digest}}},
+		PredicateType: slsaProvenancePredicateType,
+		Predicate:     slsaProvenance{BuildDefinition: buildDefinition, RunDetails: runDetails},
+	}, nil
+}
+
+// packagePurl returns the package URL of the npm package, such as pkg:npm/%40acme/widgets@1.0.0.
+func packagePurl(packageInfo *biutils.PackageInfo) string {
+	name := url.PathEscape(packageInfo.Name)
+	if packageInfo.Scope != "" {
+		// The "@" of the scope is percent-encoded, as in the purls of the npm provenance.
+		name = "%40" + url.PathEscape(strings.TrimPrefix(packageInfo.Scope, "@")) + "/" + name
+	}
+	return "pkg:npm/" + name + "@" + url.PathEscape(packageInfo.Version)
+}
+
+// getCiBuildDetails returns the build definition and the run details of the CI job which publishes the package.
+func getCiBuildDetails() (slsaBuildDefinition, slsaRunDetails, error) {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return getGithubBuildDetails()
+	case os.Getenv("GITLAB_CI") == "true":
+		return getGitlabBuildDetails()
+	}
+	return slsaBuildDefinition{}, slsaRunDetails{}, errorutils.CheckErrorf("the npm provenance can only be generated in GitHub Actions workflows and in GitLab CI jobs")
+}
+
+func getGithubBuildDetails() (slsaBuildDefinition, slsaRunDetails, error) {
+	serverUrl, repository := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY")
+	// The workflow ref is in the form of <owner>/<repo>/<workflow path>@<ref>.
+	workflowPath, workflowRef, found := strings.Cut(strings.TrimPrefix(os.Getenv("GITHUB_WORKFLOW_REF"), repository+"/"), "@")
+	if serverUrl == "" || repository == "" || !found {
+		return slsaBuildDefinition{}, slsaRunDetails{}, errorutils.CheckErrorf("the GitHub Actions environment is missing the GITHUB_SERVER_URL, GITHUB_REPOSITORY or GITHUB_WORKFLOW_REF variables")
+	}
+	repositoryUrl := serverUrl + "/" + repository
+	buildDefinition := slsaBuildDefinition{
+		BuildType: githubWorkflowBuildType,
+		ExternalParameters: map[string]any{
+			"workflow": map[string]string{"ref": workflowRef, "repository": repositoryUrl, "path": workflowPath},
+		},
+		InternalParameters: map[string]any{
+			"github": map[string]string{
+				"event_name":          os.Getenv("GITHUB_EVENT_NAME"),
+				"repository_id":       os.Getenv("GITHUB_REPOSITORY_ID"),
+				"repository_owner_id": os.Getenv("GITHUB_REPOSITORY_OWNER_ID"),
+			},
+		},
+		ResolvedDependencies: []slsaResourceDescriptor{{
+			Uri:    "git+" + repositoryUrl + "@" + os.Getenv("GITHUB_REF"),
+			Digest: map[string]string{"gitCommit": os.Getenv("GITHUB_SHA")},
+		}},
+	}
+	runDetails := slsaRunDetails{
+		Builder: slsaBuilder{Id: "https://github.com/actions/runner/" + os.Getenv("RUNNER_ENVIRONMENT")},
+		Metadata: slsaBuildMetadata{
+			InvocationId: fmt.Sprintf("%s/actions/runs/%s/attempts/%s", repositoryUrl, os.Getenv("GITHUB_RUN_ID"), os.Getenv("GITHUB_RUN_ATTEMPT")),
+		},
+	}
+	return buildDefinition, runDetails, nil
+}
+
+func getGitlabBuildDetails() (slsaBuildDefinition, slsaRunDetails, error) {
+	projectUrl := os.Getenv("CI_PROJECT_URL")
+	if projectUrl == "" {
+		return slsaBuildDefinition{}, slsaRunDetails{}, errorutils.CheckErrorf("the GitLab CI environment is missing the CI_PROJECT_URL variable")
+	}
+	buildDefinition := slsaBuildDefinition{
+		BuildType: gitlabJobBuildType,
+		ExternalParameters: map[string]any{
+			"CI":                   os.Getenv("CI"),
+			"CI_CONFIG_PATH":       os.Getenv("CI_CONFIG_PATH"),
+			"CI_JOB_NAME":          os.Getenv("CI_JOB_NAME"),
+			"CI_JOB_STAGE":         os.Getenv("CI_JOB_STAGE"),
+			"CI_PIPELINE_SOURCE":   os.Getenv("CI_PIPELINE_SOURCE"),
+			"CI_PROJECT_PATH":      os.Getenv("CI_PROJECT_PATH"),
+			"CI_COMMIT_REF_NAME":   os.Getenv("CI_COMMIT_REF_NAME"),
+			"CI_COMMIT_SHA":        os.Getenv("CI_COMMIT_SHA"),
+			"GITLAB_USER_ID":       os.Getenv("GITLAB_USER_ID"),
+			"CI_SERVER_URL":        os.Getenv("CI_SERVER_URL"),
+			"CI_RUNNER_ID":         os.Getenv("CI_RUNNER_ID"),
+			"CI_RUNNER_REVISION":   os.Getenv("CI_RUNNER_REVISION"),
+			"CI_PIPELINE_ID":       os.Getenv("CI_PIPELINE_ID"),
+			"CI_PROJECT_ID":        os.Getenv("CI_PROJECT_ID"),
+			"CI_PROJECT_URL":       projectUrl,
+			"CI_JOB_ID":            os.Getenv("CI_JOB_ID"),
+			"CI_JOB_URL":           os.Getenv("CI_JOB_URL"),
+			"CI_PIPELINE_URL":      os.Getenv("CI_PIPELINE_URL"),
+			"CI_DEFAULT_BRANCH":    os.Getenv("CI_DEFAULT_BRANCH"),
+			"CI_COMMIT_BRANCH":     os.Getenv("CI_COMMIT_BRANCH"),
+			"CI_COMMIT_TAG":        os.Getenv("CI_COMMIT_TAG"),
+			"CI_PROJECT_NAMESPACE": os.Getenv("CI_PROJECT_NAMESPACE"),
+		},
+		ResolvedDependencies: []slsaResourceDescriptor{{
+			Uri:    "git+" + projectUrl + "@" + os.Getenv("CI_COMMIT_REF_NAME"),
+			Digest: map[string]string{"gitCommit": os.Getenv("CI_COMMIT_SHA")},
+		}},
+	}
+	runDetails := slsaRunDetails{
+		Builder:  slsaBuilder{Id: fmt.Sprintf("%s/-/runners/%s", projectUrl, os.Getenv("CI_RUNNER_ID"))},
+		Metadata: slsaBuildMetadata{InvocationId: os.Getenv("CI_JOB_URL")},
+	}
+	return buildDefinition, runDetails, nil
+}
+
+func fileDigest(filePath string, digest hash.Hash) (result string, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	if _, err = io.Copy(digest, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// writeProvenanceFile writes the JSON of the value to a file named fileName in a new temp directory.
+func writeProvenanceFile(value any, fileName string) (filePath string, cleanup func() error, err error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() error { return fileutils.RemoveTempDir(tempDir) }
+	filePath = filepath.Join(tempDir, fileName)
+	if err = os.WriteFile(filePath, append(data, '\n'), 0600); err != nil {
+		return "", nil, errors.Join(errorutils.CheckError(err), cleanup())
+	}
+	return filePath, cleanup, nil
+}
+
+func deployProvenance(serverDetails *config.ServerDetails, statement *inTotoStatement, target string) (err error) {
+	statementPath, cleanup, err := writeProvenanceFile(statement, filepath.Base(target))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, cleanup())
+	}()
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &specutils.CommonParams{Pattern: statementPath, Target: target}
+	uploadParams.Flat = true
+	_, totalFailed, err := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, uploadParams)
+	if err != nil {
+		return err
+	}
+	if totalFailed > 0 {
+		return errorutils.CheckErrorf("failed to deploy the provenance of the npm package to %s", target)
+	}
+	log.Info("Deployed the provenance of the npm package to:", target)
+	return nil
+}
+
+// attachProvenanceEvidence attaches the provenance predicate to the published package as signed evidence.
+func attachProvenanceEvidence(serverDetails *config.ServerDetails, statement *inTotoStatement, subjectRepoPath, packedFilePath, keyPath, keyAlias string) (err error) {
+	predicatePath, cleanup, err := writeProvenanceFile(statement.Predicate, "predicate.json")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, cleanup())
+	}()
+	sha256Digest, err := fileDigest(packedFilePath, sha256.New())
+	if err != nil {
+		return err
+	}
+	// The evidence service URLs are set on a copy, to keep the server details of the command as they are.
+	evidenceServerDetails := *serverDetails
+	if err = common.CreateEvidence(&evidenceServerDetails, common.CreateEvidenceOpts{
+		SubjectRepoPath: subjectRepoPath,
+		SubjectSHA256:   sha256Digest,
+		PredicatePath:   predicatePath,
+		PredicateType:   slsaProvenancePredicateType,
+		KeyPath:         keyPath,
+		KeyAlias:        keyAlias,
+	}); err != nil {
+		return err
+	}
+	log.Info("Attached the provenance to the npm package as evidence:", subjectRepoPath)
+	return nil
+}
@@ -0,0 +1,113 @@
+package npm
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setGithubActionsEnv(t *testing.T) {
+	t.Setenv("GITLAB_CI", "")
+	for key, value := range map[string]string{
+		"GITHUB_ACTIONS":             "true",
+		"GITHUB_SERVER_URL":          "https://github.com",
+		"GITHUB_REPOSITORY":          "acme/widgets",
+		"GITHUB_WORKFLOW_REF":        "acme/widgets/.github/workflows/release.yml@refs/heads/main",
+		"GITHUB_REF":                 "refs/heads/main",
+		"GITHUB_SHA":                 "5f4d2e1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e",
+		"GITHUB_EVENT_NAME":          "push",
+		"GITHUB_REPOSITORY_ID":       "123",
+		"GITHUB_REPOSITORY_OWNER_ID": "456",
+		"GITHUB_RUN_ID":              "789",
+		"GITHUB_RUN_ATTEMPT":         "1",
+		"RUNNER_ENVIRONMENT":         "github-hosted",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+func TestPackagePurl(t *testing.T) {
+	assert.Equal(t, "pkg:npm/widgets@1.0.0", packagePurl(&biutils.PackageInfo{Name: "widgets", Version: "1.0.0"}))
+	assert.Equal(t, "pkg:npm/%40acme/widgets@1.0.0-beta.1", packagePurl(&biutils.PackageInfo{Name: "widgets", Version: "1.0.0-beta.1", Scope: "@acme"}))
+}
+
+func TestCreateProvenanceStatement(t *testing.T) {
+	setGithubActionsEnv(t)
+	packedFilePath := filepath.Join("..", "testdata", "npm", "npm-example-0.0.3.tgz")
+	expectedDigest, err := fileDigest(packedFilePath, sha512.New())
+	require.NoError(t, err)
+
+	statement, err := createProvenanceStatement(&biutils.PackageInfo{Name: "npm-example", Version: "0.0.3"}, packedFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, inTotoStatementType, statement.Type)
+	assert.Equal(t, slsaProvenancePredicateType, statement.PredicateType)
+	assert.Equal(t, []provenanceSubject{{Name: "pkg:npm/npm-example@0.0.3", Digest: map[string]string{"sha512": expectedDigest}}}, statement.Subject)
+
+	buildDefinition := statement.Predicate.BuildDefinition
+	assert.Equal(t, githubWorkflowBuildType, buildDefinition.BuildType)
+	assert.Equal(t, map[string]string{"ref": "refs/heads/main", "repository": "https://github.com/acme/widgets", "path": ".github/workflows/release.yml"},
+		buildDefinition.ExternalParameters["workflow"])
+	assert.Equal(t, []slsaResourceDescriptor{{
+		Uri:    "git+https://github.com/acme/widgets@refs/heads/main",
+		Digest: map[string]string{"gitCommit": "5f4d2e1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e"},
+	}}, buildDefinition.ResolvedDependencies)
+	assert.Equal(t, "https://github.com/actions/runner/github-hosted", statement.Predicate.RunDetails.Builder.Id)
+	assert.Equal(t, "https://github.com/acme/widgets/actions/runs/789/attempts/1", statement.Predicate.RunDetails.Metadata.InvocationId)
+}
+
+func TestGetCiBuildDetails(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "")
+	_, _, err := getCiBuildDetails()
+	assert.ErrorContains(t, err, "can only be generated in GitHub Actions workflows and in GitLab CI jobs")
+
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_PROJECT_URL", "https://gitlab.com/acme/widgets")
+	t.Setenv("CI_COMMIT_REF_NAME", "main")
+	t.Setenv("CI_COMMIT_SHA", "5f4d2e1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e")
+	t.Setenv("CI_RUNNER_ID", "42")
+	t.Setenv("CI_JOB_URL", "https://gitlab.com/acme/widgets/-/jobs/1001")
+	buildDefinition, runDetails, err := getCiBuildDetails()
+	require.NoError(t, err)
+	assert.Equal(t, gitlabJobBuildType, buildDefinition.BuildType)
+	assert.Equal(t, "git+https://gitlab.com/acme/widgets@main", buildDefinition.ResolvedDependencies[0].Uri)
+	assert.Equal(t, "https://gitlab.com/acme/widgets/-/runners/42", runDetails.Builder.Id)
+	assert.Equal(t, "https://gitlab.com/acme/widgets/-/jobs/1001", runDetails.Metadata.InvocationId)
+}
+
+func TestDeployProvenance(t *testing.T) {
+	setGithubActionsEnv(t)
+	var deployedPath string
+	var deployed []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		deployedPath = r.URL.Path
+		var err error
+		deployed, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	packedFilePath := filepath.Join("..", "testdata", "npm", "npm-example-0.0.3.tgz")
+	statement, err := createProvenanceStatement(&biutils.PackageInfo{Name: "npm-example", Version: "0.0.3"}, packedFilePath)
+	require.NoError(t, err)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+	require.NoError(t, deployProvenance(serverDetails, statement, "npm-local/npm-example/-/npm-example-0.0.3.tgz"+provenanceFileSuffix))
+	assert.Equal(t, "/artifactory/npm-local/npm-example/-/npm-example-0.0.3.tgz.intoto.jsonl", deployedPath)
+	expected, err := json.Marshal(statement)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(deployed))
+}
@@ -48,6 +48,11 @@ type NpmPublishCommandArgs struct {
 	xrayScan               bool
 	scanOutputFormat       format.OutputFormat
 	distTag                string
+	// The workspaces published by the --workspaces flag, in their publishing order.
+	workspaces         []*npmWorkspace
+	provenance         bool
+	provenanceKeyPath  string
+	provenanceKeyAlias string
 }
 
 type NpmPublishCommand struct {
@@ -138,6 +143,18 @@ func (npc *NpmPublishCommand) Init() error {
 	if err != nil {
 		return err
 	}
+	filteredNpmArgs, provenance, err := coreutils.ExtractBoolFlagFromArgs(filteredNpmArgs, "provenance")
+	if err != nil {
+		return err
+	}
+	filteredNpmArgs, provenanceKeyPath, err := coreutils.ExtractStringOptionFromArgs(filteredNpmArgs, "provenance-key")
+	if err != nil {
+		return err
+	}
+	filteredNpmArgs, provenanceKeyAlias, err := coreutils.ExtractStringOptionFromArgs(filteredNpmArgs, "provenance-key-alias")
+	if err != nil {
+		return err
+	}
 	if npc.configFilePath != "" {
 		// Read config file.
 		log.Debug("Preparing to read the config file", npc.configFilePath)
@@ -155,7 +172,8 @@ func (npc *NpmPublishCommand) Init() error {
 		}
 		npc.SetBuildConfiguration(buildConfiguration).SetRepo(deployerParams.TargetRepo()).SetNpmArgs(filteredNpmArgs).SetServerDetails(rtDetails)
 	}
-	npc.SetDetailedSummary(detailedSummary).SetXrayScan(xrayScan).SetScanOutputFormat(scanOutputFormat).SetDistTag(tag).SetUseNative(useNative)
+	npc.SetDetailedSummary(detailedSummary).SetXrayScan(xrayScan).SetScanOutputFormat(scanOutputFormat).SetDistTag(tag).
+		SetProvenance(provenance).SetProvenanceKey(provenanceKeyPath, provenanceKeyAlias).SetUseNative(useNative)
 	return nil
 }
 
@@ -202,6 +220,15 @@ func (npc *NpmPublishCommand) Run() (err error) {
 		return errors.Join(err, deleteCreatedTarball(npc.packedFilePaths))
 	}
 
+	if npc.provenance {
+		if err = npc.publishProvenance(); err != nil {
+			if npc.tarballProvided {
+				return err
+			}
+			return errors.Join(err, deleteCreatedTarball(npc.packedFilePaths))
+		}
+	}
+
 	if !npc.tarballProvided {
 		if err = deleteCreatedTarball(npc.packedFilePaths); err != nil {
 			return err
@@ -213,6 +240,23 @@ func (npc *NpmPublishCommand) Run() (err error) {
 		return nil
 	}
 
+	if len(npc.workspaces) > 0 {
+		err = npc.addWorkspacesArtifacts(npmBuild, publishStrategy)
+	} else {
+		err = npc.addArtifacts(npmBuild, publishStrategy)
+	}
+	for _, artifactReader := range npc.artifactsDetailsReader {
+		gofrogcmd.Close(artifactReader, &err)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("npm publish finished successfully.")
+	return nil
+}
+
+func (npc *NpmPublishCommand) addArtifacts(npmBuild *build.Build, publishStrategy *NpmPublishStrategy) error {
 	npmModule, err := npmBuild.AddNpmModule("")
 	if err != nil {
 		return errorutils.CheckError(err)
@@ -220,17 +264,24 @@ func (npc *NpmPublishCommand) Run() (err error) {
 	if npc.buildConfiguration.GetModule() != "" {
 		npmModule.SetName(npc.buildConfiguration.GetModule())
 	}
+	return errorutils.CheckError(npmModule.AddArtifacts(publishStrategy.GetBuildArtifacts(npc.artifactsDetailsReader...)...))
+}
 
-	buildArtifacts := publishStrategy.GetBuildArtifacts()
-	for _, artifactReader := range npc.artifactsDetailsReader {
-		gofrogcmd.Close(artifactReader, &err)
+// addWorkspacesArtifacts adds the package of each published workspace to the build-info module of the workspace.
+// The packages are published in the order of the workspaces, so the details of each upload belong to the workspace in the same position.
+func (npc *NpmPublishCommand) addWorkspacesArtifacts(npmBuild *build.Build, publishStrategy *NpmPublishStrategy) error {
+	if len(npc.artifactsDetailsReader) != len(npc.workspaces) {
+		return errorutils.CheckErrorf("expected the details of %d published workspaces, but got %d", len(npc.workspaces), len(npc.artifactsDetailsReader))
 	}
-	err = npmModule.AddArtifacts(buildArtifacts...)
-	if err != nil {
-		return errorutils.CheckError(err)
+	for i, workspace := range npc.workspaces {
+		npmModule, err := npmBuild.AddNpmModule(workspace.path)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = npmModule.AddArtifacts(publishStrategy.GetBuildArtifacts(npc.artifactsDetailsReader[i])...); err != nil {
+			return errorutils.CheckError(err)
+		}
 	}
-
-	log.Info("npm publish finished successfully.")
 	return nil
 }
 
@@ -256,9 +307,16 @@ func (npc *NpmPublishCommand) preparePrerequisites() error {
 	if err != nil {
 		return err
 	}
+	var allWorkspaces bool
+	npc.npmArgs, allWorkspaces = extractAllWorkspacesFlag(npc.npmArgs)
 	if err = npc.setPublishPath(); err != nil {
 		return err
 	}
+	if allWorkspaces {
+		if err = npc.setWorkspaces(); err != nil {
+			return err
+		}
+	}
 
 	artDetails, err := npc.serverDetails.CreateArtAuthConfig()
 	if err != nil {
@@ -269,12 +327,62 @@ func (npc *NpmPublishCommand) preparePrerequisites() error {
 		return err
 	}
 
+	if npc.provenance {
+		// Fail before publishing, if the provenance can't be generated.
+		if _, _, err = getCiBuildDetails(); err != nil {
+			return err
+		}
+	}
+
 	return npc.setPackageInfo()
 }
 
+// setWorkspaces sets the workspaces of the project in the publish path to be published, so that each workspace is published after the workspaces it depends on.
+func (npc *NpmPublishCommand) setWorkspaces() error {
+	if fileInfo, err := os.Stat(npc.publishPath); err != nil || !fileInfo.IsDir() {
+		return errorutils.CheckErrorf("the --workspaces flag requires the path of an npm project with workspaces, but got: %s", npc.publishPath)
+	}
+	workspaces, err := readWorkspaces(npc.publishPath, npc.npmVersion)
+	if err != nil {
+		return err
+	}
+	if workspaces, err = sortWorkspacesTopologically(workspaces); err != nil {
+		return err
+	}
+	for _, workspace := range workspaces {
+		if workspace.private {
+			log.Info("Skipping the private workspace:", workspace.location)
+			continue
+		}
+		npc.workspaces = append(npc.workspaces, workspace)
+	}
+	if len(npc.workspaces) == 0 {
+		return errorutils.CheckErrorf("no workspaces to publish were found in the package.json of %s", npc.publishPath)
+	}
+	return nil
+}
+
 func (npc *NpmPublishCommand) pack() error {
+	if len(npc.workspaces) == 0 {
+		return npc.packPath(npc.npmArgs)
+	}
+	npmFlags := npc.npmArgs
+	if len(npmFlags) > 0 && !strings.HasPrefix(strings.TrimSpace(npmFlags[0]), "-") {
+		// The publish path is replaced by the paths of the workspaces.
+		npmFlags = npmFlags[1:]
+	}
+	for _, workspace := range npc.workspaces {
+		log.Info("Packing the workspace:", workspace.location)
+		if err := npc.packPath(append([]string{workspace.path}, npmFlags...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (npc *NpmPublishCommand) packPath(npmArgs []string) error {
 	log.Debug("Creating npm package.")
-	packedFileNames, err := npm.Pack(npc.npmArgs, npc.executablePath)
+	packedFileNames, err := npm.Pack(npmArgs, npc.executablePath)
 	if err != nil {
 		return err
 	}
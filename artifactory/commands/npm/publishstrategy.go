@@ -1,7 +1,10 @@
 package npm
 
 import (
+	"path/filepath"
+
 	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
@@ -12,7 +15,7 @@ import (
 
 type Publisher interface {
 	upload() error
-	getBuildArtifacts() []buildinfo.Artifact
+	getBuildArtifacts(artifactsDetailsReader []*content.ContentReader) []buildinfo.Artifact
 }
 
 type NpmPublishStrategy struct {
@@ -38,9 +41,10 @@ func (nps *NpmPublishStrategy) Publish() error {
 	return nps.strategy.upload()
 }
 
-func (nps *NpmPublishStrategy) GetBuildArtifacts() []buildinfo.Artifact {
+// GetBuildArtifacts converts the details of the uploaded artifacts to build-info artifacts.
+func (nps *NpmPublishStrategy) GetBuildArtifacts(artifactsDetailsReader ...*content.ContentReader) []buildinfo.Artifact {
 	log.Debug("Using strategy for build info: ", nps.strategyName)
-	return nps.strategy.getBuildArtifacts()
+	return nps.strategy.getBuildArtifacts(artifactsDetailsReader)
 }
 
 // ConvertArtifactsDetailsToBuildInfoArtifacts converts artifact details readers to build info artifacts
@@ -67,8 +71,7 @@ func performXrayScan(filePath string, repo string, serverDetails *config.ServerD
 		Pattern(filePath).
 		Target(repo + "/").
 		BuildSpec()
-	if err := commandsutils.ConditionalUploadScanFunc(serverDetails, fileSpec, 1, scanOutputFormat); err != nil {
-		return err
-	}
-	return nil
+	err := commandsutils.ConditionalUploadScanFunc(serverDetails, fileSpec, 1, scanOutputFormat)
+	jobsummary.RecordScanGate(filepath.Base(filePath), err)
+	return err
 }
@@ -0,0 +1,234 @@
+package npm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	biUtils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The npm flags which run a command on all the workspaces of the project.
+var allWorkspacesFlags = []string{"--workspaces", "--workspaces=true", "--ws", "-ws"}
+
+// npmWorkspace is a package of the workspaces of an npm project, declared by the workspaces field of the package.json of the project's root.
+type npmWorkspace struct {
+	// The path of the workspace, relative to the root of the project.
+	location    string
+	path        string
+	packageInfo *biUtils.PackageInfo
+	// Private packages aren't published.
+	private bool
+}
+
+// dependencyId returns the ID of the workspace in the npm ls dependencies tree of the project's root, which lists the workspaces as its dependencies.
+func (nw *npmWorkspace) dependencyId() string {
+	return nw.packageInfo.FullName() + ":" + nw.packageInfo.Version
+}
+
+// readWorkspaces returns the workspaces of the npm project in rootPath, sorted by their locations.
+// The workspaces field is either a list of glob patterns, such as packages/*, or an object with the patterns in its packages field.
+func readWorkspaces(rootPath string, npmVersion *version.Version) ([]*npmWorkspace, error) {
+	data, err := os.ReadFile(filepath.Join(rootPath, "package.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var packageJson struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err = json.Unmarshal(data, &packageJson); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the package.json of %s: %s", rootPath, err.Error())
+	}
+	patterns, err := parseWorkspacesField(packageJson.Workspaces)
+	if err != nil {
+		return nil, err
+	}
+	var workspaces []*npmWorkspace
+	found := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(rootPath, filepath.FromSlash(strings.TrimPrefix(pattern, "./"))))
+		if err != nil {
+			return nil, errorutils.CheckErrorf("invalid workspaces pattern '%s': %s", pattern, err.Error())
+		}
+		for _, workspacePath := range matches {
+			if found[workspacePath] {
+				continue
+			}
+			if _, err = os.Stat(filepath.Join(workspacePath, "package.json")); err != nil {
+				// Only the directories with a package.json are workspaces.
+				continue
+			}
+			workspace, err := readWorkspace(workspacePath, npmVersion)
+			if err != nil {
+				return nil, err
+			}
+			location, err := filepath.Rel(rootPath, workspacePath)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			workspace.location = filepath.ToSlash(location)
+			found[workspacePath] = true
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].location < workspaces[j].location
+	})
+	return workspaces, nil
+}
+
+func readWorkspace(workspacePath string, npmVersion *version.Version) (*npmWorkspace, error) {
+	data, err := os.ReadFile(filepath.Join(workspacePath, "package.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	packageInfo, err := biUtils.ReadPackageInfo(data, npmVersion)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var packageJson struct {
+		Private bool `json:"private"`
+	}
+	if err = json.Unmarshal(data, &packageJson); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &npmWorkspace{path: workspacePath, packageInfo: packageInfo, private: packageJson.Private}, nil
+}
+
+func parseWorkspacesField(workspacesField json.RawMessage) ([]string, error) {
+	if len(workspacesField) == 0 || string(workspacesField) == "null" {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(workspacesField, &patterns); err == nil {
+		return patterns, nil
+	}
+	var workspacesObject struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(workspacesField, &workspacesObject); err != nil {
+		return nil, errorutils.CheckErrorf("invalid workspaces field in package.json: %s", string(workspacesField))
+	}
+	return workspacesObject.Packages, nil
+}
+
+// sortWorkspacesTopologically orders the workspaces so that each workspace comes after the workspaces it depends on.
+// The workspaces which don't depend on each other keep their order.
+func sortWorkspacesTopologically(workspaces []*npmWorkspace) ([]*npmWorkspace, error) {
+	byName := make(map[string]*npmWorkspace, len(workspaces))
+	for _, workspace := range workspaces {
+		byName[workspace.packageInfo.FullName()] = workspace
+	}
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*npmWorkspace]int, len(workspaces))
+	sorted := make([]*npmWorkspace, 0, len(workspaces))
+	var visit func(workspace *npmWorkspace, requiredBy []string) error
+	visit = func(workspace *npmWorkspace, requiredBy []string) error {
+		switch state[workspace] {
+		case visited:
+			return nil
+		case visiting:
+			return errorutils.CheckErrorf("the npm workspaces have a circular dependency: %s", strings.Join(append(requiredBy, workspace.packageInfo.FullName()), " -> "))
+		}
+		state[workspace] = visiting
+		requiredBy = append(requiredBy, workspace.packageInfo.FullName())
+		for _, dependencyName := range workspaceDependencyNames(workspace.packageInfo) {
+			if dependency, ok := byName[dependencyName]; ok && dependency != workspace {
+				if err := visit(dependency, requiredBy); err != nil {
+					return err
+				}
+			}
+		}
+		state[workspace] = visited
+		sorted = append(sorted, workspace)
+		return nil
+	}
+	for _, workspace := range workspaces {
+		if err := visit(workspace, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// workspaceDependencyNames returns the sorted names of all the dependencies declared by the package.
+func workspaceDependencyNames(packageInfo *biUtils.PackageInfo) []string {
+	var names []string
+	for _, dependencies := range []map[string]string{packageInfo.Dependencies, packageInfo.DevDependencies, packageInfo.PeerDependencies, packageInfo.OptionalDependencies} {
+		for name := range dependencies {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// splitWorkspacesDependencies splits the dependencies of the npm project's root, calculated by npm ls, to the build-info modules of the root and of its workspaces.
+// npm ls lists the workspaces as dependencies of the root, so the dependencies requested through a workspace belong to the workspace's module.
+// The root module is first, followed by the modules of the workspaces in their order.
+func splitWorkspacesDependencies(rootModuleId string, dependencies []entities.Dependency, workspaces []*npmWorkspace) []entities.Module {
+	moduleIds := make(map[string]string, len(workspaces))
+	for _, workspace := range workspaces {
+		moduleIds[workspace.dependencyId()] = workspace.packageInfo.BuildInfoModuleId()
+	}
+	moduleDependencies := make(map[string][]entities.Dependency)
+	for _, dependency := range dependencies {
+		if _, isWorkspace := moduleIds[dependency.Id]; isWorkspace {
+			continue
+		}
+		requestedByModule := make(map[string][][]string)
+		var moduleOrder []string
+		for _, requestedBy := range dependency.RequestedBy {
+			moduleId, path := rootModuleId, requestedBy
+			// The path ends with the root module, and the element before it is the direct dependency of the root.
+			if len(requestedBy) > 1 {
+				if workspaceModuleId, ok := moduleIds[requestedBy[len(requestedBy)-2]]; ok {
+					moduleId = workspaceModuleId
+					path = append(append([]string{}, requestedBy[:len(requestedBy)-2]...), workspaceModuleId)
+				}
+			}
+			if _, exists := requestedByModule[moduleId]; !exists {
+				moduleOrder = append(moduleOrder, moduleId)
+			}
+			requestedByModule[moduleId] = append(requestedByModule[moduleId], path)
+		}
+		for _, moduleId := range moduleOrder {
+			moduleDependency := dependency
+			moduleDependency.RequestedBy = requestedByModule[moduleId]
+			moduleDependencies[moduleId] = append(moduleDependencies[moduleId], moduleDependency)
+		}
+	}
+	modules := []entities.Module{{Id: rootModuleId, Type: entities.Npm, Dependencies: moduleDependencies[rootModuleId]}}
+	for _, workspace := range workspaces {
+		moduleId := workspace.packageInfo.BuildInfoModuleId()
+		modules = append(modules, entities.Module{Id: moduleId, Type: entities.Npm, Dependencies: moduleDependencies[moduleId]})
+	}
+	return modules
+}
+
+// extractAllWorkspacesFlag removes the flags which run the command on all the workspaces from the npm args, and returns whether they were found.
+func extractAllWorkspacesFlag(npmArgs []string) (filteredArgs []string, allWorkspaces bool) {
+	for _, arg := range npmArgs {
+		switch {
+		case slices.Contains(allWorkspacesFlags, arg):
+			allWorkspaces = true
+		case arg == "--workspaces=false":
+			allWorkspaces = false
+		default:
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	biUtils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePackageJson(t *testing.T, dir, content string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0644))
+}
+
+func TestReadWorkspaces(t *testing.T) {
+	npmVersion := version.NewVersion("10.8.2")
+	rootPath := t.TempDir()
+	writePackageJson(t, rootPath, `{"name": "root", "version": "1.0.0", "private": true, "workspaces": ["packages/*", "tools/cli"]}`)
+	writePackageJson(t, filepath.Join(rootPath, "packages", "b"), `{"name": "@acme/b", "version": "2.0.0"}`)
+	writePackageJson(t, filepath.Join(rootPath, "packages", "a"), `{"name": "@acme/a", "version": "1.0.0", "dependencies": {"@acme/b": "^2.0.0"}}`)
+	writePackageJson(t, filepath.Join(rootPath, "tools", "cli"), `{"name": "cli", "version": "0.1.0", "private": true}`)
+	// A directory without a package.json isn't a workspace.
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "packages", "docs"), 0755))
+
+	workspaces, err := readWorkspaces(rootPath, npmVersion)
+	require.NoError(t, err)
+	require.Len(t, workspaces, 3)
+	assert.Equal(t, "packages/a", workspaces[0].location)
+	assert.Equal(t, "@acme/a:1.0.0", workspaces[0].dependencyId())
+	assert.Equal(t, "acme:a:1.0.0", workspaces[0].packageInfo.BuildInfoModuleId())
+	assert.Equal(t, "packages/b", workspaces[1].location)
+	assert.Equal(t, "tools/cli", workspaces[2].location)
+	assert.True(t, workspaces[2].private)
+
+	// The workspaces may also be declared in the packages field of an object.
+	writePackageJson(t, rootPath, `{"name": "root", "version": "1.0.0", "workspaces": {"packages": ["tools/*"]}}`)
+	workspaces, err = readWorkspaces(rootPath, npmVersion)
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	assert.Equal(t, "tools/cli", workspaces[0].location)
+
+	writePackageJson(t, rootPath, `{"name": "root", "version": "1.0.0"}`)
+	workspaces, err = readWorkspaces(rootPath, npmVersion)
+	require.NoError(t, err)
+	assert.Empty(t, workspaces)
+}
+
+func newTestWorkspace(name, version string, dependencies ...string) *npmWorkspace {
+	packageInfo := &biUtils.PackageInfo{Name: name, Version: version, Dependencies: map[string]string{}}
+	for _, dependency := range dependencies {
+		packageInfo.Dependencies[dependency] = "*"
+	}
+	return &npmWorkspace{location: name, packageInfo: packageInfo}
+}
+
+func TestSortWorkspacesTopologically(t *testing.T) {
+	app := newTestWorkspace("app", "1.0.0", "lib", "utils", "lodash")
+	lib := newTestWorkspace("lib", "1.0.0", "utils")
+	tool := newTestWorkspace("tool", "1.0.0")
+	utils := newTestWorkspace("utils", "1.0.0")
+
+	sorted, err := sortWorkspacesTopologically([]*npmWorkspace{app, lib, tool, utils})
+	require.NoError(t, err)
+	assert.Equal(t, []*npmWorkspace{utils, lib, app, tool}, sorted)
+
+	utils.packageInfo.DevDependencies = map[string]string{"app": "*"}
+	_, err = sortWorkspacesTopologically([]*npmWorkspace{app, lib, tool, utils})
+	assert.ErrorContains(t, err, "circular dependency: app -> lib -> utils -> app")
+}
+
+func TestSplitWorkspacesDependencies(t *testing.T) {
+	workspaceA := newTestWorkspace("a", "1.0.0", "b")
+	workspaceB := newTestWorkspace("b", "2.0.0")
+	dependencies := []entities.Dependency{
+		{Id: "typescript:5.4.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"root:1.0.0"}}},
+		{Id: "lodash:4.17.21", RequestedBy: [][]string{{"a:1.0.0", "root:1.0.0"}, {"express:4.19.0", "b:2.0.0", "root:1.0.0"}}},
+		{Id: "express:4.19.0", RequestedBy: [][]string{{"b:2.0.0", "root:1.0.0"}, {"b:2.0.0", "a:1.0.0", "root:1.0.0"}}},
+		{Id: "b:2.0.0", RequestedBy: [][]string{{"root:1.0.0"}}},
+	}
+
+	modules := splitWorkspacesDependencies("root:1.0.0", dependencies, []*npmWorkspace{workspaceA, workspaceB})
+	assert.Equal(t, []entities.Module{
+		{Id: "root:1.0.0", Type: entities.Npm, Dependencies: []entities.Dependency{
+			{Id: "typescript:5.4.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"root:1.0.0"}}},
+		}},
+		{Id: "a:1.0.0", Type: entities.Npm, Dependencies: []entities.Dependency{
+			{Id: "lodash:4.17.21", RequestedBy: [][]string{{"a:1.0.0"}}},
+			{Id: "express:4.19.0", RequestedBy: [][]string{{"b:2.0.0", "a:1.0.0"}}},
+		}},
+		{Id: "b:2.0.0", Type: entities.Npm, Dependencies: []entities.Dependency{
+			{Id: "lodash:4.17.21", RequestedBy: [][]string{{"express:4.19.0", "b:2.0.0"}}},
+			{Id: "express:4.19.0", RequestedBy: [][]string{{"b:2.0.0"}}},
+		}},
+	}, modules)
+}
+
+func TestExtractAllWorkspacesFlag(t *testing.T) {
+	args, allWorkspaces := extractAllWorkspacesFlag([]string{"--workspaces", "--tag=beta"})
+	assert.True(t, allWorkspaces)
+	assert.Equal(t, []string{"--tag=beta"}, args)
+
+	args, allWorkspaces = extractAllWorkspacesFlag([]string{"-ws", "--workspaces=false"})
+	assert.False(t, allWorkspaces)
+	assert.Empty(t, args)
+
+	args, allWorkspaces = extractAllWorkspacesFlag([]string{"dist", "--workspace=packages/a"})
+	assert.False(t, allWorkspaces)
+	assert.Equal(t, []string{"dist", "--workspace=packages/a"}, args)
+}
@@ -0,0 +1,112 @@
+package ociartifact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// CopySummary is the summary of the copy of an image.
+type CopySummary struct {
+	// The digest of the manifest of the image, which is the same in both of the repositories.
+	Digest string `json:"digest"`
+	// The number of the manifests copied, including the manifests of the platforms of multi-arch images.
+	Manifests    int   `json:"manifests"`
+	CopiedBlobs  int   `json:"copiedBlobs"`
+	SkippedBlobs int   `json:"skippedBlobs"`
+	CopiedBytes  int64 `json:"copiedBytes"`
+}
+
+// imageCopier streams the manifests and the blobs of an image from one registry to another.
+type imageCopier struct {
+	source *registryClient
+	target *registryClient
+	// The digests of the blobs which the target repository has, so that the blobs shared by the platforms are checked once.
+	targetBlobs map[string]bool
+	summary     CopySummary
+}
+
+// CopyImage copies an image, or any other OCI artifact, through the distribution API, from its repository to a tag of a
+// repository of the same or of another Artifactory instance. The blobs which the target repository already has aren't copied,
+// and the other blobs are streamed from the source to the target, without being stored locally. The manifests of the platforms
+// of multi-arch images are copied by their digests, before their list.
+func CopyImage(sourceManager, targetManager artifactory.ArtifactoryServicesManager, source, target *Reference) (*CopySummary, error) {
+	if target.Digest != "" {
+		return nil, errorutils.CheckErrorf("the image must be copied to a tag, rather than to the digest of %s", target)
+	}
+	copier := &imageCopier{
+		source:      &registryClient{servicesManager: sourceManager, reference: source},
+		target:      &registryClient{servicesManager: targetManager, reference: target},
+		targetBlobs: make(map[string]bool),
+	}
+	digest, err := copier.copyManifest(copier.source, copier.target)
+	if err != nil {
+		return nil, err
+	}
+	copier.summary.Digest = digest
+	return &copier.summary, nil
+}
+
+// copyManifest copies the blobs or the manifests referenced by a manifest, and then the manifest itself. Returns its digest.
+func (ic *imageCopier) copyManifest(source, target *registryClient) (string, error) {
+	manifest, err := readImageManifest(source)
+	if err != nil {
+		return "", err
+	}
+	if manifest.isIndex() {
+		for _, platformManifest := range manifest.Manifests {
+			if _, err = ic.copyManifest(source.forDigest(platformManifest.Digest), target.forDigest(platformManifest.Digest)); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		for _, blob := range manifest.blobs() {
+			if err = ic.copyBlob(blob); err != nil {
+				return "", err
+			}
+		}
+	}
+	log.Debug("Copying the manifest", manifest.digest, "of", source.reference, "to", target.reference)
+	if _, err = target.putManifest(manifest.content, manifest.mediaType); err != nil {
+		return "", err
+	}
+	ic.summary.Manifests++
+	return manifest.digest, nil
+}
+
+// copyBlob streams a blob from the source repository to the target repository, unless the target repository already has it.
+func (ic *imageCopier) copyBlob(blob Descriptor) error {
+	exists := ic.targetBlobs[blob.Digest]
+	if !exists {
+		var err error
+		if exists, err = ic.target.blobExists(blob.Digest); err != nil {
+			return err
+		}
+	}
+	ic.targetBlobs[blob.Digest] = true
+	if exists {
+		ic.summary.SkippedBlobs++
+		return nil
+	}
+	if err := ic.streamBlob(blob); err != nil {
+		return err
+	}
+	ic.summary.CopiedBlobs++
+	ic.summary.CopiedBytes += blob.Size
+	return nil
+}
+
+func (ic *imageCopier) streamBlob(blob Descriptor) (err error) {
+	reader, err := ic.source.readBlob(blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	log.Info(fmt.Sprintf("Copying the blob %s (%d bytes) to %s...", blob.Digest, blob.Size, ic.target.reference.Repo))
+	return ic.target.uploadBlob(reader, blob.Size, blob.Digest)
+}
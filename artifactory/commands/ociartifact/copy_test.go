@@ -0,0 +1,62 @@
+package ociartifact
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyImage(t *testing.T) {
+	sourceRegistry, sourceDetails := newTestRegistry(t)
+	targetRegistry, targetDetails := newTestRegistry(t)
+	dir := t.TempDir()
+	for _, platform := range []string{"amd64", "arm64"} {
+		layerPath := filepath.Join(dir, platform+".tar")
+		require.NoError(t, os.WriteFile(layerPath, []byte("layer of "+platform), 0644))
+		require.NoError(t, NewOciPushCommand().SetServerDetails(sourceDetails).SetReference("oci-local/sboms/app:"+platform).SetFiles([]string{layerPath}).Run())
+	}
+	// A multi-arch index of the images of the platforms, which share their config.
+	index, err := json.Marshal(imageManifest{MediaType: IndexMediaType, Manifests: []Descriptor{
+		{MediaType: ManifestMediaType, Digest: formatDigest(sha256Hex(sourceRegistry.manifests["amd64"]))},
+		{MediaType: ManifestMediaType, Digest: formatDigest(sha256Hex(sourceRegistry.manifests["arm64"]))},
+	}})
+	require.NoError(t, err)
+	indexDigest := formatDigest(sha256Hex(index))
+	sourceRegistry.manifests["1.0"], sourceRegistry.manifests[indexDigest], sourceRegistry.mediaTypes[indexDigest] = index, index, IndexMediaType
+
+	sourceManager, err := utils.CreateServiceManager(sourceDetails, -1, 0, false)
+	require.NoError(t, err)
+	targetManager, err := utils.CreateServiceManager(targetDetails, -1, 0, false)
+	require.NoError(t, err)
+	source, err := ParseReference("oci-local/sboms/app:1.0")
+	require.NoError(t, err)
+	target, err := ParseReference("oci-local/sboms/app:release")
+	require.NoError(t, err)
+	summary, err := CopyImage(sourceManager, targetManager, source, target)
+	require.NoError(t, err)
+	assert.Equal(t, &CopySummary{Digest: indexDigest, Manifests: 3, CopiedBlobs: 3, SkippedBlobs: 1, CopiedBytes: 2 + int64(len("layer of amd64")+len("layer of arm64"))}, summary)
+	assert.Equal(t, index, targetRegistry.manifests["release"])
+	assert.Equal(t, IndexMediaType, targetRegistry.mediaTypes[indexDigest])
+	assert.Equal(t, sourceRegistry.manifests["arm64"], targetRegistry.manifests[formatDigest(sha256Hex(sourceRegistry.manifests["arm64"]))])
+	assert.Equal(t, sourceRegistry.blobs, targetRegistry.blobs)
+
+	// The blobs which the target repository already has aren't copied again.
+	target.Tag = "latest"
+	summary, err = CopyImage(sourceManager, targetManager, source, target)
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.CopiedBlobs)
+	assert.Equal(t, 4, summary.SkippedBlobs)
+	assert.Len(t, targetRegistry.uploaded, 3)
+
+	// A manifest which doesn't match the digest it was referenced by isn't copied.
+	source, err = ParseReference("oci-local/sboms/app@" + indexDigest)
+	require.NoError(t, err)
+	sourceRegistry.manifests[indexDigest] = sourceRegistry.manifests["amd64"]
+	_, err = CopyImage(sourceManager, targetManager, source, target)
+	assert.ErrorContains(t, err, "doesn't match its digest")
+}
@@ -0,0 +1,106 @@
+package ociartifact
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// FetchSummary is the summary of the fetch of an image.
+type FetchSummary struct {
+	Digest string `json:"digest"`
+	// The number of the manifests fetched, including the manifests of the platforms of multi-arch images.
+	Manifests    int   `json:"manifests"`
+	FetchedBlobs int   `json:"fetchedBlobs"`
+	FetchedBytes int64 `json:"fetchedBytes"`
+	// The blobs which were already fetched, with another image which shares them.
+	SkippedBlobs int `json:"skippedBlobs"`
+}
+
+// ImageFetcher reads the manifests and the blobs of images from a repository, and discards them. Reading the images through
+// a remote repository caches them, so that they're served from the cache when they're pulled. The fetcher is safe for concurrent
+// use, and reads the blobs shared by the images once.
+type ImageFetcher struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	mutex           sync.Mutex
+	fetchedBlobs    map[string]bool
+}
+
+func NewImageFetcher(servicesManager artifactory.ArtifactoryServicesManager) *ImageFetcher {
+	return &ImageFetcher{servicesManager: servicesManager, fetchedBlobs: make(map[string]bool)}
+}
+
+// Fetch reads the manifest of the image and its blobs. The manifests of all the platforms of multi-arch images are read.
+func (imf *ImageFetcher) Fetch(reference *Reference) (*FetchSummary, error) {
+	summary := &FetchSummary{}
+	digest, err := imf.fetchManifest(&registryClient{servicesManager: imf.servicesManager, reference: reference}, summary)
+	if err != nil {
+		return nil, err
+	}
+	summary.Digest = digest
+	return summary, nil
+}
+
+func (imf *ImageFetcher) fetchManifest(client *registryClient, summary *FetchSummary) (string, error) {
+	manifest, err := readImageManifest(client)
+	if err != nil {
+		return "", err
+	}
+	summary.Manifests++
+	if manifest.isIndex() {
+		for _, platformManifest := range manifest.Manifests {
+			if _, err = imf.fetchManifest(client.forDigest(platformManifest.Digest), summary); err != nil {
+				return "", err
+			}
+		}
+		return manifest.digest, nil
+	}
+	for _, blob := range manifest.blobs() {
+		if !imf.startFetch(blob.Digest) {
+			summary.SkippedBlobs++
+			continue
+		}
+		if err = fetchBlob(client, blob); err != nil {
+			imf.failFetch(blob.Digest)
+			return "", err
+		}
+		summary.FetchedBlobs++
+		summary.FetchedBytes += blob.Size
+	}
+	return manifest.digest, nil
+}
+
+// startFetch returns true if the blob wasn't fetched yet, and marks it as fetched.
+func (imf *ImageFetcher) startFetch(digest string) bool {
+	imf.mutex.Lock()
+	defer imf.mutex.Unlock()
+	if imf.fetchedBlobs[digest] {
+		return false
+	}
+	imf.fetchedBlobs[digest] = true
+	return true
+}
+
+// failFetch unmarks a blob which failed to be fetched, so that the other images which share it fetch it again.
+func (imf *ImageFetcher) failFetch(digest string) {
+	imf.mutex.Lock()
+	defer imf.mutex.Unlock()
+	delete(imf.fetchedBlobs, digest)
+}
+
+func fetchBlob(client *registryClient, blob Descriptor) (err error) {
+	log.Debug("Fetching the blob", blob.Digest, "of", client.reference)
+	reader, err := client.readBlob(blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	_, err = io.Copy(io.Discard, reader)
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,49 @@
+package ociartifact
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageFetcher(t *testing.T) {
+	registry, serverDetails := newTestRegistry(t)
+	layerPath := filepath.Join(t.TempDir(), "layer.tar")
+	require.NoError(t, os.WriteFile(layerPath, []byte("shared layer"), 0644))
+	for _, tag := range []string{"amd64", "arm64"} {
+		require.NoError(t, NewOciPushCommand().SetServerDetails(serverDetails).SetReference("oci-local/sboms/app:"+tag).SetFiles([]string{layerPath}).
+			SetAnnotations(map[string]string{"platform": tag}).Run())
+	}
+	index, err := json.Marshal(imageManifest{MediaType: IndexMediaType, Manifests: []Descriptor{
+		{MediaType: ManifestMediaType, Digest: formatDigest(sha256Hex(registry.manifests["amd64"]))},
+		{MediaType: ManifestMediaType, Digest: formatDigest(sha256Hex(registry.manifests["arm64"]))},
+	}})
+	require.NoError(t, err)
+	indexDigest := formatDigest(sha256Hex(index))
+	registry.manifests["1.0"], registry.manifests[indexDigest], registry.mediaTypes[indexDigest] = index, index, IndexMediaType
+
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	fetcher := NewImageFetcher(servicesManager)
+	reference, err := ParseReference("oci-local/sboms/app:1.0")
+	require.NoError(t, err)
+	// The config and the layer are shared by the platforms, so they're fetched once.
+	summary, err := fetcher.Fetch(reference)
+	require.NoError(t, err)
+	assert.Equal(t, &FetchSummary{Digest: indexDigest, Manifests: 3, FetchedBlobs: 2, FetchedBytes: 2 + int64(len("shared layer")), SkippedBlobs: 2}, summary)
+
+	reference.Tag = "amd64"
+	summary, err = fetcher.Fetch(reference)
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.FetchedBlobs)
+	assert.Equal(t, 2, summary.SkippedBlobs)
+
+	reference.Tag = "missing"
+	_, err = fetcher.Fetch(reference)
+	assert.ErrorContains(t, err, "404")
+}
@@ -0,0 +1,80 @@
+package ociartifact
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	IndexMediaType              = "application/vnd.oci.image.index.v1+json"
+	DockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+	DockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// The layers of these media types are referenced by their URLs, rather than stored in the registries.
+	dockerForeignLayerMediaType = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
+	nonDistributableLayerPrefix = "application/vnd.oci.image.layer.nondistributable."
+)
+
+// The media types of the manifests of images, of multi-arch images, and of artifacts.
+var imageManifestMediaTypes = []string{ManifestMediaType, IndexMediaType, DockerManifestMediaType, DockerManifestListMediaType}
+
+// imageManifest holds the fields of image manifests and of indexes which reference blobs and other manifests.
+type imageManifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    *Descriptor  `json:"config,omitempty"`
+	Layers    []Descriptor `json:"layers,omitempty"`
+	Manifests []Descriptor `json:"manifests,omitempty"`
+
+	// The content of the manifest as it was read, and its media type and digest.
+	content   []byte
+	mediaType string
+	digest    string
+}
+
+// readImageManifest reads the manifest of a client's reference. If the manifest is referenced by its digest, its content
+// is verified against it.
+func readImageManifest(client *registryClient) (*imageManifest, error) {
+	content, mediaType, err := client.getManifest(imageManifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &imageManifest{content: content, digest: formatDigest(sha256Hex(content))}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the manifest of %s: %s", client.reference, err.Error())
+	}
+	// The media type of the manifest is taken from its content, if the registry didn't return it.
+	if mediaType, _, _ = strings.Cut(mediaType, ";"); !slices.Contains(imageManifestMediaTypes, strings.TrimSpace(mediaType)) {
+		mediaType = manifest.MediaType
+	}
+	if manifest.mediaType = strings.TrimSpace(mediaType); !slices.Contains(imageManifestMediaTypes, manifest.mediaType) {
+		return nil, errorutils.CheckErrorf("the manifest of %s is a '%s', rather than a manifest of an image", client.reference, manifest.mediaType)
+	}
+	if client.reference.Digest != "" && client.reference.Digest != manifest.digest {
+		return nil, errorutils.CheckErrorf("the manifest of %s doesn't match its digest: got %s", client.reference, manifest.digest)
+	}
+	return manifest, nil
+}
+
+// isIndex returns true if the manifest is a list of the manifests of the platforms of a multi-arch image.
+func (im *imageManifest) isIndex() bool {
+	return im.mediaType == IndexMediaType || im.mediaType == DockerManifestListMediaType
+}
+
+// blobs returns the config and the layers of the manifest, which are stored in the registry. The foreign layers are skipped.
+func (im *imageManifest) blobs() []Descriptor {
+	var blobs []Descriptor
+	if im.Config != nil {
+		blobs = append(blobs, *im.Config)
+	}
+	for _, layer := range im.Layers {
+		if layer.MediaType == dockerForeignLayerMediaType || strings.HasPrefix(layer.MediaType, nonDistributableLayerPrefix) {
+			log.Debug("Skipping the foreign layer", layer.Digest)
+			continue
+		}
+		blobs = append(blobs, layer)
+	}
+	return blobs
+}
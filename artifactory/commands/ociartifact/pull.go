@@ -0,0 +1,171 @@
+package ociartifact
+
+import (
+	"crypto/md5"  // #nosec G501 -- sha1/md5 are used for the checksums of the build-info dependencies, not for security
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// OciPullCommand pulls the files of an OCI artifact from a Docker or OCI repository. The layers of the manifest of the artifact
+// are written to the target directory by the names of their title annotations.
+type OciPullCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	reference          string
+	target             string
+}
+
+func NewOciPullCommand() *OciPullCommand {
+	return &OciPullCommand{}
+}
+
+func (opc *OciPullCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPullCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPullCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPullCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+// SetReference sets the artifact to pull, in the form of <repo>/<name>:<tag> or <repo>/<name>@<digest>.
+func (opc *OciPullCommand) SetReference(reference string) *OciPullCommand {
+	opc.reference = reference
+	return opc
+}
+
+// SetTarget sets the directory the files are written to. The current directory is used if it isn't set.
+func (opc *OciPullCommand) SetTarget(target string) *OciPullCommand {
+	opc.target = target
+	return opc
+}
+
+func (opc *OciPullCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPullCommand) CommandName() string {
+	return "rt_oci_pull"
+}
+
+// Run downloads the layers of the artifact which have a title, and verifies them against their digests. The layers without a title,
+// such as the layers of container images, are skipped. When the build-info is collected, the files are added to it as dependencies.
+func (opc *OciPullCommand) Run() error {
+	reference, err := ParseReference(opc.reference)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(opc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	client := &registryClient{servicesManager: servicesManager, reference: reference}
+	content, _, err := client.getManifest(ManifestMediaType)
+	if err != nil {
+		return err
+	}
+	var manifest Manifest
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return errorutils.CheckErrorf("failed to parse the manifest of %s: %s", reference, err.Error())
+	}
+	if manifest.MediaType != "" && manifest.MediaType != ManifestMediaType {
+		return errorutils.CheckErrorf("the manifest of %s is a %s, rather than an OCI image manifest", reference, manifest.MediaType)
+	}
+	target := opc.target
+	if target == "" {
+		target = "."
+	}
+	var dependencies []buildinfo.Dependency
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[TitleAnnotation]
+		if title == "" {
+			log.Warn(fmt.Sprintf("Skipping the layer %s of %s, which doesn't have the %s annotation.", layer.Digest, reference, TitleAnnotation))
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(title)) {
+			return errorutils.CheckErrorf("the title '%s' of the layer %s of %s isn't a local path", title, layer.Digest, reference)
+		}
+		localPath := filepath.Join(target, filepath.FromSlash(title))
+		log.Info(fmt.Sprintf("Pulling %s of %s to %s...", title, reference, localPath))
+		checksum, err := downloadLayer(client, layer, localPath)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, buildinfo.Dependency{Id: title, Repository: reference.Repo, Checksum: checksum})
+	}
+	log.Info(fmt.Sprintf("Pulled %d files of %s.", len(dependencies), reference))
+	return opc.addDependenciesToBuildInfo(reference, dependencies)
+}
+
+// downloadLayer writes the blob of the layer to the local path, and returns its checksums. The file is removed if it doesn't
+// match the digest of the layer.
+func downloadLayer(client *registryClient, layer Descriptor, localPath string) (checksum buildinfo.Checksum, err error) {
+	reader, err := client.readBlob(layer.Digest)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	sha1Hash, sha256Hash, md5Hash := sha1.New(), sha256.New(), md5.New() // #nosec G401
+	_, err = io.Copy(io.MultiWriter(file, sha1Hash, sha256Hash, md5Hash), reader)
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(file.Close())); err != nil {
+		return
+	}
+	checksum = buildinfo.Checksum{Sha1: hex.EncodeToString(sha1Hash.Sum(nil)), Sha256: hex.EncodeToString(sha256Hash.Sum(nil)), Md5: hex.EncodeToString(md5Hash.Sum(nil))}
+	if digest := formatDigest(checksum.Sha256); digest != layer.Digest {
+		return checksum, errors.Join(errorutils.CheckErrorf("the content of %s doesn't match the digest of its layer: expected %s, got %s", localPath, layer.Digest, digest),
+			errorutils.CheckError(os.Remove(localPath)))
+	}
+	return checksum, nil
+}
+
+// addDependenciesToBuildInfo adds the pulled files to the build-info. Unless the module is set, the module is named after the artifact.
+func (opc *OciPullCommand) addDependenciesToBuildInfo(reference *Reference, dependencies []buildinfo.Dependency) error {
+	if opc.buildConfiguration == nil {
+		return nil
+	}
+	collectBuildInfo, err := opc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	buildName, err := opc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := opc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	module := opc.buildConfiguration.GetModule()
+	if module == "" {
+		module = reference.Name + ":" + reference.manifestReference()
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, opc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+		partial.ModuleId = module
+		partial.ModuleType = buildinfo.Docker
+	})
+}
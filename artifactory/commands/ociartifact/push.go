@@ -0,0 +1,262 @@
+package ociartifact
+
+import (
+	"crypto/md5"  // #nosec G501 -- sha1/md5 are used for the checksums of the build-info artifacts, not for security
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// pushedFile is a file of the artifact, which is pushed as a layer of its manifest.
+type pushedFile struct {
+	localPath  string
+	descriptor Descriptor
+	checksum   buildinfo.Checksum
+}
+
+// OciPushCommand pushes files as an OCI artifact to a Docker or OCI repository. Each file is a layer of the manifest of the artifact,
+// with its media type, and with its name in the title annotation, so that it's restored by its name when the artifact is pulled.
+type OciPushCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	reference          string
+	files              []string
+	artifactType       string
+	annotations        map[string]string
+}
+
+func NewOciPushCommand() *OciPushCommand {
+	return &OciPushCommand{}
+}
+
+func (opc *OciPushCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPushCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPushCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPushCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+// SetReference sets the artifact to push, in the form of <repo>/<name>:<tag>.
+func (opc *OciPushCommand) SetReference(reference string) *OciPushCommand {
+	opc.reference = reference
+	return opc
+}
+
+// SetFiles sets the files of the artifact, each in the form of <path>[:<media type>].
+func (opc *OciPushCommand) SetFiles(files []string) *OciPushCommand {
+	opc.files = files
+	return opc
+}
+
+func (opc *OciPushCommand) SetArtifactType(artifactType string) *OciPushCommand {
+	opc.artifactType = artifactType
+	return opc
+}
+
+func (opc *OciPushCommand) SetAnnotations(annotations map[string]string) *OciPushCommand {
+	opc.annotations = annotations
+	return opc
+}
+
+func (opc *OciPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPushCommand) CommandName() string {
+	return "rt_oci_push"
+}
+
+// Run uploads the blobs of the files which the repository doesn't have, and then the manifest of the artifact under its tag.
+// When the build-info is collected, the blobs and the manifest are added to it as artifacts.
+func (opc *OciPushCommand) Run() error {
+	reference, err := ParseReference(opc.reference)
+	if err != nil {
+		return err
+	}
+	if reference.Digest != "" {
+		return errorutils.CheckErrorf("the OCI artifact %s must be pushed by a tag", reference)
+	}
+	if len(opc.files) == 0 {
+		return errorutils.CheckErrorf("at least one file must be pushed as the OCI artifact %s", reference)
+	}
+	files := make([]pushedFile, 0, len(opc.files))
+	for _, file := range opc.files {
+		localPath, mediaType := parseFileArgument(file)
+		pushed, err := readPushedFile(localPath, mediaType)
+		if err != nil {
+			return err
+		}
+		files = append(files, pushed)
+	}
+	servicesManager, err := utils.CreateServiceManager(opc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	client := &registryClient{servicesManager: servicesManager, reference: reference}
+
+	config := newEmptyConfig()
+	if err = pushBlob(client, config.Digest, func() error {
+		return client.uploadBlob(strings.NewReader(emptyConfigContent), config.Size, config.Digest)
+	}); err != nil {
+		return err
+	}
+	manifest := Manifest{SchemaVersion: 2, MediaType: ManifestMediaType, ArtifactType: opc.artifactType, Config: config, Annotations: opc.annotations}
+	if manifest.ArtifactType == "" {
+		manifest.ArtifactType = DefaultArtifactType
+	}
+	for _, file := range files {
+		log.Info(fmt.Sprintf("Pushing %s to %s...", file.localPath, reference))
+		if err = pushBlob(client, file.descriptor.Digest, func() error { return uploadFile(client, file) }); err != nil {
+			return err
+		}
+		manifest.Layers = append(manifest.Layers, file.descriptor)
+	}
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	manifestDigest, err := client.putManifest(content, ManifestMediaType)
+	if err != nil {
+		return err
+	}
+	if manifestDigest == "" {
+		manifestDigest = formatDigest(sha256Hex(content))
+	}
+	log.Info(fmt.Sprintf("Pushed %d files as %s, with the digest %s.", len(files), reference, manifestDigest))
+	return opc.addArtifactsToBuildInfo(reference, files, content)
+}
+
+// ParseAnnotations parses the annotations of a manifest, in the form of "key1=value1;key2=value2".
+func ParseAnnotations(annotations string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, annotation := range strings.Split(annotations, ";") {
+		if strings.TrimSpace(annotation) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(annotation, "=")
+		if key = strings.TrimSpace(key); !found || key == "" {
+			return nil, errorutils.CheckErrorf("the annotation '%s' must be in the form of key=value", annotation)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// pushBlob uploads a blob, unless the repository already has it.
+func pushBlob(client *registryClient, digest string, upload func() error) error {
+	exists, err := client.blobExists(digest)
+	if err != nil || exists {
+		return err
+	}
+	return upload()
+}
+
+func uploadFile(client *registryClient, file pushedFile) (err error) {
+	reader, err := os.Open(file.localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		// The file is closed by the HTTP client once its request is sent.
+		if closeErr := reader.Close(); err == nil && !errors.Is(closeErr, os.ErrClosed) {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	return client.uploadBlob(reader, file.descriptor.Size, file.descriptor.Digest)
+}
+
+// parseFileArgument splits a file argument to its path and its media type, which are separated by the last colon. Since media
+// types include a slash, and since the path of an existing file is taken as is, a colon of a path, such as of a Windows drive,
+// isn't mistaken for the separator.
+func parseFileArgument(file string) (localPath, mediaType string) {
+	if i := strings.LastIndex(file, ":"); i > 0 && strings.Contains(file[i+1:], "/") {
+		if _, err := os.Stat(file); err != nil {
+			return file[:i], file[i+1:]
+		}
+	}
+	return file, DefaultLayerMediaType
+}
+
+func readPushedFile(localPath, mediaType string) (pushedFile, error) {
+	fileInfo, err := os.Stat(localPath)
+	if err != nil {
+		return pushedFile{}, errorutils.CheckError(err)
+	}
+	if fileInfo.IsDir() {
+		return pushedFile{}, errorutils.CheckErrorf("%s is a directory. Only files can be pushed as the layers of OCI artifacts", localPath)
+	}
+	checksums, err := crypto.GetFileChecksums(localPath, crypto.SHA1, crypto.SHA256, crypto.MD5)
+	if err != nil {
+		return pushedFile{}, errorutils.CheckError(err)
+	}
+	return pushedFile{
+		localPath: localPath,
+		descriptor: Descriptor{
+			MediaType:   mediaType,
+			Digest:      formatDigest(checksums[crypto.SHA256]),
+			Size:        fileInfo.Size(),
+			Annotations: map[string]string{TitleAnnotation: filepath.Base(localPath)},
+		},
+		checksum: buildinfo.Checksum{Sha1: checksums[crypto.SHA1], Sha256: checksums[crypto.SHA256], Md5: checksums[crypto.MD5]},
+	}, nil
+}
+
+// addArtifactsToBuildInfo adds the blobs of the files and the manifest to the build-info, by their paths in the tag folder of the
+// repository. Unless the module is set, the module is named after the artifact.
+func (opc *OciPushCommand) addArtifactsToBuildInfo(reference *Reference, files []pushedFile, manifestContent []byte) error {
+	if opc.buildConfiguration == nil {
+		return nil
+	}
+	collectBuildInfo, err := opc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	tagPath := path.Join(reference.Name, reference.Tag)
+	var artifacts []buildinfo.Artifact
+	for _, file := range files {
+		name := digestToArtifactName(file.descriptor.Digest)
+		artifacts = append(artifacts, buildinfo.Artifact{Name: name, Path: path.Join(tagPath, name), OriginalDeploymentRepo: reference.Repo, Checksum: file.checksum})
+	}
+	artifacts = append(artifacts, buildinfo.Artifact{
+		Name:                   "manifest.json",
+		Type:                   "json",
+		Path:                   path.Join(tagPath, "manifest.json"),
+		OriginalDeploymentRepo: reference.Repo,
+		Checksum:               contentChecksum(manifestContent),
+	})
+	module := opc.buildConfiguration.GetModule()
+	if module == "" {
+		opc.buildConfiguration.SetModule(reference.Name + ":" + reference.Tag)
+		defer opc.buildConfiguration.SetModule(module)
+	}
+	return build.PopulateBuildArtifactsAsPartials(artifacts, opc.buildConfiguration, buildinfo.Docker)
+}
+
+func contentChecksum(content []byte) buildinfo.Checksum {
+	sha1Sum := sha1.Sum(content) // #nosec G401
+	md5Sum := md5.Sum(content)   // #nosec G401
+	return buildinfo.Checksum{Sha1: hex.EncodeToString(sha1Sum[:]), Sha256: sha256Hex(content), Md5: hex.EncodeToString(md5Sum[:])}
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package ociartifact
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRegistry is an in-memory OCI registry, served under the Docker API of the oci-local repository.
+type testRegistry struct {
+	mutex     sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	// The media types of the manifests, by their digests.
+	mediaTypes map[string]string
+	uploaded   []string
+}
+
+func newTestRegistry(t *testing.T) (*testRegistry, *config.ServerDetails) {
+	registry := &testRegistry{blobs: make(map[string][]byte), manifests: make(map[string][]byte), mediaTypes: make(map[string]string)}
+	server := httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	t.Cleanup(server.Close)
+	return registry, &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+}
+
+func (tr *testRegistry) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	if r.URL.Path == "/artifactory/api/system/version" {
+		_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		return
+	}
+	endpoint, found := strings.CutPrefix(r.URL.Path, "/artifactory/api/docker/oci-local/v2/sboms/app/")
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case endpoint == "blobs/uploads/" && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/artifactory/api/docker/oci-local/v2/sboms/app/blobs/uploads/1234?state=started")
+		w.WriteHeader(http.StatusAccepted)
+	case endpoint == "blobs/uploads/1234" && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if r.URL.Query().Get("state") != "started" || digest != formatDigest(sha256Hex(content)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.blobs[digest] = content
+		tr.uploaded = append(tr.uploaded, digest)
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(endpoint, "blobs/"):
+		content, exists := tr.blobs[strings.TrimPrefix(endpoint, "blobs/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	case strings.HasPrefix(endpoint, "manifests/") && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		mediaType := r.Header.Get("Content-Type")
+		if !slices.Contains(imageManifestMediaTypes, mediaType) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		digest := formatDigest(sha256Hex(content))
+		tr.manifests[strings.TrimPrefix(endpoint, "manifests/")] = content
+		tr.manifests[digest] = content
+		tr.mediaTypes[digest] = mediaType
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(endpoint, "manifests/") && r.Method == http.MethodGet:
+		content, exists := tr.manifests[strings.TrimPrefix(endpoint, "manifests/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", tr.mediaTypes[formatDigest(sha256Hex(content))])
+		_, _ = w.Write(content)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestParseFileArgument(t *testing.T) {
+	localPath, mediaType := parseFileArgument("sbom.spdx.json:application/spdx+json")
+	assert.Equal(t, "sbom.spdx.json", localPath)
+	assert.Equal(t, "application/spdx+json", mediaType)
+	localPath, mediaType = parseFileArgument(`C:\sboms\sbom.json`)
+	assert.Equal(t, `C:\sboms\sbom.json`, localPath)
+	assert.Equal(t, DefaultLayerMediaType, mediaType)
+}
+
+func TestParseAnnotations(t *testing.T) {
+	annotations, err := ParseAnnotations("org.opencontainers.image.source=https://github.com/acme/app; org.opencontainers.image.description=SBOM, of app")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.source":      "https://github.com/acme/app",
+		"org.opencontainers.image.description": "SBOM, of app",
+	}, annotations)
+	_, err = ParseAnnotations("org.opencontainers.image.source")
+	assert.ErrorContains(t, err, "must be in the form of key=value")
+}
+
+func TestPushAndPull(t *testing.T) {
+	registry, serverDetails := newTestRegistry(t)
+	dir := t.TempDir()
+	sbomPath := filepath.Join(dir, "sbom.spdx.json")
+	require.NoError(t, os.WriteFile(sbomPath, []byte(`{"spdxVersion":"SPDX-2.3"}`), 0644))
+	wasmPath := filepath.Join(dir, "filter.wasm")
+	require.NoError(t, os.WriteFile(wasmPath, []byte("wasm"), 0644))
+
+	pushCommand := NewOciPushCommand().SetServerDetails(serverDetails).SetReference("oci-local/sboms/app:1.0").
+		SetFiles([]string{sbomPath + ":application/spdx+json", wasmPath}).SetArtifactType("application/vnd.acme.sbom.v1").
+		SetAnnotations(map[string]string{"org.opencontainers.image.source": "https://github.com/acme/app"})
+	require.NoError(t, pushCommand.Run())
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(registry.manifests["1.0"], &manifest))
+	assert.Equal(t, ManifestMediaType, registry.mediaTypes[formatDigest(sha256Hex(registry.manifests["1.0"]))])
+	assert.Equal(t, "application/vnd.acme.sbom.v1", manifest.ArtifactType)
+	assert.Equal(t, newEmptyConfig(), manifest.Config)
+	assert.Equal(t, "https://github.com/acme/app", manifest.Annotations["org.opencontainers.image.source"])
+	require.Len(t, manifest.Layers, 2)
+	assert.Equal(t, "application/spdx+json", manifest.Layers[0].MediaType)
+	assert.Equal(t, "sbom.spdx.json", manifest.Layers[0].Annotations[TitleAnnotation])
+	assert.Equal(t, DefaultLayerMediaType, manifest.Layers[1].MediaType)
+	assert.Len(t, registry.uploaded, 3)
+
+	// The blobs which the repository already has aren't uploaded again.
+	require.NoError(t, NewOciPushCommand().SetServerDetails(serverDetails).SetReference("oci-local/sboms/app:latest").SetFiles([]string{wasmPath}).Run())
+	assert.Len(t, registry.uploaded, 3)
+
+	target := t.TempDir()
+	require.NoError(t, NewOciPullCommand().SetServerDetails(serverDetails).SetReference("oci-local/sboms/app:1.0").SetTarget(target).Run())
+	content, err := os.ReadFile(filepath.Join(target, "sbom.spdx.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"spdxVersion":"SPDX-2.3"}`, string(content))
+	content, err = os.ReadFile(filepath.Join(target, "filter.wasm"))
+	require.NoError(t, err)
+	assert.Equal(t, "wasm", string(content))
+
+	// A layer whose content doesn't match its digest is removed.
+	registry.blobs[manifest.Layers[1].Digest] = []byte("tampered")
+	target = t.TempDir()
+	err = NewOciPullCommand().SetServerDetails(serverDetails).SetReference("oci-local/sboms/app:1.0").SetTarget(target).Run()
+	assert.ErrorContains(t, err, "doesn't match the digest of its layer")
+	assert.NoFileExists(t, filepath.Join(target, "filter.wasm"))
+}
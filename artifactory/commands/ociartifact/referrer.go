@@ -0,0 +1,94 @@
+package ociartifact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// CycloneDxMediaType is the media type and the artifact type of the CycloneDX SBOMs attached to images.
+const CycloneDxMediaType = "application/vnd.cyclonedx+json"
+
+// Referrer is an artifact which refers to the manifest of an image, such as its SBOM or an attestation, by the subject of its manifest.
+type Referrer struct {
+	// The image which the artifact refers to, by its digest.
+	Subject      string `json:"subject"`
+	Digest       string `json:"digest"`
+	ArtifactType string `json:"artifactType"`
+	Size         int64  `json:"size"`
+
+	reference       *Reference
+	manifestContent []byte
+	blob            Descriptor
+	blobChecksum    buildinfo.Checksum
+}
+
+// AttachReferrer pushes the content as an artifact of the artifact type, whose manifest refers to the manifest of the subject,
+// so that it's listed by the OCI referrers API of the repository. The artifact is pushed by its digest, rather than by a tag,
+// so the tags of the repository aren't changed. If the subject is a multi-arch image, the artifact refers to its list.
+func AttachReferrer(servicesManager artifactory.ArtifactoryServicesManager, subject *Reference, artifactType, mediaType string, content []byte,
+	annotations map[string]string) (*Referrer, error) {
+	client := &registryClient{servicesManager: servicesManager, reference: subject}
+	subjectManifest, err := readImageManifest(client)
+	if err != nil {
+		return nil, err
+	}
+	config := newEmptyConfig()
+	if err = pushBlob(client, config.Digest, func() error {
+		return client.uploadBlob(strings.NewReader(emptyConfigContent), config.Size, config.Digest)
+	}); err != nil {
+		return nil, err
+	}
+	blob := Descriptor{MediaType: mediaType, Digest: formatDigest(sha256Hex(content)), Size: int64(len(content))}
+	if err = pushBlob(client, blob.Digest, func() error {
+		return client.uploadBlob(bytes.NewReader(content), blob.Size, blob.Digest)
+	}); err != nil {
+		return nil, err
+	}
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		ArtifactType:  artifactType,
+		Config:        config,
+		Layers:        []Descriptor{blob},
+		Subject:       &Descriptor{MediaType: subjectManifest.mediaType, Digest: subjectManifest.digest, Size: int64(len(subjectManifest.content))},
+		Annotations:   annotations,
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	referrerClient := client.forDigest(formatDigest(sha256Hex(manifestContent)))
+	log.Debug(fmt.Sprintf("Pushing the %s referrer %s of %s", artifactType, referrerClient.reference.Digest, subject))
+	if _, err = referrerClient.putManifest(manifestContent, ManifestMediaType); err != nil {
+		return nil, err
+	}
+	return &Referrer{
+		Subject:         client.forDigest(subjectManifest.digest).reference.String(),
+		Digest:          referrerClient.reference.Digest,
+		ArtifactType:    artifactType,
+		Size:            blob.Size,
+		reference:       referrerClient.reference,
+		manifestContent: manifestContent,
+		blob:            blob,
+		blobChecksum:    contentChecksum(content),
+	}, nil
+}
+
+// BuildInfoArtifacts returns the manifest and the blob of the referrer as build-info artifacts, by their paths in the digest
+// folder of the referrer in the repository.
+func (r *Referrer) BuildInfoArtifacts() []buildinfo.Artifact {
+	digestPath := path.Join(r.reference.Name, digestToArtifactName(r.Digest))
+	blobName := digestToArtifactName(r.blob.Digest)
+	return []buildinfo.Artifact{
+		{Name: blobName, Type: "json", Path: path.Join(digestPath, blobName), OriginalDeploymentRepo: r.reference.Repo, Checksum: r.blobChecksum},
+		{Name: "manifest.json", Type: "json", Path: path.Join(digestPath, "manifest.json"), OriginalDeploymentRepo: r.reference.Repo, Checksum: contentChecksum(r.manifestContent)},
+	}
+}
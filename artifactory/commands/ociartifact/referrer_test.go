@@ -0,0 +1,56 @@
+package ociartifact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachReferrer(t *testing.T) {
+	registry, serverDetails := newTestRegistry(t)
+	image := `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:1234","size":2},"layers":[]}`
+	imageDigest := formatDigest(sha256Hex([]byte(image)))
+	registry.manifests["1.0"], registry.manifests[imageDigest] = []byte(image), []byte(image)
+	registry.mediaTypes[imageDigest] = ManifestMediaType
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+
+	sbom := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`)
+	subject := &Reference{Repo: "oci-local", Name: "sboms/app", Tag: "1.0"}
+	referrer, err := AttachReferrer(servicesManager, subject, CycloneDxMediaType, CycloneDxMediaType, sbom, map[string]string{"org.opencontainers.image.created": "2026-10-14T00:00:00Z"})
+	require.NoError(t, err)
+	assert.Equal(t, "oci-local/sboms/app@"+imageDigest, referrer.Subject)
+	assert.Equal(t, CycloneDxMediaType, referrer.ArtifactType)
+	assert.Equal(t, int64(len(sbom)), referrer.Size)
+
+	// The referrer is pushed by its digest, and the tag of the image isn't changed.
+	assert.Equal(t, image, string(registry.manifests["1.0"]))
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(registry.manifests[referrer.Digest], &manifest))
+	assert.Equal(t, CycloneDxMediaType, manifest.ArtifactType)
+	require.NotNil(t, manifest.Subject)
+	assert.Equal(t, Descriptor{MediaType: ManifestMediaType, Digest: imageDigest, Size: int64(len(image))}, *manifest.Subject)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, CycloneDxMediaType, manifest.Layers[0].MediaType)
+	assert.Equal(t, sbom, registry.blobs[manifest.Layers[0].Digest])
+
+	artifacts := referrer.BuildInfoArtifacts()
+	require.Len(t, artifacts, 2)
+	digestPath := "sboms/app/" + digestToArtifactName(referrer.Digest)
+	assert.Equal(t, digestPath+"/"+digestToArtifactName(manifest.Layers[0].Digest), artifacts[0].Path)
+	assert.Equal(t, sha256Hex(sbom), artifacts[0].Sha256)
+	assert.Equal(t, digestPath+"/manifest.json", artifacts[1].Path)
+	assert.Equal(t, "sha256:"+artifacts[1].Sha256, referrer.Digest)
+	assert.Equal(t, "oci-local", artifacts[1].OriginalDeploymentRepo)
+}
+
+func TestAttachReferrerMissingSubject(t *testing.T) {
+	_, serverDetails := newTestRegistry(t)
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	_, err = AttachReferrer(servicesManager, &Reference{Repo: "oci-local", Name: "sboms/app", Tag: "missing"}, CycloneDxMediaType, CycloneDxMediaType, []byte("{}"), nil)
+	assert.Error(t, err)
+}
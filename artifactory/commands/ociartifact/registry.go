@@ -0,0 +1,235 @@
+// Package ociartifact pushes and pulls arbitrary files as OCI artifacts, as ORAS does, to and from the Docker and OCI repositories
+// of Artifactory, and copies images between them. The artifacts are sent through the OCI distribution API of the repositories,
+// so no Docker client is needed.
+package ociartifact
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// The media type of the files of an artifact, if it isn't set for them.
+	DefaultLayerMediaType = "application/vnd.oci.image.layer.v1.tar"
+	// The artifact type of the manifests, if it isn't set.
+	DefaultArtifactType = "application/vnd.unknown.artifact.v1"
+	// The empty config of the artifacts, which aren't images.
+	emptyConfigMediaType = "application/vnd.oci.empty.v1+json"
+	emptyConfigContent   = "{}"
+	emptyConfigDigest    = "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+	// The annotation of the files, which names them when they're pulled.
+	TitleAnnotation = "org.opencontainers.image.title"
+
+	defaultTag = "latest"
+)
+
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest, whose layers are the files of the artifact.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	ArtifactType  string       `json:"artifactType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+	// The manifest which the artifact refers to, such as the image of an SBOM. See AttachReferrer.
+	Subject     *Descriptor       `json:"subject,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Reference is an artifact in a repository, in the form of <repo>/<name>:<tag> or <repo>/<name>@<digest>.
+type Reference struct {
+	Repo string
+	Name string
+	// The tag or the digest of the artifact.
+	Tag    string
+	Digest string
+}
+
+// ParseReference parses the reference of an artifact. The tag is latest, unless the tag or the digest is set.
+func ParseReference(reference string) (*Reference, error) {
+	repo, name, found := strings.Cut(strings.TrimPrefix(reference, "/"), "/")
+	if !found || repo == "" || name == "" {
+		return nil, errorutils.CheckErrorf("the OCI reference '%s' must be in the form of <repo>/<name>:<tag> or <repo>/<name>@<digest>", reference)
+	}
+	parsed := &Reference{Repo: repo, Name: name, Tag: defaultTag}
+	if name, digest, found := strings.Cut(name, "@"); found {
+		if !strings.HasPrefix(digest, "sha256:") {
+			return nil, errorutils.CheckErrorf("the digest of the OCI reference '%s' must be a sha256 digest", reference)
+		}
+		parsed.Name, parsed.Tag, parsed.Digest = name, "", digest
+		return parsed, nil
+	}
+	// A colon after the last slash separates the tag from the name.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		parsed.Name, parsed.Tag = name[:i], name[i+1:]
+	}
+	if parsed.Name == "" || parsed.Tag == "" {
+		return nil, errorutils.CheckErrorf("the OCI reference '%s' must be in the form of <repo>/<name>:<tag> or <repo>/<name>@<digest>", reference)
+	}
+	return parsed, nil
+}
+
+// manifestReference returns the tag or the digest the manifest of the artifact is referenced by.
+func (r *Reference) manifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+func (r *Reference) String() string {
+	if r.Digest != "" {
+		return path.Join(r.Repo, r.Name) + "@" + r.Digest
+	}
+	return path.Join(r.Repo, r.Name) + ":" + r.Tag
+}
+
+// registryClient sends the requests of the OCI distribution API to a repository of Artifactory.
+type registryClient struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	reference       *Reference
+}
+
+// url returns the URL of an endpoint of the distribution API of the artifact, such as blobs/uploads/.
+func (rc *registryClient) url(endpoint string) string {
+	return rc.servicesManager.GetConfig().GetServiceDetails().GetUrl() + path.Join("api/docker", rc.reference.Repo, "v2", rc.reference.Name) + "/" + endpoint
+}
+
+// blobExists returns true if the repository already has the blob, so that it isn't uploaded again.
+func (rc *registryClient) blobExists(digest string) (bool, error) {
+	httpClientDetails := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := rc.servicesManager.Client().SendHead(rc.url("blobs/"+digest), &httpClientDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNotFound)
+	}
+}
+
+// uploadBlob uploads the content of a blob in a single request, after starting its upload session.
+func (rc *registryClient) uploadBlob(content io.Reader, size int64, digest string) error {
+	httpClientDetails := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	uploadsUrl := rc.url("blobs/uploads/")
+	resp, body, err := rc.servicesManager.Client().SendPost(uploadsUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+		return err
+	}
+	uploadUrl, err := resolveLocation(uploadsUrl, resp.Header.Get("Location"))
+	if err != nil {
+		return err
+	}
+	query := uploadUrl.Query()
+	query.Set("digest", digest)
+	uploadUrl.RawQuery = query.Encode()
+	log.Debug("Uploading the blob", digest, "to", rc.reference.Repo)
+	httpClientDetails = rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.Headers["Content-Type"] = "application/octet-stream"
+	_, _, err = rc.servicesManager.Client().UploadFileFromReader(content, uploadUrl.String(), &httpClientDetails, size)
+	return err
+}
+
+// resolveLocation returns the URL of the Location header of the response to the request, which may be relative to it.
+func resolveLocation(requestUrl, location string) (*url.URL, error) {
+	if location == "" {
+		return nil, errorutils.CheckErrorf("the response of %s doesn't include the location of the upload", requestUrl)
+	}
+	base, err := url.Parse(requestUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	locationUrl, err := url.Parse(location)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return base.ResolveReference(locationUrl), nil
+}
+
+// putManifest uploads the manifest of the artifact under its tag, and returns the digest of the manifest.
+func (rc *registryClient) putManifest(content []byte, mediaType string) (string, error) {
+	httpClientDetails := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.Headers["Content-Type"] = mediaType
+	resp, body, err := rc.servicesManager.Client().SendPut(rc.url("manifests/"+rc.reference.manifestReference()), content, &httpClientDetails)
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK); err != nil {
+		return "", err
+	}
+	return resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+// getManifest returns the manifest of the artifact, by its tag or digest, and its media type. The accepted media types are sent
+// in the Accept header, so that the registry doesn't convert the manifest to another schema.
+func (rc *registryClient) getManifest(acceptedMediaTypes ...string) (content []byte, mediaType string, err error) {
+	httpClientDetails := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.Headers["Accept"] = strings.Join(acceptedMediaTypes, ", ")
+	resp, body, _, err := rc.servicesManager.Client().SendGet(rc.url("manifests/"+rc.reference.manifestReference()), true, &httpClientDetails)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, "", err
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// forDigest returns a client of the manifest of the digest, in the repository and under the name of the artifact.
+func (rc *registryClient) forDigest(digest string) *registryClient {
+	return &registryClient{servicesManager: rc.servicesManager, reference: &Reference{Repo: rc.reference.Repo, Name: rc.reference.Name, Digest: digest}}
+}
+
+// readBlob returns the content of a blob. The reader must be closed by the caller.
+func (rc *registryClient) readBlob(digest string) (io.ReadCloser, error) {
+	httpClientDetails := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	reader, resp, err := rc.servicesManager.Client().ReadRemoteFile(rc.url("blobs/"+digest), &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr == nil {
+			readErr = resp.Body.Close()
+		}
+		if readErr != nil {
+			log.Debug("Failed to read the response of the blob", digest+":", readErr.Error())
+		}
+		return nil, errorutils.CheckErrorf("failed to download the blob %s of %s: %s %s", digest, rc.reference, resp.Status, string(body))
+	}
+	return reader, nil
+}
+
+func digestToArtifactName(digest string) string {
+	return strings.Replace(digest, ":", "__", 1)
+}
+
+func newEmptyConfig() Descriptor {
+	return Descriptor{MediaType: emptyConfigMediaType, Digest: emptyConfigDigest, Size: int64(len(emptyConfigContent))}
+}
+
+func formatDigest(sha256 string) string {
+	return fmt.Sprintf("sha256:%s", sha256)
+}
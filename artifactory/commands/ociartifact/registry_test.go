@@ -0,0 +1,38 @@
+package ociartifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	reference, err := ParseReference("oci-local/sboms/app:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, &Reference{Repo: "oci-local", Name: "sboms/app", Tag: "1.0"}, reference)
+
+	reference, err = ParseReference("oci-local/wasm/filter")
+	require.NoError(t, err)
+	assert.Equal(t, "oci-local/wasm/filter:latest", reference.String())
+
+	reference, err = ParseReference("oci-local/charts/app@sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a")
+	require.NoError(t, err)
+	assert.Equal(t, "charts/app", reference.Name)
+	assert.Equal(t, "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", reference.manifestReference())
+
+	_, err = ParseReference("app:1.0")
+	assert.ErrorContains(t, err, "must be in the form of <repo>/<name>:<tag>")
+	_, err = ParseReference("oci-local/app:")
+	assert.ErrorContains(t, err, "must be in the form of <repo>/<name>:<tag>")
+	_, err = ParseReference("oci-local/app@md5:abc")
+	assert.ErrorContains(t, err, "must be a sha256 digest")
+}
+
+func TestResolveLocation(t *testing.T) {
+	location, err := resolveLocation("https://acme.jfrog.io/artifactory/api/docker/oci-local/v2/app/blobs/uploads/", "/artifactory/api/docker/oci-local/v2/app/blobs/uploads/1234?state=a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/docker/oci-local/v2/app/blobs/uploads/1234?state=a", location.String())
+	_, err = resolveLocation("https://acme.jfrog.io/artifactory/api/docker/oci-local/v2/app/blobs/uploads/", "")
+	assert.ErrorContains(t, err, "doesn't include the location of the upload")
+}
@@ -0,0 +1,83 @@
+package ocicontainer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// RegistryAuthFileEnv overrides the containers-auth.json file, which Podman and Buildah read the registries credentials from.
+const RegistryAuthFileEnv = "REGISTRY_AUTH_FILE"
+
+// saveAuthFileCredentials logs in to the registry by saving its credentials in the auth file of Podman and Buildah, rather
+// than by running their login command. Unlike the login command, this doesn't depend on the keyring or on the session of
+// the user, which rootless containers, such as the pods of OpenShift, usually don't have.
+// The registry may include a path, in which case the credentials are used for the images under the path only.
+func saveAuthFileCredentials(registry, username, password string) error {
+	authFile := getAuthFilePath(os.Getenv, os.Getuid(), runtime.GOOS)
+	log.Debug(fmt.Sprintf("Saving the credentials of %s in %s", registry, authFile))
+	return writeAuthFileCredentials(authFile, registry, username, password)
+}
+
+// getAuthFilePath returns the auth file of Podman and Buildah, as resolved by them, according to containers-auth.json(5).
+// The runtime directory is used on Linux, so that rootless users, whose config directory may be read-only, are supported.
+func getAuthFilePath(getenv func(string) string, uid int, goos string) string {
+	if authFile := getenv(RegistryAuthFileEnv); authFile != "" {
+		return authFile
+	}
+	if goos != "linux" {
+		return filepath.Join(getHomeDir(getenv), ".config", "containers", "auth.json")
+	}
+	if runtimeDir := getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "containers", "auth.json")
+	}
+	return fmt.Sprintf("/run/containers/%d/auth.json", uid)
+}
+
+func getHomeDir(getenv func(string) string) string {
+	if home := getenv("HOME"); home != "" {
+		return home
+	}
+	return getenv("USERPROFILE")
+}
+
+// writeAuthFileCredentials sets the credentials of the registry in the auth file, and keeps the rest of its content.
+func writeAuthFileCredentials(authFile, registry, username, password string) error {
+	content := map[string]json.RawMessage{}
+	existing, err := os.ReadFile(authFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errorutils.CheckError(err)
+	}
+	if len(existing) > 0 {
+		if err = json.Unmarshal(existing, &content); err != nil {
+			return errorutils.CheckErrorf("failed to parse the auth file %s: %s", authFile, err.Error())
+		}
+	}
+	auths := map[string]json.RawMessage{}
+	if content["auths"] != nil {
+		if err = json.Unmarshal(content["auths"], &auths); err != nil {
+			return errorutils.CheckErrorf("failed to parse the auths of the auth file %s: %s", authFile, err.Error())
+		}
+	}
+	if auths[registry], err = json.Marshal(map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(username + ":" + password))}); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if content["auths"], err = json.Marshal(auths); err != nil {
+		return errorutils.CheckError(err)
+	}
+	updated, err := json.MarshalIndent(content, "", "\t")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(authFile), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(authFile, updated, 0600))
+}
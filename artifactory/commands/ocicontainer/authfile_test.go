@@ -0,0 +1,69 @@
+package ocicontainer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAuthFilePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		uid      int
+		goos     string
+		expected string
+	}{
+		{"env", map[string]string{RegistryAuthFileEnv: "/tmp/auth.json", "XDG_RUNTIME_DIR": "/run/user/1000"}, 1000, "linux", "/tmp/auth.json"},
+		{"rootless", map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}, 1000, "linux", "/run/user/1000/containers/auth.json"},
+		{"noRuntimeDir", map[string]string{}, 1000, "linux", "/run/containers/1000/auth.json"},
+		{"root", map[string]string{}, 0, "linux", "/run/containers/0/auth.json"},
+		{"darwin", map[string]string{"HOME": "/Users/frog"}, 501, "darwin", filepath.Join("/Users/frog", ".config", "containers", "auth.json")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getenv := func(key string) string { return test.env[key] }
+			assert.Equal(t, test.expected, getAuthFilePath(getenv, test.uid, test.goos))
+		})
+	}
+}
+
+func TestWriteAuthFileCredentials(t *testing.T) {
+	authFile := filepath.Join(t.TempDir(), "containers", "auth.json")
+	require.NoError(t, writeAuthFileCredentials(authFile, "acme.jfrog.io", "frog", "token"))
+
+	// The credentials of the other registries and the other fields are kept.
+	existing := `{"auths":{"quay.io":{"auth":"cXVheTpwYXNz"},"acme.jfrog.io":{"auth":"b2xkOm9sZA=="}},"credHelpers":{"gcr.io":"gcloud"}}`
+	require.NoError(t, os.WriteFile(authFile, []byte(existing), 0600))
+	require.NoError(t, writeAuthFileCredentials(authFile, "acme.jfrog.io/docker-local", "frog", "token"))
+
+	content, err := os.ReadFile(authFile)
+	require.NoError(t, err)
+	var authConfig struct {
+		Auths       map[string]map[string]string `json:"auths"`
+		CredHelpers map[string]string            `json:"credHelpers"`
+	}
+	require.NoError(t, json.Unmarshal(content, &authConfig))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("frog:token")), authConfig.Auths["acme.jfrog.io/docker-local"]["auth"])
+	assert.Equal(t, "b2xkOm9sZA==", authConfig.Auths["acme.jfrog.io"]["auth"])
+	assert.Equal(t, "cXVheTpwYXNz", authConfig.Auths["quay.io"]["auth"])
+	assert.Equal(t, "gcloud", authConfig.CredHelpers["gcr.io"])
+
+	info, err := os.Stat(authFile)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+}
+
+func TestWriteAuthFileCredentialsInvalidFile(t *testing.T) {
+	authFile := filepath.Join(t.TempDir(), "auth.json")
+	require.NoError(t, os.WriteFile(authFile, []byte("{"), 0600))
+	assert.ErrorContains(t, writeAuthFileCredentials(authFile, "acme.jfrog.io", "frog", "token"), "failed to parse the auth file")
+}
@@ -480,6 +480,21 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 	return buildInfo, nil
 }
 
+// Search for the images of all the platforms of a fat-manifest in Artifactory.
+func (builder *buildInfoBuilder) handleFatManifestImage(results map[string]*utils.ResultItem) (map[string][]*utils.ResultItem, *utils.ResultItem, *FatManifest, error) {
+	if fatManifestResult, ok := results["list.manifest.json"]; ok {
+		log.Debug("Found list.manifest.json. Proceeding to create build-info.")
+		fatManifestRootPath := getFatManifestRoot(fatManifestResult.GetItemRelativeLocation()) + "/*"
+		fatManifest, err := getFatManifest(results, builder.serviceManager, builder.repositoryDetails.key)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		multiPlatformImages, err := performMultiPlatformImageSearch(fatManifestRootPath, builder.serviceManager)
+		return multiPlatformImages, fatManifestResult, fatManifest, err
+	}
+	return nil, nil, nil, errorutils.CheckErrorf(`couldn't find image "%s" fat manifest in Artifactory`, builder.image.name)
+}
+
 // Create the image's build info from list.manifest.json.
 func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatManifest, searchResultFatManifest *utils.ResultItem, candidateImages map[string][]*utils.ResultItem, baseModuleId string) (*buildinfo.BuildInfo, error) {
 	imageProperties := map[string]string{
@@ -508,6 +523,9 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 	// Create all image arch modules
 	for _, manifest := range fatManifest.Manifests {
 		image := candidateImages[manifest.Digest]
+		if len(image) == 0 {
+			log.Warn(fmt.Sprintf("The manifest %s of the '%s' image wasn't found in Artifactory. Its layers aren't recorded in the build-info.", manifest.Digest, builder.image.Name()))
+		}
 		var artifacts []buildinfo.Artifact
 		for _, layer := range image {
 			builder.imageLayers = append(builder.imageLayers, *layer)
@@ -58,10 +58,23 @@ type ContainerManagerType int
 const (
 	DockerClient ContainerManagerType = iota
 	Podman
+	Buildah
 )
 
 func (cmt ContainerManagerType) String() string {
-	return [...]string{"docker", "podman"}[cmt]
+	return [...]string{"docker", "podman", "buildah"}[cmt]
+}
+
+// UsesAuthFile returns true if the container manager reads the registries credentials from a containers-auth.json file,
+// rather than from the Docker config.
+func (cmt ContainerManagerType) UsesAuthFile() bool {
+	return cmt == Podman || cmt == Buildah
+}
+
+// SupportsDigestFile returns true if the push command of the container manager can write the digest of the pushed manifest
+// to a file, with the --digestfile option.
+func (cmt ContainerManagerType) SupportsDigestFile() bool {
+	return cmt == Podman || cmt == Buildah
 }
 
 // Container image
@@ -109,7 +122,15 @@ func (containerManager *containerManager) Id(image *Image, commandType CommandTy
 		// WithFileBufferedOpener spools the `docker save` stream to a temp
 		// file rather than buffering it entirely in memory, which avoids the
 		// OOM on large images without dropping the verification step.
-		localImage, err := daemon.Image(ref, daemon.WithFileBufferedOpener())
+		options := []daemon.Option{daemon.WithFileBufferedOpener()}
+		dockerClient, err := newRootlessDockerClient()
+		if err != nil {
+			return "", err
+		}
+		if dockerClient != nil {
+			options = append(options, daemon.WithClient(dockerClient))
+		}
+		localImage, err := daemon.Image(ref, options...)
 		if err != nil {
 			return "", err
 		}
@@ -125,7 +146,12 @@ func (containerManager *containerManager) Id(image *Image, commandType CommandTy
 	if err != nil {
 		return "", err
 	}
-	return strings.Split(content, "\n")[0], nil
+	id := strings.Split(content, "\n")[0]
+	// Buildah may print the ID without the algorithm.
+	if !strings.Contains(id, ":") {
+		id = "sha256:" + id
+	}
+	return id, nil
 }
 
 // Return the OS and architecture on which the image runs e.g. (linux, amd64, nil).
@@ -199,6 +225,9 @@ type getImageSystemCompatibilityCmd struct {
 }
 
 func (getImageSystemCompatibilityCmd *getImageSystemCompatibilityCmd) GetCmd() *exec.Cmd {
+	if getImageSystemCompatibilityCmd.containerManager == Buildah {
+		return exec.Command(Buildah.String(), "inspect", "--type", "image", "--format", "{{ .OCIv1.OS}},{{ .OCIv1.Architecture}}", getImageSystemCompatibilityCmd.image.name)
+	}
 	var cmd []string
 	cmd = append(cmd, "image")
 	cmd = append(cmd, "inspect")
@@ -255,6 +284,9 @@ func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginCo
 		}
 		password = config.ServerDetails.AccessToken
 	}
+	if containerManager.UsesAuthFile() {
+		return saveAuthFileCredentials(imageRegistry, username, password)
+	}
 	// Perform login.
 	cmd := &LoginCmd{DockerRegistry: imageRegistry, Username: username, Password: password, containerManager: containerManager}
 	err := cmd.RunCmd()
@@ -270,7 +302,7 @@ func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginCo
 		}
 		return errorutils.CheckErrorf(LoginFailureMessage, containerManager.String(), imageRegistry, containerManager.String())
 	}
-	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ServerDetails.User, Password: config.ServerDetails.Password}
+	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: username, Password: password, containerManager: containerManager}
 	err = cmd.RunCmd()
 	if err != nil {
 		if printConsoleError {
@@ -133,3 +133,11 @@ func TestDockerClientId_PushWithNonBoolSkipEnv_GoesToDaemonPath(t *testing.T) {
 	_, err := cm.Id(NewImage("INVALID NAME WITH SPACE"), Push)
 	require.Error(t, err, "strconv.ParseBool returns an error for non-bool values; must fall through to daemon path")
 }
+
+func TestContainerManagerTypeString(t *testing.T) {
+	assert.Equal(t, "docker", DockerClient.String())
+	assert.Equal(t, "podman", Podman.String())
+	assert.Equal(t, "buildah", Buildah.String())
+	assert.False(t, DockerClient.UsesAuthFile())
+	assert.True(t, Buildah.SupportsDigestFile())
+}
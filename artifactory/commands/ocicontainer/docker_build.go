@@ -26,6 +26,8 @@ type DockerBuildInfoBuilder struct {
 	baseImages     []DockerImage
 	isImagePushed  bool
 	cmdArgs        []string
+	imageDigest    string
+	labels         map[string]string
 }
 
 type DockerRepositoryDetails struct {
@@ -69,6 +71,18 @@ func NewDockerBuildInfoBuilder(buildName, buildNumber, project string, module st
 	}
 }
 
+// SetImageDigest sets the digest of the pushed image, as reported by BuildKit.
+func (dbib *DockerBuildInfoBuilder) SetImageDigest(imageDigest string) *DockerBuildInfoBuilder {
+	dbib.imageDigest = imageDigest
+	return dbib
+}
+
+// SetLabels sets the labels of the image, which are recorded as properties of the module.
+func (dbib *DockerBuildInfoBuilder) SetLabels(labels map[string]string) *DockerBuildInfoBuilder {
+	dbib.labels = labels
+	return dbib
+}
+
 // Build orchestrates the collection of dependencies and artifacts for the docker build
 func (dbib *DockerBuildInfoBuilder) Build() error {
 	log.Debug(fmt.Sprintf("Starting docker build-info collection for %s/%s", dbib.buildName, dbib.buildNumber))
@@ -148,5 +162,11 @@ func (dbib *DockerBuildInfoBuilder) getBiProperties(leadSha string) map[string]s
 	if dbib.cmdArgs != nil {
 		properties["docker.build.command"] = strings.Join(dbib.cmdArgs, " ")
 	}
+	if dbib.imageDigest != "" {
+		properties["docker.image.digest"] = dbib.imageDigest
+	}
+	for key, value := range dbib.labels {
+		properties["docker.label."+key] = value
+	}
 	return properties
 }
@@ -64,9 +64,17 @@ func (labib *localAgentBuildInfoBuilder) Build(module string) (*buildinfo.BuildI
 		log.Warn("Failed to collect build-info. No layer(s) was found for image:'" + labib.buildInfoBuilder.image.name + "'. Hint, try to delete the image from the local cache and rerun the command")
 		log.Debug(err.Error())
 		return nil, nil
-	} else {
-		log.Debug("Found manifest.json with the following layers to create build-info:", candidateLayers)
 	}
+	// A multi-platform image is pushed with a fat-manifest, which references the manifests of its platforms.
+	if manifest == nil {
+		log.Debug("Found list.manifest.json of a multi-platform image. Collecting the manifests and layers of all its platforms.")
+		multiPlatformImages, fatManifestDetails, fatManifest, err := labib.buildInfoBuilder.handleFatManifestImage(candidateLayers)
+		if err != nil {
+			return nil, err
+		}
+		return labib.buildInfoBuilder.createMultiPlatformBuildInfo(fatManifest, fatManifestDetails, multiPlatformImages, module)
+	}
+	log.Debug("Found manifest.json with the following layers to create build-info:", candidateLayers)
 	// Create build-info from search results.
 	return labib.buildInfoBuilder.createBuildInfo(labib.commandType, manifest, candidateLayers, module)
 }
@@ -122,6 +130,12 @@ func (labib *localAgentBuildInfoBuilder) searchImage() (map[string]*utils.Result
 		if manifest != nil && labib.resolveAndVerifyManifest(manifest) {
 			return resultMap, manifest, nil
 		}
+		if fatManifest, ok := resultMap["list.manifest.json"]; manifest == nil && ok && labib.commandType == Push {
+			if labib.buildInfoBuilder.imageSha2 != "" && labib.buildInfoBuilder.imageSha2 != "sha256:"+fatManifest.Sha256 {
+				log.Debug(`The pushed list.manifest.json digest "sha256:` + fatManifest.Sha256 + `" differs from the local image id "` + labib.buildInfoBuilder.imageSha2 + `"`)
+			}
+			return resultMap, nil, nil
+		}
 	}
 	return nil, nil, errorutils.CheckErrorf(imageNotFoundErrorMessage, labib.buildInfoBuilder.image.name)
 }
@@ -43,7 +43,7 @@ func (rabib *RemoteAgentBuildInfoBuilder) Build(module string) (*buildinfo.Build
 		return rabib.buildInfoBuilder.createBuildInfo(Push, manifest, searchResults, module)
 	}
 	// Create build-info based on image fat-manifest.
-	multiPlatformImages, fatManifestDetails, fatManifest, err := rabib.handleFatManifestImage(results)
+	multiPlatformImages, fatManifestDetails, fatManifest, err := rabib.buildInfoBuilder.handleFatManifestImage(results)
 	if err != nil {
 		return nil, err
 	}
@@ -68,20 +68,6 @@ func (rabib *RemoteAgentBuildInfoBuilder) handleManifest(resultMap map[string]*u
 	return nil, nil, errorutils.CheckErrorf(`couldn't find image "%s" manifest in Artifactory`, rabib.buildInfoBuilder.image.name)
 }
 
-func (rabib *RemoteAgentBuildInfoBuilder) handleFatManifestImage(results map[string]*utils.ResultItem) (map[string][]*utils.ResultItem, *utils.ResultItem, *FatManifest, error) {
-	if fatManifestResult, ok := results["list.manifest.json"]; ok {
-		log.Debug("Found list.manifest.json. Proceeding to create build-info.")
-		fatManifestRootPath := getFatManifestRoot(fatManifestResult.GetItemRelativeLocation()) + "/*"
-		fatManifest, err := getFatManifest(results, rabib.buildInfoBuilder.serviceManager, rabib.buildInfoBuilder.repositoryDetails.key)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		multiPlatformImages, err := performMultiPlatformImageSearch(fatManifestRootPath, rabib.buildInfoBuilder.serviceManager)
-		return multiPlatformImages, fatManifestResult, fatManifest, err
-	}
-	return nil, nil, nil, errorutils.CheckErrorf(`couldn't find image "%s" fat manifest in Artifactory`, rabib.buildInfoBuilder.image.name)
-}
-
 // Search image manifest or fat-manifest of and image.
 func (rabib *RemoteAgentBuildInfoBuilder) searchImage() (resultMap map[string]*utils.ResultItem, err error) {
 	longImageName, err := rabib.buildInfoBuilder.image.GetImageLongNameWithTag()
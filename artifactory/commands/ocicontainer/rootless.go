@@ -0,0 +1,56 @@
+package ocicontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/moby/moby/client"
+)
+
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// newRootlessDockerClient returns a client of the Docker API socket of a rootless Docker or Podman service, or nil if the
+// default socket should be used.
+// Unlike the Docker client, which finds the socket of rootless Docker by its context, the Docker API client used for reading
+// the local images only uses DOCKER_HOST, and fails when it's not set and the default socket doesn't exist.
+func newRootlessDockerClient() (*client.Client, error) {
+	host := findRootlessDockerHost(os.Getenv, os.Getuid(), socketExists)
+	if host == "" {
+		return nil, nil
+	}
+	log.Debug("Using the Docker API socket " + host)
+	dockerClient, err := client.New(client.WithHost(host))
+	return dockerClient, errorutils.CheckError(err)
+}
+
+// findRootlessDockerHost returns the address of the socket of rootless Docker, or of Podman's Docker-compatible service,
+// when neither DOCKER_HOST nor a Docker context is set and the default socket doesn't exist.
+func findRootlessDockerHost(getenv func(string) string, uid int, exists func(string) bool) string {
+	if getenv("DOCKER_HOST") != "" || getenv("DOCKER_CONTEXT") != "" || exists(defaultDockerSocket) {
+		return ""
+	}
+	runtimeDir := getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", uid)
+	}
+	candidates := []string{
+		filepath.Join(runtimeDir, "docker.sock"),
+		filepath.Join(runtimeDir, "podman", "podman.sock"),
+		"/run/podman/podman.sock",
+	}
+	for _, socket := range candidates {
+		if exists(socket) {
+			return "unix://" + socket
+		}
+	}
+	return ""
+}
+
+func socketExists(path string) bool {
+	exists, err := fileutils.IsFileExists(path, false)
+	return err == nil && exists
+}
@@ -0,0 +1,39 @@
+package ocicontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRootlessDockerHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		sockets  []string
+		expected string
+	}{
+		{"defaultSocket", map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}, []string{defaultDockerSocket, "/run/user/1000/docker.sock"}, ""},
+		{"dockerHost", map[string]string{"DOCKER_HOST": "tcp://localhost:2375"}, []string{"/run/user/1000/docker.sock"}, ""},
+		{"dockerContext", map[string]string{"DOCKER_CONTEXT": "rootless"}, []string{"/run/user/1000/docker.sock"}, ""},
+		{"rootlessDocker", map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}, []string{"/run/user/1000/docker.sock", "/run/user/1000/podman/podman.sock"}, "unix:///run/user/1000/docker.sock"},
+		{"rootlessPodman", map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}, []string{"/run/user/1000/podman/podman.sock"}, "unix:///run/user/1000/podman/podman.sock"},
+		{"noRuntimeDir", map[string]string{}, []string{"/run/user/1000/podman/podman.sock"}, "unix:///run/user/1000/podman/podman.sock"},
+		{"rootfulPodman", map[string]string{}, []string{"/run/podman/podman.sock"}, "unix:///run/podman/podman.sock"},
+		{"none", map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}, nil, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getenv := func(key string) string { return test.env[key] }
+			exists := func(path string) bool {
+				for _, socket := range test.sockets {
+					if socket == path {
+						return true
+					}
+				}
+				return false
+			}
+			assert.Equal(t, test.expected, findRootlessDockerHost(getenv, 1000, exists))
+		})
+	}
+}
@@ -0,0 +1,519 @@
+package onboarding
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	RegistryArtifactory = "artifactory"
+	RegistryPublic      = "public"
+	RegistryOther       = "other"
+)
+
+var (
+	// The directories of dependencies and build outputs, which aren't part of the project's sources.
+	skippedDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true, "target": true, "build": true, "dist": true, ".gradle": true, ".venv": true, "venv": true, "bin": true, "obj": true, ".terraform": true}
+
+	// The hosts of the public registries, which are proxied by remote repositories once the project is onboarded.
+	publicRegistryHosts = map[string]bool{
+		"registry.npmjs.org": true, "registry.yarnpkg.com": true, "repo.maven.apache.org": true, "repo1.maven.org": true,
+		"jcenter.bintray.com": true, "plugins.gradle.org": true, "pypi.org": true, "pypi.python.org": true, "files.pythonhosted.org": true,
+		"proxy.golang.org": true, "api.nuget.org": true, "docker.io": true, "registry-1.docker.io": true, "index.docker.io": true,
+		"rubygems.org": true, "registry.terraform.io": true, "center2.conan.io": true, "cdn.cocoapods.org": true,
+	}
+	// The hosts of URLs which are common in build descriptors, but aren't registries, such as XML namespaces and project links.
+	ignoredHosts = map[string]bool{"www.w3.org": true, "maven.apache.org": true, "www.apache.org": true, "github.com": true, "gitlab.com": true, "json.schemastore.org": true, "opensource.org": true}
+
+	urlRegex = regexp.MustCompile(`https?://[A-Za-z0-9.\-]+(?::\d+)?(?:/[^\s"'<>()\[\]{},;` + "`" + `]*)?`)
+	// The registry of a Docker base image, such as FROM my.registry.io/library/alpine:3.
+	dockerFromRegex = regexp.MustCompile(`(?i)^\s*FROM\s+(?:--platform=\S+\s+)?([A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)+(?::\d+)?)/`)
+	// The repository of an Artifactory URL, such as https://acme.jfrog.io/artifactory/api/npm/npm-virtual/.
+	artifactoryApiRepoRegex = regexp.MustCompile(`/api/[a-z]+/([^/?#]+)`)
+	artifactoryRepoRegex    = regexp.MustCompile(`/artifactory/([^/?#]+)`)
+	// Native commands which the CI pipelines run, and the JFrog CLI commands which replace them.
+	nativeCommandRegex = regexp.MustCompile(`(^|[\s;&|('"])((?:\./)?(?:npm|pnpm|yarn|mvnw?|gradlew?|go|pip3?|pipenv|poetry|twine|dotnet|nuget|docker|helm))\s+(\S+)`)
+)
+
+// The JFrog CLI command, by the native command, and the sub-commands it replaces. An empty list of sub-commands replaces all the sub-commands.
+var nativeCommands = map[string]struct {
+	jfCommand   string
+	subCommands []string
+}{
+	"npm":     {"npm", []string{"install", "i", "ci", "publish"}},
+	"pnpm":    {"pnpm", []string{"install", "i", "publish"}},
+	"yarn":    {"yarn", []string{"install", "npm"}},
+	"mvn":     {"mvn", nil},
+	"mvnw":    {"mvn", nil},
+	"gradle":  {"gradle", nil},
+	"gradlew": {"gradle", nil},
+	"go":      {"go", []string{"build", "get", "install", "mod"}},
+	"pip":     {"pip", []string{"install"}},
+	"pip3":    {"pip", []string{"install"}},
+	"pipenv":  {"pipenv", []string{"install"}},
+	"poetry":  {"poetry", []string{"install", "publish"}},
+	"twine":   {"twine", []string{"upload"}},
+	"dotnet":  {"dotnet", []string{"restore", "build", "nuget"}},
+	"nuget":   {"nuget", []string{"restore", "push"}},
+	"docker":  {"docker", []string{"build", "pull", "push"}},
+	"helm":    {"helm", []string{"package", "push", "dependency"}},
+}
+
+// DetectedBuildTool is a build tool of the project, and the directories it's detected in.
+type DetectedBuildTool struct {
+	Tool string `json:"tool"`
+	// The directories of the build descriptors, relative to the project.
+	Dirs        []string `json:"dirs"`
+	Descriptors []string `json:"descriptors"`
+	// The existing publish configuration, such as the distributionManagement of a pom.xml.
+	PublishConfig []string `json:"publishConfig,omitempty"`
+	// The JFrog CLI config file generated for the tool.
+	ConfigFile string `json:"configFile,omitempty"`
+	// Whether the project uses the Gradle or Maven wrapper.
+	wrapper bool
+}
+
+// HardcodedRegistry is a registry URL which is hardcoded in a build descriptor, a registry configuration or a CI pipeline.
+type HardcodedRegistry struct {
+	Url  string `json:"url"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// The build tool of the file, if it's a build descriptor or a registry configuration.
+	Tool string `json:"tool,omitempty"`
+	// Acceptable values are: artifactory, public and other.
+	Kind string `json:"kind"`
+	// The repository, for Artifactory URLs.
+	Repo string `json:"repo,omitempty"`
+}
+
+// CiCommand is a native build tool command of a CI pipeline, and the JFrog CLI command which replaces it.
+type CiCommand struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Command   string `json:"command"`
+	Suggested string `json:"suggested"`
+}
+
+// SuggestedCommand is a JFrog CLI command to run in a directory of the project.
+type SuggestedCommand struct {
+	Tool        string `json:"tool"`
+	Dir         string `json:"dir"`
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// OnboardingReport is the migration report of a project.
+type OnboardingReport struct {
+	Path       string              `json:"path"`
+	BuildTools []DetectedBuildTool `json:"buildTools"`
+	Registries []HardcodedRegistry `json:"registries"`
+	CiCommands []CiCommand         `json:"ciCommands"`
+	Commands   []SuggestedCommand  `json:"commands"`
+}
+
+// OnboardingAnalyzeCommand inspects the working tree of a project, and produces a report of its build tools, their existing
+// publish configurations, the registries hardcoded in the project and its CI pipelines, and the native commands of the pipelines.
+// It suggests the JFrog CLI commands which replace them, and generates the JFrog CLI config files of the build tools.
+// The analysis is local, so no server is contacted.
+type OnboardingAnalyzeCommand struct {
+	path       string
+	outputDir  string
+	serverId   string
+	buildName  string
+	report     *OnboardingReport
+	buildTools map[project.ProjectType]*DetectedBuildTool
+}
+
+func NewOnboardingAnalyzeCommand() *OnboardingAnalyzeCommand {
+	return &OnboardingAnalyzeCommand{}
+}
+
+func (oac *OnboardingAnalyzeCommand) SetPath(path string) *OnboardingAnalyzeCommand {
+	oac.path = path
+	return oac
+}
+
+// SetOutputDir sets the directory which the config files are generated in, under .jfrog/projects. The config files aren't generated if not set.
+func (oac *OnboardingAnalyzeCommand) SetOutputDir(outputDir string) *OnboardingAnalyzeCommand {
+	oac.outputDir = outputDir
+	return oac
+}
+
+// SetServerId sets the server of the generated config files and suggested commands. The default server is used if not set.
+func (oac *OnboardingAnalyzeCommand) SetServerId(serverId string) *OnboardingAnalyzeCommand {
+	oac.serverId = serverId
+	return oac
+}
+
+// SetBuildName sets the build name of the suggested commands. The name of the project's directory is used if not set.
+func (oac *OnboardingAnalyzeCommand) SetBuildName(buildName string) *OnboardingAnalyzeCommand {
+	oac.buildName = buildName
+	return oac
+}
+
+func (oac *OnboardingAnalyzeCommand) Report() *OnboardingReport {
+	return oac.report
+}
+
+func (oac *OnboardingAnalyzeCommand) CommandName() string {
+	return "rt_onboarding_analyze"
+}
+
+func (oac *OnboardingAnalyzeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (oac *OnboardingAnalyzeCommand) Run() error {
+	info, err := os.Stat(oac.path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !info.IsDir() {
+		return errorutils.CheckErrorf("%s is not a directory", oac.path)
+	}
+	if oac.buildName == "" {
+		absPath, err := filepath.Abs(oac.path)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		oac.buildName = filepath.Base(absPath)
+	}
+	if oac.serverId == "" {
+		if serverDetails, err := config.GetDefaultServerConf(); err == nil && serverDetails != nil {
+			oac.serverId = serverDetails.ServerId
+		}
+	}
+	if oac.serverId == "" {
+		if oac.outputDir != "" {
+			return errorutils.CheckErrorf("the config files can't be generated without a server. Configure a default server using 'jf config add', or specify --server-id")
+		}
+		oac.serverId = "<server-id>"
+	}
+	oac.report = &OnboardingReport{Path: oac.path, BuildTools: []DetectedBuildTool{}, Registries: []HardcodedRegistry{}, CiCommands: []CiCommand{}, Commands: []SuggestedCommand{}}
+	oac.buildTools = make(map[project.ProjectType]*DetectedBuildTool)
+	log.Info("Analyzing", oac.path+"...")
+	if err = filepath.WalkDir(oac.path, oac.analyzeFile); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, buildTool := range oac.sortedBuildTools() {
+		if err = oac.generateConfigFile(buildTool); err != nil {
+			return err
+		}
+		oac.report.Commands = append(oac.report.Commands, oac.suggestCommands(buildTool)...)
+		oac.report.BuildTools = append(oac.report.BuildTools, *buildTool.detected)
+	}
+	if len(oac.report.Commands) > 0 {
+		oac.report.Commands = append(oac.report.Commands, SuggestedCommand{Dir: ".", Command: "jf rt build-publish " + oac.buildName + " $BUILD_NUMBER", Description: "Publish the build-info collected by the commands."})
+	}
+	log.Info(fmt.Sprintf("Detected %d build tools, %d hardcoded registries and %d native CI commands.", len(oac.report.BuildTools), len(oac.report.Registries), len(oac.report.CiCommands)))
+	return nil
+}
+
+func (oac *OnboardingAnalyzeCommand) analyzeFile(filePath string, entry fs.DirEntry, err error) error {
+	if err != nil {
+		return err
+	}
+	relativePath, err := filepath.Rel(oac.path, filePath)
+	if err != nil {
+		return err
+	}
+	relativePath = filepath.ToSlash(relativePath)
+	if entry.IsDir() {
+		if relativePath != "." && skippedDirs[entry.Name()] {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if isCiFile(relativePath) {
+		return oac.analyzeCiFile(filePath, relativePath)
+	}
+	tool, isDescriptor, ok := classifyFile(filePath, entry.Name())
+	if !ok {
+		return nil
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if isDescriptor {
+		oac.addDescriptor(tool, relativePath, content)
+	}
+	oac.addRegistries(tool.String(), tool == project.Docker, relativePath, content)
+	return nil
+}
+
+// classifyFile returns the build tool of a build descriptor or a registry configuration file.
+// Lockfiles aren't considered, since they record the registry of each of the dependencies.
+func classifyFile(filePath, name string) (tool project.ProjectType, isDescriptor, ok bool) {
+	lowerName := strings.ToLower(name)
+	switch {
+	case name == "package.json":
+		return npmProjectType(filepath.Dir(filePath)), true, true
+	case name == ".npmrc":
+		return npmProjectType(filepath.Dir(filePath)), false, true
+	case name == ".yarnrc.yml" || name == ".yarnrc":
+		return project.Yarn, false, true
+	case name == "pom.xml":
+		return project.Maven, true, true
+	case name == "settings.xml" || name == "extensions.xml":
+		return project.Maven, false, true
+	case name == "build.gradle" || name == "build.gradle.kts":
+		return project.Gradle, true, true
+	case name == "settings.gradle" || name == "settings.gradle.kts" || name == "gradle.properties" || name == "init.gradle":
+		return project.Gradle, false, true
+	case name == "go.mod":
+		return project.Go, true, true
+	case name == "requirements.txt" || name == "setup.py" || name == "setup.cfg":
+		return project.Pip, true, true
+	case name == "pyproject.toml":
+		return pyprojectType(filePath), true, true
+	case name == "Pipfile":
+		return project.Pipenv, true, true
+	case name == "pip.conf" || name == "pip.ini" || name == ".pypirc":
+		return project.Pip, false, true
+	case strings.HasSuffix(lowerName, ".csproj") || strings.HasSuffix(lowerName, ".fsproj") || strings.HasSuffix(lowerName, ".vbproj"):
+		return project.Dotnet, true, true
+	case name == "packages.config":
+		return project.Nuget, true, true
+	case lowerName == "nuget.config":
+		return project.Dotnet, false, true
+	case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile.") || strings.HasSuffix(name, ".Dockerfile") || name == "Containerfile":
+		return project.Docker, true, true
+	case name == "Chart.yaml":
+		return project.Helm, true, true
+	case strings.HasSuffix(name, ".tf"):
+		return project.Terraform, true, true
+	case name == "Gemfile":
+		return project.Ruby, true, true
+	case name == "conanfile.txt" || name == "conanfile.py":
+		return project.Conan, true, true
+	case name == "Podfile":
+		return project.Cocoapods, true, true
+	case name == "Package.swift":
+		return project.Swift, true, true
+	}
+	return 0, false, false
+}
+
+// npmProjectType returns the package manager of the npm project in the directory, by its lockfile.
+func npmProjectType(dir string) project.ProjectType {
+	switch {
+	case fileExists(filepath.Join(dir, "pnpm-lock.yaml")):
+		return project.Pnpm
+	case fileExists(filepath.Join(dir, "yarn.lock")):
+		return project.Yarn
+	}
+	return project.Npm
+}
+
+// pyprojectType returns the package manager of a pyproject.toml.
+func pyprojectType(filePath string) project.ProjectType {
+	if fileExists(filepath.Join(filepath.Dir(filePath), "uv.lock")) {
+		return project.UV
+	}
+	content, err := os.ReadFile(filePath)
+	if err == nil && bytes.Contains(content, []byte("[tool.poetry")) {
+		return project.Poetry
+	}
+	return project.Pip
+}
+
+func isCiFile(relativePath string) bool {
+	switch relativePath {
+	case ".gitlab-ci.yml", "Jenkinsfile", "azure-pipelines.yml", "bitbucket-pipelines.yml", ".circleci/config.yml", ".travis.yml":
+		return true
+	}
+	dir, name := path.Split(relativePath)
+	return dir == ".github/workflows/" && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml"))
+}
+
+func (oac *OnboardingAnalyzeCommand) addDescriptor(tool project.ProjectType, relativePath string, content []byte) {
+	buildTool, exists := oac.buildTools[tool]
+	if !exists {
+		buildTool = &DetectedBuildTool{Tool: tool.String()}
+		oac.buildTools[tool] = buildTool
+	}
+	buildTool.Descriptors = append(buildTool.Descriptors, relativePath)
+	dir := path.Dir(relativePath)
+	if len(buildTool.Dirs) == 0 || buildTool.Dirs[len(buildTool.Dirs)-1] != dir {
+		buildTool.Dirs = append(buildTool.Dirs, dir)
+	}
+	if publishConfig := detectPublishConfig(tool, content); publishConfig != "" {
+		buildTool.PublishConfig = append(buildTool.PublishConfig, relativePath+": "+publishConfig)
+	}
+	if (tool == project.Gradle && fileExists(filepath.Join(oac.path, dir, "gradlew"))) || (tool == project.Maven && fileExists(filepath.Join(oac.path, dir, "mvnw"))) {
+		buildTool.wrapper = true
+	}
+}
+
+// detectPublishConfig returns the description of the publish configuration of a build descriptor, if it has one.
+func detectPublishConfig(tool project.ProjectType, content []byte) string {
+	switch tool {
+	case project.Npm, project.Pnpm, project.Yarn:
+		var packageJson struct {
+			PublishConfig map[string]any `json:"publishConfig"`
+			Private       bool           `json:"private"`
+		}
+		if json.Unmarshal(content, &packageJson) == nil && packageJson.PublishConfig != nil {
+			if registry, ok := packageJson.PublishConfig["registry"].(string); ok {
+				return "publishConfig.registry " + registry
+			}
+			return "publishConfig"
+		}
+	case project.Maven:
+		if bytes.Contains(content, []byte("<distributionManagement>")) {
+			return "distributionManagement"
+		}
+	case project.Gradle:
+		for _, marker := range []string{"com.jfrog.artifactory", "artifactoryPublish", "maven-publish", "publishing {"} {
+			if bytes.Contains(content, []byte(marker)) {
+				return marker
+			}
+		}
+	case project.Poetry, project.Pip, project.UV:
+		if bytes.Contains(content, []byte("[build-system]")) || bytes.Contains(content, []byte("setup(")) {
+			return "distributable package"
+		}
+	case project.Helm:
+		return "chart"
+	}
+	return ""
+}
+
+// addRegistries records the registry URLs of a file, once per file. The tool is empty for CI pipelines.
+func (oac *OnboardingAnalyzeCommand) addRegistries(tool string, isDockerfile bool, relativePath string, content []byte) {
+	recorded := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		urls := urlRegex.FindAllString(line, -1)
+		if isDockerfile {
+			if match := dockerFromRegex.FindStringSubmatch(line); match != nil {
+				urls = append(urls, "https://"+match[1])
+			}
+		}
+		for _, registryUrl := range urls {
+			registryUrl = strings.TrimRight(registryUrl, ".:")
+			registry, ok := classifyRegistry(registryUrl)
+			if !ok || recorded[registryUrl] {
+				continue
+			}
+			recorded[registryUrl] = true
+			registry.File, registry.Line = relativePath, lineNumber
+			registry.Tool = tool
+			oac.report.Registries = append(oac.report.Registries, registry)
+		}
+	}
+}
+
+// classifyRegistry returns the kind of a registry URL, and its repository if it's an Artifactory URL.
+func classifyRegistry(registryUrl string) (HardcodedRegistry, bool) {
+	parsedUrl, err := url.Parse(registryUrl)
+	if err != nil || parsedUrl.Hostname() == "" || ignoredHosts[parsedUrl.Hostname()] {
+		return HardcodedRegistry{}, false
+	}
+	registry := HardcodedRegistry{Url: registryUrl, Kind: RegistryOther}
+	switch {
+	case strings.Contains(parsedUrl.Path, "/artifactory/") || strings.HasSuffix(parsedUrl.Hostname(), ".jfrog.io"):
+		registry.Kind = RegistryArtifactory
+		if match := artifactoryApiRepoRegex.FindStringSubmatch(parsedUrl.Path); match != nil {
+			registry.Repo = match[1]
+		} else if match = artifactoryRepoRegex.FindStringSubmatch(parsedUrl.Path); match != nil && match[1] != "api" {
+			registry.Repo = match[1]
+		}
+	case publicRegistryHosts[parsedUrl.Hostname()]:
+		registry.Kind = RegistryPublic
+	}
+	return registry, true
+}
+
+// analyzeCiFile records the registries of a CI pipeline, and its native build tool commands.
+func (oac *OnboardingAnalyzeCommand) analyzeCiFile(filePath, relativePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	oac.addRegistries("", false, relativePath, content)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if suggested, ok := suggestJfCommand(scanner.Text()); ok {
+			oac.report.CiCommands = append(oac.report.CiCommands, CiCommand{File: relativePath, Line: lineNumber, Command: strings.TrimSpace(scanner.Text()), Suggested: suggested})
+		}
+	}
+	return nil
+}
+
+// suggestJfCommand replaces the native build tool commands of a line with the JFrog CLI commands.
+func suggestJfCommand(line string) (string, bool) {
+	if strings.Contains(line, "jf ") || strings.Contains(line, "jfrog ") {
+		return "", false
+	}
+	replaced := false
+	suggested := nativeCommandRegex.ReplaceAllStringFunc(line, func(match string) string {
+		groups := nativeCommandRegex.FindStringSubmatch(match)
+		nativeCommand, known := nativeCommands[strings.TrimPrefix(groups[2], "./")]
+		if !known {
+			return match
+		}
+		if len(nativeCommand.subCommands) > 0 && !slices.Contains(nativeCommand.subCommands, groups[3]) {
+			return match
+		}
+		replaced = true
+		return groups[1] + "jf " + nativeCommand.jfCommand + " " + groups[3]
+	})
+	return strings.TrimSpace(suggested), replaced
+}
+
+func (oac *OnboardingAnalyzeCommand) sortedBuildTools() []*buildToolAnalysis {
+	var analyses []*buildToolAnalysis
+	for tool, detected := range oac.buildTools {
+		sort.Strings(detected.Dirs)
+		detected.Dirs = uniqueStrings(detected.Dirs)
+		analyses = append(analyses, &buildToolAnalysis{tool: tool, detected: detected, registries: oac.registriesOf(tool)})
+	}
+	sort.Slice(analyses, func(i, j int) bool { return analyses[i].tool < analyses[j].tool })
+	return analyses
+}
+
+func (oac *OnboardingAnalyzeCommand) registriesOf(tool project.ProjectType) []HardcodedRegistry {
+	var registries []HardcodedRegistry
+	for _, registry := range oac.report.Registries {
+		if registry.Tool == tool.String() {
+			registries = append(registries, registry)
+		}
+	}
+	return registries
+}
+
+func fileExists(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
+
+func uniqueStrings(sorted []string) []string {
+	unique := sorted[:0]
+	for i, value := range sorted {
+		if i == 0 || sorted[i-1] != value {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
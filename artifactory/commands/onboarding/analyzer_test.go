@@ -0,0 +1,141 @@
+package onboarding
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestJfCommand(t *testing.T) {
+	testCases := []struct {
+		line      string
+		suggested string
+		replaced  bool
+	}{
+		{"      - run: npm ci && npm publish", "- run: jf npm ci && jf npm publish", true},
+		{"        run: ./mvnw -B deploy", "run: jf mvn -B deploy", true},
+		{"sh 'docker build -t app .'", "sh 'jf docker build -t app .'", true},
+		{"run: npm test", "", false},
+		{"run: go test ./...", "", false},
+		{"run: jf npm install", "", false},
+		{"# Install the dependencies", "", false},
+	}
+	for _, testCase := range testCases {
+		suggested, replaced := suggestJfCommand(testCase.line)
+		assert.Equal(t, testCase.replaced, replaced, testCase.line)
+		if testCase.replaced {
+			assert.Equal(t, testCase.suggested, suggested)
+		}
+	}
+}
+
+func TestClassifyRegistry(t *testing.T) {
+	registry, ok := classifyRegistry("https://acme.jfrog.io/artifactory/api/npm/npm-virtual/")
+	require.True(t, ok)
+	assert.Equal(t, HardcodedRegistry{Url: "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/", Kind: RegistryArtifactory, Repo: "npm-virtual"}, registry)
+	registry, ok = classifyRegistry("https://acme.com/artifactory/libs-release")
+	require.True(t, ok)
+	assert.Equal(t, "libs-release", registry.Repo)
+	registry, ok = classifyRegistry("https://repo1.maven.org/maven2")
+	require.True(t, ok)
+	assert.Equal(t, RegistryPublic, registry.Kind)
+	registry, ok = classifyRegistry("https://nexus.acme.com/repository/npm/")
+	require.True(t, ok)
+	assert.Equal(t, RegistryOther, registry.Kind)
+	_, ok = classifyRegistry("http://maven.apache.org/POM/4.0.0")
+	assert.False(t, ok)
+}
+
+func TestOnboardingAnalyze(t *testing.T) {
+	projectDir := t.TempDir()
+	files := map[string]string{
+		"package.json":      `{"name": "app", "version": "1.0.0", "publishConfig": {"registry": "https://acme.jfrog.io/artifactory/api/npm/npm-local/"}}`,
+		"package-lock.json": `{"lockfileVersion": 3}`,
+		".npmrc":            "registry=https://acme.jfrog.io/artifactory/api/npm/npm-remote/\n",
+		"services/api/pom.xml": `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <repositories><repository><url>https://repo1.maven.org/maven2</url></repository></repositories>
+  <distributionManagement><repository><url>https://nexus.acme.com/repository/releases</url></repository></distributionManagement>
+</project>`,
+		"services/api/mvnw":               "#!/bin/sh\n",
+		"node_modules/dep/package.json":   `{"name": "dep"}`,
+		"Dockerfile":                      "FROM docker.acme.com/base/alpine:3\n",
+		".github/workflows/ci.yml":        "jobs:\n  build:\n    steps:\n      - run: npm ci && npm publish\n      - run: ./mvnw -B deploy\n",
+		".github/workflows/README.md":     "run: npm ci\n",
+		"docs/guide.md":                   "https://registry.npmjs.org/\n",
+		"services/api/src/main/Main.java": "class Main {}\n",
+	}
+	for filePath, content := range files {
+		localPath := filepath.Join(projectDir, filepath.FromSlash(filePath))
+		require.NoError(t, os.MkdirAll(filepath.Dir(localPath), 0755))
+		require.NoError(t, os.WriteFile(localPath, []byte(content), 0644))
+	}
+	outputDir := t.TempDir()
+
+	analyzeCommand := NewOnboardingAnalyzeCommand().SetPath(projectDir).SetOutputDir(outputDir).SetServerId("acme").SetBuildName("app")
+	require.NoError(t, analyzeCommand.Run())
+	report := analyzeCommand.Report()
+
+	require.Len(t, report.BuildTools, 3)
+	assert.Equal(t, DetectedBuildTool{Tool: "npm", Dirs: []string{"."}, Descriptors: []string{"package.json"},
+		PublishConfig: []string{"package.json: publishConfig.registry https://acme.jfrog.io/artifactory/api/npm/npm-local/"}, ConfigFile: ".jfrog/projects/npm.yaml"}, report.BuildTools[0])
+	assert.Equal(t, "maven", report.BuildTools[1].Tool)
+	assert.Equal(t, []string{"services/api"}, report.BuildTools[1].Dirs)
+	assert.Equal(t, []string{"services/api/pom.xml: distributionManagement"}, report.BuildTools[1].PublishConfig)
+	assert.Equal(t, "docker", report.BuildTools[2].Tool)
+	assert.Empty(t, report.BuildTools[2].ConfigFile)
+
+	var registries []string
+	for _, registry := range report.Registries {
+		registries = append(registries, registry.File+" "+registry.Kind+" "+registry.Url)
+	}
+	assert.ElementsMatch(t, []string{
+		".npmrc artifactory https://acme.jfrog.io/artifactory/api/npm/npm-remote/",
+		"package.json artifactory https://acme.jfrog.io/artifactory/api/npm/npm-local/",
+		"services/api/pom.xml public https://repo1.maven.org/maven2",
+		"services/api/pom.xml other https://nexus.acme.com/repository/releases",
+		"Dockerfile other https://docker.acme.com",
+	}, registries)
+
+	require.Len(t, report.CiCommands, 2)
+	assert.Equal(t, CiCommand{File: ".github/workflows/ci.yml", Line: 4, Command: "- run: npm ci && npm publish", Suggested: "- run: jf npm ci && jf npm publish"}, report.CiCommands[0])
+
+	var commands []string
+	for _, command := range report.Commands {
+		commands = append(commands, command.Dir+": "+command.Command)
+	}
+	assert.Equal(t, []string{
+		".: jf npm ci --build-name=app --build-number=$BUILD_NUMBER",
+		".: jf npm publish --build-name=app --build-number=$BUILD_NUMBER",
+		"services/api: jf mvn clean deploy --build-name=app --build-number=$BUILD_NUMBER",
+		".: jf docker build -t <image> . --build-name=app --build-number=$BUILD_NUMBER",
+		".: jf docker push <image> --build-name=app --build-number=$BUILD_NUMBER",
+		".: jf rt build-publish app $BUILD_NUMBER",
+	}, commands)
+
+	// The npm resolver is the Artifactory repository which the project already resolves from.
+	npmConfig, err := os.ReadFile(filepath.Join(outputDir, ".jfrog", "projects", "npm.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(npmConfig), "type: npm")
+	assert.Contains(t, string(npmConfig), "repo: npm-remote")
+	assert.Contains(t, string(npmConfig), "repo: npm-local")
+	assert.Contains(t, string(npmConfig), "serverId: acme")
+	mavenConfig, err := os.ReadFile(filepath.Join(outputDir, ".jfrog", "projects", "maven.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mavenConfig), "releaseRepo: maven-virtual")
+	assert.Contains(t, string(mavenConfig), "useWrapper: true")
+}
+
+func TestOnboardingAnalyzeWithoutOutputDir(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	analyzeCommand := NewOnboardingAnalyzeCommand().SetPath(projectDir).SetServerId("acme").SetBuildName("app")
+	require.NoError(t, analyzeCommand.Run())
+	assert.Equal(t, []SuggestedCommand{
+		{Tool: "go", Dir: ".", Command: "jf go-config --server-id-resolve=acme --repo-resolve=go-virtual --server-id-deploy=acme --repo-deploy=go-local", Description: "Configure the go repositories."},
+		{Tool: "go", Dir: ".", Command: "jf go build --build-name=app --build-number=$BUILD_NUMBER", Description: "Run 'go build' through Artifactory, and collect the build-info."},
+		{Dir: ".", Command: "jf rt build-publish app $BUILD_NUMBER", Description: "Publish the build-info collected by the commands."},
+	}, analyzeCommand.Report().Commands)
+}
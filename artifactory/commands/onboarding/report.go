@@ -0,0 +1,278 @@
+package onboarding
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// The directory of the project config files, relative to the output directory, as read by the JFrog CLI build tool commands.
+const projectConfigsDir = ".jfrog/projects"
+
+// toolProfile is how a build tool is onboarded: the package type of its repositories, and its JFrog CLI config command.
+type toolProfile struct {
+	packageType   string
+	configCommand string
+	resolver      bool
+	deployer      bool
+}
+
+var toolProfiles = map[project.ProjectType]toolProfile{
+	project.Npm:       {"npm", "npm-config", true, true},
+	project.Pnpm:      {"npm", "pnpm-config", true, true},
+	project.Yarn:      {"npm", "yarn-config", true, true},
+	project.Maven:     {"maven", "mvn-config", true, true},
+	project.Gradle:    {"gradle", "gradle-config", true, true},
+	project.Go:        {"go", "go-config", true, true},
+	project.Pip:       {"pypi", "pip-config", true, false},
+	project.Pipenv:    {"pypi", "pipenv-config", true, false},
+	project.Poetry:    {"pypi", "poetry-config", true, false},
+	project.Dotnet:    {"nuget", "dotnet-config", true, false},
+	project.Nuget:     {"nuget", "nuget-config", true, false},
+	project.Terraform: {"terraform", "terraform-config", false, true},
+}
+
+// buildToolAnalysis is a detected build tool, with the registries hardcoded in its files.
+type buildToolAnalysis struct {
+	tool       project.ProjectType
+	detected   *DetectedBuildTool
+	registries []HardcodedRegistry
+}
+
+// resolveRepo returns the repository to resolve the dependencies from: the Artifactory repository which the project already
+// resolves from, or the virtual repository of the package type.
+func (bta *buildToolAnalysis) resolveRepo(profile toolProfile) string {
+	for _, registry := range bta.registries {
+		if registry.Kind == RegistryArtifactory && registry.Repo != "" {
+			return registry.Repo
+		}
+	}
+	return profile.packageType + "-virtual"
+}
+
+func (bta *buildToolAnalysis) deployRepo(profile toolProfile) string {
+	return profile.packageType + "-local"
+}
+
+// isPublished returns true if a descriptor of the directory has a publish configuration.
+func (bta *buildToolAnalysis) isPublished(dir string) bool {
+	for _, publishConfig := range bta.detected.PublishConfig {
+		descriptor, _, _ := strings.Cut(publishConfig, ": ")
+		if path.Dir(descriptor) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// generateConfigFile writes the JFrog CLI config file of the build tool to the output directory, in the format of 'jf <tool>-config'.
+func (oac *OnboardingAnalyzeCommand) generateConfigFile(analysis *buildToolAnalysis) (err error) {
+	profile, supported := toolProfiles[analysis.tool]
+	if !supported || oac.outputDir == "" {
+		return nil
+	}
+	var options []commands.ConfigOption
+	if profile.resolver {
+		if resolveRepo := analysis.resolveRepo(profile); analysis.tool == project.Maven {
+			options = append(options, commands.WithResolverReleaseRepo(resolveRepo), commands.WithResolverSnapshotRepo(resolveRepo))
+		} else {
+			options = append(options, commands.WithResolverRepo(resolveRepo))
+		}
+		options = append(options, commands.WithResolverServerId(oac.serverId))
+	}
+	if profile.deployer {
+		if deployRepo := analysis.deployRepo(profile); analysis.tool == project.Maven {
+			options = append(options, commands.WithDeployerReleaseRepo(deployRepo), commands.WithDeployerSnapshotRepo(deployRepo))
+		} else {
+			options = append(options, commands.WithDeployerRepo(deployRepo))
+		}
+		options = append(options, commands.WithDeployerServerId(oac.serverId))
+	}
+	if analysis.detected.wrapper {
+		options = append(options, commands.UseWrapper(true))
+	}
+	content, err := yaml.Marshal(commands.NewConfigFileWithOptions(analysis.tool, options...))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	configFile := path.Join(projectConfigsDir, analysis.tool.String()+".yaml")
+	configPath := filepath.Join(oac.outputDir, filepath.FromSlash(configFile))
+	if err = os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Generating", configPath)
+	if err = os.WriteFile(configPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	analysis.detected.ConfigFile = configFile
+	return nil
+}
+
+// suggestCommands returns the JFrog CLI commands which configure the build tool, and which replace its native commands.
+func (oac *OnboardingAnalyzeCommand) suggestCommands(analysis *buildToolAnalysis) []SuggestedCommand {
+	tool := analysis.tool.String()
+	var suggested []SuggestedCommand
+	if profile, supported := toolProfiles[analysis.tool]; supported && analysis.detected.ConfigFile == "" {
+		suggested = append(suggested, SuggestedCommand{Tool: tool, Dir: ".", Command: oac.configCommand(analysis, profile), Description: "Configure the " + tool + " repositories."})
+	}
+	buildFlags := " --build-name=" + oac.buildName + " --build-number=$BUILD_NUMBER"
+	for _, dir := range analysis.detected.Dirs {
+		published := analysis.isPublished(dir)
+		for _, command := range toolCommands(analysis.tool, filepath.Join(oac.path, dir), published) {
+			suggested = append(suggested, SuggestedCommand{Tool: tool, Dir: dir, Command: command + buildFlags, Description: "Run '" + strings.TrimPrefix(command, "jf ") + "' through Artifactory, and collect the build-info."})
+		}
+	}
+	return suggested
+}
+
+func (oac *OnboardingAnalyzeCommand) configCommand(analysis *buildToolAnalysis, profile toolProfile) string {
+	command := []string{"jf", profile.configCommand}
+	if profile.resolver {
+		resolveRepo := analysis.resolveRepo(profile)
+		command = append(command, "--server-id-resolve="+oac.serverId)
+		if analysis.tool == project.Maven {
+			command = append(command, "--repo-resolve-releases="+resolveRepo, "--repo-resolve-snapshots="+resolveRepo)
+		} else {
+			command = append(command, "--repo-resolve="+resolveRepo)
+		}
+	}
+	if profile.deployer {
+		deployRepo := analysis.deployRepo(profile)
+		command = append(command, "--server-id-deploy="+oac.serverId)
+		if analysis.tool == project.Maven {
+			command = append(command, "--repo-deploy-releases="+deployRepo, "--repo-deploy-snapshots="+deployRepo)
+		} else {
+			command = append(command, "--repo-deploy="+deployRepo)
+		}
+	}
+	if analysis.detected.wrapper {
+		command = append(command, "--use-wrapper")
+	}
+	return strings.Join(command, " ")
+}
+
+// toolCommands returns the JFrog CLI commands which build the project of the directory, and publish it if it's published.
+func toolCommands(tool project.ProjectType, dir string, published bool) []string {
+	var buildCommands []string
+	publish := func(commands ...string) {
+		if published {
+			buildCommands = append(buildCommands, commands...)
+		}
+	}
+	switch tool {
+	case project.Npm:
+		if fileExists(filepath.Join(dir, "package-lock.json")) {
+			buildCommands = append(buildCommands, "jf npm ci")
+		} else {
+			buildCommands = append(buildCommands, "jf npm install")
+		}
+		publish("jf npm publish")
+	case project.Pnpm:
+		buildCommands = append(buildCommands, "jf pnpm install")
+		publish("jf pnpm publish")
+	case project.Yarn:
+		buildCommands = append(buildCommands, "jf yarn install")
+		publish("jf yarn npm publish")
+	case project.Maven:
+		if published {
+			return []string{"jf mvn clean deploy"}
+		}
+		buildCommands = append(buildCommands, "jf mvn clean install")
+	case project.Gradle:
+		if published {
+			return []string{"jf gradle clean artifactoryPublish"}
+		}
+		buildCommands = append(buildCommands, "jf gradle clean build")
+	case project.Go:
+		buildCommands = append(buildCommands, "jf go build")
+	case project.Pip:
+		if fileExists(filepath.Join(dir, "requirements.txt")) {
+			buildCommands = append(buildCommands, "jf pip install -r requirements.txt")
+		} else {
+			buildCommands = append(buildCommands, "jf pip install .")
+		}
+		publish("jf twine upload dist/*")
+	case project.Pipenv:
+		buildCommands = append(buildCommands, "jf pipenv install")
+	case project.Poetry:
+		buildCommands = append(buildCommands, "jf poetry install")
+		publish("jf poetry publish")
+	case project.Dotnet:
+		buildCommands = append(buildCommands, "jf dotnet restore")
+	case project.Nuget:
+		buildCommands = append(buildCommands, "jf nuget restore")
+	case project.Terraform:
+		buildCommands = append(buildCommands, "jf terraform publish --namespace=<namespace> --provider=<provider> --tag=<tag>")
+	case project.Docker:
+		buildCommands = append(buildCommands, "jf docker build -t <image> .", "jf docker push <image>")
+	case project.Helm:
+		buildCommands = append(buildCommands, "jf helm package .")
+	}
+	return buildCommands
+}
+
+type buildToolRow struct {
+	Tool          string `col-name:"Build Tool"`
+	Dirs          string `col-name:"Directories"`
+	PublishConfig string `col-name:"Publish Config"`
+	ConfigFile    string `col-name:"Config File"`
+}
+
+type registryRow struct {
+	File string `col-name:"File"`
+	Url  string `col-name:"URL"`
+	Kind string `col-name:"Kind"`
+	Repo string `col-name:"Repository"`
+}
+
+type ciCommandRow struct {
+	File      string `col-name:"File"`
+	Command   string `col-name:"Command"`
+	Suggested string `col-name:"Suggested"`
+}
+
+type suggestedCommandRow struct {
+	Dir         string `col-name:"Directory"`
+	Command     string `col-name:"Command"`
+	Description string `col-name:"Description"`
+}
+
+// PrintOnboardingReport prints the sections of the report as tables.
+func PrintOnboardingReport(report *OnboardingReport) error {
+	buildToolRows := make([]buildToolRow, 0, len(report.BuildTools))
+	for _, buildTool := range report.BuildTools {
+		buildToolRows = append(buildToolRows, buildToolRow{Tool: buildTool.Tool, Dirs: strings.Join(buildTool.Dirs, "\n"), PublishConfig: strings.Join(buildTool.PublishConfig, "\n"), ConfigFile: buildTool.ConfigFile})
+	}
+	if err := coreutils.PrintTable(buildToolRows, "Build tools", "No build tools were detected", false); err != nil {
+		return err
+	}
+	registryRows := make([]registryRow, 0, len(report.Registries))
+	for _, registry := range report.Registries {
+		registryRows = append(registryRows, registryRow{File: registry.File + ":" + strconv.Itoa(registry.Line), Url: registry.Url, Kind: registry.Kind, Repo: registry.Repo})
+	}
+	if err := coreutils.PrintTable(registryRows, "Hardcoded registries", "No hardcoded registries were found", false); err != nil {
+		return err
+	}
+	ciCommandRows := make([]ciCommandRow, 0, len(report.CiCommands))
+	for _, ciCommand := range report.CiCommands {
+		ciCommandRows = append(ciCommandRows, ciCommandRow{File: ciCommand.File + ":" + strconv.Itoa(ciCommand.Line), Command: ciCommand.Command, Suggested: ciCommand.Suggested})
+	}
+	if err := coreutils.PrintTable(ciCommandRows, "CI pipeline commands", "No native build tool commands were found in CI pipelines", false); err != nil {
+		return err
+	}
+	suggestedCommandRows := make([]suggestedCommandRow, 0, len(report.Commands))
+	for _, command := range report.Commands {
+		suggestedCommandRows = append(suggestedCommandRows, suggestedCommandRow{Dir: command.Dir, Command: command.Command, Description: command.Description})
+	}
+	return coreutils.PrintTable(suggestedCommandRows, fmt.Sprintf("Suggested commands for %s", report.Path), "No commands to suggest", false)
+}
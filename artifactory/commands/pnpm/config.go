@@ -0,0 +1,47 @@
+package pnpm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/npm"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// getProjectRepoConfig returns the resolver or deployer repository of the pnpm project configuration, created by 'jf pnpm-config'.
+// It returns nil if the project isn't configured, or if the repository is missing from its configuration, so that
+// pnpm uses the registries of the existing .npmrc.
+func getProjectRepoConfig(prefix string) (*project.RepositoryConfig, error) {
+	configFilePath, exists, err := project.GetProjectConfFilePath(project.Pnpm)
+	if err != nil || !exists {
+		return nil, err
+	}
+	log.Debug("Preparing to read the config file", configFilePath)
+	vConfig, err := project.ReadConfigFile(configFilePath, project.YAML)
+	if err != nil {
+		return nil, err
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(configFilePath, prefix, vConfig)
+	var missingResolverErr *project.MissingResolverErr
+	if errors.As(err, &missingResolverErr) {
+		log.Debug(missingResolverErr.Error())
+		return nil, nil
+	}
+	return repoConfig, err
+}
+
+// configureArtifactoryRegistry points the registry of the project's .npmrc, which pnpm reads too, to the Artifactory repository
+// with the credentials of the server. Returns a function which restores the original .npmrc.
+func configureArtifactoryRegistry(serverDetails *config.ServerDetails, repo string) (restoreNpmrc func() error, err error) {
+	npmCmd := npm.NewNpmInstallCommand().SetServerDetails(serverDetails)
+	if err = npmCmd.PreparePrerequisites(repo); err != nil {
+		return
+	}
+	if err = npmCmd.CreateTempNpmrc(); err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Using the Artifactory repository '%s' of %s as the pnpm registry.", repo, serverDetails.Url))
+	return npmCmd.RestoreNpmrcFunc(), nil
+}
@@ -3,6 +3,7 @@ package pnpm
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -20,6 +21,7 @@ import (
 type PnpmInstallCommand struct {
 	pnpmArgs           []string
 	workingDirectory   string
+	repo               string
 	buildConfiguration *buildUtils.BuildConfiguration
 	serverDetails      *config.ServerDetails
 }
@@ -33,6 +35,13 @@ func (pic *PnpmInstallCommand) SetArgs(args []string) *PnpmInstallCommand {
 	return pic
 }
 
+// SetRepo sets the Artifactory repository which the registry of the .npmrc is pointed to while the command runs.
+// If not set, the registries of the existing .npmrc are used.
+func (pic *PnpmInstallCommand) SetRepo(repo string) *PnpmInstallCommand {
+	pic.repo = repo
+	return pic
+}
+
 func (pic *PnpmInstallCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *PnpmInstallCommand {
 	pic.buildConfiguration = buildConfiguration
 	return pic
@@ -51,15 +60,24 @@ func (pic *PnpmInstallCommand) ServerDetails() (*config.ServerDetails, error) {
 	return pic.serverDetails, nil
 }
 
-func (pic *PnpmInstallCommand) Run() error {
+func (pic *PnpmInstallCommand) Run() (err error) {
 	log.Info("Running pnpm install...")
-	var err error
 	pic.workingDirectory, err = coreutils.GetWorkingDirectory()
 	if err != nil {
 		return err
 	}
 	log.Debug("Working directory set to:", pic.workingDirectory)
 
+	if pic.repo != "" {
+		var restoreNpmrc func() error
+		if restoreNpmrc, err = configureArtifactoryRegistry(pic.serverDetails, pic.repo); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, restoreNpmrc())
+		}()
+	}
+
 	collectBuildInfo, err := pic.buildConfiguration.IsCollectBuildInfo()
 	if err != nil {
 		return err
@@ -1,10 +1,16 @@
 package pnpm
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -552,3 +558,41 @@ func TestPublishBuildInfoGracefulDegradation(t *testing.T) {
 	assert.Error(t, err, "collectSinglePublishBuildInfo should fail with invalid JSON")
 	assert.Contains(t, err.Error(), "parsing pnpm publish --json output")
 }
+
+func TestExtractScanOptions(t *testing.T) {
+	args, xrayScan, scanOutputFormat, err := extractScanOptions([]string{"-r", "--scan", "--format=json", "--tag", "next"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-r", "--tag", "next"}, args)
+	assert.True(t, xrayScan)
+	assert.Equal(t, format.Json, scanOutputFormat)
+
+	args, xrayScan, scanOutputFormat, err = extractScanOptions([]string{"--access", "public"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--access", "public"}, args)
+	assert.False(t, xrayScan)
+	assert.Equal(t, format.Table, scanOutputFormat)
+
+	_, _, _, err = extractScanOptions([]string{"--scan", "--format=xml"})
+	assert.Error(t, err)
+}
+
+func TestGetRepoAndServerDetailsWithoutProjectConfig(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	serverDetails := &config.ServerDetails{ServerId: "default"}
+
+	repo, actualServerDetails, err := getRepoAndServerDetails(project.ProjectConfigResolverPrefix, serverDetails)
+	assert.NoError(t, err)
+	assert.Empty(t, repo)
+	assert.Same(t, serverDetails, actualServerDetails)
+
+	// The project is configured, but without a deployer, so the existing .npmrc is used by pnpm publish.
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog", "projects"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", "projects", "pnpm.yaml"),
+		[]byte("version: 1\ntype: pnpm\nresolver:\n  repo: npm-virtual\n  serverId: default\n"), 0644))
+	repo, actualServerDetails, err = getRepoAndServerDetails(project.ProjectConfigDeployerPrefix, serverDetails)
+	assert.NoError(t, err)
+	assert.Empty(t, repo)
+	assert.Same(t, serverDetails, actualServerDetails)
+}
@@ -2,6 +2,7 @@ package pnpm
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,6 +13,7 @@ import (
 	artCliUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
 	artCoreUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -27,8 +29,11 @@ const publishSummaryFile = "pnpm-publish-summary.json"
 type PnpmPublishCommand struct {
 	pnpmArgs           []string
 	workingDirectory   string
+	repo               string
 	buildConfiguration *buildUtils.BuildConfiguration
 	serverDetails      *config.ServerDetails
+	xrayScan           bool
+	scanOutputFormat   format.OutputFormat
 }
 
 func NewPnpmPublishCommand() *PnpmPublishCommand {
@@ -40,6 +45,13 @@ func (ppc *PnpmPublishCommand) SetArgs(args []string) *PnpmPublishCommand {
 	return ppc
 }
 
+// SetRepo sets the Artifactory repository which the registry of the .npmrc is pointed to while the command runs.
+// If not set, the registries of the existing .npmrc are used.
+func (ppc *PnpmPublishCommand) SetRepo(repo string) *PnpmPublishCommand {
+	ppc.repo = repo
+	return ppc
+}
+
 func (ppc *PnpmPublishCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *PnpmPublishCommand {
 	ppc.buildConfiguration = buildConfiguration
 	return ppc
@@ -71,7 +83,23 @@ func (ppc *PnpmPublishCommand) Run() (err error) {
 		return err
 	}
 
-	flags := extractPublishFlags(ppc.pnpmArgs)
+	args, xrayScan, scanOutputFormat, err := extractScanOptions(ppc.pnpmArgs)
+	if err != nil {
+		return err
+	}
+	ppc.xrayScan, ppc.scanOutputFormat = xrayScan, scanOutputFormat
+
+	if ppc.repo != "" {
+		var restoreNpmrc func() error
+		if restoreNpmrc, err = configureArtifactoryRegistry(ppc.serverDetails, ppc.repo); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, restoreNpmrc())
+		}()
+	}
+
+	flags := extractPublishFlags(args)
 	log.Debug(fmt.Sprintf("Publish flags - recursive: %v, dryRun: %v, userProvidedSummary: %v, filter args: %v, publish args: %v",
 		flags.isRecursive, flags.isDryRun, flags.userProvidedSummary, flags.filterArgs, flags.publishArgs))
 
@@ -80,6 +108,12 @@ func (ppc *PnpmPublishCommand) Run() (err error) {
 		collectBuildInfo = false
 	}
 
+	if ppc.xrayScan {
+		if err = ppc.scanBeforePublish(flags); err != nil {
+			return err
+		}
+	}
+
 	if !collectBuildInfo {
 		return ppc.runPnpmPublishNative(flags)
 	}
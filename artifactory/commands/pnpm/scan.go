@@ -0,0 +1,96 @@
+package pnpm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// extractScanOptions extracts the --scan and --format options of the conditional upload from the pnpm publish args.
+func extractScanOptions(args []string) (cleanArgs []string, xrayScan bool, scanOutputFormat format.OutputFormat, err error) {
+	cleanArgs, xrayScan, err = coreutils.ExtractXrayScanFromArgs(args)
+	if err != nil {
+		return
+	}
+	cleanArgs, outputFormat, err := coreutils.ExtractXrayOutputFormatFromArgs(cleanArgs)
+	if err != nil {
+		return
+	}
+	scanOutputFormat = format.Table
+	if outputFormat != "" {
+		scanOutputFormat, err = format.ParseOutputFormat(outputFormat, format.All)
+	}
+	return
+}
+
+// scanBeforePublish packs the packages which are about to be published, and scans them by Xray.
+// The publish is aborted if any of the packages violates the Xray policies, so nothing is published.
+func (ppc *PnpmPublishCommand) scanBeforePublish(flags publishFlags) (err error) {
+	if ppc.serverDetails == nil {
+		return errorutils.CheckErrorf("no server configuration for the Xray scan. Use 'jfrog config add' or specify --server-id")
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	packages, err := ppc.packForScan(tempDir, flags)
+	if err != nil {
+		return err
+	}
+	if len(packages) == 0 {
+		return errorutils.CheckErrorf("no packages were packed for the Xray scan")
+	}
+	published := make([]publishedPackage, 0, len(packages))
+	for _, pkg := range packages {
+		published = append(published, publishedPackage{Name: pkg.Name, Version: pkg.Version})
+	}
+	publishRepos := getPublishConfigRepos(ppc.workingDirectory, published)
+	fallbackRepos := getRegistryRepos(ppc.workingDirectory)
+	for _, pkg := range packages {
+		repo := resolvePublishRepo(pkg.Name, publishRepos, fallbackRepos)
+		if repo == "" {
+			return errorutils.CheckErrorf("could not determine the Artifactory repository of '%s' for the Xray scan. Configure the pnpm registry of the package to an Artifactory npm repository", pkg.Name)
+		}
+		log.Info(fmt.Sprintf("Scanning %s@%s by Xray before publishing it to '%s'...", pkg.Name, pkg.Version, repo))
+		fileSpec := spec.NewBuilder().Pattern(pkg.Filename).Target(repo + "/").BuildSpec()
+		err = commandsutils.ConditionalUploadScanFunc(ppc.serverDetails, fileSpec, 1, ppc.scanOutputFormat)
+		jobsummary.RecordScanGate(filepath.Base(pkg.Filename), err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packForScan packs the packages which are selected by the publish, into the destination directory.
+func (ppc *PnpmPublishCommand) packForScan(destDir string, flags publishFlags) ([]pnpmPackResult, error) {
+	args := []string{"pack", "--json", "--pack-destination", destDir}
+	if flags.isRecursive {
+		args = append(args, "-r")
+	}
+	args = append(args, flags.filterArgs...)
+	log.Debug("Running command: pnpm", strings.Join(args, " "))
+	cmd := exec.Command("pnpm", args...)
+	cmd.Dir = ppc.workingDirectory
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errorutils.CheckErrorf("pnpm pack failed: %s", err.Error())
+	}
+	return parsePackOutput(out)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jfrog/gofrog/version"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -28,14 +29,36 @@ func NewCommand(cmdName string, args []string, buildConfig *buildUtils.BuildConf
 	}
 	switch cmdName {
 	case "install", "i":
-		return NewPnpmInstallCommand().SetArgs(args).SetBuildConfiguration(buildConfig).SetServerDetails(serverDetails), nil
+		repo, serverDetails, err := getRepoAndServerDetails(project.ProjectConfigResolverPrefix, serverDetails)
+		if err != nil {
+			return nil, err
+		}
+		return NewPnpmInstallCommand().SetArgs(args).SetRepo(repo).SetBuildConfiguration(buildConfig).SetServerDetails(serverDetails), nil
 	case "publish":
-		return NewPnpmPublishCommand().SetArgs(args).SetBuildConfiguration(buildConfig).SetServerDetails(serverDetails), nil
+		repo, serverDetails, err := getRepoAndServerDetails(project.ProjectConfigDeployerPrefix, serverDetails)
+		if err != nil {
+			return nil, err
+		}
+		return NewPnpmPublishCommand().SetArgs(args).SetRepo(repo).SetBuildConfiguration(buildConfig).SetServerDetails(serverDetails), nil
 	default:
 		return nil, fmt.Errorf("unsupported pnpm command: %s", cmdName)
 	}
 }
 
+// getRepoAndServerDetails returns the repository and the server of the pnpm project configuration, if configured.
+// Otherwise, the repository is empty and the given server details are returned.
+func getRepoAndServerDetails(prefix string, serverDetails *config.ServerDetails) (string, *config.ServerDetails, error) {
+	repoConfig, err := getProjectRepoConfig(prefix)
+	if err != nil || repoConfig == nil {
+		return "", serverDetails, err
+	}
+	configServerDetails, err := repoConfig.ServerDetails()
+	if err != nil {
+		return "", nil, err
+	}
+	return repoConfig.TargetRepo(), configServerDetails, nil
+}
+
 // validatePnpmPrerequisites checks that pnpm and Node.js meet the version requirements.
 // Currently only pnpm 10.x is supported.
 func validatePnpmPrerequisites() error {
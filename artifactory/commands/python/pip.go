@@ -23,6 +23,9 @@ func NewPipCommand() *PipCommand {
 }
 
 func (pc *PipCommand) Run() (err error) {
+	if pc.commandName == pipBundleCommand {
+		return pc.bundle()
+	}
 	return pc.PythonCommand.Run()
 }
 
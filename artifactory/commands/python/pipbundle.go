@@ -0,0 +1,217 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The pip sub-command which downloads the requirements into a wheelhouse directory, for offline installations.
+	pipBundleCommand = "bundle"
+	// The options of the bundle sub-command, which aren't passed to pip download.
+	wheelhouseOption       = "wheelhouse"
+	bundleUploadOption     = "upload"
+	defaultWheelhouseDir   = "wheelhouse"
+	wheelhouseRequirements = "requirements.txt"
+)
+
+// wheelhouseDistribution is a wheel or a source distribution downloaded to the wheelhouse.
+type wheelhouseDistribution struct {
+	name     string
+	version  string
+	fileName string
+	sha256   string
+}
+
+// bundle resolves the requirements through the repository and downloads all of their wheels and source distributions into the
+// wheelhouse directory, together with a hash-pinned requirements.txt, which installs them without access to Artifactory with:
+// pip install --no-index --find-links <wheelhouse> --require-hashes -r <wheelhouse>/requirements.txt
+// If --upload is set, the wheelhouse is deployed to Artifactory as a single zip archive.
+func (pc *PipCommand) bundle() (err error) {
+	args, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(pc.args)
+	if err != nil {
+		return err
+	}
+	args, wheelhouse, uploadTarget, err := extractBundleOptions(args)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(wheelhouse, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	rtUrl, err := GetPypiRepoUrl(pc.serverDetails, pc.repository, false)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Downloading the requirements through '%s' into %s...", pc.repository, wheelhouse))
+	downloadArgs := append(args, "--dest", wheelhouse, pipRemoteRegistryFlag, rtUrl)
+	if err = gofrogcmd.RunCmd(gofrogcmd.NewCommand(string(pc.pythonTool), "download", downloadArgs)); err != nil {
+		return errorutils.CheckErrorf("pip download failed: %s", err.Error())
+	}
+	distributions, err := readWheelhouse(wheelhouse)
+	if err != nil {
+		return err
+	}
+	if err = writeHashPinnedRequirements(wheelhouse, distributions); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Bundled %d distributions in %s. Install them offline with: pip install --no-index --find-links %s --require-hashes -r %s",
+		len(distributions), wheelhouse, wheelhouse, filepath.Join(wheelhouse, wheelhouseRequirements)))
+	if uploadTarget == "" {
+		return nil
+	}
+	return pc.uploadWheelhouse(wheelhouse, uploadTarget, buildConfiguration)
+}
+
+// extractBundleOptions extracts the --wheelhouse and --upload options of the bundle sub-command from the args.
+func extractBundleOptions(args []string) (cleanArgs []string, wheelhouse, uploadTarget string, err error) {
+	cleanArgs, wheelhouse, err = coreutils.ExtractStringOptionFromArgs(args, wheelhouseOption)
+	if err != nil {
+		return
+	}
+	if wheelhouse == "" {
+		wheelhouse = defaultWheelhouseDir
+	}
+	cleanArgs, uploadTarget, err = coreutils.ExtractStringOptionFromArgs(cleanArgs, bundleUploadOption)
+	if err != nil {
+		return
+	}
+	// A target directory is completed with the name of the wheelhouse.
+	if strings.HasSuffix(uploadTarget, "/") {
+		uploadTarget += filepath.Base(wheelhouse) + ".zip"
+	}
+	return
+}
+
+// readWheelhouse returns the wheels and the source distributions in the wheelhouse, with their checksums.
+func readWheelhouse(wheelhouse string) ([]wheelhouseDistribution, error) {
+	entries, err := os.ReadDir(wheelhouse)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var distributions []wheelhouseDistribution
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name, version, ok := parseDistributionFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		fileDetails, err := fileutils.GetFileDetails(filepath.Join(wheelhouse, entry.Name()), true)
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, wheelhouseDistribution{name: name, version: version, fileName: entry.Name(), sha256: fileDetails.Checksum.Sha256})
+	}
+	return distributions, nil
+}
+
+// parseDistributionFileName returns the normalized project name and the version of a wheel or a source distribution file.
+// Wheels are named <name>-<version>(-<build>)?-<python>-<abi>-<platform>.whl, and source distributions <name>-<version>.<extension>.
+func parseDistributionFileName(fileName string) (name, version string, ok bool) {
+	if baseName, isWheel := strings.CutSuffix(fileName, ".whl"); isWheel {
+		parts := strings.Split(baseName, "-")
+		if len(parts) < 5 {
+			return "", "", false
+		}
+		return normalizeRequirementName(parts[0]), parts[1], true
+	}
+	for _, extension := range []string{".tar.gz", ".zip", ".tar.bz2"} {
+		if baseName, isSdist := strings.CutSuffix(fileName, extension); isSdist {
+			separator := strings.LastIndex(baseName, "-")
+			if separator <= 0 {
+				return "", "", false
+			}
+			return normalizeRequirementName(baseName[:separator]), baseName[separator+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// normalizeRequirementName returns the name of the project, as normalized by PEP 503.
+func normalizeRequirementName(name string) string {
+	return distNameSeparatorsRegex.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// writeHashPinnedRequirements writes the requirements.txt of the wheelhouse, which pins each of its projects to the downloaded version,
+// with the hashes of all the distributions of the version.
+func writeHashPinnedRequirements(wheelhouse string, distributions []wheelhouseDistribution) error {
+	hashes := make(map[string][]string)
+	for _, distribution := range distributions {
+		requirement := distribution.name + "==" + distribution.version
+		hashes[requirement] = append(hashes[requirement], distribution.sha256)
+	}
+	requirements := make([]string, 0, len(hashes))
+	for requirement := range hashes {
+		requirements = append(requirements, requirement)
+	}
+	sort.Strings(requirements)
+	var content strings.Builder
+	for _, requirement := range requirements {
+		content.WriteString(requirement)
+		sort.Strings(hashes[requirement])
+		for _, hash := range hashes[requirement] {
+			content.WriteString(" \\\n    --hash=sha256:" + hash)
+		}
+		content.WriteString("\n")
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(wheelhouse, wheelhouseRequirements), []byte(content.String()), 0644))
+}
+
+// uploadWheelhouse deploys the wheelhouse to the target in Artifactory, as a single zip archive.
+// When the build-info is collected, the archive is added to it as an artifact.
+func (pc *PipCommand) uploadWheelhouse(wheelhouse, uploadTarget string, buildConfiguration *buildUtils.BuildConfiguration) (err error) {
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &servicesUtils.CommonParams{Pattern: filepath.ToSlash(wheelhouse) + "/(*)", Target: uploadTarget, TargetProps: servicesUtils.NewProperties()}
+	uploadParams.TargetPathInArchive = "{1}"
+	uploadParams.Archive = "zip"
+	uploadParams.Recursive = true
+	if collectBuildInfo {
+		if uploadParams.BuildProps, err = buildUtils.CreateBuildPropsFromConfiguration(buildConfiguration); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Deploying the wheelhouse to %s...", uploadTarget))
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams)
+	if err != nil {
+		return err
+	}
+	defer gofrogcmd.Close(summary, &err)
+	if summary.TotalFailed > 0 || summary.TotalSucceeded == 0 {
+		return errorutils.CheckErrorf("failed to deploy the wheelhouse to %s", uploadTarget)
+	}
+	log.Info("Deployed the wheelhouse to:", path.Clean(uploadTarget))
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := servicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return buildUtils.PopulateBuildArtifactsAsPartials(artifacts, buildConfiguration, entities.Python)
+}
@@ -0,0 +1,72 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractBundleOptions(t *testing.T) {
+	args, wheelhouse, uploadTarget, err := extractBundleOptions([]string{"-r", "requirements.txt", "--wheelhouse=offline/wheels", "--upload", "pypi-bundles/app/", "--no-deps"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-r", "requirements.txt", "--no-deps"}, args)
+	assert.Equal(t, "offline/wheels", wheelhouse)
+	assert.Equal(t, "pypi-bundles/app/wheels.zip", uploadTarget)
+
+	args, wheelhouse, uploadTarget, err = extractBundleOptions([]string{"-r", "requirements.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-r", "requirements.txt"}, args)
+	assert.Equal(t, defaultWheelhouseDir, wheelhouse)
+	assert.Empty(t, uploadTarget)
+}
+
+func TestParseDistributionFileName(t *testing.T) {
+	testCases := []struct {
+		fileName string
+		name     string
+		version  string
+		ok       bool
+	}{
+		{"requests-2.31.0-py3-none-any.whl", "requests", "2.31.0", true},
+		{"charset_normalizer-3.3.2-cp312-cp312-manylinux_2_17_x86_64.whl", "charset-normalizer", "3.3.2", true},
+		{"Zope.Interface-6.1.tar.gz", "zope-interface", "6.1", true},
+		{"py-bcrypt-0.4.zip", "py-bcrypt", "0.4", true},
+		{"requests-2.31.0.whl", "", "", false},
+		{"requirements.txt", "", "", false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.fileName, func(t *testing.T) {
+			name, version, ok := parseDistributionFileName(testCase.fileName)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.name, name)
+			assert.Equal(t, testCase.version, version)
+		})
+	}
+}
+
+func TestWriteHashPinnedRequirements(t *testing.T) {
+	wheelhouse := t.TempDir()
+	for _, fileName := range []string{"urllib3-2.2.1-py3-none-any.whl", "six-1.16.0-py2.py3-none-any.whl", "six-1.16.0.tar.gz", wheelhouseRequirements} {
+		require.NoError(t, os.WriteFile(filepath.Join(wheelhouse, fileName), []byte(fileName), 0644))
+	}
+	distributions, err := readWheelhouse(wheelhouse)
+	require.NoError(t, err)
+	require.Len(t, distributions, 3)
+	require.NoError(t, writeHashPinnedRequirements(wheelhouse, distributions))
+
+	hashes := map[string]string{}
+	for _, distribution := range distributions {
+		hashes[distribution.fileName] = distribution.sha256
+	}
+	sixHashes := []string{hashes["six-1.16.0-py2.py3-none-any.whl"], hashes["six-1.16.0.tar.gz"]}
+	if sixHashes[0] > sixHashes[1] {
+		sixHashes[0], sixHashes[1] = sixHashes[1], sixHashes[0]
+	}
+	content, err := os.ReadFile(filepath.Join(wheelhouse, wheelhouseRequirements))
+	require.NoError(t, err)
+	assert.Equal(t, "six==1.16.0 \\\n    --hash=sha256:"+sixHashes[0]+" \\\n    --hash=sha256:"+sixHashes[1]+"\n"+
+		"urllib3==2.2.1 \\\n    --hash=sha256:"+hashes["urllib3-2.2.1-py3-none-any.whl"]+"\n", string(content))
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/spf13/viper"
-	"golang.org/x/exp/slices"
 )
 
 const (
@@ -60,18 +59,13 @@ func (pc *PoetryCommand) Run() (err error) {
 	if err != nil {
 		return err
 	}
-	if pythonBuildInfo != nil {
-		switch pc.commandName {
-		case "install":
-			return pc.install(buildConfiguration, pythonBuildInfo)
-		case "publish":
-			return pc.publish(buildConfiguration, pythonBuildInfo)
-		default:
-			// poetry native command
-			return gofrogcmd.RunCmd(pc)
-
-		}
+	if pc.commandName == "publish" {
+		return pc.publish(buildConfiguration, pythonBuildInfo)
+	}
+	if pythonBuildInfo != nil && pc.commandName == "install" {
+		return pc.install(buildConfiguration, pythonBuildInfo)
 	}
+	// poetry native command
 	return gofrogcmd.RunCmd(pc)
 }
 
@@ -95,166 +89,6 @@ func (pc *PoetryCommand) install(buildConfiguration *buildUtils.BuildConfigurati
 	return errorutils.CheckError(pythonModule.RunInstallAndCollectDependencies(pc.args))
 }
 
-func (pc *PoetryCommand) publish(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
-	publishCmdArgs := append(slices.Clone(pc.args), "-r "+pc.repository)
-
-	// Get build name and number (already extracted from CLI arguments)
-	// Since buildConfiguration is created from CLI args, these should be available directly
-	buildName, err := buildConfiguration.GetBuildName()
-	if err != nil {
-		return err
-	}
-	buildNumber, err := buildConfiguration.GetBuildNumber()
-	if err != nil {
-		return err
-	}
-
-	// Run the publish command to upload artifacts
-	pc.args = publishCmdArgs
-	err = gofrogcmd.RunCmd(pc)
-	if err != nil {
-		return err
-	}
-
-	// After successful publish, collect artifacts information
-	if buildName != "" && buildNumber != "" {
-		workingDir, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-		return pc.collectPublishedArtifacts(buildConfiguration, pythonBuildInfo, workingDir)
-	}
-
-	return nil
-}
-
-// collectPublishedArtifacts collects information about artifacts that were published
-func (pc *PoetryCommand) collectPublishedArtifacts(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build, workingDir string) error {
-	// Get the build directory from pyproject.toml configuration or use default
-	buildDir, err := pc.getBuildDirectoryFromPyproject(workingDir)
-	if err != nil {
-		log.Debug("Failed to read build directory from pyproject.toml, using default 'dist': " + err.Error())
-		buildDir = filepath.Join(workingDir, "dist")
-	}
-
-	// Look for built artifacts in the build directory
-	if _, err := os.Stat(buildDir); os.IsNotExist(err) {
-		log.Debug(fmt.Sprintf("No build directory found at %s, skipping artifact collection", buildDir))
-		return nil
-	}
-
-	// Get module name
-	moduleName := buildConfiguration.GetModule()
-	if moduleName == "" {
-		// Try to determine module name from pyproject.toml
-		if name, err := pc.getProjectNameFromPyproject(workingDir); err == nil {
-			moduleName = name
-		} else {
-			moduleName = "poetry-module"
-		}
-	}
-
-	// Find artifacts in build directory
-	artifacts, err := pc.findDistArtifacts(buildDir)
-	if err != nil {
-		return err
-	}
-
-	if len(artifacts) > 0 {
-		log.Debug(fmt.Sprintf("Found %d artifacts to add to build info", len(artifacts)))
-		// Add artifacts to build info
-		return pythonBuildInfo.AddArtifacts(moduleName, "pypi", artifacts...)
-	}
-
-	return nil
-}
-
-// getBuildDirectoryFromPyproject reads the build directory configuration from pyproject.toml
-func (pc *PoetryCommand) getBuildDirectoryFromPyproject(workingDir string) (string, error) {
-	pyprojectPath := filepath.Join(workingDir, pyproject)
-	viper.SetConfigType("toml")
-	viper.SetConfigFile(pyprojectPath)
-	if err := viper.ReadInConfig(); err != nil {
-		return "", err
-	}
-
-	// Check for build directory configuration in [tool.poetry.build] section
-	buildDir := viper.GetString("tool.poetry.build.directory")
-	if buildDir != "" {
-		// If it's a relative path, make it absolute relative to working directory
-		if !filepath.IsAbs(buildDir) {
-			buildDir = filepath.Join(workingDir, buildDir)
-		}
-		return buildDir, nil
-	}
-
-	// Default to "dist" directory if not configured
-	return filepath.Join(workingDir, "dist"), nil
-}
-
-// getProjectNameFromPyproject extracts project name from pyproject.toml
-func (pc *PoetryCommand) getProjectNameFromPyproject(workingDir string) (string, error) {
-	pyprojectPath := filepath.Join(workingDir, pyproject)
-	viper.SetConfigType("toml")
-	viper.SetConfigFile(pyprojectPath)
-	if err := viper.ReadInConfig(); err != nil {
-		return "", err
-	}
-
-	name := viper.GetString("tool.poetry.name")
-	if name == "" {
-		return "", fmt.Errorf("no project name found in pyproject.toml")
-	}
-
-	return name, nil
-}
-
-// findDistArtifacts finds and creates artifact entries for files in dist directory
-func (pc *PoetryCommand) findDistArtifacts(distDir string) ([]entities.Artifact, error) {
-	var artifacts []entities.Artifact
-
-	entries, err := os.ReadDir(distDir)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		filename := entry.Name()
-		// Only include wheel and tar.gz files
-		artifactType := getArtifactType(filename)
-		if artifactType == "unknown" {
-			continue
-		}
-
-		filePath := filepath.Join(distDir, filename)
-
-		// Create artifact entry
-		artifact := entities.Artifact{
-			Name: filename,
-			Path: filePath,
-			Type: artifactType,
-		}
-
-		artifacts = append(artifacts, artifact)
-	}
-
-	return artifacts, nil
-}
-
-// getArtifactType determines the artifact type based on file extension
-func getArtifactType(filename string) string {
-	if strings.HasSuffix(filename, ".whl") {
-		return "wheel"
-	} else if strings.HasSuffix(filename, ".tar.gz") {
-		return "sdist"
-	}
-	return "unknown"
-}
-
 func (pc *PoetryCommand) UpdateDepsChecksumInfoFunc(dependenciesMap map[string]entities.Dependency, srcPath string) error {
 	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
 	if err != nil {
@@ -0,0 +1,269 @@
+package python
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/build-info-go/build"
+	"github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/jobsummary"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The poetry publish option which builds the distributions before publishing them.
+const poetryBuildFlag = "--build"
+
+// Runs of characters which are normalized to a single underscore in the distribution file names, by PEP 427 and PEP 625.
+var distNameSeparatorsRegex = regexp.MustCompile(`[-_.]+`)
+
+// poetryProject is the Poetry project whose distributions are published.
+type poetryProject struct {
+	name     string
+	version  string
+	buildDir string
+}
+
+// publish builds the distributions of the project if needed, scans them by Xray if --scan is set, and publishes them to the repository.
+// When the build-info is collected, the published distributions are added to it as artifacts, with their checksums.
+func (pc *PoetryCommand) publish(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
+	args, xrayScan, scanOutputFormat, err := extractScanOptions(pc.args)
+	if err != nil {
+		return err
+	}
+	buildRequested := slices.Contains(args, poetryBuildFlag)
+	args = slices.DeleteFunc(args, func(arg string) bool { return arg == poetryBuildFlag })
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	poetryProj, err := readPoetryProject(workingDir)
+	if err != nil {
+		return err
+	}
+	distributions, err := poetryProj.findDistributions()
+	if err != nil {
+		return err
+	}
+	if buildRequested || len(distributions) == 0 {
+		// The distributions are built before the publish, so that they can be scanned before they are deployed.
+		if err = gofrogcmd.RunCmd(gofrogcmd.NewCommand(string(pc.pythonTool), "build", []string{})); err != nil {
+			return errorutils.CheckErrorf("poetry build failed: %s", err.Error())
+		}
+		if distributions, err = poetryProj.findDistributions(); err != nil {
+			return err
+		}
+	}
+	if len(distributions) == 0 {
+		return errorutils.CheckErrorf("no distributions of %s %s were found in %s", poetryProj.name, poetryProj.version, poetryProj.buildDir)
+	}
+	if xrayScan {
+		if err = pc.scanDistributions(distributions, scanOutputFormat); err != nil {
+			return err
+		}
+	}
+	pc.args = append(args, "-r", pc.repository)
+	if err = gofrogcmd.RunCmd(pc); err != nil {
+		return err
+	}
+	if pythonBuildInfo == nil {
+		return nil
+	}
+	moduleName := buildConfiguration.GetModule()
+	if moduleName == "" {
+		moduleName = poetryProj.name
+	}
+	return pc.collectPublishedArtifacts(buildConfiguration, pythonBuildInfo, moduleName, distributions)
+}
+
+// extractScanOptions extracts the --scan and --format options of the conditional upload from the args.
+func extractScanOptions(args []string) (cleanArgs []string, xrayScan bool, scanOutputFormat format.OutputFormat, err error) {
+	cleanArgs, xrayScan, err = coreutils.ExtractXrayScanFromArgs(args)
+	if err != nil {
+		return
+	}
+	cleanArgs, outputFormat, err := coreutils.ExtractXrayOutputFormatFromArgs(cleanArgs)
+	if err != nil {
+		return
+	}
+	scanOutputFormat = format.Table
+	if outputFormat != "" {
+		scanOutputFormat, err = format.ParseOutputFormat(outputFormat, format.All)
+	}
+	return
+}
+
+// scanDistributions scans the distributions by Xray before they're published.
+// The publish is aborted if any of the distributions violates the Xray policies, so nothing is published.
+func (pc *PoetryCommand) scanDistributions(distributions []entities.Artifact, scanOutputFormat format.OutputFormat) error {
+	if commandsutils.ConditionalUploadScanFunc == nil {
+		return errorutils.CheckErrorf("the Xray scan isn't available in this build of JFrog CLI")
+	}
+	for _, distribution := range distributions {
+		log.Info(fmt.Sprintf("Scanning %s by Xray before publishing it to '%s'...", distribution.Name, pc.repository))
+		fileSpec := spec.NewBuilder().Pattern(distribution.Path).Target(pc.repository + "/").BuildSpec()
+		err := commandsutils.ConditionalUploadScanFunc(pc.serverDetails, fileSpec, 1, scanOutputFormat)
+		jobsummary.RecordScanGate(distribution.Name, err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectPublishedArtifacts adds the published distributions to the build-info, and sets the build properties on them in Artifactory.
+func (pc *PoetryCommand) collectPublishedArtifacts(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build, moduleName string, distributions []entities.Artifact) (err error) {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	sha256s := make([]string, 0, len(distributions))
+	for _, distribution := range distributions {
+		sha256s = append(sha256s, distribution.Sha256)
+	}
+	searchParams := services.SearchParams{CommonParams: &servicesUtils.CommonParams{Aql: servicesUtils.Aql{ItemsFind: CreateAqlQueryForSearchBySHA256(pc.repository, sha256s)}}}
+	searchReader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(searchReader.Close()))
+	}()
+	deployedPaths := make(map[string]string, len(distributions))
+	for item := new(servicesUtils.ResultItem); searchReader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		deployedPaths[item.Sha256] = item.Path + "/" + item.Name
+	}
+	if err = searchReader.GetError(); err != nil {
+		return err
+	}
+	artifacts := make([]entities.Artifact, 0, len(distributions))
+	for _, distribution := range distributions {
+		deployedPath, found := deployedPaths[distribution.Sha256]
+		if !found {
+			return errorutils.CheckErrorf("the published %s wasn't found in the '%s' repository", distribution.Name, pc.repository)
+		}
+		distribution.Path = deployedPath
+		distribution.OriginalDeploymentRepo = pc.repository
+		artifacts = append(artifacts, distribution)
+	}
+	searchReader.Reset()
+	timestamp := strconv.FormatInt(pythonBuildInfo.GetBuildTimestamp().UnixNano()/int64(time.Millisecond), 10)
+	propsParams := services.PropsParams{
+		Reader: searchReader,
+		Props:  fmt.Sprintf("build.name=%s;build.number=%s;build.timestamp=%s", buildName, buildNumber, timestamp),
+	}
+	if _, err = servicesManager.SetProps(propsParams); err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Adding %d published distributions to the build-info.", len(artifacts)))
+	return errorutils.CheckError(pythonBuildInfo.AddArtifacts(moduleName, entities.Python, artifacts...))
+}
+
+// readPoetryProject reads the name, version and build directory of the project from its pyproject.toml file.
+// Both the [tool.poetry] section and the [project] section of PEP 621 are supported.
+func readPoetryProject(workingDir string) (*poetryProject, error) {
+	pyprojectConfig := viper.New()
+	pyprojectConfig.SetConfigType("toml")
+	pyprojectConfig.SetConfigFile(filepath.Join(workingDir, pyproject))
+	if err := pyprojectConfig.ReadInConfig(); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read %s: %s", pyproject, err.Error())
+	}
+	poetryProj := &poetryProject{
+		name:     pyprojectConfig.GetString("project.name"),
+		version:  pyprojectConfig.GetString("project.version"),
+		buildDir: pyprojectConfig.GetString("tool.poetry.build.directory"),
+	}
+	if poetryProj.name == "" {
+		poetryProj.name = pyprojectConfig.GetString("tool.poetry.name")
+	}
+	if poetryProj.version == "" {
+		poetryProj.version = pyprojectConfig.GetString("tool.poetry.version")
+	}
+	if poetryProj.name == "" || poetryProj.version == "" {
+		return nil, errorutils.CheckErrorf("the name and the version of the project weren't found in %s", pyproject)
+	}
+	if poetryProj.buildDir == "" {
+		poetryProj.buildDir = "dist"
+	}
+	if !filepath.IsAbs(poetryProj.buildDir) {
+		poetryProj.buildDir = filepath.Join(workingDir, poetryProj.buildDir)
+	}
+	return poetryProj, nil
+}
+
+// findDistributions returns the wheels and the source distributions of the project's version in the build directory, with their checksums.
+// The distributions of other versions, which are left in the build directory, aren't published by poetry.
+func (pp *poetryProject) findDistributions() ([]entities.Artifact, error) {
+	entries, err := os.ReadDir(pp.buildDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	prefix := normalizeDistName(pp.name) + "-" + pp.version
+	var distributions []entities.Artifact
+	for _, entry := range entries {
+		fileName := entry.Name()
+		artifactType := getArtifactType(fileName)
+		if entry.IsDir() || artifactType == "" {
+			continue
+		}
+		if baseName := strings.TrimSuffix(strings.TrimSuffix(fileName, ".whl"), ".tar.gz"); baseName != prefix && !strings.HasPrefix(baseName, prefix+"-") {
+			continue
+		}
+		filePath := filepath.Join(pp.buildDir, fileName)
+		fileDetails, err := fileutils.GetFileDetails(filePath, true)
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, entities.Artifact{
+			Name:     fileName,
+			Path:     filePath,
+			Type:     artifactType,
+			Checksum: entities.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+		})
+	}
+	return distributions, nil
+}
+
+// normalizeDistName returns the project name, as it appears in the names of its distribution files.
+func normalizeDistName(name string) string {
+	return distNameSeparatorsRegex.ReplaceAllString(strings.ToLower(name), "_")
+}
+
+// getArtifactType determines the artifact type based on file extension
+func getArtifactType(filename string) string {
+	if strings.HasSuffix(filename, ".whl") {
+		return "wheel"
+	} else if strings.HasSuffix(filename, ".tar.gz") {
+		return "sdist"
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPoetryProject(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, pyproject), []byte("[tool.poetry]\nname = \"my-poetry-project\"\nversion = \"0.1.0\"\n"), 0644))
+	poetryProj, err := readPoetryProject(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, &poetryProject{name: "my-poetry-project", version: "0.1.0", buildDir: filepath.Join(projectDir, "dist")}, poetryProj)
+
+	// The [project] section of PEP 621 is used by Poetry 2.
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, pyproject), []byte("[project]\nname = \"My.Project\"\nversion = \"1.0.0\"\n\n[tool.poetry.build]\ndirectory = \"out\"\n"), 0644))
+	poetryProj, err = readPoetryProject(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, &poetryProject{name: "My.Project", version: "1.0.0", buildDir: filepath.Join(projectDir, "out")}, poetryProj)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, pyproject), []byte("[tool.poetry]\nname = \"app\"\n"), 0644))
+	_, err = readPoetryProject(projectDir)
+	assert.ErrorContains(t, err, "the name and the version of the project weren't found")
+}
+
+func TestFindDistributions(t *testing.T) {
+	poetryProj := &poetryProject{name: "My.Project", version: "1.0.0", buildDir: filepath.Join(t.TempDir(), "dist")}
+	distributions, err := poetryProj.findDistributions()
+	require.NoError(t, err)
+	assert.Empty(t, distributions)
+
+	require.NoError(t, os.MkdirAll(poetryProj.buildDir, 0755))
+	for _, fileName := range []string{"my_project-1.0.0-py3-none-any.whl", "my_project-1.0.0.tar.gz", "my_project-0.9.0.tar.gz", "my_project-1.0.0.1.tar.gz", "other-1.0.0.tar.gz", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(poetryProj.buildDir, fileName), []byte(fileName), 0644))
+	}
+	distributions, err = poetryProj.findDistributions()
+	require.NoError(t, err)
+	require.Len(t, distributions, 2)
+	assert.Equal(t, "my_project-1.0.0-py3-none-any.whl", distributions[0].Name)
+	assert.Equal(t, "wheel", distributions[0].Type)
+	assert.Equal(t, filepath.Join(poetryProj.buildDir, "my_project-1.0.0-py3-none-any.whl"), distributions[0].Path)
+	assert.NotEmpty(t, distributions[0].Sha256)
+	assert.Equal(t, "sdist", distributions[1].Type)
+}
+
+func TestExtractScanOptions(t *testing.T) {
+	args, xrayScan, scanOutputFormat, err := extractScanOptions([]string{"--scan", "--format=json", "--dry-run"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--dry-run"}, args)
+	assert.True(t, xrayScan)
+	assert.Equal(t, format.Json, scanOutputFormat)
+
+	_, xrayScan, scanOutputFormat, err = extractScanOptions([]string{"--dry-run"})
+	require.NoError(t, err)
+	assert.False(t, xrayScan)
+	assert.Equal(t, format.Table, scanOutputFormat)
+}
+
+func TestScanDistributions(t *testing.T) {
+	previousScanFunc := commandsutils.ConditionalUploadScanFunc
+	defer func() {
+		commandsutils.ConditionalUploadScanFunc = previousScanFunc
+	}()
+	var scanned []spec.File
+	commandsutils.ConditionalUploadScanFunc = func(_ *config.ServerDetails, fileSpec *spec.SpecFiles, _ int, _ format.OutputFormat) error {
+		scanned = append(scanned, fileSpec.Files...)
+		if len(scanned) > 1 {
+			return assert.AnError
+		}
+		return nil
+	}
+	poetryCommand := NewPoetryCommand().SetRepo("pypi-local")
+	distributions := []entities.Artifact{{Name: "app-1.0.0-py3-none-any.whl", Path: "dist/app-1.0.0-py3-none-any.whl"}, {Name: "app-1.0.0.tar.gz", Path: "dist/app-1.0.0.tar.gz"}}
+	assert.ErrorIs(t, poetryCommand.scanDistributions(distributions, format.Table), assert.AnError)
+	require.Len(t, scanned, 2)
+	assert.Equal(t, "dist/app-1.0.0-py3-none-any.whl", scanned[0].Pattern)
+	assert.Equal(t, "pypi-local/", scanned[0].Target)
+}
@@ -0,0 +1,221 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/fileprops"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// pypiDistribution is a wheel or a source distribution which is uploaded to a PyPI repository.
+type pypiDistribution struct {
+	localPath      string
+	normalizedName string
+	version        string
+	artifactType   string
+	props          *servicesUtils.Properties
+}
+
+// PypiUploadCommand uploads wheels and source distributions to a PyPI repository in Artifactory, as twine upload does.
+// The files are deployed through the REST API of Artifactory, so twine and its .pypirc aren't needed.
+type PypiUploadCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+	repo               string
+	pattern            string
+}
+
+func NewPypiUploadCommand() *PypiUploadCommand {
+	return &PypiUploadCommand{}
+}
+
+func (puc *PypiUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *PypiUploadCommand {
+	puc.serverDetails = serverDetails
+	return puc
+}
+
+func (puc *PypiUploadCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *PypiUploadCommand {
+	puc.buildConfiguration = buildConfiguration
+	return puc
+}
+
+func (puc *PypiUploadCommand) SetRepo(repo string) *PypiUploadCommand {
+	puc.repo = repo
+	return puc
+}
+
+// SetPattern sets the files to upload, as a path which may include the wildcards of filepath.Match, such as dist/*.
+func (puc *PypiUploadCommand) SetPattern(pattern string) *PypiUploadCommand {
+	puc.pattern = pattern
+	return puc
+}
+
+func (puc *PypiUploadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return puc.serverDetails, nil
+}
+
+func (puc *PypiUploadCommand) CommandName() string {
+	return "rt_pypi_upload"
+}
+
+// Run uploads the distributions which match the pattern to <repo>/<normalized name>/<version>/, with the PEP 503 metadata properties
+// of the PyPI repositories. The checksums of the files are deployed first, so files which already exist in Artifactory aren't sent again.
+// When the build-info is collected, the distributions are added to it as artifacts.
+func (puc *PypiUploadCommand) Run() (err error) {
+	if puc.repo == "" {
+		return errorutils.CheckErrorf("the PyPI repository to upload to must be set with --repo")
+	}
+	distributions, err := findPypiDistributions(puc.pattern)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo := false
+	if puc.buildConfiguration != nil {
+		if collectBuildInfo, err = puc.buildConfiguration.IsCollectBuildInfo(); err != nil {
+			return err
+		}
+	}
+	var buildProps string
+	if collectBuildInfo {
+		if buildProps, err = buildUtils.CreateBuildPropsFromConfiguration(puc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(puc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	// The distributions of each project are added to the build-info module of the project.
+	artifactsByProject := make(map[string][]entities.Artifact)
+	for _, distribution := range distributions {
+		artifacts, err := puc.uploadDistribution(servicesManager, distribution, buildProps)
+		if err != nil {
+			return err
+		}
+		artifactsByProject[distribution.normalizedName] = append(artifactsByProject[distribution.normalizedName], artifacts...)
+	}
+	log.Info(fmt.Sprintf("Uploaded %d distributions to '%s'.", len(distributions), puc.repo))
+	if !collectBuildInfo {
+		return nil
+	}
+	return puc.addArtifactsToBuildInfo(artifactsByProject)
+}
+
+func (puc *PypiUploadCommand) uploadDistribution(servicesManager artifactory.ArtifactoryServicesManager, distribution pypiDistribution, buildProps string) (artifacts []entities.Artifact, err error) {
+	target := path.Join(puc.repo, distribution.normalizedName, distribution.version) + "/"
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &servicesUtils.CommonParams{Pattern: filepath.ToSlash(distribution.localPath), Target: target, TargetProps: distribution.props}
+	uploadParams.Flat = true
+	uploadParams.BuildProps = buildProps
+	// Every distribution is deployed by its checksum, if Artifactory already has its content.
+	uploadParams.ChecksumsCalcEnabled = true
+	uploadParams.MinChecksumDeploy = 0
+	log.Info(fmt.Sprintf("Uploading %s to %s...", filepath.Base(distribution.localPath), target))
+	summary, err := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParams)
+	if err != nil {
+		return nil, err
+	}
+	defer gofrogcmd.Close(summary, &err)
+	if summary.TotalFailed > 0 || summary.TotalSucceeded == 0 {
+		return nil, errorutils.CheckErrorf("failed to upload %s to %s", distribution.localPath, target)
+	}
+	if artifacts, err = servicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader); err != nil {
+		return nil, err
+	}
+	for i := range artifacts {
+		artifacts[i].Type = distribution.artifactType
+	}
+	return artifacts, nil
+}
+
+// addArtifactsToBuildInfo adds the uploaded distributions to the build-info. Unless the module is set, the module of each project
+// is named after the project.
+func (puc *PypiUploadCommand) addArtifactsToBuildInfo(artifactsByProject map[string][]entities.Artifact) error {
+	module := puc.buildConfiguration.GetModule()
+	projects := make([]string, 0, len(artifactsByProject))
+	for project := range artifactsByProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	for _, project := range projects {
+		if module == "" {
+			puc.buildConfiguration.SetModule(project)
+		}
+		if err := buildUtils.PopulateBuildArtifactsAsPartials(artifactsByProject[project], puc.buildConfiguration, entities.Python); err != nil {
+			return err
+		}
+	}
+	puc.buildConfiguration.SetModule(module)
+	return nil
+}
+
+// findPypiDistributions returns the wheels and the source distributions which match the pattern, with the properties of their metadata.
+func findPypiDistributions(pattern string) ([]pypiDistribution, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the files pattern '%s' is invalid: %s", pattern, err.Error())
+	}
+	var distributions []pypiDistribution
+	for _, match := range matches {
+		artifactType := getArtifactType(match)
+		if artifactType == "" {
+			log.Debug("Skipping", match+", which isn't a wheel or a source distribution.")
+			continue
+		}
+		fileInfo, err := os.Stat(match)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if fileInfo.IsDir() {
+			continue
+		}
+		distribution, err := readPypiDistribution(match, artifactType)
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, distribution)
+	}
+	if len(distributions) == 0 {
+		return nil, errorutils.CheckErrorf("no wheels or source distributions were found in %s", pattern)
+	}
+	return distributions, nil
+}
+
+// readPypiDistribution returns the distribution with the pypi.name, pypi.normalized.name, pypi.version and pypi.summary properties,
+// which the PyPI repositories of Artifactory use for their simple index. The name and the version are read from the metadata of the
+// distribution, or from its file name if the metadata isn't found.
+func readPypiDistribution(localPath, artifactType string) (pypiDistribution, error) {
+	metadataProps, err := fileprops.ExtractPythonDistributionProps(localPath)
+	if err != nil {
+		return pypiDistribution{}, err
+	}
+	fileName, fileVersion, ok := parseDistributionFileName(filepath.Base(localPath))
+	name, version := metadataProps["pypi.name"], metadataProps["pypi.version"]
+	if name == "" || version == "" {
+		if !ok {
+			return pypiDistribution{}, errorutils.CheckErrorf("the name and the version of %s weren't found in its metadata or in its file name", localPath)
+		}
+		name, version = fileName, fileVersion
+	}
+	distribution := pypiDistribution{localPath: localPath, normalizedName: normalizeRequirementName(name), version: version, artifactType: artifactType, props: servicesUtils.NewProperties()}
+	distribution.props.AddProperty("pypi.name", name)
+	distribution.props.AddProperty("pypi.normalized.name", distribution.normalizedName)
+	distribution.props.AddProperty("pypi.version", version)
+	if summary := metadataProps["pypi.summary"]; summary != "" {
+		distribution.props.AddProperty("pypi.summary", summary)
+	}
+	return distribution, nil
+}
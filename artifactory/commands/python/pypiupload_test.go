@@ -0,0 +1,90 @@
+package python
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestWheel(t *testing.T, wheelPath, metadata string) {
+	file, err := os.Create(wheelPath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(file)
+	entry, err := zipWriter.Create("acme_tools-2.1.0.dist-info/METADATA")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(metadata))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, file.Close())
+}
+
+func writeTestSdist(t *testing.T, sdistPath string) {
+	file, err := os.Create(sdistPath)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	setupPy := []byte("from setuptools import setup\n")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "acme_tools-2.1.0/setup.py", Mode: 0644, Size: int64(len(setupPy))}))
+	_, err = tarWriter.Write(setupPy)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestFindPypiDistributions(t *testing.T) {
+	distDir := t.TempDir()
+	writeTestWheel(t, filepath.Join(distDir, "acme_tools-2.1.0-py3-none-any.whl"), "Name: Acme.Tools\nVersion: 2.1.0\nSummary: Tools, for Acme\n")
+	// A source distribution without a PKG-INFO is named after its file.
+	writeTestSdist(t, filepath.Join(distDir, "acme_tools-2.1.0.tar.gz"))
+	require.NoError(t, os.WriteFile(filepath.Join(distDir, "README.md"), []byte{}, 0644))
+
+	distributions, err := findPypiDistributions(filepath.Join(distDir, "*"))
+	require.NoError(t, err)
+	require.Len(t, distributions, 2)
+	assert.Equal(t, "acme-tools", distributions[0].normalizedName)
+	assert.Equal(t, "2.1.0", distributions[0].version)
+	assert.Equal(t, "wheel", distributions[0].artifactType)
+	assert.Equal(t, map[string][]string{"pypi.name": {"Acme.Tools"}, "pypi.normalized.name": {"acme-tools"}, "pypi.version": {"2.1.0"}, "pypi.summary": {"Tools, for Acme"}},
+		distributions[0].props.ToMap())
+	assert.Equal(t, "sdist", distributions[1].artifactType)
+	assert.Equal(t, map[string][]string{"pypi.name": {"acme-tools"}, "pypi.normalized.name": {"acme-tools"}, "pypi.version": {"2.1.0"}}, distributions[1].props.ToMap())
+
+	_, err = findPypiDistributions(filepath.Join(distDir, "*.md"))
+	assert.ErrorContains(t, err, "no wheels or source distributions were found in")
+}
+
+func TestPypiUpload(t *testing.T) {
+	var lock sync.Mutex
+	var uploadedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		uploadedPaths = append(uploadedPaths, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	distDir := t.TempDir()
+	writeTestWheel(t, filepath.Join(distDir, "acme_tools-2.1.0-py3-none-any.whl"), "Name: acme-tools\nVersion: 2.1.0\n")
+
+	uploadCommand := NewPypiUploadCommand().SetRepo("pypi-local").SetPattern(filepath.Join(distDir, "*.whl")).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+	require.NoError(t, uploadCommand.Run())
+	assert.Contains(t, uploadedPaths, "/artifactory/pypi-local/acme-tools/2.1.0/acme_tools-2.1.0-py3-none-any.whl;pypi.name=acme-tools;pypi.normalized.name=acme-tools;pypi.version=2.1.0")
+
+	assert.ErrorContains(t, uploadCommand.SetRepo("").Run(), "the PyPI repository to upload to must be set")
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// RepoCreateRemoteCommand creates a remote repository proxying the upstream registry of a preset, with its recommended settings.
+type RepoCreateRemoteCommand struct {
+	serverDetails *config.ServerDetails
+	presetName    string
+	repoKey       string
+	packageType   string
+	projectKey    string
+}
+
+func NewRepoCreateRemoteCommand() *RepoCreateRemoteCommand {
+	return &RepoCreateRemoteCommand{}
+}
+
+func (rcrc *RepoCreateRemoteCommand) SetPresetName(presetName string) *RepoCreateRemoteCommand {
+	rcrc.presetName = presetName
+	return rcrc
+}
+
+func (rcrc *RepoCreateRemoteCommand) SetRepoKey(repoKey string) *RepoCreateRemoteCommand {
+	rcrc.repoKey = repoKey
+	return rcrc
+}
+
+// SetPackageType sets the package type of the repository, for presets supporting several package types.
+func (rcrc *RepoCreateRemoteCommand) SetPackageType(packageType string) *RepoCreateRemoteCommand {
+	rcrc.packageType = packageType
+	return rcrc
+}
+
+func (rcrc *RepoCreateRemoteCommand) SetProjectKey(projectKey string) *RepoCreateRemoteCommand {
+	rcrc.projectKey = projectKey
+	return rcrc
+}
+
+func (rcrc *RepoCreateRemoteCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoCreateRemoteCommand {
+	rcrc.serverDetails = serverDetails
+	return rcrc
+}
+
+func (rcrc *RepoCreateRemoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rcrc.serverDetails, nil
+}
+
+func (rcrc *RepoCreateRemoteCommand) CommandName() string {
+	return "rt_repo_create_remote"
+}
+
+func (rcrc *RepoCreateRemoteCommand) Run() (err error) {
+	repoConfigMap, err := rcrc.buildRepoConfigMap()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(rcrc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if err = (&SingleRepositoryHandler{}).Execute([]map[string]interface{}{repoConfigMap}, servicesManager, false); err != nil {
+		return err
+	}
+	log.Info("Successfully created the remote repository " + rcrc.repoKey + " proxying " + repoConfigMap[Url].(string) + ".")
+	return nil
+}
+
+func (rcrc *RepoCreateRemoteCommand) buildRepoConfigMap() (map[string]interface{}, error) {
+	preset, err := getRemoteRepoPreset(rcrc.presetName)
+	if err != nil {
+		return nil, err
+	}
+	repoConfigMap, err := preset.newRemoteRepoConfigMap(rcrc.repoKey, rcrc.packageType)
+	if err != nil {
+		return nil, err
+	}
+	if rcrc.projectKey != "" {
+		repoConfigMap[ProjectKey] = rcrc.projectKey
+	}
+	return repoConfigMap, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// RepoCreateSetCommand creates a local, a remote and a virtual repository of the same package type.
+// The virtual repository aggregates the local repository followed by the remote repository, so that internal packages
+// are resolved before packages of the public registry, and deploys to the local repository.
+// If one of the repositories fails to be created, the repositories which were already created are deleted.
+type RepoCreateSetCommand struct {
+	serverDetails *config.ServerDetails
+	packageType   string
+	name          string
+	remoteUrl     string
+	remotePreset  string
+	projectKey    string
+	includeRepos  []string
+}
+
+func NewRepoCreateSetCommand() *RepoCreateSetCommand {
+	return &RepoCreateSetCommand{}
+}
+
+func (rcsc *RepoCreateSetCommand) SetPackageType(packageType string) *RepoCreateSetCommand {
+	rcsc.packageType = packageType
+	return rcsc
+}
+
+// SetName sets the name shared by the repositories. The repository keys are <name>-<package type>-<rclass>.
+func (rcsc *RepoCreateSetCommand) SetName(name string) *RepoCreateSetCommand {
+	rcsc.name = name
+	return rcsc
+}
+
+func (rcsc *RepoCreateSetCommand) SetRemoteUrl(remoteUrl string) *RepoCreateSetCommand {
+	rcsc.remoteUrl = remoteUrl
+	return rcsc
+}
+
+// SetRemotePreset sets the preset of the remote repository. By default, the preset of the package type is used, if there is one.
+func (rcsc *RepoCreateSetCommand) SetRemotePreset(remotePreset string) *RepoCreateSetCommand {
+	rcsc.remotePreset = remotePreset
+	return rcsc
+}
+
+// SetIncludeRepos sets existing repositories, such as other virtual repositories, which the virtual repository includes as well.
+// The included repositories are ordered by their class: the local repositories, then the virtual repositories, and then the
+// remote repositories, so that the created local and remote repositories keep resolving first and last.
+func (rcsc *RepoCreateSetCommand) SetIncludeRepos(includeRepos []string) *RepoCreateSetCommand {
+	rcsc.includeRepos = includeRepos
+	return rcsc
+}
+
+func (rcsc *RepoCreateSetCommand) SetProjectKey(projectKey string) *RepoCreateSetCommand {
+	rcsc.projectKey = projectKey
+	return rcsc
+}
+
+func (rcsc *RepoCreateSetCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoCreateSetCommand {
+	rcsc.serverDetails = serverDetails
+	return rcsc
+}
+
+func (rcsc *RepoCreateSetCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rcsc.serverDetails, nil
+}
+
+func (rcsc *RepoCreateSetCommand) CommandName() string {
+	return "rt_repo_create_set"
+}
+
+func (rcsc *RepoCreateSetCommand) Run() (err error) {
+	servicesManager, err := rtUtils.CreateServiceManager(rcsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	includedRepos, err := rcsc.getIncludedRepos(servicesManager)
+	if err != nil {
+		return err
+	}
+	repoConfigMaps, err := rcsc.buildRepoConfigMaps(includedRepos)
+	if err != nil {
+		return err
+	}
+	// The repositories are created one by one, since the virtual repository can only include existing repositories.
+	var createdKeys []string
+	for _, repoConfigMap := range repoConfigMaps {
+		repoKey := repoConfigMap[Key].(string)
+		if err = (&SingleRepositoryHandler{}).Execute([]map[string]interface{}{repoConfigMap}, servicesManager, false); err != nil {
+			return errors.Join(err, deleteCreatedRepos(servicesManager, createdKeys))
+		}
+		createdKeys = append(createdKeys, repoKey)
+	}
+	log.Info("Successfully created the repositories " + createdKeys[0] + ", " + createdKeys[1] + " and " + createdKeys[2] + ".")
+	return nil
+}
+
+// getIncludedRepos returns the details of the included repositories, which must exist and be of the package type.
+func (rcsc *RepoCreateSetCommand) getIncludedRepos(servicesManager artifactory.ArtifactoryServicesManager) ([]services.RepositoryDetails, error) {
+	var includedRepos []services.RepositoryDetails
+	for _, repoKey := range rcsc.includeRepos {
+		repoDetails := services.RepositoryDetails{}
+		if err := servicesManager.GetRepository(repoKey, &repoDetails); err != nil {
+			return nil, errorutils.CheckErrorf("failed to get the details of the included repository '%s': %s", repoKey, err.Error())
+		}
+		if !strings.EqualFold(repoDetails.PackageType, rcsc.packageType) {
+			return nil, errorutils.CheckErrorf("the included repository '%s' is of the package type '%s' rather than '%s'", repoKey, repoDetails.PackageType, rcsc.packageType)
+		}
+		includedRepos = append(includedRepos, repoDetails)
+	}
+	return includedRepos, nil
+}
+
+// deleteCreatedRepos deletes the repositories which were created before the creation of the set failed, in the reverse order of
+// their creation, so that the virtual repository is deleted before the repositories it includes.
+func deleteCreatedRepos(servicesManager artifactory.ArtifactoryServicesManager, createdKeys []string) error {
+	var errs []error
+	for i := len(createdKeys) - 1; i >= 0; i-- {
+		log.Info("Deleting the repository " + createdKeys[i] + ", which was created before the failure...")
+		if err := servicesManager.DeleteRepository(createdKeys[i]); err != nil {
+			errs = append(errs, errorutils.CheckErrorf("failed to delete the repository '%s': %s", createdKeys[i], err.Error()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// buildRepoConfigMaps returns the configurations of the local, remote and virtual repositories, in their creation order.
+// The virtual repository includes the included repositories as well, ordered by their class.
+func (rcsc *RepoCreateSetCommand) buildRepoConfigMaps(includedRepos []services.RepositoryDetails) ([]map[string]interface{}, error) {
+	packageType := strings.ToLower(rcsc.packageType)
+	if localRepoHandlers[packageType] == nil || remoteRepoHandlers[packageType] == nil || virtualRepoHandlers[packageType] == nil {
+		return nil, errorutils.CheckErrorf("the package type '%s' doesn't support local, remote and virtual repositories", rcsc.packageType)
+	}
+	if rcsc.name == "" {
+		return nil, errorutils.CheckErrorf("the repositories name is missing")
+	}
+	remotePreset := rcsc.remotePreset
+	if remotePreset == "" {
+		remotePreset = findRemoteRepoPreset(packageType)
+	}
+	if remotePreset == "" && rcsc.remoteUrl == "" {
+		return nil, errorutils.CheckErrorf("no remote repository preset exists for the package type '%s'. Provide the URL of the remote repository", packageType)
+	}
+
+	keyPrefix := rcsc.name + "-" + packageType + "-"
+	// Repository keys of a project must start with "<projectKey>-".
+	if rcsc.projectKey != "" && !strings.HasPrefix(keyPrefix, rcsc.projectKey+"-") {
+		keyPrefix = rcsc.projectKey + "-" + keyPrefix
+	}
+	localKey, remoteKey, virtualKey := keyPrefix+Local, keyPrefix+Remote, keyPrefix+Virtual
+
+	newRepoConfigMap := func(key, rclass string) map[string]interface{} {
+		repoConfigMap := map[string]interface{}{Key: key, Rclass: rclass, PackageType: packageType}
+		if rcsc.projectKey != "" {
+			repoConfigMap[ProjectKey] = rcsc.projectKey
+		}
+		return repoConfigMap
+	}
+	local := newRepoConfigMap(localKey, Local)
+	remote := newRepoConfigMap(remoteKey, Remote)
+	if remotePreset != "" {
+		preset, err := getRemoteRepoPreset(remotePreset)
+		if err != nil {
+			return nil, err
+		}
+		presetConfigMap, err := preset.newRemoteRepoConfigMap(remoteKey, packageType)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range presetConfigMap {
+			remote[key] = value
+		}
+	}
+	if rcsc.remoteUrl != "" {
+		remote[Url] = rcsc.remoteUrl
+	}
+	virtual := newRepoConfigMap(virtualKey, Virtual)
+	// Repositories is a comma-separated list, which is converted to an array by its answer writer.
+	virtual[Repositories] = strings.Join(orderVirtualRepos(localKey, remoteKey, includedRepos), ",")
+	virtual[DefaultDeploymentRepo] = localKey
+	return []map[string]interface{}{local, remote, virtual}, nil
+}
+
+// orderVirtualRepos returns the repositories included by the virtual repository, in their resolution order: the local repositories,
+// starting by the created one, then the nested virtual repositories, and then the remote repositories, ending by the created one.
+func orderVirtualRepos(localKey, remoteKey string, includedRepos []services.RepositoryDetails) []string {
+	locals, virtuals, remotes := []string{localKey}, []string{}, []string{}
+	included := map[string]bool{localKey: true, remoteKey: true}
+	for _, repo := range includedRepos {
+		if included[repo.Key] {
+			continue
+		}
+		included[repo.Key] = true
+		switch strings.ToLower(repo.GetRepoType()) {
+		case Virtual:
+			virtuals = append(virtuals, repo.Key)
+		case Remote:
+			remotes = append(remotes, repo.Key)
+		default:
+			// Local and federated repositories.
+			locals = append(locals, repo.Key)
+		}
+	}
+	return append(append(append(locals, virtuals...), remotes...), remoteKey)
+}
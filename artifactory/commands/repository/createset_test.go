@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoCreateSetBuildRepoConfigMaps(t *testing.T) {
+	repoConfigMaps, err := NewRepoCreateSetCommand().SetPackageType("Npm").SetName("team").SetProjectKey("proj").buildRepoConfigMaps(nil)
+	require.NoError(t, err)
+	require.Len(t, repoConfigMaps, 3)
+	assert.Equal(t, map[string]interface{}{Key: "proj-team-npm-local", Rclass: Local, PackageType: Npm, ProjectKey: "proj"}, repoConfigMaps[0])
+	assert.Equal(t, map[string]interface{}{Key: "proj-team-npm-remote", Rclass: Remote, PackageType: Npm, ProjectKey: "proj", Url: "https://registry.npmjs.org",
+		RetrievalCachePeriodSecs: "600", MissedRetrievalCachePeriodSecs: "1800"}, repoConfigMaps[1])
+	assert.Equal(t, map[string]interface{}{Key: "proj-team-npm-virtual", Rclass: Virtual, PackageType: Npm, ProjectKey: "proj",
+		Repositories: "proj-team-npm-local,proj-team-npm-remote", DefaultDeploymentRepo: "proj-team-npm-local"}, repoConfigMaps[2])
+
+	// The project prefix isn't duplicated.
+	repoConfigMaps, err = NewRepoCreateSetCommand().SetPackageType(Maven).SetName("proj-team").SetProjectKey("proj").SetRemoteUrl("https://mirror.example.com").buildRepoConfigMaps(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "proj-team-maven-local", repoConfigMaps[0][Key])
+	assert.Equal(t, "https://mirror.example.com", repoConfigMaps[1][Url])
+	assert.Equal(t, "false", repoConfigMaps[1][HandleSnapshots])
+
+	// Without a preset, the remote URL is mandatory.
+	repoConfigMaps, err = NewRepoCreateSetCommand().SetPackageType(Generic).SetName("team").SetRemoteUrl("https://downloads.example.com").buildRepoConfigMaps(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{Key: "team-generic-remote", Rclass: Remote, PackageType: Generic, Url: "https://downloads.example.com"}, repoConfigMaps[1])
+}
+
+func TestRepoCreateSetBuildRepoConfigMapsIncludedRepos(t *testing.T) {
+	includedRepos := []services.RepositoryDetails{
+		{Key: "shared-npm-remote", Rclass: Remote, PackageType: Npm},
+		{Key: "shared-npm-virtual", Rclass: Virtual, PackageType: Npm},
+		{Key: "shared-npm-local", Rclass: Local, PackageType: Npm},
+		{Key: "team-npm-local", Rclass: Local, PackageType: Npm},
+		{Key: "shared-npm-virtual", Rclass: Virtual, PackageType: Npm},
+	}
+	repoConfigMaps, err := NewRepoCreateSetCommand().SetPackageType(Npm).SetName("team").buildRepoConfigMaps(includedRepos)
+	require.NoError(t, err)
+	// The local repositories are resolved first, then the nested virtual repositories, and then the remote repositories.
+	assert.Equal(t, "team-npm-local,shared-npm-local,shared-npm-virtual,shared-npm-remote,team-npm-remote", repoConfigMaps[2][Repositories])
+	assert.Equal(t, "team-npm-local", repoConfigMaps[2][DefaultDeploymentRepo])
+}
+
+func TestRepoCreateSetBuildRepoConfigMapsErrors(t *testing.T) {
+	_, err := NewRepoCreateSetCommand().SetPackageType(Vagrant).SetName("team").buildRepoConfigMaps(nil)
+	assert.ErrorContains(t, err, "doesn't support local, remote and virtual repositories")
+	_, err = NewRepoCreateSetCommand().SetPackageType(Generic).SetName("team").buildRepoConfigMaps(nil)
+	assert.ErrorContains(t, err, "no remote repository preset exists")
+	_, err = NewRepoCreateSetCommand().SetPackageType(Npm).SetName("team").SetRemotePreset("pypi").buildRepoConfigMaps(nil)
+	assert.ErrorContains(t, err, "the preset doesn't support the package type 'npm'")
+	_, err = NewRepoCreateSetCommand().SetPackageType(Npm).buildRepoConfigMaps(nil)
+	assert.ErrorContains(t, err, "name is missing")
+}
+
+func TestRepoCreateSetRun(t *testing.T) {
+	var createdRepos []map[string]interface{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		content, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		repo := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(content, &repo))
+		assert.Equal(t, "/api/repositories/"+repo[Key].(string), r.URL.Path)
+		createdRepos = append(createdRepos, repo)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	createSetCmd := NewRepoCreateSetCommand().SetPackageType(Go).SetName("team").
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	require.NoError(t, createSetCmd.Run())
+
+	require.Len(t, createdRepos, 3)
+	assert.Equal(t, "team-go-local", createdRepos[0][Key])
+	assert.Equal(t, "team-go-remote", createdRepos[1][Key])
+	assert.Equal(t, "https://proxy.golang.org/", createdRepos[1][Url])
+	// The virtual repository is created last, since it includes the other repositories.
+	assert.Equal(t, "team-go-virtual", createdRepos[2][Key])
+	assert.Equal(t, []interface{}{"team-go-local", "team-go-remote"}, createdRepos[2][Repositories])
+	assert.Equal(t, "team-go-local", createdRepos[2][DefaultDeploymentRepo])
+}
+
+func TestRepoCreateSetRunIncludedRepos(t *testing.T) {
+	var virtualRepo map[string]interface{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repoKey := strings.TrimPrefix(r.URL.Path, "/api/repositories/")
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"key":"` + repoKey + `","rclass":"virtual","packageType":"go"}`))
+			assert.NoError(t, err)
+			return
+		}
+		if repoKey == "team-go-virtual" {
+			content, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(content, &virtualRepo))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	createSetCmd := NewRepoCreateSetCommand().SetPackageType(Go).SetName("team").SetIncludeRepos([]string{"shared-go-virtual"}).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	require.NoError(t, createSetCmd.Run())
+	assert.Equal(t, []interface{}{"team-go-local", "shared-go-virtual", "team-go-remote"}, virtualRepo[Repositories])
+
+	// The included repositories must be of the package type of the set.
+	createSetCmd.SetPackageType(Npm)
+	assert.ErrorContains(t, createSetCmd.Run(), "is of the package type 'go' rather than 'npm'")
+}
+
+func TestRepoCreateSetRunRollback(t *testing.T) {
+	var requests []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "-virtual") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	createSetCmd := NewRepoCreateSetCommand().SetPackageType(Go).SetName("team").
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	assert.Error(t, createSetCmd.Run())
+	// The repositories which were created before the failure are deleted, in the reverse order of their creation.
+	assert.Equal(t, []string{
+		"PUT /api/repositories/team-go-local",
+		"PUT /api/repositories/team-go-remote",
+		"PUT /api/repositories/team-go-virtual",
+		"DELETE /api/repositories/team-go-remote",
+		"DELETE /api/repositories/team-go-local",
+	}, requests)
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// RemoteRepoPreset describes a well-known upstream registry, and the recommended settings of remote repositories proxying it.
+type RemoteRepoPreset struct {
+	// The package types, which can proxy the registry. The first is used when no package type is requested.
+	PackageTypes []string
+	Url          string
+	// Remote repository configuration values by their JSON keys, in the format of the repository templates.
+	Settings map[string]string
+}
+
+// The metadata of these registries is updated frequently, so it is cached for a short period only.
+// Missing artifacts are cached longer, to reduce the requests to the upstream.
+var remoteRepoPresets = map[string]RemoteRepoPreset{
+	"maven-central": {
+		PackageTypes: []string{Maven, Gradle, Ivy, Sbt},
+		Url:          "https://repo1.maven.org/maven2/",
+		Settings: map[string]string{
+			HandleReleases:                 "true",
+			HandleSnapshots:                "false",
+			RetrievalCachePeriodSecs:       "7200",
+			MissedRetrievalCachePeriodSecs: "1800",
+			RemoteRepoChecksumPolicyType:   "generate-if-absent",
+		},
+	},
+	"npmjs": {
+		PackageTypes: []string{Npm},
+		Url:          "https://registry.npmjs.org",
+		Settings: map[string]string{
+			RetrievalCachePeriodSecs:       "600",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"pypi": {
+		PackageTypes: []string{Pypi},
+		Url:          "https://files.pythonhosted.org",
+		Settings: map[string]string{
+			PyPIRegistryUrl:                "https://pypi.org",
+			RetrievalCachePeriodSecs:       "600",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"docker-hub": {
+		PackageTypes: []string{Docker},
+		Url:          "https://registry-1.docker.io/",
+		Settings: map[string]string{
+			// Docker Hub requires token authentication, and limits the rate of anonymous pulls.
+			EnableTokenAuthentication:      "true",
+			BlockPushingSchema1:            "true",
+			RetrievalCachePeriodSecs:       "21600",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"go-proxy": {
+		PackageTypes: []string{Go},
+		Url:          "https://proxy.golang.org/",
+		Settings: map[string]string{
+			RetrievalCachePeriodSecs:       "7200",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"crates-io": {
+		PackageTypes: []string{Cargo},
+		Url:          "https://crates.io/",
+		Settings: map[string]string{
+			GitRegistryUrl:                 "https://github.com/rust-lang/crates.io-index",
+			RetrievalCachePeriodSecs:       "600",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"nuget-gallery": {
+		PackageTypes: []string{Nuget},
+		Url:          "https://www.nuget.org/",
+		Settings: map[string]string{
+			FeedContextPath:                "api/v2",
+			DownloadContextPath:            "api/v2/package",
+			V3FeedUrl:                      "https://api.nuget.org/v3/index.json",
+			RetrievalCachePeriodSecs:       "7200",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"rubygems": {
+		PackageTypes: []string{Gems},
+		Url:          "https://rubygems.org/",
+		Settings: map[string]string{
+			RetrievalCachePeriodSecs:       "7200",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+	"anaconda": {
+		PackageTypes: []string{Conda},
+		Url:          "https://repo.anaconda.com/pkgs/main",
+		Settings: map[string]string{
+			RetrievalCachePeriodSecs:       "7200",
+			MissedRetrievalCachePeriodSecs: "1800",
+		},
+	},
+}
+
+// GetRemoteRepoPresetNames returns the names of the presets, sorted.
+func GetRemoteRepoPresetNames() []string {
+	names := make([]string, 0, len(remoteRepoPresets))
+	for name := range remoteRepoPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getRemoteRepoPreset(name string) (RemoteRepoPreset, error) {
+	preset, ok := remoteRepoPresets[name]
+	if !ok {
+		return RemoteRepoPreset{}, errorutils.CheckErrorf("unknown remote repository preset '%s'. The available presets are: %v", name, GetRemoteRepoPresetNames())
+	}
+	return preset, nil
+}
+
+// findRemoteRepoPreset returns the name of the preset of the package type, or an empty string if there is none.
+func findRemoteRepoPreset(packageType string) string {
+	for _, name := range GetRemoteRepoPresetNames() {
+		for _, presetPackageType := range remoteRepoPresets[name].PackageTypes {
+			if presetPackageType == packageType {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// newRemoteRepoConfigMap returns the configuration of a remote repository of the package type, proxying the registry of the preset.
+// The package type may be empty, to use the default package type of the preset.
+func (preset RemoteRepoPreset) newRemoteRepoConfigMap(key, packageType string) (map[string]interface{}, error) {
+	if packageType == "" {
+		packageType = preset.PackageTypes[0]
+	}
+	supported := false
+	for _, presetPackageType := range preset.PackageTypes {
+		supported = supported || presetPackageType == packageType
+	}
+	if !supported {
+		return nil, errorutils.CheckErrorf("the preset doesn't support the package type '%s'. The supported package types are: %v", packageType, preset.PackageTypes)
+	}
+	repoConfigMap := map[string]interface{}{Key: key, Rclass: Remote, PackageType: packageType, Url: preset.Url}
+	for settingKey, value := range preset.Settings {
+		repoConfigMap[settingKey] = value
+	}
+	return repoConfigMap, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteRepoPresetsSettings(t *testing.T) {
+	for name, preset := range remoteRepoPresets {
+		require.NotEmpty(t, preset.PackageTypes, name)
+		for _, packageType := range preset.PackageTypes {
+			assert.NotNil(t, remoteRepoHandlers[packageType], "preset %s: package type %s", name, packageType)
+		}
+		// The settings must be written by the repository handlers, as the values of templates are.
+		for settingKey := range preset.Settings {
+			assert.Contains(t, writersMap, settingKey, "preset %s", name)
+		}
+	}
+}
+
+func TestFindRemoteRepoPreset(t *testing.T) {
+	assert.Equal(t, "maven-central", findRemoteRepoPreset(Gradle))
+	assert.Equal(t, "docker-hub", findRemoteRepoPreset(Docker))
+	assert.Empty(t, findRemoteRepoPreset(Generic))
+}
+
+func TestRepoCreateRemoteBuildRepoConfigMap(t *testing.T) {
+	repoConfigMap, err := NewRepoCreateRemoteCommand().SetPresetName("maven-central").SetRepoKey("gradle-remote").SetPackageType(Gradle).SetProjectKey("proj").buildRepoConfigMap()
+	require.NoError(t, err)
+	assert.Equal(t, Gradle, repoConfigMap[PackageType])
+	assert.Equal(t, "https://repo1.maven.org/maven2/", repoConfigMap[Url])
+	assert.Equal(t, "proj", repoConfigMap[ProjectKey])
+
+	repoConfigMap, err = NewRepoCreateRemoteCommand().SetPresetName("maven-central").SetRepoKey("maven-remote").buildRepoConfigMap()
+	require.NoError(t, err)
+	assert.Equal(t, Maven, repoConfigMap[PackageType])
+
+	_, err = NewRepoCreateRemoteCommand().SetPresetName("unknown").SetRepoKey("remote").buildRepoConfigMap()
+	assert.ErrorContains(t, err, "unknown remote repository preset 'unknown'")
+}
+
+func TestRepoCreateRemoteRun(t *testing.T) {
+	var createdRepo map[string]interface{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/repositories/docker-hub-remote", r.URL.Path)
+		content, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, &createdRepo))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	createRemoteCmd := NewRepoCreateRemoteCommand().SetPresetName("docker-hub").SetRepoKey("docker-hub-remote").
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	require.NoError(t, createRemoteCmd.Run())
+	assert.Equal(t, Docker, createdRepo[PackageType])
+	assert.Equal(t, "https://registry-1.docker.io/", createdRepo[Url])
+	assert.Equal(t, true, createdRepo[EnableTokenAuthentication])
+	assert.Equal(t, float64(21600), createdRepo[RetrievalCachePeriodSecs])
+}
@@ -241,6 +241,7 @@ var writersMap = map[string]ioutils.AnswerWriter{
 	ListRemoteFolderItems:             ioutils.WriteBoolAnswer,
 	RejectInvalidJars:                 ioutils.WriteBoolAnswer,
 	PodsSpecsRepoUrl:                  ioutils.WriteStringAnswer,
+	GitRegistryUrl:                    ioutils.WriteStringAnswer,
 	EnableTokenAuthentication:         ioutils.WriteBoolAnswer,
 	Repositories:                      ioutils.WriteStringArrayAnswer,
 	ArtifactoryRequestsCanRetrieveRemoteArtifacts: ioutils.WriteBoolAnswer,
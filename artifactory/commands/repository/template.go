@@ -114,6 +114,7 @@ const (
 	ListRemoteFolderItems             = "listRemoteFolderItems"
 	EnableTokenAuthentication         = "enableTokenAuthentication"
 	PodsSpecsRepoUrl                  = "podsSpecsRepoUrl"
+	GitRegistryUrl                    = "gitRegistryUrl"
 
 	// Unique virtual repository configuration JSON keys
 	Repositories                                  = "repositories"
@@ -0,0 +1,152 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+const (
+	// MavenBuildCacheServerID is the ID of the settings.xml server which authenticates the Maven build cache extension.
+	MavenBuildCacheServerID = "artifactory-build-cache"
+
+	mavenBuildCacheExtensionGroupId    = "org.apache.maven.extensions"
+	mavenBuildCacheExtensionArtifactId = "maven-build-cache-extension"
+	mavenBuildCacheExtensionVersion    = "1.2.0"
+
+	// jfrog-ignore - Maven build cache XML namespace URL, required by specification
+	mavenBuildCacheConfigXmlns = "http://maven.apache.org/BUILD-CACHE-CONFIG/1.0.0"
+	// jfrog-ignore - Maven extensions XML namespace URL, required by specification
+	mavenExtensionsXmlns = "http://maven.apache.org/EXTENSIONS/1.0.0"
+)
+
+// SetBuildCacheRepoName sets the generic repository used as the remote build cache of Gradle and Maven.
+// If empty, the build cache isn't configured.
+func (sc *SetupCommand) SetBuildCacheRepoName(buildCacheRepoName string) *SetupCommand {
+	sc.buildCacheRepoName = buildCacheRepoName
+	return sc
+}
+
+// SetBuildCachePush allows the builds to store their outputs in the remote build cache. This is usually enabled on CI only.
+func (sc *SetupCommand) SetBuildCachePush(buildCachePush bool) *SetupCommand {
+	sc.buildCachePush = buildCachePush
+	return sc
+}
+
+// configureMavenBuildCache configures the Maven build cache extension of the project in projectDir to use the build cache
+// repository as its remote cache. The extension is registered in .mvn/extensions.xml, the remote cache is set in
+// .mvn/maven-build-cache-config.xml, and its credentials are added as a server to the settings.xml file.
+func (sc *SetupCommand) configureMavenBuildCache(settingsXmlPath, projectDir, username, password string) error {
+	if err := updateXmlFile(settingsXmlPath, newSettingsXmlDocument, func(root *etree.Element) {
+		servers := getOrCreateChild(root, "servers")
+		server := findChildByText(servers, "server", "id", MavenBuildCacheServerID)
+		if server == nil {
+			server = servers.CreateElement("server")
+		}
+		getOrCreateChild(server, "id").SetText(MavenBuildCacheServerID)
+		getOrCreateChild(server, "username").SetText(username)
+		getOrCreateChild(server, "password").SetText(password)
+	}); err != nil {
+		return err
+	}
+
+	mvnDir := filepath.Join(projectDir, ".mvn")
+	if err := updateXmlFile(filepath.Join(mvnDir, "extensions.xml"), newMavenExtensionsDocument, func(root *etree.Element) {
+		for _, extension := range root.SelectElements("extension") {
+			if childText(extension, "groupId") == mavenBuildCacheExtensionGroupId && childText(extension, "artifactId") == mavenBuildCacheExtensionArtifactId {
+				// Keep the version the project already uses.
+				return
+			}
+		}
+		extension := root.CreateElement("extension")
+		extension.CreateElement("groupId").SetText(mavenBuildCacheExtensionGroupId)
+		extension.CreateElement("artifactId").SetText(mavenBuildCacheExtensionArtifactId)
+		extension.CreateElement("version").SetText(mavenBuildCacheExtensionVersion)
+	}); err != nil {
+		return err
+	}
+
+	repoUrl := strings.TrimSuffix(sc.serverDetails.GetArtifactoryUrl(), "/") + "/" + sc.buildCacheRepoName
+	return updateXmlFile(filepath.Join(mvnDir, "maven-build-cache-config.xml"), newMavenBuildCacheConfigDocument, func(root *etree.Element) {
+		configuration := getOrCreateChild(root, "configuration")
+		getOrCreateChild(configuration, "enabled").SetText("true")
+		remote := getOrCreateChild(configuration, "remote")
+		remote.CreateAttr("enabled", "true")
+		remote.CreateAttr("id", MavenBuildCacheServerID)
+		remote.CreateAttr("saveToRemote", strconv.FormatBool(sc.buildCachePush))
+		getOrCreateChild(remote, "url").SetText(repoUrl)
+	})
+}
+
+// updateXmlFile reads the XML file, or creates it using newDocument if it doesn't exist, updates its root element and writes it back.
+func updateXmlFile(filePath string, newDocument func() *etree.Document, update func(root *etree.Element)) error {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromFile(filePath); err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		doc = newDocument()
+	}
+	root := doc.Root()
+	if root == nil {
+		return fmt.Errorf("failed to read %s: the file has no root element", filePath)
+	}
+	update(root)
+	doc.Indent(2)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filePath, err)
+	}
+	if err := doc.WriteToFile(filePath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func newXmlDocument(rootName, xmlns string) *etree.Document {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	doc.CreateElement(rootName).CreateAttr("xmlns", xmlns)
+	return doc
+}
+
+func newSettingsXmlDocument() *etree.Document {
+	// jfrog-ignore - Maven XML namespace URL, required by specification
+	return newXmlDocument("settings", "http://maven.apache.org/SETTINGS/1.2.0")
+}
+
+func newMavenExtensionsDocument() *etree.Document {
+	return newXmlDocument("extensions", mavenExtensionsXmlns)
+}
+
+func newMavenBuildCacheConfigDocument() *etree.Document {
+	return newXmlDocument("cache", mavenBuildCacheConfigXmlns)
+}
+
+func getOrCreateChild(parent *etree.Element, name string) *etree.Element {
+	child := parent.SelectElement(name)
+	if child == nil {
+		child = parent.CreateElement(name)
+	}
+	return child
+}
+
+// findChildByText returns the child element whose field element has the given text, or nil if not found.
+func findChildByText(parent *etree.Element, name, field, text string) *etree.Element {
+	for _, child := range parent.SelectElements(name) {
+		if childText(child, field) == text {
+			return child
+		}
+	}
+	return nil
+}
+
+func childText(parent *etree.Element, name string) string {
+	if child := parent.SelectElement(name); child != nil {
+		return strings.TrimSpace(child.Text())
+	}
+	return ""
+}
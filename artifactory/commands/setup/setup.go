@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -75,8 +76,28 @@ type SetupCommand struct {
 	serverDetails *config.ServerDetails
 	// commandName specifies the command for this instance.
 	commandName string
+	// buildCacheRepoName is the generic repository used as the remote build cache of Gradle and Maven.
+	buildCacheRepoName string
+	// buildCachePush allows the builds to store their outputs in the remote build cache.
+	buildCachePush bool
+	// scope is where the configuration is written. The project scope is supported by Gradle only.
+	scope SetupScope
+	// gradleDsl is the language of the Gradle init script. If empty, it's detected from the existing init script.
+	gradleDsl gradle.InitScriptDsl
+	// verify checks that the package manager can resolve from the repository with the written configuration.
+	verify bool
 }
 
+// SetupScope is where the package manager configuration is written.
+type SetupScope string
+
+const (
+	// UserScope writes the configuration to the user's home, so that it applies to all the projects.
+	UserScope SetupScope = "user"
+	// ProjectScope writes the configuration to the project in the current directory.
+	ProjectScope SetupScope = "project"
+)
+
 // NewSetupCommand initializes a new SetupCommand for the specified package manager
 func NewSetupCommand(packageManager project.ProjectType) *SetupCommand {
 	return &SetupCommand{
@@ -143,11 +164,43 @@ func (sc *SetupCommand) SetProjectKey(projectKey string) *SetupCommand {
 	return sc
 }
 
+// SetScope sets where the configuration is written. If empty, it's written to the user's home.
+func (sc *SetupCommand) SetScope(scope SetupScope) *SetupCommand {
+	sc.scope = scope
+	return sc
+}
+
+// SetGradleDsl sets the language of the Gradle init script. If empty, the init script is written in the language of the one the JFrog CLI
+// already wrote, or in Groovy.
+func (sc *SetupCommand) SetGradleDsl(gradleDsl gradle.InitScriptDsl) *SetupCommand {
+	sc.gradleDsl = gradleDsl
+	return sc
+}
+
+// SetVerify checks, after the configuration is written, that the package manager can resolve from the repository with the
+// configured credentials. Supported by Gradle only.
+func (sc *SetupCommand) SetVerify(verify bool) *SetupCommand {
+	sc.verify = verify
+	return sc
+}
+
 // Run executes the configuration method corresponding to the package manager specified for the command.
 func (sc *SetupCommand) Run() (err error) {
 	if !IsSupportedPackageManager(sc.packageManager) {
 		return errorutils.CheckErrorf("unsupported package manager: %s", sc.packageManager)
 	}
+	switch sc.scope {
+	case "", UserScope:
+	case ProjectScope:
+		if sc.packageManager != project.Gradle {
+			return errorutils.CheckErrorf("the %s scope is supported by Gradle only", ProjectScope)
+		}
+	default:
+		return errorutils.CheckErrorf("unsupported scope: %s. The supported scopes are %s and %s", sc.scope, UserScope, ProjectScope)
+	}
+	if sc.verify && sc.packageManager != project.Gradle {
+		return errorutils.CheckErrorf("the verification of the setup is supported by Gradle only")
+	}
 
 	// If the repository name is not provided, and the package manager is not Docker or Podman, prompt the user to select a repository.
 	// Docker and Podman do not require a repository name as they authenticate directly with the platform and require the repository name as part of the image name.
@@ -450,6 +503,7 @@ func (sc *SetupCommand) configureContainer() error {
 }
 
 // configureMaven updates the Maven settings.xml file to use the repo Url as mirror.
+// If a build cache repository is set, the Maven build cache extension of the project in the current directory is configured to use it as its remote cache.
 func (sc *SetupCommand) configureMaven() error {
 	username := sc.serverDetails.GetUser()
 	password := sc.serverDetails.GetPassword()
@@ -469,10 +523,25 @@ func (sc *SetupCommand) configureMaven() error {
 	if err = settingsXml.ConfigureArtifactoryRepository(sc.serverDetails.GetArtifactoryUrl(), sc.repoName, username, password); err != nil {
 		return fmt.Errorf("failed to update Artifactory mirror in Maven settings.xml: %w", err)
 	}
+	if sc.buildCacheRepoName == "" {
+		return nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = sc.configureMavenBuildCache(filepath.Join(homeDir, ".m2", "settings.xml"), projectDir, username, password); err != nil {
+		return fmt.Errorf("failed to configure the Maven build cache: %w", err)
+	}
 	return nil
 }
 
 // configureGradle configures Gradle to use the specified Artifactory repository for both dependency resolution and publishing.
+// If a build cache repository is set, it's also configured as the remote build cache.
 func (sc *SetupCommand) configureGradle() error {
 	password := sc.serverDetails.GetPassword()
 	username := sc.serverDetails.GetUser()
@@ -480,20 +549,48 @@ func (sc *SetupCommand) configureGradle() error {
 		password = sc.serverDetails.GetAccessToken()
 		username = auth.ExtractUsernameFromAccessToken(password)
 	}
+	projectDir := ""
+	initScriptsDir := gradle.UserInitScriptsDir()
+	if sc.scope == ProjectScope {
+		var err error
+		if projectDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+		initScriptsDir = gradle.ProjectInitScriptsDirPath(projectDir)
+	}
+	dsl := sc.gradleDsl
+	if dsl == "" {
+		dsl = gradle.DetectInitScriptDsl(initScriptsDir)
+	}
 	initScriptAuthConfig := gradle.InitScriptAuthConfig{
 		ArtifactoryURL:         sc.serverDetails.GetArtifactoryUrl(),
 		GradleRepoName:         sc.repoName,
 		ArtifactoryAccessToken: password,
 		ArtifactoryUsername:    username,
+		BuildCacheRepoName:     sc.buildCacheRepoName,
+		BuildCachePush:         sc.buildCachePush,
+		Dsl:                    dsl,
 	}
 	initScript, err := gradle.GenerateInitScript(initScriptAuthConfig)
 	if err != nil {
 		return fmt.Errorf("failed to generate Gradle init script: %w", err)
 	}
 
-	if err := gradle.WriteInitScript(initScript); err != nil {
+	if sc.scope == ProjectScope {
+		initScriptPath, err := gradle.WriteProjectInitScript(initScript, projectDir, dsl)
+		if err != nil {
+			return fmt.Errorf("failed to write Gradle init script: %w", err)
+		}
+		log.Info(fmt.Sprintf("The Gradle init script was written to %s. Apply it to the builds of the project by running Gradle with --init-script %s.", initScriptPath, filepath.ToSlash(filepath.Join(gradle.ProjectInitScriptsDir, dsl.InitScriptName()))))
+	} else if err := gradle.WriteInitScriptWithDsl(initScript, dsl); err != nil {
 		return fmt.Errorf("failed to write Gradle init script: %w", err)
 	}
+	if !sc.verify {
+		return nil
+	}
+	if err = gradle.VerifyResolution(sc.serverDetails, []string{sc.repoName}, username, password); err != nil {
+		return fmt.Errorf("the Gradle init script was written, but its verification failed: %w", err)
+	}
 	return nil
 }
 
@@ -817,3 +817,115 @@ func TestSetupCommand_MavenCorrupted(t *testing.T) {
 		assert.NotContains(t, content, testCredential(), "Old token should be replaced")
 	})
 }
+
+func TestSetupCommand_GradleBuildCache(t *testing.T) {
+	testGradleUserHome := t.TempDir()
+	t.Setenv(gradle.UserHomeEnv, testGradleUserHome)
+	gradleLoginCmd := createTestSetupCommand(project.Gradle).SetBuildCacheRepoName("gradle-build-cache").SetBuildCachePush(true)
+	gradleLoginCmd.serverDetails.SetAccessToken(testCredential())
+	require.NoError(t, gradleLoginCmd.Run())
+
+	contentBytes, err := os.ReadFile(filepath.Join(testGradleUserHome, "init.d", gradle.InitScriptName))
+	require.NoError(t, err)
+	content := string(contentBytes)
+	assert.Contains(t, content, "def buildCacheRepoName = 'gradle-build-cache'")
+	assert.Contains(t, content, "def buildCachePush = true")
+}
+
+func TestConfigureMavenBuildCache(t *testing.T) {
+	tempDir := t.TempDir()
+	settingsXmlPath := filepath.Join(tempDir, ".m2", "settings.xml")
+	projectDir := filepath.Join(tempDir, "project")
+	extensionsXmlPath := filepath.Join(projectDir, ".mvn", "extensions.xml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(extensionsXmlPath), 0o755))
+	// The existing content of the project's extensions.xml is preserved.
+	require.NoError(t, os.WriteFile(extensionsXmlPath, []byte(`<extensions><extension><groupId>kr.motd.maven</groupId><artifactId>os-maven-plugin</artifactId><version>1.7.1</version></extension></extensions>`), 0o644))
+
+	mavenLoginCmd := createTestSetupCommand(project.Maven).SetBuildCacheRepoName("maven-build-cache")
+	require.NoError(t, mavenLoginCmd.configureMavenBuildCache(settingsXmlPath, projectDir, "myUser", "myPassword"))
+	// Running the setup again doesn't duplicate the configuration.
+	require.NoError(t, mavenLoginCmd.configureMavenBuildCache(settingsXmlPath, projectDir, "myUser", "myPassword"))
+
+	settingsXml, err := os.ReadFile(settingsXmlPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(settingsXml), "<id>"+MavenBuildCacheServerID+"</id>"))
+	assert.Contains(t, string(settingsXml), "<username>myUser</username>")
+	assert.Contains(t, string(settingsXml), "<password>myPassword</password>")
+
+	extensionsXml, err := os.ReadFile(extensionsXmlPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(extensionsXml), "<artifactId>os-maven-plugin</artifactId>")
+	assert.Equal(t, 1, strings.Count(string(extensionsXml), "<artifactId>maven-build-cache-extension</artifactId>"))
+
+	buildCacheConfig, err := os.ReadFile(filepath.Join(projectDir, ".mvn", "maven-build-cache-config.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(buildCacheConfig), `<remote enabled="true" id="artifactory-build-cache" saveToRemote="false">`)
+	assert.Contains(t, string(buildCacheConfig), "<url>https://acme.jfrog.io/artifactory/maven-build-cache</url>")
+}
+
+func TestSetupCommand_GradleProjectScope(t *testing.T) {
+	userGradleHome := t.TempDir()
+	t.Setenv(gradle.UserHomeEnv, userGradleHome)
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	gradleLoginCmd := createTestSetupCommand(project.Gradle).SetScope(ProjectScope)
+	gradleLoginCmd.serverDetails.SetAccessToken(testCredential())
+	require.NoError(t, gradleLoginCmd.Run())
+
+	contentBytes, err := os.ReadFile(filepath.Join(projectDir, "gradle", "init.d", gradle.InitScriptName))
+	require.NoError(t, err)
+	assert.Contains(t, string(contentBytes), "artifactoryUrl = 'https://acme.jfrog.io/artifactory'")
+	// The init script of the user home isn't written.
+	assert.NoFileExists(t, filepath.Join(userGradleHome, "init.d", gradle.InitScriptName))
+
+	assert.Error(t, createTestSetupCommand(project.Npm).SetScope(ProjectScope).Run())
+	assert.Error(t, createTestSetupCommand(project.Gradle).SetScope("global").Run())
+}
+
+func TestSetupCommand_GradleKotlinDsl(t *testing.T) {
+	testGradleUserHome := t.TempDir()
+	t.Setenv(gradle.UserHomeEnv, testGradleUserHome)
+	gradleLoginCmd := createTestSetupCommand(project.Gradle).SetGradleDsl(gradle.KotlinDsl)
+	gradleLoginCmd.serverDetails.SetAccessToken(testCredential())
+	require.NoError(t, gradleLoginCmd.Run())
+
+	kotlinInitScriptPath := filepath.Join(testGradleUserHome, "init.d", gradle.KotlinInitScriptName)
+	contentBytes, err := os.ReadFile(kotlinInitScriptPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(contentBytes), `val artifactoryUrl = "https://acme.jfrog.io/artifactory"`)
+	assert.NoFileExists(t, filepath.Join(testGradleUserHome, "init.d", gradle.InitScriptName))
+
+	// The DSL of the existing init script is detected.
+	require.NoError(t, os.Remove(kotlinInitScriptPath))
+	require.NoError(t, os.WriteFile(kotlinInitScriptPath, []byte("// user script"), 0644))
+	gradleLoginCmd = createTestSetupCommand(project.Gradle)
+	gradleLoginCmd.serverDetails.SetAccessToken(testCredential())
+	require.NoError(t, gradleLoginCmd.Run())
+	contentBytes, err = os.ReadFile(kotlinInitScriptPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(contentBytes), `val artifactoryUrl = "https://acme.jfrog.io/artifactory"`)
+	assert.NoFileExists(t, filepath.Join(testGradleUserHome, "init.d", gradle.InitScriptName))
+}
+
+func TestSetupCommand_GradleVerify(t *testing.T) {
+	testGradleUserHome := t.TempDir()
+	t.Setenv(gradle.UserHomeEnv, testGradleUserHome)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, ok := r.BasicAuth(); !ok || password != "myPassword" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	gradleLoginCmd := NewSetupCommand(project.Gradle).SetRepoName("test-repo").SetVerify(true).
+		SetServerDetails(&config.ServerDetails{Url: server.URL, ArtifactoryUrl: server.URL + "/artifactory", User: "myUser", Password: "myPassword"})
+	require.NoError(t, gradleLoginCmd.Run())
+
+	// The init script is kept when the verification fails.
+	gradleLoginCmd.serverDetails.SetPassword("wrongPassword")
+	assert.ErrorContains(t, gradleLoginCmd.Run(), "rejected the credentials of the Gradle init script")
+	assert.FileExists(t, filepath.Join(testGradleUserHome, "init.d", gradle.InitScriptName))
+
+	assert.ErrorContains(t, createTestSetupCommand(project.Npm).SetVerify(true).Run(), "supported by Gradle only")
+}
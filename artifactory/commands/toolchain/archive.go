@@ -0,0 +1,93 @@
+package toolchain
+
+import (
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/jfrog/gofrog/unarchive"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// provisionArchive returns the home of the toolchain archive in Artifactory, in the form of <repository>/<path>, downloading and extracting
+// it to the cache directory if it isn't cached. The toolchains are cached by the checksum of their archives, which the download is verified
+// against. The home is found in the extracted archive by findHome.
+func provisionArchive(servicesManager artifactory.ArtifactoryServicesManager, remotePath, checksum string, newHash func() hash.Hash, cacheDir,
+	toolchainName string, findHome func(extractedDir string) (string, error)) (home string, err error) {
+	toolchainDir := filepath.Join(cacheDir, checksum)
+	exists, err := fileutils.IsDirExists(toolchainDir, false)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if exists {
+		log.Debug("Using the cached", toolchainName, remotePath, "from", toolchainDir)
+		return findHome(toolchainDir)
+	}
+	if err = os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	downloadDir, err := os.MkdirTemp(cacheDir, checksum+"-")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(downloadDir); err == nil {
+			err = errorutils.CheckError(removeErr)
+		}
+	}()
+	log.Info("Downloading the", toolchainName, remotePath, "from Artifactory...")
+	archiveName := path.Base(remotePath)
+	archivePath := filepath.Join(downloadDir, archiveName)
+	if err = downloadArchive(servicesManager, remotePath, archivePath, checksum, newHash()); err != nil {
+		return "", err
+	}
+	extractedDir := filepath.Join(downloadDir, "toolchain")
+	if err = (&unarchive.Unarchiver{}).Unarchive(archivePath, archiveName, extractedDir); err != nil {
+		return "", errorutils.CheckErrorf("failed to extract the %s archive %s: %s", toolchainName, remotePath, err.Error())
+	}
+	if _, err = findHome(extractedDir); err != nil {
+		return "", err
+	}
+	// The toolchain is moved to the cache when it's complete. If a concurrent build cached it first, its toolchain is used.
+	if err = os.Rename(extractedDir, toolchainDir); err != nil {
+		if exists, _ = fileutils.IsDirExists(toolchainDir, false); !exists {
+			return "", errorutils.CheckError(err)
+		}
+	}
+	return findHome(toolchainDir)
+}
+
+// downloadArchive downloads the archive, and verifies its checksum.
+func downloadArchive(servicesManager artifactory.ArtifactoryServicesManager, remotePath, archivePath, checksum string, checksumHash hash.Hash) (err error) {
+	reader, err := servicesManager.ReadRemoteFile(remotePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := archive.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	if _, err = io.Copy(io.MultiWriter(archive, checksumHash), reader); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if downloaded := hex.EncodeToString(checksumHash.Sum(nil)); downloaded != checksum {
+		return errorutils.CheckErrorf("the checksum of the downloaded archive %s is %s, while %s was expected", remotePath, downloaded, checksum)
+	}
+	return nil
+}
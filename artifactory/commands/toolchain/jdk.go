@@ -0,0 +1,77 @@
+package toolchain
+
+import (
+	"crypto/sha1" // #nosec G505 -- Artifactory lists the SHA-1 of the artifacts without a SHA-256.
+	"crypto/sha256"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	// The directory under the JFrog dependencies directory, where the provisioned JDKs are cached by their checksums.
+	jdksCacheDir = "jdks"
+	// The JDK homes are searched for up to this depth of the archives, such as jdk-17/Contents/Home in the macOS archives.
+	maxJdkHomeDepth = 3
+)
+
+// JdksCacheDir returns the directory where the provisioned JDKs are cached.
+func JdksCacheDir() (string, error) {
+	dependenciesPath, err := config.GetJfrogDependenciesPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dependenciesPath, jdksCacheDir), nil
+}
+
+// ProvisionJdk returns the home of the JDK archive in Artifactory, in the form of <repository>/<path>, downloading and extracting it
+// to the cache directory if it isn't cached. The JDKs are cached by the checksum of their archives, so a JDK overwritten in Artifactory
+// is downloaded again.
+func ProvisionJdk(servicesManager artifactory.ArtifactoryServicesManager, jdkPath, cacheDir string) (jdkHome string, err error) {
+	fileInfo, err := servicesManager.FileInfo(jdkPath)
+	if err != nil {
+		return "", err
+	}
+	checksum, newHash := fileInfo.Checksums.Sha256, sha256.New
+	if checksum == "" {
+		checksum, newHash = fileInfo.Checksums.Sha1, sha1.New
+	}
+	if checksum == "" {
+		return "", errorutils.CheckErrorf("Artifactory didn't return the checksum of the JDK archive %s", jdkPath)
+	}
+	return provisionArchive(servicesManager, jdkPath, checksum, newHash, cacheDir, "JDK", findJdkHome)
+}
+
+// findJdkHome returns the directory of the extracted JDK, which includes bin/java.
+func findJdkHome(extractedDir string) (jdkHome string, err error) {
+	err = filepath.WalkDir(extractedDir, func(walkedPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		for _, java := range []string{"java", "java.exe"} {
+			if exists, _ := fileutils.IsFileExists(filepath.Join(walkedPath, "bin", java), false); exists {
+				jdkHome = walkedPath
+				return fs.SkipAll
+			}
+		}
+		if relativePath, _ := filepath.Rel(extractedDir, walkedPath); relativePath != "." && strings.Count(relativePath, string(filepath.Separator))+1 >= maxJdkHomeDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if jdkHome == "" {
+		return "", errorutils.CheckErrorf("the JDK archive extracted to %s doesn't include bin/java", extractedDir)
+	}
+	return jdkHome, nil
+}
@@ -0,0 +1,81 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestJdkArchive(t *testing.T) []byte {
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	java := []byte("#!/bin/sh\n")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "jdk-17.0.9+9/bin/java", Mode: 0755, Size: int64(len(java)), Typeflag: tar.TypeReg}))
+	_, err := tarWriter.Write(java)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return archive.Bytes()
+}
+
+func TestProvisionJdk(t *testing.T) {
+	archive := createTestJdkArchive(t)
+	checksum := sha256.Sum256(archive)
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/storage/jdks-local/temurin/jdk-17.tar.gz":
+			_, _ = fmt.Fprintf(w, `{"repo":"jdks-local","path":"/temurin/jdk-17.tar.gz","checksums":{"sha256":"%s"}}`, hex.EncodeToString(checksum[:]))
+		case "/artifactory/jdks-local/temurin/jdk-17.tar.gz":
+			downloads++
+			_, _ = w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+	cacheDir := t.TempDir()
+
+	jdkHome, err := ProvisionJdk(servicesManager, "jdks-local/temurin/jdk-17.tar.gz", cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, hex.EncodeToString(checksum[:]), "jdk-17.0.9+9"), jdkHome)
+	assert.FileExists(t, filepath.Join(jdkHome, "bin", "java"))
+
+	// The cached JDK is used.
+	jdkHome, err = ProvisionJdk(servicesManager, "jdks-local/temurin/jdk-17.tar.gz", cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, hex.EncodeToString(checksum[:]), "jdk-17.0.9+9"), jdkHome)
+	assert.Equal(t, 1, downloads)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFindJdkHome(t *testing.T) {
+	extractedDir := t.TempDir()
+	_, err := findJdkHome(extractedDir)
+	assert.ErrorContains(t, err, "doesn't include bin/java")
+
+	jdkHome := filepath.Join(extractedDir, "jdk-17.jdk", "Contents", "Home")
+	require.NoError(t, os.MkdirAll(filepath.Join(jdkHome, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(jdkHome, "bin", "java"), []byte{}, 0755))
+	found, err := findJdkHome(extractedDir)
+	require.NoError(t, err)
+	assert.Equal(t, jdkHome, found)
+}
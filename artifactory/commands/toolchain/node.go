@@ -0,0 +1,340 @@
+package toolchain
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The version spec which provisions the latest LTS release of Node.js.
+	NodeLtsVersion = "lts"
+
+	// The prefix of the build-info environment properties which record the provisioned Node.js runtime.
+	NodeBuildInfoPropsPrefix = "buildInfo.node."
+
+	// The directory under the JFrog dependencies directory, where the provisioned Node.js runtimes are cached by their checksums.
+	nodesCacheDir = "nodes"
+	// The index of the releases, and the checksums of the files of each release, in the layout of https://nodejs.org/dist.
+	nodeIndexFile     = "index.json"
+	nodeChecksumsFile = "SHASUMS256.txt"
+	// The Node.js homes are searched for up to this depth of the archives.
+	maxNodeHomeDepth = 2
+)
+
+var nodeExactVersionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+// NodeRuntime is the Node.js runtime provisioned from Artifactory.
+type NodeRuntime struct {
+	Version string `json:"version"`
+	Os      string `json:"os"`
+	Arch    string `json:"arch"`
+	// The path of the Node.js archive in Artifactory, in the form of <repository>/<path>.
+	Path     string `json:"path"`
+	Sha256   string `json:"sha256"`
+	NodeHome string `json:"nodeHome"`
+	// The directory of the node, npm and npx executables, which is added to the PATH.
+	BinDir string `json:"binDir"`
+}
+
+// nodeRelease is a release in the index of the Node.js distributions.
+type nodeRelease struct {
+	Version string `json:"version"`
+	// The codename of the LTS line of the release, or false if it isn't an LTS release.
+	Lts any `json:"lts"`
+}
+
+// NodeProvisionCommand downloads, verifies and caches a Node.js runtime from an Artifactory repository which proxies https://nodejs.org/dist,
+// and exports its PATH. A version spec of 20 provisions the latest Node.js 20 release in the index, and lts the latest LTS release.
+// The archive is verified against the SHASUMS256.txt of its release.
+type NodeProvisionCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	repo               string
+	version            string
+	os                 string
+	arch               string
+	result             *NodeRuntime
+}
+
+func NewNodeProvisionCommand() *NodeProvisionCommand {
+	return &NodeProvisionCommand{os: defaultNodeOs(), arch: defaultNodeArch()}
+}
+
+func (npc *NodeProvisionCommand) SetServerDetails(serverDetails *config.ServerDetails) *NodeProvisionCommand {
+	npc.serverDetails = serverDetails
+	return npc
+}
+
+func (npc *NodeProvisionCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *NodeProvisionCommand {
+	npc.buildConfiguration = buildConfiguration
+	return npc
+}
+
+func (npc *NodeProvisionCommand) SetRepo(repo string) *NodeProvisionCommand {
+	npc.repo = repo
+	return npc
+}
+
+// SetVersion sets the version spec of the Node.js runtime, with or without the v prefix.
+func (npc *NodeProvisionCommand) SetVersion(version string) *NodeProvisionCommand {
+	npc.version = strings.TrimPrefix(version, "v")
+	return npc
+}
+
+// SetOs sets the operating system of the Node.js runtime, as named by the Node.js distributions, such as linux, darwin or win.
+// An empty operating system keeps the default, which is the operating system of the machine.
+func (npc *NodeProvisionCommand) SetOs(os string) *NodeProvisionCommand {
+	if os != "" {
+		npc.os = os
+	}
+	return npc
+}
+
+// SetArch sets the architecture of the Node.js runtime, as named by the Node.js distributions, such as x64 or arm64.
+// An empty architecture keeps the default, which is the architecture of the machine.
+func (npc *NodeProvisionCommand) SetArch(arch string) *NodeProvisionCommand {
+	if arch != "" {
+		npc.arch = arch
+	}
+	return npc
+}
+
+func (npc *NodeProvisionCommand) Result() *NodeRuntime {
+	return npc.result
+}
+
+func (npc *NodeProvisionCommand) CommandName() string {
+	return "rt_node_provision"
+}
+
+func (npc *NodeProvisionCommand) ServerDetails() (*config.ServerDetails, error) {
+	return npc.serverDetails, nil
+}
+
+func (npc *NodeProvisionCommand) Run() error {
+	if npc.repo == "" || npc.version == "" {
+		return errorutils.CheckErrorf("the repository and the version of Node.js are mandatory")
+	}
+	servicesManager, err := utils.CreateServiceManager(npc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	nodeVersion, err := npc.resolveNodeVersion(servicesManager)
+	if err != nil {
+		return err
+	}
+	archiveName := fmt.Sprintf("node-v%s-%s-%s.%s", nodeVersion, npc.os, npc.arch, nodeArchiveExtension(npc.os))
+	releasePath := path.Join(npc.repo, "v"+nodeVersion)
+	checksum, err := readNodeChecksum(servicesManager, path.Join(releasePath, nodeChecksumsFile), archiveName)
+	if err != nil {
+		return err
+	}
+	nodeRuntime := &NodeRuntime{Version: nodeVersion, Os: npc.os, Arch: npc.arch, Path: path.Join(releasePath, archiveName), Sha256: checksum}
+	dependenciesPath, err := config.GetJfrogDependenciesPath()
+	if err != nil {
+		return err
+	}
+	nodeRuntime.NodeHome, err = provisionArchive(servicesManager, nodeRuntime.Path, checksum, sha256.New, filepath.Join(dependenciesPath, nodesCacheDir), "Node.js runtime", findNodeHome)
+	if err != nil {
+		return err
+	}
+	nodeRuntime.BinDir = nodeBinDir(nodeRuntime.NodeHome, npc.os)
+	log.Info(fmt.Sprintf("Provisioned Node.js %s from %s at %s.", nodeRuntime.Version, nodeRuntime.Path, nodeRuntime.NodeHome))
+	npc.result = nodeRuntime
+	if err = appendToEnvFile(githubPathFileEnv, nodeRuntime.BinDir); err != nil {
+		return err
+	}
+	return npc.recordBuildInfo(nodeRuntime)
+}
+
+// resolveNodeVersion returns the version of the spec, if it's an exact version, or the latest release in the index which matches it.
+func (npc *NodeProvisionCommand) resolveNodeVersion(servicesManager artifactory.ArtifactoryServicesManager) (string, error) {
+	if nodeExactVersionRegex.MatchString(npc.version) {
+		return npc.version, nil
+	}
+	indexPath := path.Join(npc.repo, nodeIndexFile)
+	content, err := readRemoteFile(servicesManager, indexPath)
+	if err != nil {
+		return "", err
+	}
+	var releases []nodeRelease
+	if err = json.Unmarshal(content, &releases); err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the Node.js releases index %s: %s", indexPath, err.Error())
+	}
+	latest := ""
+	for _, release := range releases {
+		releaseVersion := strings.TrimPrefix(release.Version, "v")
+		if !matchesNodeVersionSpec(release, npc.version) {
+			continue
+		}
+		if latest == "" || !version.NewVersion(latest).AtLeast(releaseVersion) {
+			latest = releaseVersion
+		}
+	}
+	if latest == "" {
+		return "", errorutils.CheckErrorf("no Node.js release which matches %s was found in %s", npc.version, indexPath)
+	}
+	return latest, nil
+}
+
+func matchesNodeVersionSpec(release nodeRelease, specVersion string) bool {
+	if specVersion == NodeLtsVersion {
+		ltsName, isLts := release.Lts.(string)
+		return isLts && ltsName != ""
+	}
+	return matchesVersionSpec(strings.TrimPrefix(release.Version, "v"), specVersion)
+}
+
+// readNodeChecksum returns the SHA-256 of the archive, as listed by the SHASUMS256.txt of its release.
+func readNodeChecksum(servicesManager artifactory.ArtifactoryServicesManager, checksumsPath, archiveName string) (string, error) {
+	content, err := readRemoteFile(servicesManager, checksumsPath)
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[1] == archiveName {
+			return fields[0], nil
+		}
+	}
+	return "", errorutils.CheckErrorf("%s doesn't list the checksum of %s", checksumsPath, archiveName)
+}
+
+func readRemoteFile(servicesManager artifactory.ArtifactoryServicesManager, remotePath string) (content []byte, err error) {
+	reader, err := servicesManager.ReadRemoteFile(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	content, err = io.ReadAll(reader)
+	return content, errorutils.CheckError(err)
+}
+
+// findNodeHome returns the directory of the extracted Node.js runtime, which includes bin/node, or node.exe on Windows.
+func findNodeHome(extractedDir string) (nodeHome string, err error) {
+	err = filepath.WalkDir(extractedDir, func(walkedPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		for _, node := range []string{filepath.Join("bin", "node"), "node.exe"} {
+			if exists, _ := fileutils.IsFileExists(filepath.Join(walkedPath, node), false); exists {
+				nodeHome = walkedPath
+				return fs.SkipAll
+			}
+		}
+		if relativePath, _ := filepath.Rel(extractedDir, walkedPath); relativePath != "." && strings.Count(relativePath, string(filepath.Separator))+1 >= maxNodeHomeDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if nodeHome == "" {
+		return "", errorutils.CheckErrorf("the Node.js archive extracted to %s doesn't include the node executable", extractedDir)
+	}
+	return nodeHome, nil
+}
+
+// nodeBinDir returns the directory of the executables of the Node.js runtime, which is its home on Windows.
+func nodeBinDir(nodeHome, nodeOs string) string {
+	if nodeOs == "win" {
+		return nodeHome
+	}
+	return filepath.Join(nodeHome, "bin")
+}
+
+func nodeArchiveExtension(nodeOs string) string {
+	if nodeOs == "win" {
+		return "zip"
+	}
+	return "tar.gz"
+}
+
+// recordBuildInfo records the Node.js runtime in the environment of the build-info, if collected.
+func (npc *NodeProvisionCommand) recordBuildInfo(nodeRuntime *NodeRuntime) error {
+	if npc.buildConfiguration == nil {
+		return nil
+	}
+	isCollect, err := npc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !isCollect {
+		return err
+	}
+	buildName, err := npc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := npc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	nodeBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, npc.buildConfiguration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	env := buildinfo.Env{
+		NodeBuildInfoPropsPrefix + "version": nodeRuntime.Version,
+		NodeBuildInfoPropsPrefix + "os":      nodeRuntime.Os,
+		NodeBuildInfoPropsPrefix + "arch":    nodeRuntime.Arch,
+		NodeBuildInfoPropsPrefix + "path":    nodeRuntime.Path,
+		NodeBuildInfoPropsPrefix + "sha256":  nodeRuntime.Sha256,
+	}
+	return errorutils.CheckError(nodeBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: env}))
+}
+
+// PathExportCommands returns the shell commands which add the directory to the PATH of the current shell,
+// for example with: eval "$(jf rt node-provision nodejs-remote 20)".
+func PathExportCommands(binDir string) []string {
+	if coreutils.IsWindows() {
+		return []string{"set PATH=" + binDir + ";%PATH%"}
+	}
+	return []string{"export PATH=" + shellQuote(binDir) + `:"$PATH"`}
+}
+
+// defaultNodeOs returns the operating system of the machine, as named by the Node.js distributions.
+func defaultNodeOs() string {
+	if runtime.GOOS == "windows" {
+		return "win"
+	}
+	return runtime.GOOS
+}
+
+// defaultNodeArch returns the architecture of the machine, as named by the Node.js distributions.
+func defaultNodeArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x64"
+	case "386":
+		return "x86"
+	case "arm":
+		return "armv7l"
+	default:
+		return runtime.GOARCH
+	}
+}
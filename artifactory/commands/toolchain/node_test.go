@@ -0,0 +1,104 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeIndex = `[
+{"version":"v21.6.2","lts":false},
+{"version":"v20.11.1","lts":"Iron"},
+{"version":"v20.9.0","lts":"Iron"},
+{"version":"v18.19.1","lts":"Hydrogen"}
+]`
+
+func createTestNodeArchive(t *testing.T) []byte {
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	node := []byte("#!/bin/sh\n")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "node-v20.11.1-linux-x64/bin/node", Mode: 0755, Size: int64(len(node)), Typeflag: tar.TypeReg}))
+	_, err := tarWriter.Write(node)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return archive.Bytes()
+}
+
+func TestNodeProvision(t *testing.T) {
+	archive := createTestNodeArchive(t)
+	checksum := sha256.Sum256(archive)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/nodejs-remote/index.json":
+			_, _ = w.Write([]byte(testNodeIndex))
+		case "/artifactory/nodejs-remote/v20.11.1/SHASUMS256.txt":
+			_, _ = fmt.Fprintf(w, "%s  node-v20.11.1-darwin-arm64.tar.gz\n%s  node-v20.11.1-linux-x64.tar.gz\n", "0000", hex.EncodeToString(checksum[:]))
+		case "/artifactory/nodejs-remote/v20.11.1/node-v20.11.1-linux-x64.tar.gz", "/artifactory/nodejs-remote/v20.11.1/node-v20.11.1-darwin-arm64.tar.gz":
+			_, _ = w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	githubPathFile := filepath.Join(t.TempDir(), "github_path")
+	t.Setenv(githubPathFileEnv, githubPathFile)
+
+	nodeProvisionCommand := NewNodeProvisionCommand().SetRepo("nodejs-remote").SetVersion("20").SetOs("linux").SetArch("x64").
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"})
+	require.NoError(t, nodeProvisionCommand.Run())
+	nodeRuntime := nodeProvisionCommand.Result()
+	assert.Equal(t, "20.11.1", nodeRuntime.Version)
+	assert.Equal(t, "nodejs-remote/v20.11.1/node-v20.11.1-linux-x64.tar.gz", nodeRuntime.Path)
+	assert.Equal(t, hex.EncodeToString(checksum[:]), nodeRuntime.Sha256)
+	assert.FileExists(t, filepath.Join(nodeRuntime.BinDir, "node"))
+	githubPath, err := os.ReadFile(githubPathFile)
+	require.NoError(t, err)
+	assert.Equal(t, nodeRuntime.BinDir+"\n", string(githubPath))
+
+	// The archive must match the checksum of its release.
+	err = nodeProvisionCommand.SetOs("darwin").SetArch("arm64").Run()
+	assert.ErrorContains(t, err, "the checksum of the downloaded archive nodejs-remote/v20.11.1/node-v20.11.1-darwin-arm64.tar.gz is "+hex.EncodeToString(checksum[:]))
+
+	err = nodeProvisionCommand.SetOs("win").Run()
+	assert.ErrorContains(t, err, "doesn't list the checksum of node-v20.11.1-win-arm64.zip")
+}
+
+func TestResolveNodeVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifactory/nodejs-remote/index.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testNodeIndex))
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+	nodeProvisionCommand := NewNodeProvisionCommand().SetRepo("nodejs-remote")
+
+	testCases := map[string]string{"v20.9.0": "20.9.0", "20": "20.11.1", "20.9": "20.9.0", "lts": "20.11.1", "18": "18.19.1", "21": "21.6.2"}
+	for spec, expected := range testCases {
+		resolved, err := nodeProvisionCommand.SetVersion(spec).resolveNodeVersion(servicesManager)
+		require.NoError(t, err, spec)
+		assert.Equal(t, expected, resolved, spec)
+	}
+	_, err = nodeProvisionCommand.SetVersion("2").resolveNodeVersion(servicesManager)
+	assert.ErrorContains(t, err, "no Node.js release which matches 2 was found")
+}
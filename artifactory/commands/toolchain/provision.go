@@ -0,0 +1,328 @@
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DefaultJdkVendor = "temurin"
+	// The default layout of the JDK archives in the repository.
+	DefaultJdkLayout = "{vendor}/{version}/{os}/{arch}/*"
+
+	// The prefix of the build-info environment properties which record the provisioned JDK.
+	JdkBuildInfoPropsPrefix = "buildInfo.jdk."
+
+	// The environment files of GitHub Actions, which set the environment variables and the PATH of the job's next steps.
+	githubEnvFileEnv  = "GITHUB_ENV"
+	githubPathFileEnv = "GITHUB_PATH"
+)
+
+var jdkArchiveExtensions = []string{".tar.gz", ".tgz", ".zip"}
+
+// JdkToolchain is the JDK provisioned from Artifactory.
+type JdkToolchain struct {
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+	Os      string `json:"os"`
+	Arch    string `json:"arch"`
+	// The path of the JDK archive in Artifactory, in the form of <repository>/<path>.
+	Path     string `json:"path"`
+	Sha256   string `json:"sha256,omitempty"`
+	JavaHome string `json:"javaHome"`
+}
+
+// JdkProvisionCommand downloads and caches the JDK of a toolchain spec from Artifactory, and exports its JAVA_HOME.
+// The JDK archive is found in the repository by the layout, whose {version} is the latest version which matches the spec,
+// so that a spec of 17 provisions the latest JDK 17 in the repository.
+type JdkProvisionCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	repo               string
+	vendor             string
+	version            string
+	os                 string
+	arch               string
+	layout             string
+	result             *JdkToolchain
+}
+
+func NewJdkProvisionCommand() *JdkProvisionCommand {
+	return &JdkProvisionCommand{vendor: DefaultJdkVendor, os: defaultJdkOs(), arch: defaultJdkArch(), layout: DefaultJdkLayout}
+}
+
+func (jpc *JdkProvisionCommand) SetServerDetails(serverDetails *config.ServerDetails) *JdkProvisionCommand {
+	jpc.serverDetails = serverDetails
+	return jpc
+}
+
+func (jpc *JdkProvisionCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *JdkProvisionCommand {
+	jpc.buildConfiguration = buildConfiguration
+	return jpc
+}
+
+func (jpc *JdkProvisionCommand) SetRepo(repo string) *JdkProvisionCommand {
+	jpc.repo = repo
+	return jpc
+}
+
+func (jpc *JdkProvisionCommand) SetVersion(version string) *JdkProvisionCommand {
+	jpc.version = version
+	return jpc
+}
+
+// SetVendor sets the vendor of the JDK. An empty vendor keeps the default.
+func (jpc *JdkProvisionCommand) SetVendor(vendor string) *JdkProvisionCommand {
+	if vendor != "" {
+		jpc.vendor = vendor
+	}
+	return jpc
+}
+
+// SetOs sets the operating system of the JDK, in the names of the JDK distributions, such as linux, mac or windows.
+// An empty operating system keeps the default, which is the operating system of the machine.
+func (jpc *JdkProvisionCommand) SetOs(os string) *JdkProvisionCommand {
+	if os != "" {
+		jpc.os = os
+	}
+	return jpc
+}
+
+// SetArch sets the architecture of the JDK, in the names of the JDK distributions, such as x64 or aarch64.
+// An empty architecture keeps the default, which is the architecture of the machine.
+func (jpc *JdkProvisionCommand) SetArch(arch string) *JdkProvisionCommand {
+	if arch != "" {
+		jpc.arch = arch
+	}
+	return jpc
+}
+
+// SetLayout sets the layout of the JDK archives in the repository, with the {vendor}, {version}, {os} and {arch} placeholders.
+// An empty layout keeps the default.
+func (jpc *JdkProvisionCommand) SetLayout(layout string) *JdkProvisionCommand {
+	if layout != "" {
+		jpc.layout = layout
+	}
+	return jpc
+}
+
+func (jpc *JdkProvisionCommand) Result() *JdkToolchain {
+	return jpc.result
+}
+
+func (jpc *JdkProvisionCommand) CommandName() string {
+	return "rt_jdk_provision"
+}
+
+func (jpc *JdkProvisionCommand) ServerDetails() (*config.ServerDetails, error) {
+	return jpc.serverDetails, nil
+}
+
+func (jpc *JdkProvisionCommand) Run() error {
+	if jpc.repo == "" || jpc.version == "" {
+		return errorutils.CheckErrorf("the repository and the version of the JDK are mandatory")
+	}
+	if !strings.Contains(jpc.layout, "{version}") {
+		return errorutils.CheckErrorf("the layout of the JDK archives '%s' must include the {version} placeholder", jpc.layout)
+	}
+	servicesManager, err := utils.CreateServiceManager(jpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	toolchain, err := jpc.findJdkArchive(servicesManager)
+	if err != nil {
+		return err
+	}
+	cacheDir, err := JdksCacheDir()
+	if err != nil {
+		return err
+	}
+	if toolchain.JavaHome, err = ProvisionJdk(servicesManager, toolchain.Path, cacheDir); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Provisioned the %s JDK %s from %s at %s.", toolchain.Vendor, toolchain.Version, toolchain.Path, toolchain.JavaHome))
+	jpc.result = toolchain
+	if err = exportToGithubActions(toolchain.JavaHome); err != nil {
+		return err
+	}
+	return jpc.recordBuildInfo(toolchain)
+}
+
+// findJdkArchive finds the archive of the latest JDK version which matches the spec, in the layout of the repository.
+func (jpc *JdkProvisionCommand) findJdkArchive(servicesManager artifactory.ArtifactoryServicesManager) (latest *JdkToolchain, err error) {
+	placeholders := strings.NewReplacer("{vendor}", jpc.vendor, "{os}", jpc.os, "{arch}", jpc.arch)
+	layout := placeholders.Replace(jpc.layout)
+	layoutRegex, err := jdkLayoutRegex(layout)
+	if err != nil {
+		return nil, err
+	}
+	searchPattern := jpc.repo + "/" + strings.ReplaceAll(layout, "{version}", jpc.version+"*")
+	log.Debug("Searching for the JDK archives", searchPattern)
+	reader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &servicesUtils.CommonParams{Pattern: searchPattern, Recursive: true}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		archivePath := strings.TrimPrefix(path.Join(item.Path, item.Name), "./")
+		if !isJdkArchive(item.Name) {
+			continue
+		}
+		match := layoutRegex.FindStringSubmatch(archivePath)
+		if match == nil || !matchesVersionSpec(match[1], jpc.version) {
+			continue
+		}
+		if latest == nil || !version.NewVersion(latest.Version).AtLeast(match[1]) {
+			latest = &JdkToolchain{Vendor: jpc.vendor, Version: match[1], Os: jpc.os, Arch: jpc.arch, Path: item.Repo + "/" + archivePath, Sha256: item.Sha256}
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, errorutils.CheckErrorf("no %s JDK %s archive for %s %s was found in %s", jpc.vendor, jpc.version, jpc.os, jpc.arch, searchPattern)
+	}
+	return latest, nil
+}
+
+// jdkLayoutRegex returns the regular expression of the archive paths of the layout, whose group is the version.
+// The wildcards of the layout match within a single directory.
+func jdkLayoutRegex(layout string) (*regexp.Regexp, error) {
+	quoted := regexp.QuoteMeta(strings.TrimPrefix(layout, "/"))
+	quoted = strings.Replace(quoted, regexp.QuoteMeta("{version}"), "([^/]+)", 1)
+	quoted = strings.ReplaceAll(quoted, regexp.QuoteMeta("{version}"), "[^/]+")
+	quoted = strings.ReplaceAll(quoted, regexp.QuoteMeta("*"), "[^/]*")
+	layoutRegex, err := regexp.Compile("^" + quoted + "$")
+	return layoutRegex, errorutils.CheckError(err)
+}
+
+// matchesVersionSpec returns true if the version is the version of the spec, or a later update of it, such as 17.0.9+9 of 17.
+func matchesVersionSpec(version, specVersion string) bool {
+	if !strings.HasPrefix(version, specVersion) {
+		return false
+	}
+	suffix := strings.TrimPrefix(version, specVersion)
+	return suffix == "" || !strings.ContainsAny(suffix[:1], "0123456789")
+}
+
+func isJdkArchive(fileName string) bool {
+	for _, extension := range jdkArchiveExtensions {
+		if strings.HasSuffix(fileName, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportToGithubActions sets the JAVA_HOME and the PATH of the next steps of the job, when running in GitHub Actions.
+func exportToGithubActions(javaHome string) error {
+	if err := appendToEnvFile(githubEnvFileEnv, "JAVA_HOME="+javaHome); err != nil {
+		return err
+	}
+	return appendToEnvFile(githubPathFileEnv, filepath.Join(javaHome, "bin"))
+}
+
+func appendToEnvFile(fileEnv, line string) (err error) {
+	envFile := os.Getenv(fileEnv)
+	if envFile == "" {
+		return nil
+	}
+	file, err := os.OpenFile(envFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = fmt.Fprintln(file, line)
+	return errorutils.CheckError(err)
+}
+
+// recordBuildInfo records the JDK in the environment of the build-info, if collected.
+func (jpc *JdkProvisionCommand) recordBuildInfo(toolchain *JdkToolchain) error {
+	if jpc.buildConfiguration == nil {
+		return nil
+	}
+	isCollect, err := jpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !isCollect {
+		return err
+	}
+	buildName, err := jpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := jpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	jdkBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, jpc.buildConfiguration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	env := buildinfo.Env{
+		JdkBuildInfoPropsPrefix + "vendor":  toolchain.Vendor,
+		JdkBuildInfoPropsPrefix + "version": toolchain.Version,
+		JdkBuildInfoPropsPrefix + "os":      toolchain.Os,
+		JdkBuildInfoPropsPrefix + "arch":    toolchain.Arch,
+		JdkBuildInfoPropsPrefix + "path":    toolchain.Path,
+	}
+	if toolchain.Sha256 != "" {
+		env[JdkBuildInfoPropsPrefix+"sha256"] = toolchain.Sha256
+	}
+	return errorutils.CheckError(jdkBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: env}))
+}
+
+// ExportCommands returns the shell commands which set the JAVA_HOME and the PATH of the JDK in the current shell,
+// for example with: eval "$(jf rt jdk-provision jdks 17)".
+func ExportCommands(javaHome string) []string {
+	if coreutils.IsWindows() {
+		return []string{"set JAVA_HOME=" + javaHome, `set PATH=%JAVA_HOME%\bin;%PATH%`}
+	}
+	return []string{"export JAVA_HOME=" + shellQuote(javaHome), `export PATH="$JAVA_HOME/bin:$PATH"`}
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// defaultJdkOs returns the operating system of the machine, as named by the JDK distributions.
+func defaultJdkOs() string {
+	if runtime.GOOS == "darwin" {
+		return "mac"
+	}
+	return runtime.GOOS
+}
+
+// defaultJdkArch returns the architecture of the machine, as named by the JDK distributions.
+func defaultJdkArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x64"
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "x86"
+	default:
+		return runtime.GOARCH
+	}
+}
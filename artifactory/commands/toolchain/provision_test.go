@@ -0,0 +1,62 @@
+package toolchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindJdkArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/artifactory/api/search/aql":
+			_, _ = w.Write([]byte(`{"results":[
+{"repo":"jdks","path":"temurin/17.0.9+9/linux/x64","name":"OpenJDK17U-jdk_x64_linux.tar.gz","type":"file","sha256":"a"},
+{"repo":"jdks","path":"temurin/17.0.11+9/linux/x64","name":"OpenJDK17U-jdk_x64_linux.tar.gz","type":"file","sha256":"b"},
+{"repo":"jdks","path":"temurin/17.0.11+9/linux/x64","name":"OpenJDK17U-jdk_x64_linux.tar.gz.sig","type":"file","sha256":"c"},
+{"repo":"jdks","path":"temurin/170.0.1/linux/x64","name":"OpenJDK170U-jdk_x64_linux.tar.gz","type":"file","sha256":"d"},
+{"repo":"jdks","path":"temurin/17.0.12+7/linux/x64/nested","name":"OpenJDK17U-jdk_x64_linux.tar.gz","type":"file","sha256":"e"}
+],"range":{"start_pos":0,"end_pos":5,"total":5}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+
+	jdkProvisionCommand := NewJdkProvisionCommand().SetRepo("jdks").SetVersion("17").SetOs("linux").SetArch("x64")
+	toolchain, err := jdkProvisionCommand.findJdkArchive(servicesManager)
+	require.NoError(t, err)
+	assert.Equal(t, &JdkToolchain{Vendor: "temurin", Version: "17.0.11+9", Os: "linux", Arch: "x64", Path: "jdks/temurin/17.0.11+9/linux/x64/OpenJDK17U-jdk_x64_linux.tar.gz", Sha256: "b"}, toolchain)
+
+	_, err = jdkProvisionCommand.SetVersion("21").findJdkArchive(servicesManager)
+	assert.ErrorContains(t, err, "no temurin JDK 21 archive for linux x64 was found in jdks/temurin/21*/linux/x64/*")
+}
+
+func TestJdkLayoutRegex(t *testing.T) {
+	layoutRegex, err := jdkLayoutRegex("temurin/{version}/linux/x64/*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"temurin/17.0.9+9/linux/x64/jdk.tar.gz", "17.0.9+9"}, layoutRegex.FindStringSubmatch("temurin/17.0.9+9/linux/x64/jdk.tar.gz"))
+	assert.Nil(t, layoutRegex.FindStringSubmatch("temurin/17.0.9+9/linux/x64/nested/jdk.tar.gz"))
+
+	layoutRegex, err = jdkLayoutRegex("jdk-{version}/OpenJDK-{version}_linux.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "21.0.2", layoutRegex.FindStringSubmatch("jdk-21.0.2/OpenJDK-21.0.2_linux.tar.gz")[1])
+}
+
+func TestMatchesVersionSpec(t *testing.T) {
+	assert.True(t, matchesVersionSpec("17", "17"))
+	assert.True(t, matchesVersionSpec("17.0.9+9", "17"))
+	assert.True(t, matchesVersionSpec("17.0.9+9", "17.0.9"))
+	assert.False(t, matchesVersionSpec("170.0.1", "17"))
+	assert.False(t, matchesVersionSpec("17.0.10", "17.0.1"))
+	assert.False(t, matchesVersionSpec("11.0.21", "17"))
+}
@@ -0,0 +1,96 @@
+package yarn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/build-info-go/build"
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// runPublish runs 'yarn npm publish' to the deployment repository, and adds the published package to the build-info.
+// The registry of the deployment repository is set as the publish registry, unless the package.json sets
+// its 'publishConfig.registry', which Yarn prefers.
+func (yc *YarnCommand) runPublish(envVarsBackup map[string]*string, yarnArgs []string) error {
+	publishRegistry, npmAuthIdent, npmAuthToken, err := GetYarnAuthDetails(yc.deployerDetails, yc.deployRepo)
+	if err != nil {
+		return err
+	}
+	oldVal, err := backupAndSetEnvironmentVariable(yarnNpmPublishRegistryEnv, publishRegistry)
+	if err != nil {
+		return err
+	}
+	envVarsBackup[yarnNpmPublishRegistryEnv] = &oldVal
+	// The deployer may be a different server than the resolver, so the authentication of the publish registry is set explicitly.
+	err = updateNpmRegistries(yc.executablePath, map[string]yarnNpmRegistry{publishRegistry: {NpmAlwaysAuth: true, NpmAuthIdent: npmAuthIdent, NpmAuthToken: npmAuthToken}})
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Publishing to the Artifactory repository '%s'...", yc.deployRepo))
+	if err = build.RunYarnCommand(yc.executablePath, yc.workingDirectory, yarnArgs...); err != nil {
+		return err
+	}
+	if !yc.collectBuildInfo {
+		return nil
+	}
+	return yc.collectPublishedArtifact()
+}
+
+// collectPublishedArtifact sets the build properties on the published package, and adds it to the build-info as an artifact,
+// with the checksums calculated by Artifactory.
+func (yc *YarnCommand) collectPublishedArtifact() (err error) {
+	packageInfo, err := buildutils.ReadPackageInfoFromPackageJsonIfExists(yc.workingDirectory, nil)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if packageInfo.Name == "" || packageInfo.Version == "" {
+		return errorutils.CheckErrorf("the name and the version of the published package are missing from %s", yc.workingDirectory)
+	}
+	servicesManager, err := utils.CreateServiceManager(yc.deployerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	target := yc.deployRepo + "/" + packageInfo.GetDeployPath()
+	searchReader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &servicesUtils.CommonParams{Pattern: target}})
+	if err != nil {
+		log.Error("Failed to get the published yarn package:", err.Error())
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, searchReader.Close())
+	}()
+
+	buildName, err := yc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := yc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	buildProps, err := buildUtils.CreateBuildProperties(buildName, buildNumber, yc.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	if _, err = servicesManager.SetProps(services.PropsParams{Reader: searchReader, Props: buildProps}); err != nil {
+		log.Warn("Unable to set build properties:", err, "\nThis may cause build to not properly link with artifact, please add build name and build number properties on the tarball artifact manually")
+	}
+
+	searchReader.Reset()
+	artifacts, err := utils.ConvertArtifactsSearchDetailsToBuildInfoArtifacts(searchReader)
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		log.Warn(fmt.Sprintf("The published package %s could not be found in Artifactory, and therefore is not included in the build-info.", target))
+		return nil
+	}
+	return yc.buildInfoModule.AddArtifacts(artifacts...)
+}
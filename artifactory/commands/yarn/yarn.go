@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"encoding/json"
 	"errors"
+	buildutils "github.com/jfrog/build-info-go/build/utils"
 	"github.com/jfrog/build-info-go/entities"
 	gofrogio "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -33,16 +35,20 @@ import (
 )
 
 const (
-	YarnrcFileName       = ".yarnrc.yml"
-	YarnrcBackupFileName = "jfrog.yarnrc.backup"
-	NpmScopesConfigName  = "npmScopes"
-	YarnLockFileName     = "yarn.lock"
+	YarnrcFileName          = ".yarnrc.yml"
+	YarnrcBackupFileName    = "jfrog.yarnrc.backup"
+	NpmScopesConfigName     = "npmScopes"
+	NpmRegistriesConfigName = "npmRegistries"
+	YarnLockFileName        = "yarn.lock"
 	//#nosec G101
 	yarnNpmRegistryServerEnv = "YARN_NPM_REGISTRY_SERVER"
 	yarnNpmAuthIndent        = "YARN_NPM_AUTH_IDENT"
 	// #nosec G101
-	yarnNpmAuthToken  = "YARN_NPM_AUTH_TOKEN"
-	yarnNpmAlwaysAuth = "YARN_NPM_ALWAYS_AUTH"
+	yarnNpmAuthToken          = "YARN_NPM_AUTH_TOKEN"
+	yarnNpmAlwaysAuth         = "YARN_NPM_ALWAYS_AUTH"
+	yarnNpmPublishRegistryEnv = "YARN_NPM_PUBLISH_REGISTRY"
+	// The first Yarn version which isn't supported.
+	unsupportedYarnVersion = "5.0.0"
 )
 
 type YarnCommand struct {
@@ -52,11 +58,14 @@ type YarnCommand struct {
 	npmAuthIdent       string
 	npmAuthToken       string
 	repo               string
+	deployRepo         string
+	publish            bool
 	collectBuildInfo   bool
 	configFilePath     string
 	yarnArgs           []string
 	threads            int
 	serverDetails      *config.ServerDetails
+	deployerDetails    *config.ServerDetails
 	buildConfiguration *buildUtils.BuildConfiguration
 	buildInfoModule    *build.YarnModule
 }
@@ -104,7 +113,8 @@ func (yc *YarnCommand) Run() (err error) {
 
 	var missingDepsChan chan string
 	var missingDependencies []string
-	if yc.collectBuildInfo {
+	// The dependencies aren't collected by 'yarn npm publish', so there's no need to collect their checksums.
+	if yc.collectBuildInfo && !yc.publish {
 		missingDepsChan, err = yc.prepareBuildInfo()
 		if err != nil {
 			return
@@ -125,12 +135,18 @@ func (yc *YarnCommand) Run() (err error) {
 		return errors.Join(err, restoreYarnrcFunc())
 	}
 
-	yc.buildInfoModule.SetArgs(filteredYarnArgs)
-	if err = yc.buildInfoModule.Build(); err != nil {
-		return errors.Join(err, restoreYarnrcFunc())
+	if yc.publish {
+		if err = yc.runPublish(backupEnvMap, filteredYarnArgs); err != nil {
+			return errors.Join(err, restoreYarnrcFunc())
+		}
+	} else {
+		yc.buildInfoModule.SetArgs(filteredYarnArgs)
+		if err = yc.buildInfoModule.Build(); err != nil {
+			return errors.Join(err, restoreYarnrcFunc())
+		}
 	}
 
-	if yc.collectBuildInfo {
+	if yc.collectBuildInfo && !yc.publish {
 		close(missingDepsChan)
 		printMissingDependencies(missingDependencies)
 	}
@@ -153,13 +169,13 @@ func (yc *YarnCommand) CommandName() string {
 
 func (yc *YarnCommand) validateSupportedCommand() error {
 	for index, arg := range yc.yarnArgs {
-		if arg == "npm" && len(yc.yarnArgs) > index {
+		if arg == "npm" && len(yc.yarnArgs) > index+1 {
 			npmCommand := yc.yarnArgs[index+1]
-			// The command 'yarn npm publish' is not supported
 			if npmCommand == "publish" {
-				return errorutils.CheckErrorf("The command 'jfrog rt yarn npm publish' is not supported. Use 'jfrog rt upload' instead.")
+				yc.publish = true
+				continue
 			}
-			// 'yarn npm *' commands other than 'info' and 'whoami' are not supported
+			// 'yarn npm *' commands other than 'info', 'whoami' and 'publish' are not supported
 			if npmCommand != "info" && npmCommand != "whoami" {
 				return errorutils.CheckErrorf("The command 'jfrog rt yarn npm %s' is not supported.", npmCommand)
 			}
@@ -174,13 +190,13 @@ func (yc *YarnCommand) validateSupportedCommand() error {
 }
 
 // validateSupportedVersion checks if the version to be set is supported.
-// currently version 4 is not supported.
+// currently versions 5 and above are not supported.
 func validateSupportedVersion(arg string, yarnArgs []string, index int) error {
-	if arg == "set" && len(yarnArgs) > index {
+	if arg == "set" && len(yarnArgs) > index+1 {
 		setCommand := yarnArgs[index+1]
 		if setCommand == "version" && len(yarnArgs) > index+2 {
 			versionCommand := yarnArgs[index+2]
-			err := yarn.IsVersionSupported(versionCommand)
+			err := isVersionSupported(versionCommand)
 			if err != nil {
 				return err
 			}
@@ -189,6 +205,18 @@ func validateSupportedVersion(arg string, yarnArgs []string, index int) error {
 	return nil
 }
 
+// isVersionSupported checks if the Yarn version is supported. Tags, such as 'stable' or 'berry', are resolved by Yarn itself,
+// so they're allowed.
+func isVersionSupported(versionStr string) error {
+	if versionStr == "" || versionStr[0] < '0' || versionStr[0] > '9' {
+		return nil
+	}
+	if version.NewVersion(versionStr).Compare(unsupportedYarnVersion) <= 0 {
+		return errorutils.CheckErrorf("Yarn versions %s and above are not supported. The current version is: %s. Please downgrade to a compatible version to continue", unsupportedYarnVersion, versionStr)
+	}
+	return nil
+}
+
 func (yc *YarnCommand) readConfigFile() error {
 	log.Debug("Preparing to read the config file", yc.configFilePath)
 	vConfig, err := project.ReadConfigFile(yc.configFilePath, project.YAML)
@@ -203,7 +231,22 @@ func (yc *YarnCommand) readConfigFile() error {
 	}
 	yc.repo = resolverParams.TargetRepo()
 	yc.serverDetails, err = resolverParams.ServerDetails()
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Extract deployment params. The packages are published to the resolution repository if no deployment repository is configured.
+	yc.deployRepo, yc.deployerDetails = yc.repo, yc.serverDetails
+	if vConfig.IsSet(project.ProjectConfigDeployerPrefix) {
+		deployerParams, err := project.GetRepoConfigByPrefix(yc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		yc.deployRepo = deployerParams.TargetRepo()
+		yc.deployerDetails, err = deployerParams.ServerDetails()
+		return err
+	}
+	return nil
 }
 
 func (yc *YarnCommand) preparePrerequisites() error {
@@ -304,9 +347,11 @@ func verifyYarnVersion(executablePath string, filteredYarnArgs []string) error {
 		log.Debug("Skipping yarn version verification")
 		return nil
 	}
-	err := yarn.IsInstalledYarnVersionSupported(executablePath)
-	log.Debug("Yarn version verified")
+	yarnVersion, err := buildutils.GetVersion(executablePath, "")
 	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = isVersionSupported(yarnVersion); err != nil {
 		return err
 	}
 	log.Debug("Successfully verified yarn version")
@@ -376,7 +421,11 @@ func ModifyYarnConfigurations(execPath, registry, npmAuthIdent, npmAuthToken str
 		envVarsBackup[key] = &oldVal
 	}
 	// Update scoped registries (these cannot be set in environment variables)
-	return envVarsBackup, errorutils.CheckError(updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken))
+	if err := updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken); err != nil {
+		return envVarsBackup, errorutils.CheckError(err)
+	}
+	// Update the registry's authentication, for registries which are set explicitly, such as the 'publishConfig.registry' of package.json
+	return envVarsBackup, updateNpmRegistries(execPath, map[string]yarnNpmRegistry{registry: {NpmAlwaysAuth: true, NpmAuthIdent: npmAuthIdent, NpmAuthToken: npmAuthToken}})
 }
 
 func updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken string) error {
@@ -404,6 +453,47 @@ func updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken string
 	return yarn.ConfigSet(NpmScopesConfigName, string(updatedNpmScopesStr), execPath, true)
 }
 
+// updateNpmRegistries sets the authentication of the registries in the npmRegistries configuration, keeping the other registries.
+func updateNpmRegistries(execPath string, registries map[string]yarnNpmRegistry) error {
+	// The configuration is read unredacted, since it's written back, including the authentication of the other registries.
+	npmRegistriesStr, err := gofrogio.RunCmdOutput(&yarn.YarnConfig{
+		Executable:   execPath,
+		Command:      []string{"config", "get", NpmRegistriesConfigName},
+		CommandFlags: []string{"--json", "--no-redacted"},
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	npmRegistriesStr = strings.TrimSpace(npmRegistriesStr)
+	npmRegistriesMap := make(map[string]yarnNpmRegistry)
+	if npmRegistriesStr != "" && npmRegistriesStr != "undefined" {
+		if err = json.Unmarshal([]byte(npmRegistriesStr), &npmRegistriesMap); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	for registry, registryConfig := range registries {
+		npmRegistriesMap[npmRegistryKey(registry)] = registryConfig
+	}
+	updatedNpmRegistriesStr, err := json.Marshal(npmRegistriesMap)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return yarn.ConfigSet(NpmRegistriesConfigName, string(updatedNpmRegistriesStr), execPath, true)
+}
+
+// npmRegistryKey returns the key of the registry in the npmRegistries configuration. Yarn matches the keys without the protocol
+// and the trailing slash, such as //acme.jfrog.io/artifactory/api/npm/npm-remote.
+func npmRegistryKey(registry string) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(registry, "https:"), "http:")
+	return "//" + strings.Trim(key, "/")
+}
+
+type yarnNpmRegistry struct {
+	NpmAlwaysAuth bool   `json:"npmAlwaysAuth,omitempty"`
+	NpmAuthIdent  string `json:"npmAuthIdent,omitempty"`
+	NpmAuthToken  string `json:"npmAuthToken,omitempty"`
+}
+
 type yarnNpmScope struct {
 	NpmAlwaysAuth     bool   `json:"npmAlwaysAuth,omitempty"`
 	NpmAuthIdent      string `json:"npmAuthIdent,omitempty"`
@@ -16,14 +16,17 @@ func TestValidateSupportedCommand(t *testing.T) {
 	}{
 		{[]string{}, true},
 		{[]string{"--json"}, true},
-		{[]string{"npm", "publish", "--json"}, false},
+		{[]string{"npm", "publish", "--json"}, true},
 		{[]string{"npm", "--json", "publish"}, false},
+		{[]string{"npm"}, true},
 		{[]string{"npm", "tag", "list"}, false},
 		{[]string{"npm", "info", "package-name"}, true},
 		{[]string{"npm", "whoami"}, true},
 		{[]string{"--version"}, true},
-		{[]string{"set", "version", "4.0.1"}, false},
+		{[]string{"set", "version", "5.0.0"}, false},
+		{[]string{"set", "version", "4.0.1"}, true},
 		{[]string{"set", "version", "3.2.1"}, true},
+		{[]string{"set", "version", "stable"}, true},
 	}
 
 	for _, testCase := range testCases {
@@ -33,6 +36,30 @@ func TestValidateSupportedCommand(t *testing.T) {
 	}
 }
 
+func TestValidateSupportedCommandPublish(t *testing.T) {
+	yarnCmd := NewYarnCommand().SetArgs([]string{"npm", "publish", "--access", "public"})
+	assert.NoError(t, yarnCmd.validateSupportedCommand())
+	assert.True(t, yarnCmd.publish)
+
+	yarnCmd = NewYarnCommand().SetArgs([]string{"npm", "info", "package-name"})
+	assert.NoError(t, yarnCmd.validateSupportedCommand())
+	assert.False(t, yarnCmd.publish)
+}
+
+func TestNpmRegistryKey(t *testing.T) {
+	testCases := []struct {
+		registry string
+		expected string
+	}{
+		{"https://acme.jfrog.io/artifactory/api/npm/npm-remote", "//acme.jfrog.io/artifactory/api/npm/npm-remote"},
+		{"http://localhost:8081/artifactory/api/npm/npm-local/", "//localhost:8081/artifactory/api/npm/npm-local"},
+		{"//acme.jfrog.io/artifactory/api/npm/npm-remote/", "//acme.jfrog.io/artifactory/api/npm/npm-remote"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, npmRegistryKey(testCase.registry), "Registry:", testCase.registry)
+	}
+}
+
 func TestSetAndRestoreEnvironmentVariables(t *testing.T) {
 	const jfrogCliTestingEnvVar = "JFROG_CLI_ENV_VAR_FOR_TESTING"
 	// Check backup and restore of an existing variable
@@ -0,0 +1,25 @@
+package airgapexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{
+	"rt airgap-export [command options] --build=<build name>/<build number> <archive path>",
+	"rt airgap-export [command options] --repo=<repository> <archive path> <dependencies file>...",
+}
+
+func GetDescription() string {
+	return "Download the dependencies of a build, or the dependencies listed by Gradle lockfiles and Maven dependency lists, into a portable archive with an import manifest. Use 'rt airgap-import' to import the archive into the repositories of a disconnected instance."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "archive path",
+			Description: "Path of the zip archive to create.",
+		},
+		{
+			Name:        "dependencies file",
+			Description: "A gradle.lockfile, or the output of 'mvn dependency:list'. The pom and the artifact of each dependency are downloaded through the --repo repository. More than one file can be provided.",
+		},
+	}
+}
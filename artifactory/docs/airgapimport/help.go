@@ -0,0 +1,18 @@
+package airgapimport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt airgap-import [command options] <archive path>"}
+
+func GetDescription() string {
+	return "Verify the files of an archive created by 'rt airgap-export' against its manifest, and upload them to the repositories of the instance. The files of remote repositories are uploaded to repositories named like the remote repositories, unless --repo is set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "archive path",
+			Description: "Path of the archive created by 'rt airgap-export'.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package artifactdiff
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt artifact-diff [command options] <source> <target>"}
+
+func GetDescription() string {
+	return "Compare the content of two artifacts. Archives, such as JAR, WAR, ZIP and TGZ files, are compared entry by entry by their checksums, including the archives they contain, and the added, removed and changed entries are printed. Archives with the same entries have the same content, even if their checksums differ."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "source",
+			Description: "A local file, or the path of an artifact in Artifactory in the format of <repository name>/<repository path>. If a local file exists in the path, it is compared.",
+		},
+		{
+			Name:        "target",
+			Description: "The artifact to compare the source to. A local file, or the path of an artifact in Artifactory in the format of <repository name>/<repository path>.",
+		},
+	}
+}
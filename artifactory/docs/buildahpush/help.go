@@ -0,0 +1,22 @@
+package buildahpush
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt buildah-push <image tag> <target repo>"}
+
+func GetDescription() string {
+	return "Buildah push."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "image tag",
+			Description: "Docker image tag to push.",
+		},
+		{
+			Name:        "target repo",
+			Description: "Target repository in Artifactory.",
+		},
+	}
+}
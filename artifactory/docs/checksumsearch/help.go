@@ -0,0 +1,18 @@
+package checksumsearch
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt checksum-search [command options] <checksum>"}
+
+func GetDescription() string {
+	return "Find the artifacts with the same content across the repositories by their SHA-256, SHA-1 or MD5 checksum, and print the paths of all the artifacts referencing the same binary."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "checksum",
+			Description: "The SHA-256, SHA-1 or MD5 checksum of the content, as a hex string. The algorithm is detected by the length of the checksum.",
+		},
+	}
+}
@@ -99,6 +99,61 @@ const (
 		Defines the directory path where the command summaries data is stored.
 		Every command will have its own individual directory within this base directory.`
 
+	JfrogCliWorkspace = `	JFROG_CLI_WORKSPACE
+		The name of a workspace, which the commands save their results to, so that the next commands can reference them.
+		The upload, copy, move and delete commands save the paths they succeeded on as @last-upload, @last-copy, @last-move
+		and @last-delete, which can be used as the source pattern of the next commands.
+		The commands which run with --spec save the spec as @last-spec, which can be used as --spec=@last-spec.
+		The release bundle creation saves the created version as @last-release-bundle, which can be used as --bundle=@last-release-bundle.`
+
+	JfrogCliSshTunnels = `	JFROG_CLI_SSH_TUNNELS
+		[Default: ssh-tunnels.json in the JFrog CLI home directory]
+		The path of the SSH tunnels file, which routes the traffic of the server profiles to their servers through SSH.
+		Each tunnel, by the ID of its server profile, sets either a jumpHost ([user@]host[:port]) which JFrog CLI connects to,
+		with its identityFile and knownHostsFile, or the localForward address (host:port) of a port-forward opened beforehand.`
+
+	JfrogCliSshKeyPassphrase = `	JFROG_CLI_SSH_KEY_PASSPHRASE
+		The passphrase of the SSH identity file of the jump hosts of the SSH tunnels.`
+
+	JfrogCliTraceCommands = `	JFROG_CLI_TRACE_COMMANDS
+		[Default: false]
+		Set to true to start a span per command, which is propagated on the requests by the W3C traceparent header.
+		The span is a child of the trace context of the TRACEPARENT and TRACESTATE environment variables, if they're set.
+		Supported by the following commands: copy, delete, delete-props, download, move, ping, search, set-props and upload.
+		The build tools and container commands don't propagate the trace context.`
+
+	JfrogCliIpFamily = `	JFROG_CLI_IP_FAMILY
+		[Default: auto]
+		The address family of the connections to the servers: auto, ipv4 or ipv6. With auto, the IPv6 and IPv4 addresses
+		of the servers are attempted in a staggered race, and the first one which connects is used.
+		The family of specific server profiles can be set by <server-id>=<family> entries, separated by commas (,),
+		in the form of "ipv4,prod=auto".
+		When set, the commands open their connections through a local proxy, which is also set as HTTPS_PROXY and HTTP_PROXY
+		for the package managers they run. Doesn't apply if a proxy is set by the environment, or through an SSH tunnel.`
+
+	JfrogCliConnectionAttemptDelay = `	JFROG_CLI_CONNECTION_ATTEMPT_DELAY
+		[Default: 250]
+		The delay in milliseconds before the next address of a server is attempted, while the previous attempts are pending.
+		Applies to the same connections as JFROG_CLI_IP_FAMILY, and when set, also starts the local proxy.`
+
+	JfrogCliRateLimitRetries = `	JFROG_CLI_RATE_LIMIT_RETRIES
+		[Default: 3]
+		The number of times a request is retried after the server throttled it with 429 Too Many Requests. While the server
+		throttles the requests, all the requests of the command to that server wait, for the time of its Retry-After header.
+		Set to 0 to disable the handling, which leaves the retries to the HTTP client.
+		Not supported by the commands which run package managers or other tools.`
+
+	JfrogCliOutputMode = `	JFROG_CLI_OUTPUT_MODE
+		The output mode of the commands: porcelain or quiet. With porcelain, the commands which support the json format
+		write their results as stable, tab-separated key and value lines, and the other commands write their status as
+		a status line, of success or failure, once they're done. With quiet, the results of the commands which
+		support the json format aren't written. The --format option of a command takes precedence over the mode.`
+
+	JfrogCliOutputMessages = `	JFROG_CLI_OUTPUT_MESSAGES
+		The path of a JSON file of the translations of the table titles and headers of the rt commands, by the IDs of
+		the messages, in the form of {"table.field": "CHAMP", "search.title": "Résultats"}. The JSON and porcelain
+		outputs aren't translated.`
+
 	JfrogSecurityCliAnalyzerManagerVersion = `    JFROG_CLI_ANALYZER_MANAGER_VERSION
 		Specifies the version of Analyzer Manager to be used for security commands, provided in semantic versioning (e.g 1.13.4) format. 
 		By default, the latest stable version is used. `
@@ -142,6 +197,15 @@ func GetGlobalEnvVars() string {
 		JfrogCliEncryptionKey,
 		JfrogCliAvoidNewVersionWarning,
 		JfrogCliCommandSummaryOutputDirectory,
+		JfrogCliWorkspace,
+		JfrogCliSshTunnels,
+		JfrogCliSshKeyPassphrase,
+		JfrogCliTraceCommands,
+		JfrogCliIpFamily,
+		JfrogCliConnectionAttemptDelay,
+		JfrogCliRateLimitRetries,
+		JfrogCliOutputMode,
+		JfrogCliOutputMessages,
 		JfrogSecurityCliAnalyzerManagerVersion)
 }
 
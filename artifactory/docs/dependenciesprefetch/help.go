@@ -0,0 +1,23 @@
+package dependenciesprefetch
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt dependencies-prefetch [command options] <repository> <dependencies file>..."}
+
+func GetDescription() string {
+	return "Download the dependencies listed by Gradle lockfiles or Maven dependency lists through an Artifactory repository, without running a build. Downloading through a remote repository caches the dependencies, in order to speed up the first build which uses the repository."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The Maven or Gradle repository to download the dependencies through, usually a remote repository or a virtual repository which includes it.",
+		},
+		{
+			Name: "dependencies file",
+			Description: "A gradle.lockfile, or the output of 'mvn dependency:list', such as the file written by 'mvn dependency:list -DoutputFile=dependencies.txt'. " +
+				"The pom and the artifact of each dependency are downloaded. More than one file can be provided.",
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package deploymentmanifest
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt deployment-manifest [command options] <template path>"}
+
+func GetDescription() string {
+	return "Render a deployment manifest, such as Kubernetes YAML, Helm values or a Compose file, from the image digests and the artifacts recorded by a build or a release bundle, so that deployments reference the exact published images."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name: "template path",
+			Description: "Path to a Go template of the manifest. The template can use .Source, .Name, .Version, .Images and .Artifacts. " +
+				"Each image has the Name, Tag, Digest and Reference fields, and {{ (.Image \"app\").Reference }} references the image whose name ends with 'app' by its digest.",
+		},
+	}
+}
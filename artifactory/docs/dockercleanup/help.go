@@ -0,0 +1,18 @@
+package dockercleanup
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt docker-cleanup [command options] <repository>"}
+
+func GetDescription() string {
+	return "Find the manifests, which are not referenced by any tag, and the layers, which are not referenced by any manifest, in a Docker repository. Reports the reclaimable storage, and deletes them when --delete is set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The Docker repository to clean.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package dockercopy
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt docker-copy [command options] <source repo>/<image>:<tag> <target repo>/<image>:<tag>"}
+
+func GetDescription() string {
+	return "Copy a Docker image to another repository and tag it, without pulling it locally. Within an Artifactory instance, the image is copied by the server, which is supported by local repositories only. When --target-server-id is set, the blobs the target repository doesn't have are streamed to it from the source repository."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "source",
+			Description: "The image to copy, in the form of <repo>/<image>:<tag>. When --target-server-id is set, the image can also be referenced by its digest, in the form of <repo>/<image>@<digest>.",
+		},
+		{
+			Name:        "target",
+			Description: "The repository, name and tag of the copy, in the form of <repo>/<image>:<tag>.",
+		},
+	}
+}
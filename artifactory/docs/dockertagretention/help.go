@@ -0,0 +1,19 @@
+package dockertagretention
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt docker-tag-retention [command options] <repository>"}
+
+func GetDescription() string {
+	return "Delete the tags of the images in a Docker repository according to retention rules: keep the last tags of each image, protect release tags, and delete the tags older than an age. " +
+		"Running the command again with the same rules deletes nothing new, so it can be scheduled. Run 'rt docker-cleanup' afterwards to remove the manifests and layers left unreferenced."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The Docker repository to apply the retention rules to.",
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package dockerwarmcache
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt docker-warm-cache [command options] <repository> <image list file>..."}
+
+func GetDescription() string {
+	return "Pull Docker images through an Artifactory repository without a Docker client. The manifests and the layers of the images are downloaded and discarded. Pulling through a remote repository caches the images, so that their first pulls, such as on a release day, are served from the cache."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The Docker repository to pull the images through, usually a remote repository or a virtual repository which includes it.",
+		},
+		{
+			Name: "image list file",
+			Description: "A file listing an image per line, in the form of <image>:<tag> or <image>@<digest>, such as 'library/nginx:1.27'. " +
+				"Empty lines and lines which start with # are skipped. All the platforms of multi-arch images are pulled. More than one file can be provided.",
+		},
+	}
+}
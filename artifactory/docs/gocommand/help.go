@@ -13,8 +13,10 @@ func GetDescription() string {
 func GetArguments() []components.Argument {
 	return []components.Argument{
 		{
-			Name:        "go commands",
-			Description: "Arguments and options for the Go command.",
+			Name: "go commands",
+			Description: "Arguments and options for the Go command. " +
+				"The vendor command runs go mod vendor, resolving all the modules from Artifactory, and writes an integrity report of the vendored modules " +
+				"with their h1 hashes to the path of its --report option, go-vendor-report.json by default. The report is added to the build-info if it's collected.",
 		},
 	}
 }
@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rt go-config [command options]"}
 
 func GetDescription() string {
-	return "Generate Go build configuration."
+	return "Generate Go build configuration. The GOPRIVATE, GONOSUMDB and GOFLAGS which the Go commands run with can be set too, as well as the checksum database proxied by the resolution repository, which the go.sum of the project is verified against when the build-info is collected."
 }
 
 func GetArguments() []components.Argument {
@@ -2,7 +2,7 @@ package gopublish
 
 import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 
-var Usage = []string{"rt gp [command options] <project version>"}
+var Usage = []string{"rt gp [command options] [project version]"}
 
 func GetDescription() string {
 	return "Publish a Go package and/or its dependencies to Artifactory."
@@ -11,8 +11,10 @@ func GetDescription() string {
 func GetArguments() []components.Argument {
 	return []components.Argument{
 		{
-			Name:        "project version",
-			Description: "Specifies the version of the Go package to be published.",
+			Name: "project version",
+			Description: "Specifies the version of the Go package to be published. A pseudo-version, such as v0.0.0-20260101120000-abcdef123456, can be used. " +
+				"If omitted, each module is published with the version of its current Git commit: the version of the commit's release tag, or a pseudo-version based on the latest release tag of the module. " +
+				"The release tags of a module in a subdirectory are prefixed with the subdirectory, such as sub/v1.2.0.",
 		},
 	}
 }
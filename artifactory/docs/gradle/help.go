@@ -5,7 +5,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 )
 
-var Usage = []string{"rt gradle <tasks and options> [command options]"}
+var Usage = []string{"rt gradle <tasks and options> [command options]", "rt gradle <tasks and options> [command options] -- <gradle options>"}
 
 var EnvVar = []string{common.JfrogCliReleasesRepo, common.JfrogCliDependenciesDir}
 
@@ -19,5 +19,9 @@ func GetArguments() []components.Argument {
 			Name:        "tasks and options",
 			Description: "Tasks and options to run with the Gradle command. For example: '-b path/to/build.gradle'.",
 		},
+		{
+			Name:        "gradle options",
+			Description: "Options passed to Gradle as is, without being parsed by JFrog CLI. For example: '-- --scan --offline'.",
+		},
 	}
 }
@@ -0,0 +1,22 @@
+package jdkprovision
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt jdk-provision [command options] <repository> <version>"}
+
+func GetDescription() string {
+	return "Download the JDK of a toolchain spec from a generic or remote repository in Artifactory, and cache it under the JFrog CLI dependencies directory. The commands which set the JAVA_HOME and the PATH of the JDK are printed, for example to run: eval \"$(jf rt jdk-provision jdks 17)\". In GitHub Actions, they are also set for the next steps of the job. The JDK is recorded in the environment of the build-info, if the build name and number are set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The repository of the JDK archives.",
+		},
+		{
+			Name:        "version",
+			Description: "The version of the JDK. The latest version in the repository which matches it is provisioned, so that 17 provisions the latest JDK 17 update.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package latestupdate
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt latest-update [command options] <family pattern> [latest path]"}
+
+func GetDescription() string {
+	return "Maintain a stable \"latest\" pointer to the latest artifact of a versioned artifact family, by copying it to a stable path or by marking it with a property. Run it after each upload, so that consumers can fetch a fixed path without sorting the versions."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "family pattern",
+			Description: "The wildcard pattern of the artifacts of the family, in the form of <repo>/<path>, such as generic-local/app/*/app-*.zip. By default, the first wildcard matches the versions.",
+		},
+		{
+			Name:        "latest path",
+			Description: "The stable path which the latest artifact is copied to, such as generic-local/app/latest/app.zip. Required in the copy mode.",
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package manifestsync
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt manifest-sync [command options] <manifest path> <target path>"}
+
+func GetDescription() string {
+	return "Converge a repository path to the files of a manifest. The files missing from the path are uploaded, the files whose checksum changed are uploaded again, the files which aren't in the manifest are deleted, and the properties of the manifest are set on the files. Other properties of the files are kept."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name: "manifest path",
+			Description: "Path to a JSON manifest in the format of {\"props\": {\"<key>\": \"<value>\"}, \"files\": [{\"path\": \"<path relative to the target path>\", \"file\": \"<local file>\", \"sha256\": \"<checksum>\", \"props\": {\"<key>\": \"<value>\"}}]}. " +
+				"The local files are relative to the manifest. A file without a local file must already be in the target path with the sha256 checksum. The properties of a file override the common ones.",
+		},
+		{
+			Name:        "target path",
+			Description: "The repository path to converge, in the format of <repository name>/<repository path>.",
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package mvnpromote
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt mvn-promote [command options] <build name> <build number> <release repository>"}
+
+func GetDescription() string {
+	return "Promote the Maven snapshot artifacts of a published build to a release version. The artifacts are deployed to the release repository with the release version in their paths and in their POMs, and a release build-info, which links back to the snapshot build, is published."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "build name",
+			Description: "The name of the snapshot build. If the build is assigned to a project, provide the project key using the --project flag.",
+		},
+		{
+			Name:        "build number",
+			Description: "The number of the snapshot build.",
+		},
+		{
+			Name:        "release repository",
+			Description: "The repository the release artifacts are deployed to.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package nodeprovision
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt node-provision [command options] <repository> <version>"}
+
+func GetDescription() string {
+	return "Download a Node.js runtime from a repository in Artifactory which proxies https://nodejs.org/dist, verify it against the SHASUMS256.txt of its release, and cache it under the JFrog CLI dependencies directory. The command which adds the runtime to the PATH is printed, for example to run: eval \"$(jf rt node-provision nodejs-remote 20)\". In GitHub Actions, the PATH is also set for the next steps of the job. The runtime is recorded in the environment of the build-info, if the build name and number are set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The repository which proxies the Node.js distributions.",
+		},
+		{
+			Name:        "version",
+			Description: "The version of Node.js. The latest release in the index of the repository which matches it is provisioned, so that 20 provisions the latest Node.js 20 release. Set to lts to provision the latest LTS release.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package npmbundle
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt npm-bundle [command options] <repository> <target>"}
+
+func GetDescription() string {
+	return "Download the tarballs of all the dependencies resolved by the package-lock.json of the npm project in the current directory from Artifactory, together with the lockfile and a manifest of the tarballs and their checksums, for redistribution to air-gapped environments. Run it after 'jf npm install' or 'jf npm ci'. The tarballs are verified against the integrity recorded by the lockfile."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository",
+			Description: "The npm repository to download the dependencies through. Dependencies which the lockfile resolves from the Artifactory server are downloaded from their resolved URLs.",
+		},
+		{
+			Name:        "target",
+			Description: "The directory to write the bundle to, or the path of the zip archive if --archive is set.",
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package npmdisttag
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt npm-dist-tag [command options] <action> <repository> <package> [tag]"}
+
+func GetDescription() string {
+	return "Manage the dist-tags of an npm package in an Artifactory npm repository. The tags of a virtual repository are managed in its default deployment repository. The tagged tarball gets an npm.dist-tag.<tag> property, and the build properties if a build name and number are provided."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "action",
+			Description: "The action. Acceptable values are: add - set the tag to the version of the package, rm - remove the tag, ls - list the tags.",
+		},
+		{
+			Name:        "repository",
+			Description: "The npm repository of the package.",
+		},
+		{
+			Name:        "package",
+			Description: "The package. In the form of <name>@<version> for the add action, and <name> for the others.",
+		},
+		{
+			Name:        "tag",
+			Description: "The dist-tag. Required for the rm action. [Default: latest] for the add action.",
+		},
+	}
+}
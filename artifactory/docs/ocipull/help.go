@@ -0,0 +1,23 @@
+package ocipull
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"oci pull [command options] <reference> [target directory]"}
+
+func GetDescription() string {
+	return "Pull the files of an OCI artifact from a Docker or OCI repository in Artifactory, as oras pull does, without a Docker client. The layers are written by the names of their org.opencontainers.image.title annotations, after they're verified against their digests. The pulled files are added to the build-info as dependencies, if the build name and number are set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "reference",
+			Description: "The artifact to pull, in the form of <repo>/<name>:<tag> or <repo>/<name>@<digest>.",
+		},
+		{
+			Name:        "target directory",
+			Description: "The directory the files are written to. The current directory is used if it isn't set.",
+			Optional:    true,
+		},
+	}
+}
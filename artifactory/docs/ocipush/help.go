@@ -0,0 +1,22 @@
+package ocipush
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"oci push [command options] <reference> <file>[:<media type>]..."}
+
+func GetDescription() string {
+	return "Push files as an OCI artifact to a Docker or OCI repository in Artifactory, as oras push does, without a Docker client. Each file is a layer of the manifest of the artifact, with its media type and its file name, such as an SBOM, a WASM module or a Helm chart. The pushed blobs and the manifest are added to the build-info, if the build name and number are set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "reference",
+			Description: "The artifact to push, in the form of <repo>/<name>:<tag>.",
+		},
+		{
+			Name:        "files",
+			Description: "The files of the artifact, each in the form of <path>[:<media type>]. The media type of the files is application/vnd.oci.image.layer.v1.tar, unless it's set.",
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package onboardinganalyze
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt onboarding-analyze [command options] [path]"}
+
+func GetDescription() string {
+	return "Analyze the working tree of a project for its migration to Artifactory. The report lists the detected build tools and their existing publish configurations, the registries hardcoded in the build descriptors, the registry configurations and the CI pipelines, and the native build tool commands of the CI pipelines with the JFrog CLI commands which replace them. The JFrog CLI config files of the build tools are generated if --output-dir is set. The analysis is local, and no server is contacted."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "path",
+			Description: "[Default: The current directory] The directory of the project.",
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package pypiupload
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"pypi upload [command options] <files pattern>"}
+
+func GetDescription() string {
+	return "Upload wheels and source distributions to a PyPI repository in Artifactory, as twine upload does, without configuring twine or a .pypirc. The files are deployed by their checksums when Artifactory already has their content, and get the pypi.name, pypi.normalized.name, pypi.version and pypi.summary properties of their metadata. The distributions are added to the build-info, if the build name and number are set."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "files pattern",
+			Description: "The distributions to upload, as a path which may include wildcards, such as dist/*. Files which aren't wheels or source distributions are skipped.",
+		},
+	}
+}
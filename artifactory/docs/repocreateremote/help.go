@@ -0,0 +1,26 @@
+package repocreateremote
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+)
+
+var Usage = []string{"rt rcr [command options] <preset> <repository key>"}
+
+func GetDescription() string {
+	return "Create a remote repository in Artifactory, proxying a well-known registry with its recommended settings."
+}
+
+func GetArguments(presetNames []string) []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "preset",
+			Description: "The registry proxied by the repository. The available presets are: " + strings.Join(presetNames, ", ") + ".",
+		},
+		{
+			Name:        "repository key",
+			Description: "The key of the created repository.",
+		},
+	}
+}
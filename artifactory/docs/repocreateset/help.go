@@ -0,0 +1,25 @@
+package repocreateset
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt rcs [command options] <package type> <name>"}
+
+func GetDescription() string {
+	return "Create a local, a remote and a virtual repository of a package type in Artifactory. " +
+		"The virtual repository includes the local repository followed by the remote repository, and deploys to the local repository. " +
+		"Existing repositories, such as other virtual repositories, can be nested in the virtual repository by the --include-repos option. " +
+		"If one of the repositories fails to be created, the created repositories are deleted."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "package type",
+			Description: "The package type of the repositories, such as npm, maven or docker.",
+		},
+		{
+			Name:        "name",
+			Description: "The name shared by the repositories. The repository keys are <name>-<package type>-local, <name>-<package type>-remote and <name>-<package type>-virtual.",
+		},
+	}
+}
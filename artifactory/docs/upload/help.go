@@ -6,7 +6,8 @@ import (
 )
 
 var Usage = []string{"rt u [command options] <source pattern> <target pattern>",
-	"rt u --spec=<File Spec path> [command options]"}
+	"rt u --spec=<File Spec path> [command options]",
+	"rt u --routing-rules=<routing rules file path> [command options]"}
 
 var EnvVar = []string{common.JfrogCliMinChecksumDeploySizeKb, common.JfrogCliFailNoOp, common.JfrogCliUploadEmptyArchive}
 
@@ -0,0 +1,83 @@
+package formats
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// MessagesEnv sets the path of a JSON file of the translations of the human-readable output of the commands, by the IDs of
+// the messages, in the form of {"table.field": "CHAMP"}. The messages without a translation are written in English.
+// The JSON and porcelain outputs aren't translated.
+const MessagesEnv = "JFROG_CLI_OUTPUT_MESSAGES"
+
+// Localizer translates the messages of the human-readable output. The IDs of the messages don't change between versions,
+// so that the translations keep applying when the English messages are reworded.
+type Localizer interface {
+	// Localize returns the translation of the message by its ID, or the message as is, if it has no translation.
+	Localize(id, message string) string
+}
+
+// Catalog is a Localizer of the translations by the IDs of the messages.
+type Catalog map[string]string
+
+func (c Catalog) Localize(id, message string) string {
+	if translation := c[id]; translation != "" {
+		return translation
+	}
+	return message
+}
+
+var (
+	localizerMutex sync.RWMutex
+	localizer      Localizer
+	loadMessages   sync.Once
+)
+
+// SetLocalizer sets the localizer of the human-readable output, for programs which embed the commands and translate their
+// output. A nil localizer restores the translations of the MessagesEnv file.
+func SetLocalizer(newLocalizer Localizer) {
+	loadMessages.Do(func() {})
+	localizerMutex.Lock()
+	defer localizerMutex.Unlock()
+	localizer = newLocalizer
+	if localizer == nil {
+		localizer = loadCatalog(os.Getenv(MessagesEnv))
+	}
+}
+
+// Localize returns the translation of the message of the human-readable output by its ID, or the message as is, if it has
+// no translation.
+func Localize(id, message string) string {
+	loadMessages.Do(func() {
+		catalog := loadCatalog(os.Getenv(MessagesEnv))
+		localizerMutex.Lock()
+		defer localizerMutex.Unlock()
+		localizer = catalog
+	})
+	localizerMutex.RLock()
+	defer localizerMutex.RUnlock()
+	if localizer == nil {
+		return message
+	}
+	return localizer.Localize(id, message)
+}
+
+// loadCatalog reads the translations of the file. The output isn't translated if the file can't be read, rather than failing
+// the command.
+func loadCatalog(path string) Localizer {
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err == nil {
+		catalog := Catalog{}
+		if err = json.Unmarshal(content, &catalog); err == nil {
+			return catalog
+		}
+	}
+	log.Warn("The output isn't translated, since the", MessagesEnv, "file couldn't be read:", err.Error())
+	return nil
+}
@@ -0,0 +1,38 @@
+package formats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalize(t *testing.T) {
+	messagesFile := filepath.Join(t.TempDir(), "messages.json")
+	require.NoError(t, os.WriteFile(messagesFile, []byte(`{"table.field":"CHAMP"}`), 0600))
+	t.Setenv(MessagesEnv, messagesFile)
+	SetLocalizer(nil)
+	defer func() {
+		t.Setenv(MessagesEnv, "")
+		SetLocalizer(nil)
+	}()
+
+	assert.Equal(t, "CHAMP", Localize("table.field", "FIELD"))
+	// The messages without a translation are written as is.
+	assert.Equal(t, "VALUE", Localize("table.value", "VALUE"))
+
+	SetLocalizer(Catalog{"table.value": "VALEUR"})
+	assert.Equal(t, "FIELD", Localize("table.field", "FIELD"))
+	assert.Equal(t, "VALEUR", Localize("table.value", "VALUE"))
+}
+
+func TestLoadCatalogInvalidFile(t *testing.T) {
+	assert.Nil(t, loadCatalog(""))
+	assert.Nil(t, loadCatalog(filepath.Join(t.TempDir(), "missing.json")))
+
+	messagesFile := filepath.Join(t.TempDir(), "messages.json")
+	require.NoError(t, os.WriteFile(messagesFile, []byte(`["not", "a", "catalog"]`), 0600))
+	assert.Nil(t, loadCatalog(messagesFile))
+}
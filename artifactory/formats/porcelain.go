@@ -0,0 +1,126 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Porcelain is the output format for scripts. It's rendered from the JSON output of the commands, whose fields are an API,
+// so the lines don't change between versions, unlike the human-readable output and the log messages, and aren't localized.
+// The commands which don't have a JSON output write the status of the command as their porcelain output:
+//
+//	status	success
+//
+// Each value of the JSON output is written in a line of its own, as its key and its value, separated by a tab:
+//
+//	files.0.path	generic-local/app/app.zip
+//	files.0.status	success
+//	status	success
+//
+// The keys are the paths of the values, whose objects' keys and arrays' indexes are separated by dots. The objects' keys
+// are sorted. Strings are written as is, and numbers and booleans as in JSON. Nulls, empty objects and empty arrays aren't
+// written, and a document which is a single value is written as is, without a key. Backslashes, tabs, newlines and carriage
+// returns are escaped in the keys and the values, and so are dots in the objects' keys, so that every line has a single tab,
+// and the keys can be split by the unescaped dots.
+const Porcelain coreformat.OutputFormat = "porcelain"
+
+// Quiet is the output format which writes no results. The errors are still reported by the exit code, and the log messages are
+// still written to the standard error.
+const Quiet coreformat.OutputFormat = "quiet"
+
+// OutputModeEnv sets the output format of all the commands, to Porcelain or Quiet, unless their --format option is set.
+const OutputModeEnv = "JFROG_CLI_OUTPUT_MODE"
+
+var porcelainEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// WritePorcelain writes the JSON documents of the content as porcelain lines.
+func WritePorcelain(w io.Writer, content []byte) error {
+	decoder := newPorcelainDecoder(content)
+	for {
+		var document any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckErrorf("failed to render the output as porcelain lines: %s", err.Error())
+		}
+		if err = writePorcelainValue(w, "", document); err != nil {
+			return err
+		}
+	}
+}
+
+// The numbers are decoded as they're written, rather than as floats, so that large numbers aren't rounded.
+func newPorcelainDecoder(content []byte) *json.Decoder {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	return decoder
+}
+
+func decodePorcelainDocument(content []byte) (document any, err error) {
+	if err = newPorcelainDecoder(content).Decode(&document); err != nil {
+		return nil, errorutils.CheckErrorf("failed to render the output as porcelain lines: %s", err.Error())
+	}
+	return document, nil
+}
+
+func writePorcelainValue(w io.Writer, key string, value any) error {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for objectKey := range typed {
+			keys = append(keys, objectKey)
+		}
+		sort.Strings(keys)
+		for _, objectKey := range keys {
+			if err := writePorcelainValue(w, joinPorcelainKey(key, escapePorcelainKey(objectKey)), typed[objectKey]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for i, element := range typed {
+			if err := writePorcelainValue(w, joinPorcelainKey(key, strconv.Itoa(i)), element); err != nil {
+				return err
+			}
+		}
+		return nil
+	case string:
+		return writePorcelainLine(w, key, porcelainEscaper.Replace(typed))
+	default:
+		// Numbers and booleans.
+		return writePorcelainLine(w, key, fmt.Sprint(typed))
+	}
+}
+
+func escapePorcelainKey(objectKey string) string {
+	return strings.ReplaceAll(porcelainEscaper.Replace(objectKey), ".", `\.`)
+}
+
+func joinPorcelainKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func writePorcelainLine(w io.Writer, key, value string) (err error) {
+	if key == "" {
+		_, err = fmt.Fprintln(w, value)
+	} else {
+		_, err = fmt.Fprintf(w, "%s\t%s\n", key, value)
+	}
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,64 @@
+package formats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePorcelain(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "nested objects and arrays",
+			content:  `{"status":"success","files":[{"path":"generic-local/a.zip","size":10},{"path":"generic-local/b.zip","size":20}],"totals":{"success":2,"failure":0}}`,
+			expected: "files.0.path\tgeneric-local/a.zip\nfiles.0.size\t10\nfiles.1.path\tgeneric-local/b.zip\nfiles.1.size\t20\nstatus\tsuccess\ntotals.failure\t0\ntotals.success\t2\n",
+		},
+		{
+			name:     "numbers and booleans",
+			content:  `{"size":12345678901234567890,"ratio":0.5,"dryRun":false}`,
+			expected: "dryRun\tfalse\nratio\t0.5\nsize\t12345678901234567890\n",
+		},
+		{
+			name:     "nulls and empty values",
+			content:  `{"error":null,"files":[],"props":{},"name":""}`,
+			expected: "name\t\n",
+		},
+		{
+			name:     "escaping",
+			content:  `{"build.name":"a\tb\nc\\d","props":{"key\twith\ttabs":"v"}}`,
+			expected: "build\\.name\ta\\tb\\nc\\\\d\nprops.key\\twith\\ttabs\tv\n",
+		},
+		{
+			name:     "multiple documents",
+			content:  "{\"status\":\"success\"}\n{\"status\":\"failure\"}\n",
+			expected: "status\tsuccess\nstatus\tfailure\n",
+		},
+		{
+			name:     "single values",
+			content:  `"OK" 3`,
+			expected: "OK\n3\n",
+		},
+		{
+			name:     "empty content",
+			content:  "",
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			require.NoError(t, WritePorcelain(output, []byte(test.content)))
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestWritePorcelainInvalidJson(t *testing.T) {
+	assert.Error(t, WritePorcelain(&bytes.Buffer{}, []byte("Uploaded 2 files")))
+}
@@ -0,0 +1,166 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ResultWriter writes the JSON results of a command to its writer, as indented JSON, or as porcelain lines with the Porcelain format.
+// The results are written to the writer the ResultWriter is created with, rather than to the standard output or the logger, so that
+// commands which run concurrently, or are embedded in other programs, write their results where their callers need them.
+type ResultWriter struct {
+	w         io.Writer
+	porcelain bool
+}
+
+func NewResultWriter(w io.Writer, outputFormat coreformat.OutputFormat) *ResultWriter {
+	return &ResultWriter{w: w, porcelain: outputFormat == Porcelain}
+}
+
+// WriteJSON writes a JSON document.
+func (rw *ResultWriter) WriteJSON(content []byte) error {
+	if rw.porcelain {
+		return WritePorcelain(rw.w, content)
+	}
+	_, err := fmt.Fprintln(rw.w, clientUtils.IndentJson(content))
+	return errorutils.CheckError(err)
+}
+
+// WriteJSONArray writes the items returned by next, until it returns false, as a JSON array. The items are written one by one,
+// so that large results aren't held in memory.
+func (rw *ResultWriter) WriteJSONArray(next func() (item any, ok bool)) error {
+	if rw.porcelain {
+		return rw.writePorcelainItems("", next)
+	}
+	if _, err := io.WriteString(rw.w, "["); err != nil {
+		return errorutils.CheckError(err)
+	}
+	count, err := rw.writeJSONItems("  ", next)
+	if err != nil {
+		return err
+	}
+	closing := "]\n"
+	if count > 0 {
+		closing = "\n" + closing
+	}
+	_, err = io.WriteString(rw.w, closing)
+	return errorutils.CheckError(err)
+}
+
+// WriteJSONWithArray writes the JSON object, with the items returned by next, until it returns false, as the array of its field.
+// The items are written one by one, so that large results aren't held in memory.
+func (rw *ResultWriter) WriteJSONWithArray(object []byte, field string, next func() (item any, ok bool)) error {
+	if rw.porcelain {
+		return rw.writePorcelainWithArray(object, field, next)
+	}
+	if !isJSONObject(object) {
+		return errorutils.CheckErrorf("failed to write the output: the output isn't a JSON object")
+	}
+	// The array is written after the fields of the object, before its closing brace.
+	opening := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(clientUtils.IndentJson(object)), "}"))
+	if opening != "{" {
+		opening += ","
+	}
+	fieldName, err := json.Marshal(field)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if _, err = fmt.Fprintf(rw.w, "%s\n  %s: [", opening, fieldName); err != nil {
+		return errorutils.CheckError(err)
+	}
+	count, err := rw.writeJSONItems("    ", next)
+	if err != nil {
+		return err
+	}
+	closing := "]\n}\n"
+	if count > 0 {
+		closing = "\n  " + closing
+	}
+	_, err = io.WriteString(rw.w, closing)
+	return errorutils.CheckError(err)
+}
+
+func (rw *ResultWriter) writeJSONItems(indent string, next func() (any, bool)) (count int, err error) {
+	for item, ok := next(); ok; item, ok = next() {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return count, errorutils.CheckError(err)
+		}
+		var indented bytes.Buffer
+		if err = json.Indent(&indented, data, indent, "  "); err != nil {
+			return count, errorutils.CheckError(err)
+		}
+		separator := ",\n"
+		if count == 0 {
+			separator = "\n"
+		}
+		if _, err = fmt.Fprintf(rw.w, "%s%s%s", separator, indent, indented.String()); err != nil {
+			return count, errorutils.CheckError(err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// writePorcelainWithArray writes the porcelain lines of the object, and the lines of the items of its array field in between, at the
+// place of the field among the sorted keys of the object.
+func (rw *ResultWriter) writePorcelainWithArray(object []byte, field string, next func() (any, bool)) error {
+	document, err := decodePorcelainDocument(object)
+	if err != nil {
+		return err
+	}
+	fields, isObject := document.(map[string]any)
+	if !isObject {
+		return errorutils.CheckErrorf("failed to write the output: the output isn't a JSON object")
+	}
+	delete(fields, field)
+	keys := make([]string, 0, len(fields)+1)
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	keys = append(keys, field)
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == field {
+			err = rw.writePorcelainItems(escapePorcelainKey(field), next)
+		} else {
+			err = writePorcelainValue(rw.w, escapePorcelainKey(key), fields[key])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rw *ResultWriter) writePorcelainItems(key string, next func() (any, bool)) error {
+	i := 0
+	for item, ok := next(); ok; item, ok = next() {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		document, err := decodePorcelainDocument(data)
+		if err != nil {
+			return err
+		}
+		if err = writePorcelainValue(rw.w, joinPorcelainKey(key, strconv.Itoa(i)), document); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+func isJSONObject(content []byte) bool {
+	return json.Valid(content) && bytes.HasPrefix(bytes.TrimSpace(content), []byte("{"))
+}
@@ -0,0 +1,83 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFile struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+// nextOf returns the items one by one, as the readers of the results of the commands do.
+func nextOf(items ...testFile) func() (any, bool) {
+	return func() (any, bool) {
+		if len(items) == 0 {
+			return nil, false
+		}
+		item := items[0]
+		items = items[1:]
+		return item, true
+	}
+}
+
+func TestResultWriterWriteJSON(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSON([]byte(`{"status":"success"}`)))
+	assert.Equal(t, "{\n  \"status\": \"success\"\n}\n", output.String())
+
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, Porcelain).WriteJSON([]byte(`{"status":"success"}`)))
+	assert.Equal(t, "status\tsuccess\n", output.String())
+}
+
+func TestResultWriterWriteJSONArray(t *testing.T) {
+	files := []testFile{{Path: "generic-local/a.zip", Size: 10}, {Path: "generic-local/b.zip", Size: 20}}
+	var output bytes.Buffer
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSONArray(nextOf(files...)))
+	var written []testFile
+	require.NoError(t, json.Unmarshal(output.Bytes(), &written))
+	assert.Equal(t, files, written)
+
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, Porcelain).WriteJSONArray(nextOf(files...)))
+	assert.Equal(t, "0.path\tgeneric-local/a.zip\n0.size\t10\n1.path\tgeneric-local/b.zip\n1.size\t20\n", output.String())
+
+	// An empty array is written as an empty JSON array, and as no porcelain lines.
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSONArray(nextOf()))
+	assert.Equal(t, "[]\n", output.String())
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, Porcelain).WriteJSONArray(nextOf()))
+	assert.Empty(t, output.String())
+}
+
+func TestResultWriterWriteJSONWithArray(t *testing.T) {
+	summary := []byte(`{"status":"success","totals":{"success":1,"failure":0}}`)
+	files := []testFile{{Path: "generic-local/a.zip", Size: 10}}
+
+	var output bytes.Buffer
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSONWithArray(summary, "files", nextOf(files...)))
+	assert.JSONEq(t, `{"status":"success","totals":{"success":1,"failure":0},"files":[{"path":"generic-local/a.zip","size":10}]}`, output.String())
+
+	// The lines of the array are written at the place of the field among the sorted keys.
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, Porcelain).WriteJSONWithArray(summary, "files", nextOf(files...)))
+	assert.Equal(t, "files.0.path\tgeneric-local/a.zip\nfiles.0.size\t10\nstatus\tsuccess\ntotals.failure\t0\ntotals.success\t1\n", output.String())
+
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSONWithArray(summary, "files", nextOf()))
+	assert.JSONEq(t, `{"status":"success","totals":{"success":1,"failure":0},"files":[]}`, output.String())
+
+	output.Reset()
+	require.NoError(t, NewResultWriter(&output, coreformat.Json).WriteJSONWithArray([]byte(`{}`), "files", nextOf(files...)))
+	assert.JSONEq(t, `{"files":[{"path":"generic-local/a.zip","size":10}]}`, output.String())
+
+	assert.ErrorContains(t, NewResultWriter(&output, coreformat.Json).WriteJSONWithArray([]byte(`[]`), "files", nextOf()), "isn't a JSON object")
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// DefaultAqlPageSize is the number of results of each page, which is below the default limit of the results of the AQL queries
+// of non-admin users.
+const DefaultAqlPageSize = 1000
+
+// The fields which the pages are sorted by. They identify each item, so the order of the items is the same in every page.
+var aqlPageSortFields = []string{"repo", "path", "name"}
+
+// AqlPager pages through the results of an items AQL query, so that queries matching more items than the maximum number of
+// results the server returns aren't truncated.
+// By default, the pages are sorted by the repo, path and name of the items, and each page starts at the offset of the previous
+// one's end. With search-after, each page starts after the last item of the previous one instead. Use it when the items are
+// deleted or moved between the pages, since that shifts the offsets of the remaining items.
+type AqlPager struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	// The criteria of the items.find() query, without the enclosing braces, such as "repo":"generic-local","type":"file".
+	criteria    string
+	include     []string
+	pageSize    int
+	searchAfter bool
+	offset      int
+	lastItem    *servicesUtils.ResultItem
+	done        bool
+}
+
+func NewAqlPager(servicesManager artifactory.ArtifactoryServicesManager, criteria string) *AqlPager {
+	return &AqlPager{servicesManager: servicesManager, criteria: criteria, pageSize: DefaultAqlPageSize}
+}
+
+// SetInclude sets the fields of the results. The repo, path and name fields are always included, since the pages are sorted by them.
+func (ap *AqlPager) SetInclude(include ...string) *AqlPager {
+	ap.include = include
+	return ap
+}
+
+func (ap *AqlPager) SetPageSize(pageSize int) *AqlPager {
+	ap.pageSize = pageSize
+	return ap
+}
+
+func (ap *AqlPager) SetSearchAfter(searchAfter bool) *AqlPager {
+	ap.searchAfter = searchAfter
+	return ap
+}
+
+// Next returns the next page of the results. Returns an empty page once all the results were returned.
+func (ap *AqlPager) Next() ([]servicesUtils.ResultItem, error) {
+	if ap.done {
+		return nil, nil
+	}
+	if ap.pageSize <= 0 {
+		return nil, errorutils.CheckErrorf("the AQL page size must be positive, got %d", ap.pageSize)
+	}
+	page, err := ExecuteAqlQuery(ap.servicesManager, ap.createPageQuery())
+	if err != nil {
+		return nil, err
+	}
+	ap.offset += len(page)
+	if len(page) < ap.pageSize {
+		ap.done = true
+	}
+	if len(page) > 0 {
+		ap.lastItem = &page[len(page)-1]
+	}
+	return page, nil
+}
+
+// All returns all the results, by reading all the pages.
+func (ap *AqlPager) All() (results []servicesUtils.ResultItem, err error) {
+	for {
+		page, err := ap.Next()
+		if err != nil || len(page) == 0 {
+			return results, err
+		}
+		results = append(results, page...)
+	}
+}
+
+func (ap *AqlPager) createPageQuery() string {
+	criteria := "{" + ap.criteria + "}"
+	offset := ap.offset
+	if ap.searchAfter {
+		offset = 0
+		if ap.lastItem != nil {
+			criteria = fmt.Sprintf(`{"$and":[%s,%s]}`, criteria, createSearchAfterCriteria(ap.lastItem))
+		}
+	}
+	include := append([]string{}, aqlPageSortFields...)
+	for _, field := range ap.include {
+		if !slices.Contains(include, field) {
+			include = append(include, field)
+		}
+	}
+	return fmt.Sprintf(`items.find(%s).include(%s).sort({"$asc":[%s]}).offset(%d).limit(%d)`,
+		criteria, quoteAqlFields(include), quoteAqlFields(aqlPageSortFields), offset, ap.pageSize)
+}
+
+// createSearchAfterCriteria returns the criteria of the items which are sorted after the item.
+func createSearchAfterCriteria(item *servicesUtils.ResultItem) string {
+	repo, itemPath, name := quoteAqlValue(item.Repo), quoteAqlValue(item.Path), quoteAqlValue(item.Name)
+	return fmt.Sprintf(`{"$or":[{"repo":{"$gt":%s}},{"$and":[{"repo":%s},{"path":{"$gt":%s}}]},{"$and":[{"repo":%s},{"path":%s},{"name":{"$gt":%s}}]}]}`,
+		repo, repo, itemPath, repo, itemPath, name)
+}
+
+func quoteAqlFields(fields []string) string {
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		quoted = append(quoted, quoteAqlValue(field))
+	}
+	return strings.Join(quoted, ",")
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOffsetPattern = regexp.MustCompile(`\.offset\((\d+)\)\.limit\((\d+)\)$`)
+
+func TestAqlPager(t *testing.T) {
+	items := []servicesUtils.ResultItem{
+		{Repo: "generic-local", Path: "a", Name: "1.bin"},
+		{Repo: "generic-local", Path: "a", Name: "2.bin"},
+		{Repo: "generic-local", Path: "b", Name: "1.bin"},
+		{Repo: "generic-local", Path: "b", Name: "2.bin"},
+		{Repo: "generic-local", Path: "c", Name: "1.bin"},
+	}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/artifactory/api/system/version" {
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		query := string(body)
+		queries = append(queries, query)
+		match := testOffsetPattern.FindStringSubmatch(query)
+		require.NotNil(t, match, query)
+		offset, _ := strconv.Atoi(match[1])
+		limit, _ := strconv.Atoi(match[2])
+		page := items[min(offset, len(items)):min(offset+limit, len(items))]
+		content, err := json.Marshal(map[string]any{"results": page, "range": map[string]int{"start_pos": offset, "end_pos": offset + len(page), "total": len(page)}})
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+	servicesManager, err := artifactoryUtils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+
+	results, err := NewAqlPager(servicesManager, `"repo":"generic-local","type":"file"`).SetInclude("name", "sha256").SetPageSize(2).All()
+	require.NoError(t, err)
+	assert.Equal(t, items, results)
+	assert.Equal(t, []string{
+		`items.find({"repo":"generic-local","type":"file"}).include("repo","path","name","sha256").sort({"$asc":["repo","path","name"]}).offset(0).limit(2)`,
+		`items.find({"repo":"generic-local","type":"file"}).include("repo","path","name","sha256").sort({"$asc":["repo","path","name"]}).offset(2).limit(2)`,
+		`items.find({"repo":"generic-local","type":"file"}).include("repo","path","name","sha256").sort({"$asc":["repo","path","name"]}).offset(4).limit(2)`,
+	}, queries)
+}
+
+func TestAqlPagerSearchAfterQuery(t *testing.T) {
+	pager := NewAqlPager(nil, `"repo":"generic-local"`).SetSearchAfter(true).SetPageSize(2)
+	assert.Equal(t, `items.find({"repo":"generic-local"}).include("repo","path","name").sort({"$asc":["repo","path","name"]}).offset(0).limit(2)`, pager.createPageQuery())
+	// The next pages start after the last item of the previous page, rather than at an offset.
+	pager.offset, pager.lastItem = 2, &servicesUtils.ResultItem{Repo: "generic-local", Path: "a", Name: `"2".bin`}
+	query := pager.createPageQuery()
+	assert.True(t, strings.HasSuffix(query, ".offset(0).limit(2)"), query)
+	assert.Contains(t, query, `{"$and":[{"repo":"generic-local"},{"$or":[{"repo":{"$gt":"generic-local"}},`+
+		`{"$and":[{"repo":"generic-local"},{"path":{"$gt":"a"}}]},{"$and":[{"repo":"generic-local"},{"path":"a"},{"name":{"$gt":"\"2\".bin"}}]}]}]}`)
+}
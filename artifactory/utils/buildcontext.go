@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildStoppedProp is the build-info property of the reason a build was stopped before it completed.
+const BuildStoppedProp = "buildInfo.stopped"
+
+// The time the processes of a stopped build have to exit, before they are killed.
+var stopGracePeriod = 10 * time.Second
+
+// NewBuildContext returns the context of a build, which is canceled with the parent context, or after the timeout, if positive.
+func NewBuildContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeoutCause(parent, timeout, fmt.Errorf("timed out after %s: %w", timeout, context.DeadlineExceeded))
+}
+
+// RunWithContext runs a build, whose processes are forked by the run function, such as the build-info extractors which fork mvn
+// and gradle. When the context is done first, the processes forked by the CLI are terminated, and killed if they don't exit
+// within the grace period. A Gradle daemon isn't forked by the CLI, and cancels the build when its client is terminated.
+func RunWithContext(ctx context.Context, run func() error) error {
+	if ctx == nil {
+		return run()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- run()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	stopErr := errorutils.CheckError(fmt.Errorf("the build was stopped: %w", context.Cause(ctx)))
+	log.Warn(stopErr.Error())
+	stopProcessTree(false)
+	select {
+	case <-done:
+		return stopErr
+	case <-time.After(stopGracePeriod):
+	}
+	log.Warn("The processes of the build didn't exit after", stopGracePeriod.String()+". Killing them.")
+	stopProcessTree(true)
+	select {
+	case <-done:
+	case <-time.After(stopGracePeriod):
+		log.Warn("The build didn't complete after its processes were killed.")
+	}
+	return stopErr
+}
+
+// SaveStoppedBuildInfo adds the reason the build was stopped to the build-info collected until it stopped, so that the build-info
+// can still be published.
+func SaveStoppedBuildInfo(configuration *build.BuildConfiguration, stopErr error) error {
+	if configuration == nil {
+		return nil
+	}
+	isCollect, err := configuration.IsCollectBuildInfo()
+	if err != nil || !isCollect {
+		return err
+	}
+	buildName, err := configuration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := configuration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	stoppedBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, configuration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(stoppedBuild.SavePartialBuildInfo(&buildinfo.Partial{Env: buildinfo.Env{BuildStoppedProp: stopErr.Error()}}))
+}
+
+// stopProcessTree terminates the processes forked by the CLI and their descendants, or kills them if force is set.
+// Windows processes can only be killed.
+func stopProcessTree(force bool) {
+	pids, err := listDescendantProcesses(os.Getpid())
+	if err != nil {
+		log.Warn("Failed to list the processes of the build:", err.Error())
+		return
+	}
+	for _, pid := range pids {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+			continue
+		}
+		if force || coreutils.IsWindows() {
+			err = process.Kill()
+		} else {
+			err = process.Signal(syscall.SIGTERM)
+		}
+		if err != nil {
+			log.Debug(fmt.Sprintf("Failed to stop the process %d: %s", pid, err.Error()))
+		}
+	}
+}
+
+// listDescendantProcesses returns the descendants of the process, parents first.
+func listDescendantProcesses(pid int) ([]int, error) {
+	var cmd *exec.Cmd
+	if coreutils.IsWindows() {
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", `Get-CimInstance Win32_Process | ForEach-Object { "$($_.ProcessId) $($_.ParentProcessId)" }`)
+	} else {
+		cmd = exec.Command("ps", "-A", "-o", "pid=", "-o", "ppid=")
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	descendants := descendantProcesses(parseProcessTable(string(output)), pid)
+	// The listing process already exited.
+	for i, descendant := range descendants {
+		if descendant == cmd.Process.Pid {
+			descendants = append(descendants[:i], descendants[i+1:]...)
+			break
+		}
+	}
+	return descendants, nil
+}
+
+// parseProcessTable parses lines of process and parent process IDs to the children of each process.
+func parseProcessTable(table string) map[int][]int {
+	children := make(map[int][]int)
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		pid, pidErr := strconv.Atoi(fields[0])
+		ppid, ppidErr := strconv.Atoi(fields[1])
+		if pidErr != nil || ppidErr != nil || pid == ppid {
+			continue
+		}
+		children[ppid] = append(children[ppid], pid)
+	}
+	return children
+}
+
+func descendantProcesses(children map[int][]int, pid int) (descendants []int) {
+	visited := map[int]bool{pid: true}
+	queue := []int{pid}
+	for len(queue) > 0 {
+		for _, child := range children[queue[0]] {
+			if !visited[child] {
+				visited[child] = true
+				descendants = append(descendants, child)
+				queue = append(queue, child)
+			}
+		}
+		queue = queue[1:]
+	}
+	return
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescendantProcesses(t *testing.T) {
+	children := parseProcessTable(`    1     0
+  100     1
+  200   100
+  201   100
+  300   200
+  400     1
+ garbage
+`)
+	assert.Equal(t, []int{200, 201, 300}, descendantProcesses(children, 100))
+	assert.Empty(t, descendantProcesses(children, 300))
+}
+
+func TestRunWithContext(t *testing.T) {
+	assert.EqualError(t, RunWithContext(context.Background(), func() error { return errors.New("build failed") }), "build failed")
+
+	if coreutils.IsWindows() {
+		t.Skip("The test forks a Unix sleep process.")
+	}
+	ctx, cancel := NewBuildContext(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := RunWithContext(ctx, exec.Command("sleep", "30").Run)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "the build was stopped: timed out after 100ms")
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
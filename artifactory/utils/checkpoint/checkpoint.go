@@ -0,0 +1,129 @@
+package checkpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const checkpointsDirName = "checkpoints"
+
+// Checkpoint describes the progress of a long-running operation, so that an interrupted run can be resumed.
+// The items are identifiers chosen by the operation, such as the target paths of uploaded files.
+type Checkpoint struct {
+	Command   string   `json:"command"`
+	UpdatedAt string   `json:"updatedAt"`
+	Completed []string `json:"completed"`
+	InFlight  []string `json:"inFlight"`
+	Pending   []string `json:"pending"`
+
+	path      string
+	mutex     sync.Mutex
+	completed map[string]bool
+}
+
+// Load returns the checkpoint of the command with the given identity, or an empty checkpoint if none was saved.
+// The identity should include everything that affects the items of the operation, such as its spec and server URL,
+// so that a checkpoint is only used by runs of the same operation.
+func Load(command string, identity []byte) (*Checkpoint, error) {
+	dir, err := getCheckpointsDir()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(append([]byte(command+"\n"), identity...))
+	checkpoint := &Checkpoint{
+		Command:   command,
+		path:      filepath.Join(dir, command+"-"+hex.EncodeToString(hash[:8])+".json"),
+		completed: make(map[string]bool),
+	}
+	content, err := os.ReadFile(checkpoint.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return checkpoint, nil
+		}
+		return nil, errorutils.CheckErrorf("failed to read the checkpoint file %s: %s", checkpoint.path, err.Error())
+	}
+	if err = json.Unmarshal(content, checkpoint); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the checkpoint file %s: %s", checkpoint.path, err.Error())
+	}
+	for _, item := range checkpoint.Completed {
+		checkpoint.completed[item] = true
+	}
+	return checkpoint, nil
+}
+
+func getCheckpointsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(homeDir, checkpointsDirName)
+	return dir, errorutils.CheckError(os.MkdirAll(dir, 0700))
+}
+
+func (c *Checkpoint) Path() string {
+	return c.path
+}
+
+// IsEmpty returns true if no progress was recorded by a previous run.
+func (c *Checkpoint) IsEmpty() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.Completed) == 0 && len(c.InFlight) == 0 && len(c.Pending) == 0
+}
+
+func (c *Checkpoint) IsCompleted(item string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.completed[item]
+}
+
+// Update adds the completed items, and replaces the in-flight and pending items.
+func (c *Checkpoint) Update(completed, inFlight, pending []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, item := range completed {
+		if !c.completed[item] {
+			c.completed[item] = true
+			c.Completed = append(c.Completed, item)
+		}
+	}
+	c.InFlight = inFlight
+	c.Pending = pending
+}
+
+// Save writes the checkpoint to its file.
+func (c *Checkpoint) Save() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	content, err := json.Marshal(c)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Write to a temporary file first, so that an interruption while saving doesn't corrupt the previous checkpoint.
+	tempPath := c.path + ".tmp"
+	if err = os.WriteFile(tempPath, content, 0600); err != nil {
+		return errorutils.CheckErrorf("failed to write the checkpoint file %s: %s", c.path, err.Error())
+	}
+	return errorutils.CheckError(os.Rename(tempPath, c.path))
+}
+
+// Remove deletes the checkpoint file, once the operation completed successfully.
+func (c *Checkpoint) Remove() error {
+	exists, err := fileutils.IsFileExists(c.path, false)
+	if err != nil || !exists {
+		return err
+	}
+	log.Debug("Removing the checkpoint file", c.path)
+	return errorutils.CheckError(os.Remove(c.path))
+}
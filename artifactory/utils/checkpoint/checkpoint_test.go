@@ -0,0 +1,64 @@
+package checkpoint
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointSaveAndLoad(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	checkpoint, err := Load("rt_upload", []byte("spec"))
+	require.NoError(t, err)
+	assert.True(t, checkpoint.IsEmpty())
+
+	checkpoint.Update([]string{"repo/a"}, []string{"repo/b"}, []string{"repo/c"})
+	checkpoint.Update([]string{"repo/a", "repo/b"}, nil, []string{"repo/c"})
+	require.NoError(t, checkpoint.Save())
+
+	loaded, err := Load("rt_upload", []byte("spec"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo/a", "repo/b"}, loaded.Completed)
+	assert.Empty(t, loaded.InFlight)
+	assert.Equal(t, []string{"repo/c"}, loaded.Pending)
+	assert.True(t, loaded.IsCompleted("repo/b"))
+	assert.False(t, loaded.IsCompleted("repo/c"))
+
+	// A different identity doesn't use the checkpoint.
+	other, err := Load("rt_upload", []byte("other spec"))
+	require.NoError(t, err)
+	assert.True(t, other.IsEmpty())
+
+	require.NoError(t, loaded.Remove())
+	assert.NoFileExists(t, loaded.Path())
+	assert.NoError(t, loaded.Remove())
+}
+
+func TestOnInterrupt(t *testing.T) {
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	exitCodes := make(chan int, 1)
+	exit = func(code int) { exitCodes <- code }
+	defer func() { exit = os.Exit }()
+
+	flushed := false
+	stop := OnInterrupt(func() error {
+		flushed = true
+		return nil
+	})
+	defer stop()
+	if err = process.Signal(os.Interrupt); err != nil {
+		t.Skip("Sending signals isn't supported on this platform:", err.Error())
+	}
+	select {
+	case code := <-exitCodes:
+		assert.Equal(t, ExitCodeInterrupted, code)
+		assert.True(t, flushed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("The interrupt wasn't handled")
+	}
+}
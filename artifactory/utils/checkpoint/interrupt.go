@@ -0,0 +1,42 @@
+package checkpoint
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ExitCodeInterrupted is the exit code of commands stopped by SIGINT or SIGTERM (128 + SIGINT).
+const ExitCodeInterrupted = 130
+
+// Allows replacing the exit function in tests.
+var exit = os.Exit
+
+// OnInterrupt calls flush when SIGINT or SIGTERM is received, and exits with ExitCodeInterrupted.
+// The returned function stops handling the signals, and must be called once the operation is done.
+func OnInterrupt(flush func() error) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Warn("Received", sig.String()+". Saving the progress before exiting...")
+			if err := flush(); err != nil {
+				log.Error("Failed to save the progress:", err.Error())
+			}
+			exit(ExitCodeInterrupted)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+)
+
+// The number of artifacts whose checksums are fetched by a single AQL query.
+const artifactsSha256AqlBatchSize = 500
+
+// GetArtifactsSha256 returns the sha256 checksums of the artifacts found in Artifactory, by their paths.
+// The paths are in the format of <repository name>/<repository path>. Artifacts which weren't found are missing from the result.
+func GetArtifactsSha256(servicesManager artifactory.ArtifactoryServicesManager, paths []string) (map[string]string, error) {
+	checksums := make(map[string]string, len(paths))
+	for start := 0; start < len(paths); start += artifactsSha256AqlBatchSize {
+		end := min(start+artifactsSha256AqlBatchSize, len(paths))
+		results, err := ExecuteAqlQuery(servicesManager, createArtifactsSha256Aql(paths[start:end]))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range results {
+			checksums[item.GetItemRelativePath()] = item.Sha256
+		}
+	}
+	return checksums, nil
+}
+
+func createArtifactsSha256Aql(paths []string) string {
+	criteria := make([]string, 0, len(paths))
+	for _, artifactPath := range paths {
+		repo, relativePath, _ := strings.Cut(strings.TrimPrefix(artifactPath, "/"), "/")
+		dir, name := path.Split(relativePath)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" {
+			dir = "."
+		}
+		criteria = append(criteria, fmt.Sprintf(`{"$and":[{"repo":%s},{"path":%s},{"name":%s}]}`, quoteAqlValue(repo), quoteAqlValue(dir), quoteAqlValue(name)))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include("repo","path","name","sha256")`, strings.Join(criteria, ","))
+}
+
+func quoteAqlValue(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
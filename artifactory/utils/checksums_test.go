@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateArtifactsSha256Aql(t *testing.T) {
+	assert.Equal(t,
+		`items.find({"$or":[{"$and":[{"repo":"generic-local"},{"path":"app/v1"},{"name":"app.tgz"}]},{"$and":[{"repo":"generic-local"},{"path":"."},{"name":"readme \"1\".md"}]}]}).include("repo","path","name","sha256")`,
+		createArtifactsSha256Aql([]string{"generic-local/app/v1/app.tgz", `/generic-local/readme "1".md`}))
+}
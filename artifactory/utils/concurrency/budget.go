@@ -0,0 +1,202 @@
+// Package concurrency shares a budget of threads between the CLI processes which run simultaneously on the machine,
+// such as the jobs of a matrix build on the same agent, so that together they don't send more concurrent requests
+// to a server than it accepts.
+package concurrency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/osutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// BudgetEnv sets the total number of threads the CLI processes of the machine use toward the same server.
+	// If not set, each process uses the number of threads it's configured with.
+	BudgetEnv = "JFROG_CLI_CONCURRENCY_BUDGET"
+	// BudgetTimeoutEnv sets the number of seconds a process waits for the other processes to release threads of the budget,
+	// before it fails.
+	BudgetTimeoutEnv = "JFROG_CLI_CONCURRENCY_BUDGET_TIMEOUT"
+
+	budgetsDirName = "concurrency"
+	leasesFileName = "leases.json"
+	defaultTimeout = 30 * time.Minute
+)
+
+// The interval between the attempts to acquire threads, while the budget is used by the other processes.
+var retryInterval = 500 * time.Millisecond
+
+// lease is the number of threads a process acquired. The start time of the process tells it apart from a later process
+// which reuses its process ID.
+type lease struct {
+	Pid       int   `json:"pid"`
+	StartTime int64 `json:"startTime,omitempty"`
+	Threads   int   `json:"threads"`
+}
+
+// Acquire acquires up to the requested number of threads toward the server from the budget of the machine, and returns the number
+// of threads to use. At least one thread is acquired, so Acquire waits while the other processes use the whole budget, up to the
+// timeout of BudgetTimeoutEnv. The threads are returned to the budget by release. The threads of processes which exited without
+// releasing them are reclaimed.
+func Acquire(serverUrl string, threads int) (acquired int, release func() error, err error) {
+	release = func() error { return nil }
+	budget, err := getBudget()
+	if err != nil || budget <= 0 {
+		return threads, release, err
+	}
+	timeout, err := getTimeout()
+	if err != nil {
+		return
+	}
+	threads = max(1, min(threads, budget))
+	dir, err := getBudgetDir(serverUrl)
+	if err != nil {
+		return
+	}
+	currentLease := lease{Pid: os.Getpid()}
+	if startTime, startTimeErr := processStartTime(currentLease.Pid); startTimeErr == nil {
+		currentLease.StartTime = startTime
+	} else {
+		log.Debug("Failed to read the start time of the process, its lease is identified by its process ID only:", startTimeErr.Error())
+	}
+	leaseId := strconv.Itoa(currentLease.Pid) + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	deadline := time.Now().Add(timeout)
+	waiting := false
+	for {
+		if acquired, err = tryAcquire(dir, leaseId, currentLease, threads, budget); err != nil || acquired > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			return 0, release, errorutils.CheckErrorf("the other JFrog CLI processes didn't release the threads of the %s=%d budget within %s. "+
+				"The timeout can be set by the %s environment variable", BudgetEnv, budget, timeout, BudgetTimeoutEnv)
+		}
+		if !waiting {
+			log.Info(fmt.Sprintf("Waiting for the other JFrog CLI processes to release the threads of the %s=%d budget...", BudgetEnv, budget))
+			waiting = true
+		}
+		time.Sleep(retryInterval)
+	}
+	if err != nil {
+		return
+	}
+	if acquired < threads {
+		log.Debug(fmt.Sprintf("Using %d of the %d requested threads, due to the %s budget.", acquired, threads, BudgetEnv))
+	}
+	release = func() error { return releaseLease(dir, leaseId) }
+	return
+}
+
+func getBudget() (int, error) {
+	value := strings.TrimSpace(os.Getenv(BudgetEnv))
+	if value == "" {
+		return 0, nil
+	}
+	budget, err := strconv.Atoi(value)
+	if err != nil || budget < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative number, got '%s'", BudgetEnv, value)
+	}
+	return budget, nil
+}
+
+func getTimeout() (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv(BudgetTimeoutEnv))
+	if value == "" {
+		return defaultTimeout, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative number of seconds, got '%s'", BudgetTimeoutEnv, value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// getBudgetDir returns the directory of the budget of the server, which is shared by the processes of the machine's user.
+func getBudgetDir(serverUrl string) (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(strings.TrimSuffix(serverUrl, "/")))
+	dir := filepath.Join(homeDir, budgetsDirName, hex.EncodeToString(hash[:8]))
+	return dir, errorutils.CheckError(os.MkdirAll(dir, 0700))
+}
+
+// tryAcquire adds the lease of the available threads, up to the requested number, and returns their number.
+func tryAcquire(dir, leaseId string, newLease lease, threads, budget int) (acquired int, err error) {
+	err = updateLeases(dir, func(leases map[string]lease) {
+		used := 0
+		for _, otherLease := range leases {
+			used += otherLease.Threads
+		}
+		if acquired = min(threads, budget-used); acquired > 0 {
+			newLease.Threads = acquired
+			leases[leaseId] = newLease
+		}
+	})
+	return
+}
+
+func releaseLease(dir, leaseId string) error {
+	return updateLeases(dir, func(leases map[string]lease) {
+		delete(leases, leaseId)
+	})
+}
+
+// updateLeases updates the leases file of the budget while holding its lock. The leases of processes which aren't running anymore are removed.
+// A process is considered running only if its process ID is running, with the start time of the lease.
+func updateLeases(dir string, update func(leases map[string]lease)) (err error) {
+	unlock, err := lock.CreateLock(filepath.Join(dir, "lock"))
+	defer func() {
+		if unlockErr := unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+	if err != nil {
+		return
+	}
+	leasesPath := filepath.Join(dir, leasesFileName)
+	leases := map[string]lease{}
+	content, err := os.ReadFile(leasesPath)
+	switch {
+	case err == nil:
+		if json.Unmarshal(content, &leases) != nil {
+			log.Debug("Ignoring the corrupted leases file", leasesPath)
+			leases = map[string]lease{}
+		}
+	case !os.IsNotExist(err):
+		return errorutils.CheckError(err)
+	}
+	for leaseId, otherLease := range leases {
+		if !isLeaseProcessRunning(otherLease) {
+			delete(leases, leaseId)
+		}
+	}
+	update(leases)
+	if content, err = json.Marshal(leases); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(leasesPath, content, 0600))
+}
+
+func isLeaseProcessRunning(otherLease lease) bool {
+	if running, err := osutils.IsProcessRunning(otherLease.Pid); err == nil && !running {
+		return false
+	}
+	if otherLease.StartTime == 0 {
+		return true
+	}
+	// The process ID is of a later process, if the process started at another time.
+	startTime, err := processStartTime(otherLease.Pid)
+	return err != nil || startTime == otherLease.StartTime
+}
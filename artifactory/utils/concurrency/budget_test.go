@@ -0,0 +1,108 @@
+package concurrency
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServerUrl = "https://acme.jfrog.io/artifactory/"
+
+func setBudget(t *testing.T, budget string) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv(BudgetEnv, budget)
+	previousInterval := retryInterval
+	retryInterval = 10 * time.Millisecond
+	t.Cleanup(func() { retryInterval = previousInterval })
+}
+
+func TestAcquireWithoutBudget(t *testing.T) {
+	setBudget(t, "")
+	acquired, release, err := Acquire(testServerUrl, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 8, acquired)
+	assert.NoError(t, release())
+}
+
+func TestAcquireSharesBudget(t *testing.T) {
+	setBudget(t, "4")
+	first, releaseFirst, err := Acquire(testServerUrl, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, first)
+	// Only the rest of the budget is acquired.
+	second, releaseSecond, err := Acquire(testServerUrl, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, second)
+	// The budget of another server is separate.
+	other, releaseOther, err := Acquire("https://other.jfrog.io/artifactory/", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, other)
+	assert.NoError(t, releaseOther())
+
+	// While the budget is used, Acquire waits for a release.
+	acquiredChan := make(chan int)
+	go func() {
+		third, releaseThird, err := Acquire(testServerUrl, 8)
+		assert.NoError(t, err)
+		assert.NoError(t, releaseThird())
+		acquiredChan <- third
+	}()
+	select {
+	case <-acquiredChan:
+		assert.Fail(t, "threads were acquired beyond the budget")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, releaseFirst())
+	assert.Equal(t, 3, <-acquiredChan)
+	assert.NoError(t, releaseSecond())
+}
+
+func TestAcquireReclaimsLeasesOfExitedProcesses(t *testing.T) {
+	setBudget(t, "2")
+	dir, err := getBudgetDir(testServerUrl)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, leasesFileName), []byte(`{"exited": {"pid": 2147483646, "threads": 2}}`), 0600))
+	acquired, release, err := Acquire(testServerUrl, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, acquired)
+	assert.NoError(t, release())
+}
+
+func TestAcquireReclaimsLeasesOfReusedProcessIds(t *testing.T) {
+	setBudget(t, "2")
+	startTime, err := processStartTime(os.Getpid())
+	require.NoError(t, err)
+	dir, err := getBudgetDir(testServerUrl)
+	require.NoError(t, err)
+	// The lease of an exited process, whose process ID is reused by the current process.
+	leases := fmt.Sprintf(`{"exited": {"pid": %d, "startTime": %d, "threads": 2}}`, os.Getpid(), startTime-1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, leasesFileName), []byte(leases), 0600))
+	acquired, release, err := Acquire(testServerUrl, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, acquired)
+	assert.NoError(t, release())
+}
+
+func TestAcquireTimeout(t *testing.T) {
+	setBudget(t, "1")
+	t.Setenv(BudgetTimeoutEnv, "0")
+	_, release, err := Acquire(testServerUrl, 1)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, release())
+	}()
+	_, _, err = Acquire(testServerUrl, 1)
+	assert.ErrorContains(t, err, BudgetTimeoutEnv)
+}
+
+func TestAcquireInvalidBudget(t *testing.T) {
+	setBudget(t, "many")
+	_, _, err := Acquire(testServerUrl, 3)
+	assert.ErrorContains(t, err, BudgetEnv)
+}
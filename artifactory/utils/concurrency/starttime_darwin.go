@@ -0,0 +1,15 @@
+package concurrency
+
+import (
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/sys/unix"
+)
+
+// processStartTime returns the time the process started at, in nanoseconds since the epoch.
+func processStartTime(pid int) (int64, error) {
+	process, err := unix.SysctlKinfoProc("kern.proc.pid", pid)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	return process.Proc.P_starttime.Nano(), nil
+}
@@ -0,0 +1,25 @@
+package concurrency
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// processStartTime returns the time the process started at, in clock ticks since the boot, from the /proc/<pid>/stat file.
+func processStartTime(pid int) (int64, error) {
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	// The command name in the second field may contain spaces, so the fields are counted from its closing parenthesis.
+	// The start time is the 22nd field, which is the 20th after the command name.
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	if len(fields) < 20 {
+		return 0, errorutils.CheckErrorf("unexpected format of the stat file of the process %d", pid)
+	}
+	startTime, err := strconv.ParseInt(fields[19], 10, 64)
+	return startTime, errorutils.CheckError(err)
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package concurrency
+
+// processStartTime returns 0, since the start time of the processes isn't read on this platform. The leases of the
+// processes are then reclaimed only once their process IDs aren't running.
+func processStartTime(int) (int64, error) {
+	return 0, nil
+}
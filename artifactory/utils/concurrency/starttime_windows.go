@@ -0,0 +1,24 @@
+package concurrency
+
+import (
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/sys/windows"
+)
+
+// processStartTime returns the time the process started at, in nanoseconds since the epoch.
+func processStartTime(pid int) (startTime int64, err error) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := windows.CloseHandle(process); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	var creationTime, exitTime, kernelTime, userTime windows.Filetime
+	if err = windows.GetProcessTimes(process, &creationTime, &exitTime, &kernelTime, &userTime); err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	return creationTime.Nanoseconds(), nil
+}
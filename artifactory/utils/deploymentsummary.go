@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DeploymentSummary is the detailed summary of the build's artifacts, printed when the JSON format is requested.
+type DeploymentSummary struct {
+	summary.Summary
+	Files []DeploymentSummaryFile `json:"files"`
+}
+
+// The categories of the artifacts in the detailed summary.
+const (
+	ArtifactCategory = "artifact"
+	PomCategory      = "pom"
+	// Gradle Module Metadata (.module) files, which describe the variants of the components for the variant-aware resolution of Gradle.
+	GradleModuleMetadataCategory = "gradle-module-metadata"
+)
+
+type DeploymentSummaryFile struct {
+	// The local path of the artifact.
+	Path string `json:"path"`
+	// The target path of the artifact, in the format of <repository>/<path>.
+	Target   string `json:"target"`
+	Category string `json:"category"`
+	Sha1     string `json:"sha1"`
+	Sha256   string `json:"sha256"`
+	Md5      string `json:"md5"`
+	Size     int64  `json:"size"`
+	Deployed bool   `json:"deployed"`
+	// True if the artifact was excluded from the deployment by a filter of the command.
+	Filtered bool `json:"filtered,omitempty"`
+}
+
+// CreateDeploymentSummary reads the deployable artifacts file written by the Maven or Gradle extractor. It must be read before it's converted
+// to file transfer details, which only include the deployed artifacts. The checksums which aren't listed by the file are calculated locally.
+// getDefaultRepo returns the deployment repository of the project configuration, used if the extractor didn't list the target repository.
+func CreateDeploymentSummary(deployableArtifactsFile string, getDefaultRepo func(artifact clientutils.DeployableArtifactDetails) string) (*DeploymentSummary, error) {
+	modules, err := ReadDeployableArtifacts(deployableArtifactsFile)
+	if err != nil {
+		return nil, err
+	}
+	files := []DeploymentSummaryFile{}
+	succeeded, failed := 0, 0
+	for _, moduleName := range SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			files = append(files, createDeploymentSummaryFile(artifact, getDefaultRepo))
+			if artifact.DeploySucceeded {
+				succeeded++
+			} else {
+				failed++
+			}
+		}
+	}
+	return &DeploymentSummary{Summary: *summary.GetSummaryReport(succeeded, failed, false, nil), Files: files}, nil
+}
+
+// AddFiltered adds the artifacts of the modules, which were excluded from the deployment, to the summary as filtered.
+// The filtered artifacts aren't counted as failures.
+func (ds *DeploymentSummary) AddFiltered(modules map[string][]clientutils.DeployableArtifactDetails, getDefaultRepo func(artifact clientutils.DeployableArtifactDetails) string) {
+	for _, moduleName := range SortedModuleNames(modules) {
+		for _, artifact := range modules[moduleName] {
+			file := createDeploymentSummaryFile(artifact, getDefaultRepo)
+			file.Filtered = true
+			ds.Files = append(ds.Files, file)
+		}
+	}
+}
+
+func createDeploymentSummaryFile(artifact clientutils.DeployableArtifactDetails, getDefaultRepo func(artifact clientutils.DeployableArtifactDetails) string) DeploymentSummaryFile {
+	repo := artifact.TargetRepository
+	if repo == "" {
+		repo = getDefaultRepo(artifact)
+	}
+	file := DeploymentSummaryFile{Path: artifact.SourcePath, Target: path.Join(repo, artifact.ArtifactDest), Category: GetDeploymentCategory(artifact.ArtifactDest),
+		Sha256: artifact.Sha256, Deployed: artifact.DeploySucceeded}
+	info, err := os.Stat(artifact.SourcePath)
+	if err != nil {
+		log.Debug("Couldn't read the artifact", artifact.SourcePath+":", err.Error())
+		return file
+	}
+	file.Size = info.Size()
+	checksums, err := crypto.GetFileChecksums(artifact.SourcePath, crypto.SHA1, crypto.SHA256, crypto.MD5)
+	if err != nil {
+		log.Debug("Couldn't calculate the checksums of", artifact.SourcePath+":", err.Error())
+		return file
+	}
+	file.Sha1, file.Sha256, file.Md5 = checksums[crypto.SHA1], checksums[crypto.SHA256], checksums[crypto.MD5]
+	return file
+}
+
+// GetDeploymentCategory returns the category of the artifact, by the extension of its path in the repository.
+func GetDeploymentCategory(artifactPath string) string {
+	switch {
+	case strings.HasSuffix(artifactPath, ".pom"):
+		return PomCategory
+	case strings.HasSuffix(artifactPath, ".module"):
+		return GradleModuleMetadataCategory
+	default:
+		return ArtifactCategory
+	}
+}
+
+// ReadDeployableArtifacts reads the artifacts of each module from the deployable artifacts file written by the Maven or Gradle extractor.
+func ReadDeployableArtifacts(deployableArtifactsFile string) (map[string][]clientutils.DeployableArtifactDetails, error) {
+	fileContent, err := os.ReadFile(deployableArtifactsFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var modules map[string][]clientutils.DeployableArtifactDetails
+	if len(fileContent) > 0 {
+		if err = json.Unmarshal(fileContent, &modules); err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the deployable artifacts file %s: %s", deployableArtifactsFile, err.Error())
+		}
+	}
+	return modules, nil
+}
+
+func SortedModuleNames(modules map[string][]clientutils.DeployableArtifactDetails) []string {
+	moduleNames := make([]string, 0, len(modules))
+	for moduleName := range modules {
+		moduleNames = append(moduleNames, moduleName)
+	}
+	sort.Strings(moduleNames)
+	return moduleNames
+}
+
+// MarkDeployed marks the artifacts which were uploaded as deployed.
+func MarkDeployed(modules map[string][]clientutils.DeployableArtifactDetails, transferDetailsReader *content.ContentReader) error {
+	uploaded := make(map[string]bool)
+	for transferDetails := new(clientutils.FileTransferDetails); transferDetailsReader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		uploaded[filepath.Clean(transferDetails.SourcePath)] = true
+	}
+	if err := transferDetailsReader.GetError(); err != nil {
+		return err
+	}
+	transferDetailsReader.Reset()
+	for _, artifacts := range modules {
+		for i := range artifacts {
+			if uploaded[filepath.Clean(artifacts[i].SourcePath)] {
+				artifacts[i].DeploySucceeded = true
+			}
+		}
+	}
+	return nil
+}
+
+// WriteDeployableArtifacts writes the artifacts of each module to a deployable artifacts file, in the format of the extractors.
+func WriteDeployableArtifacts(deployableArtifactsFile string, modules map[string][]clientutils.DeployableArtifactDetails) error {
+	data, err := json.Marshal(modules)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(deployableArtifactsFile, data, 0o644))
+}
@@ -0,0 +1,308 @@
+// Package dualstack opens the connections to the servers on dual-stack networks, where the hosts resolve to both IPv6 and IPv4
+// addresses. The addresses of both families are resolved in parallel, and connected to in a race staggered by a short delay
+// (happy eyeballs, RFC 8305), so that a broken IPv6 route of a CI environment costs that delay rather than a connect timeout.
+// The family which connected to a host is preferred by the next connections of the process to that host.
+//
+// The dialer is used by the upstream transport of the local server proxies, and by the local forward proxy which the commands
+// start when FamilyEnv or AttemptDelayEnv is set (see the serverproxy package). The forward proxy is set as the proxy of the
+// environment, so that the service managers, whose transports can't be replaced, and the package managers which read the proxy
+// environment variables, connect through it. The connections aren't opened by the dialer if the environment sets a proxy already,
+// or through an SSH tunnel.
+//
+// That fallback isn't enough on a broken IPv6 route: net.Dialer waits for the lookup of both families before connecting, so a
+// slow AAAA lookup delays every connection, it races only the first address of each family, and it doesn't remember the family
+// which connected. The dialer therefore runs its own race over connections of single addresses, and turns off the fallback of
+// the net.Dialer it connects with, which would otherwise start a race of its own.
+package dualstack
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// FamilyEnv sets the address family of the connections to the servers: auto, ipv4 or ipv6. The family of specific server
+	// profiles is set by <server-id>=<family> entries, separated by commas, such as "ipv4,prod=auto".
+	FamilyEnv = "JFROG_CLI_IP_FAMILY"
+	// AttemptDelayEnv sets the delay in milliseconds before the connection to the next address of a host is attempted, while the
+	// previous attempts are still pending.
+	AttemptDelayEnv = "JFROG_CLI_CONNECTION_ATTEMPT_DELAY"
+
+	defaultAttemptDelay = 250 * time.Millisecond
+	// The time the lookup of an address family waits for the other one, once it resolved.
+	resolutionDelay = 50 * time.Millisecond
+	connectTimeout  = 30 * time.Second
+	keepAlive       = 30 * time.Second
+)
+
+type Family string
+
+const (
+	Auto Family = "auto"
+	IPv4 Family = "ipv4"
+	IPv6 Family = "ipv6"
+)
+
+// The families which the last connections to the hosts succeeded with. They're shared by all the connections of the process.
+var preferredFamilies sync.Map
+
+// Dialer opens the connections to the hosts of a server profile, to the addresses of its family.
+type Dialer struct {
+	family       Family
+	attemptDelay time.Duration
+	lookupIP     func(ctx context.Context, network, host string) ([]net.IP, error)
+	dial         func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewDialer returns the dialer of the server profile, with the family set for it by the environment.
+func NewDialer(serverId string) (*Dialer, error) {
+	family, err := GetFamily(serverId)
+	if err != nil {
+		return nil, err
+	}
+	attemptDelay, err := getAttemptDelay()
+	if err != nil {
+		return nil, err
+	}
+	// The race is run by DialContext, which connects to a single address at a time.
+	dialer := &net.Dialer{Timeout: connectTimeout, KeepAlive: keepAlive, FallbackDelay: -1}
+	return &Dialer{family: family, attemptDelay: attemptDelay, lookupIP: net.DefaultResolver.LookupIP, dial: dialer.DialContext}, nil
+}
+
+// GetFamily returns the address family of the connections to the servers of the server profile.
+func GetFamily(serverId string) (Family, error) {
+	family, serverFamily := Auto, Family("")
+	for _, entry := range strings.Split(os.Getenv(FamilyEnv), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		entryServerId, value, found := strings.Cut(entry, "=")
+		if !found {
+			entryServerId, value = "", entry
+		}
+		entryFamily := Family(strings.ToLower(strings.TrimSpace(value)))
+		if entryFamily != Auto && entryFamily != IPv4 && entryFamily != IPv6 {
+			return "", errorutils.CheckErrorf("the address family of %s must be one of auto, ipv4 or ipv6, got '%s'", FamilyEnv, value)
+		}
+		switch strings.TrimSpace(entryServerId) {
+		case "":
+			family = entryFamily
+		case serverId:
+			serverFamily = entryFamily
+		}
+	}
+	// The family of the server profile takes precedence over the default one.
+	if serverFamily != "" {
+		return serverFamily, nil
+	}
+	return family, nil
+}
+
+func getAttemptDelay() (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv(AttemptDelayEnv))
+	if value == "" {
+		return defaultAttemptDelay, nil
+	}
+	milliseconds, err := strconv.Atoi(value)
+	if err != nil || milliseconds <= 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a positive number of milliseconds, got '%s'", AttemptDelayEnv, value)
+	}
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+// DialContext connects to the address, which is a host:port. The addresses of the host are attempted in the order of their families,
+// starting by the preferred one, and the first connection which succeeds is returned.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	family := d.family
+	switch network {
+	case "tcp4":
+		family = IPv4
+	case "tcp6":
+		family = IPv6
+	case "tcp":
+	default:
+		return d.dial(ctx, network, address)
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if net.ParseIP(host) != nil {
+		return d.dial(ctx, network, address)
+	}
+	ips, err := d.resolve(ctx, host, family)
+	if err != nil {
+		return nil, err
+	}
+	return d.race(ctx, host, port, ips)
+}
+
+// resolve returns the addresses of the host of the family, sorted in the order they're attempted.
+func (d *Dialer) resolve(ctx context.Context, host string, family Family) ([]net.IP, error) {
+	switch family {
+	case IPv4:
+		return d.lookup(ctx, "ip4", host)
+	case IPv6:
+		return d.lookup(ctx, "ip6", host)
+	}
+	type lookupResult struct {
+		family Family
+		ips    []net.IP
+		err    error
+	}
+	lookupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan lookupResult, 2)
+	for lookupFamily, network := range map[Family]string{IPv4: "ip4", IPv6: "ip6"} {
+		go func() {
+			ips, err := d.lookupIP(lookupCtx, network, host)
+			results <- lookupResult{family: lookupFamily, ips: ips, err: err}
+		}()
+	}
+	ipsByFamily := make(map[Family][]net.IP)
+	var errs []error
+	var timeout <-chan time.Time
+lookups:
+	for pending := 2; pending > 0; pending-- {
+		var result lookupResult
+		select {
+		case result = <-results:
+		case <-timeout:
+			log.Debug("The lookup of the addresses of", host, "didn't resolve both families on time. Connecting to the resolved ones.")
+			break lookups
+		}
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+		ipsByFamily[result.family] = result.ips
+		// A slow lookup of the other family, such as of the IPv6 addresses on a network without IPv6, doesn't delay the connection.
+		if timeout == nil && len(result.ips) > 0 {
+			timeout = time.After(resolutionDelay)
+		}
+	}
+	preferredFamily := getPreferredFamily(host)
+	ips := interleave(ipsByFamily[preferredFamily], ipsByFamily[otherFamily(preferredFamily)])
+	if len(ips) == 0 {
+		return nil, errorutils.CheckErrorf("failed to resolve the addresses of %s: %s", host, errors.Join(errs...))
+	}
+	return ips, nil
+}
+
+func (d *Dialer) lookup(ctx context.Context, network, host string) ([]net.IP, error) {
+	ips, err := d.lookupIP(ctx, network, host)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to resolve the addresses of %s: %s", host, err.Error())
+	}
+	if len(ips) == 0 {
+		return nil, errorutils.CheckErrorf("no %s address of %s was found", network, host)
+	}
+	return ips, nil
+}
+
+// getPreferredFamily returns the family which the last connection to the host succeeded with. IPv6 is preferred otherwise.
+func getPreferredFamily(host string) Family {
+	if family, exists := preferredFamilies.Load(host); exists {
+		return family.(Family)
+	}
+	return IPv6
+}
+
+func otherFamily(family Family) Family {
+	if family == IPv6 {
+		return IPv4
+	}
+	return IPv6
+}
+
+// interleave alternates the addresses of the preferred family and the other family, starting by the preferred one.
+func interleave(preferred, other []net.IP) []net.IP {
+	ips := make([]net.IP, 0, len(preferred)+len(other))
+	for i := 0; i < len(preferred) || i < len(other); i++ {
+		if i < len(preferred) {
+			ips = append(ips, preferred[i])
+		}
+		if i < len(other) {
+			ips = append(ips, other[i])
+		}
+	}
+	return ips
+}
+
+// race connects to the addresses, starting the attempt of each address once the previous attempt failed, or once the attempt delay
+// elapsed. The first connection which succeeds is returned, and the other attempts are canceled.
+func (d *Dialer) race(ctx context.Context, host, port string, ips []net.IP) (net.Conn, error) {
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan dialResult, len(ips))
+	next, pending := 0, 0
+	startNext := func() {
+		ip := ips[next]
+		next++
+		pending++
+		go func() {
+			conn, err := d.dial(raceCtx, "tcp", net.JoinHostPort(ip.String(), port))
+			results <- dialResult{conn: conn, ip: ip, err: err}
+		}()
+	}
+	startNext()
+	timer := time.NewTimer(d.attemptDelay)
+	defer timer.Stop()
+	var errs []error
+	for pending > 0 {
+		select {
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				preferredFamilies.Store(host, familyOf(result.ip))
+				// The connections of the attempts which succeeded afterward aren't used.
+				go closeConnections(results, pending)
+				return result.conn, nil
+			}
+			log.Debug("Failed to connect to", host, "at", result.ip.String()+":", result.err.Error())
+			errs = append(errs, result.err)
+			if next < len(ips) {
+				startNext()
+				timer.Reset(d.attemptDelay)
+			}
+		case <-timer.C:
+			if next < len(ips) {
+				startNext()
+				timer.Reset(d.attemptDelay)
+			}
+		}
+	}
+	return nil, errorutils.CheckErrorf("failed to connect to %s: %s", host, errors.Join(errs...))
+}
+
+type dialResult struct {
+	conn net.Conn
+	ip   net.IP
+	err  error
+}
+
+// closeConnections closes the connections of the pending attempts of a race which was already won.
+func closeConnections(results <-chan dialResult, pending int) {
+	for ; pending > 0; pending-- {
+		if result := <-results; result.err == nil {
+			_ = result.conn.Close()
+		}
+	}
+}
+
+func familyOf(ip net.IP) Family {
+	if ip.To4() != nil {
+		return IPv4
+	}
+	return IPv6
+}
@@ -0,0 +1,127 @@
+package dualstack
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testIPv6 = net.ParseIP("2001:db8::1")
+	testIPv4 = net.ParseIP("192.0.2.1")
+)
+
+func TestGetFamily(t *testing.T) {
+	family, err := GetFamily("prod")
+	require.NoError(t, err)
+	assert.Equal(t, Auto, family)
+
+	t.Setenv(FamilyEnv, "prod=IPv6, ipv4")
+	family, err = GetFamily("prod")
+	require.NoError(t, err)
+	assert.Equal(t, IPv6, family)
+	family, err = GetFamily("staging")
+	require.NoError(t, err)
+	assert.Equal(t, IPv4, family)
+
+	t.Setenv(FamilyEnv, "prod=ipv5")
+	_, err = GetFamily("prod")
+	assert.ErrorContains(t, err, "must be one of auto, ipv4 or ipv6")
+}
+
+func TestInterleave(t *testing.T) {
+	otherIPv6 := net.ParseIP("2001:db8::2")
+	otherIPv4 := net.ParseIP("192.0.2.2")
+	assert.Equal(t, []net.IP{testIPv6, testIPv4, otherIPv6, otherIPv4}, interleave([]net.IP{testIPv6, otherIPv6}, []net.IP{testIPv4, otherIPv4}))
+	assert.Equal(t, []net.IP{testIPv4, otherIPv4}, interleave(nil, []net.IP{testIPv4, otherIPv4}))
+}
+
+// newTestDialer returns a dialer of hosts which resolve to testIPv6 and testIPv4, whose connections to the IPv6 address hang
+// until they're canceled, as on a network with a broken IPv6 route.
+func newTestDialer(t *testing.T, family Family) (*Dialer, func() []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	var mutex sync.Mutex
+	var dialed []string
+	dialer := &net.Dialer{}
+	return &Dialer{
+		family:       family,
+		attemptDelay: 20 * time.Millisecond,
+		lookupIP: func(_ context.Context, network, _ string) ([]net.IP, error) {
+			if network == "ip6" {
+				return []net.IP{testIPv6}, nil
+			}
+			return []net.IP{testIPv4}, nil
+		},
+		dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			mutex.Lock()
+			dialed = append(dialed, address)
+			mutex.Unlock()
+			if address == net.JoinHostPort(testIPv6.String(), "443") {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return dialer.DialContext(ctx, network, listener.Addr().String())
+		},
+	}, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, dialed...)
+	}
+}
+
+func TestDialContextFallback(t *testing.T) {
+	preferredFamilies.Delete("fallback.example.com")
+	dialer, dialed := newTestDialer(t, Auto)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", "fallback.example.com:443")
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, "[2001:db8::1]:443", dialed()[0])
+	assert.Equal(t, IPv4, getPreferredFamily("fallback.example.com"))
+
+	// The next connections start by the family which connected.
+	conn, err = dialer.DialContext(ctx, "tcp", "fallback.example.com:443")
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, "192.0.2.1:443", dialed()[2])
+}
+
+func TestDialContextFamily(t *testing.T) {
+	dialer, dialed := newTestDialer(t, IPv4)
+	conn, err := dialer.DialContext(context.Background(), "tcp", "ipv4.example.com:443")
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, []string{"192.0.2.1:443"}, dialed())
+
+	// The connections to the IPv6 addresses fail once the context is done.
+	dialer.family = IPv6
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = dialer.DialContext(ctx, "tcp", "ipv6.example.com:443")
+	assert.ErrorContains(t, err, "failed to connect to ipv6.example.com")
+}
+
+func TestResolveSlowFamily(t *testing.T) {
+	preferredFamilies.Delete("slow.example.com")
+	dialer := &Dialer{lookupIP: func(ctx context.Context, network, _ string) ([]net.IP, error) {
+		if network == "ip6" {
+			// The lookup of the IPv6 addresses doesn't resolve.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []net.IP{testIPv4}, nil
+	}}
+	ips, err := dialer.resolve(context.Background(), "slow.example.com", Auto)
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{testIPv4}, ips)
+}
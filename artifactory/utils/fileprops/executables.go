@@ -0,0 +1,125 @@
+package fileprops
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/pe"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	gnuBuildIdSection = ".note.gnu.build-id"
+	peResourceSection = ".rsrc"
+	// The length of the VS_FIXEDFILEINFO structure of the version resources, from its signature to its product version.
+	fixedFileInfoLength = 24
+)
+
+// The signature of the VS_FIXEDFILEINFO structure, in little endian.
+var fixedFileInfoSignature = []byte{0xbd, 0x04, 0xef, 0xfe}
+
+var peMachines = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_I386:  "i386",
+	pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+	pe.IMAGE_FILE_MACHINE_ARM:   "arm",
+	pe.IMAGE_FILE_MACHINE_ARMNT: "armnt",
+	pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+}
+
+func extractElfProps(filePath string) (props map[string]string, err error) {
+	file, err := elf.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	props = make(map[string]string)
+	addProp(props, "elf.class", file.Class.String())
+	addProp(props, "elf.machine", file.Machine.String())
+	if section := file.Section(gnuBuildIdSection); section != nil {
+		data, err := section.Data()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		addProp(props, "elf.build.id", parseBuildIdNote(data, file.ByteOrder))
+	}
+	addGoProps(props, filePath)
+	return props, nil
+}
+
+// parseBuildIdNote returns the build ID of the GNU build ID note, which is made of the name size, the descriptor size
+// and the type of the note, followed by its name, padded to 4 bytes, and by the build ID.
+func parseBuildIdNote(data []byte, byteOrder binary.ByteOrder) string {
+	if len(data) < 12 {
+		return ""
+	}
+	nameSize, descSize := byteOrder.Uint32(data[0:4]), byteOrder.Uint32(data[4:8])
+	descStart := 12 + uint64(nameSize+3)&^3
+	if descStart+uint64(descSize) > uint64(len(data)) {
+		return ""
+	}
+	return hex.EncodeToString(data[descStart : descStart+uint64(descSize)])
+}
+
+func extractPeProps(filePath string) (props map[string]string, err error) {
+	file, err := pe.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	props = make(map[string]string)
+	if machine, ok := peMachines[file.Machine]; ok {
+		addProp(props, "pe.machine", machine)
+	} else {
+		addProp(props, "pe.machine", fmt.Sprintf("0x%x", file.Machine))
+	}
+	if section := file.Section(peResourceSection); section != nil {
+		data, err := section.Data()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		fileVersion, productVersion := parseFixedFileInfo(data)
+		addProp(props, "pe.file.version", fileVersion)
+		addProp(props, "pe.product.version", productVersion)
+	}
+	addGoProps(props, filePath)
+	return props, nil
+}
+
+// parseFixedFileInfo returns the file and product versions of the VS_FIXEDFILEINFO structure of the version resource,
+// which follows its signature with the version of the structure, and the most and least significant parts of the versions.
+func parseFixedFileInfo(resources []byte) (fileVersion, productVersion string) {
+	index := bytes.Index(resources, fixedFileInfoSignature)
+	if index < 0 || index+fixedFileInfoLength > len(resources) {
+		return "", ""
+	}
+	info := resources[index : index+fixedFileInfoLength]
+	return formatPeVersion(binary.LittleEndian.Uint32(info[8:12]), binary.LittleEndian.Uint32(info[12:16])),
+		formatPeVersion(binary.LittleEndian.Uint32(info[16:20]), binary.LittleEndian.Uint32(info[20:24]))
+}
+
+func formatPeVersion(mostSignificant, leastSignificant uint32) string {
+	return fmt.Sprintf("%d.%d.%d.%d", mostSignificant>>16, mostSignificant&0xffff, leastSignificant>>16, leastSignificant&0xffff)
+}
+
+// addGoProps adds the Go version and the main module of the executable, if it was built by Go.
+func addGoProps(props map[string]string, filePath string) {
+	info, err := buildinfo.ReadFile(filePath)
+	if err != nil {
+		// Not a Go executable.
+		return
+	}
+	addProp(props, "go.version", info.GoVersion)
+	addProp(props, "go.module.path", info.Main.Path)
+	if info.Main.Version != "(devel)" {
+		addProp(props, "go.module.version", info.Main.Version)
+	}
+}
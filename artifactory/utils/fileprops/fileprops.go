@@ -0,0 +1,126 @@
+package fileprops
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The magic numbers of the executables, at the start of the files.
+var (
+	elfMagic = []byte("\x7fELF")
+	peMagic  = []byte("MZ")
+)
+
+// Extract returns the properties derived from the metadata of the file, such as the name and the version of a package.
+// The file type is detected by its extension for packages, and by its content for executables.
+// Files of other types have no properties, and return an empty map.
+//
+// The extracted properties are:
+//   - JAR, WAR and EAR files: the main attributes of the manifest, such as jar.Implementation-Version.
+//   - npm packages (.tgz): npm.name and npm.version of the package.json.
+//   - Python wheels (.whl): pypi.name, pypi.version and pypi.summary of the METADATA.
+//   - ELF executables: elf.class, elf.machine and elf.build.id.
+//   - PE executables: pe.machine, pe.file.version and pe.product.version of the version resource.
+//   - Go executables, in addition to the above: go.version, go.module.path and go.module.version.
+func Extract(filePath string) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".jar", ".war", ".ear":
+		return extractJarProps(filePath)
+	case ".whl":
+		return extractWheelProps(filePath)
+	case ".tgz":
+		return extractNpmProps(filePath)
+	}
+	magic, err := readMagic(filePath, len(elfMagic))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, elfMagic):
+		return extractElfProps(filePath)
+	case bytes.HasPrefix(magic, peMagic):
+		return extractPeProps(filePath)
+	}
+	return map[string]string{}, nil
+}
+
+func readMagic(filePath string, length int) (magic []byte, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	magic = make([]byte, length)
+	n, err := io.ReadFull(file, magic)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return magic[:n], nil
+	}
+	return magic, errorutils.CheckError(err)
+}
+
+// parseHeaders parses the "Name: Value" headers of a manifest or of a package metadata, until the first empty line.
+// Lines starting with a space continue the value of the previous header.
+func parseHeaders(content []byte, keepLineBreaks bool) map[string]string {
+	headers := make(map[string]string)
+	var lastName string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if lastName != "" {
+				if keepLineBreaks {
+					headers[lastName] += " " + strings.TrimSpace(line)
+				} else {
+					headers[lastName] += line[1:]
+				}
+			}
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		lastName = strings.TrimSpace(name)
+		if _, exists := headers[lastName]; !exists {
+			headers[lastName] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+// addProp adds the property to the properties, unless its value is empty.
+func addProp(props map[string]string, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		props[key] = value
+	}
+}
+
+// ToPropsString returns the properties in the key=value;key=value form of the properties flags, sorted by their keys.
+// The separators in the values are escaped, so each value is kept as a single value.
+func ToPropsString(props map[string]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	escaper := strings.NewReplacer(";", "\\;", ",", "\\,")
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+escaper.Replace(props[key]))
+	}
+	return strings.Join(pairs, ";")
+}
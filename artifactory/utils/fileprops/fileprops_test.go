@@ -0,0 +1,155 @@
+package fileprops
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeZip(t *testing.T, filePath string, entries map[string]string) {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for name, content := range entries {
+		entry, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, os.WriteFile(filePath, buffer.Bytes(), 0644))
+}
+
+func TestExtractJarProps(t *testing.T) {
+	jarPath := filepath.Join(t.TempDir(), "app-1.0.jar")
+	writeZip(t, jarPath, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nImplementation-Title: app\r\nImplementation-Version: 1.0\r\n" +
+			"Implementation-Vendor: Acme Widgets, Inc.\r\nMain-Class: org.acme.app.VeryLongMainClassNameWhichIsContinuedOnTheNext\r\n Line\r\n\r\n" +
+			"Name: org/acme/app/\r\nImplementation-Version: 2.0\r\n",
+		"org/acme/app/Main.class": "",
+	})
+	props, err := Extract(jarPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"jar.Implementation-Title":   "app",
+		"jar.Implementation-Version": "1.0",
+		"jar.Implementation-Vendor":  "Acme Widgets, Inc.",
+		"jar.Main-Class":             "org.acme.app.VeryLongMainClassNameWhichIsContinuedOnTheNextLine",
+	}, props)
+	assert.Equal(t, `jar.Implementation-Title=app;jar.Implementation-Vendor=Acme Widgets\, Inc.;jar.Implementation-Version=1.0;`+
+		`jar.Main-Class=org.acme.app.VeryLongMainClassNameWhichIsContinuedOnTheNextLine`, ToPropsString(props))
+}
+
+func TestExtractWheelProps(t *testing.T) {
+	wheelPath := filepath.Join(t.TempDir(), "acme_tools-2.1.0-py3-none-any.whl")
+	writeZip(t, wheelPath, map[string]string{
+		"acme_tools/__init__.py":               "",
+		"acme_tools-2.1.0.dist-info/METADATA":  "Metadata-Version: 2.1\nName: acme-tools\nVersion: 2.1.0\nSummary: Tools\n for Acme\n\nThe description.\nName: other\n",
+		"acme_tools/vendor.dist-info/METADATA": "Name: vendored\n",
+	})
+	props, err := Extract(wheelPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pypi.name": "acme-tools", "pypi.version": "2.1.0", "pypi.summary": "Tools for Acme"}, props)
+}
+
+func writeTarGz(t *testing.T, filePath string, entries map[string]string) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range entries {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, os.WriteFile(filePath, buffer.Bytes(), 0644))
+}
+
+func TestExtractNpmProps(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "acme-widgets-3.0.0-beta.1.tgz")
+	writeTarGz(t, packagePath, map[string]string{
+		"package/node_modules/dep/package.json": `{"name": "dep", "version": "0.0.1"}`,
+		"package/package.json":                  `{"name": "@acme/widgets", "version": "3.0.0-beta.1"}`,
+	})
+
+	props, err := Extract(packagePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"npm.name": "@acme/widgets", "npm.version": "3.0.0-beta.1"}, props)
+}
+
+func TestExtractPythonDistributionProps(t *testing.T) {
+	sdistPath := filepath.Join(t.TempDir(), "acme_tools-2.1.0.tar.gz")
+	writeTarGz(t, sdistPath, map[string]string{
+		"acme_tools-2.1.0/src/acme_tools.egg-info/PKG-INFO": "Name: egg-info\n",
+		"acme_tools-2.1.0/PKG-INFO":                         "Metadata-Version: 2.1\nName: acme-tools\nVersion: 2.1.0\nSummary: Tools for Acme\n",
+	})
+	props, err := ExtractPythonDistributionProps(sdistPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pypi.name": "acme-tools", "pypi.version": "2.1.0", "pypi.summary": "Tools for Acme"}, props)
+
+	wheelPath := filepath.Join(t.TempDir(), "acme_tools-2.1.0-py3-none-any.whl")
+	writeZip(t, wheelPath, map[string]string{"acme_tools-2.1.0.dist-info/METADATA": "Name: acme-tools\nVersion: 2.1.0\n"})
+	props, err = ExtractPythonDistributionProps(wheelPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pypi.name": "acme-tools", "pypi.version": "2.1.0"}, props)
+}
+
+func TestExtractExecutableProps(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("The test binary is an ELF executable only on Linux.")
+	}
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	props, err := Extract(executable)
+	require.NoError(t, err)
+	assert.Equal(t, "ELFCLASS64", props["elf.class"])
+	assert.NotEmpty(t, props["elf.machine"])
+	assert.Equal(t, runtime.Version(), props["go.version"])
+}
+
+func TestExtractUnsupportedFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("M"), 0644))
+	props, err := Extract(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, props)
+
+	// A corrupted package fails the extraction.
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(filePath), "broken.jar"), []byte("not a zip"), 0644))
+	_, err = Extract(filepath.Join(filepath.Dir(filePath), "broken.jar"))
+	assert.Error(t, err)
+}
+
+func TestParseBuildIdNote(t *testing.T) {
+	note := make([]byte, 12)
+	binary.LittleEndian.PutUint32(note[0:4], 4)
+	binary.LittleEndian.PutUint32(note[4:8], 4)
+	binary.LittleEndian.PutUint32(note[8:12], 3)
+	note = append(note, []byte("GNU\x00")...)
+	note = append(note, 0xde, 0xad, 0xbe, 0xef)
+	assert.Equal(t, "deadbeef", parseBuildIdNote(note, binary.LittleEndian))
+	assert.Empty(t, parseBuildIdNote(note[:18], binary.LittleEndian))
+}
+
+func TestParseFixedFileInfo(t *testing.T) {
+	resources := append([]byte("VS_VERSION_INFO padding"), fixedFileInfoSignature...)
+	for _, value := range []uint32{0x00010000, 0x00020003, 0x00040005, 0x00020003, 0x00040000} {
+		resources = binary.LittleEndian.AppendUint32(resources, value)
+	}
+	fileVersion, productVersion := parseFixedFileInfo(resources)
+	assert.Equal(t, "2.3.4.5", fileVersion)
+	assert.Equal(t, "2.3.4.0", productVersion)
+
+	fileVersion, productVersion = parseFixedFileInfo(resources[:len(resources)-1])
+	assert.Empty(t, fileVersion)
+	assert.Empty(t, productVersion)
+}
@@ -0,0 +1,160 @@
+package fileprops
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	jarManifestPath = "META-INF/MANIFEST.MF"
+	// The limit of the size of the metadata files which are read from the packages.
+	maxMetadataSize = 1 << 20
+)
+
+// The main attributes of the JAR manifests which are extracted as properties.
+var jarManifestAttributes = []string{
+	"Implementation-Title", "Implementation-Version", "Implementation-Vendor",
+	"Specification-Title", "Specification-Version",
+	"Bundle-SymbolicName", "Bundle-Version", "Automatic-Module-Name", "Main-Class",
+}
+
+func extractJarProps(filePath string) (map[string]string, error) {
+	manifest, err := readZipEntry(filePath, func(name string) bool { return name == jarManifestPath })
+	if err != nil || manifest == nil {
+		return map[string]string{}, err
+	}
+	attributes := parseHeaders(manifest, false)
+	props := make(map[string]string)
+	for _, attribute := range jarManifestAttributes {
+		addProp(props, "jar."+attribute, attributes[attribute])
+	}
+	return props, nil
+}
+
+func extractWheelProps(filePath string) (map[string]string, error) {
+	metadata, err := readZipEntry(filePath, func(name string) bool {
+		dir, file := path.Split(name)
+		return file == "METADATA" && strings.HasSuffix(dir, ".dist-info/") && strings.Count(dir, "/") == 1
+	})
+	if err != nil || metadata == nil {
+		return map[string]string{}, err
+	}
+	return pythonMetadataProps(metadata), nil
+}
+
+// pythonMetadataProps returns the properties of the core metadata of a Python distribution, which is the same in the METADATA of
+// the wheels and in the PKG-INFO of the source distributions.
+func pythonMetadataProps(metadata []byte) map[string]string {
+	headers := parseHeaders(metadata, true)
+	props := make(map[string]string)
+	addProp(props, "pypi.name", headers["Name"])
+	addProp(props, "pypi.version", headers["Version"])
+	addProp(props, "pypi.summary", headers["Summary"])
+	return props
+}
+
+// readZipEntry returns the content of the first entry of the zip file which matches, or nil if no entry matches.
+func readZipEntry(filePath string, matches func(name string) bool) (content []byte, err error) {
+	zipReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipReader.Close()))
+	}()
+	for _, file := range zipReader.File {
+		if !matches(file.Name) {
+			continue
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		content, err = io.ReadAll(io.LimitReader(entry, maxMetadataSize))
+		return content, errors.Join(errorutils.CheckError(err), errorutils.CheckError(entry.Close()))
+	}
+	return nil, nil
+}
+
+func extractNpmProps(filePath string) (map[string]string, error) {
+	// The package.json is at the root directory of the package, which is usually named package.
+	packageJsonContent, err := readTarGzEntry(filePath, func(name string) bool {
+		return path.Base(name) == "package.json" && strings.Count(strings.TrimPrefix(name, "./"), "/") == 1
+	})
+	if err != nil || packageJsonContent == nil {
+		return map[string]string{}, err
+	}
+	var packageJson struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err = json.Unmarshal(packageJsonContent, &packageJson); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the package.json of %s: %s", filePath, err.Error())
+	}
+	props := make(map[string]string)
+	addProp(props, "npm.name", packageJson.Name)
+	addProp(props, "npm.version", packageJson.Version)
+	return props, nil
+}
+
+func extractSdistProps(filePath string) (map[string]string, error) {
+	// The PKG-INFO is at the root directory of the source distribution, which is named <name>-<version>.
+	pkgInfo, err := readTarGzEntry(filePath, func(name string) bool {
+		return path.Base(name) == "PKG-INFO" && strings.Count(strings.TrimPrefix(name, "./"), "/") == 1
+	})
+	if err != nil || pkgInfo == nil {
+		return map[string]string{}, err
+	}
+	return pythonMetadataProps(pkgInfo), nil
+}
+
+// ExtractPythonDistributionProps returns the pypi.name, pypi.version and pypi.summary properties of a wheel (.whl),
+// or of a source distribution (.tar.gz), from its METADATA or its PKG-INFO.
+// Files of other types have no properties, and return an empty map.
+func ExtractPythonDistributionProps(filePath string) (map[string]string, error) {
+	switch {
+	case strings.HasSuffix(strings.ToLower(filePath), ".whl"):
+		return extractWheelProps(filePath)
+	case strings.HasSuffix(strings.ToLower(filePath), ".tar.gz"):
+		return extractSdistProps(filePath)
+	}
+	return map[string]string{}, nil
+}
+
+// readTarGzEntry returns the content of the first entry of the gzipped tar file which matches, or nil if no entry matches.
+func readTarGzEntry(filePath string, matches func(name string) bool) (content []byte, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if matches(header.Name) {
+			content, err = io.ReadAll(io.LimitReader(tarReader, maxMetadataSize))
+			return content, errorutils.CheckError(err)
+		}
+	}
+}
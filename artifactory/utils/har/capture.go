@@ -0,0 +1,40 @@
+package har
+
+import (
+	"errors"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Capture records the HTTP traffic of a command to a HAR file.
+// The URLs of the server details are pointed to local reverse proxies, which record the traffic and
+// forward it to the original servers. This captures the traffic of every service manager created
+// from the server details, without changes to the commands themselves.
+type Capture struct {
+	harPath  string
+	recorder *Recorder
+	proxies  *serverproxy.Proxies
+}
+
+// StartCapture starts recording the traffic sent using the server details to the HAR file at harPath.
+// The server details are modified in place, and restored by Close.
+func StartCapture(serverDetails *config.ServerDetails, harPath string) (capture *Capture, err error) {
+	capture = &Capture{harPath: harPath, recorder: NewRecorder()}
+	if capture.proxies, err = serverproxy.Start(serverDetails, "HAR capture", capture.recorder.Transport); err != nil {
+		return nil, err
+	}
+	log.Info("Capturing the HTTP traffic to", harPath)
+	return capture, nil
+}
+
+// Close stops capturing the traffic, restores the server details and writes the HAR file.
+func (c *Capture) Close() error {
+	err := c.proxies.Close()
+	if writeErr := c.recorder.WriteFile(c.harPath); writeErr != nil {
+		return errors.Join(err, writeErr)
+	}
+	log.Info("The HTTP traffic was written to", c.harPath, "- credentials were redacted, but review the file before sharing it.")
+	return err
+}
@@ -0,0 +1,129 @@
+package har
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/artifactory/api/redirect" {
+			http.Redirect(w, r, "http://"+r.Host+"/artifactory/api/system/ping", http.StatusFound)
+			return
+		}
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","access_token":"abc"}`))
+	}))
+	defer server.Close()
+
+	serverDetails := &config.ServerDetails{Url: server.URL + "/", ArtifactoryUrl: server.URL + "/artifactory/"}
+	harPath := filepath.Join(t.TempDir(), "traffic.har")
+	capture, err := StartCapture(serverDetails, harPath)
+	require.NoError(t, err)
+	assert.NotEqual(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	assert.True(t, strings.HasSuffix(serverDetails.ArtifactoryUrl, "/artifactory/"))
+
+	req, err := http.NewRequest(http.MethodPost, serverDetails.ArtifactoryUrl+"api/redirect?token=123&repo=generic", strings.NewReader(`{"password":"p4ss","name":"user"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `"status":"OK"`)
+
+	require.NoError(t, capture.Close())
+	assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	assert.Equal(t, server.URL+"/", serverDetails.Url)
+
+	content, err := os.ReadFile(harPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "secret-token")
+	assert.NotContains(t, string(content), "p4ss")
+	assert.NotContains(t, string(content), `"abc"`)
+	harFile := new(Har)
+	require.NoError(t, json.Unmarshal(content, harFile))
+	require.Len(t, harFile.Log.Entries, 2)
+
+	redirectEntry := harFile.Log.Entries[0]
+	assert.Equal(t, http.MethodPost, redirectEntry.Request.Method)
+	assert.Equal(t, server.URL+"/artifactory/api/redirect?repo=generic&token=REDACTED", redirectEntry.Request.Url)
+	assert.Equal(t, http.StatusFound, redirectEntry.Response.Status)
+	require.NotNil(t, redirectEntry.Request.PostData)
+	assert.Equal(t, `{"password":"REDACTED","name":"user"}`, redirectEntry.Request.PostData.Text)
+	assert.Contains(t, redirectEntry.Request.Headers, NameValue{Name: "Authorization", Value: redacted})
+
+	pingEntry := harFile.Log.Entries[1]
+	assert.Equal(t, server.URL+"/artifactory/api/system/ping", pingEntry.Request.Url)
+	assert.Equal(t, http.StatusOK, pingEntry.Response.Status)
+	assert.Equal(t, `{"status":"OK","access_token":"REDACTED"}`, pingEntry.Response.Content.Text)
+}
+
+func TestCaptureRecordsServerUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+	// The capture forwards the traffic to the proxy of another option of the command.
+	proxies, err := serverproxy.Start(serverDetails, "test", func(upstream http.RoundTripper) http.RoundTripper { return upstream })
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, proxies.Close())
+	}()
+	harPath := filepath.Join(t.TempDir(), "traffic.har")
+	capture, err := StartCapture(serverDetails, harPath)
+	require.NoError(t, err)
+
+	resp, err := http.Get(serverDetails.ArtifactoryUrl + "api/system/ping")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, capture.Close())
+
+	content, err := os.ReadFile(harPath)
+	require.NoError(t, err)
+	harFile := new(Har)
+	require.NoError(t, json.Unmarshal(content, harFile))
+	require.Len(t, harFile.Log.Entries, 1)
+	assert.Equal(t, server.URL+"/artifactory/api/system/ping", harFile.Log.Entries[0].Request.Url)
+}
+
+func TestCapturedBodyText(t *testing.T) {
+	binary := &capturedBody{ReadCloser: io.NopCloser(strings.NewReader("\xff\xfe\x00binary"))}
+	_, err := io.ReadAll(binary)
+	require.NoError(t, err)
+	text, size, comment := binary.text()
+	assert.Empty(t, text)
+	assert.Equal(t, int64(9), size)
+	assert.Equal(t, "Binary content omitted.", comment)
+
+	// A multibyte character cut by the truncation doesn't make the content binary.
+	large := strings.Repeat("a", maxCapturedBodySize-1) + "é" + "tail"
+	truncated := &capturedBody{ReadCloser: io.NopCloser(strings.NewReader(large))}
+	_, err = io.ReadAll(truncated)
+	require.NoError(t, err)
+	text, size, comment = truncated.text()
+	assert.Equal(t, strings.Repeat("a", maxCapturedBodySize-1), text)
+	assert.Equal(t, int64(len(large)), size)
+	assert.Contains(t, comment, "truncated")
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, `{"refresh_token" : "REDACTED","user":"admin"}`, redactBody(`{"refresh_token" : "a\"b","user":"admin"}`))
+	assert.Equal(t, "username=admin&password=REDACTED&apiKey=REDACTED", redactBody("username=admin&password=secret&apiKey=123"))
+}
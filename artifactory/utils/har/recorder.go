@@ -0,0 +1,270 @@
+package har
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// maxCapturedBodySize is the maximum number of bytes of each request and response body which are kept in the HAR file.
+	maxCapturedBodySize = 64 * 1024
+	redacted            = "REDACTED"
+)
+
+var (
+	sensitiveHeaders = map[string]bool{
+		"authorization":       true,
+		"proxy-authorization": true,
+		"cookie":              true,
+		"set-cookie":          true,
+		"x-jfrog-art-api":     true,
+		"x-api-key":           true,
+	}
+	sensitiveParamNames = []string{"password", "token", "secret", "apikey", "api_key"}
+	// Matches JSON string fields such as "password": "..." or "access_token": "...".
+	sensitiveJsonFieldRegexp = regexp.MustCompile(`(?i)("[a-z_\-]*(?:password|token|secret|api_?key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// Matches URL encoded form fields such as password=... or refresh_token=...
+	sensitiveFormFieldRegexp = regexp.MustCompile(`(?i)((?:^|&)[a-z_\-]*(?:password|token|secret|api_?key)=)[^&]*`)
+)
+
+// Recorder records the HTTP traffic passing through its transport, and writes it as a HAR file.
+// Credentials are redacted from the headers, query parameters and textual bodies of the recorded traffic.
+type Recorder struct {
+	mutex   sync.Mutex
+	entries []*Entry
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Transport returns an http.RoundTripper which records the traffic sent through the next round tripper.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{next: next, recorder: r}
+}
+
+// WriteFile writes the recorded traffic to a HAR file.
+func (r *Recorder) WriteFile(harPath string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, entry := range r.entries {
+		entry.fillBodies()
+	}
+	content, err := json.MarshalIndent(Har{Log: Log{
+		Version: harVersion,
+		Creator: Creator{Name: creatorName, Version: coreutils.GetCliUserAgentVersion()},
+		Entries: r.entries,
+	}}, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(harPath, content, 0600))
+}
+
+func (r *Recorder) addEntry(entry *Entry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+type recordingTransport struct {
+	next     http.RoundTripper
+	recorder *Recorder
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	entry := &Entry{StartedDateTime: start.Format(time.RFC3339Nano), Request: newRequest(req)}
+	if req.Body != nil && req.Body != http.NoBody {
+		entry.requestBody = &capturedBody{ReadCloser: req.Body}
+		req.Body = entry.requestBody
+	}
+	resp, err := rt.next.RoundTrip(req)
+	entry.Time = float64(time.Since(start).Microseconds()) / 1000
+	entry.Timings = Timings{Send: 0, Wait: entry.Time, Receive: 0}
+	if err != nil {
+		entry.Comment = "The request failed: " + err.Error()
+		entry.Response = Response{Cookies: []NameValue{}, Headers: []NameValue{}, BodySize: -1, HeadersSize: -1}
+	} else {
+		entry.Response = newResponse(resp)
+		if resp.Body != nil && resp.Body != http.NoBody {
+			entry.responseBody = &capturedBody{ReadCloser: resp.Body}
+			resp.Body = entry.responseBody
+		}
+	}
+	rt.recorder.addEntry(entry)
+	return resp, err
+}
+
+// capturedBody reads through the wrapped body and keeps its first maxCapturedBodySize bytes.
+type capturedBody struct {
+	io.ReadCloser
+	mutex     sync.Mutex
+	content   bytes.Buffer
+	size      int64
+	truncated bool
+}
+
+func (cb *capturedBody) Read(p []byte) (int, error) {
+	n, err := cb.ReadCloser.Read(p)
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.size += int64(n)
+	if remaining := maxCapturedBodySize - cb.content.Len(); remaining > 0 {
+		cb.content.Write(p[:min(n, remaining)])
+	}
+	if cb.size > maxCapturedBodySize {
+		cb.truncated = true
+	}
+	return n, err
+}
+
+// text returns the captured content if it is textual, with its credentials redacted, and a comment describing omitted content.
+func (cb *capturedBody) text() (text string, size int64, comment string) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	content := cb.content.Bytes()
+	if cb.truncated {
+		content = trimPartialRune(content)
+	}
+	if !utf8.Valid(content) {
+		return "", cb.size, "Binary content omitted."
+	}
+	if cb.truncated {
+		comment = fmt.Sprintf("Content truncated to the first %d bytes.", maxCapturedBodySize)
+	}
+	return redactBody(string(content)), cb.size, comment
+}
+
+// trimPartialRune removes a multibyte character which was cut at the end of the content by the truncation.
+func trimPartialRune(content []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(content); i++ {
+		if utf8.RuneStart(content[len(content)-i]) {
+			if !utf8.FullRune(content[len(content)-i:]) {
+				return content[:len(content)-i]
+			}
+			break
+		}
+	}
+	return content
+}
+
+func (e *Entry) fillBodies() {
+	if e.requestBody != nil {
+		text, size, comment := e.requestBody.text()
+		e.Request.BodySize = size
+		e.Request.PostData = &PostData{MimeType: headerValue(e.Request.Headers, "Content-Type"), Text: text, Params: []NameValue{}, Comment: comment}
+	}
+	if e.responseBody != nil {
+		text, size, comment := e.responseBody.text()
+		e.Response.BodySize = size
+		e.Response.Content.Size = size
+		e.Response.Content.Text = text
+		e.Response.Content.Comment = comment
+	}
+}
+
+func newRequest(req *http.Request) Request {
+	requestUrl := *req.URL
+	// The capture may forward the traffic to the proxies of the other options of the command, so the URL of the server is recorded,
+	// rather than the local URL of the proxy.
+	if originalUrl, err := url.Parse(serverproxy.OriginalUrl(req.URL.String())); err == nil {
+		requestUrl = *originalUrl
+	}
+	requestUrl.User = nil
+	requestUrl.RawQuery = redactQuery(requestUrl.Query()).Encode()
+	return Request{
+		Method:      req.Method,
+		Url:         requestUrl.String(),
+		HttpVersion: req.Proto,
+		Cookies:     []NameValue{},
+		Headers:     toNameValues(req.Header),
+		QueryString: toQueryString(requestUrl.Query()),
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+}
+
+func newResponse(resp *http.Response) Response {
+	return Response{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HttpVersion: resp.Proto,
+		Cookies:     []NameValue{},
+		Headers:     toNameValues(resp.Header),
+		Content:     Content{MimeType: resp.Header.Get("Content-Type")},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+}
+
+func toNameValues(header http.Header) []NameValue {
+	nameValues := []NameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			if sensitiveHeaders[strings.ToLower(name)] {
+				value = redacted
+			}
+			nameValues = append(nameValues, NameValue{Name: name, Value: value})
+		}
+	}
+	return nameValues
+}
+
+func toQueryString(query url.Values) []NameValue {
+	nameValues := []NameValue{}
+	for name, values := range query {
+		for _, value := range values {
+			nameValues = append(nameValues, NameValue{Name: name, Value: value})
+		}
+	}
+	return nameValues
+}
+
+func headerValue(nameValues []NameValue, name string) string {
+	for _, nameValue := range nameValues {
+		if strings.EqualFold(nameValue.Name, name) {
+			return nameValue.Value
+		}
+	}
+	return ""
+}
+
+func redactQuery(query url.Values) url.Values {
+	for name := range query {
+		if isSensitiveParam(name) {
+			query.Set(name, redacted)
+		}
+	}
+	return query
+}
+
+func isSensitiveParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParamNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactBody(body string) string {
+	body = sensitiveJsonFieldRegexp.ReplaceAllString(body, `$1"`+redacted+`"`)
+	return sensitiveFormFieldRegexp.ReplaceAllString(body, "${1}"+redacted)
+}
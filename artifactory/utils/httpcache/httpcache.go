@@ -0,0 +1,296 @@
+// Package httpcache caches the responses of the metadata API requests, such as the file info, repository configuration and build
+// requests, on the disk. Cached responses are revalidated with conditional requests using their ETag and Last-Modified headers,
+// so repeated identical requests, during a command and across the successive commands of a pipeline, transfer no content
+// unless it changed.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// CacheEnv enables the cache of the metadata API responses, if set to true.
+	CacheEnv = "JFROG_CLI_HTTP_CACHE"
+	// MaxAgeEnv sets the number of seconds during which a cached response is used without being revalidated.
+	// By default, each request is revalidated.
+	MaxAgeEnv = "JFROG_CLI_HTTP_CACHE_MAX_AGE"
+
+	cacheDirName = "http-cache"
+	// maxCachedBodySize is the maximum size of a cached response body. Larger responses are passed through.
+	maxCachedBodySize = 10 * 1024 * 1024
+	// Entries which weren't used for this long are removed when the cache is started.
+	unusedEntryExpiry = 24 * time.Hour
+)
+
+// Matches the paths of the Artifactory REST API requests whose responses are cached: file info and folder listings,
+// repository configurations, and build lists and build-info.
+var metadataPathPattern = regexp.MustCompile(`/api/(?:storage|repositories|build)(?:/|$)`)
+
+// These headers identify the caller or the representation of the response, so they're part of the key of the cached response.
+var keyHeaders = []string{"Authorization", "X-JFrog-Art-Api", "Accept", "Accept-Encoding"}
+
+// Cache stores the responses of the metadata requests in a directory.
+type Cache struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// entry is a cached response.
+type entry struct {
+	Url      string      `json:"url"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+func NewCache(dir string, maxAge time.Duration) *Cache {
+	return &Cache{dir: dir, maxAge: maxAge}
+}
+
+// Start makes the metadata requests sent using the server details go through the cache, if it's enabled by CacheEnv.
+// The server details are modified in place, and restored by stop.
+func Start(serverDetails *config.ServerDetails) (stop func() error, err error) {
+	stop = func() error { return nil }
+	cache, err := newCacheFromEnv()
+	if err != nil || cache == nil {
+		return
+	}
+	cache.removeUnusedEntries()
+	proxies, err := serverproxy.Start(serverDetails, "HTTP cache", cache.Transport)
+	if err != nil {
+		return
+	}
+	log.Debug("Caching the metadata API responses in", cache.dir)
+	return proxies.Close, nil
+}
+
+// newCacheFromEnv returns the cache in the JFrog home directory, or nil if the cache isn't enabled.
+func newCacheFromEnv() (*Cache, error) {
+	if enabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(CacheEnv))); err != nil || !enabled {
+		return nil, nil
+	}
+	var maxAge time.Duration
+	if value := strings.TrimSpace(os.Getenv(MaxAgeEnv)); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return nil, errorutils.CheckErrorf("the value of %s must be a non-negative number of seconds, got '%s'", MaxAgeEnv, value)
+		}
+		maxAge = time.Duration(seconds) * time.Second
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(homeDir, cacheDirName)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return NewCache(dir, maxAge), nil
+}
+
+// Transport returns an http.RoundTripper which serves the metadata requests from the cache, and sends the other requests through next.
+func (c *Cache) Transport(next http.RoundTripper) http.RoundTripper {
+	return &cachingTransport{next: next, cache: c}
+}
+
+type cachingTransport struct {
+	next  http.RoundTripper
+	cache *Cache
+}
+
+func (ct *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isCacheable(req) {
+		return ct.next.RoundTrip(req)
+	}
+	originalUrl := serverproxy.OriginalUrl(req.URL.String())
+	key := cacheKey(originalUrl, req)
+	cached := ct.cache.read(key)
+	if cached != nil && ct.cache.maxAge > 0 && time.Since(cached.StoredAt) < ct.cache.maxAge {
+		log.Debug("Using the cached response of", originalUrl)
+		return cached.response(req), nil
+	}
+	outReq := req
+	if cached != nil {
+		outReq = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			outReq.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+	resp, err := ct.next.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if err = resp.Body.Close(); err != nil {
+			return nil, err
+		}
+		log.Debug("The cached response of", originalUrl, "wasn't modified")
+		cached.StoredAt = time.Now()
+		ct.cache.write(key, cached)
+		return cached.response(req), nil
+	}
+	if !isStorable(resp) {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	if err = resp.Body.Close(); err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	ct.cache.write(key, &entry{Url: originalUrl, Header: header, Body: body, StoredAt: time.Now()})
+	return resp, nil
+}
+
+// isCacheable returns true if the request is a metadata request, which isn't conditional or partial already.
+func isCacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet || !metadataPathPattern.MatchString(req.URL.Path) {
+		return false
+	}
+	for _, header := range []string{"Range", "If-None-Match", "If-Modified-Since", "Cache-Control"} {
+		if req.Header.Get(header) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isStorable returns true if the response can be revalidated, and the server allows storing it.
+func isStorable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return false
+	}
+	cacheControl := strings.ToLower(resp.Header.Get("Cache-Control"))
+	// The cache is per user, so private responses are stored too.
+	return !strings.Contains(cacheControl, "no-store")
+}
+
+// cacheKey identifies the response of the request to the URL. The credentials are hashed with the URL, so that the responses aren't shared
+// between users. The URL is the URL of the server rather than of the proxies the request passed through, whose ports change on every command.
+func cacheKey(originalUrl string, req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(originalUrl))
+	for _, header := range keyHeaders {
+		hash.Write([]byte("\n" + req.Header.Get(header)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *Cache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// read returns the cached response of the key, or nil if it's not cached. Failures to read the cache are logged, and are considered misses.
+func (c *Cache) read(key string) *entry {
+	content, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug("Failed to read the HTTP cache:", err.Error())
+		}
+		return nil
+	}
+	cached := new(entry)
+	if err = json.Unmarshal(content, cached); err != nil {
+		log.Debug("Failed to parse the HTTP cache entry", c.entryPath(key)+":", err.Error())
+		return nil
+	}
+	return cached
+}
+
+// write stores the response. The entry is written to a temporary file which is then renamed, so that the processes sharing
+// the cache never read a partial entry. Since the cache is only an optimization, failures are logged.
+func (c *Cache) write(key string, cached *entry) {
+	content, err := json.Marshal(cached)
+	if err == nil {
+		err = writeFileAtomically(c.entryPath(key), content)
+	}
+	if err != nil {
+		log.Debug("Failed to write the HTTP cache:", err.Error())
+	}
+}
+
+func writeFileAtomically(path string, content []byte) (err error) {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tempFile.Name())
+		}
+	}()
+	if _, err = tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path)
+}
+
+func (c *Cache) removeUnusedEntries() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		log.Debug("Failed to read the HTTP cache directory:", err.Error())
+		return
+	}
+	for _, dirEntry := range entries {
+		info, err := dirEntry.Info()
+		if err != nil || time.Since(info.ModTime()) < unusedEntryExpiry {
+			continue
+		}
+		if err = os.Remove(filepath.Join(c.dir, dirEntry.Name())); err != nil {
+			log.Debug(fmt.Sprintf("Failed to remove the HTTP cache entry %s: %s", dirEntry.Name(), err.Error()))
+		}
+	}
+}
+
+// response creates the response of the request from the cached entry.
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,141 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const etag = `"a1b2c3"`
+
+// newMetadataServer serves a response with an ETag, and counts the full and the not modified responses.
+func newMetadataServer(t *testing.T) (server *httptest.Server, full, notModified *atomic.Int32) {
+	full, notModified = new(atomic.Int32), new(atomic.Int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"repo":"` + r.Header.Get("Authorization") + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return
+}
+
+func get(t *testing.T, client *http.Client, url, authorization string) string {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", authorization)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCacheRevalidatesResponses(t *testing.T) {
+	server, full, notModified := newMetadataServer(t)
+	dir := t.TempDir()
+	client := &http.Client{Transport: NewCache(dir, 0).Transport(http.DefaultTransport)}
+	url := server.URL + "/artifactory/api/storage/generic-local/a.zip"
+
+	assert.Equal(t, `{"repo":"Bearer 1/artifactory/api/storage/generic-local/a.zip"}`, get(t, client, url, "Bearer 1"))
+	assert.Equal(t, `{"repo":"Bearer 1/artifactory/api/storage/generic-local/a.zip"}`, get(t, client, url, "Bearer 1"))
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+
+	// The cache is shared by the successive commands.
+	otherClient := &http.Client{Transport: NewCache(dir, 0).Transport(http.DefaultTransport)}
+	assert.Equal(t, `{"repo":"Bearer 1/artifactory/api/storage/generic-local/a.zip"}`, get(t, otherClient, url, "Bearer 1"))
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, int32(2), notModified.Load())
+
+	// The responses aren't shared between users.
+	assert.Equal(t, `{"repo":"Bearer 2/artifactory/api/storage/generic-local/a.zip"}`, get(t, client, url, "Bearer 2"))
+	assert.Equal(t, int32(2), full.Load())
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	server, full, notModified := newMetadataServer(t)
+	client := &http.Client{Transport: NewCache(t.TempDir(), time.Hour).Transport(http.DefaultTransport)}
+	url := server.URL + "/artifactory/api/repositories/maven-local"
+	get(t, client, url, "Bearer 1")
+	get(t, client, url, "Bearer 1")
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, int32(0), notModified.Load())
+}
+
+func TestCacheSkipsOtherRequests(t *testing.T) {
+	server, full, notModified := newMetadataServer(t)
+	client := &http.Client{Transport: NewCache(t.TempDir(), time.Hour).Transport(http.DefaultTransport)}
+	url := server.URL + "/artifactory/generic-local/a.zip"
+	get(t, client, url, "Bearer 1")
+	get(t, client, url, "Bearer 1")
+	assert.Equal(t, int32(2), full.Load())
+	assert.Equal(t, int32(0), notModified.Load())
+}
+
+func TestStart(t *testing.T) {
+	server, full, notModified := newMetadataServer(t)
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+
+	// The cache is disabled by default.
+	stop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	assert.NoError(t, stop())
+
+	t.Setenv(CacheEnv, "true")
+	stop, err = Start(serverDetails)
+	require.NoError(t, err)
+	assert.NotEqual(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	get(t, http.DefaultClient, serverDetails.ArtifactoryUrl+"api/build/my-build", "Bearer 1")
+	get(t, http.DefaultClient, serverDetails.ArtifactoryUrl+"api/build/my-build", "Bearer 1")
+	assert.NoError(t, stop())
+	assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+
+	t.Setenv(MaxAgeEnv, "soon")
+	_, err = Start(serverDetails)
+	assert.ErrorContains(t, err, MaxAgeEnv)
+}
+
+func TestStartBehindOtherProxies(t *testing.T) {
+	server, full, notModified := newMetadataServer(t)
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv(CacheEnv, "true")
+	t.Setenv(MaxAgeEnv, "3600")
+
+	// The proxies of the successive commands listen on other ports, which shouldn't change the cached responses.
+	for range 2 {
+		serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+		outer, err := serverproxy.Start(serverDetails, "outer", func(upstream http.RoundTripper) http.RoundTripper { return upstream })
+		require.NoError(t, err)
+		stop, err := Start(serverDetails)
+		require.NoError(t, err)
+		get(t, http.DefaultClient, serverDetails.ArtifactoryUrl+"api/build/my-build", "Bearer 1")
+		assert.NoError(t, stop())
+		assert.NoError(t, outer.Close())
+	}
+	assert.Equal(t, int32(1), full.Load())
+	assert.Equal(t, int32(0), notModified.Load())
+}
@@ -0,0 +1,126 @@
+package jobsummary
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// JobSummaryFileEnv is the path of a Markdown file, to which the commands append a summary of their results.
+// The file has the format of GitHub Actions job summaries, so setting it to $GITHUB_STEP_SUMMARY adds the
+// summaries to the job summary of the workflow. Other CI systems can publish the file as a build artifact.
+const JobSummaryFileEnv = "JFROG_CLI_JOB_SUMMARY_FILE"
+
+// The maximum number of artifacts listed in the table of a single command.
+const maxArtifactRows = 100
+
+func IsEnabled() bool {
+	return os.Getenv(JobSummaryFileEnv) != ""
+}
+
+// RecordTransferDetails appends a table of the files of the transfer details reader, and resets the reader.
+// Failures to write the summary are logged, and don't fail the command.
+func RecordTransferDetails(title string, transferDetailsReader *content.ContentReader) {
+	if !IsEnabled() || transferDetailsReader == nil {
+		return
+	}
+	var rows []string
+	total := 0
+	for item := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		total++
+		if total <= maxArtifactRows {
+			rows = append(rows, fmt.Sprintf("| %s | %s |", artifactLink(item), escapeCell(item.SourcePath)))
+		}
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	if err != nil {
+		log.Warn("Failed to read the transferred files for the job summary:", err.Error())
+		return
+	}
+	if total == 0 {
+		return
+	}
+	markdown := []string{"### " + title, "", "| Artifact | Source |", "| --- | --- |"}
+	markdown = append(markdown, rows...)
+	if total > maxArtifactRows {
+		markdown = append(markdown, "", fmt.Sprintf("_%d more artifacts are not listed._", total-maxArtifactRows))
+	}
+	appendMarkdown(markdown...)
+}
+
+// RecordBuildInfo appends a link to a published build-info, and the project it was published to, if any.
+func RecordBuildInfo(buildName, buildNumber, project, buildLink string) {
+	if !IsEnabled() {
+		return
+	}
+	build := escapeCell(buildName + " " + buildNumber)
+	if buildLink != "" {
+		build = fmt.Sprintf("[%s](%s)", build, buildLink)
+	}
+	if project != "" {
+		build += " (project: " + escapeCell(project) + ")"
+	}
+	appendMarkdown("### Build-info published", "", "📦 "+build)
+}
+
+// RecordScanGate appends the result of an Xray scan, which gates the deployment of the scanned files.
+// A nil scanErr means that the scan passed and the files were allowed to be deployed.
+func RecordScanGate(subject string, scanErr error) {
+	if !IsEnabled() {
+		return
+	}
+	result := "✅ Passed - the artifacts were allowed to be deployed."
+	if scanErr != nil {
+		result = "❌ Failed - the artifacts were not deployed: " + escapeCell(scanErr.Error())
+	}
+	appendMarkdown("### Xray scan of "+escapeCell(subject), "", result)
+}
+
+// RecordBuildScan appends a link to the Build Scan published by a Gradle build.
+func RecordBuildScan(buildScanUrl string) {
+	if !IsEnabled() {
+		return
+	}
+	appendMarkdown("### Gradle Build Scan", "", fmt.Sprintf("🔎 [%s](%s)", escapeCell(buildScanUrl), buildScanUrl))
+}
+
+func artifactLink(item *clientUtils.FileTransferDetails) string {
+	if item.RtUrl == "" {
+		return escapeCell(item.TargetPath)
+	}
+	rtUrl := clientUtils.AddTrailingSlashIfNeeded(item.RtUrl)
+	targetPath := strings.TrimPrefix(item.TargetPath, rtUrl)
+	return fmt.Sprintf("[%s](%s%s)", escapeCell(targetPath), rtUrl, targetPath)
+}
+
+// escapeCell escapes the characters which break the layout of a table cell.
+func escapeCell(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ", "\r", "").Replace(value)
+}
+
+func appendMarkdown(lines ...string) {
+	path := os.Getenv(JobSummaryFileEnv)
+	if err := appendToFile(path, strings.Join(lines, "\n")+"\n\n"); err != nil {
+		log.Warn("Failed to write the job summary to", path+":", err.Error())
+	}
+}
+
+func appendToFile(path, markdown string) (err error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	_, err = file.WriteString(markdown)
+	return errorutils.CheckError(err)
+}
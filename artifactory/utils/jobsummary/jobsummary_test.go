@@ -0,0 +1,100 @@
+package jobsummary
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTransferDetailsReader(t *testing.T, items ...clientUtils.FileTransferDetails) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, item := range items {
+		writer.Write(item)
+	}
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	t.Cleanup(func() { assert.NoError(t, reader.Close()) })
+	return reader
+}
+
+func TestRecordJobSummary(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(summaryPath, []byte("# Existing summary\n\n"), 0644))
+	t.Setenv(JobSummaryFileEnv, summaryPath)
+
+	reader := createTransferDetailsReader(t,
+		clientUtils.FileTransferDetails{SourcePath: "build/a.jar", TargetPath: "libs-local/a.jar", RtUrl: "https://acme.jfrog.io/artifactory/"},
+		clientUtils.FileTransferDetails{SourcePath: "build/b|c.jar", TargetPath: "libs-local/b|c.jar"},
+	)
+	RecordTransferDetails("Files uploaded to Artifactory", reader)
+	RecordBuildInfo("my-build", "7", "proj", "https://acme.jfrog.io/ui/builds/my-build/7")
+	RecordScanGate("a.jar", nil)
+	RecordScanGate("b.jar", errors.New("violations were found"))
+	RecordBuildScan("https://gradle.com/s/abc123")
+
+	summary, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# Existing summary
+
+### Files uploaded to Artifactory
+
+| Artifact | Source |
+| --- | --- |
+| [libs-local/a.jar](https://acme.jfrog.io/artifactory/libs-local/a.jar) | build/a.jar |
+| libs-local/b\|c.jar | build/b\|c.jar |
+
+### Build-info published
+
+📦 [my-build 7](https://acme.jfrog.io/ui/builds/my-build/7) (project: proj)
+
+### Xray scan of a.jar
+
+✅ Passed - the artifacts were allowed to be deployed.
+
+### Xray scan of b.jar
+
+❌ Failed - the artifacts were not deployed: violations were found
+
+### Gradle Build Scan
+
+🔎 [https://gradle.com/s/abc123](https://gradle.com/s/abc123)
+
+`, string(summary))
+
+	// The reader is reset, so that it can be used by the detailed summary.
+	count := 0
+	for item := new(clientUtils.FileTransferDetails); reader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestRecordTransferDetailsMaxRows(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv(JobSummaryFileEnv, summaryPath)
+	var items []clientUtils.FileTransferDetails
+	for i := 0; i < maxArtifactRows+3; i++ {
+		items = append(items, clientUtils.FileTransferDetails{SourcePath: "file", TargetPath: "repo/file"})
+	}
+	RecordTransferDetails("Files uploaded to Artifactory", createTransferDetailsReader(t, items...))
+	summary, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "_3 more artifacts are not listed._")
+}
+
+func TestJobSummaryDisabled(t *testing.T) {
+	t.Setenv(JobSummaryFileEnv, "")
+	assert.False(t, IsEnabled())
+	// Nothing is written, and the reader isn't read.
+	RecordTransferDetails("Files uploaded to Artifactory", nil)
+	RecordBuildInfo("my-build", "7", "", "")
+	RecordScanGate("a.jar", nil)
+	RecordBuildScan("https://gradle.com/s/abc123")
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The layouts of a point in time. Timestamps without a time zone are in UTC.
+var pointInTimeLayouts = []string{time.RFC3339Nano, IsoDateTimeLayout, "2006-01-02T15:04:05", "2006-01-02"}
+
+// ParsePointInTime parses a point in time, in the RFC 3339 format (for example 2024-05-01T12:00:00Z), or a date (for example 2024-05-01),
+// which stands for the beginning of the day.
+func ParsePointInTime(pointInTime string) (time.Time, error) {
+	for _, layout := range pointInTimeLayouts {
+		if parsed, err := time.Parse(layout, pointInTime); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, errorutils.CheckErrorf("invalid point in time '%s'. Use the RFC 3339 format, for example 2024-05-01T12:00:00Z, or a date, for example 2024-05-01", pointInTime)
+}
+
+// ApplyPointInTime limits the artifacts the file group matches to the artifacts created and last modified before the point in time.
+// Artifactory keeps only the latest content of each artifact, so the artifacts overwritten after the point in time aren't matched,
+// rather than matched with content which didn't exist at that time. The artifacts deleted after it can't be matched either.
+func ApplyPointInTime(file *spec.File, pointInTime time.Time) error {
+	timestamp := pointInTime.UTC().Format("2006-01-02T15:04:05.000Z")
+	criteria := fmt.Sprintf(`{"created":{"$lt":"%s"},"modified":{"$lt":"%s"}}`, timestamp, timestamp)
+	return addAqlCriteria(file, criteria, "a point in time")
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePointInTime(t *testing.T) {
+	testCases := map[string]time.Time{
+		"2024-05-01T12:30:00Z":          time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		"2024-05-01T14:30:00.500+02:00": time.Date(2024, 5, 1, 12, 30, 0, 500000000, time.UTC),
+		"2024-05-01T12:30:00.000+0000":  time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		"2024-05-01T12:30:00":           time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		"2024-05-01":                    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for value, expected := range testCases {
+		parsed, err := ParsePointInTime(value)
+		require.NoError(t, err, value)
+		assert.True(t, expected.Equal(parsed), value)
+	}
+	_, err := ParsePointInTime("yesterday")
+	assert.Error(t, err)
+}
+
+func TestApplyPointInTime(t *testing.T) {
+	pointInTime := time.Date(2024, 5, 1, 14, 30, 0, 0, time.FixedZone("", 2*60*60))
+	file := &spec.File{Aql: servicesUtils.Aql{ItemsFind: `{"repo":"generic-local"}`}}
+	require.NoError(t, ApplyPointInTime(file, pointInTime))
+	assert.Equal(t, `{"$and":[{"repo":"generic-local"},{"created":{"$lt":"2024-05-01T12:30:00.000Z"},"modified":{"$lt":"2024-05-01T12:30:00.000Z"}}]}`, file.Aql.ItemsFind)
+
+	// Combined with a properties expression.
+	file = &spec.File{Aql: servicesUtils.Aql{ItemsFind: `{"repo":"generic-local"}`}}
+	require.NoError(t, ApplyPropsExpr(file, "stage=prod"))
+	require.NoError(t, ApplyPointInTime(file, pointInTime))
+	assert.Equal(t, `{"$and":[{"$and":[{"repo":"generic-local"},{"@stage":{"$eq":"prod"}}]},{"created":{"$lt":"2024-05-01T12:30:00.000Z"},"modified":{"$lt":"2024-05-01T12:30:00.000Z"}}]}`, file.Aql.ItemsFind)
+
+	assert.Error(t, ApplyPointInTime(&spec.File{Bundle: "bundle/1"}, pointInTime))
+}
+
+func TestApplyPointInTimeSkipsOverwrittenArtifacts(t *testing.T) {
+	pointInTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	file := &spec.File{Aql: servicesUtils.Aql{ItemsFind: `{"repo":"generic-local"}`}}
+	require.NoError(t, ApplyPointInTime(file, pointInTime))
+	var query struct {
+		And []json.RawMessage `json:"$and"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(file.Aql.ItemsFind), &query))
+	require.Len(t, query.And, 2)
+	var criteria map[string]map[string]string
+	require.NoError(t, json.Unmarshal(query.And[1], &criteria))
+
+	// Evaluates the $lt criteria of the point in time, as AQL does, on the timestamps of an artifact.
+	matches := func(timestamps map[string]time.Time) bool {
+		for field, condition := range criteria {
+			bound, err := time.Parse(time.RFC3339Nano, condition["$lt"])
+			require.NoError(t, err)
+			if !timestamps[field].Before(bound) {
+				return false
+			}
+		}
+		return true
+	}
+	before, after := pointInTime.Add(-time.Hour), pointInTime.Add(time.Hour)
+	assert.True(t, matches(map[string]time.Time{"created": before, "modified": before}))
+	assert.False(t, matches(map[string]time.Time{"created": after, "modified": after}))
+	// An artifact which existed at the point in time, but was overwritten after it, doesn't have its content of that time anymore.
+	assert.False(t, matches(map[string]time.Time{"created": before, "modified": after}))
+}
@@ -0,0 +1,407 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The spec file field of the boolean properties expression, which filters the artifacts of the file group.
+const PropsExprSpecField = "propsExpr"
+
+// PropsExpr is a boolean expression on the properties of the artifacts, such as "stage=prod AND NOT deprecated AND buildNumber>100".
+// The supported conditions are:
+//   - key: the artifact has the property.
+//   - key=value and key!=value, where the value may include the '*' and '?' wildcards.
+//   - key>value, key>=value, key<value and key<=value. Numeric values are compared as numbers by Artifactory.
+//
+// The conditions are combined by AND, OR and NOT (or &&, || and !), and grouped by parentheses. NOT binds stronger than AND,
+// which binds stronger than OR. Values which include spaces or operator characters should be quoted.
+type PropsExpr interface {
+	// toAql returns the AQL criteria of the expression. If negate is true, the criteria match the artifacts the expression doesn't match.
+	toAql(negate bool) string
+}
+
+type propsExprKind int
+
+const (
+	andExpr propsExprKind = iota
+	orExpr
+)
+
+type compoundPropsExpr struct {
+	kind     propsExprKind
+	operands []PropsExpr
+}
+
+type notPropsExpr struct {
+	operand PropsExpr
+}
+
+// An empty operator matches the artifacts which have the property.
+type conditionPropsExpr struct {
+	key      string
+	operator string
+	value    string
+}
+
+// ParsePropsExpr parses a boolean properties expression.
+func ParsePropsExpr(expr string) (PropsExpr, error) {
+	tokens, err := tokenizePropsExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errorutils.CheckErrorf("the properties expression is empty")
+	}
+	parser := &propsExprParser{expr: expr, tokens: tokens}
+	result, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.done() {
+		return nil, parser.errorf("unexpected '%s'", parser.peek().text)
+	}
+	return result, nil
+}
+
+// PropsExprToAql returns the AQL criteria of the expression, which can be combined with the other criteria of an items.find() query.
+func PropsExprToAql(expr PropsExpr) string {
+	return expr.toAql(false)
+}
+
+// ApplyPropsExpr limits the artifacts the file group matches to the artifacts matching the properties expression.
+func ApplyPropsExpr(file *spec.File, expr string) error {
+	propsExpr, err := ParsePropsExpr(expr)
+	if err != nil {
+		return err
+	}
+	return addAqlCriteria(file, PropsExprToAql(propsExpr), "a properties expression")
+}
+
+// addAqlCriteria limits the artifacts the file group matches by the AQL criteria. The file group is converted to an AQL file group,
+// whose query combines the criteria with the pattern or the AQL of the group. Since the pattern is no longer used for the search,
+// its placeholders can't be used by the target. filterName describes the criteria in the errors.
+func addAqlCriteria(file *spec.File, criteria, filterName string) error {
+	if file.Build != "" || file.Bundle != "" {
+		return errorutils.CheckErrorf("%s can't be used with build or bundle", filterName)
+	}
+	query := file.Aql.ItemsFind
+	if query == "" {
+		if file.Pattern == "" {
+			return errorutils.CheckErrorf("%s requires a pattern or aql", filterName)
+		}
+		params, err := file.ToCommonParams()
+		if err != nil {
+			return err
+		}
+		if query, err = servicesUtils.CreateAqlBodyForSpecWithPattern(params); err != nil {
+			return err
+		}
+	}
+	file.Aql.ItemsFind = `{"$and":[` + query + `,` + criteria + `]}`
+	return nil
+}
+
+// ReadSpecPropsExprs returns the properties expression of each file group of the spec file. The spec vars are replaced
+// as they are when the spec file is read.
+func ReadSpecPropsExprs(specFilePath string, specVars map[string]string) ([]string, error) {
+	content, err := fileutils.ReadFile(specFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(specVars) > 0 {
+		content = coreutils.ReplaceVars(content, specVars)
+	}
+	var specFiles struct {
+		Files []map[string]json.RawMessage `json:"files"`
+	}
+	if err = json.Unmarshal(content, &specFiles); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	exprs := make([]string, len(specFiles.Files))
+	for i, file := range specFiles.Files {
+		if rawExpr, ok := file[PropsExprSpecField]; ok {
+			if err = json.Unmarshal(rawExpr, &exprs[i]); err != nil {
+				return nil, errorutils.CheckErrorf("the %s of a spec file group must be a string: %s", PropsExprSpecField, err.Error())
+			}
+		}
+	}
+	return exprs, nil
+}
+
+func (e *compoundPropsExpr) toAql(negate bool) string {
+	// By De Morgan's laws, the negation of AND is the OR of the negated operands, and vice versa.
+	operator := "$and"
+	if (e.kind == orExpr) != negate {
+		operator = "$or"
+	}
+	criteria := make([]string, 0, len(e.operands))
+	for _, operand := range e.operands {
+		criteria = append(criteria, operand.toAql(negate))
+	}
+	return fmt.Sprintf(`{"%s":[%s]}`, operator, strings.Join(criteria, ","))
+}
+
+func (e *notPropsExpr) toAql(negate bool) string {
+	return e.operand.toAql(!negate)
+}
+
+func (e *conditionPropsExpr) toAql(negate bool) string {
+	key := "@" + e.key
+	switch e.operator {
+	case "":
+		if negate {
+			return aqlCriterion(key, "$nmatch", `"*"`)
+		}
+		return aqlCriterion(key, "$match", `"*"`)
+	case "=", "!=":
+		equals := (e.operator == "=") != negate
+		hasWildcards := strings.ContainsAny(e.value, "*?")
+		switch {
+		case equals && hasWildcards:
+			return aqlCriterion(key, "$match", aqlString(e.value))
+		case equals:
+			return aqlCriterion(key, "$eq", aqlString(e.value))
+		case hasWildcards:
+			return aqlCriterion(key, "$nmatch", aqlString(e.value))
+		default:
+			return aqlCriterion(key, "$ne", aqlString(e.value))
+		}
+	}
+	operator := map[string]string{">": "$gt", ">=": "$gte", "<": "$lt", "<=": "$lte"}[e.operator]
+	if negate {
+		operator = map[string]string{"$gt": "$lte", "$gte": "$lt", "$lt": "$gte", "$lte": "$gt"}[operator]
+		// The artifacts without the property don't match the comparison, so they match its negation.
+		return fmt.Sprintf(`{"$or":[%s,%s]}`, aqlCriterion(key, operator, aqlComparedValue(e.value)), aqlCriterion(key, "$nmatch", `"*"`))
+	}
+	return aqlCriterion(key, operator, aqlComparedValue(e.value))
+}
+
+func aqlCriterion(field, operator, value string) string {
+	return fmt.Sprintf(`{%s:{"%s":%s}}`, aqlString(field), operator, value)
+}
+
+func aqlString(value string) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+var numberPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// Numbers are compared as numbers, and other values as strings.
+func aqlComparedValue(value string) string {
+	if numberPattern.MatchString(value) {
+		return value
+	}
+	return aqlString(value)
+}
+
+type propsExprTokenKind int
+
+const (
+	wordToken propsExprTokenKind = iota
+	operatorToken
+	andToken
+	orToken
+	notToken
+	openToken
+	closeToken
+)
+
+type propsExprToken struct {
+	kind propsExprTokenKind
+	text string
+	// The position of the token in the expression, reported by the parsing errors.
+	pos int
+}
+
+func tokenizePropsExpr(expr string) ([]propsExprToken, error) {
+	var tokens []propsExprToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, propsExprToken{openToken, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, propsExprToken{closeToken, ")", i})
+			i++
+		case strings.HasPrefix(string(runes[i:]), "&&"):
+			tokens = append(tokens, propsExprToken{andToken, "&&", i})
+			i += 2
+		case strings.HasPrefix(string(runes[i:]), "||"):
+			tokens = append(tokens, propsExprToken{orToken, "||", i})
+			i += 2
+		case strings.ContainsRune("=!<>", r):
+			operator := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' {
+				operator += "="
+			}
+			kind := operatorToken
+			if operator == "!" {
+				kind = notToken
+			}
+			tokens = append(tokens, propsExprToken{kind, operator, i})
+			i += len(operator)
+		case r == '"' || r == '\'':
+			value, end, err := readQuotedPropsExprValue(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, propsExprToken{wordToken, value, i})
+			i = end
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()=!<>&|\"'", runes[i]) {
+				i++
+			}
+			if start == i {
+				return nil, errorutils.CheckErrorf("invalid properties expression '%s': unexpected '%c' at position %d", expr, r, start)
+			}
+			word := string(runes[start:i])
+			kind := wordToken
+			switch strings.ToUpper(word) {
+			case "AND":
+				kind = andToken
+			case "OR":
+				kind = orToken
+			case "NOT":
+				kind = notToken
+			}
+			tokens = append(tokens, propsExprToken{kind, word, start})
+		}
+	}
+	return tokens, nil
+}
+
+// readQuotedPropsExprValue reads the quoted value which starts at start, and returns it with the position after its closing quote.
+// A backslash escapes the next character.
+func readQuotedPropsExprValue(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var value strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				value.WriteRune(runes[i])
+			}
+		case quote:
+			return value.String(), i + 1, nil
+		default:
+			value.WriteRune(runes[i])
+		}
+	}
+	return "", 0, errorutils.CheckErrorf("invalid properties expression '%s': unterminated quote at position %d", string(runes), start)
+}
+
+type propsExprParser struct {
+	expr   string
+	tokens []propsExprToken
+	next   int
+}
+
+func (p *propsExprParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *propsExprParser) peek() propsExprToken {
+	return p.tokens[p.next]
+}
+
+func (p *propsExprParser) accept(kind propsExprTokenKind) bool {
+	if !p.done() && p.peek().kind == kind {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *propsExprParser) errorf(format string, args ...any) error {
+	position := len([]rune(p.expr))
+	if !p.done() {
+		position = p.peek().pos
+	}
+	return errorutils.CheckErrorf("invalid properties expression '%s': %s at position %d", p.expr, fmt.Sprintf(format, args...), position)
+}
+
+func (p *propsExprParser) parseOr() (PropsExpr, error) {
+	return p.parseCompound(orExpr, orToken, p.parseAnd)
+}
+
+func (p *propsExprParser) parseAnd() (PropsExpr, error) {
+	return p.parseCompound(andExpr, andToken, p.parseNot)
+}
+
+func (p *propsExprParser) parseCompound(kind propsExprKind, separator propsExprTokenKind, parseOperand func() (PropsExpr, error)) (PropsExpr, error) {
+	operand, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	operands := []PropsExpr{operand}
+	for p.accept(separator) {
+		if operand, err = parseOperand(); err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &compoundPropsExpr{kind: kind, operands: operands}, nil
+}
+
+func (p *propsExprParser) parseNot() (PropsExpr, error) {
+	if p.accept(notToken) {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notPropsExpr{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *propsExprParser) parsePrimary() (PropsExpr, error) {
+	if p.done() {
+		return nil, p.errorf("missing condition")
+	}
+	if p.accept(openToken) {
+		result, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(closeToken) {
+			return nil, p.errorf("missing ')'")
+		}
+		return result, nil
+	}
+	if p.peek().kind != wordToken {
+		return nil, p.errorf("unexpected '%s'", p.peek().text)
+	}
+	condition := &conditionPropsExpr{key: p.peek().text}
+	p.next++
+	if p.done() || p.peek().kind != operatorToken {
+		return condition, nil
+	}
+	condition.operator = p.peek().text
+	p.next++
+	if p.done() || p.peek().kind != wordToken {
+		return nil, p.errorf("missing the value of '%s'", condition.key)
+	}
+	condition.value = p.peek().text
+	p.next++
+	return condition, nil
+}
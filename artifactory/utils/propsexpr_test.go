@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropsExprToAql(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{`stage=prod`, `{"@stage":{"$eq":"prod"}}`},
+		{`deprecated`, `{"@deprecated":{"$match":"*"}}`},
+		{`os!=linux*`, `{"@os":{"$nmatch":"linux*"}}`},
+		{`buildNumber>100`, `{"@buildNumber":{"$gt":100}}`},
+		{`version<="1.0 beta"`, `{"@version":{"$lte":"1.0 beta"}}`},
+		{
+			`stage=prod AND NOT deprecated AND buildNumber>100`,
+			`{"$and":[{"@stage":{"$eq":"prod"}},{"@deprecated":{"$nmatch":"*"}},{"@buildNumber":{"$gt":100}}]}`,
+		},
+		// AND binds stronger than OR.
+		{`a=1 || b=2 && c=3`, `{"$or":[{"@a":{"$eq":"1"}},{"$and":[{"@b":{"$eq":"2"}},{"@c":{"$eq":"3"}}]}]}`},
+		// The negation is pushed down to the conditions. The artifacts without the compared property match the negated comparison.
+		{
+			`!(stage=prod or buildNumber>=100)`,
+			`{"$and":[{"@stage":{"$ne":"prod"}},{"$or":[{"@buildNumber":{"$lt":100}},{"@buildNumber":{"$nmatch":"*"}}]}]}`,
+		},
+		{`NOT NOT stage=prod`, `{"@stage":{"$eq":"prod"}}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expr, func(t *testing.T) {
+			expr, err := ParsePropsExpr(testCase.expr)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, PropsExprToAql(expr))
+		})
+	}
+}
+
+func TestParsePropsExprErrors(t *testing.T) {
+	for _, expr := range []string{``, `stage=`, `(stage=prod`, `stage=prod)`, `stage=prod AND`, `stage="prod`, `a==b`, `&`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParsePropsExpr(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApplyPropsExpr(t *testing.T) {
+	file := &spec.File{Pattern: "libs-release-local/org/app/*.jar", Recursive: "true"}
+	require.NoError(t, ApplyPropsExpr(file, "stage=prod"))
+	assert.True(t, strings.HasPrefix(file.Aql.ItemsFind, `{"$and":[{`))
+	assert.Contains(t, file.Aql.ItemsFind, `"repo":"libs-release-local"`)
+	assert.True(t, strings.HasSuffix(file.Aql.ItemsFind, `,{"@stage":{"$eq":"prod"}}]}`))
+
+	file = &spec.File{Aql: servicesUtils.Aql{ItemsFind: `{"repo":"generic-local"}`}}
+	require.NoError(t, ApplyPropsExpr(file, "deprecated"))
+	assert.Equal(t, `{"$and":[{"repo":"generic-local"},{"@deprecated":{"$match":"*"}}]}`, file.Aql.ItemsFind)
+
+	assert.Error(t, ApplyPropsExpr(&spec.File{Pattern: "generic-local/*", Build: "app/1"}, "stage=prod"))
+	assert.Error(t, ApplyPropsExpr(&spec.File{}, "stage=prod"))
+}
+
+func TestReadSpecPropsExprs(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(specFile, []byte(`{"files":[{"pattern":"a/*","propsExpr":"stage=${stage}"},{"pattern":"b/*"}]}`), 0644))
+	exprs, err := ReadSpecPropsExprs(specFile, map[string]string{"stage": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stage=prod", ""}, exprs)
+}
@@ -0,0 +1,252 @@
+// Package ratelimit handles the throttling of the servers. When a server responds with 429 Too Many Requests, all the requests
+// of the process toward that server are paused, for the time requested by its Retry-After header, or for a jittered exponential
+// backoff if it has none. The throttled requests are then retried, so that the worker pools of the commands don't keep loading
+// the server with immediate retries while it's throttling them.
+//
+// The handling is enabled by default, and is disabled by setting the RetriesEnv environment variable to 0. The traffic of
+// the commands is routed through a local proxy for it, while the URLs of the servers are pointed to the proxy. The URLs
+// which the commands print and log are resolved back to the URLs of the servers by serverproxy.OriginalUrl.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// RetriesEnv sets the number of times a throttled request is retried after the pause, which is defaultRetries if it isn't set.
+	// The throttling isn't handled if it's set to 0.
+	RetriesEnv     = "JFROG_CLI_RATE_LIMIT_RETRIES"
+	defaultRetries = 3
+
+	// The maximum number of bytes of a throttled response which are read, so that its connection can be reused.
+	maxDrainedBodySize = 64 * 1024
+	// The maximum size of a request body which can't be sent again, such as the body of a request forwarded by the proxy,
+	// which is buffered so that the request can be retried. Larger requests aren't retried by the transport, and are retried
+	// by the client once the pause is over.
+	maxBufferedBodySize = 4 * 1024 * 1024
+)
+
+var (
+	// The backoff after the first throttled response without a Retry-After header. It's doubled after each consecutive one.
+	baseBackoff = time.Second
+	maxBackoff  = time.Minute
+	// The maximum pause requested by a Retry-After header which is honored.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// The gates of the servers, by their hosts. They're shared by all the requests of the process.
+var (
+	gatesMutex sync.Mutex
+	gates      = make(map[string]*gate)
+)
+
+// The server details whose requests are rate limited already, so that nested commands don't retry the requests twice.
+var (
+	limitedMutex sync.Mutex
+	limited      = make(map[*config.ServerDetails]bool)
+)
+
+// Start makes the requests sent using the server details wait while the servers throttle the process, and retries the
+// throttled requests. The server details are modified in place, and restored by stop. Starting it again for server details
+// whose requests are rate limited already does nothing.
+func Start(serverDetails *config.ServerDetails) (stop func() error, err error) {
+	stop = func() error { return nil }
+	retries, err := getRetries()
+	if err != nil || retries == 0 {
+		return
+	}
+	limitedMutex.Lock()
+	defer limitedMutex.Unlock()
+	if limited[serverDetails] {
+		return
+	}
+	proxies, err := serverproxy.Start(serverDetails, "rate limit", func(next http.RoundTripper) http.RoundTripper {
+		return Transport(next, retries)
+	})
+	if err != nil {
+		return
+	}
+	limited[serverDetails] = true
+	return func() error {
+		limitedMutex.Lock()
+		delete(limited, serverDetails)
+		limitedMutex.Unlock()
+		return proxies.Close()
+	}, nil
+}
+
+func getRetries() (int, error) {
+	value := strings.TrimSpace(os.Getenv(RetriesEnv))
+	if value == "" {
+		return defaultRetries, nil
+	}
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative number, got '%s'", RetriesEnv, value)
+	}
+	return retries, nil
+}
+
+// Transport returns an http.RoundTripper which sends the requests through next, once the server of each request doesn't
+// throttle the process. A throttled request is retried up to retries times, if its body can be sent again or is small enough
+// to be buffered. Otherwise, the throttled response is returned, and the retry of the caller waits for the pause too.
+func Transport(next http.RoundTripper, retries int) http.RoundTripper {
+	return &rateLimitTransport{next: next, retries: retries}
+}
+
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	retries int
+}
+
+func (rlt *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, err := bufferBody(req)
+	if err != nil {
+		return nil, err
+	}
+	serverGate := getGate(req.URL.Host)
+	for attempt := 0; ; attempt++ {
+		if err := serverGate.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := rlt.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			serverGate.resume()
+			return resp, nil
+		}
+		pause := serverGate.throttle(parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()))
+		if attempt >= rlt.retries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, nil
+		}
+		log.Warn(fmt.Sprintf("The server throttled the request %s %s. Retrying in %s...", req.Method, serverproxy.OriginalUrl(req.URL.String()), pause.Round(time.Millisecond)))
+		_, _ = io.CopyN(io.Discard, resp.Body, maxDrainedBodySize)
+		_ = resp.Body.Close()
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// bufferBody returns a copy of the request whose body can be sent again, if the body of the request can't be, and isn't larger
+// than maxBufferedBodySize. The requests forwarded by the proxy are such requests.
+func bufferBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil || req.ContentLength < 0 || req.ContentLength > maxBufferedBodySize {
+		return req, nil
+	}
+	content, err := io.ReadAll(req.Body)
+	if closeErr := req.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	buffered := req.Clone(req.Context())
+	buffered.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	buffered.Body, _ = buffered.GetBody()
+	return buffered, nil
+}
+
+// parseRetryAfter returns the pause requested by a Retry-After header, which is a number of seconds or an HTTP date,
+// or 0 if the header isn't set or is invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	var pause time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		pause = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		pause = date.Sub(now)
+	}
+	return max(0, min(pause, maxRetryAfter))
+}
+
+func getGate(host string) *gate {
+	gatesMutex.Lock()
+	defer gatesMutex.Unlock()
+	serverGate, exists := gates[host]
+	if !exists {
+		serverGate = new(gate)
+		gates[host] = serverGate
+	}
+	return serverGate
+}
+
+// gate pauses the requests toward a server while it throttles the process.
+type gate struct {
+	mutex       sync.Mutex
+	pausedUntil time.Time
+	// The number of consecutive throttled responses without a Retry-After header, which sets the backoff of the next one.
+	backoffs int
+}
+
+// wait waits until the gate is open. The requests which waited are resumed at random times over a quarter of the pause,
+// rather than all at once, so that they don't get throttled again together.
+func (g *gate) wait(ctx context.Context) error {
+	for {
+		g.mutex.Lock()
+		pause := time.Until(g.pausedUntil)
+		g.mutex.Unlock()
+		if pause <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(pause + rand.N(pause/4+1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// throttle pauses the gate after a throttled response, for the pause requested by the server, or for the next backoff if
+// the server didn't request a pause. Returns the pause.
+func (g *gate) throttle(retryAfter time.Duration) time.Duration {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	pause := retryAfter
+	if pause <= 0 {
+		backoff := min(maxBackoff, baseBackoff<<min(g.backoffs, 16))
+		g.backoffs++
+		// Equal jitter: at least half of the backoff, so that the backoff still grows.
+		pause = backoff/2 + rand.N(backoff/2+1)
+	}
+	if pausedUntil := time.Now().Add(pause); pausedUntil.After(g.pausedUntil) {
+		g.pausedUntil = pausedUntil
+	}
+	return pause
+}
+
+// resume resets the backoff once the server responds without throttling.
+func (g *gate) resume() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.backoffs = 0
+}
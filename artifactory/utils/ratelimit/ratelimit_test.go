@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setPauses(t *testing.T, backoff, retryAfter time.Duration) {
+	previousBase, previousMax, previousRetryAfter := baseBackoff, maxBackoff, maxRetryAfter
+	baseBackoff, maxBackoff, maxRetryAfter = backoff, 4*backoff, retryAfter
+	t.Cleanup(func() { baseBackoff, maxBackoff, maxRetryAfter = previousBase, previousMax, previousRetryAfter })
+}
+
+// throttlingServer throttles the first throttled requests it receives, and records the times of the requests.
+func throttlingServer(t *testing.T, throttled int, retryAfter string) (server *httptest.Server, times func() []time.Time) {
+	var mutex sync.Mutex
+	var requestTimes []time.Time
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mutex.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		count := len(requestTimes)
+		mutex.Unlock()
+		if count <= throttled {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]time.Time{}, requestTimes...)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, maxRetryAfter, parseRetryAfter("3600", now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("", now))
+}
+
+func TestTransportHonorsRetryAfter(t *testing.T) {
+	setPauses(t, time.Millisecond, 50*time.Millisecond)
+	server, times := throttlingServer(t, 2, "1")
+	client := &http.Client{Transport: Transport(http.DefaultTransport, 3)}
+
+	resp, err := client.Post(server.URL+"/artifactory/api/search/aql", "text/plain", strings.NewReader("items.find()"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	// The body is sent again with the retries.
+	assert.Equal(t, "items.find()", string(body))
+	requestTimes := times()
+	require.Len(t, requestTimes, 3)
+	for i := 1; i < len(requestTimes); i++ {
+		assert.GreaterOrEqual(t, requestTimes[i].Sub(requestTimes[i-1]), maxRetryAfter)
+	}
+}
+
+func TestTransportPausesAllRequests(t *testing.T) {
+	setPauses(t, 40*time.Millisecond, time.Minute)
+	server, times := throttlingServer(t, 1, "")
+	transport := Transport(http.DefaultTransport, 0)
+	// Without retries, the throttled response is returned, and the next requests toward the server wait for the backoff.
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/artifactory/libs-local/a.jar", io.NopCloser(strings.NewReader("content")))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/artifactory/api/system/version", nil)
+			assert.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			if assert.NoError(t, err) {
+				assert.NoError(t, resp.Body.Close())
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	requestTimes := times()
+	require.Len(t, requestTimes, 4)
+	for _, requestTime := range requestTimes[1:] {
+		// The backoff is at least half of the base backoff.
+		assert.GreaterOrEqual(t, requestTime.Sub(requestTimes[0]), baseBackoff/2)
+	}
+}
+
+func TestGetRetries(t *testing.T) {
+	t.Setenv(RetriesEnv, "")
+	retries, err := getRetries()
+	require.NoError(t, err)
+	assert.Equal(t, defaultRetries, retries)
+	t.Setenv(RetriesEnv, "0")
+	retries, err = getRetries()
+	require.NoError(t, err)
+	assert.Zero(t, retries)
+	t.Setenv(RetriesEnv, "5")
+	retries, err = getRetries()
+	require.NoError(t, err)
+	assert.Equal(t, 5, retries)
+	t.Setenv(RetriesEnv, "-1")
+	_, err = getRetries()
+	assert.ErrorContains(t, err, RetriesEnv)
+}
+
+func TestStartRetriesProxiedRequests(t *testing.T) {
+	setPauses(t, time.Millisecond, 10*time.Millisecond)
+	server, times := throttlingServer(t, 1, "")
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+
+	t.Setenv(RetriesEnv, "0")
+	stop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	require.NoError(t, stop())
+
+	t.Setenv(RetriesEnv, "")
+	stop, err = Start(serverDetails)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, stop())
+		assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	}()
+	// The requests of a nested command aren't routed through a second proxy.
+	proxiedUrl := serverDetails.ArtifactoryUrl
+	nestedStop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assert.Equal(t, proxiedUrl, serverDetails.ArtifactoryUrl)
+	require.NoError(t, nestedStop())
+	assert.Equal(t, proxiedUrl, serverDetails.ArtifactoryUrl)
+	req, err := http.NewRequest(http.MethodPut, serverDetails.ArtifactoryUrl+"libs-local/a.jar", strings.NewReader("content"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	// The body of the request forwarded by the proxy is buffered, so the throttled upload is retried by the proxy.
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "content", string(body))
+	assert.Len(t, times(), 2)
+}
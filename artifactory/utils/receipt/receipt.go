@@ -0,0 +1,172 @@
+// Package receipt records the operations which a command performed on the artifacts in Artifactory, with the identifiers the server
+// returned for them, and writes them to a receipt file. Downstream jobs can use the receipt to verify and reference the exact
+// operations, by the request IDs of the server, the trace IDs of the requests, and the checksums of the artifacts.
+package receipt
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The version of the receipt schema.
+const receiptVersion = 1
+
+// The operations which are recorded in the receipts.
+const (
+	Upload = "upload"
+	Copy   = "copy"
+	Move   = "move"
+	Delete = "delete"
+)
+
+// Receipt is the content of a receipt file.
+type Receipt struct {
+	Version    int    `json:"version"`
+	Command    string `json:"command"`
+	Server     string `json:"server"`
+	StartedAt  string `json:"startedAt"`
+	FinishedAt string `json:"finishedAt"`
+	Succeeded  int    `json:"succeeded"`
+	Failed     int    `json:"failed"`
+	Items      []Item `json:"items"`
+}
+
+// Item is an operation on an artifact, or on a folder, in Artifactory.
+type Item struct {
+	Operation string `json:"operation"`
+	// The source path of a copy or a move, in the form of repo/path.
+	Source string `json:"source,omitempty"`
+	// The path of the artifact which was uploaded, copied, moved or deleted, in the form of repo/path.
+	Path string `json:"path"`
+	// The HTTP status returned by the server, or 0 if no response was received.
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// The ID which the server returned for the request.
+	RequestId string `json:"requestId,omitempty"`
+	// The ID of the W3C trace of the request, if the trace context was propagated.
+	TraceId string `json:"traceId,omitempty"`
+	// The creation time and the sha256 of the artifact, as returned by the server. The created time of an upload is the time of the
+	// new artifact, and of other operations, the time of the source artifact.
+	Created string `json:"created,omitempty"`
+	Sha256  string `json:"sha256,omitempty"`
+	Time    string `json:"time"`
+}
+
+func (i *Item) Succeeded() bool {
+	return i.Status >= 200 && i.Status < 300
+}
+
+// Recording records the operations of a command to a receipt file.
+// The URLs of the server details are pointed to local reverse proxies, which record the operations and forward the traffic
+// to the original servers, as the HAR capture does.
+type Recording struct {
+	receiptPath string
+	recorder    *Recorder
+	proxies     *serverproxy.Proxies
+}
+
+// Start starts recording the operations of the command which are sent using the server details, to the receipt file at receiptPath.
+// If receiptPath is empty, the operations are only recorded, and no receipt file is written. The receipt references the server by
+// artifactoryUrl, since the URLs of the server details may already point to the proxies of other options.
+// The server details are modified in place, and restored by Close.
+func Start(serverDetails *config.ServerDetails, artifactoryUrl, command, receiptPath string) (recording *Recording, err error) {
+	recorder, err := NewRecorder(command, artifactoryUrl)
+	if err != nil {
+		return nil, err
+	}
+	recording = &Recording{receiptPath: receiptPath, recorder: recorder}
+	if recording.proxies, err = serverproxy.Start(serverDetails, "receipt", recorder.Transport); err != nil {
+		return nil, err
+	}
+	log.Debug("Recording the operations of the command to the receipt", receiptPath)
+	return recording, nil
+}
+
+// Receipt returns the receipt of the operations which were recorded so far.
+func (r *Recording) Receipt() *Receipt {
+	return r.recorder.Receipt()
+}
+
+// Close stops recording, restores the server details and writes the receipt file.
+func (r *Recording) Close() error {
+	err := r.proxies.Close()
+	if r.receiptPath == "" {
+		return err
+	}
+	if writeErr := r.recorder.WriteFile(r.receiptPath); writeErr != nil {
+		return errors.Join(err, writeErr)
+	}
+	log.Info("The receipt of the operations was written to", r.receiptPath)
+	return err
+}
+
+// WriteFile writes the recorded operations to the receipt file. The receipt is written to a temporary file, which replaces the
+// receipt file once it's synced to the disk, so that an existing receipt is never left partially written.
+func (r *Recorder) WriteFile(receiptPath string) (err error) {
+	content, err := json.MarshalIndent(r.Receipt(), "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(receiptPath), "."+filepath.Base(receiptPath)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckErrorf("failed to create the receipt file: %s", err.Error())
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(tempFile.Name())))
+		}
+	}()
+	_, err = tempFile.Write(append(content, '\n'))
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if err = errors.Join(err, tempFile.Close()); err != nil {
+		return errorutils.CheckErrorf("failed to write the receipt file: %s", err.Error())
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), receiptPath))
+}
+
+// Receipt returns the receipt of the operations which were recorded so far.
+func (r *Recorder) Receipt() *Receipt {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	receipt := &Receipt{
+		Version:    receiptVersion,
+		Command:    r.command,
+		Server:     r.server,
+		StartedAt:  r.startedAt.Format(time.RFC3339Nano),
+		FinishedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		Items:      append([]Item{}, r.items...),
+	}
+	for _, item := range r.items {
+		if item.Succeeded() {
+			receipt.Succeeded++
+		} else {
+			receipt.Failed++
+		}
+	}
+	return receipt
+}
+
+// Recorder records the operations on the artifacts passing through its transport.
+type Recorder struct {
+	command string
+	server  string
+	// The path of the Artifactory URL, which the paths of the requests to Artifactory start with.
+	artifactoryPath string
+	startedAt       time.Time
+
+	mutex sync.Mutex
+	items []Item
+	// The details of the artifacts returned by the searches, by their repo/path.
+	artifacts map[string]artifactDetails
+}
@@ -0,0 +1,129 @@
+package receipt
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-"+r.Method)
+		switch {
+		case r.Method == http.MethodPut && r.Header.Get(checksumDeployHeader) == "true":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"repo":"libs-local","path":"/app/app.jar","created":"2026-10-14T10:00:00.000Z","checksums":{"sha256":"uploaded"}}`))
+		case r.URL.Path == "/artifactory/api/search/aql":
+			_, _ = w.Write([]byte(`{"results":[{"repo":"libs-local","path":"app","name":"app.jar","created":"2026-10-13T10:00:00.000Z","sha256":"found"}],"range":{"total":1}}`))
+		case strings.HasPrefix(r.URL.Path, "/artifactory/api/move/"):
+			_, _ = w.Write([]byte(`{"messages":[]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	recorder, err := NewRecorder("upload", server.URL+"/artifactory/")
+	require.NoError(t, err)
+	client := &http.Client{Transport: recorder.Transport(http.DefaultTransport)}
+	send := func(method, path string, header http.Header) {
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		require.NoError(t, err)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	send(http.MethodPut, "/artifactory/libs-local/app/app.jar;build.name=app", http.Header{checksumDeployHeader: {"true"}})
+	send(http.MethodPut, "/artifactory/libs-local/app/app.jar;build.name=app", http.Header{"Traceparent": {testTraceparent}})
+	send(http.MethodPost, "/artifactory/api/search/aql", nil)
+	send(http.MethodPost, "/artifactory/api/move/libs-local/app/app.jar?to=/libs-release/app/app.jar&dry=1", nil)
+	send(http.MethodPost, "/artifactory/api/move/libs-local/app/app.jar?to=/libs-release/app/app.jar&dry=0", nil)
+	send(http.MethodDelete, "/artifactory/libs-local/app/app.jar", nil)
+	send(http.MethodGet, "/artifactory/api/system/version", nil)
+
+	receipt := recorder.Receipt()
+	assert.Equal(t, 2, receipt.Succeeded)
+	assert.Equal(t, 1, receipt.Failed)
+	require.Len(t, receipt.Items, 3)
+	for i := range receipt.Items {
+		assert.NotEmpty(t, receipt.Items[i].Time)
+		receipt.Items[i].Time = ""
+	}
+	assert.Equal(t, []Item{
+		{Operation: Upload, Path: "libs-local/app/app.jar", Status: http.StatusCreated, RequestId: "request-PUT", TraceId: "4bf92f3577b34da6a3ce929d0e0e4736",
+			Created: "2026-10-14T10:00:00.000Z", Sha256: "uploaded"},
+		{Operation: Move, Source: "libs-local/app/app.jar", Path: "libs-release/app/app.jar", Status: http.StatusOK, RequestId: "request-POST",
+			Created: "2026-10-13T10:00:00.000Z", Sha256: "found"},
+		{Operation: Delete, Path: "libs-local/app/app.jar", Status: http.StatusNotFound, RequestId: "request-DELETE",
+			Created: "2026-10-13T10:00:00.000Z", Sha256: "found"},
+	}, receipt.Items)
+}
+
+func TestWriteFile(t *testing.T) {
+	recorder, err := NewRecorder("delete", "https://acme.jfrog.io/artifactory/")
+	require.NoError(t, err)
+	recorder.addItem(Item{Operation: Delete, Path: "libs-local/app.jar", Status: http.StatusNoContent, Time: "2026-10-14T10:00:00Z"})
+	receiptPath := filepath.Join(t.TempDir(), "receipt.json")
+	require.NoError(t, os.WriteFile(receiptPath, []byte("previous receipt"), 0644))
+	require.NoError(t, recorder.WriteFile(receiptPath))
+
+	content, err := os.ReadFile(receiptPath)
+	require.NoError(t, err)
+	receipt := new(Receipt)
+	require.NoError(t, json.Unmarshal(content, receipt))
+	assert.Equal(t, "delete", receipt.Command)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/", receipt.Server)
+	assert.Equal(t, 1, receipt.Succeeded)
+	assert.Equal(t, []Item{{Operation: Delete, Path: "libs-local/app.jar", Status: http.StatusNoContent, Time: "2026-10-14T10:00:00Z"}}, receipt.Items)
+	// The temporary file is renamed to the receipt.
+	entries, err := os.ReadDir(filepath.Dir(receiptPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestStartBehindOtherProxies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	artifactoryUrl := server.URL + "/artifactory/"
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: artifactoryUrl}
+	outer, err := serverproxy.Start(serverDetails, "outer", func(upstream http.RoundTripper) http.RoundTripper { return upstream })
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, outer.Close())
+	}()
+
+	recording, err := Start(serverDetails, artifactoryUrl, "delete", "")
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodDelete, serverDetails.ArtifactoryUrl+"libs-local/app.jar", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, recording.Close())
+
+	receipt := recording.Receipt()
+	assert.Equal(t, artifactoryUrl, receipt.Server)
+	require.Len(t, receipt.Items, 1)
+	assert.Equal(t, "libs-local/app.jar", receipt.Items[0].Path)
+}
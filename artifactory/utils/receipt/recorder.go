@@ -0,0 +1,200 @@
+package receipt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	aqlApi  = "api/search/aql"
+	copyApi = "api/copy/"
+	moveApi = "api/move/"
+	// The header of the uploads which deploy an artifact by its checksum. If the server doesn't have the checksum, the file is uploaded next.
+	checksumDeployHeader = "X-Checksum-Deploy"
+	// The maximum size of the responses which are parsed for the details of the artifacts.
+	maxUploadResponseSize = 1 << 20
+)
+
+// artifactDetails are the details which the server returned for an artifact.
+type artifactDetails struct {
+	created string
+	sha256  string
+}
+
+func NewRecorder(command, artifactoryUrl string) (*Recorder, error) {
+	parsedUrl, err := url.Parse(artifactoryUrl)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid Artifactory URL '%s': %s", artifactoryUrl, err.Error())
+	}
+	return &Recorder{
+		command:         command,
+		server:          artifactoryUrl,
+		artifactoryPath: strings.TrimSuffix(parsedUrl.Path, "/") + "/",
+		startedAt:       time.Now().UTC(),
+		artifacts:       make(map[string]artifactDetails),
+	}, nil
+}
+
+// Transport returns an http.RoundTripper which records the operations sent through the next round tripper.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{next: next, recorder: r}
+}
+
+type recordingTransport struct {
+	next     http.RoundTripper
+	recorder *Recorder
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	apiPath, isArtifactory := strings.CutPrefix(req.URL.Path, rt.recorder.artifactoryPath)
+	if !isArtifactory {
+		return rt.next.RoundTrip(req)
+	}
+	item, isOperation := newItem(req, apiPath)
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		if isOperation {
+			item.Error = err.Error()
+			rt.recorder.addItem(item)
+		}
+		return resp, err
+	}
+	if req.Method == http.MethodPost && apiPath == aqlApi && resp.StatusCode == http.StatusOK {
+		resp.Body = &searchResultsBody{ReadCloser: resp.Body, recorder: rt.recorder}
+	}
+	if !isOperation {
+		return resp, nil
+	}
+	// A checksum deploy of a checksum the server doesn't have isn't an operation, since the file is uploaded next.
+	if item.Operation == Upload && resp.StatusCode == http.StatusNotFound && strings.EqualFold(req.Header.Get(checksumDeployHeader), "true") {
+		return resp, nil
+	}
+	item.Status = resp.StatusCode
+	item.RequestId = tracing.ResponseRequestId(resp)
+	if item.Operation == Upload && item.Succeeded() {
+		if err = rt.readUploadResponse(resp, &item); err != nil {
+			return nil, err
+		}
+	}
+	rt.recorder.addItem(item)
+	return resp, nil
+}
+
+// readUploadResponse reads the details of the uploaded artifact from the response, and restores the body of the response.
+func (rt *recordingTransport) readUploadResponse(resp *http.Response, item *Item) error {
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxUploadResponseSize))
+	if err = errors.Join(err, resp.Body.Close()); err != nil {
+		return errorutils.CheckError(err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+	var uploaded struct {
+		Created   string `json:"created"`
+		Checksums struct {
+			Sha256 string `json:"sha256"`
+		} `json:"checksums"`
+	}
+	if json.Unmarshal(content, &uploaded) == nil {
+		item.Created, item.Sha256 = uploaded.Created, uploaded.Checksums.Sha256
+	}
+	return nil
+}
+
+// newItem returns the operation of the request, if it uploads, copies, moves or deletes an artifact.
+func newItem(req *http.Request, apiPath string) (item Item, isOperation bool) {
+	item = Item{Time: time.Now().UTC().Format(time.RFC3339Nano)}
+	if spanContext, err := tracing.ParseTraceparent(req.Header.Get("traceparent")); err == nil {
+		item.TraceId = spanContext.TraceId
+	}
+	isApi := strings.HasPrefix(apiPath, "api/")
+	switch {
+	case req.Method == http.MethodPut && !isApi:
+		// The properties of the upload are matrix parameters of the path.
+		item.Operation, item.Path = Upload, strings.SplitN(apiPath, ";", 2)[0]
+	case req.Method == http.MethodDelete && !isApi:
+		item.Operation, item.Path = Delete, apiPath
+	case req.Method == http.MethodPost && (strings.HasPrefix(apiPath, copyApi) || strings.HasPrefix(apiPath, moveApi)):
+		query := req.URL.Query()
+		if query.Get("dry") == "1" {
+			return item, false
+		}
+		item.Operation = Copy
+		if strings.HasPrefix(apiPath, moveApi) {
+			item.Operation = Move
+		}
+		item.Source = strings.TrimPrefix(apiPath, "api/"+item.Operation+"/")
+		item.Path = strings.TrimPrefix(query.Get("to"), "/")
+	default:
+		return item, false
+	}
+	item.Path = strings.TrimSuffix(item.Path, "/")
+	return item, true
+}
+
+func (r *Recorder) addItem(item Item) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// The artifacts which are copied, moved or deleted were found by the searches of the command, which returned their details.
+	if item.Operation != Upload {
+		details := r.artifacts[item.Path]
+		if item.Source != "" {
+			details = r.artifacts[item.Source]
+		}
+		item.Created, item.Sha256 = details.created, details.sha256
+	}
+	r.items = append(r.items, item)
+}
+
+func (r *Recorder) addSearchResults(content []byte) {
+	var searchResults struct {
+		Results []struct {
+			Repo    string `json:"repo"`
+			Path    string `json:"path"`
+			Name    string `json:"name"`
+			Created string `json:"created"`
+			Sha256  string `json:"sha256"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(content, &searchResults); err != nil {
+		log.Debug("Failed to parse the search results for the receipt:", err.Error())
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, result := range searchResults.Results {
+		r.artifacts[path.Join(result.Repo, result.Path, result.Name)] = artifactDetails{created: result.Created, sha256: result.Sha256}
+	}
+}
+
+// searchResultsBody reads through the body of the search results, and adds their details to the recorder once the body is closed.
+type searchResultsBody struct {
+	io.ReadCloser
+	recorder *Recorder
+	once     sync.Once
+	content  bytes.Buffer
+}
+
+func (srb *searchResultsBody) Read(p []byte) (int, error) {
+	n, err := srb.ReadCloser.Read(p)
+	srb.content.Write(p[:n])
+	if err == io.EOF {
+		srb.once.Do(func() { srb.recorder.addSearchResults(srb.content.Bytes()) })
+	}
+	return n, err
+}
+
+func (srb *searchResultsBody) Close() error {
+	srb.once.Do(func() { srb.recorder.addSearchResults(srb.content.Bytes()) })
+	return srb.ReadCloser.Close()
+}
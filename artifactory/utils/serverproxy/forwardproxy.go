@@ -0,0 +1,200 @@
+package serverproxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/dualstack"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The proxy environment variables, which are read by http.ProxyFromEnvironment, and by most of the package managers.
+var proxyEnvs = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "ALL_PROXY", "all_proxy"}
+
+var (
+	forwardProxyMutex sync.Mutex
+	// The URL of the running forward proxy, or nil if it isn't running.
+	forwardProxyUrl *url.URL
+)
+
+// forwardProxy is an HTTP proxy which opens the connections to the servers by the dual-stack dialers of their server profiles.
+type forwardProxy struct {
+	// The dialers by the hosts of the configured servers, and the dialer of the other hosts.
+	dialers       map[string]*dualstack.Dialer
+	defaultDialer *dualstack.Dialer
+	transport     *http.Transport
+}
+
+// StartForwardProxy starts a local forward proxy, which opens the connections by the dual-stack dialer of the configured server profile of each host,
+// if the dialer is configured by dualstack.FamilyEnv or dualstack.AttemptDelayEnv. The proxy environment variables are pointed to it, so
+// that the connections of the service managers, whose transports use the proxy of the environment, and of the package managers run by
+// the commands, which read these variables too, are opened by the dialer. The proxy isn't started if a proxy is already set by the
+// environment, since the connections are then opened by that proxy.
+// The proxy runs until the process exits, since the proxy of the environment is read once per process. Starting it again does nothing.
+func StartForwardProxy() error {
+	if os.Getenv(dualstack.FamilyEnv) == "" && os.Getenv(dualstack.AttemptDelayEnv) == "" {
+		return nil
+	}
+	forwardProxyMutex.Lock()
+	defer forwardProxyMutex.Unlock()
+	if forwardProxyUrl != nil {
+		return nil
+	}
+	for _, proxyEnv := range proxyEnvs {
+		if os.Getenv(proxyEnv) != "" {
+			log.Debug("The connections are opened by the proxy of", proxyEnv+", rather than by the", dualstack.FamilyEnv, "dialer.")
+			return nil
+		}
+	}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		log.Debug("Opening the connections by the default", dualstack.FamilyEnv, "dialer, since the server profiles couldn't be read:", err.Error())
+	}
+	proxy, err := newForwardProxy(servers)
+	if err != nil {
+		return err
+	}
+	proxyUrl, err := proxy.start()
+	if err != nil {
+		return err
+	}
+	for _, proxyEnv := range []string{"HTTPS_PROXY", "HTTP_PROXY"} {
+		if err = os.Setenv(proxyEnv, proxyUrl.String()); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	// The proxy of the environment is read by the first request of the process, so it doesn't apply if a request was sent before.
+	probe := &http.Request{URL: &url.URL{Scheme: "https", Host: "proxy-probe.invalid"}}
+	if probeUrl, probeErr := http.ProxyFromEnvironment(probe); probeErr != nil || probeUrl == nil || probeUrl.String() != proxyUrl.String() {
+		log.Debug("The service managers don't connect through the", dualstack.FamilyEnv, "dialer, since the proxy of the environment was read already.")
+	}
+	forwardProxyUrl = proxyUrl
+	return nil
+}
+
+// isForwardProxy returns true if the URL is of the running forward proxy.
+func isForwardProxy(proxyUrl *url.URL) bool {
+	forwardProxyMutex.Lock()
+	defer forwardProxyMutex.Unlock()
+	return proxyUrl != nil && forwardProxyUrl != nil && proxyUrl.Host == forwardProxyUrl.Host
+}
+
+func newForwardProxy(servers []*config.ServerDetails) (*forwardProxy, error) {
+	defaultDialer, err := dualstack.NewDialer("")
+	if err != nil {
+		return nil, err
+	}
+	proxy := &forwardProxy{dialers: make(map[string]*dualstack.Dialer), defaultDialer: defaultDialer}
+	for _, server := range servers {
+		dialer, err := dualstack.NewDialer(server.ServerId)
+		if err != nil {
+			return nil, err
+		}
+		for _, serviceUrl := range serviceUrls(server) {
+			parsedUrl, err := url.Parse(*serviceUrl)
+			if err != nil || parsedUrl.Hostname() == "" {
+				continue
+			}
+			// The first server profile of a host determines its family.
+			host := strings.ToLower(parsedUrl.Hostname())
+			if _, exists := proxy.dialers[host]; !exists {
+				proxy.dialers[host] = dialer
+			}
+		}
+	}
+	proxy.transport = http.DefaultTransport.(*http.Transport).Clone()
+	proxy.transport.Proxy = nil
+	proxy.transport.DialContext = proxy.dial
+	return proxy, nil
+}
+
+func (fp *forwardProxy) start() (*url.URL, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to start the %s proxy: %s", dualstack.FamilyEnv, err.Error())
+	}
+	// #nosec G112 -- The proxy listens on the loopback interface only.
+	server := &http.Server{Handler: fp}
+	go func() {
+		if serveErr := server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			log.Warn("The", dualstack.FamilyEnv, "proxy stopped:", serveErr.Error())
+		}
+	}()
+	proxyUrl := &url.URL{Scheme: "http", Host: listener.Addr().String()}
+	log.Debug("Opening the connections by the", dualstack.FamilyEnv, "dialer through the proxy listening on", proxyUrl.String())
+	return proxyUrl, nil
+}
+
+func (fp *forwardProxy) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	dialer, exists := fp.dialers[strings.ToLower(host)]
+	if !exists {
+		dialer = fp.defaultDialer
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+func (fp *forwardProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodConnect {
+		fp.tunnel(w, req)
+		return
+	}
+	if !req.URL.IsAbs() {
+		http.Error(w, "the proxy accepts requests with absolute URLs only", http.StatusBadRequest)
+		return
+	}
+	proxy := &httputil.ReverseProxy{
+		// The URL of a proxy request is the URL of the server already.
+		Rewrite:   func(*httputil.ProxyRequest) {},
+		Transport: fp.transport,
+	}
+	proxy.ServeHTTP(w, req)
+}
+
+// tunnel connects to the address of the CONNECT request, and copies the traffic between the client and the server.
+func (fp *forwardProxy) tunnel(w http.ResponseWriter, req *http.Request) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "the connection can't be tunneled", http.StatusInternalServerError)
+		return
+	}
+	upstream, err := fp.dial(req.Context(), "tcp", req.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		_ = upstream.Close()
+		log.Debug("Failed to tunnel the connection to", req.Host+":", err.Error())
+		return
+	}
+	closeConnections := func() {
+		_ = upstream.Close()
+		_ = conn.Close()
+	}
+	if _, err = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		closeConnections()
+		return
+	}
+	go func() {
+		// The data which the client sent after the CONNECT request may be buffered already.
+		_, _ = io.Copy(upstream, buffered.Reader)
+		closeConnections()
+	}()
+	_, _ = io.Copy(conn, upstream)
+	closeConnections()
+}
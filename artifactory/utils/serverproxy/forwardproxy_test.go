@@ -0,0 +1,57 @@
+package serverproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/dualstack"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartForwardProxyNotConfigured(t *testing.T) {
+	t.Setenv(dualstack.FamilyEnv, "")
+	t.Setenv(dualstack.AttemptDelayEnv, "")
+	require.NoError(t, StartForwardProxy())
+	assert.Nil(t, forwardProxyUrl)
+
+	// The proxy of the environment opens the connections.
+	t.Setenv(dualstack.FamilyEnv, "ipv4")
+	t.Setenv("HTTPS_PROXY", "http://proxy.acme.io:8080")
+	require.NoError(t, StartForwardProxy())
+	assert.Nil(t, forwardProxyUrl)
+}
+
+func TestForwardProxy(t *testing.T) {
+	t.Setenv(dualstack.FamilyEnv, "ipv6,prod=ipv4")
+	proxy, err := newForwardProxy([]*config.ServerDetails{{ServerId: "prod", Url: "https://Acme.jfrog.io/", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}})
+	require.NoError(t, err)
+	assert.Len(t, proxy.dialers, 1)
+	assert.Contains(t, proxy.dialers, "acme.jfrog.io")
+
+	proxyUrl, err := proxy.start()
+	require.NoError(t, err)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path))
+	})
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// The TLS connections are tunneled, and the other requests are forwarded.
+	tlsTransport := tlsServer.Client().Transport.(*http.Transport).Clone()
+	tlsTransport.Proxy = http.ProxyURL(proxyUrl)
+	transport := &http.Transport{Proxy: http.ProxyURL(proxyUrl)}
+	for serverUrl, client := range map[string]*http.Client{tlsServer.URL: {Transport: tlsTransport}, server.URL: {Transport: transport}} {
+		resp, err := client.Get(serverUrl + "/artifactory/api/system/ping")
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "GET /artifactory/api/system/ping", string(body))
+	}
+}
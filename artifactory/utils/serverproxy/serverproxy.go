@@ -0,0 +1,258 @@
+package serverproxy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/dualstack"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+var (
+	upstreamOriginsMutex sync.RWMutex
+	// The origins the running proxies forward the traffic to, by the local origins of the proxies.
+	// When the proxies are stacked, the origin of a proxy may be the local origin of another one.
+	upstreamOrigins = make(map[string]string)
+)
+
+// Proxies route the HTTP traffic sent using server details through local reverse proxies.
+// The URLs of the server details are pointed to the proxies, which forward the traffic to the original servers
+// through a transport of the caller. This applies to every service manager created from the server details,
+// without changes to the commands themselves.
+type Proxies struct {
+	name         string
+	servers      []*http.Server
+	localOrigins []string
+	originalUrls map[*string]string
+}
+
+// Start starts a proxy for each server of the server details, forwarding the traffic through the transport
+// returned by newTransport. The name of the proxies is used in the log messages.
+// The server details are modified in place, and restored by Close.
+func Start(serverDetails *config.ServerDetails, name string, newTransport func(upstream http.RoundTripper) http.RoundTripper) (*Proxies, error) {
+	upstream, err := createUpstreamTransport(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	return start(serverDetails, name, newTransport(upstream))
+}
+
+// StartWithDialer starts a proxy for each server of the server details, which opens the connections to the servers by dial, rather
+// than directly. The addresses passed to dial are the host:port of the servers, and the TLS connections are still verified against
+// the hosts of the servers.
+func StartWithDialer(serverDetails *config.ServerDetails, name string, dial func(ctx context.Context, network, address string) (net.Conn, error)) (*Proxies, error) {
+	upstream, err := createUpstreamTransport(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	upstream.DialContext = dial
+	// The proxy settings of the environment would bypass the dialer.
+	upstream.Proxy = nil
+	return start(serverDetails, name, upstream)
+}
+
+func start(serverDetails *config.ServerDetails, name string, transport http.RoundTripper) (*Proxies, error) {
+	proxies := &Proxies{name: name, originalUrls: make(map[*string]string)}
+	localOrigins := make(map[string]string)
+	for _, serviceUrl := range serviceUrls(serverDetails) {
+		if *serviceUrl == "" {
+			continue
+		}
+		parsedUrl, err := url.Parse(*serviceUrl)
+		if err != nil || parsedUrl.Host == "" {
+			log.Debug("Skipping the", name, "proxy of the invalid URL:", *serviceUrl)
+			continue
+		}
+		origin := parsedUrl.Scheme + "://" + parsedUrl.Host
+		localOrigin, exists := localOrigins[origin]
+		if !exists {
+			if localOrigin, err = proxies.startProxy(origin, transport); err != nil {
+				return nil, errors.Join(err, proxies.Close())
+			}
+			localOrigins[origin] = localOrigin
+		}
+		proxies.originalUrls[serviceUrl] = *serviceUrl
+		*serviceUrl = localOrigin + strings.TrimPrefix(*serviceUrl, origin)
+	}
+	return proxies, nil
+}
+
+// Close stops the proxies and restores the URLs of the server details.
+func (p *Proxies) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	var err error
+	for _, server := range p.servers {
+		err = errors.Join(err, errorutils.CheckError(server.Shutdown(ctx)))
+	}
+	p.servers = nil
+	upstreamOriginsMutex.Lock()
+	for _, localOrigin := range p.localOrigins {
+		delete(upstreamOrigins, localOrigin)
+	}
+	upstreamOriginsMutex.Unlock()
+	p.localOrigins = nil
+	for serviceUrl, originalUrl := range p.originalUrls {
+		*serviceUrl = originalUrl
+	}
+	return err
+}
+
+// OriginalUrl returns the URL of the server which the URL of a running proxy forwards the traffic to, through all the stacked proxies.
+// Other URLs are returned as is. The results of the commands, which are reported with the URLs of the server details,
+// should be restored by it while the proxies are running, so that they aren't reported with the local URLs of the proxies.
+func OriginalUrl(rawUrl string) string {
+	upstreamOriginsMutex.RLock()
+	defer upstreamOriginsMutex.RUnlock()
+	// Each proxy is registered after its origin, so the chain of the stacked proxies is at most as long as the registry.
+	for range len(upstreamOrigins) {
+		origin, rest, found := upstreamOriginOf(rawUrl)
+		if !found {
+			break
+		}
+		rawUrl = origin + rest
+	}
+	return rawUrl
+}
+
+func upstreamOriginOf(rawUrl string) (origin, rest string, found bool) {
+	for localOrigin, upstreamOrigin := range upstreamOrigins {
+		if rest, found = strings.CutPrefix(rawUrl, localOrigin); found && (rest == "" || strings.ContainsAny(rest[:1], "/?#")) {
+			return upstreamOrigin, rest, true
+		}
+	}
+	return "", "", false
+}
+
+// RestoreTransferDetails returns the transfer details of the reader with the URLs of the running proxies replaced by
+// the URLs of the servers, as returned by OriginalUrl, and closes the reader. If no proxy is running, the reader is returned as is.
+func RestoreTransferDetails(reader *content.ContentReader) (restored *content.ContentReader, err error) {
+	upstreamOriginsMutex.RLock()
+	proxying := len(upstreamOrigins) > 0
+	upstreamOriginsMutex.RUnlock()
+	if reader == nil || !proxying {
+		return reader, nil
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, errors.Join(err, reader.Close())
+	}
+	for item := new(clientUtils.FileTransferDetails); reader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		item.RtUrl = OriginalUrl(item.RtUrl)
+		item.SourcePath = OriginalUrl(item.SourcePath)
+		item.TargetPath = OriginalUrl(item.TargetPath)
+		writer.Write(*item)
+	}
+	if err = errors.Join(reader.GetError(), reader.Close(), writer.Close()); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+func (p *Proxies) startProxy(origin string, transport http.RoundTripper) (string, error) {
+	target, err := url.Parse(origin)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to start the %s proxy: %s", p.name, err.Error())
+	}
+	localOrigin := "http://" + listener.Addr().String()
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(proxyRequest *httputil.ProxyRequest) {
+			proxyRequest.SetURL(target)
+		},
+		Transport: transport,
+		ModifyResponse: func(resp *http.Response) error {
+			// Keep redirects to the same server within the proxy, so that they pass through it too.
+			if location := resp.Header.Get("Location"); strings.HasPrefix(location, origin) {
+				resp.Header.Set("Location", localOrigin+strings.TrimPrefix(location, origin))
+			}
+			return nil
+		},
+	}
+	// #nosec G112 -- The proxy listens on the loopback interface only, during a single command.
+	server := &http.Server{Handler: proxy}
+	p.servers = append(p.servers, server)
+	p.localOrigins = append(p.localOrigins, localOrigin)
+	upstreamOriginsMutex.Lock()
+	upstreamOrigins[localOrigin] = origin
+	upstreamOriginsMutex.Unlock()
+	go func() {
+		if serveErr := server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			log.Warn("The", p.name, "proxy stopped:", serveErr.Error())
+		}
+	}()
+	log.Debug("Forwarding the traffic to", origin, "through the", p.name, "proxy listening on", localOrigin)
+	return localOrigin, nil
+}
+
+// createUpstreamTransport creates the transport used to forward the traffic, with the TLS configuration of the server details.
+// Its connections are opened by the dual-stack dialer of the server profile, so they don't pass through the forward proxy of
+// StartForwardProxy, which would open them by the same dialer.
+func createUpstreamTransport(serverDetails *config.ServerDetails) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer, err := dualstack.NewDialer(serverDetails.ServerId)
+	if err != nil {
+		return nil, err
+	}
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyUrl, err := http.ProxyFromEnvironment(req)
+		if err != nil || isForwardProxy(proxyUrl) {
+			return nil, err
+		}
+		return proxyUrl, nil
+	}
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	if transport, err = cert.GetTransportWithLoadedCert(certsPath, serverDetails.InsecureTls, transport); err != nil {
+		return nil, err
+	}
+	if serverDetails.ClientCertPath != "" {
+		certificate, err := cert.LoadCertificate(serverDetails.ClientCertPath, serverDetails.ClientCertKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return transport, nil
+}
+
+func serviceUrls(serverDetails *config.ServerDetails) []*string {
+	return []*string{
+		&serverDetails.Url,
+		&serverDetails.ArtifactoryUrl,
+		&serverDetails.DistributionUrl,
+		&serverDetails.XrayUrl,
+		&serverDetails.XscUrl,
+		&serverDetails.CatalogUrl,
+		&serverDetails.MissionControlUrl,
+		&serverDetails.PipelinesUrl,
+		&serverDetails.AccessUrl,
+		&serverDetails.LifecycleUrl,
+		&serverDetails.EvidenceUrl,
+		&serverDetails.MetadataUrl,
+		&serverDetails.OnemodelUrl,
+		&serverDetails.ApptrustUrl,
+	}
+}
@@ -0,0 +1,71 @@
+package serverproxy
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passThrough(upstream http.RoundTripper) http.RoundTripper {
+	return upstream
+}
+
+func TestOriginalUrl(t *testing.T) {
+	const artifactoryUrl = "https://acme.jfrog.io/artifactory/"
+	serverDetails := &config.ServerDetails{Url: "https://acme.jfrog.io/", ArtifactoryUrl: artifactoryUrl}
+	outer, err := Start(serverDetails, "outer", passThrough)
+	require.NoError(t, err)
+	inner, err := Start(serverDetails, "inner", passThrough)
+	require.NoError(t, err)
+	assert.NotEqual(t, artifactoryUrl, serverDetails.ArtifactoryUrl)
+
+	assert.Equal(t, artifactoryUrl+"repo/file.zip", OriginalUrl(serverDetails.ArtifactoryUrl+"repo/file.zip"))
+	localOrigin := strings.TrimSuffix(serverDetails.Url, "/")
+	assert.Equal(t, "https://acme.jfrog.io", OriginalUrl(localOrigin))
+	assert.Equal(t, "repo/file.zip", OriginalUrl("repo/file.zip"))
+	// A port which only starts with the port of a proxy isn't its URL.
+	assert.Equal(t, localOrigin+"0/", OriginalUrl(localOrigin+"0/"))
+
+	innerUrl := serverDetails.ArtifactoryUrl
+	require.NoError(t, inner.Close())
+	require.NoError(t, outer.Close())
+	assert.Equal(t, artifactoryUrl, serverDetails.ArtifactoryUrl)
+	assert.Equal(t, innerUrl, OriginalUrl(innerUrl))
+}
+
+func TestRestoreTransferDetails(t *testing.T) {
+	const artifactoryUrl = "https://acme.jfrog.io/artifactory/"
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: artifactoryUrl}
+	proxies, err := Start(serverDetails, "test", passThrough)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, proxies.Close())
+	}()
+
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	writer.Write(clientUtils.FileTransferDetails{SourcePath: "file.zip", TargetPath: "repo/file.zip", RtUrl: serverDetails.ArtifactoryUrl})
+	writer.Write(clientUtils.FileTransferDetails{SourcePath: serverDetails.ArtifactoryUrl + "repo/other.zip", TargetPath: "other.zip", RtUrl: serverDetails.ArtifactoryUrl})
+	require.NoError(t, writer.Close())
+
+	reader, err := RestoreTransferDetails(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	var items []clientUtils.FileTransferDetails
+	for item := new(clientUtils.FileTransferDetails); reader.NextRecord(item) == nil; item = new(clientUtils.FileTransferDetails) {
+		items = append(items, *item)
+	}
+	require.NoError(t, reader.GetError())
+	assert.ElementsMatch(t, []clientUtils.FileTransferDetails{
+		{SourcePath: "file.zip", TargetPath: "repo/file.zip", RtUrl: artifactoryUrl},
+		{SourcePath: artifactoryUrl + "repo/other.zip", TargetPath: "other.zip", RtUrl: artifactoryUrl},
+	}, items)
+}
@@ -0,0 +1,289 @@
+// Package sshtunnel routes the traffic of the commands to the servers through SSH, for the environments where the servers aren't
+// reachable directly from the build agents. A tunnel is defined per server profile in the SSH tunnels file, either through an SSH
+// jump host, which JFrog CLI connects to itself, or through a local port-forward established beforehand, such as by ssh -L.
+// The TLS connections are still established end to end with the servers, and verified against their hosts.
+package sshtunnel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// TunnelsFileEnv is the path of the SSH tunnels file. If not set, the ssh-tunnels.json file in the JFrog CLI home directory is used,
+	// if it exists.
+	TunnelsFileEnv = "JFROG_CLI_SSH_TUNNELS"
+	// KeyPassphraseEnv is the passphrase of the private keys of the identity files.
+	KeyPassphraseEnv = "JFROG_CLI_SSH_KEY_PASSPHRASE"
+
+	tunnelsFileName = "ssh-tunnels.json"
+	defaultSshPort  = "22"
+	connectTimeout  = 30 * time.Second
+)
+
+// The private keys which are used when no identity file is set and no SSH agent is running, as by ssh.
+var defaultIdentityFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// TunnelsConfig is the content of the SSH tunnels file.
+type TunnelsConfig struct {
+	// The tunnels by the IDs of the server profiles.
+	Tunnels map[string]*Tunnel `json:"tunnels"`
+}
+
+// Tunnel is the way the traffic of a server profile reaches its servers. Either JumpHost or LocalForward is set.
+type Tunnel struct {
+	// The SSH jump host, in the form of [user@]host[:port]. The user is the current user by default.
+	JumpHost string `json:"jumpHost,omitempty"`
+	// The private key which authenticates to the jump host. If not set, the keys of the SSH agent are used, or the default keys
+	// under ~/.ssh.
+	IdentityFile string `json:"identityFile,omitempty"`
+	// The known hosts file which verifies the host key of the jump host. ~/.ssh/known_hosts by default.
+	KnownHostsFile string `json:"knownHostsFile,omitempty"`
+	// Skip the verification of the host key of the jump host. Use it only for tests.
+	InsecureIgnoreHostKey bool `json:"insecureIgnoreHostKey,omitempty"`
+	// The local address of a port-forward to the servers, such as 127.0.0.1:8443. All the connections to the servers of the profile
+	// are opened to it.
+	LocalForward string `json:"localForward,omitempty"`
+}
+
+func (t *Tunnel) Validate(serverId string) error {
+	if (t.JumpHost == "") == (t.LocalForward == "") {
+		return errorutils.CheckErrorf("the SSH tunnel of the '%s' server must set either jumpHost or localForward", serverId)
+	}
+	if t.LocalForward != "" {
+		if _, _, err := net.SplitHostPort(t.LocalForward); err != nil {
+			return errorutils.CheckErrorf("the localForward of the SSH tunnel of the '%s' server must be in the form of host:port, got '%s'", serverId, t.LocalForward)
+		}
+	}
+	return nil
+}
+
+// LoadTunnelsConfig reads the SSH tunnels file. It returns nil if no tunnels file is found.
+func LoadTunnelsConfig() (*TunnelsConfig, error) {
+	tunnelsPath := os.Getenv(TunnelsFileEnv)
+	if tunnelsPath == "" {
+		homeDir, err := coreutils.GetJfrogHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		tunnelsPath = filepath.Join(homeDir, tunnelsFileName)
+		if _, err = os.Stat(tunnelsPath); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	content, err := os.ReadFile(tunnelsPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the SSH tunnels file: %s", err.Error())
+	}
+	tunnelsConfig := new(TunnelsConfig)
+	if err = json.Unmarshal(content, tunnelsConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the SSH tunnels file '%s': %s", tunnelsPath, err.Error())
+	}
+	for serverId, tunnel := range tunnelsConfig.Tunnels {
+		if err = tunnel.Validate(serverId); err != nil {
+			return nil, err
+		}
+	}
+	return tunnelsConfig, nil
+}
+
+// Start routes the traffic sent using the server details through the SSH tunnel of their server profile, if the tunnels file defines one.
+// The server details are modified in place, and restored by stop.
+func Start(serverDetails *config.ServerDetails) (stop func() error, err error) {
+	stop = func() error { return nil }
+	if serverDetails == nil || serverDetails.ServerId == "" {
+		return
+	}
+	tunnelsConfig, err := LoadTunnelsConfig()
+	if err != nil || tunnelsConfig == nil {
+		return
+	}
+	tunnel, exists := tunnelsConfig.Tunnels[serverDetails.ServerId]
+	if !exists {
+		return
+	}
+	return tunnel.start(serverDetails)
+}
+
+func (t *Tunnel) start(serverDetails *config.ServerDetails) (stop func() error, err error) {
+	if t.LocalForward != "" {
+		log.Debug("Connecting to the", serverDetails.ServerId, "server through the local port-forward", t.LocalForward)
+		var dialer net.Dialer
+		proxies, err := serverproxy.StartWithDialer(serverDetails, "SSH tunnel", func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, t.LocalForward)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return proxies.Close, nil
+	}
+	client, err := t.connect()
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := serverproxy.StartWithDialer(serverDetails, "SSH tunnel", client.DialContext)
+	if err != nil {
+		return nil, errors.Join(err, client.Close())
+	}
+	return func() error {
+		return errors.Join(proxies.Close(), client.Close())
+	}, nil
+}
+
+// connect connects to the jump host.
+func (t *Tunnel) connect() (*ssh.Client, error) {
+	username, address, err := parseJumpHost(t.JumpHost)
+	if err != nil {
+		return nil, err
+	}
+	authMethods, closeAgent, err := t.getAuthMethods()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := closeAgent(); closeErr != nil {
+			log.Debug("Failed to close the connection to the SSH agent:", closeErr.Error())
+		}
+	}()
+	hostKeyCallback, err := t.getHostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Connecting to the SSH jump host", address, "as", username)
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         connectTimeout,
+	})
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to connect to the SSH jump host %s: %s", address, err.Error())
+	}
+	return client, nil
+}
+
+// parseJumpHost returns the user and the host:port of a jump host in the form of [user@]host[:port].
+func parseJumpHost(jumpHost string) (username, address string, err error) {
+	username, host, found := strings.Cut(jumpHost, "@")
+	if !found {
+		host = jumpHost
+		currentUser, err := user.Current()
+		if err != nil {
+			return "", "", errorutils.CheckErrorf("failed to get the current user for the SSH jump host %s: %s", jumpHost, err.Error())
+		}
+		username = currentUser.Username
+	}
+	if _, _, err = net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, defaultSshPort)
+	}
+	return username, host, nil
+}
+
+// getAuthMethods returns the methods which authenticate to the jump host. The connection to the SSH agent, if any, is closed by closeAgent
+// once the client is connected.
+func (t *Tunnel) getAuthMethods() (authMethods []ssh.AuthMethod, closeAgent func() error, err error) {
+	closeAgent = func() error { return nil }
+	if t.IdentityFile != "" {
+		signer, err := readIdentityFile(expandHome(t.IdentityFile))
+		if err != nil {
+			return nil, closeAgent, err
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, closeAgent, nil
+	}
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}, conn.Close, nil
+		}
+		log.Debug("Failed to connect to the SSH agent:", err.Error())
+	}
+	var signers []ssh.Signer
+	for _, identityFile := range defaultIdentityFiles {
+		identityPath := expandHome(filepath.Join("~", ".ssh", identityFile))
+		exists, err := fileutils.IsFileExists(identityPath, false)
+		if err != nil {
+			return nil, closeAgent, err
+		}
+		if !exists {
+			continue
+		}
+		signer, err := readIdentityFile(identityPath)
+		if err != nil {
+			return nil, closeAgent, err
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) == 0 {
+		return nil, closeAgent, errorutils.CheckErrorf("no SSH key was found to authenticate to the jump host %s. Set the identityFile of its tunnel, or run an SSH agent", t.JumpHost)
+	}
+	return []ssh.AuthMethod{ssh.PublicKeys(signers...)}, closeAgent, nil
+}
+
+func readIdentityFile(identityPath string) (ssh.Signer, error) {
+	content, err := os.ReadFile(identityPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the SSH identity file: %s", err.Error())
+	}
+	var signer ssh.Signer
+	if passphrase := os.Getenv(KeyPassphraseEnv); passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(content, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(content)
+	}
+	if err != nil {
+		var passphraseMissingError *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseMissingError) {
+			return nil, errorutils.CheckErrorf("the SSH identity file %s is encrypted. Set its passphrase in %s", identityPath, KeyPassphraseEnv)
+		}
+		return nil, errorutils.CheckErrorf("failed to parse the SSH identity file %s: %s", identityPath, err.Error())
+	}
+	return signer, nil
+}
+
+func (t *Tunnel) getHostKeyCallback() (ssh.HostKeyCallback, error) {
+	if t.InsecureIgnoreHostKey {
+		log.Warn(fmt.Sprintf("The host key of the SSH jump host %s isn't verified.", t.JumpHost))
+		// #nosec G106 -- Set explicitly by the user for the tests of the tunnels.
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	knownHostsFile := t.KnownHostsFile
+	if knownHostsFile == "" {
+		knownHostsFile = filepath.Join("~", ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(expandHome(knownHostsFile))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the known hosts file of the SSH jump host %s: %s", t.JumpHost, err.Error())
+	}
+	return callback, nil
+}
+
+// expandHome replaces the ~ prefix of the path by the home directory of the user.
+func expandHome(path string) string {
+	rest, found := strings.CutPrefix(path, "~")
+	if !found {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, rest)
+}
@@ -0,0 +1,211 @@
+package sshtunnel
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const testServerUrl = "http://artifactory.example.invalid/artifactory/"
+
+func writeTunnelsFile(t *testing.T, tunnels map[string]*Tunnel) {
+	content, err := json.Marshal(TunnelsConfig{Tunnels: tunnels})
+	require.NoError(t, err)
+	tunnelsPath := filepath.Join(t.TempDir(), tunnelsFileName)
+	require.NoError(t, os.WriteFile(tunnelsPath, content, 0600))
+	t.Setenv(TunnelsFileEnv, tunnelsPath)
+}
+
+// createTestServer returns an Artifactory server which responds with the host of the requests.
+func createTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host + r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func assertTunneled(t *testing.T, serverDetails *config.ServerDetails) {
+	resp, err := http.Get(serverDetails.ArtifactoryUrl + "api/system/ping")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, resp.Body.Close())
+	}()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "artifactory.example.invalid/artifactory/api/system/ping", string(body))
+}
+
+func TestLoadTunnelsConfig(t *testing.T) {
+	t.Setenv(TunnelsFileEnv, filepath.Join(t.TempDir(), "missing.json"))
+	_, err := LoadTunnelsConfig()
+	assert.ErrorContains(t, err, "failed to read the SSH tunnels file")
+
+	writeTunnelsFile(t, map[string]*Tunnel{"prod": {JumpHost: "bastion", LocalForward: "127.0.0.1:8443"}})
+	_, err = LoadTunnelsConfig()
+	assert.ErrorContains(t, err, "must set either jumpHost or localForward")
+	writeTunnelsFile(t, map[string]*Tunnel{"prod": {LocalForward: "8443"}})
+	_, err = LoadTunnelsConfig()
+	assert.ErrorContains(t, err, "must be in the form of host:port")
+}
+
+func TestParseJumpHost(t *testing.T) {
+	username, address, err := parseJumpHost("ci@bastion.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", username)
+	assert.Equal(t, "bastion.example.com:22", address)
+	_, address, err = parseJumpHost("ci@bastion.example.com:2222")
+	require.NoError(t, err)
+	assert.Equal(t, "bastion.example.com:2222", address)
+}
+
+func TestStartWithoutTunnel(t *testing.T) {
+	writeTunnelsFile(t, map[string]*Tunnel{"other": {LocalForward: "127.0.0.1:8443"}})
+	serverDetails := &config.ServerDetails{ServerId: "prod", ArtifactoryUrl: testServerUrl}
+	stop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assert.Equal(t, testServerUrl, serverDetails.ArtifactoryUrl)
+	assert.NoError(t, stop())
+}
+
+func TestLocalForward(t *testing.T) {
+	server := createTestServer(t)
+	writeTunnelsFile(t, map[string]*Tunnel{"prod": {LocalForward: server.Listener.Addr().String()}})
+	serverDetails := &config.ServerDetails{ServerId: "prod", ArtifactoryUrl: testServerUrl}
+	stop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assert.NotEqual(t, testServerUrl, serverDetails.ArtifactoryUrl)
+	assertTunneled(t, serverDetails)
+	assert.NoError(t, stop())
+	assert.Equal(t, testServerUrl, serverDetails.ArtifactoryUrl)
+}
+
+func TestJumpHost(t *testing.T) {
+	server := createTestServer(t)
+	clientKey, clientPrivateKey := generateKey(t)
+	jumpHostAddress, hostKey, targets := startTestJumpHost(t, clientKey, server.Listener.Addr().String())
+
+	dir := t.TempDir()
+	identityBlock, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	require.NoError(t, err)
+	identityPath := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(identityPath, pem.EncodeToMemory(identityBlock), 0600))
+	knownHostsPath := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{jumpHostAddress}, hostKey)+"\n"), 0600))
+
+	writeTunnelsFile(t, map[string]*Tunnel{"prod": {JumpHost: "ci@" + jumpHostAddress, IdentityFile: identityPath, KnownHostsFile: knownHostsPath}})
+	serverDetails := &config.ServerDetails{ServerId: "prod", ArtifactoryUrl: testServerUrl}
+	stop, err := Start(serverDetails)
+	require.NoError(t, err)
+	assertTunneled(t, serverDetails)
+	assert.NoError(t, stop())
+	assert.Contains(t, targets(), "artifactory.example.invalid:80")
+
+	// The host key of the jump host must be known.
+	require.NoError(t, os.WriteFile(knownHostsPath, nil, 0600))
+	_, err = Start(serverDetails)
+	assert.ErrorContains(t, err, "failed to connect to the SSH jump host")
+}
+
+func generateKey(t *testing.T) (ssh.PublicKey, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	return sshPublicKey, privateKey
+}
+
+// startTestJumpHost starts an SSH server which accepts the client key, and forwards the connections of the clients to upstream.
+// Returns the addresses which the clients asked to connect to.
+func startTestJumpHost(t *testing.T, clientKey ssh.PublicKey, upstream string) (address string, hostKey ssh.PublicKey, targets func() []string) {
+	hostKey, hostPrivateKey := generateKey(t)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+	serverConfig := &ssh.ServerConfig{PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+		if !bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+			return nil, errors.New("unknown key")
+		}
+		return nil, nil
+	}}
+	serverConfig.AddHostKey(hostSigner)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	var mutex sync.Mutex
+	var dialedTargets []string
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, channels, requests, err := ssh.NewServerConn(conn, serverConfig)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(requests)
+				for newChannel := range channels {
+					var target struct {
+						Host       string
+						Port       uint32
+						OriginHost string
+						OriginPort uint32
+					}
+					if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+						_ = newChannel.Reject(ssh.UnknownChannelType, "only direct-tcpip channels are supported")
+						continue
+					}
+					mutex.Lock()
+					dialedTargets = append(dialedTargets, net.JoinHostPort(target.Host, strconv.FormatUint(uint64(target.Port), 10)))
+					mutex.Unlock()
+					go forward(newChannel, upstream)
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), hostKey, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, dialedTargets...)
+	}
+}
+
+func forward(newChannel ssh.NewChannel, upstream string) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	upstreamConn, err := net.Dial("tcp", upstream)
+	if err != nil {
+		_ = channel.Close()
+		return
+	}
+	go func() {
+		_, _ = io.Copy(channel, upstreamConn)
+		_ = channel.Close()
+	}()
+	_, _ = io.Copy(upstreamConn, channel)
+	_ = upstreamConn.Close()
+}
@@ -0,0 +1,213 @@
+// Package tracing propagates the W3C trace context on the HTTP requests sent to the JFrog Platform, so that the operations
+// of the CLI can be correlated with the traces of the servers. The trace context is read from the TRACEPARENT and TRACESTATE
+// environment variables, which the OpenTelemetry instrumented CI systems set. The trace context is propagated only if
+// SpanEnv is enabled, in which case each command starts its own span, which records the request IDs returned by the servers.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// TraceparentEnv is the W3C trace context of the caller, propagated on the requests of the command.
+	TraceparentEnv = "TRACEPARENT"
+	// TracestateEnv is the vendor specific trace state of the caller, propagated with the trace context.
+	TracestateEnv = "TRACESTATE"
+	// SpanEnv starts a span per command, if set to true. The span is a child of the trace context of TraceparentEnv,
+	// or the root of a new trace, and the request IDs returned by the servers are recorded in the log.
+	SpanEnv = "JFROG_CLI_TRACE_COMMANDS"
+
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+	// The headers in which the JFrog Platform returns the ID of a request, in the order they're looked up.
+	requestIdHeader      = "X-Request-Id"
+	jfrogRequestIdHeader = "X-JFrog-Request-Id"
+	// The number of request IDs of the failed requests which are listed when the span ends.
+	maxListedFailedRequests = 10
+)
+
+// Matches the version 00 traceparent: version, trace ID, parent ID and flags. Future versions may append fields.
+var traceparentPattern = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})(-.*)?$`)
+
+// SpanContext identifies a span of a trace, as it's propagated by the traceparent header.
+type SpanContext struct {
+	TraceId string
+	SpanId  string
+	Flags   string
+}
+
+// Traceparent returns the traceparent header value of the span.
+func (sc SpanContext) Traceparent() string {
+	return "00-" + sc.TraceId + "-" + sc.SpanId + "-" + sc.Flags
+}
+
+// ParseTraceparent parses a traceparent header value. Invalid values, including the all-zero IDs, are rejected.
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	matches := traceparentPattern.FindStringSubmatch(strings.TrimSpace(traceparent))
+	if matches == nil || matches[1] == "ff" || (matches[1] == "00" && matches[5] != "") ||
+		matches[2] == strings.Repeat("0", 32) || matches[3] == strings.Repeat("0", 16) {
+		return SpanContext{}, errorutils.CheckErrorf("invalid traceparent '%s'", traceparent)
+	}
+	return SpanContext{TraceId: matches[2], SpanId: matches[3], Flags: matches[4]}, nil
+}
+
+// Span is the span of a command. Its context is propagated on the requests, and the request IDs returned by the servers are recorded.
+type Span struct {
+	name       string
+	context    SpanContext
+	tracestate string
+
+	mutex            sync.Mutex
+	requests         int
+	failedRequests   int
+	failedRequestIds []string
+}
+
+// newSpan returns the span of the command, or nil if SpanEnv isn't enabled.
+func newSpan(name string) (*Span, error) {
+	if enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(SpanEnv))); !enabled {
+		return nil, nil
+	}
+	span := &Span{name: name, tracestate: strings.TrimSpace(os.Getenv(TracestateEnv))}
+	parent, hasParent := SpanContext{}, false
+	if traceparent := strings.TrimSpace(os.Getenv(TraceparentEnv)); traceparent != "" {
+		var err error
+		if parent, err = ParseTraceparent(traceparent); err != nil {
+			log.Warn(fmt.Sprintf("Ignoring the trace context of %s: %s", TraceparentEnv, err.Error()))
+		} else {
+			hasParent = true
+		}
+	}
+	spanId, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	span.context = SpanContext{TraceId: parent.TraceId, SpanId: spanId, Flags: parent.Flags}
+	if !hasParent {
+		if span.context.TraceId, err = randomHex(16); err != nil {
+			return nil, err
+		}
+		span.context.Flags = "01"
+		span.tracestate = ""
+	}
+	return span, nil
+}
+
+// Start starts the span of the command and propagates its context on the requests sent using the server details, if SpanEnv is enabled.
+// The server details are modified in place, and restored by end, which also logs the summary of the span of the command.
+func Start(serverDetails *config.ServerDetails, commandName string) (end func() error, err error) {
+	end = func() error { return nil }
+	span, err := newSpan(commandName)
+	if err != nil || span == nil {
+		return
+	}
+	proxies, err := serverproxy.Start(serverDetails, "tracing", span.Transport)
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Tracing the command '%s' in the trace %s, span %s.", commandName, span.context.TraceId, span.context.SpanId))
+	return func() error {
+		err := proxies.Close()
+		span.End()
+		return err
+	}, nil
+}
+
+// Context returns the context of the span, which is propagated on the requests.
+func (s *Span) Context() SpanContext {
+	return s.context
+}
+
+// End logs the summary of the span: the number of requests sent, and the request IDs of the failed requests.
+func (s *Span) End() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	summary := fmt.Sprintf("The command '%s' sent %d requests in the trace %s", s.name, s.requests, s.context.TraceId)
+	if s.failedRequests == 0 {
+		log.Info(summary + ".")
+		return
+	}
+	summary = fmt.Sprintf("%s. %d requests failed", summary, s.failedRequests)
+	if len(s.failedRequestIds) == 0 {
+		log.Info(summary + ".")
+		return
+	}
+	listed := s.failedRequestIds
+	if len(listed) > maxListedFailedRequests {
+		listed = listed[:maxListedFailedRequests]
+	}
+	log.Info(fmt.Sprintf("%s, with the request IDs: %s", summary, strings.Join(listed, ", ")))
+}
+
+// Transport returns an http.RoundTripper which adds the trace context to the requests, and sends them through next.
+func (s *Span) Transport(next http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{next: next, span: s}
+}
+
+type tracingTransport struct {
+	next http.RoundTripper
+	span *Span
+}
+
+func (tt *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	outReq := req.Clone(req.Context())
+	outReq.Header.Set(traceparentHeader, tt.span.context.Traceparent())
+	if tt.span.tracestate != "" {
+		outReq.Header.Set(tracestateHeader, tt.span.tracestate)
+	} else {
+		outReq.Header.Del(tracestateHeader)
+	}
+	resp, err := tt.next.RoundTrip(outReq)
+	tt.span.recordResponse(req, resp, err)
+	return resp, err
+}
+
+func (s *Span) recordResponse(req *http.Request, resp *http.Response, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests++
+	if err != nil {
+		s.failedRequests++
+		log.Debug(fmt.Sprintf("Trace %s: %s %s failed: %s", s.context.TraceId, req.Method, req.URL.Path, err.Error()))
+		return
+	}
+	requestId := ResponseRequestId(resp)
+	log.Debug(fmt.Sprintf("Trace %s: %s %s returned %d, request ID: %s", s.context.TraceId, req.Method, req.URL.Path, resp.StatusCode, requestId))
+	if resp.StatusCode >= http.StatusBadRequest {
+		s.failedRequests++
+		if requestId != "" {
+			s.failedRequestIds = append(s.failedRequestIds, requestId)
+		}
+	}
+}
+
+// ResponseRequestId returns the ID the server returned for the request, or an empty string if it didn't return one.
+func ResponseRequestId(resp *http.Response) string {
+	for _, header := range []string{requestIdHeader, jfrogRequestIdHeader} {
+		if requestId := resp.Header.Get(header); requestId != "" {
+			return requestId
+		}
+	}
+	return ""
+}
+
+func randomHex(size int) (string, error) {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(id), nil
+}
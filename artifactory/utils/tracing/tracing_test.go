@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	spanContext, err := ParseTraceparent(testTraceparent)
+	require.NoError(t, err)
+	assert.Equal(t, SpanContext{TraceId: "4bf92f3577b34da6a3ce929d0e0e4736", SpanId: "00f067aa0ba902b7", Flags: "01"}, spanContext)
+	assert.Equal(t, testTraceparent, spanContext.Traceparent())
+
+	// Future versions may append fields.
+	_, err = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	assert.NoError(t, err)
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+	} {
+		_, err = ParseTraceparent(invalid)
+		assert.ErrorContains(t, err, "invalid traceparent", invalid)
+	}
+}
+
+func TestNewSpan(t *testing.T) {
+	t.Setenv(TraceparentEnv, "")
+	t.Setenv(TracestateEnv, "")
+	t.Setenv(SpanEnv, "")
+	span, err := newSpan("rt upload")
+	require.NoError(t, err)
+	assert.Nil(t, span)
+
+	// The trace context of the caller alone doesn't start a span.
+	t.Setenv(TraceparentEnv, testTraceparent)
+	t.Setenv(TracestateEnv, "vendor=value")
+	span, err = newSpan("rt upload")
+	require.NoError(t, err)
+	assert.Nil(t, span)
+
+	// The span of the command is a child of the span of the caller.
+	t.Setenv(SpanEnv, "true")
+	span, err = newSpan("rt upload")
+	require.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Context().TraceId)
+	assert.NotEqual(t, "00f067aa0ba902b7", span.Context().SpanId)
+	assert.Len(t, span.Context().SpanId, 16)
+	assert.Equal(t, "vendor=value", span.tracestate)
+
+	// Without a trace context of the caller, the span of the command starts a new trace.
+	t.Setenv(TraceparentEnv, "invalid")
+	span, err = newSpan("rt upload")
+	require.NoError(t, err)
+	assert.Len(t, span.Context().TraceId, 32)
+	assert.Equal(t, "01", span.Context().Flags)
+	assert.Empty(t, span.tracestate)
+}
+
+func TestStart(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(traceparentHeader))
+		if r.URL.Path == "/artifactory/api/missing" {
+			w.Header().Set(requestIdHeader, "request-404")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(jfrogRequestIdHeader, "request-200")
+	}))
+	defer server.Close()
+	t.Setenv(TraceparentEnv, testTraceparent)
+	t.Setenv(TracestateEnv, "")
+	t.Setenv(SpanEnv, "true")
+
+	serverDetails := &config.ServerDetails{Url: server.URL + "/", ArtifactoryUrl: server.URL + "/artifactory/"}
+	end, err := Start(serverDetails, "rt search")
+	require.NoError(t, err)
+	assert.NotEqual(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+	for _, requestPath := range []string{"api/system/ping", "api/missing"} {
+		resp, err := http.Get(serverDetails.ArtifactoryUrl + requestPath)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	require.NoError(t, end())
+	assert.Equal(t, server.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+
+	require.Len(t, received, 2)
+	assert.Equal(t, received[0], received[1])
+	spanContext, err := ParseTraceparent(received[0])
+	require.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceId)
+	assert.NotEqual(t, "00f067aa0ba902b7", spanContext.SpanId)
+}
+
+func TestRecordResponse(t *testing.T) {
+	span := &Span{name: "rt upload"}
+	request := httptest.NewRequest(http.MethodPut, "/artifactory/generic-local/file.zip", nil)
+	span.recordResponse(request, &http.Response{StatusCode: http.StatusCreated, Header: http.Header{requestIdHeader: {"request-1"}}}, nil)
+	span.recordResponse(request, &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{requestIdHeader: {"request-2"}}}, nil)
+	span.recordResponse(request, &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}, nil)
+	span.recordResponse(request, nil, assert.AnError)
+	assert.Equal(t, 4, span.requests)
+	assert.Equal(t, 3, span.failedRequests)
+	assert.Equal(t, []string{"request-2"}, span.failedRequestIds)
+}
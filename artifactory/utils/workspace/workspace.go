@@ -0,0 +1,262 @@
+// Package workspace persists the results of the commands in a state file, so that the next commands of the same pipeline can
+// reference them symbolically, such as @last-upload, rather than passing the paths between the steps with shell plumbing.
+// The workspace is enabled by setting its name in the WorkspaceEnv environment variable. Its state file is saved under the
+// persistent temp dir of JFrog CLI, with the build-info of the builds.
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/receipt"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// WorkspaceEnv is the name of the workspace of the commands. The commands share their results through the workspace of the same name.
+const WorkspaceEnv = "JFROG_CLI_WORKSPACE"
+
+// The results which the commands save to the workspace, and which are referenced as @<key>.
+const (
+	LastUpload = "last-upload"
+	LastCopy   = "last-copy"
+	LastMove   = "last-move"
+	LastDelete = "last-delete"
+	// The spec of the last command which ran with --spec, after its variables were replaced.
+	LastSpec = "last-spec"
+	// The <name>/<version> of the last release bundle which was created.
+	LastReleaseBundle = "last-release-bundle"
+
+	referencePrefix   = "@"
+	workspacesDirName = "workspaces"
+	stateFileName     = "state.json"
+	stateVersion      = 1
+)
+
+var (
+	keys          = []string{LastUpload, LastCopy, LastMove, LastDelete, LastSpec, LastReleaseBundle}
+	nameRegexp    = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	operationKeys = map[string]string{receipt.Upload: LastUpload, receipt.Copy: LastCopy, receipt.Move: LastMove, receipt.Delete: LastDelete}
+)
+
+// State is the content of the state file of a workspace.
+type State struct {
+	Version int               `json:"version"`
+	Entries map[string]*Entry `json:"entries"`
+}
+
+// Entry is a result saved by a command.
+type Entry struct {
+	Command   string `json:"command"`
+	UpdatedAt string `json:"updatedAt"`
+	// The paths in Artifactory, in the form of repo/path, of the artifacts which an upload, copy, move or delete operated on.
+	Paths []string        `json:"paths,omitempty"`
+	Spec  json.RawMessage `json:"spec,omitempty"`
+	Value string          `json:"value,omitempty"`
+}
+
+// IsEnabled returns true if the WorkspaceEnv environment variable is set.
+func IsEnabled() bool {
+	return os.Getenv(WorkspaceEnv) != ""
+}
+
+// ParseReference returns the key of the result referenced by value, if it's a reference such as @last-upload.
+func ParseReference(value string) (key string, isReference bool) {
+	key, isReference = strings.CutPrefix(value, referencePrefix)
+	if !isReference || !slices.Contains(keys, key) {
+		return "", false
+	}
+	return key, true
+}
+
+// Get returns the result of the workspace saved under the key.
+func Get(key string) (*Entry, error) {
+	if !IsEnabled() {
+		return nil, errorutils.CheckErrorf("the %s%s reference requires a workspace. Set the %s environment variable to the name of the workspace", referencePrefix, key, WorkspaceEnv)
+	}
+	dir, err := getWorkspaceDir()
+	if err != nil {
+		return nil, err
+	}
+	state, err := readState(dir)
+	if err != nil {
+		return nil, err
+	}
+	entry, exists := state.Entries[key]
+	if !exists {
+		return nil, errorutils.CheckErrorf("no command saved %s%s in the '%s' workspace yet", referencePrefix, key, os.Getenv(WorkspaceEnv))
+	}
+	return entry, nil
+}
+
+// Save saves the result of the command under the key. Does nothing if the workspace isn't enabled.
+func Save(key string, entry Entry) (err error) {
+	if !IsEnabled() {
+		return nil
+	}
+	dir, err := getWorkspaceDir()
+	if err != nil {
+		return err
+	}
+	// The lock is shared by the processes of the workspace, so that the results of concurrent commands aren't lost.
+	unlock, err := lock.CreateLock(filepath.Join(dir, "lock"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	state, err := readState(dir)
+	if err != nil {
+		return err
+	}
+	entry.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	state.Entries[key] = &entry
+	log.Debug("Saving", referencePrefix+key, "to the workspace", os.Getenv(WorkspaceEnv))
+	return writeState(dir, state)
+}
+
+// SaveReceipt saves the paths of the artifacts which the operations of the receipt succeeded on, under the key of each operation,
+// such as LastUpload.
+func SaveReceipt(commandReceipt *receipt.Receipt) error {
+	paths := make(map[string][]string)
+	for _, item := range commandReceipt.Items {
+		if item.Succeeded() {
+			paths[item.Operation] = append(paths[item.Operation], item.Path)
+		}
+	}
+	for operation, operationPaths := range paths {
+		key, exists := operationKeys[operation]
+		if !exists {
+			continue
+		}
+		if err := Save(key, Entry{Command: commandReceipt.Command, Paths: operationPaths}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveSpec saves the spec of the command as LastSpec.
+func SaveSpec(command string, specFiles *spec.SpecFiles) error {
+	if !IsEnabled() {
+		return nil
+	}
+	content, err := json.Marshal(specFiles)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return Save(LastSpec, Entry{Command: command, Spec: content})
+}
+
+// GetSpec returns the spec saved as LastSpec.
+func GetSpec() (*spec.SpecFiles, error) {
+	entry, err := Get(LastSpec)
+	if err != nil {
+		return nil, err
+	}
+	specFiles := new(spec.SpecFiles)
+	return specFiles, errorutils.CheckError(json.Unmarshal(entry.Spec, specFiles))
+}
+
+// ResolveValue returns the value saved under the key of the reference, or the value itself if it isn't a reference.
+func ResolveValue(value string) (string, error) {
+	key, isReference := ParseReference(value)
+	if !isReference {
+		return value, nil
+	}
+	entry, err := Get(key)
+	if err != nil {
+		return "", err
+	}
+	if entry.Value == "" {
+		return "", errorutils.CheckErrorf("the %s reference can't be used as a value", value)
+	}
+	return entry.Value, nil
+}
+
+// ExpandSpec replaces the files of the spec whose pattern references saved paths, such as @last-upload, by a file for each of the
+// paths, with the other fields of the referencing file. The bundle fields which reference a release bundle are resolved as well.
+func ExpandSpec(specFiles *spec.SpecFiles) (*spec.SpecFiles, error) {
+	expanded := make([]spec.File, 0, len(specFiles.Files))
+	for _, file := range specFiles.Files {
+		var err error
+		if file.Bundle, err = ResolveValue(file.Bundle); err != nil {
+			return nil, err
+		}
+		key, isReference := ParseReference(file.Pattern)
+		if !isReference {
+			expanded = append(expanded, file)
+			continue
+		}
+		entry, err := Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(entry.Paths) == 0 {
+			return nil, errorutils.CheckErrorf("the %s reference can't be used as a pattern", file.Pattern)
+		}
+		for _, path := range entry.Paths {
+			pathFile := file
+			pathFile.Pattern = path
+			expanded = append(expanded, pathFile)
+		}
+	}
+	specFiles.Files = expanded
+	return specFiles, nil
+}
+
+func getWorkspaceDir() (string, error) {
+	name := os.Getenv(WorkspaceEnv)
+	if !nameRegexp.MatchString(name) {
+		return "", errorutils.CheckErrorf("the value of %s must consist of letters, digits, dots, underscores and dashes, got '%s'", WorkspaceEnv, name)
+	}
+	dir := filepath.Join(coreutils.GetCliPersistentTempDirPath(), workspacesDirName, name)
+	return dir, errorutils.CheckError(os.MkdirAll(dir, 0700))
+}
+
+func readState(dir string) (*State, error) {
+	state := &State{Version: stateVersion, Entries: make(map[string]*Entry)}
+	statePath := filepath.Join(dir, stateFileName)
+	content, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, errorutils.CheckErrorf("failed to read the workspace state file %s: %s", statePath, err.Error())
+	}
+	if err = json.Unmarshal(content, state); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the workspace state file %s: %s", statePath, err.Error())
+	}
+	if state.Entries == nil {
+		state.Entries = make(map[string]*Entry)
+	}
+	return state, nil
+}
+
+// writeState writes the state to a temporary file, which then replaces the state file, so that the readers never see a partially
+// written state.
+func writeState(dir string, state *State) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempFile, err := os.CreateTemp(dir, "."+stateFileName+".*.tmp")
+	if err != nil {
+		return errorutils.CheckErrorf("failed to create the workspace state file: %s", err.Error())
+	}
+	_, err = tempFile.Write(append(content, '\n'))
+	if err = errors.Join(err, tempFile.Close()); err != nil {
+		return errors.Join(errorutils.CheckErrorf("failed to write the workspace state file: %s", err.Error()), os.Remove(tempFile.Name()))
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), filepath.Join(dir, stateFileName)))
+}
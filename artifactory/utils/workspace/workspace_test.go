@@ -0,0 +1,95 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/receipt"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestWorkspace(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "test-"+t.Name())
+	dir, err := getWorkspaceDir()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	})
+}
+
+func TestParseReference(t *testing.T) {
+	key, isReference := ParseReference("@last-upload")
+	assert.True(t, isReference)
+	assert.Equal(t, LastUpload, key)
+	_, isReference = ParseReference("@scope/package")
+	assert.False(t, isReference)
+	_, isReference = ParseReference("last-upload")
+	assert.False(t, isReference)
+}
+
+func TestWorkspaceDisabled(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "")
+	assert.NoError(t, Save(LastUpload, Entry{Paths: []string{"generic-local/a.bin"}}))
+	_, err := Get(LastUpload)
+	assert.ErrorContains(t, err, WorkspaceEnv)
+	// Specs without references don't require a workspace.
+	specFiles, err := ExpandSpec(spec.NewBuilder().Pattern("generic-local/*.bin").BuildSpec())
+	require.NoError(t, err)
+	assert.Equal(t, "generic-local/*.bin", specFiles.Files[0].Pattern)
+}
+
+func TestSaveReceiptAndExpandSpec(t *testing.T) {
+	setTestWorkspace(t)
+	_, err := ExpandSpec(spec.NewBuilder().Pattern("@last-upload").BuildSpec())
+	assert.ErrorContains(t, err, "no command saved @last-upload")
+
+	require.NoError(t, SaveReceipt(&receipt.Receipt{Command: "upload", Items: []receipt.Item{
+		{Operation: receipt.Upload, Path: "generic-local/a.bin", Status: 201},
+		{Operation: receipt.Upload, Path: "generic-local/b.bin", Status: 500},
+		{Operation: receipt.Upload, Path: "generic-local/c.bin", Status: 201},
+	}}))
+	entry, err := Get(LastUpload)
+	require.NoError(t, err)
+	assert.Equal(t, "upload", entry.Command)
+	assert.Equal(t, []string{"generic-local/a.bin", "generic-local/c.bin"}, entry.Paths)
+
+	specFiles, err := ExpandSpec(spec.NewBuilder().Pattern("@last-upload").Target("generic-release/").Flat(true).BuildSpec())
+	require.NoError(t, err)
+	require.Len(t, specFiles.Files, 2)
+	assert.Equal(t, "generic-local/a.bin", specFiles.Files[0].Pattern)
+	assert.Equal(t, "generic-local/c.bin", specFiles.Files[1].Pattern)
+	assert.Equal(t, "generic-release/", specFiles.Files[1].Target)
+	assert.Equal(t, "true", specFiles.Files[1].Flat)
+}
+
+func TestSaveSpecAndResolveValue(t *testing.T) {
+	setTestWorkspace(t)
+	require.NoError(t, SaveSpec("download", spec.NewBuilder().Pattern("generic-local/*.bin").Target("out/").BuildSpec()))
+	specFiles, err := GetSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "generic-local/*.bin", specFiles.Files[0].Pattern)
+	assert.Equal(t, "out/", specFiles.Files[0].Target)
+
+	require.NoError(t, Save(LastReleaseBundle, Entry{Command: "rbc", Value: "app/1.0.0"}))
+	value, err := ResolveValue("@last-release-bundle")
+	require.NoError(t, err)
+	assert.Equal(t, "app/1.0.0", value)
+	value, err = ResolveValue("app/2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "app/2.0.0", value)
+	_, err = ResolveValue("@last-spec")
+	assert.ErrorContains(t, err, "can't be used as a value")
+
+	specFiles, err = ExpandSpec(spec.NewBuilder().Bundle("@last-release-bundle").BuildSpec())
+	require.NoError(t, err)
+	assert.Equal(t, "app/1.0.0", specFiles.Files[0].Bundle)
+	_, err = ExpandSpec(spec.NewBuilder().Pattern("@last-release-bundle").BuildSpec())
+	assert.ErrorContains(t, err, "can't be used as a pattern")
+}
+
+func TestInvalidWorkspaceName(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "../other")
+	assert.ErrorContains(t, Save(LastUpload, Entry{}), "must consist of letters")
+}
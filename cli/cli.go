@@ -2,10 +2,13 @@ package cli
 
 import (
 	artifactoryCLI "github.com/jfrog/jfrog-cli-artifactory/artifactory/cli"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/serverproxy"
 	distributionCLI "github.com/jfrog/jfrog-cli-artifactory/distribution/cli"
 	ideCLI "github.com/jfrog/jfrog-cli-artifactory/ide/cli"
 	"github.com/jfrog/jfrog-cli-artifactory/lifecycle"
+	packagesCLI "github.com/jfrog/jfrog-cli-artifactory/packages/cli"
 	skillsCLI "github.com/jfrog/jfrog-cli-artifactory/skills/cli"
+	workersCLI "github.com/jfrog/jfrog-cli-artifactory/workers/cli"
 	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 )
@@ -41,7 +44,55 @@ func GetJfrogCliArtifactoryApp() components.App {
 		Commands:    skillsCLI.GetCommands(),
 		Category:    "Command Namespaces",
 	})
+	app.Subcommands = append(app.Subcommands, components.Namespace{
+		Name:        "worker",
+		Aliases:     []string{"workers"},
+		Description: "Workers commands.",
+		Commands:    workersCLI.GetCommands(),
+		Category:    "Command Namespaces",
+	})
+	app.Subcommands = append(app.Subcommands, components.Namespace{
+		Name:        "package",
+		Aliases:     []string{"pkg"},
+		Description: "Package commands.",
+		Commands:    packagesCLI.GetCommands(),
+		Category:    "Command Namespaces",
+	})
+	app.Subcommands = append(app.Subcommands, components.Namespace{
+		Name:        "pypi",
+		Description: "PyPI commands.",
+		Commands:    artifactoryCLI.GetPypiCommands(),
+		Category:    "Command Namespaces",
+	})
+	app.Subcommands = append(app.Subcommands, components.Namespace{
+		Name:        "oci",
+		Description: "OCI artifacts commands.",
+		Commands:    artifactoryCLI.GetOciCommands(),
+		Category:    "Command Namespaces",
+	})
 	app.Commands = append(app.Commands, lifecycle.GetCommands()...)
 
+	app.Commands = artifactoryCLI.WithOutputMode(withForwardProxy(app.Commands))
+	for i := range app.Subcommands {
+		app.Subcommands[i].Commands = artifactoryCLI.WithOutputMode(withForwardProxy(app.Subcommands[i].Commands))
+	}
 	return app
 }
+
+// withForwardProxy starts the forward proxy of the dual-stack dialer before the actions of the commands, so that the connections
+// of all the commands are opened by the dialer, if it's configured by the environment.
+func withForwardProxy(commands []components.Command) []components.Command {
+	for i := range commands {
+		action := commands[i].Action
+		if action == nil {
+			continue
+		}
+		commands[i].Action = func(c *components.Context) error {
+			if err := serverproxy.StartForwardProxy(); err != nil {
+				return err
+			}
+			return action(c)
+		}
+	}
+	return commands
+}
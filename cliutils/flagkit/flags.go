@@ -51,6 +51,24 @@ const (
 	Gradle                 = "gradle"
 	GradleConfig           = "gradle-config"
 	DockerPromote          = "docker-promote"
+	DockerCleanup          = "docker-cleanup"
+	DockerTagRetention     = "docker-tag-retention"
+	DockerCopy             = "docker-copy"
+	DockerWarmCache        = "docker-warm-cache"
+	DeploymentManifest     = "deployment-manifest"
+	DependenciesPrefetch   = "dependencies-prefetch"
+	AirGapExport           = "airgap-export"
+	AirGapImport           = "airgap-import"
+	ArtifactDiff           = "artifact-diff"
+	ManifestSync           = "manifest-sync"
+	ChecksumSearch         = "checksum-search"
+	LatestUpdate           = "latest-update"
+	NpmDistTag             = "npm-dist-tag"
+	NpmBundle              = "npm-bundle"
+	OnboardingAnalyze      = "onboarding-analyze"
+	MvnPromote             = "mvn-promote"
+	JdkProvision           = "jdk-provision"
+	NodeProvision          = "node-provision"
 	Docker                 = "docker"
 	DockerPush             = "docker-push"
 	DockerPull             = "docker-pull"
@@ -89,6 +107,8 @@ const (
 	RepoCreate             = "repo-create"
 	RepoUpdate             = "repo-update"
 	RepoDelete             = "repo-delete"
+	RepoCreateSet          = "repo-create-set"
+	RepoCreateRemote       = "repo-create-remote"
 	ReplicationDelete      = "replication-delete"
 	PermissionTargetDelete = "permission-target-delete"
 	// #nosec G101 -- False positive - no hardcoded credentials.
@@ -139,6 +159,8 @@ const (
 	ClientCertPath    = "client-cert-path"
 	ClientCertKeyPath = "client-cert-key-path"
 	InsecureTls       = "insecure-tls"
+	captureHar        = "capture-har"
+	receipt           = "receipt"
 
 	// Sort & limit flags
 	sortBy    = "sort-by"
@@ -172,6 +194,8 @@ const (
 	props                   = "props"
 	targetProps             = "target-props"
 	excludeProps            = "exclude-props"
+	propsExpr               = "props-expr"
+	pointInTime             = "point-in-time"
 	repoOnly                = "repo-only"
 	failNoOp                = "fail-no-op"
 	threads                 = "threads"
@@ -190,6 +214,7 @@ const (
 	MinSplit                = "min-split"
 	SplitCount              = "split-count"
 	chunkSize               = "chunk-size"
+	overrideProtection      = "override-protection"
 
 	// Config flags
 	interactive   = "interactive"
@@ -212,6 +237,15 @@ const (
 	deb               = "deb"
 	symlinks          = "symlinks"
 	uploadAnt         = uploadPrefix + antFlag
+	routingRules      = "routing-rules"
+
+	// Transfer validation report flags, shared by the upload and download commands
+	validationReport       = "validation-report"
+	validationReportKey    = "validation-report-key"
+	validationReportTarget = "validation-report-target"
+
+	uploadResume       = "resume"
+	uploadExtractProps = "extract-props"
 
 	// Unique download flags
 	downloadPrefix       = "download-"
@@ -220,11 +254,16 @@ const (
 	downloadExplode      = downloadPrefix + explode
 	downloadProps        = downloadPrefix + props
 	downloadExcludeProps = downloadPrefix + excludeProps
+	downloadPropsExpr    = downloadPrefix + propsExpr
+	downloadPointInTime  = downloadPrefix + pointInTime
 	downloadSyncDeletes  = downloadPrefix + syncDeletes
 	downloadMinSplit     = downloadPrefix + MinSplit
 	downloadSplitCount   = downloadPrefix + SplitCount
 	validateSymlinks      = "validate-symlinks"
 	skipChecksum          = "skip-checksum"
+	expectedArtifacts     = "expected-artifacts"
+	validateOnly          = "validate-only"
+	folderArchive         = "folder-archive"
 
 	// Unique move flags
 	movePrefix       = "move-"
@@ -253,6 +292,8 @@ const (
 	searchRecursive    = searchPrefix + Recursive
 	searchProps        = searchPrefix + props
 	searchExcludeProps = searchPrefix + excludeProps
+	searchPropsExpr    = searchPrefix + propsExpr
+	searchPointInTime  = searchPrefix + pointInTime
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
 
@@ -261,9 +302,19 @@ const (
 	propsRecursive    = propertiesPrefix + Recursive
 	propsProps        = propertiesPrefix + props
 	propsExcludeProps = propertiesPrefix + excludeProps
+	batchSize         = "batch-size"
+	recursiveFolders  = "recursive-folders"
 
 	// Unique go publish flags
 	goPublishExclusions = GoPublish + exclusions
+	goPublishAllModules = GoPublish + "-all-modules"
+
+	// Unique go config flags
+	goConfigPrefix     = "go-config-"
+	goConfigPrivate    = goConfigPrefix + "go-private"
+	goConfigNoSumDb    = goConfigPrefix + "go-no-sumdb"
+	goConfigGoFlags    = goConfigPrefix + "go-flags"
+	goConfigSumDbProxy = goConfigPrefix + "sumdb-proxy"
 
 	// Unique build-publish flags
 	buildPublishPrefix = "bp-"
@@ -279,6 +330,8 @@ const (
 	dotGitPath         = "dot-git-path"
 	gitConfigFilePath  = "git-config-file-path"
 	depExclude         = "dep-exclude-scopes"
+	depSources         = "dependency-sources"
+	depSourcesReport   = "dependency-sources-report"
 
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
@@ -346,10 +399,37 @@ const (
 	ivyDescPattern      = "ivy-desc-pattern"
 	ivyArtifactsPattern = "ivy-artifacts-pattern"
 
+	// Unique mvn flags
+	effectivePom            = "effective-pom"
+	useMvnd                 = "use-mvnd"
+	saveDeployableArtifacts = "save-deployable-artifacts"
+	fromFile                = "from-file"
+	jdk                     = "jdk"
+	jdkVersion              = "jdk-version"
+	jdkVendor               = "jdk-vendor"
+	mvnExcludeArtifacts     = "mvn-" + excludeArtifacts
+
+	// Mvn and gradle flags
+	buildTimeout = "build-timeout"
+
+	// Unique gradle flags
+	includeCompositeBuilds = "include-composite-builds"
+	dependencyGraph        = "dependency-graph"
+	extractorPath          = "extractor-path"
+	includePublications    = "include-publications"
+	excludePublications    = "exclude-publications"
+	gradleDryRun           = "gradle-" + dryRun
+	gradleTargetProps      = "gradle-" + targetProps
+	resumeFrom             = "resume-from"
+	skipIdentical          = "skip-identical"
+	publishBuildInfo       = "publish-build-info"
+
 	// Build tool flags
 	deploymentThreads = "deployment-threads"
 	skipLogin         = "skip-login"
 	validateSha       = "validate-sha"
+	sbom              = "sbom"
+	generateSbom      = "generate-sbom"
 
 	// Unique docker promote flags
 	dockerPromotePrefix = "docker-promote-"
@@ -358,6 +438,93 @@ const (
 	targetTag           = "target-tag"
 	dockerPromoteCopy   = dockerPromotePrefix + Copy
 
+	// Unique docker cleanup flags
+	dockerCleanupPrefix = "docker-cleanup-"
+	dockerCleanupImage  = dockerCleanupPrefix + "image"
+	dockerCleanupDelete = dockerCleanupPrefix + Delete
+	dockerCleanupQuiet  = dockerCleanupPrefix + quiet
+
+	// Unique docker tag retention flags
+	dockerTagRetentionPrefix          = "docker-tag-retention-"
+	dockerTagRetentionImage           = dockerTagRetentionPrefix + "image"
+	dockerTagRetentionKeepLast        = "keep-last"
+	dockerTagRetentionOlderThan       = "older-than"
+	dockerTagRetentionProtectReleases = "protect-releases"
+	dockerTagRetentionDryRun          = dockerTagRetentionPrefix + dryRun
+	dockerTagRetentionQuiet           = dockerTagRetentionPrefix + quiet
+
+	// Unique docker copy flags
+	dockerCopyTargetServerId = "target-server-id"
+
+	// Unique docker warm cache flags
+	dockerWarmCacheThreads = "docker-warm-cache-" + threads
+
+	// Unique deployment manifest flags
+	deploymentManifestPrefix = "dm-"
+	deploymentManifestBuild  = deploymentManifestPrefix + build
+	deploymentManifestBundle = deploymentManifestPrefix + bundle
+	deploymentManifestOutput = deploymentManifestPrefix + "output"
+
+	// Unique dependencies prefetch flags
+	dependenciesPrefetchPrefix  = "dependencies-prefetch-"
+	dependenciesPrefetchThreads = dependenciesPrefetchPrefix + threads
+
+	// Unique air-gap flags
+	airGapExportPrefix  = "airgap-export-"
+	airGapExportBuild   = airGapExportPrefix + build
+	airGapExportRepo    = airGapExportPrefix + repo
+	airGapExportThreads = airGapExportPrefix + threads
+	airGapImportPrefix  = "airgap-import-"
+	airGapImportRepo    = airGapImportPrefix + repo
+	airGapImportThreads = airGapImportPrefix + threads
+
+	// Unique artifact diff flags
+	artifactDiffFailOnDiff = "fail-on-diff"
+
+	// Unique checksum search flags
+	checksumSearchRepos = "checksum-search-repos"
+
+	// Unique latest update flags
+	latestUpdatePrefix   = "latest-update-"
+	latestUpdateMode     = latestUpdatePrefix + "mode"
+	latestUpdateSortBy   = latestUpdatePrefix + "sort-by"
+	latestUpdateProperty = latestUpdatePrefix + "property"
+	latestUpdateDryRun   = latestUpdatePrefix + dryRun
+
+	// Unique npm bundle flags
+	npmBundleArchive = "npm-bundle-" + archive
+
+	// Unique onboarding analyze flags
+	onboardingAnalyzePrefix    = "onboarding-analyze-"
+	onboardingAnalyzeOutputDir = onboardingAnalyzePrefix + "output-dir"
+	onboardingAnalyzeBuildName = onboardingAnalyzePrefix + BuildName
+
+	// Unique manifest sync flags
+	manifestSyncPrefix  = "manifest-sync-"
+	manifestSyncDryRun  = manifestSyncPrefix + dryRun
+	manifestSyncQuiet   = manifestSyncPrefix + quiet
+	manifestSyncThreads = manifestSyncPrefix + threads
+
+	// Unique mvn promote flags
+	mvnPromotePrefix            = "mvn-promote-"
+	mvnPromoteReleaseVersion    = mvnPromotePrefix + "release-version"
+	mvnPromoteTargetBuildName   = mvnPromotePrefix + "target-" + BuildName
+	mvnPromoteTargetBuildNumber = mvnPromotePrefix + "target-" + BuildNumber
+	mvnPromoteDryRun            = mvnPromotePrefix + dryRun
+	mvnPromoteThreads           = mvnPromotePrefix + threads
+
+	// Unique jdk provision flags
+	jdkProvisionPrefix = "jdk-provision-"
+	jdkProvisionVendor = jdkProvisionPrefix + "vendor"
+	jdkProvisionOs     = jdkProvisionPrefix + "os"
+	jdkProvisionArch   = jdkProvisionPrefix + "arch"
+	jdkProvisionLayout = jdkProvisionPrefix + "layout"
+
+	// Unique node provision flags
+	nodeProvisionPrefix = "node-provision-"
+	nodeProvisionOs     = nodeProvisionPrefix + "os"
+	nodeProvisionArch   = nodeProvisionPrefix + "arch"
+
 	// Unique build docker create
 	imageFile = "image-file"
 
@@ -371,6 +538,9 @@ const (
 	runNative          = "run-native"
 	npmWorkspaces      = "workspaces"
 	disableCVSCheck    = "disable-cvs-check"
+	npmBlockedProps    = "blocked-props"
+	npmRequiredProps   = "required-props"
+	npmOnBlocked       = "on-blocked"
 
 	// Unique nuget/dotnet config flags
 	nugetV2                  = "nuget-v2"
@@ -387,6 +557,13 @@ const (
 	// Template user flags
 	vars = "vars"
 
+	// Unique repo-create-set and repo-create-remote flags
+	remoteUrl                 = "remote-url"
+	remotePreset              = "preset"
+	repoPackageType           = "package-type"
+	repoCreateSetPrefix       = "repo-create-set-"
+	repoCreateSetIncludeRepos = repoCreateSetPrefix + IncludeRepos
+
 	// User Management flags
 	csv            = "csv"
 	usersCreateCsv = "users-create-csv"
@@ -502,6 +679,9 @@ const (
 	SourceTypeBuilds         = "source-type-builds"
 	Draft                    = "draft"
 	AddSources               = "add"
+	PublicKey                = "public-key"
+	lcPublicKey              = lifecyclePrefix + PublicKey
+	lcFormat                 = lifecyclePrefix + Format
 
 	// Skills commands keys
 	SkillsPublish = "skills-publish"
@@ -530,6 +710,45 @@ const (
 	skillsSortOrder     = "skills-" + sortOrder
 	skillsCheckUpdates  = "skills-check-updates"
 	checkUpdates        = "check-updates"
+
+	// Workers commands keys
+	WorkerDeploy  = "worker-deploy"
+	WorkerList    = "worker-list"
+	WorkerTestRun = "worker-test-run"
+	WorkerRemove  = "worker-remove"
+
+	// Workers-specific flags
+	workerFormat  = "worker-" + Format
+	WorkerPayload = "payload"
+	workerQuiet   = "worker-" + quiet
+
+	// Package commands keys
+	PackageVersions  = "package-versions"
+	PackageDeprecate = "package-deprecate"
+
+	// Package-specific flags
+	packageFormat          = "package-" + Format
+	PackageType            = "type"
+	PackageLatest          = "latest"
+	packageDeprecationType = "package-deprecation-type"
+	PackageRepo            = "repo"
+	packageRepo            = "package-" + PackageRepo
+	PackageMessage         = "message"
+	PackageFile            = "file"
+
+	// PyPI commands keys
+	PypiUpload = "pypi-upload"
+
+	// PyPI-specific flags
+	pypiUploadRepo = "pypi-upload-" + PackageRepo
+
+	// OCI commands keys
+	OciPush = "oci-push"
+	OciPull = "oci-pull"
+
+	// OCI-specific flags
+	OciArtifactType = "artifact-type"
+	OciAnnotations  = "annotations"
 )
 
 var commandFlags = map[string][]string{
@@ -589,6 +808,9 @@ var commandFlags = map[string][]string{
 	cmddefs.ReleaseBundleAnnotate: {
 		platformUrl, user, password, accessToken, serverId, lcProject, lcTag, lcProperties, lcDeleteProperties, propsRecursive,
 	},
+	cmddefs.ReleaseBundleVerify: {
+		platformUrl, user, password, accessToken, serverId, lcProject, lcPublicKey, lcFormat,
+	},
 	AddConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
 		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin,
@@ -605,15 +827,16 @@ var commandFlags = map[string][]string{
 		ClientCertKeyPath, specFlag, specVars, BuildName, BuildNumber, module, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, retryWaitTime, dryRun, uploadExplode, symlinks, includeDirs,
 		failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, Project,
-		uploadAnt, uploadArchive, uploadMinSplit, uploadSplitCount, chunkSize,
+		uploadAnt, uploadArchive, uploadMinSplit, uploadSplitCount, chunkSize, routingRules, captureHar,
+		validationReport, validationReportKey, validationReportTarget, uploadResume, uploadExtractProps, receipt,
 	},
 	Download: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, BuildName, BuildNumber, module, exclusions, sortBy,
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, downloadMinSplit, downloadSplitCount,
 		retries, retryWaitTime, dryRun, downloadExplode, bypassArchiveInspection, validateSymlinks, bundle, publicGpgKey, includeDirs,
-		downloadProps, downloadExcludeProps, failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, Project,
-		skipChecksum,
+		downloadProps, downloadExcludeProps, downloadPropsExpr, downloadPointInTime, failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, Project,
+		skipChecksum, captureHar, validationReport, validationReportKey, validationReportTarget, expectedArtifacts, validateOnly, folderArchive,
 	},
 	DirectDownload: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -626,35 +849,35 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, Project,
+		InsecureTls, retries, retryWaitTime, Project, captureHar, overrideProtection, receipt,
 	},
 	Copy: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, copyRecursive,
 		copyFlat, dryRun, build, includeDeps, excludeArtifacts, bundle, copyProps, copyExcludeProps, failNoOp, threads,
-		archiveEntries, InsecureTls, retries, retryWaitTime, Project,
+		archiveEntries, InsecureTls, retries, retryWaitTime, Project, captureHar, receipt,
 	},
 	Delete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, Project,
+		InsecureTls, retries, retryWaitTime, Project, captureHar, overrideProtection, receipt,
 	},
 	Search: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
-		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		InsecureTls, searchTransitive, retries, retryWaitTime, Project, searchInclude,
+		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, searchPropsExpr, searchPointInTime, failNoOp, archiveEntries,
+		InsecureTls, searchTransitive, retries, retryWaitTime, Project, searchInclude, captureHar,
 	},
 	Properties: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
-		InsecureTls, retries, retryWaitTime, Project, repoOnly,
+		InsecureTls, retries, retryWaitTime, Project, repoOnly, batchSize, recursiveFolders, captureHar,
 	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, bpOverwrite, collectEnv, collectGitInfo, gitConfigFilePath, dotGitPath, depExclude,
+		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, bpOverwrite, collectEnv, collectGitInfo, gitConfigFilePath, dotGitPath, depExclude, depSources, depSourcesReport,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -706,10 +929,12 @@ var commandFlags = map[string][]string{
 		deployIvyDesc, ivyDescPattern, ivyArtifactsPattern,
 	},
 	Mvn: {
-		BuildName, BuildNumber, deploymentThreads, InsecureTls, Project, detailedSummary, xrayScan, xrOutput,
+		BuildName, BuildNumber, deploymentThreads, InsecureTls, Project, detailedSummary, xrayScan, xrOutput, effectivePom, useMvnd, buildTimeout,
+		saveDeployableArtifacts, fromFile, jdk, jdkVersion, jdkVendor, mvnExcludeArtifacts,
 	},
 	Gradle: {
-		BuildName, BuildNumber, deploymentThreads, Project, detailedSummary, xrayScan, xrOutput,
+		BuildName, BuildNumber, deploymentThreads, Project, detailedSummary, xrayScan, xrOutput, includeCompositeBuilds, dependencyGraph,
+		extractorPath, includePublications, excludePublications, gradleDryRun, gradleTargetProps, retries, retryWaitTime, resumeFrom, skipIdentical, publishBuildInfo, buildTimeout,
 	},
 	Docker: {
 		BuildName, BuildNumber, module, Project,
@@ -727,9 +952,69 @@ var commandFlags = map[string][]string{
 		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, url, user, password, accessToken, sshPassphrase, sshKeyPath,
 		serverId,
 	},
+	DockerCleanup: {
+		dockerCleanupImage, dockerCleanupDelete, dockerCleanupQuiet, url, user, password, accessToken, sshPassphrase, sshKeyPath,
+		serverId, InsecureTls,
+	},
+	AirGapExport: {
+		airGapExportBuild, Project, airGapExportRepo, airGapExportThreads, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	AirGapImport: {
+		airGapImportRepo, airGapImportThreads, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	ArtifactDiff: {
+		artifactDiffFailOnDiff, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	ManifestSync: {
+		manifestSyncDryRun, manifestSyncQuiet, manifestSyncThreads, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	ChecksumSearch: {
+		checksumSearchRepos, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	LatestUpdate: {
+		latestUpdateMode, latestUpdateSortBy, latestUpdateProperty, latestUpdateDryRun, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	NpmDistTag: {
+		BuildName, BuildNumber, Project, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	NpmBundle: {
+		npmBundleArchive, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	OnboardingAnalyze: {
+		onboardingAnalyzeOutputDir, onboardingAnalyzeBuildName, serverId,
+	},
+	MvnPromote: {
+		mvnPromoteReleaseVersion, mvnPromoteTargetBuildName, mvnPromoteTargetBuildNumber, Project, mvnPromoteDryRun, mvnPromoteThreads,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	JdkProvision: {
+		jdkProvisionVendor, jdkProvisionOs, jdkProvisionArch, jdkProvisionLayout, BuildName, BuildNumber, Project,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	NodeProvision: {
+		nodeProvisionOs, nodeProvisionArch, BuildName, BuildNumber, Project,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	DependenciesPrefetch: {
+		dependenciesPrefetchThreads, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	DeploymentManifest: {
+		deploymentManifestBuild, deploymentManifestBundle, deploymentManifestOutput, Project, url, user, password, accessToken,
+		sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	DockerTagRetention: {
+		dockerTagRetentionImage, dockerTagRetentionKeepLast, dockerTagRetentionOlderThan, dockerTagRetentionProtectReleases,
+		dockerTagRetentionDryRun, dockerTagRetentionQuiet, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	DockerCopy: {
+		dockerCopyTargetServerId, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	DockerWarmCache: {
+		dockerWarmCacheThreads, url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
 	ContainerPush: {
 		BuildName, BuildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, skipLogin, threads, Project, detailedSummary, validateSha,
+		serverId, skipLogin, threads, Project, detailedSummary, validateSha, sbom, generateSbom,
 	},
 	ContainerPull: {
 		BuildName, BuildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
@@ -739,7 +1024,7 @@ var commandFlags = map[string][]string{
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	NpmInstallCi: {
-		BuildName, BuildNumber, module, Project, runNative, disableCVSCheck,
+		BuildName, BuildNumber, module, Project, runNative, disableCVSCheck, npmBlockedProps, npmRequiredProps, npmOnBlocked,
 	},
 	NpmPublish: {
 		BuildName, BuildNumber, module, Project, npmDetailedSummary, xrayScan, xrOutput, runNative, npmWorkspaces,
@@ -748,10 +1033,10 @@ var commandFlags = map[string][]string{
 		global, serverIdResolve, repoResolve,
 	},
 	Pnpm: {
-		BuildName, BuildNumber, module, Project,
+		BuildName, BuildNumber, module, Project, xrayScan, xrOutput,
 	},
 	YarnConfig: {
-		global, serverIdResolve, repoResolve,
+		global, serverIdResolve, repoResolve, serverIdDeploy, repoDeploy,
 	},
 	Yarn: {
 		BuildName, BuildNumber, module, Project,
@@ -769,10 +1054,10 @@ var commandFlags = map[string][]string{
 		BuildName, BuildNumber, module, Project,
 	},
 	GoConfig: {
-		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy, goConfigPrivate, goConfigNoSumDb, goConfigGoFlags, goConfigSumDbProxy,
 	},
 	GoPublish: {
-		url, user, password, accessToken, BuildName, BuildNumber, module, Project, detailedSummary, goPublishExclusions,
+		url, user, password, accessToken, BuildName, BuildNumber, module, Project, detailedSummary, goPublishExclusions, goPublishAllModules,
 	},
 	Go: {
 		BuildName, BuildNumber, module, Project, noFallback,
@@ -789,7 +1074,7 @@ var commandFlags = map[string][]string{
 	},
 	Ping: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
-		ClientCertKeyPath, InsecureTls,
+		ClientCertKeyPath, InsecureTls, captureHar,
 	},
 	NugetDepsTree: {},
 	RtCurl: {
@@ -833,6 +1118,14 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, deleteQuiet,
 	},
+	RepoCreateSet: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, remoteUrl, remotePreset, repoCreateSetIncludeRepos, Project,
+	},
+	RepoCreateRemote: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, repoPackageType, Project,
+	},
 	ReplicationDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, deleteQuiet,
@@ -889,6 +1182,36 @@ var commandFlags = map[string][]string{
 	SkillsList: {
 		url, user, password, accessToken, serverId, repo, agent, projectDir, skillsGlobal, skillsFormat, skillsLimit, skillsSortBy, skillsSortOrder, skillsCheckUpdates,
 	},
+	WorkerDeploy: {
+		url, user, password, accessToken, serverId,
+	},
+	WorkerList: {
+		url, user, password, accessToken, serverId, workerFormat,
+	},
+	WorkerTestRun: {
+		url, user, password, accessToken, serverId, WorkerPayload,
+	},
+	WorkerRemove: {
+		url, user, password, accessToken, serverId, workerQuiet,
+	},
+	PackageVersions: {
+		url, user, password, accessToken, serverId, PackageType, PackageLatest, packageFormat,
+	},
+	PackageDeprecate: {
+		url, user, password, accessToken, serverId, packageDeprecationType, packageRepo, PackageMessage, PackageFile,
+	},
+	PypiUpload: {
+		pypiUploadRepo, BuildName, BuildNumber, module, Project,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	OciPush: {
+		OciArtifactType, OciAnnotations, BuildName, BuildNumber, module, Project,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
+	OciPull: {
+		BuildName, BuildNumber, module, Project,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls,
+	},
 }
 
 var flagsMap = map[string]components.Flag{
@@ -912,6 +1235,8 @@ var flagsMap = map[string]components.Flag{
 	retryWaitTime:     components.NewStringFlag(retryWaitTime, "[Default: 0] Number of seconds or milliseconds to wait between retries. The numeric value should either end with s for seconds or ms for milliseconds (for example: 10s or 100ms).", components.SetMandatoryFalse()),
 	dryRun:            components.NewBoolFlag(dryRun, "Set to true to disable communication with Artifactory.", components.WithBoolDefaultValueFalse()),
 	InsecureTls:       components.NewBoolFlag(InsecureTls, "Set to true to skip TLS certificates verification.", components.WithBoolDefaultValueFalse()),
+	captureHar:        components.NewStringFlag(captureHar, "Path to a HAR file, to which the HTTP traffic of the command to the JFrog Platform is recorded, with credentials redacted. Useful for attaching network traces to support tickets. Supported by the upload, download, move, copy, delete, search, set-props, delete-props and ping commands.", components.SetMandatoryFalse()),
+	receipt:           components.NewStringFlag(receipt, "Path to a receipt file, to which the operations of the command are written as JSON, with the request ID, the trace ID, the created time and the sha256 the server returned for each artifact.", components.SetMandatoryFalse()),
 	detailedSummary:   components.NewBoolFlag(detailedSummary, "Set to true to include a list of the affected files in the command summary.", components.WithBoolDefaultValueFalse()),
 	Project:           components.NewStringFlag(Project, "JFrog Artifactory project key.", components.SetMandatoryFalse()),
 	failNoOp:          components.NewBoolFlag(failNoOp, "Set to true if you'd like the command to return exit code 2 in case of no files are affected.", components.WithBoolDefaultValueFalse()),
@@ -965,6 +1290,11 @@ var flagsMap = map[string]components.Flag{
 	includeDirs:             components.NewBoolFlag(includeDirs, "Set to true if you'd like to also apply the source path pattern for directories and not just for files.", components.WithBoolDefaultValueFalse()),
 	downloadProps:           components.NewStringFlag(props, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts with these properties will be downloaded.", components.SetMandatoryFalse()),
 	downloadExcludeProps:    components.NewStringFlag(excludeProps, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts without the specified properties will be downloaded.", components.SetMandatoryFalse()),
+	downloadPropsExpr:       components.NewStringFlag(propsExpr, "[Optional] Boolean expression on the properties of the artifacts, such as \"stage=prod AND NOT deprecated AND buildNumber>100\". Supports AND, OR, NOT, parentheses, key existence, = and != with wildcards, and the >, >=, < and <= comparisons. Only artifacts matching the expression will be downloaded. Can't be used with --build or --bundle, or with placeholders in the target.", components.SetMandatoryFalse()),
+	downloadPointInTime:     components.NewStringFlag(pointInTime, "[Optional] Only artifacts created and last modified before this point in time will be downloaded, to reproduce the repository state of a historical build. The artifacts overwritten after it are skipped, since their earlier content isn't kept. Use the RFC 3339 format, for example 2024-05-01T12:00:00Z, or a date, for example 2024-05-01. Can't be used with --build or --bundle, or with placeholders in the target.", components.SetMandatoryFalse()),
+	expectedArtifacts:       components.NewStringFlag(expectedArtifacts, "[Optional] Path to a JSON file with the artifacts which the download spec is expected to resolve to, in the form of {\"count\": <number>, \"artifacts\": [{\"path\": \"<repo>/<path>\", \"name\": \"<name>\", \"sha256\": \"<checksum>\", \"sha1\": \"<checksum>\", \"md5\": \"<checksum>\"}]}. The command fails before downloading if the resolved artifacts differ.", components.SetMandatoryFalse()),
+	validateOnly:            components.NewBoolFlag(validateOnly, "Set to true to only validate the artifacts of the download spec against --expected-artifacts, without downloading them.", components.WithBoolDefaultValueFalse()),
+	folderArchive:           components.NewStringFlag(folderArchive, "[Optional] Set to zip, tar, tar.gz or tgz to download each folder of the spec as a single archive, generated by Artifactory, rather than file by file. The patterns must be paths of folders, without wildcards.", components.SetMandatoryFalse()),
 	archiveEntries:          components.NewStringFlag(archiveEntries, "This option is no longer supported since version 7.90.5 of Artifactory. If specified, only archive artifacts containing entries matching this pattern are matched. You can use wildcards to specify multiple artifacts.", components.SetMandatoryFalse()),
 	downloadSyncDeletes:     components.NewStringFlag(syncDeletes, "Specific path in the local file system, under which to sync dependencies after the download. After the download, this path will include only the dependencies downloaded during this download operation. The other files under this path will be deleted.", components.SetMandatoryFalse()),
 	skipChecksum:            components.NewBoolFlag(skipChecksum, "Set to true to skip checksum verification when downloading.", components.WithBoolDefaultValueFalse()),
@@ -984,6 +1314,15 @@ var flagsMap = map[string]components.Flag{
 	uploadMinSplit:    components.NewStringFlag(MinSplit, "[Default: "+strconv.Itoa(UploadMinSplitMb)+"] The minimum file size in MiB required to attempt a multi-part upload. This option, as well as the functionality of multi-part upload, requires Artifactory with S3 or GCP storage.", components.SetMandatoryFalse()),
 	uploadSplitCount:  components.NewStringFlag(SplitCount, "[Default: "+strconv.Itoa(UploadSplitCount)+"] The maximum number of parts that can be concurrently uploaded per file during a multi-part upload. Set to 0 to disable multi-part upload. This option, as well as the functionality of multi-part upload, requires Artifactory with S3 or GCP storage.", components.SetMandatoryFalse()),
 	chunkSize:         components.NewStringFlag(chunkSize, "[Default: "+strconv.Itoa(UploadChunkSizeMb)+"] The upload chunk size in MiB that can be concurrently uploaded during a multi-part upload. This option, as well as the functionality of multi-part upload, requires Artifactory with S3 or GCP storage.", components.SetMandatoryFalse()),
+	routingRules:      components.NewStringFlag(routingRules, "Path to a routing rules file, which maps local file patterns to the target paths in Artifactory the matching files should be uploaded to. Each file is uploaded according to the first rule it matches. Cannot be used together with the --spec option or with arguments.", components.SetMandatoryFalse()),
+
+	// Transfer validation report flags
+	validationReport:       components.NewStringFlag(validationReport, "Path to a local file, to which a validation report of the transferred files is saved. The report includes the paths, sizes and checksums of the files and the result of verifying the checksums calculated locally against the checksums reported by Artifactory.", components.SetMandatoryFalse()),
+	validationReportKey:    components.NewStringFlag(validationReportKey, "Path to a PEM private key (RSA, ECDSA or ED25519) used to sign the validation report. When set, the report is saved as a signed DSSE envelope.", components.SetMandatoryFalse()),
+	validationReportTarget: components.NewStringFlag(validationReportTarget, "Artifactory path in the format of <repository name>/<repository path>, to which the validation report is uploaded.", components.SetMandatoryFalse()),
+
+	uploadResume:       components.NewBoolFlag(uploadResume, "[Default: false] Set to true to make the upload resumable. If the upload is interrupted, its progress is saved to a checkpoint, and running the same command again with this option uploads only the remaining files. Cannot be used with archives, --include-dirs, --sync-deletes or build-info collection.", components.WithBoolDefaultValueFalse()),
+	uploadExtractProps: components.NewBoolFlag(uploadExtractProps, "[Default: false] Set to true to set properties extracted from the metadata of the uploaded files: the name and version of npm packages (npm.*) and Python wheels (pypi.*), the manifest attributes of JAR, WAR and EAR files (jar.*), and the version info of ELF, PE and Go executables (elf.*, pe.*, go.*). Cannot be used with archives or --explode.", components.WithBoolDefaultValueFalse()),
 
 	// Move specific commands flags
 	moveRecursive:    components.NewBoolFlag(Recursive, "[Default: true] Set to false if you do not wish to move artifacts inside sub-folders in Artifactory.", components.WithBoolDefaultValueFalse()),
@@ -1003,11 +1342,16 @@ var flagsMap = map[string]components.Flag{
 	deleteProps:        components.NewStringFlag(props, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts with these properties will be deleted.", components.SetMandatoryFalse()),
 	deleteExcludeProps: components.NewStringFlag(excludeProps, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts without the specified properties will be deleted.", components.SetMandatoryFalse()),
 
+	// Deletion protection flags
+	overrideProtection: components.NewBoolFlag(overrideProtection, "Set to true to delete or move artifacts protected by the deletion protection policy, at $JFROG_CLI_DELETION_PROTECTION_POLICY or deletion-protection.json in the JFrog CLI home directory. The names of the repositories of the protected artifacts must be typed to confirm.", components.WithBoolDefaultValueFalse()),
+
 	// Search specific commands flags
 	searchRecursive:    components.NewBoolFlag(Recursive, "[Default: true] Set to false if you do not wish to search artifacts inside sub-folders in Artifactory.", components.WithBoolDefaultValueFalse()),
 	count:              components.NewBoolFlag(count, "Set to true to display only the total of files or folders found.", components.WithBoolDefaultValueFalse()),
 	searchProps:        components.NewStringFlag(props, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts with these properties will be returned.", components.SetMandatoryFalse()),
 	searchExcludeProps: components.NewStringFlag(excludeProps, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts without the specified properties will be returned.", components.SetMandatoryFalse()),
+	searchPropsExpr:    components.NewStringFlag(propsExpr, "[Optional] Boolean expression on the properties of the artifacts, such as \"stage=prod AND NOT deprecated AND buildNumber>100\". Supports AND, OR, NOT, parentheses, key existence, = and != with wildcards, and the >, >=, < and <= comparisons. Only artifacts matching the expression will be returned. Can't be used with --build or --bundle.", components.SetMandatoryFalse()),
+	searchPointInTime:  components.NewStringFlag(pointInTime, "[Optional] Only artifacts created and last modified before this point in time will be returned. The artifacts overwritten after it are skipped, since their earlier content isn't kept. Use the RFC 3339 format, for example 2024-05-01T12:00:00Z, or a date, for example 2024-05-01. Can't be used with --build or --bundle.", components.SetMandatoryFalse()),
 	searchTransitive:   components.NewBoolFlag(transitive, "Set to true to look for artifacts also in remote repositories. The search will run on the first five remote repositories within the virtual repository. Available on Artifactory version 7.17.0 or higher.", components.WithBoolDefaultValueFalse()),
 	searchInclude:      components.NewStringFlag(searchInclude, "List of semicolon-separated(;) fields in the form of \"value1;value2;...\". Only the path and the fields that are specified will be returned. The fields must be part of the 'items' AQL domain. For the full supported items list, check %sjfrog-artifactory-documentation/artifactory-query-language.", components.SetMandatoryFalse()),
 
@@ -1016,6 +1360,8 @@ var flagsMap = map[string]components.Flag{
 	propsProps:        components.NewStringFlag(props, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts with these properties are affected.", components.SetMandatoryFalse()),
 	propsExcludeProps: components.NewStringFlag(excludeProps, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Only artifacts without the specified properties are affected.", components.SetMandatoryFalse()),
 	repoOnly:          components.NewBoolFlag(repoOnly, "When true, properties will be applicable only on repository level.", components.WithBoolDefaultValueFalse()),
+	batchSize:         components.NewStringFlag(batchSize, "[Default: 0] Number of items to process in each batch. When set, the properties are applied batch after batch, and the progress is reported after each batch. 0 processes all items together.", components.SetMandatoryFalse()),
+	recursiveFolders:  components.NewBoolFlag(recursiveFolders, "Set to true to apply the properties server-side on the top-most folders matched by the pattern, recursively affecting their entire content, instead of sending a request per file. Cannot be used with file filtering options, such as exclusions and props.", components.WithBoolDefaultValueFalse()),
 
 	// Build Publish and Append specific commands flags
 	buildUrl:          components.NewStringFlag(buildUrl, "Can be used for setting the CI server build URL in the build-info.", components.SetMandatoryFalse()),
@@ -1029,6 +1375,8 @@ var flagsMap = map[string]components.Flag{
 	dotGitPath:        components.NewStringFlag(dotGitPath, "Path to the .git directory. If not provided, the .git directory will be searched in the current working directory or its parent directories. Only respected when collect-git-info is enabled.", components.SetMandatoryFalse()),
 	gitConfigFilePath: components.NewStringFlag(gitConfigFilePath, "Path to the git configuration file. Only respected when collect-git-info is enabled.", components.SetMandatoryFalse()),
 	depExclude:        components.NewStringFlag(depExclude, "List of semicolon-separated(;) dependency scopes to exclude from the published build info. Relevant for Package managers with supported dependency scopes (e.g. Maven, NPM). For example: \"test;provided\".", components.SetMandatoryFalse()),
+	depSources:        components.NewBoolFlag(depSources, "Set to true to record the Artifactory repository which served each Maven, Gradle and npm dependency in the build info. The dependencies are found by their checksums, and the number of dependencies served by each repository is added to the build info properties.", components.WithBoolDefaultValueFalse()),
+	depSourcesReport:  components.NewStringFlag(depSourcesReport, "Path to a JSON report of the repository which served each Maven, Gradle and npm dependency, including the virtual repositories it was resolved through and the other repositories which store it. Implies dependency-sources.", components.SetMandatoryFalse()),
 
 	// Build Add Dependencies specific commands flags
 	badRecursive: components.NewBoolFlag(Recursive, "[Default: true] Set to false if you do not wish to collect artifacts in sub-folders to be added to the build info.", components.WithBoolDefaultValueFalse()),
@@ -1094,9 +1442,35 @@ var flagsMap = map[string]components.Flag{
 	xrayScan:          components.NewBoolFlag(xrayScan, "Set if you'd like all files to be scanned by Xray on the local file system prior to the upload, and skip the upload if any of the files are found vulnerable.", components.WithBoolDefaultValueFalse()),
 	xrOutput:          components.NewStringFlag(xrOutput, "[Default: table] Defines the output format of the command. Acceptable values are: table, json, simple-json and sarif. Note: the json format doesn't include information about scans that are included as part of the Advanced Security package.", components.SetMandatoryFalse()),
 
+	// Mvn specific commands flags
+	effectivePom: components.NewBoolFlag(effectivePom, "Set to true to capture the effective POM of each module and deploy it next to the module's artifacts. The effective POMs are also added to the modules in the build-info.", components.WithBoolDefaultValueFalse()),
+	useMvnd:      components.NewBoolFlag(useMvnd, "Set to true to run the build by the Maven Daemon (mvnd) instead of mvn. The build-info is collected and the artifacts are deployed as they are with mvn.", components.WithBoolDefaultValueFalse()),
+	buildTimeout: components.NewStringFlag(buildTimeout, "[Optional] The duration after which the build is stopped and its processes are terminated, such as 30m or 1h30m. The build-info collected until then is kept.", components.SetMandatoryFalse()),
+
+	saveDeployableArtifacts: components.NewStringFlag(saveDeployableArtifacts, "[Optional] Path to a file to which the artifacts of the build are listed instead of being deployed. The artifacts can then be deployed by 'jf mvn deploy-artifacts --from-file=<path>', for example by a later stage of a CI pipeline.", components.SetMandatoryFalse()),
+	fromFile:                components.NewStringFlag(fromFile, "[Optional] Path to the deployable artifacts file saved by --save-deployable-artifacts. Used with the deploy-artifacts goal, the artifacts the file lists as not deployed are deployed instead of running a build, and the file is updated with the deployed artifacts.", components.SetMandatoryFalse()),
+	jdk:                     components.NewStringFlag(jdk, "[Optional] Path of a JDK archive in Artifactory, in the form of <repository>/<path>, such as jdks-local/temurin/jdk-17.tar.gz. The JDK is downloaded and cached, and is the JDK toolchain of the build. Requires --jdk-version.", components.SetMandatoryFalse()),
+	jdkVersion:              components.NewStringFlag(jdkVersion, "[Optional] The version the JDK toolchain of --jdk provides, such as 17.", components.SetMandatoryFalse()),
+	jdkVendor:               components.NewStringFlag(jdkVendor, "[Optional] The vendor the JDK toolchain of --jdk provides, such as temurin.", components.SetMandatoryFalse()),
+	mvnExcludeArtifacts:     components.NewStringFlag(excludeArtifacts, "[Optional] List of semicolon-separated(;) patterns of the artifacts which aren't deployed, in the form of <classifier>[:<extension>], such as \"tests;javadoc;sources;*:zip\". The patterns may include the * and the ? wildcards. The excluded artifacts are listed in the detailed summary as filtered.", components.SetMandatoryFalse()),
+
+	// Gradle specific commands flags
+	includeCompositeBuilds: components.NewBoolFlag(includeCompositeBuilds, "Set to true to also collect the modules and artifacts of the builds included by the settings file (composite builds) into the build-info. Applies to the native Gradle implementation.", components.WithBoolDefaultValueFalse()),
+	dependencyGraph:        components.NewBoolFlag(dependencyGraph, "Set to true to record the resolved dependency graph of the compileClasspath, runtimeClasspath and testRuntimeClasspath configurations in the build-info, including the dependency paths and the versions changed by the conflict resolution. Applies to the native Gradle implementation.", components.WithBoolDefaultValueFalse()),
+	extractorPath:          components.NewStringFlag(extractorPath, "[Default: $JFROG_CLI_EXTRACTORS_DIR] Directory of pre-provisioned Gradle build-info extractor jars, in the layout <version>/build-info-extractor-gradle-<version>-uber.jar. When set, the extractor is not downloaded, and its checksum is verified against the .sha256 or .sha1 file next to it.", components.SetMandatoryFalse()),
+	includePublications:    components.NewStringFlag(includePublications, "[Optional] Comma-separated list of the names or wildcard patterns of the Gradle publications to deploy. The artifacts of the other publications are not deployed, but are still listed in the detailed summary and in the build-info.", components.SetMandatoryFalse()),
+	excludePublications:    components.NewStringFlag(excludePublications, "[Optional] Comma-separated list of the names or wildcard patterns of the Gradle publications not to deploy. Their artifacts are still listed in the detailed summary and in the build-info.", components.SetMandatoryFalse()),
+	gradleDryRun:           components.NewBoolFlag(dryRun, "Set to true to run the build without deploying its artifacts, and print the target repository, path and checksum of each artifact that would have been deployed. Use --format to print them as a table or as JSON.", components.WithBoolDefaultValueFalse()),
+	gradleTargetProps:      components.NewStringFlag(targetProps, "[Optional] List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\". Those properties will be attached to the artifacts deployed by the build, in addition to the properties of the deployer.props configuration.", components.SetMandatoryFalse()),
+	resumeFrom:             components.NewStringFlag(resumeFrom, "[Optional] Path to the deployable artifacts file of a build whose deployment failed, as printed by the failed build. Instead of running the build, the artifacts which weren't deployed are deployed, using the deployer of the Gradle configuration.", components.SetMandatoryFalse()),
+	skipIdentical:          components.NewBoolFlag(skipIdentical, "Set to true to skip the deployment of the artifacts whose target path already has an artifact with the same sha256. The other artifacts are deployed after the build.", components.WithBoolDefaultValueFalse()),
+	publishBuildInfo:       components.NewBoolFlag(publishBuildInfo, "Set to true to publish the build-info after the Xray scan of the --scan option. If the scan passes, the artifacts are deployed and then the build-info is published. Otherwise, the build-info is still published, with its buildInfo.gate.status property set to 'blocked'. Requires the --build-name and --build-number options.", components.WithBoolDefaultValueFalse()),
+
 	// Docker specific commands flags
 	skipLogin:           components.NewBoolFlag(skipLogin, "Set to true if you'd like the command to skip performing docker login.", components.WithBoolDefaultValueFalse()),
 	validateSha:         components.NewBoolFlag(validateSha, "Set to true to enable SHA validation during Docker push.", components.WithBoolDefaultValueFalse()),
+	sbom:                components.NewStringFlag(sbom, "[Optional] Path to a CycloneDX SBOM of the image, in JSON. After the push, the SBOM is attached to the image with the OCI referrers API, and its digest is recorded in the build-info.", components.SetMandatoryFalse()),
+	generateSbom:        components.NewBoolFlag(generateSbom, "Set to true to generate a CycloneDX SBOM of the image with Syft, which must be installed, and to attach it to the image after the push, as with the --sbom option.", components.WithBoolDefaultValueFalse()),
 	watches:             components.NewStringFlag(watches, "A comma-separated(,) list of Xray watches, to determine Xray's violations creation.", components.SetMandatoryFalse()),
 	repoPath:            components.NewStringFlag(repoPath, "Target repo path, to enable Xray to determine watches accordingly.", components.SetMandatoryFalse()),
 	licenses:            components.NewBoolFlag(licenses, "Set to true if you'd like to receive licenses from Xray scanning.", components.WithBoolDefaultValueFalse()),
@@ -1112,15 +1486,100 @@ var flagsMap = map[string]components.Flag{
 	targetTag:         components.NewStringFlag("target-tag", "The target tag to assign the image after promotion.", components.SetMandatoryFalse()),
 	dockerPromoteCopy: components.NewBoolFlag("copy", "If set true, the Docker image is copied to the target repository, otherwise it is moved.", components.WithBoolDefaultValueFalse()),
 
+	// DockerCleanup specific commands flags
+	dockerCleanupImage:  components.NewStringFlag("image", "Limit the cleanup to the images under this path in the repository, such as 'team/app'.", components.SetMandatoryFalse()),
+	dockerCleanupDelete: components.NewBoolFlag(Delete, "Set to true to delete the unreferenced manifests and layers. Otherwise, they are only reported.", components.WithBoolDefaultValueFalse()),
+	dockerCleanupQuiet:  components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the delete confirmation message.", components.WithBoolDefaultValueFalse()),
+
+	// DockerTagRetention specific commands flags
+	dockerTagRetentionImage:           components.NewStringFlag("image", "Apply the rules to the images under this path in the repository only, such as 'team/app'.", components.SetMandatoryFalse()),
+	dockerTagRetentionKeepLast:        components.NewStringFlag(dockerTagRetentionKeepLast, "[Optional] The number of the most recently pushed tags of each image to keep.", components.SetMandatoryFalse()),
+	dockerTagRetentionOlderThan:       components.NewStringFlag(dockerTagRetentionOlderThan, "[Optional] Delete only the tags pushed before this age, such as 30d, 2w or 12h.", components.SetMandatoryFalse()),
+	dockerTagRetentionProtectReleases: components.NewBoolFlag(dockerTagRetentionProtectReleases, "Set to true to never delete semantic version release tags, such as 1.2.3 or v1.2.3.", components.WithBoolDefaultValueFalse()),
+	dockerTagRetentionDryRun:          components.NewBoolFlag(dryRun, "Set to true to only list the tags which would be deleted.", components.WithBoolDefaultValueFalse()),
+	dockerTagRetentionQuiet:           components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the delete confirmation message.", components.WithBoolDefaultValueFalse()),
+
+	// DockerCopy specific commands flags
+	dockerCopyTargetServerId: components.NewStringFlag(dockerCopyTargetServerId, "[Optional] Server ID of the Artifactory instance of the target repository, configured using the 'jf config' command. If not set, the image is copied within the instance of the source repository.", components.SetMandatoryFalse()),
+
+	// DockerWarmCache specific commands flags
+	dockerWarmCacheThreads: components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of images to pull in parallel.", components.SetMandatoryFalse()),
+
+	// DeploymentManifest specific commands flags
+	deploymentManifestBuild:  components.NewStringFlag(build, "The build whose images and artifacts are rendered, in the format build-name/build-number. If the build number is omitted, the latest build is used. If the build is assigned to a project, provide the project key using the --project flag.", components.SetMandatoryFalse()),
+	deploymentManifestBundle: components.NewStringFlag(bundle, "The release bundle whose images and artifacts are rendered, in the format bundle-name/bundle-version.", components.SetMandatoryFalse()),
+	deploymentManifestOutput: components.NewStringFlag("output", "Path of the file to write the manifest to. If not set, the manifest is printed.", components.SetMandatoryFalse()),
+
+	// DependenciesPrefetch specific commands flags
+	dependenciesPrefetchThreads: components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of dependencies to download in parallel.", components.SetMandatoryFalse()),
+
+	// AirGapExport and AirGapImport specific commands flags
+	airGapExportBuild:   components.NewStringFlag(build, "The build whose dependencies are exported, in the format build-name/build-number. If the build number is omitted, the latest build is used. If the build is assigned to a project, provide the project key using the --project flag.", components.SetMandatoryFalse()),
+	airGapExportRepo:    components.NewStringFlag(repo, "The repository to download the dependencies of the dependencies files through. Required if dependencies files are provided.", components.SetMandatoryFalse()),
+	airGapExportThreads: components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of dependencies to download in parallel.", components.SetMandatoryFalse()),
+	airGapImportRepo:    components.NewStringFlag(repo, "The repository to upload all the files to. If not set, each file is uploaded to the repository recorded by the archive manifest.", components.SetMandatoryFalse()),
+	airGapImportThreads: components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of files to upload in parallel.", components.SetMandatoryFalse()),
+
+	// ArtifactDiff specific commands flags
+	artifactDiffFailOnDiff: components.NewBoolFlag(artifactDiffFailOnDiff, "Set to true to fail the command if the content of the artifacts differs.", components.WithBoolDefaultValueFalse()),
+
+	// ManifestSync specific commands flags
+	manifestSyncDryRun:  components.NewBoolFlag(dryRun, "Set to true to only print the changes, without making them.", components.WithBoolDefaultValueFalse()),
+	manifestSyncQuiet:   components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the confirmation message before deleting the files which aren't in the manifest.", components.WithBoolDefaultValueFalse()),
+	manifestSyncThreads: components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of files to upload in parallel.", components.SetMandatoryFalse()),
+
+	// ChecksumSearch specific commands flags
+	checksumSearchRepos: components.NewStringFlag("repos", "[Default: All the repositories] List of comma-separated(,) repositories to search in.", components.SetMandatoryFalse()),
+
+	// LatestUpdate specific commands flags
+	latestUpdateMode:     components.NewStringFlag("mode", "[Default: copy] The pointer to the latest artifact. Acceptable values are: copy - copy the latest artifact to the stable path, property - mark the latest artifact with a property.", components.SetMandatoryFalse()),
+	latestUpdateSortBy:   components.NewStringFlag("sort-by", "[Default: version] How the latest artifact is selected. Acceptable values are: version - by the versions matched by the first wildcard of the pattern, created - by the creation time.", components.SetMandatoryFalse()),
+	latestUpdateProperty: components.NewStringFlag("property", "[Default: latest] The property which marks the latest artifact, in the property mode.", components.SetMandatoryFalse()),
+	latestUpdateDryRun:   components.NewBoolFlag(dryRun, "Set to true to only print the latest artifact, without updating the pointer.", components.WithBoolDefaultValueFalse()),
+
+	// NpmBundle specific commands flags
+	npmBundleArchive: components.NewBoolFlag(archive, "Set to true to write the bundle to a single zip archive, instead of a directory.", components.WithBoolDefaultValueFalse()),
+
+	// OnboardingAnalyze specific commands flags
+	onboardingAnalyzeOutputDir: components.NewStringFlag("output-dir", "The directory to generate the JFrog CLI config files of the build tools in, under .jfrog/projects. If not set, the config files aren't generated, and the config commands are suggested instead.", components.SetMandatoryFalse()),
+	onboardingAnalyzeBuildName: components.NewStringFlag(BuildName, "[Default: The name of the project's directory] The build name of the suggested commands.", components.SetMandatoryFalse()),
+
+	// MvnPromote specific commands flags
+	mvnPromoteReleaseVersion:    components.NewStringFlag("release-version", "[Default: The version of each module without the -SNAPSHOT suffix] The release version of the promoted modules.", components.SetMandatoryFalse()),
+	mvnPromoteTargetBuildName:   components.NewStringFlag("target-"+BuildName, "[Default: The name of the snapshot build] The name of the release build-info.", components.SetMandatoryFalse()),
+	mvnPromoteTargetBuildNumber: components.NewStringFlag("target-"+BuildNumber, "[Default: The release version] The number of the release build-info. Mandatory if the modules have different release versions.", components.SetMandatoryFalse()),
+	mvnPromoteDryRun:            components.NewBoolFlag(dryRun, "Set to true to only list the release artifacts, without deploying them.", components.WithBoolDefaultValueFalse()),
+	mvnPromoteThreads:           components.NewStringFlag(threads, "[Default: "+strconv.Itoa(commonCliUtils.Threads)+"] Number of release artifacts to deploy in parallel.", components.SetMandatoryFalse()),
+
+	// JdkProvision specific commands flags
+	jdkProvisionVendor: components.NewStringFlag("vendor", "[Default: temurin] The vendor of the JDK, as it appears in the layout of the repository.", components.SetMandatoryFalse()),
+	jdkProvisionOs:     components.NewStringFlag("os", "[Default: The operating system of the machine] The operating system of the JDK, as named by the JDK distributions, such as linux, mac or windows.", components.SetMandatoryFalse()),
+	jdkProvisionArch:   components.NewStringFlag("arch", "[Default: The architecture of the machine] The architecture of the JDK, as named by the JDK distributions, such as x64 or aarch64.", components.SetMandatoryFalse()),
+	jdkProvisionLayout: components.NewStringFlag("layout", "[Default: {vendor}/{version}/{os}/{arch}/*] The layout of the JDK archives in the repository, with the {vendor}, {version}, {os} and {arch} placeholders.", components.SetMandatoryFalse()),
+
+	// NodeProvision specific commands flags
+	nodeProvisionOs:   components.NewStringFlag("os", "[Default: The operating system of the machine] The operating system of the Node.js runtime, as named by the Node.js distributions, such as linux, darwin or win.", components.SetMandatoryFalse()),
+	nodeProvisionArch: components.NewStringFlag("arch", "[Default: The architecture of the machine] The architecture of the Node.js runtime, as named by the Node.js distributions, such as x64 or arm64.", components.SetMandatoryFalse()),
+
 	allowInsecureConnections: components.NewBoolFlag(allowInsecureConnections, "Set to true if you wish to configure NuGet sources with unsecured connections. This is recommended for testing purposes only.", components.WithBoolDefaultValueFalse()),
 	npmDetailedSummary:       components.NewBoolFlag(detailedSummary, "Set to true to include a list of the affected files in the command summary.", components.WithBoolDefaultValueFalse()),
 	nugetV2:                  components.NewBoolFlag(nugetV2, "Set to true if you'd like to use the NuGet V2 protocol when restoring packages from Artifactory.", components.WithBoolDefaultValueFalse()),
 	disableCVSCheck:          components.NewBoolFlag(disableCVSCheck, "Set to true to disable the CVS check that verifies if 404 errors are due to blocked packages.", components.WithBoolDefaultValueFalse()),
+	npmBlockedProps:          components.NewStringFlag(npmBlockedProps, "List of semicolon-separated properties in the form of \"key1=value1;key2=value2,...\". Installed dependencies whose packages have one of these properties in Artifactory are blocked. A '*' value matches any value.", components.SetMandatoryFalse()),
+	npmRequiredProps:         components.NewStringFlag(npmRequiredProps, "List of semicolon-separated properties in the form of \"key1=value1;key2=value2,...\". Installed dependencies whose packages don't have all of these properties in Artifactory are blocked. A '*' value matches any value.", components.SetMandatoryFalse()),
+	npmOnBlocked:             components.NewStringFlag(npmOnBlocked, "[Default: fail] The action taken when blocked dependencies are installed. Can be 'fail' or 'annotate', which adds the blocked dependencies to the build-info.", components.SetMandatoryFalse()),
 
 	// GoPublish specific commands flags
 	goPublishExclusions: components.NewStringFlag(exclusions, "List of semicolon-separated(;) exclusions. Exclusions can include the * and the ? wildcards.", components.SetMandatoryFalse()),
+	goPublishAllModules: components.NewBoolFlag("all-modules", "Set to true to publish all the Go modules under the current directory, each as its own build-info module. Nested modules are excluded from the modules containing them.", components.WithBoolDefaultValueFalse()),
 	noFallback:          components.NewBoolFlag(noFallback, "Set to true to avoid downloading packages from the VCS, if they are missing in Artifactory.", components.WithBoolDefaultValueFalse()),
 
+	// GoConfig specific commands flags
+	goConfigPrivate:    components.NewStringFlag("go-private", "Comma-separated GOPRIVATE patterns of the private modules, which are fetched directly and aren't checked against the checksum database.", components.SetMandatoryFalse()),
+	goConfigNoSumDb:    components.NewStringFlag("go-no-sumdb", "Comma-separated GONOSUMDB patterns of the modules which aren't checked against the checksum database.", components.SetMandatoryFalse()),
+	goConfigGoFlags:    components.NewStringFlag("go-flags", "GOFLAGS which are added to the GOFLAGS of the environment when the Go commands run, such as -mod=mod.", components.SetMandatoryFalse()),
+	goConfigSumDbProxy: components.NewBoolFlag("sumdb-proxy", "Set to true to use the sum.golang.org checksum database proxied by the resolution repository, and to verify the go.sum of the project against it when the build-info is collected.", components.WithBoolDefaultValueFalse()),
+
 	// Terraform specific commands flags
 	namespace:       components.NewStringFlag(namespace, "[Mandatory] Terraform namespace.", components.SetMandatoryTrue()),
 	provider:        components.NewStringFlag(provider, "[Mandatory] Terraform provider.", components.SetMandatoryTrue()),
@@ -1131,6 +1590,12 @@ var flagsMap = map[string]components.Flag{
 	// TemplateConsumer specific commands flags
 	vars: components.NewStringFlag(vars, "List of semicolon-separated(;) variables in the form of \"key1=value1;key2=value2;...\" (wrapped by quotes) to be replaced in the template. In the template, the variables should be used as follows: ${key1}.", components.SetMandatoryFalse()),
 
+	// RepoCreateSet and RepoCreateRemote specific commands flags
+	remoteUrl:                 components.NewStringFlag(remoteUrl, "[Default: the URL of the remote repository preset] The URL proxied by the remote repository.", components.SetMandatoryFalse()),
+	remotePreset:              components.NewStringFlag(remotePreset, "[Default: the preset of the package type] The preset of the remote repository, which sets the proxied registry and its recommended settings. Run 'jf rt rcr --help' for the available presets.", components.SetMandatoryFalse()),
+	repoPackageType:           components.NewStringFlag(repoPackageType, "[Default: the first package type of the preset] The package type of the repository, for presets proxying registries of several package types, such as maven-central.", components.SetMandatoryFalse()),
+	repoCreateSetIncludeRepos: components.NewStringFlag(IncludeRepos, "List of semicolon-separated(;) existing repositories of the package type, such as other virtual repositories, to include in the virtual repository as well. The included repositories are ordered automatically: the local repositories first, then the virtual repositories, and the remote repositories last.", components.SetMandatoryFalse()),
+
 	// ArtifactoryAccessTokenCreate specific commands flags
 	rtAtcGroups:      components.NewStringFlag(Groups, "[Default: *] A list of comma-separated(,) groups for the access token to be associated with. Specify * to indicate that this is a 'user-scoped token', i.e., the token provides the same access privileges that the current subject has, and is therefore evaluated dynamically. ", components.SetMandatoryFalse()),
 	rtAtcGrantAdmin:  components.NewBoolFlag(GrantAdmin, "Set to true to provide admin privileges to the access token. This is only available for administrators.", components.WithBoolDefaultValueFalse()),
@@ -1189,13 +1654,15 @@ var flagsMap = map[string]components.Flag{
 	SourceTypeBuilds:         components.NewStringFlag(SourceTypeBuilds, "List of semicolon-separated(;) builds in the form of 'name=buildName1, id=runID1, include-deps=true; name=buildName2, id=runID2' to be included in the new bundle.", components.SetMandatoryFalse()),
 	Draft:                    components.NewBoolFlag(Draft, "Set to true to create the release bundle as a draft. A draft release bundle can be updated and finalized later.", components.WithBoolDefaultValueFalse()),
 	AddSources:               components.NewBoolFlag(AddSources, "Add sources to an existing draft release bundle.", components.WithBoolDefaultValueFalse()),
+	lcPublicKey:              components.NewStringFlag(PublicKey, "[Mandatory] Path to the PEM public key (RSA, ECDSA or ED25519) of the key the release bundle was signed with.", components.SetMandatoryTrue()),
+	lcFormat:                 components.NewStringFlag(Format, "Output format: \"table\" (default) or \"json\".", components.SetMandatoryFalse()),
 
 	// Skills-specific flags
 	repo:                components.NewStringFlag(repo, "Skills repository key in Artifactory.", components.SetMandatoryFalse()),
 	version:             components.NewStringFlag(version, "Skill version (semver, e.g. 1.2.0) or \"latest\".", components.SetMandatoryFalse()),
 	installPath:         components.NewStringFlag(installPath, "Base directory for a direct install or update: files go under <path>/<slug>. Mutually exclusive with --agent, --project-dir, and --global.", components.SetMandatoryFalse()),
 	skillsForce:         components.NewBoolFlag(skillsForce, "Re-download and reinstall even if the skill is already at the target version.", components.WithBoolDefaultValueFalse()),
-	signingKey:          components.NewStringFlag(signingKey, "Path to PGP private key for signing evidence. Overrides EVD_SIGNING_KEY_PATH env var.", components.SetMandatoryFalse()),
+	signingKey:          components.NewStringFlag(signingKey, "Path to PGP private key for signing evidence, or 'ssh-agent[:<SHA256 fingerprint or key comment>]' to sign using an ed25519 or ecdsa key held by the ssh-agent, such as a YubiKey PIV key. Overrides EVD_SIGNING_KEY_PATH env var.", components.SetMandatoryFalse()),
 	keyAlias:            components.NewStringFlag(keyAlias, "Alias for the signing key. Overrides EVD_KEY_ALIAS env var.", components.SetMandatoryFalse()),
 	skillsQuiet:         components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip interactive prompts.", components.WithBoolDefaultValueFalse()),
 	skillsFormat:        components.NewStringFlag(Format, "Output format: \"table\" (default) or \"json\".", components.SetMandatoryFalse()),
@@ -1209,6 +1676,27 @@ var flagsMap = map[string]components.Flag{
 	skillsSortBy:        components.NewStringFlag(sortBy, "Field to sort by. With --repo: updated (default), downloads. With --agent: name (default, only option).", components.SetMandatoryFalse()),
 	skillsSortOrder:     components.NewStringFlag(sortOrder, "Sort order for --agent. Supported: asc (default), desc. Not supported with --repo.", components.SetMandatoryFalse()),
 	skillsCheckUpdates:  components.NewBoolFlag(checkUpdates, "With --agent only: compare installed skills to the registry (requires jf config server). Adds registry latest and status columns. Not supported with --repo.", components.WithBoolDefaultValueFalse()),
+
+	// Workers-specific flags
+	workerFormat:  components.NewStringFlag(Format, "Output format: \"table\" (default) or \"json\".", components.SetMandatoryFalse()),
+	WorkerPayload: components.NewStringFlag(WorkerPayload, "Path to a JSON file with the data of the event the worker is run with. If not set, the worker is run with empty data.", components.SetMandatoryFalse()),
+	workerQuiet:   components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the remove confirmation message.", components.WithBoolDefaultValueFalse()),
+
+	// Package-specific flags
+	packageFormat:          components.NewStringFlag(Format, "Output format: \"table\" (default) or \"json\".", components.SetMandatoryFalse()),
+	PackageType:            components.NewStringFlag(PackageType, "Comma-separated package types: npm, maven, docker or pypi. If not set, the packages of all these types are listed.", components.SetMandatoryFalse()),
+	PackageLatest:          components.NewBoolFlag(PackageLatest, "Set to true to list the latest version of each package only. The latest version is the highest release version, or the Docker tag created last.", components.WithBoolDefaultValueFalse()),
+	packageDeprecationType: components.NewStringFlag(PackageType, "The package type: npm, pypi or generic. With --file, the default type of the listed versions.", components.SetMandatoryFalse()),
+	packageRepo:            components.NewStringFlag(PackageRepo, "The repository of the package. With --file, the default repository of the listed versions.", components.SetMandatoryFalse()),
+	PackageMessage:         components.NewStringFlag(PackageMessage, "The deprecation message, which is mandatory for npm, and is the reason of a PyPI yank. With --file, the default message of the listed versions.", components.SetMandatoryFalse()),
+	PackageFile:            components.NewStringFlag(PackageFile, "Path to a JSON file with an array of the versions to deprecate, in the form of [{\"type\": \"npm\", \"repo\": \"npm-local\", \"name\": \"<name>\", \"version\": \"<version>\", \"message\": \"<message>\"}]. The type, the repo and the message are optional.", components.SetMandatoryFalse()),
+
+	// PyPI-specific flags
+	pypiUploadRepo: components.NewStringFlag(PackageRepo, "[Mandatory] The PyPI repository in Artifactory to upload the distributions to.", components.SetMandatoryTrue()),
+
+	// OCI-specific flags
+	OciArtifactType: components.NewStringFlag(OciArtifactType, "[Default: application/vnd.unknown.artifact.v1] The artifact type of the manifest, such as application/vnd.cncf.helm.config.v1+json or application/spdx+json.", components.SetMandatoryFalse()),
+	OciAnnotations:  components.NewStringFlag(OciAnnotations, "[Optional] The annotations of the manifest, in the form of \"key1=value1;key2=value2\".", components.SetMandatoryFalse()),
 }
 
 func GetCommandFlags(cmdKey string) []components.Flag {
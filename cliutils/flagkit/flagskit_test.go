@@ -1,6 +1,7 @@
 package flagkit
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +22,14 @@ func TestCommandFlags(t *testing.T) {
 		})
 	}
 }
+
+// The commands which record their traffic by --capture-har, as its description documents them.
+func TestCaptureHarCommands(t *testing.T) {
+	var commands []string
+	for cmdKey, flags := range commandFlags {
+		if slices.Contains(flags, captureHar) {
+			commands = append(commands, cmdKey)
+		}
+	}
+	assert.ElementsMatch(t, []string{Upload, Download, Move, Copy, Delete, Search, Properties, Ping}, commands)
+}
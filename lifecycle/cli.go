@@ -1,6 +1,7 @@
 package lifecycle
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/cli"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/workspace"
 	rbsearch "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/rbsearch"
 
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/cmddefs"
@@ -24,6 +26,7 @@ import (
 	rbImport "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/importbundle"
 	rbPromote "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/promote"
 	rbUpdate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/update"
+	rbVerify "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/verify"
 	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
@@ -36,6 +39,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
 	"github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const (
@@ -135,6 +139,15 @@ func GetCommands() []components.Command {
 			Category:    lcCategory,
 			Action:      annotate,
 		},
+		{
+			Name:        cmddefs.ReleaseBundleVerify,
+			Aliases:     []string{"rbv"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleVerify),
+			Description: rbVerify.GetDescription(),
+			Arguments:   rbVerify.GetArguments(),
+			Category:    lcCategory,
+			Action:      verify,
+		},
 		{
 			Name:        "release-bundle-search",
 			Aliases:     []string{"rbs"},
@@ -262,7 +275,11 @@ func create(c *components.Context) (err error) {
 			SetBuildsSources(c.GetStringFlagValue(flagkit.SourceTypeBuilds))
 	}
 
-	return commands.Exec(createCmd)
+	if err = commands.Exec(createCmd); err != nil {
+		return err
+	}
+	// The created version can be referenced as --bundle=@last-release-bundle by the next commands of the workspace.
+	return workspace.Save(workspace.LastReleaseBundle, workspace.Entry{Command: c.CommandName, Value: c.GetArgumentAt(0) + "/" + c.GetArgumentAt(1)})
 }
 
 func validateUpdateReleaseBundleContext(c *components.Context) error {
@@ -602,6 +619,48 @@ func annotate(c *components.Context) error {
 	return commands.Exec(annotateCmd)
 }
 
+func verify(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 2 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	outputFormat := c.GetStringFlagValue(flagkit.Format)
+	if outputFormat != "" && outputFormat != "table" && outputFormat != "json" {
+		return errorutils.CheckErrorf("unsupported format '%s' for release-bundle-verify. Acceptable values are: json, table", outputFormat)
+	}
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	verifyCmd := lifecycle.NewReleaseBundleVerifyCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleVersion(c.GetArgumentAt(1)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetPublicKeyPath(c.GetStringFlagValue(flagkit.PublicKey))
+	if err = commands.Exec(verifyCmd); err != nil {
+		return err
+	}
+	result := verifyCmd.Result()
+	if outputFormat == "json" {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(utils.IndentJson(data))
+	} else if err = lifecycle.PrintReleaseBundleVerifyTable(result); err != nil {
+		return err
+	}
+	if !result.Verified {
+		return errorutils.CheckErrorf("release bundle %s/%s failed the verification", result.ReleaseBundleName, result.ReleaseBundleVersion)
+	}
+	return nil
+}
+
 func validateDistributeCommand(c *components.Context) error {
 	if err := distribution.ValidateReleaseBundleDistributeCmd(c); err != nil {
 		return err
@@ -640,7 +699,7 @@ func initReleaseBundleExportCmd(c *components.Context) (command *lifecycle.Relea
 		SetReleaseBundleName(c.GetArgumentAt(0)).
 		SetReleaseBundleVersion(c.GetArgumentAt(1)).
 		SetTargetPath(c.GetArgumentAt(2)).
-		SetProject(c.GetStringFlagValue(flagkit.Project))
+		SetProject(pluginsCommon.GetProject(c))
 
 	modifications = services.Modifications{
 		PathMappings: []artClientUtils.PathMapping{
@@ -764,6 +823,6 @@ func GetReleaseBundleVersionsCmd(c *components.Context, lcDetails *config.Server
 		SetIncludes(c.GetStringFlagValue(flagkit.Includes)).
 		SetReleaseBundleName(c.Arguments[1]).
 		SetOutputFormat(c.GetStringFlagValue(flagkit.Format)).
-		SetProject(c.GetStringFlagValue(flagkit.Project))
+		SetProject(pluginsCommon.GetProject(c))
 	return commands.Exec(rbSearchCmd)
 }
@@ -15,6 +15,9 @@ type ReleaseBundlePromoteCommand struct {
 	includeReposPatterns []string
 	excludeReposPatterns []string
 	promotionType        string
+	// The promotion printed by Run(), unless suppressOutput is set.
+	result         *services.RbPromotionResp
+	suppressOutput bool
 }
 
 func NewReleaseBundlePromoteCommand() *ReleaseBundlePromoteCommand {
@@ -71,6 +74,16 @@ func (rbp *ReleaseBundlePromoteCommand) SetPromotionType(promotionType string) *
 	return rbp
 }
 
+// SetSuppressOutput prevents Run() from printing the promotion, which is available by Result().
+func (rbp *ReleaseBundlePromoteCommand) SetSuppressOutput(suppressOutput bool) *ReleaseBundlePromoteCommand {
+	rbp.suppressOutput = suppressOutput
+	return rbp
+}
+
+func (rbp *ReleaseBundlePromoteCommand) Result() *services.RbPromotionResp {
+	return rbp.result
+}
+
 func (rbp *ReleaseBundlePromoteCommand) CommandName() string {
 	return "rb_promote"
 }
@@ -100,6 +113,10 @@ func (rbp *ReleaseBundlePromoteCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	rbp.result = &promotionResp
+	if rbp.suppressOutput {
+		return nil
+	}
 	content, err := json.Marshal(promotionResp)
 	if err != nil {
 		return err
@@ -0,0 +1,299 @@
+package commands
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/cryptox"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/dsse"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ArtifactVerified         = "verified"
+	ArtifactMissing          = "missing"
+	ArtifactChecksumMismatch = "checksum-mismatch"
+)
+
+// VerifiedArtifact is an artifact listed by the release bundle manifest, compared to the artifact found in Artifactory.
+type VerifiedArtifact struct {
+	Path           string `json:"path"`
+	Status         string `json:"status"`
+	ExpectedSha256 string `json:"expectedSha256"`
+	ActualSha256   string `json:"actualSha256,omitempty"`
+}
+
+type ReleaseBundleVerifyResult struct {
+	ReleaseBundleName    string `json:"releaseBundleName"`
+	ReleaseBundleVersion string `json:"releaseBundleVersion"`
+	// The path of the manifest in Artifactory.
+	Manifest          string `json:"manifest"`
+	SignatureVerified bool   `json:"signatureVerified"`
+	// The reason the signature wasn't verified.
+	SignatureError string `json:"signatureError,omitempty"`
+	// True if the signature was verified, and all the artifacts of the manifest were found with the expected checksums.
+	Verified  bool               `json:"verified"`
+	Artifacts []VerifiedArtifact `json:"artifacts"`
+}
+
+// ReleaseBundleVerifyCommand verifies a release bundle version distributed to an Edge node, before it's consumed.
+// The signature of the release bundle manifest is verified using the public key, and the checksums of the artifacts
+// listed by the manifest are compared to the checksums of the artifacts in the Artifactory of the Edge node.
+type ReleaseBundleVerifyCommand struct {
+	releaseBundleCmd
+	publicKeyPath string
+	result        *ReleaseBundleVerifyResult
+}
+
+func NewReleaseBundleVerifyCommand() *ReleaseBundleVerifyCommand {
+	return &ReleaseBundleVerifyCommand{}
+}
+
+func (rbv *ReleaseBundleVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleVerifyCommand {
+	rbv.serverDetails = serverDetails
+	return rbv
+}
+
+func (rbv *ReleaseBundleVerifyCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleVerifyCommand {
+	rbv.releaseBundleName = releaseBundleName
+	return rbv
+}
+
+func (rbv *ReleaseBundleVerifyCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleVerifyCommand {
+	rbv.releaseBundleVersion = releaseBundleVersion
+	return rbv
+}
+
+func (rbv *ReleaseBundleVerifyCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleVerifyCommand {
+	rbv.rbProjectKey = rbProjectKey
+	return rbv
+}
+
+// SetPublicKeyPath sets the path of the PEM public key (RSA, ECDSA or ED25519) of the key the release bundle was signed with.
+func (rbv *ReleaseBundleVerifyCommand) SetPublicKeyPath(publicKeyPath string) *ReleaseBundleVerifyCommand {
+	rbv.publicKeyPath = publicKeyPath
+	return rbv
+}
+
+func (rbv *ReleaseBundleVerifyCommand) Result() *ReleaseBundleVerifyResult {
+	return rbv.result
+}
+
+func (rbv *ReleaseBundleVerifyCommand) CommandName() string {
+	return "rb_verify"
+}
+
+func (rbv *ReleaseBundleVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbv.serverDetails, nil
+}
+
+// Run fails only if the verification couldn't be completed. Whether the release bundle passed the verification is in the result.
+func (rbv *ReleaseBundleVerifyCommand) Run() error {
+	publicKey, err := readVerificationPublicKey(rbv.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := createArtifactoryServiceManager(rbv.serverDetails)
+	if err != nil {
+		return err
+	}
+	manifestPath := buildManifestPath(rbv.rbProjectKey, rbv.releaseBundleName, rbv.releaseBundleVersion)
+	log.Info("Verifying release bundle manifest", manifestPath+"...")
+	envelope, err := readManifestEnvelope(servicesManager, manifestPath)
+	if err != nil {
+		return err
+	}
+	rbv.result = &ReleaseBundleVerifyResult{
+		ReleaseBundleName:    rbv.releaseBundleName,
+		ReleaseBundleVersion: rbv.releaseBundleVersion,
+		Manifest:             manifestPath,
+		Artifacts:            []VerifiedArtifact{},
+	}
+	if err = verifyManifestSignature(envelope, publicKey); err != nil {
+		rbv.result.SignatureError = err.Error()
+		// The content of a manifest which wasn't signed by the key can't be trusted.
+		return nil
+	}
+	rbv.result.SignatureVerified = true
+	expected, err := parseManifestArtifacts(envelope)
+	if err != nil {
+		return err
+	}
+	actual, err := getArtifactsChecksums(servicesManager, expected)
+	if err != nil {
+		return err
+	}
+	rbv.result.Artifacts = compareArtifactsChecksums(expected, actual)
+	rbv.result.Verified = true
+	for _, artifact := range rbv.result.Artifacts {
+		if artifact.Status != ArtifactVerified {
+			rbv.result.Verified = false
+			break
+		}
+	}
+	return nil
+}
+
+func readVerificationPublicKey(publicKeyPath string) ([]dsse.Verifier, error) {
+	if publicKeyPath == "" {
+		return nil, errorutils.CheckErrorf("a public key to verify the release bundle signature with must be provided")
+	}
+	keyContent, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the public key: %s", err.Error())
+	}
+	publicKey, err := cryptox.ReadPublicKey(keyContent)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to load the public key: %s", err.Error())
+	}
+	if publicKey == nil {
+		return nil, errorutils.CheckErrorf("%s doesn't contain a public key", publicKeyPath)
+	}
+	return cryptox.CreateVerifier(publicKey)
+}
+
+func readManifestEnvelope(servicesManager artifactory.ArtifactoryServicesManager, manifestPath string) (*dsse.Envelope, error) {
+	reader, err := servicesManager.ReadRemoteFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the release bundle manifest %s. Make sure the release bundle version was distributed to this Edge node: %s", manifestPath, err.Error())
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	envelope := new(dsse.Envelope)
+	if err = json.Unmarshal(content, envelope); err != nil {
+		return nil, errorutils.CheckErrorf("the release bundle manifest %s isn't a signed envelope: %s", manifestPath, err.Error())
+	}
+	return envelope, nil
+}
+
+// verifyManifestSignature succeeds if one of the signatures of the envelope was made by the public key.
+func verifyManifestSignature(envelope *dsse.Envelope, verifiers []dsse.Verifier) error {
+	if len(envelope.Signatures) == 0 {
+		return errorutils.CheckErrorf("the release bundle manifest isn't signed")
+	}
+	var err error
+	for _, signature := range envelope.Signatures {
+		signed := dsse.Envelope{Payload: envelope.Payload, PayloadType: envelope.PayloadType, Signatures: []dsse.Signature{signature}}
+		for _, verifier := range verifiers {
+			if err = signed.Verify(verifier); err == nil {
+				return nil
+			}
+		}
+	}
+	return errorutils.CheckErrorf("the release bundle manifest wasn't signed by the public key: %s", err.Error())
+}
+
+// The payload of the manifest lists the artifacts either directly, or as the subjects of an in-toto statement.
+type manifestPayload struct {
+	Artifacts []struct {
+		Path     string `json:"path"`
+		Checksum string `json:"checksum"`
+		Sha256   string `json:"sha256"`
+	} `json:"artifacts"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// parseManifestArtifacts returns the sha256 checksums of the artifacts of the signed manifest, by their paths in Artifactory.
+func parseManifestArtifacts(envelope *dsse.Envelope) (map[string]string, error) {
+	decodedPayload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decode the release bundle manifest: %s", err.Error())
+	}
+	payload := new(manifestPayload)
+	if err = json.Unmarshal(decodedPayload, payload); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the release bundle manifest: %s", err.Error())
+	}
+	artifacts := make(map[string]string)
+	for _, artifact := range payload.Artifacts {
+		checksum := artifact.Sha256
+		if checksum == "" {
+			checksum = artifact.Checksum
+		}
+		artifacts[strings.TrimPrefix(artifact.Path, "/")] = checksum
+	}
+	for _, subject := range payload.Subject {
+		artifacts[strings.TrimPrefix(subject.Name, "/")] = subject.Digest["sha256"]
+	}
+	if len(artifacts) == 0 {
+		return nil, errorutils.CheckErrorf("the release bundle manifest doesn't list any artifacts")
+	}
+	for artifactPath, checksum := range artifacts {
+		if checksum == "" {
+			return nil, errorutils.CheckErrorf("the release bundle manifest has no sha256 checksum for %s", artifactPath)
+		}
+	}
+	return artifacts, nil
+}
+
+// getArtifactsChecksums returns the sha256 checksums of the artifacts found in Artifactory, by their paths.
+func getArtifactsChecksums(servicesManager artifactory.ArtifactoryServicesManager, artifacts map[string]string) (map[string]string, error) {
+	paths := make([]string, 0, len(artifacts))
+	for artifactPath := range artifacts {
+		paths = append(paths, artifactPath)
+	}
+	sort.Strings(paths)
+	return artifactoryUtils.GetArtifactsSha256(servicesManager, paths)
+}
+
+// compareArtifactsChecksums compares the expected checksums of the artifacts to the actual ones, sorted by path.
+func compareArtifactsChecksums(expected, actual map[string]string) []VerifiedArtifact {
+	artifacts := make([]VerifiedArtifact, 0, len(expected))
+	for artifactPath, expectedSha256 := range expected {
+		artifact := VerifiedArtifact{Path: artifactPath, ExpectedSha256: expectedSha256, ActualSha256: actual[artifactPath]}
+		switch {
+		case artifact.ActualSha256 == "":
+			artifact.Status = ArtifactMissing
+		case !strings.EqualFold(artifact.ActualSha256, expectedSha256):
+			artifact.Status = ArtifactChecksumMismatch
+		default:
+			artifact.Status = ArtifactVerified
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Path < artifacts[j].Path
+	})
+	return artifacts
+}
+
+type verifiedArtifactRow struct {
+	Path           string `col-name:"Path"`
+	Status         string `col-name:"Status"`
+	ExpectedSha256 string `col-name:"Expected SHA256"`
+	ActualSha256   string `col-name:"Actual SHA256"`
+}
+
+// PrintReleaseBundleVerifyTable prints the artifacts which failed the verification.
+func PrintReleaseBundleVerifyTable(result *ReleaseBundleVerifyResult) error {
+	if !result.SignatureVerified {
+		log.Output("❌ The signature of the release bundle manifest wasn't verified:", result.SignatureError)
+		return nil
+	}
+	rows := []verifiedArtifactRow{}
+	for _, artifact := range result.Artifacts {
+		if artifact.Status != ArtifactVerified {
+			rows = append(rows, verifiedArtifactRow(artifact))
+		}
+	}
+	title := fmt.Sprintf("Artifacts of %s/%s which failed the verification", result.ReleaseBundleName, result.ReleaseBundleVersion)
+	emptyTableMessage := fmt.Sprintf("✅ The signature and the %d artifacts of release bundle %s/%s were verified", len(result.Artifacts), result.ReleaseBundleName, result.ReleaseBundleVersion)
+	return coreutils.PrintTable(rows, title, emptyTableMessage, false)
+}
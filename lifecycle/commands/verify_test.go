@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-evidence/evidence/cryptox"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/sign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestPayload = `{"artifacts":[{"path":"generic-local/app/app.tgz","checksum":"aaa"},{"path":"generic-local/readme.md","sha256":"bbb"}]}`
+
+// createSignedManifest signs the payload with a new key, and returns the envelope and the path of the public key.
+func createSignedManifest(t *testing.T, payload string) (*dsse.Envelope, string) {
+	privateKeyPem, publicKeyPem, err := cryptox.GenerateECDSAKeyPair()
+	require.NoError(t, err)
+	privateKey, err := cryptox.ReadKey([]byte(privateKeyPem))
+	require.NoError(t, err)
+	signer, err := cryptox.NewECDSASignerVerifierFromSSLibKey(privateKey)
+	require.NoError(t, err)
+	envelopeSigner, err := sign.NewEnvelopeSigner(signer)
+	require.NoError(t, err)
+	envelope, err := envelopeSigner.SignPayload("application/vnd.in-toto+json", []byte(payload))
+	require.NoError(t, err)
+	publicKeyPath := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(publicKeyPath, []byte(publicKeyPem), 0644))
+	return envelope, publicKeyPath
+}
+
+func TestVerifyManifestSignature(t *testing.T) {
+	envelope, publicKeyPath := createSignedManifest(t, testManifestPayload)
+	verifiers, err := readVerificationPublicKey(publicKeyPath)
+	require.NoError(t, err)
+	assert.NoError(t, verifyManifestSignature(envelope, verifiers))
+
+	// A manifest signed by another key.
+	_, otherPublicKeyPath := createSignedManifest(t, testManifestPayload)
+	otherVerifiers, err := readVerificationPublicKey(otherPublicKeyPath)
+	require.NoError(t, err)
+	assert.Error(t, verifyManifestSignature(envelope, otherVerifiers))
+
+	// A manifest whose content was changed after it was signed.
+	tampered := *envelope
+	tampered.Payload = base64.StdEncoding.EncodeToString([]byte(`{"artifacts":[{"path":"generic-local/app/app.tgz","checksum":"ccc"}]}`))
+	assert.Error(t, verifyManifestSignature(&tampered, verifiers))
+
+	tampered.Signatures = nil
+	assert.Error(t, verifyManifestSignature(&tampered, verifiers))
+}
+
+func TestParseManifestArtifacts(t *testing.T) {
+	envelope, _ := createSignedManifest(t, testManifestPayload)
+	artifacts, err := parseManifestArtifacts(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"generic-local/app/app.tgz": "aaa", "generic-local/readme.md": "bbb"}, artifacts)
+
+	envelope, _ = createSignedManifest(t, `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"docker-local/app/1.0/manifest.json","digest":{"sha256":"ddd"}}]}`)
+	artifacts, err = parseManifestArtifacts(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"docker-local/app/1.0/manifest.json": "ddd"}, artifacts)
+
+	envelope, _ = createSignedManifest(t, `{"artifacts":[{"path":"generic-local/a.txt"}]}`)
+	_, err = parseManifestArtifacts(envelope)
+	assert.Error(t, err)
+
+	envelope, _ = createSignedManifest(t, `{}`)
+	_, err = parseManifestArtifacts(envelope)
+	assert.Error(t, err)
+}
+
+func TestCompareArtifactsChecksums(t *testing.T) {
+	expected := map[string]string{"generic-local/c.txt": "ccc", "generic-local/a.txt": "aaa", "generic-local/b.txt": "bbb"}
+	actual := map[string]string{"generic-local/a.txt": "AAA", "generic-local/b.txt": "xxx"}
+	assert.Equal(t, []VerifiedArtifact{
+		{Path: "generic-local/a.txt", Status: ArtifactVerified, ExpectedSha256: "aaa", ActualSha256: "AAA"},
+		{Path: "generic-local/b.txt", Status: ArtifactChecksumMismatch, ExpectedSha256: "bbb", ActualSha256: "xxx"},
+		{Path: "generic-local/c.txt", Status: ArtifactMissing, ExpectedSha256: "ccc"},
+	}, compareArtifactsChecksums(expected, actual))
+}
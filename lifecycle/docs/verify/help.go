@@ -0,0 +1,16 @@
+package verify
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbv [command options] <release bundle name> <release bundle version>"}
+
+func GetDescription() string {
+	return "Verify the signature and the artifacts checksums of a release bundle distributed to an Edge node, before deploying it. Fails if the verification doesn't pass."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle to verify."},
+		{Name: "release bundle version", Description: "Version of the Release Bundle to verify."},
+	}
+}
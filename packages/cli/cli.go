@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	"github.com/jfrog/jfrog-cli-artifactory/packages/commands"
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreCommands "github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	pluginsCommon "github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+func GetCommands() []components.Command {
+	return []components.Command{
+		{
+			Name:        "versions",
+			Aliases:     []string{"v"},
+			Flags:       flagkit.GetCommandFlags(flagkit.PackageVersions),
+			Description: "List the versions of a package in all the repositories, for the " + strings.Join(commands.GetSupportedPackageTypes(), ", ") + " package types.",
+			Arguments: []components.Argument{
+				{Name: "package name", Description: "The name of the package, which may include wildcards. The name of a Maven package is groupId:artifactId."},
+			},
+			Action: versionsCmd,
+		},
+		{
+			Name:        "deprecate",
+			Aliases:     []string{"yank"},
+			Flags:       flagkit.GetCommandFlags(flagkit.PackageDeprecate),
+			Description: "Deprecate a package version, or the versions listed by the --file JSON file. npm versions are deprecated with a message, as 'npm deprecate' does, PyPI versions are yanked, and the files of generic versions get deprecation properties.",
+			Arguments: []components.Argument{
+				{Name: "package name", Description: "The name of the package. The files of a generic version are in <repo>/<package name>/<version>.", Optional: true},
+				{Name: "version", Description: "The version to deprecate.", Optional: true},
+			},
+			Action: deprecateCmd,
+		},
+	}
+}
+
+func versionsCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, err := c.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+	if outputFormat == format.None {
+		outputFormat = format.Table
+	}
+	serverDetails, err := createPackagesDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	versionsCommand := commands.NewVersionsCommand().
+		SetServerDetails(serverDetails).
+		SetPackageName(c.GetArgumentAt(0)).
+		SetLatest(c.GetBoolFlagValue(flagkit.PackageLatest)).
+		SetOutputFormat(outputFormat)
+	if c.IsFlagSet(flagkit.PackageType) {
+		versionsCommand.SetPackageTypes(strings.Split(c.GetStringFlagValue(flagkit.PackageType), ","))
+	}
+	return coreCommands.Exec(versionsCommand)
+}
+
+func deprecateCmd(c *components.Context) error {
+	filePath := c.GetStringFlagValue(flagkit.PackageFile)
+	if (filePath == "" && c.GetNumberOfArgs() != 2) || (filePath != "" && c.GetNumberOfArgs() != 0) {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := createPackagesDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	deprecateCommand := commands.NewDeprecateCommand().
+		SetServerDetails(serverDetails).
+		SetPackageType(c.GetStringFlagValue(flagkit.PackageType)).
+		SetRepo(c.GetStringFlagValue(flagkit.PackageRepo)).
+		SetMessage(c.GetStringFlagValue(flagkit.PackageMessage)).
+		SetFilePath(filePath)
+	if filePath == "" {
+		deprecateCommand.SetPackageName(c.GetArgumentAt(0)).SetVersion(c.GetArgumentAt(1))
+	}
+	return coreCommands.Exec(deprecateCommand)
+}
+
+// createPackagesDetailsByFlags returns the details of the JFrog Platform. The requests are sent by the Artifactory services manager,
+// so the Artifactory URL is set as well.
+func createPackagesDetailsByFlags(c *components.Context) (*config.ServerDetails, error) {
+	serverDetails, err := pluginsCommon.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if serverDetails.Url == "" {
+		return nil, errorutils.CheckErrorf("platform URL is mandatory for the package commands")
+	}
+	if serverDetails.ArtifactoryUrl == "" {
+		serverDetails.ArtifactoryUrl = clientutils.AddTrailingSlashIfNeeded(serverDetails.Url) + "artifactory/"
+	}
+	return serverDetails, nil
+}
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The properties of the deprecated generic files.
+	DeprecatedProp        = "deprecated"
+	DeprecatedMessageProp = "deprecated.message"
+	// The properties of the yanked PyPI files, which the simple index lists as yanked once it's recalculated.
+	PypiYankedProp       = "pypi.yanked"
+	PypiYankedReasonProp = "pypi.yanked.reason"
+)
+
+// The package types which can be deprecated. npm versions are deprecated by the message of the registry, PyPI versions are yanked, and generic
+// versions are deprecated by properties.
+var deprecationPackageTypes = []string{"generic", "npm", "pypi"}
+
+var pypiNameSeparatorsPattern = regexp.MustCompile(`[-_.]+`)
+
+// PackageDeprecation is a package version to deprecate.
+type PackageDeprecation struct {
+	Type    string `json:"type,omitempty"`
+	Repo    string `json:"repo,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Message string `json:"message,omitempty"`
+}
+
+// DeprecateCommand deprecates package versions, so that their clients warn about them or don't resolve them anymore.
+// The versions are either set by the command, or listed by a JSON file, whose entries default to the type, repository and message of the command.
+type DeprecateCommand struct {
+	serverDetails *config.ServerDetails
+	deprecation   PackageDeprecation
+	filePath      string
+}
+
+func NewDeprecateCommand() *DeprecateCommand {
+	return &DeprecateCommand{}
+}
+
+func (dc *DeprecateCommand) SetServerDetails(serverDetails *config.ServerDetails) *DeprecateCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DeprecateCommand) SetPackageType(packageType string) *DeprecateCommand {
+	dc.deprecation.Type = packageType
+	return dc
+}
+
+func (dc *DeprecateCommand) SetRepo(repo string) *DeprecateCommand {
+	dc.deprecation.Repo = repo
+	return dc
+}
+
+func (dc *DeprecateCommand) SetPackageName(packageName string) *DeprecateCommand {
+	dc.deprecation.Name = packageName
+	return dc
+}
+
+func (dc *DeprecateCommand) SetVersion(version string) *DeprecateCommand {
+	dc.deprecation.Version = version
+	return dc
+}
+
+// SetMessage sets the deprecation message, which npm prints when the version is installed, and is the reason of a PyPI yank.
+func (dc *DeprecateCommand) SetMessage(message string) *DeprecateCommand {
+	dc.deprecation.Message = message
+	return dc
+}
+
+// SetFilePath sets the JSON file which lists the versions to deprecate, as an array of PackageDeprecation.
+func (dc *DeprecateCommand) SetFilePath(filePath string) *DeprecateCommand {
+	dc.filePath = filePath
+	return dc
+}
+
+func (dc *DeprecateCommand) CommandName() string {
+	return "package_deprecate"
+}
+
+func (dc *DeprecateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DeprecateCommand) Run() error {
+	deprecations, err := dc.getDeprecations()
+	if err != nil {
+		return err
+	}
+	for i := range deprecations {
+		if err = deprecations[i].validate(); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(dc.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	var yankedPypiRepos []string
+	for _, deprecation := range deprecations {
+		switch deprecation.Type {
+		case "npm":
+			err = deprecateNpmVersion(servicesManager, deprecation)
+		case "pypi":
+			if err = yankPypiVersion(servicesManager, deprecation); err == nil && !slices.Contains(yankedPypiRepos, deprecation.Repo) {
+				yankedPypiRepos = append(yankedPypiRepos, deprecation.Repo)
+			}
+		default:
+			err = deprecateGenericVersion(servicesManager, deprecation)
+		}
+		if err != nil {
+			log.Error(fmt.Sprintf("Failed to deprecate %s:", deprecation), err.Error())
+			failed++
+			continue
+		}
+		log.Info(fmt.Sprintf("Deprecated %s.", deprecation))
+	}
+	// The PyPI clients see the yanked versions once the index is recalculated.
+	for _, repo := range yankedPypiRepos {
+		if err = reindexPypiRepo(servicesManager, repo); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to deprecate %d of the %d package versions", failed, len(deprecations))
+	}
+	return nil
+}
+
+func (dc *DeprecateCommand) getDeprecations() ([]PackageDeprecation, error) {
+	if dc.filePath == "" {
+		return []PackageDeprecation{dc.deprecation}, nil
+	}
+	content, err := os.ReadFile(dc.filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var deprecations []PackageDeprecation
+	if err = json.Unmarshal(content, &deprecations); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the package versions file %s: %s", dc.filePath, err.Error())
+	}
+	if len(deprecations) == 0 {
+		return nil, errorutils.CheckErrorf("the package versions file %s lists no versions", dc.filePath)
+	}
+	for i := range deprecations {
+		if deprecations[i].Type == "" {
+			deprecations[i].Type = dc.deprecation.Type
+		}
+		if deprecations[i].Repo == "" {
+			deprecations[i].Repo = dc.deprecation.Repo
+		}
+		if deprecations[i].Message == "" {
+			deprecations[i].Message = dc.deprecation.Message
+		}
+	}
+	return deprecations, nil
+}
+
+func (deprecation *PackageDeprecation) validate() error {
+	deprecation.Type = strings.ToLower(deprecation.Type)
+	switch {
+	case !slices.Contains(deprecationPackageTypes, deprecation.Type):
+		return errorutils.CheckErrorf("unsupported package type '%s' for %s. The supported types are: %s", deprecation.Type, deprecation, strings.Join(deprecationPackageTypes, ", "))
+	case deprecation.Repo == "":
+		return errorutils.CheckErrorf("the repository of %s is missing", deprecation)
+	case deprecation.Name == "" || deprecation.Version == "":
+		return errorutils.CheckErrorf("the name and the version of a package to deprecate are mandatory")
+	// npm un-deprecates a version whose deprecation message is empty.
+	case deprecation.Type == "npm" && deprecation.Message == "":
+		return errorutils.CheckErrorf("the deprecation message of the npm package %s is missing", deprecation)
+	}
+	return nil
+}
+
+func (deprecation PackageDeprecation) String() string {
+	return deprecation.Name + "@" + deprecation.Version
+}
+
+// deprecateNpmVersion sets the deprecation message of the version in the package document of the registry, as 'npm deprecate' does.
+func deprecateNpmVersion(servicesManager artifactory.ArtifactoryServicesManager, deprecation PackageDeprecation) error {
+	packageUrl := clientutils.AddTrailingSlashIfNeeded(servicesManager.GetConfig().GetServiceDetails().GetUrl()) + "api/npm/" + deprecation.Repo + "/" + url.PathEscape(deprecation.Name)
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(packageUrl, true, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	packageDocument := map[string]any{}
+	if err = json.Unmarshal(body, &packageDocument); err != nil {
+		return errorutils.CheckError(err)
+	}
+	versions, _ := packageDocument["versions"].(map[string]any)
+	version, ok := versions[deprecation.Version].(map[string]any)
+	if !ok {
+		return errorutils.CheckErrorf("the version %s wasn't found in the npm repository %s", deprecation.Version, deprecation.Repo)
+	}
+	version["deprecated"] = deprecation.Message
+	content, err := json.Marshal(packageDocument)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails.SetContentTypeApplicationJson()
+	resp, body, err = servicesManager.Client().SendPut(packageUrl, content, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+// yankPypiVersion sets the yanked properties of the files of the version, which are found by the properties Artifactory sets on PyPI packages.
+func yankPypiVersion(servicesManager artifactory.ArtifactoryServicesManager, deprecation PackageDeprecation) error {
+	normalizedName := pypiNameSeparatorsPattern.ReplaceAllString(strings.ToLower(deprecation.Name), "-")
+	props := PypiYankedProp + "=true"
+	if deprecation.Message != "" {
+		props += ";" + PypiYankedReasonProp + "=" + escapePropValue(deprecation.Message)
+	}
+	return setVersionProps(servicesManager, deprecation, servicesutils.CommonParams{
+		Pattern:   deprecation.Repo + "/*",
+		Props:     "pypi.normalized.name=" + escapePropValue(normalizedName) + ";pypi.version=" + escapePropValue(deprecation.Version),
+		Recursive: true,
+	}, props)
+}
+
+// deprecateGenericVersion sets the deprecation properties of the files of the version, whose path is <repo>/<name>/<version>.
+func deprecateGenericVersion(servicesManager artifactory.ArtifactoryServicesManager, deprecation PackageDeprecation) error {
+	props := DeprecatedProp + "=true"
+	if deprecation.Message != "" {
+		props += ";" + DeprecatedMessageProp + "=" + escapePropValue(deprecation.Message)
+	}
+	return setVersionProps(servicesManager, deprecation, servicesutils.CommonParams{
+		Pattern:   deprecation.Repo + "/" + deprecation.Name + "/" + deprecation.Version + "/*",
+		Recursive: true,
+	}, props)
+}
+
+func setVersionProps(servicesManager artifactory.ArtifactoryServicesManager, deprecation PackageDeprecation, searchParams servicesutils.CommonParams, props string) (err error) {
+	reader, err := servicesManager.SearchFiles(services.SearchParams{CommonParams: &searchParams})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	length, err := reader.Length()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if length == 0 {
+		return errorutils.CheckErrorf("no files of %s were found in the repository %s", deprecation, deprecation.Repo)
+	}
+	_, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return err
+}
+
+// reindexPypiRepo recalculates the index of the PyPI repository.
+func reindexPypiRepo(servicesManager artifactory.ArtifactoryServicesManager, repo string) error {
+	reindexUrl := clientutils.AddTrailingSlashIfNeeded(servicesManager.GetConfig().GetServiceDetails().GetUrl()) + "api/pypi/" + repo + "/reindex"
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(reindexUrl, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// escapePropValue escapes the separators of the properties string.
+func escapePropValue(value string) string {
+	return strings.NewReplacer(";", `\;`, ",", `\,`).Replace(value)
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deprecationTestServer struct {
+	mutex           sync.Mutex
+	packageDocument map[string]any
+	aqlQueries      []string
+	// The properties set on each path.
+	props     map[string]string
+	reindexes []string
+}
+
+func createDeprecationTestServer(t *testing.T) (*deprecationTestServer, *config.ServerDetails) {
+	testServer := &deprecationTestServer{
+		packageDocument: map[string]any{"name": "@scope/pkg", "_rev": "1", "versions": map[string]any{"1.0.0": map[string]any{"version": "1.0.0"}}},
+		props:           map[string]string{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testServer.mutex.Lock()
+		defer testServer.mutex.Unlock()
+		content, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch {
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version": "7.100.0"}`))
+		case r.URL.Path == "/artifactory/api/npm/npm-local/@scope/pkg" && r.Method == http.MethodGet:
+			assert.Equal(t, "/artifactory/api/npm/npm-local/@scope%2Fpkg", r.URL.EscapedPath())
+			writeTestJson(t, w, testServer.packageDocument)
+		case r.URL.Path == "/artifactory/api/npm/npm-local/@scope/pkg" && r.Method == http.MethodPut:
+			testServer.packageDocument = map[string]any{}
+			require.NoError(t, json.Unmarshal(content, &testServer.packageDocument))
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/artifactory/api/search/aql":
+			testServer.aqlQueries = append(testServer.aqlQueries, string(content))
+			if strings.Contains(string(content), "pypi-local") {
+				_, _ = w.Write([]byte(`{"results": [{"repo": "pypi-local", "path": "my-lib/1.0", "name": "my_lib-1.0-py3-none-any.whl", "type": "file"}]}`))
+			} else if strings.Contains(string(content), "generic-local") {
+				_, _ = w.Write([]byte(`{"results": [{"repo": "generic-local", "path": "app/1.0", "name": "app.zip", "type": "file"}]}`))
+			} else {
+				_, _ = w.Write([]byte(`{"results": []}`))
+			}
+		case strings.HasPrefix(r.URL.Path, "/artifactory/api/storage/") && r.Method == http.MethodPut:
+			// The properties are separated by ';', which url.Query doesn't accept.
+			props, _, _ := strings.Cut(strings.TrimPrefix(r.URL.RawQuery, "properties="), "&")
+			props, err = url.QueryUnescape(props)
+			require.NoError(t, err)
+			testServer.props[strings.TrimPrefix(r.URL.Path, "/artifactory/api/storage/")] = props
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/artifactory/api/pypi/") && strings.HasSuffix(r.URL.Path, "/reindex"):
+			testServer.reindexes = append(testServer.reindexes, r.URL.Path)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return testServer, &config.ServerDetails{Url: server.URL + "/", ArtifactoryUrl: server.URL + "/artifactory/", AccessToken: "token"}
+}
+
+func writeTestJson(t *testing.T, w http.ResponseWriter, value any) {
+	content, err := json.Marshal(value)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	assert.NoError(t, err)
+}
+
+func TestDeprecateNpmVersion(t *testing.T) {
+	testServer, serverDetails := createDeprecationTestServer(t)
+	require.NoError(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("npm").SetRepo("npm-local").
+		SetPackageName("@scope/pkg").SetVersion("1.0.0").SetMessage("Use 2.0.0").Run())
+	assert.Equal(t, map[string]any{"version": "1.0.0", "deprecated": "Use 2.0.0"}, testServer.packageDocument["versions"].(map[string]any)["1.0.0"])
+	// The other fields of the package document are kept.
+	assert.Equal(t, "1", testServer.packageDocument["_rev"])
+
+	assert.Error(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("npm").SetRepo("npm-local").
+		SetPackageName("@scope/pkg").SetVersion("3.0.0").SetMessage("Use 2.0.0").Run())
+}
+
+func TestDeprecateFromFile(t *testing.T) {
+	testServer, serverDetails := createDeprecationTestServer(t)
+	filePath := filepath.Join(t.TempDir(), "deprecations.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`[
+		{"type": "pypi", "repo": "pypi-local", "name": "My_Lib", "version": "1.0"},
+		{"name": "app", "version": "1.0", "message": "Broken; use 1.1"}
+	]`), 0644))
+	require.NoError(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("generic").SetRepo("generic-local").
+		SetMessage("Security issue").SetFilePath(filePath).Run())
+
+	require.Len(t, testServer.aqlQueries, 2)
+	assert.Contains(t, testServer.aqlQueries[0], `"@pypi.normalized.name":"my-lib"`)
+	assert.Contains(t, testServer.aqlQueries[0], `"@pypi.version":"1.0"`)
+	assert.Equal(t, map[string]string{
+		"pypi-local/my-lib/1.0/my_lib-1.0-py3-none-any.whl": "pypi.yanked=true;pypi.yanked.reason=Security issue",
+		"generic-local/app/1.0/app.zip":                     "deprecated=true;deprecated.message=Broken; use 1.1",
+	}, testServer.props)
+	assert.Equal(t, []string{"/artifactory/api/pypi/pypi-local/reindex"}, testServer.reindexes)
+}
+
+func TestDeprecateErrors(t *testing.T) {
+	_, serverDetails := createDeprecationTestServer(t)
+	// A version without files.
+	assert.ErrorContains(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("generic").SetRepo("other-local").
+		SetPackageName("app").SetVersion("1.0").Run(), "failed to deprecate 1 of the 1 package versions")
+	assert.ErrorContains(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("npm").SetRepo("npm-local").
+		SetPackageName("@scope/pkg").SetVersion("1.0.0").Run(), "deprecation message")
+	assert.ErrorContains(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("maven").SetRepo("libs-local").
+		SetPackageName("org:app").SetVersion("1.0").Run(), "unsupported package type 'maven'")
+	assert.ErrorContains(t, NewDeprecateCommand().SetServerDetails(serverDetails).SetPackageType("npm").
+		SetPackageName("pkg").SetVersion("1.0.0").SetMessage("m").Run(), "repository")
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+)
+
+const (
+	metadataQueryApi = "metadata/api/v1/query"
+	// The maximum number of packages a query returns. A name with wildcards may match more packages.
+	maxPackages = 100
+)
+
+type metadataQuery struct {
+	Query string `json:"query"`
+}
+
+type metadataQueryResponse struct {
+	Data struct {
+		Packages struct {
+			Edges []struct {
+				Node metadataPackage `json:"node"`
+			} `json:"edges"`
+		} `json:"packages"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type metadataPackage struct {
+	Name        string            `json:"name"`
+	PackageType string            `json:"packageType"`
+	Versions    []metadataVersion `json:"versions"`
+}
+
+type metadataVersion struct {
+	Name    string `json:"name"`
+	Created string `json:"created"`
+	Repos   []struct {
+		Name string `json:"name"`
+	} `json:"repos"`
+}
+
+// metadataClient queries the Metadata GraphQL API, using the HTTP client and the credentials of the Artifactory services manager.
+type metadataClient struct {
+	client      *jfroghttpclient.JfrogHttpClient
+	httpDetails httputils.HttpClientDetails
+	queryUrl    string
+}
+
+func newMetadataClient(serverDetails *config.ServerDetails) (*metadataClient, error) {
+	platformUrl := serverDetails.GetUrl()
+	if platformUrl == "" {
+		platformUrl = strings.TrimSuffix(clientutils.AddTrailingSlashIfNeeded(serverDetails.GetArtifactoryUrl()), "artifactory/")
+	}
+	if platformUrl == "" {
+		return nil, errorutils.CheckErrorf("the JFrog Platform URL is mandatory for the package commands")
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.SetContentTypeApplicationJson()
+	return &metadataClient{
+		client:      servicesManager.Client(),
+		httpDetails: httpDetails,
+		queryUrl:    clientutils.AddTrailingSlashIfNeeded(platformUrl) + metadataQueryApi,
+	}, nil
+}
+
+// getPackages returns the packages of the types whose name matches the name, which may include wildcards, with their versions.
+func (mc *metadataClient) getPackages(name string, graphqlTypes []string) ([]metadataPackage, error) {
+	content, err := json.Marshal(metadataQuery{Query: createPackagesQuery(name, graphqlTypes)})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	resp, body, err := mc.client.SendPost(mc.queryUrl, content, &mc.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	response := new(metadataQueryResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+		for _, queryError := range response.Errors {
+			messages = append(messages, queryError.Message)
+		}
+		return nil, errorutils.CheckErrorf("the packages query failed: %s", strings.Join(messages, "; "))
+	}
+	packages := make([]metadataPackage, 0, len(response.Data.Packages.Edges))
+	for _, edge := range response.Data.Packages.Edges {
+		packages = append(packages, edge.Node)
+	}
+	return packages, nil
+}
+
+func createPackagesQuery(name string, graphqlTypes []string) string {
+	// A JSON string is a valid GraphQL string.
+	quotedName, _ := json.Marshal(name)
+	return fmt.Sprintf("query { packages(filter: {name: %s, packageTypeIn: [%s]}, first: %d) { edges { node { name packageType versions { name created repos { name } } } } } }",
+		quotedName, strings.Join(graphqlTypes, ", "), maxPackages)
+}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The package types the versions are listed for, and their names in the Metadata API.
+var supportedPackageTypes = map[string]string{
+	"npm":    "NPM",
+	"maven":  "MAVEN",
+	"docker": "DOCKER",
+	"pypi":   "PYPI",
+}
+
+// The pre-release versions of npm and Maven have a '-' suffix, and the ones of PyPI end with aN, bN, rcN or .devN.
+var preReleasePattern = regexp.MustCompile(`-|\d(a|b|rc|\.dev)\d*$`)
+
+// Package is a package with its versions, sorted from the highest, or from the Docker tag created last.
+type Package struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// The highest release version, or the highest pre-release version if the package has no releases.
+	// The tags of Docker images aren't ordered, so the latest is the tag created last.
+	Latest   string           `json:"latest"`
+	Versions []PackageVersion `json:"versions"`
+}
+
+type PackageVersion struct {
+	Version      string   `json:"version"`
+	Created      string   `json:"created,omitempty"`
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// VersionsCommand lists the versions of a package in all the repositories, using the Metadata API.
+type VersionsCommand struct {
+	serverDetails *config.ServerDetails
+	packageName   string
+	packageTypes  []string
+	latest        bool
+	outputFormat  format.OutputFormat
+	packages      []Package
+}
+
+func NewVersionsCommand() *VersionsCommand {
+	return &VersionsCommand{outputFormat: format.Table}
+}
+
+func (vc *VersionsCommand) SetServerDetails(serverDetails *config.ServerDetails) *VersionsCommand {
+	vc.serverDetails = serverDetails
+	return vc
+}
+
+// SetPackageName sets the name of the package, which may include wildcards. The name of a Maven package is groupId:artifactId.
+func (vc *VersionsCommand) SetPackageName(packageName string) *VersionsCommand {
+	vc.packageName = packageName
+	return vc
+}
+
+// SetPackageTypes sets the types of the packages. If empty, the packages of all the supported types are listed.
+func (vc *VersionsCommand) SetPackageTypes(packageTypes []string) *VersionsCommand {
+	vc.packageTypes = packageTypes
+	return vc
+}
+
+// SetLatest lists the latest version of each package only.
+func (vc *VersionsCommand) SetLatest(latest bool) *VersionsCommand {
+	vc.latest = latest
+	return vc
+}
+
+func (vc *VersionsCommand) SetOutputFormat(outputFormat format.OutputFormat) *VersionsCommand {
+	vc.outputFormat = outputFormat
+	return vc
+}
+
+func (vc *VersionsCommand) Packages() []Package {
+	return vc.packages
+}
+
+func (vc *VersionsCommand) CommandName() string {
+	return "package_versions"
+}
+
+func (vc *VersionsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return vc.serverDetails, nil
+}
+
+func (vc *VersionsCommand) Run() error {
+	if vc.outputFormat != format.Json && vc.outputFormat != format.Table {
+		return errorutils.CheckErrorf("unsupported format '%s' for package versions. Acceptable values are: json, table", vc.outputFormat)
+	}
+	graphqlTypes, err := toGraphqlTypes(vc.packageTypes)
+	if err != nil {
+		return err
+	}
+	client, err := newMetadataClient(vc.serverDetails)
+	if err != nil {
+		return err
+	}
+	metadataPackages, err := client.getPackages(vc.packageName, graphqlTypes)
+	if err != nil {
+		return err
+	}
+	vc.packages = make([]Package, 0, len(metadataPackages))
+	for _, metadataPackage := range metadataPackages {
+		pkg := toPackage(metadataPackage)
+		if vc.latest {
+			pkg.Versions = slices.DeleteFunc(pkg.Versions, func(packageVersion PackageVersion) bool {
+				return packageVersion.Version != pkg.Latest
+			})
+		}
+		vc.packages = append(vc.packages, pkg)
+	}
+	sort.Slice(vc.packages, func(i, j int) bool {
+		if vc.packages[i].Type != vc.packages[j].Type {
+			return vc.packages[i].Type < vc.packages[j].Type
+		}
+		return vc.packages[i].Name < vc.packages[j].Name
+	})
+	if vc.outputFormat == format.Json {
+		data, err := json.Marshal(vc.packages)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(data))
+		return nil
+	}
+	return printPackageVersionsTable(vc.packages)
+}
+
+func toGraphqlTypes(packageTypes []string) ([]string, error) {
+	if len(packageTypes) == 0 {
+		packageTypes = GetSupportedPackageTypes()
+	}
+	graphqlTypes := make([]string, 0, len(packageTypes))
+	for _, packageType := range packageTypes {
+		graphqlType, ok := supportedPackageTypes[strings.ToLower(strings.TrimSpace(packageType))]
+		if !ok {
+			return nil, errorutils.CheckErrorf("unsupported package type '%s'. The supported types are: %s", packageType, strings.Join(GetSupportedPackageTypes(), ", "))
+		}
+		if !slices.Contains(graphqlTypes, graphqlType) {
+			graphqlTypes = append(graphqlTypes, graphqlType)
+		}
+	}
+	return graphqlTypes, nil
+}
+
+// GetSupportedPackageTypes returns the sorted package types the versions can be listed for.
+func GetSupportedPackageTypes() []string {
+	packageTypes := make([]string, 0, len(supportedPackageTypes))
+	for packageType := range supportedPackageTypes {
+		packageTypes = append(packageTypes, packageType)
+	}
+	sort.Strings(packageTypes)
+	return packageTypes
+}
+
+// toPackage converts the package of the Metadata API, and sorts its versions.
+func toPackage(metadataPackage metadataPackage) Package {
+	pkg := Package{Name: metadataPackage.Name, Type: strings.ToLower(metadataPackage.PackageType)}
+	for _, metadataVersion := range metadataPackage.Versions {
+		packageVersion := PackageVersion{Version: metadataVersion.Name, Created: metadataVersion.Created}
+		for _, repo := range metadataVersion.Repos {
+			packageVersion.Repositories = append(packageVersion.Repositories, repo.Name)
+		}
+		sort.Strings(packageVersion.Repositories)
+		pkg.Versions = append(pkg.Versions, packageVersion)
+	}
+	pkg.Latest = sortVersions(pkg.Type, pkg.Versions)
+	return pkg
+}
+
+// sortVersions sorts the versions from the highest, or the Docker tags from the one created last, and returns the latest version.
+func sortVersions(packageType string, versions []PackageVersion) (latest string) {
+	if packageType == "docker" {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versions[i].Created > versions[j].Created
+		})
+	} else {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return version.NewVersion(versions[j].Version).Compare(versions[i].Version) > 0
+		})
+	}
+	if len(versions) == 0 {
+		return ""
+	}
+	if packageType != "docker" {
+		for _, packageVersion := range versions {
+			if !preReleasePattern.MatchString(packageVersion.Version) {
+				return packageVersion.Version
+			}
+		}
+	}
+	return versions[0].Version
+}
+
+type packageVersionRow struct {
+	Name         string `col-name:"Package"`
+	Type         string `col-name:"Type"`
+	Version      string `col-name:"Version"`
+	Latest       string `col-name:"Latest"`
+	Created      string `col-name:"Created"`
+	Repositories string `col-name:"Repositories"`
+}
+
+func printPackageVersionsTable(packages []Package) error {
+	var rows []packageVersionRow
+	for _, pkg := range packages {
+		for _, packageVersion := range pkg.Versions {
+			latest := ""
+			if packageVersion.Version == pkg.Latest {
+				latest = "true"
+			}
+			rows = append(rows, packageVersionRow{
+				Name:         pkg.Name,
+				Type:         pkg.Type,
+				Version:      packageVersion.Version,
+				Latest:       latest,
+				Created:      packageVersion.Created,
+				Repositories: strings.Join(packageVersion.Repositories, ", "),
+			})
+		}
+	}
+	return coreutils.PrintTable(rows, "Package Versions", "No package versions were found", false)
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackagesResponse = `{"data": {"packages": {"edges": [
+	{"node": {"name": "lodash", "packageType": "NPM", "versions": [
+		{"name": "4.17.20", "created": "2020-08-13T00:00:00Z", "repos": [{"name": "npm-remote"}]},
+		{"name": "4.17.21", "created": "2021-02-20T00:00:00Z", "repos": [{"name": "npm-remote"}, {"name": "npm-local"}]},
+		{"name": "5.0.0-alpha.1", "created": "2022-01-01T00:00:00Z", "repos": [{"name": "npm-local"}]},
+		{"name": "4.9.0", "created": "2016-01-01T00:00:00Z", "repos": [{"name": "npm-remote"}]}
+	]}},
+	{"node": {"name": "lodash", "packageType": "DOCKER", "versions": [
+		{"name": "stable", "created": "2023-01-01T00:00:00Z", "repos": [{"name": "docker-local"}]},
+		{"name": "1.2", "created": "2024-01-01T00:00:00Z", "repos": [{"name": "docker-local"}]}
+	]}}
+]}}}`
+
+func createMetadataTestServer(t *testing.T, response string) (*config.ServerDetails, *string) {
+	query := new(string)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/metadata/api/v1/query", r.URL.Path)
+		content, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request := metadataQuery{}
+		require.NoError(t, json.Unmarshal(content, &request))
+		*query = request.Query
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(testServer.Close)
+	return &config.ServerDetails{Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/artifactory/", AccessToken: "token"}, query
+}
+
+func TestVersionsCommand(t *testing.T) {
+	serverDetails, query := createMetadataTestServer(t, testPackagesResponse)
+	versionsCommand := NewVersionsCommand().SetServerDetails(serverDetails).SetPackageName("lodash").SetOutputFormat(format.Json)
+	require.NoError(t, versionsCommand.Run())
+	assert.Contains(t, *query, `packages(filter: {name: "lodash", packageTypeIn: [DOCKER, MAVEN, NPM, PYPI]}`)
+
+	packages := versionsCommand.Packages()
+	require.Len(t, packages, 2)
+	// The Docker tags are sorted from the one created last.
+	assert.Equal(t, "docker", packages[0].Type)
+	assert.Equal(t, "1.2", packages[0].Latest)
+	assert.Equal(t, []string{"1.2", "stable"}, getVersions(packages[0]))
+	// The highest release is the latest npm version.
+	assert.Equal(t, "npm", packages[1].Type)
+	assert.Equal(t, "4.17.21", packages[1].Latest)
+	assert.Equal(t, []string{"5.0.0-alpha.1", "4.17.21", "4.17.20", "4.9.0"}, getVersions(packages[1]))
+	assert.Equal(t, []string{"npm-local", "npm-remote"}, packages[1].Versions[1].Repositories)
+
+	versionsCommand = NewVersionsCommand().SetServerDetails(serverDetails).SetPackageName("lodash").SetPackageTypes([]string{"npm", "Docker", "npm"}).SetLatest(true)
+	require.NoError(t, versionsCommand.Run())
+	assert.Contains(t, *query, "packageTypeIn: [NPM, DOCKER]")
+	for _, pkg := range versionsCommand.Packages() {
+		assert.Equal(t, []string{pkg.Latest}, getVersions(pkg))
+	}
+}
+
+func TestVersionsCommandErrors(t *testing.T) {
+	serverDetails, _ := createMetadataTestServer(t, `{"errors": [{"message": "invalid filter"}]}`)
+	assert.ErrorContains(t, NewVersionsCommand().SetServerDetails(serverDetails).SetPackageName("lodash").Run(), "invalid filter")
+	assert.ErrorContains(t, NewVersionsCommand().SetServerDetails(serverDetails).SetPackageName("lodash").SetPackageTypes([]string{"cargo"}).Run(), "unsupported package type 'cargo'")
+	assert.Error(t, NewVersionsCommand().SetServerDetails(serverDetails).SetPackageName("lodash").SetOutputFormat(format.Sarif).Run())
+}
+
+func TestSortVersionsPreReleases(t *testing.T) {
+	versions := []PackageVersion{{Version: "2.0.0rc1"}, {Version: "1.10.0"}, {Version: "1.9.0"}, {Version: "2.0.0.dev3"}}
+	assert.Equal(t, "1.10.0", sortVersions("pypi", versions))
+	// A package without releases resolves to its highest pre-release.
+	assert.Equal(t, "1.0.0-SNAPSHOT", sortVersions("maven", []PackageVersion{{Version: "0.9.0-SNAPSHOT"}, {Version: "1.0.0-SNAPSHOT"}}))
+	assert.Empty(t, sortVersions("npm", nil))
+}
+
+func getVersions(pkg Package) []string {
+	var versions []string
+	for _, packageVersion := range pkg.Versions {
+		versions = append(versions, packageVersion.Version)
+	}
+	return versions
+}
@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+const defaultEnvInclude = "*"
+
+// PublishBuildOptions are the options of Client.PublishBuild, which match the options of 'jf rt build-publish'.
+type PublishBuildOptions struct {
+	BuildName   string
+	BuildNumber string
+	// The key of the project of the build.
+	Project string
+	// The URL of the CI job of the build.
+	BuildUrl string
+	// Record the environment variables of the process in the build-info.
+	CollectEnv bool
+	// Semicolon-separated patterns of the environment variables recorded in the build-info. Defaults to all of them.
+	EnvInclude string
+	// Semicolon-separated patterns of the environment variables which aren't recorded. Defaults to the variables whose
+	// names contain password, secret, key, token and the like.
+	EnvExclude string
+	// Record the revision, the branch and the URL of the Git repository in the build-info.
+	CollectGitInfo bool
+	// The path of the .git directory. Defaults to the .git directory of the working directory or of its ancestors.
+	DotGitPath string
+	// Replace the published build-infos of the build number.
+	Overwrite bool
+	DryRun    bool
+}
+
+// PublishBuildResult is the result of the publishing of a build-info.
+type PublishBuildResult struct {
+	// The URL of the build-info in the UI of the platform.
+	BuildInfoUiUrl string `json:"buildInfoUiUrl,omitempty"`
+}
+
+// PublishBuild publishes the build-info collected by the commands which ran with the build name and number, such as the
+// uploads, the downloads and the Gradle builds.
+func (c *Client) PublishBuild(ctx context.Context, options PublishBuildOptions) (*PublishBuildResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	buildConfiguration := build.NewBuildConfiguration(options.BuildName, options.BuildNumber, "", options.Project)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return nil, err
+	}
+	publishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(c.serverDetails).SetBuildConfiguration(buildConfiguration).
+		SetConfig(&biconf.Configuration{
+			BuildUrl:   options.BuildUrl,
+			DryRun:     options.DryRun,
+			EnvInclude: valueOrDefault(options.EnvInclude, defaultEnvInclude),
+			EnvExclude: valueOrDefault(options.EnvExclude, buildinfo.DefaultEnvExclude),
+			Overwrite:  options.Overwrite,
+		}).SetCollectEnv(options.CollectEnv).SetCollectGitInfo(options.CollectGitInfo).SetSuppressOutput(true).SetContext(ctx)
+	publishCmd.SetDotGitPath(options.DotGitPath)
+	if err := publishCmd.Run(); err != nil {
+		return nil, err
+	}
+	return &PublishBuildResult{BuildInfoUiUrl: publishCmd.GetBuildInfoUiUrl()}, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
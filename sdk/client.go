@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Server is the JFrog Platform instance the commands run against.
+type Server struct {
+	// The URL of the platform, such as https://acme.jfrog.io/.
+	Url string
+	// The URL of Artifactory. Defaults to the Artifactory URL of the platform.
+	ArtifactoryUrl string
+	AccessToken    string
+	User           string
+	Password       string
+	InsecureTls    bool
+}
+
+// Client runs the commands against a server.
+type Client struct {
+	serverDetails *config.ServerDetails
+}
+
+func NewClient(server Server) (*Client, error) {
+	if server.Url == "" && server.ArtifactoryUrl == "" {
+		return nil, errorutils.CheckErrorf("the URL of the server is required")
+	}
+	serverDetails := &config.ServerDetails{
+		ArtifactoryUrl: server.ArtifactoryUrl,
+		AccessToken:    server.AccessToken,
+		User:           server.User,
+		Password:       server.Password,
+		InsecureTls:    server.InsecureTls,
+	}
+	if server.Url != "" {
+		serverDetails.Url = clientutils.AddTrailingSlashIfNeeded(server.Url)
+		serverDetails.LifecycleUrl = serverDetails.Url + "lifecycle/"
+		if serverDetails.ArtifactoryUrl == "" {
+			serverDetails.ArtifactoryUrl = serverDetails.Url + "artifactory/"
+		}
+	}
+	serverDetails.ArtifactoryUrl = clientutils.AddTrailingSlashIfNeeded(serverDetails.ArtifactoryUrl)
+	return &Client{serverDetails: serverDetails}, nil
+}
+
+// NewClientFromConfig returns a client of a server configured by 'jf config'. The default server is used if the ID is empty.
+func NewClientFromConfig(serverId string) (*Client, error) {
+	serverDetails, err := config.GetSpecificConfig(serverId, true, false)
+	if err != nil {
+		return nil, err
+	}
+	if serverDetails.Url != "" && serverDetails.LifecycleUrl == "" {
+		serverDetails.LifecycleUrl = clientutils.AddTrailingSlashIfNeeded(serverDetails.Url) + "lifecycle/"
+	}
+	return &Client{serverDetails: serverDetails}, nil
+}
+
+// BuildOptions identifies the build-info which the artifacts of a command are recorded in.
+type BuildOptions struct {
+	BuildName   string
+	BuildNumber string
+	// The key of the project of the build.
+	Project string
+	// The module of the artifacts in the build-info. Defaults to the build name.
+	Module string
+}
+
+func (bo BuildOptions) isSet() bool {
+	return bo.BuildName != "" || bo.BuildNumber != ""
+}
+
+// checkContext returns the error of the context if it's done, so that a canceled command doesn't start.
+func checkContext(ctx context.Context) error {
+	if err := context.Cause(ctx); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
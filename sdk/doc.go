@@ -0,0 +1,13 @@
+// Package sdk runs the Artifactory commands of the CLI in-process, so that other Go programs can upload and download artifacts,
+// publish build-info, run Gradle builds and manage release bundles without running the jf binary.
+//
+// The functions of the package take a context, which cancels their requests, and an options struct. They return results
+// which are read from the commands, rather than printed. The option and the result types of the package are an API: fields
+// may be added to them, but the existing fields aren't changed or removed.
+//
+//	client, err := sdk.NewClient(sdk.Server{Url: "https://acme.jfrog.io/", AccessToken: token})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.Upload(ctx, sdk.UploadOptions{Pattern: "build/*.zip", Target: "generic-local/app/"})
+package sdk
@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/gradle"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+)
+
+// GradleOptions are the options of Client.RunGradle, which match the options of 'jf gradle'.
+type GradleOptions struct {
+	// The tasks and the arguments of the build, such as "clean", "artifactoryPublish".
+	Tasks []string
+	// The path of the Gradle configuration created by 'jf gradle-config', usually .jfrog/projects/gradle.yaml. The resolution
+	// and the deployment servers are read from the configuration, by their IDs.
+	ConfigPath string
+	// The number of artifacts deployed in parallel. Defaults to 3.
+	Threads int
+	// The build is stopped after the timeout, if positive. The build-info collected until then is kept.
+	Timeout time.Duration
+	// The build-info of the build. Like with the CLI, the build name and number default to the JFROG_CLI_BUILD_NAME and the
+	// JFROG_CLI_BUILD_NUMBER environment variables.
+	Build BuildOptions
+}
+
+// GradleResult is the result of a Gradle build.
+type GradleResult struct {
+	// The artifacts deployed by the build, if the configuration has a deployer.
+	Deployed TransferResult `json:"deployed"`
+}
+
+// RunGradle runs a Gradle build with the build-info extractor, which resolves the dependencies from Artifactory, deploys
+// the artifacts, and collects the build-info. The build and its processes are stopped when the context is canceled.
+func (c *Client) RunGradle(ctx context.Context, options GradleOptions) (*GradleResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	gradleCmd := gradle.NewGradleCommand().SetServerDetails(c.serverDetails).SetConfigPath(options.ConfigPath).SetTasks(options.Tasks).
+		SetConfiguration(build.NewBuildConfiguration(options.Build.BuildName, options.Build.BuildNumber, options.Build.Module, options.Build.Project)).SetThreads(threadsOrDefault(options.Threads)).SetDetailedSummary(true).
+		SetContext(ctx).SetTimeout(options.Timeout)
+	runErr := gradleCmd.Run()
+	result := &GradleResult{Deployed: TransferResult{Files: []TransferredFile{}}}
+	if gradleCmd.Result() == nil {
+		return result, runErr
+	}
+	deployed, err := readTransferResult(gradleCmd.Result(), runErr)
+	result.Deployed = *deployed
+	return result, err
+}
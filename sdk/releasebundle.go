@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"strconv"
+
+	lifecycle "github.com/jfrog/jfrog-cli-artifactory/lifecycle/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ReleaseBundleBuild is a build whose artifacts are added to a release bundle.
+type ReleaseBundleBuild struct {
+	BuildName   string
+	BuildNumber string
+	// Add the dependencies of the build, in addition to its artifacts.
+	IncludeDependencies bool
+}
+
+// CreateReleaseBundleOptions are the options of Client.CreateReleaseBundle, which match the options of 'jf release-bundle-create'.
+type CreateReleaseBundleOptions struct {
+	Name    string
+	Version string
+	// The key of the project of the release bundle and of its builds.
+	Project string
+	// The name of the GPG or RSA key pair which the release bundle is signed with.
+	SigningKey string
+	// The release bundle is created from the artifacts of the builds.
+	Builds []ReleaseBundleBuild
+	// Create a draft, which can be updated before it's finalized.
+	Draft bool
+	// Return once the creation started, rather than once it completed.
+	Async bool
+}
+
+// PromoteReleaseBundleOptions are the options of Client.PromoteReleaseBundle, which match the options of 'jf release-bundle-promote'.
+type PromoteReleaseBundleOptions struct {
+	Name        string
+	Version     string
+	Environment string
+	// The key of the project of the release bundle.
+	Project    string
+	SigningKey string
+	// The patterns of the repositories of the environment which the artifacts are promoted to, or aren't.
+	IncludeRepos []string
+	ExcludeRepos []string
+	// The type of the promotion, such as copy or move. Defaults to copy.
+	PromotionType string
+	// Return once the promotion started, rather than once it completed.
+	Async bool
+}
+
+// ReleaseBundlePromotion is the result of the promotion of a release bundle.
+type ReleaseBundlePromotion struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Environment string `json:"environment"`
+	// The repository of the release bundle.
+	Repository string `json:"repository,omitempty"`
+	// The creation time of the promotion, in ISO 8601 format.
+	Created string `json:"created,omitempty"`
+}
+
+// CreateReleaseBundle creates a release bundle version. The requests of the creation aren't canceled by the context, which
+// is checked before they're sent.
+func (c *Client) CreateReleaseBundle(ctx context.Context, options CreateReleaseBundleOptions) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if err := c.checkLifecycleUrl(); err != nil {
+		return err
+	}
+	if len(options.Builds) == 0 {
+		return errorutils.CheckErrorf("the release bundle %s/%s must be created from at least one build", options.Name, options.Version)
+	}
+	creationSpec := &spec.SpecFiles{}
+	for _, build := range options.Builds {
+		buildSpec, err := spec.CreateSpecFromBuildNameNumberAndProject(build.BuildName, build.BuildNumber, options.Project)
+		if err != nil {
+			return err
+		}
+		buildSpec.Files[0].IncludeDeps = strconv.FormatBool(build.IncludeDependencies)
+		creationSpec.Files = append(creationSpec.Files, buildSpec.Files...)
+	}
+	return lifecycle.NewReleaseBundleCreateCommand().SetServerDetails(c.serverDetails).SetReleaseBundleName(options.Name).
+		SetReleaseBundleVersion(options.Version).SetReleaseBundleProject(options.Project).SetSigningKeyName(options.SigningKey).
+		SetSpec(creationSpec).SetDraft(options.Draft).SetSync(!options.Async).Run()
+}
+
+// PromoteReleaseBundle promotes a release bundle version to an environment. The requests of the promotion aren't canceled
+// by the context, which is checked before they're sent.
+func (c *Client) PromoteReleaseBundle(ctx context.Context, options PromoteReleaseBundleOptions) (*ReleaseBundlePromotion, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.checkLifecycleUrl(); err != nil {
+		return nil, err
+	}
+	promoteCmd := lifecycle.NewReleaseBundlePromoteCommand().SetServerDetails(c.serverDetails).SetReleaseBundleName(options.Name).
+		SetReleaseBundleVersion(options.Version).SetEnvironment(options.Environment).SetReleaseBundleProject(options.Project).
+		SetSigningKeyName(options.SigningKey).SetIncludeReposPatterns(options.IncludeRepos).SetExcludeReposPatterns(options.ExcludeRepos).
+		SetPromotionType(options.PromotionType).SetSync(!options.Async).SetSuppressOutput(true)
+	if err := promoteCmd.Run(); err != nil {
+		return nil, err
+	}
+	promotion := promoteCmd.Result()
+	return &ReleaseBundlePromotion{
+		Name:        promotion.ReleaseBundleName,
+		Version:     promotion.ReleaseBundleVersion,
+		Environment: promotion.Environment,
+		Repository:  promotion.RepositoryKey,
+		Created:     promotion.Created,
+	}, nil
+}
+
+func (c *Client) checkLifecycleUrl() error {
+	if c.serverDetails.LifecycleUrl == "" {
+		return errorutils.CheckErrorf("the platform URL of the server is required to manage release bundles")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(Server{Url: "https://acme.jfrog.io", AccessToken: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/", client.serverDetails.Url)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/", client.serverDetails.ArtifactoryUrl)
+	assert.Equal(t, "https://acme.jfrog.io/lifecycle/", client.serverDetails.LifecycleUrl)
+	assert.Equal(t, "token", client.serverDetails.AccessToken)
+
+	client, err = NewClient(Server{ArtifactoryUrl: "https://artifactory.acme.com/artifactory"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://artifactory.acme.com/artifactory/", client.serverDetails.ArtifactoryUrl)
+	assert.Empty(t, client.serverDetails.LifecycleUrl)
+
+	_, err = NewClient(Server{AccessToken: "token"})
+	assert.Error(t, err)
+}
+
+func TestUpload(t *testing.T) {
+	var mutex sync.Mutex
+	uploaded := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/artifactory/generic-local/"):
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			mutex.Lock()
+			uploaded[strings.TrimPrefix(r.URL.Path, "/artifactory/")] = string(content)
+			mutex.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"checksums":{"sha256":"` + fmt.Sprintf("%x", sha256.Sum256(content)) + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0644))
+	client, err := NewClient(Server{ArtifactoryUrl: server.URL + "/artifactory/"})
+	require.NoError(t, err)
+
+	result, err := client.Upload(context.Background(), UploadOptions{Pattern: filepath.ToSlash(dir) + "/*.txt", Target: "generic-local/app/", Flat: true, Retries: -1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Zero(t, result.Failed)
+	assert.Equal(t, map[string]string{"generic-local/app/a.txt": "a", "generic-local/app/b.txt": "b"}, uploaded)
+	require.Len(t, result.Files, 2)
+	for _, file := range result.Files {
+		assert.Equal(t, "generic-local/app/"+filepath.Base(file.Source), file.Target)
+		assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte(uploaded[file.Target]))), file.Sha256)
+	}
+	// The result is part of the API.
+	content, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"succeeded":2`)
+}
+
+func TestCanceledContext(t *testing.T) {
+	client, err := NewClient(Server{Url: "https://acme.jfrog.io/"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.Upload(ctx, UploadOptions{Pattern: "*.txt", Target: "generic-local/"})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = client.Download(ctx, DownloadOptions{Pattern: "generic-local/*"})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = client.PublishBuild(ctx, PublishBuildOptions{BuildName: "app", BuildNumber: "1"})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = client.RunGradle(ctx, GradleOptions{Tasks: []string{"build"}})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, client.CreateReleaseBundle(ctx, CreateReleaseBundleOptions{Name: "app", Version: "1"}), context.Canceled)
+	_, err = client.PromoteReleaseBundle(ctx, PromoteReleaseBundleOptions{Name: "app", Version: "1", Environment: "PROD"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPromoteReleaseBundle(t *testing.T) {
+	var promotion map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.90.0"}`))
+		case "/lifecycle/api/v2/promotion/records/app/1.0.0":
+			assert.Equal(t, "false", r.URL.Query().Get("async"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&promotion))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"repository_key":"release-bundles-v2","release_bundle_name":"app","release_bundle_version":"1.0.0","environment":"PROD","created":"2026-10-14T10:00:00.000Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Server{Url: server.URL})
+	require.NoError(t, err)
+	result, err := client.PromoteReleaseBundle(context.Background(), PromoteReleaseBundleOptions{Name: "app", Version: "1.0.0", Environment: "PROD", IncludeRepos: []string{"prod-*"}})
+	require.NoError(t, err)
+	assert.Equal(t, &ReleaseBundlePromotion{Name: "app", Version: "1.0.0", Environment: "PROD", Repository: "release-bundles-v2", Created: "2026-10-14T10:00:00.000Z"}, result)
+	assert.Equal(t, "PROD", promotion["environment"])
+	assert.Equal(t, []any{"prod-*"}, promotion["included_repository_keys"])
+}
+
+func TestCreateReleaseBundleWithoutBuilds(t *testing.T) {
+	client, err := NewClient(Server{Url: "https://acme.jfrog.io/"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, client.CreateReleaseBundle(context.Background(), CreateReleaseBundleOptions{Name: "app", Version: "1"}), "at least one build")
+
+	client, err = NewClient(Server{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, client.CreateReleaseBundle(context.Background(), CreateReleaseBundleOptions{Name: "app", Version: "1"}), "platform URL")
+}
@@ -0,0 +1,186 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// UploadOptions are the options of Client.Upload, which match the options of 'jf rt upload'.
+type UploadOptions struct {
+	// The local files to upload, as a wildcard pattern, such as "build/*.zip", or as a regular expression if Regexp is set.
+	Pattern string
+	// The target path in Artifactory, in the form of <repo>/<path>. A target which ends with a slash is a directory.
+	Target string
+	// Semicolon-separated key=value properties, such as "a=1;b=2", attached to the uploaded artifacts.
+	TargetProps string
+	Exclusions  []string
+	// Upload the files of the subdirectories. Defaults to false, unlike the CLI.
+	Recursive bool
+	// Upload the files to the target as is, without the directories of the pattern.
+	Flat   bool
+	Regexp bool
+	Ant    bool
+	// Extract the uploaded archives to the target.
+	Explode bool
+	// The number of files uploaded in parallel. Defaults to 3.
+	Threads int
+	// The number of times a failed request is retried. If zero, the requests are retried 3 times, as by the CLI. Set a negative
+	// value to not retry them.
+	Retries int
+	DryRun  bool
+	Build   BuildOptions
+}
+
+// DownloadOptions are the options of Client.Download, which match the options of 'jf rt download'.
+type DownloadOptions struct {
+	// The artifacts to download, in the form of <repo>/<path>, with wildcards.
+	Pattern string
+	// The local target path. A target which ends with a slash is a directory. Defaults to the working directory.
+	Target string
+	// Semicolon-separated key=value properties, such as "a=1;b=2", which the artifacts must have.
+	Props      string
+	Exclusions []string
+	// Download the artifacts of the subdirectories. Defaults to false, unlike the CLI.
+	Recursive bool
+	// Download the artifacts to the target as is, without the directories of the pattern.
+	Flat bool
+	// Download the artifacts of a build, in the form of <build name>/<build number>.
+	FromBuild string
+	// Extract the downloaded archives.
+	Explode bool
+	// The number of artifacts downloaded in parallel. Defaults to 3.
+	Threads int
+	// The number of times a failed request is retried. If zero, the requests are retried 3 times, as by the CLI. Set a negative
+	// value to not retry them.
+	Retries int
+	DryRun  bool
+	Build   BuildOptions
+}
+
+// TransferredFile is a file which was uploaded or downloaded.
+type TransferredFile struct {
+	// The local path of an uploaded file, or the path in Artifactory of a downloaded artifact.
+	Source string `json:"source"`
+	// The path in Artifactory of an uploaded file, or the local path of a downloaded artifact.
+	Target string `json:"target"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+// TransferResult is the result of an upload or a download.
+type TransferResult struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Files     []TransferredFile `json:"files"`
+}
+
+// Upload uploads local files to Artifactory. The result lists the uploaded files, including when some of them failed.
+func (c *Client) Upload(ctx context.Context, options UploadOptions) (*TransferResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	uploadSpec := spec.NewBuilder().
+		Pattern(options.Pattern).
+		Target(strings.TrimPrefix(options.Target, "/")).
+		TargetProps(options.TargetProps).
+		Exclusions(options.Exclusions).
+		Recursive(options.Recursive).
+		Flat(options.Flat).
+		Regexp(options.Regexp).
+		Ant(options.Ant).
+		Explode(strconv.FormatBool(options.Explode)).
+		BuildSpec()
+	if err := spec.ValidateSpec(uploadSpec.Files, true, false); err != nil {
+		return nil, err
+	}
+	uploadCmd := generic.NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{
+		Threads:        threadsOrDefault(options.Threads),
+		SplitCount:     flagkit.UploadSplitCount,
+		MinSplitSizeMB: flagkit.UploadMinSplitMb,
+		ChunkSizeMB:    flagkit.UploadChunkSizeMb,
+		ExplodeArchive: options.Explode,
+	}).SetBuildConfiguration(buildConfiguration(options.Build)).SetSpec(uploadSpec).SetServerDetails(c.serverDetails).
+		SetDryRun(options.DryRun).SetQuiet(true).SetDetailedSummary(true).SetRetries(retriesOrDefault(options.Retries)).SetContext(ctx)
+	err := uploadCmd.Run()
+	return readTransferResult(uploadCmd.Result(), err)
+}
+
+// Download downloads artifacts from Artifactory. The result lists the downloaded artifacts, including when some of them failed.
+func (c *Client) Download(ctx context.Context, options DownloadOptions) (*TransferResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	downloadSpec := spec.NewBuilder().
+		Pattern(options.Pattern).
+		Target(options.Target).
+		Props(options.Props).
+		Exclusions(options.Exclusions).
+		Recursive(options.Recursive).
+		Flat(options.Flat).
+		Build(options.FromBuild).
+		Explode(strconv.FormatBool(options.Explode)).
+		BuildSpec()
+	if err := spec.ValidateSpec(downloadSpec.Files, false, true); err != nil {
+		return nil, err
+	}
+	downloadCmd := generic.NewDownloadCommand()
+	downloadCmd.SetConfiguration(&utils.DownloadConfiguration{
+		Threads:      threadsOrDefault(options.Threads),
+		SplitCount:   flagkit.DownloadSplitCount,
+		MinSplitSize: flagkit.DownloadMinSplitKb,
+		Symlink:      true,
+	}).SetBuildConfiguration(buildConfiguration(options.Build)).SetSpec(downloadSpec).SetServerDetails(c.serverDetails).
+		SetDryRun(options.DryRun).SetQuiet(true).SetDetailedSummary(true).SetRetries(retriesOrDefault(options.Retries)).SetContext(ctx)
+	err := downloadCmd.Run()
+	return readTransferResult(downloadCmd.Result(), err)
+}
+
+// readTransferResult reads the transferred files of the result of a command, and closes its reader.
+func readTransferResult(result *commandsutils.Result, runErr error) (*TransferResult, error) {
+	transferResult := &TransferResult{Succeeded: result.SuccessCount(), Failed: result.FailCount(), Files: []TransferredFile{}}
+	reader := result.Reader()
+	if reader == nil {
+		return transferResult, runErr
+	}
+	for file := new(clientutils.FileTransferDetails); reader.NextRecord(file) == nil; file = new(clientutils.FileTransferDetails) {
+		transferResult.Files = append(transferResult.Files, TransferredFile{Source: file.SourcePath, Target: file.TargetPath, Sha256: file.Sha256})
+	}
+	return transferResult, errors.Join(runErr, reader.GetError(), errorutils.CheckError(reader.Close()))
+}
+
+func buildConfiguration(options BuildOptions) *build.BuildConfiguration {
+	if !options.isSet() {
+		// The build-info isn't collected, including by the build name and number of the environment.
+		return nil
+	}
+	return build.NewBuildConfiguration(options.BuildName, options.BuildNumber, options.Module, options.Project)
+}
+
+func threadsOrDefault(threads int) int {
+	if threads > 0 {
+		return threads
+	}
+	return cliutils.Threads
+}
+
+func retriesOrDefault(retries int) int {
+	switch {
+	case retries == 0:
+		return flagkit.Retries
+	case retries < 0:
+		return 0
+	}
+	return retries
+}
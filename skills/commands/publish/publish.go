@@ -565,7 +565,7 @@ func (pc *PublishCommand) attachEvidence(slug, version, sha256Hex string) {
 	}
 
 	if keyPath == "" {
-		log.Info("No signing key configured. Provide --signing-key flag or set EVD_SIGNING_KEY_PATH env var. Skipping evidence creation.")
+		log.Info("No signing key configured. Provide --signing-key flag (a key file path or ssh-agent) or set EVD_SIGNING_KEY_PATH env var. Skipping evidence creation.")
 		return
 	}
 
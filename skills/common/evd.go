@@ -25,8 +25,12 @@ type VerifyEvidenceOpts struct {
 }
 
 // CreateEvidence attaches a signed publish-attestation to an artifact using jfrog-cli-evidence programmatically.
+// If the key path refers to the ssh-agent, the evidence is signed by the agent instead of a key file.
 func CreateEvidence(serverDetails *config.ServerDetails, opts CreateEvidenceOpts) error {
 	ensureServiceUrls(serverDetails)
+	if IsSSHAgentKey(opts.KeyPath) {
+		return createEvidenceWithSSHAgent(serverDetails, opts)
+	}
 	cmd := create.NewCreateEvidenceCustom(
 		serverDetails,
 		opts.PredicatePath,
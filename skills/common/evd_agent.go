@@ -0,0 +1,201 @@
+package common
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/create"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/intoto"
+	"github.com/jfrog/jfrog-cli-evidence/evidence/sign"
+	evidenceService "github.com/jfrog/jfrog-client-go/evidence/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	// SSHAgentKeyPrefix is the signing key value which selects a key held by the ssh-agent instead of a key file.
+	// The key may be selected using "ssh-agent:<SHA256 fingerprint or key comment>".
+	SSHAgentKeyPrefix = "ssh-agent"
+	envSSHAuthSock    = "SSH_AUTH_SOCK"
+)
+
+// IsSSHAgentKey returns true if the signing key refers to a key held by the ssh-agent.
+func IsSSHAgentKey(key string) bool {
+	return key == SSHAgentKeyPrefix || strings.HasPrefix(key, SSHAgentKeyPrefix+":")
+}
+
+// agentSigner signs DSSE envelopes using a key held by an ssh-agent, so that the private key never exists as a file.
+// Hardware tokens such as a YubiKey are supported through agents that expose their PIV keys, like yubikey-agent.
+type agentSigner struct {
+	agent  agent.ExtendedAgent
+	key    *agent.Key
+	keyId  string
+	format string
+}
+
+func (s *agentSigner) Sign(data []byte) ([]byte, error) {
+	signature, err := s.agent.SignWithFlags(s.key, data, 0)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the ssh-agent failed to sign the evidence: %s", err.Error())
+	}
+	return toEvidenceSignature(s.format, signature)
+}
+
+func (s *agentSigner) KeyID() (string, error) {
+	return s.keyId, nil
+}
+
+// newSSHAgentSigner connects to the ssh-agent at SSH_AUTH_SOCK and selects the signing key.
+// The returned close function must be called once signing is done.
+func newSSHAgentSigner(signingKey, keyAlias string) (*agentSigner, func(), error) {
+	socket := os.Getenv(envSSHAuthSock)
+	if socket == "" {
+		return nil, nil, errorutils.CheckErrorf("%s is not set. Make sure an ssh-agent is running and holds the signing key", envSSHAuthSock)
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to connect to the ssh-agent: %s", err.Error())
+	}
+	closeConn := func() {
+		if e := conn.Close(); e != nil {
+			log.Debug("Failed to close the ssh-agent connection:", e.Error())
+		}
+	}
+	signer, err := selectAgentSigner(agent.NewClient(conn), signingKey, keyAlias)
+	if err != nil {
+		closeConn()
+		return nil, nil, err
+	}
+	return signer, closeConn, nil
+}
+
+// selectAgentSigner picks the agent key matching the selector of the signing key.
+// Without a selector, the agent must hold exactly one key which is suitable for signing evidence.
+func selectAgentSigner(sshAgent agent.ExtendedAgent, signingKey, keyAlias string) (*agentSigner, error) {
+	keys, err := sshAgent.List()
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to list the ssh-agent keys: %s", err.Error())
+	}
+	selector := strings.TrimPrefix(strings.TrimPrefix(signingKey, SSHAgentKeyPrefix), ":")
+	var candidates []*agent.Key
+	for _, key := range keys {
+		if selector != "" && selector != ssh.FingerprintSHA256(key) && selector != key.Comment {
+			continue
+		}
+		if !isSupportedAgentKeyFormat(key.Format) {
+			if selector != "" {
+				return nil, errorutils.CheckErrorf("the ssh-agent key '%s' is of type %s, which cannot be used for signing evidence. Supported types are ed25519 and ecdsa", selector, key.Format)
+			}
+			log.Debug("Skipping ssh-agent key of unsupported type", key.Format+":", key.Comment)
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+	switch {
+	case len(candidates) == 1:
+		log.Debug("Signing evidence using ssh-agent key", ssh.FingerprintSHA256(candidates[0]), candidates[0].Comment)
+		return &agentSigner{agent: sshAgent, key: candidates[0], keyId: keyAlias, format: candidates[0].Format}, nil
+	case len(candidates) == 0 && selector != "":
+		return nil, errorutils.CheckErrorf("no key matching '%s' was found in the ssh-agent", selector)
+	case len(candidates) == 0:
+		return nil, errorutils.CheckErrorf("the ssh-agent holds no ed25519 or ecdsa key which can be used for signing evidence")
+	default:
+		return nil, errorutils.CheckErrorf("the ssh-agent holds %d suitable keys. Select one using '%s:<SHA256 fingerprint or key comment>'", len(candidates), SSHAgentKeyPrefix)
+	}
+}
+
+// isSupportedAgentKeyFormat returns true for key types whose agent signatures can be converted to evidence signatures.
+// RSA keys are not supported, since agents sign with PKCS#1 v1.5 while evidence uses RSA-PSS, and security keys (sk-*)
+// sign additional authenticator data.
+func isSupportedAgentKeyFormat(format string) bool {
+	switch format {
+	case ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		return true
+	}
+	return false
+}
+
+// toEvidenceSignature converts an ssh signature to the format verified by the evidence service.
+// Ed25519 signatures are identical, while ECDSA signatures are converted from the ssh (r, s) encoding to ASN.1 DER.
+// The agent hashes ECDSA payloads according to the curve size, which is also the hash used by the evidence verifier.
+func toEvidenceSignature(format string, signature *ssh.Signature) ([]byte, error) {
+	if format == ssh.KeyAlgoED25519 {
+		return signature.Blob, nil
+	}
+	var ecdsaSignature struct {
+		R *big.Int
+		S *big.Int
+	}
+	if err := ssh.Unmarshal(signature.Blob, &ecdsaSignature); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the ssh-agent ECDSA signature: %s", err.Error())
+	}
+	der, err := asn1.Marshal(ecdsaSignature)
+	return der, errorutils.CheckError(err)
+}
+
+// createEvidenceWithSSHAgent creates and signs the evidence DSSE envelope using an ssh-agent key, and uploads it.
+func createEvidenceWithSSHAgent(serverDetails *config.ServerDetails, opts CreateEvidenceOpts) error {
+	signer, closeAgent, err := newSSHAgentSigner(opts.KeyPath, opts.KeyAlias)
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
+	envelope, err := createAgentSignedEnvelope(serverDetails, opts, signer)
+	if err != nil {
+		return err
+	}
+	manager, err := utils.CreateEvidenceServiceManager(serverDetails, false)
+	if err != nil {
+		return err
+	}
+	log.Debug("Uploading evidence for subject:", opts.SubjectRepoPath)
+	_, err = manager.UploadEvidence(evidenceService.EvidenceDetails{
+		SubjectUri:  opts.SubjectRepoPath,
+		DSSEFileRaw: envelope,
+	})
+	return err
+}
+
+func createAgentSignedEnvelope(serverDetails *config.ServerDetails, opts CreateEvidenceOpts, signer *agentSigner) ([]byte, error) {
+	predicate, err := os.ReadFile(opts.PredicatePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read predicate file '%s': %s", opts.PredicatePath, err.Error())
+	}
+	user := serverDetails.User
+	if user == "" {
+		user = create.EvdDefaultUser
+	}
+	statement := intoto.NewStatement(predicate, opts.PredicateType, user)
+	if opts.MarkdownPath != "" {
+		markdown, err := os.ReadFile(opts.MarkdownPath)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to read markdown file '%s': %s", opts.MarkdownPath, err.Error())
+		}
+		statement.SetMarkdown(markdown)
+	}
+	if err = statement.SetSubject(opts.SubjectSHA256); err != nil {
+		return nil, err
+	}
+	statementJson, err := statement.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	envelopeSigner, err := sign.NewEnvelopeSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+	signedEnvelope, err := envelopeSigner.SignPayload(intoto.PayloadType, statementJson)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := json.Marshal(signedEnvelope)
+	return envelope, errorutils.CheckError(err)
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func newTestKeyring(t *testing.T, keys map[string]interface{}) agent.ExtendedAgent {
+	keyring, ok := agent.NewKeyring().(agent.ExtendedAgent)
+	require.True(t, ok)
+	for comment, key := range keys {
+		require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key, Comment: comment}))
+	}
+	return keyring
+}
+
+func TestIsSSHAgentKey(t *testing.T) {
+	assert.True(t, IsSSHAgentKey("ssh-agent"))
+	assert.True(t, IsSSHAgentKey("ssh-agent:ci-key"))
+	assert.False(t, IsSSHAgentKey("ssh-agent-key.pem"))
+	assert.False(t, IsSSHAgentKey("/path/to/key.pem"))
+	assert.False(t, IsSSHAgentKey(""))
+}
+
+func TestSelectAgentSignerEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyring := newTestKeyring(t, map[string]interface{}{"ed-key": private, "rsa-key": rsaKey})
+
+	// The RSA key is skipped, leaving a single suitable key.
+	signer, err := selectAgentSigner(keyring, "ssh-agent", "my-alias")
+	require.NoError(t, err)
+	keyId, err := signer.KeyID()
+	require.NoError(t, err)
+	assert.Equal(t, "my-alias", keyId)
+
+	data := []byte("payload")
+	signature, err := signer.Sign(data)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public, data, signature))
+}
+
+func TestSelectAgentSignerEcdsa(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyring := newTestKeyring(t, map[string]interface{}{"ec-key": private})
+
+	sshPublic, err := ssh.NewPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	signer, err := selectAgentSigner(keyring, "ssh-agent:"+ssh.FingerprintSHA256(sshPublic), "")
+	require.NoError(t, err)
+
+	data := []byte("payload")
+	signature, err := signer.Sign(data)
+	require.NoError(t, err)
+	digest := sha256.Sum256(data)
+	assert.True(t, ecdsa.VerifyASN1(&private.PublicKey, digest[:], signature))
+}
+
+func TestSelectAgentSignerErrors(t *testing.T) {
+	_, first, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, second, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyring := newTestKeyring(t, map[string]interface{}{"first": first, "second": second, "rsa-key": rsaKey})
+
+	_, err = selectAgentSigner(keyring, "ssh-agent", "")
+	assert.ErrorContains(t, err, "holds 2 suitable keys")
+
+	_, err = selectAgentSigner(keyring, "ssh-agent:missing", "")
+	assert.ErrorContains(t, err, "no key matching 'missing'")
+
+	_, err = selectAgentSigner(keyring, "ssh-agent:rsa-key", "")
+	assert.ErrorContains(t, err, "cannot be used for signing evidence")
+
+	signer, err := selectAgentSigner(keyring, "ssh-agent:second", "")
+	require.NoError(t, err)
+	assert.Equal(t, "second", signer.key.Comment)
+
+	_, err = selectAgentSigner(newTestKeyring(t, nil), "ssh-agent", "")
+	assert.ErrorContains(t, err, "holds no ed25519 or ecdsa key")
+}
+
+func TestNewSSHAgentSignerWithoutAgent(t *testing.T) {
+	t.Setenv(envSSHAuthSock, "")
+	_, _, err := newSSHAgentSigner("ssh-agent", "")
+	assert.ErrorContains(t, err, envSSHAuthSock)
+}
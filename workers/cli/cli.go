@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	"github.com/jfrog/jfrog-cli-artifactory/workers/commands"
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreCommands "github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	pluginsCommon "github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+func GetCommands() []components.Command {
+	return []components.Command{
+		{
+			Name:        "deploy",
+			Flags:       flagkit.GetCommandFlags(flagkit.WorkerDeploy),
+			Description: "Create or update a worker from its local source files. The worker is described by the " + commands.ManifestFileName + " file of the directory. The values of the secrets the manifest lists are read from the environment variables of the same names.",
+			Arguments:   getDirArguments(),
+			Action:      deployCmd,
+		},
+		{
+			Name:        "list",
+			Aliases:     []string{"ls"},
+			Flags:       flagkit.GetCommandFlags(flagkit.WorkerList),
+			Description: "List the workers.",
+			Action:      listCmd,
+		},
+		{
+			Name:        "test-run",
+			Flags:       flagkit.GetCommandFlags(flagkit.WorkerTestRun),
+			Description: "Run the local source code of a worker with an event payload, without deploying it, and print the response.",
+			Arguments:   getDirArguments(),
+			Action:      testRunCmd,
+		},
+		{
+			Name:        "remove",
+			Aliases:     []string{"rm"},
+			Flags:       flagkit.GetCommandFlags(flagkit.WorkerRemove),
+			Description: "Remove a worker.",
+			Arguments: []components.Argument{
+				{Name: "worker key", Description: "The key of the worker to remove."},
+			},
+			Action: removeCmd,
+		},
+	}
+}
+
+func getDirArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "worker directory", Description: "The directory of the " + commands.ManifestFileName + " file of the worker. Default: the current directory.", Optional: true},
+	}
+}
+
+func deployCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := createWorkersDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	deployCommand := commands.NewDeployCommand().SetServerDetails(serverDetails)
+	if c.GetNumberOfArgs() == 1 {
+		deployCommand.SetDir(c.GetArgumentAt(0))
+	}
+	return coreCommands.Exec(deployCommand)
+}
+
+func listCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 0 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	outputFormat, err := c.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+	if outputFormat == format.None {
+		outputFormat = format.Table
+	}
+	serverDetails, err := createWorkersDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	return coreCommands.Exec(commands.NewListCommand().SetServerDetails(serverDetails).SetOutputFormat(outputFormat))
+}
+
+func testRunCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := createWorkersDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	testRunCommand := commands.NewTestRunCommand().SetServerDetails(serverDetails).SetPayloadPath(c.GetStringFlagValue(flagkit.WorkerPayload))
+	if c.GetNumberOfArgs() == 1 {
+		testRunCommand.SetDir(c.GetArgumentAt(0))
+	}
+	return coreCommands.Exec(testRunCommand)
+}
+
+func removeCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := createWorkersDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	return coreCommands.Exec(commands.NewRemoveCommand().SetServerDetails(serverDetails).SetKey(c.GetArgumentAt(0)).SetQuiet(pluginsCommon.GetQuietValue(c)))
+}
+
+// createWorkersDetailsByFlags returns the details of the JFrog Platform. The requests are sent by the Artifactory services manager,
+// so the Artifactory URL is set as well.
+func createWorkersDetailsByFlags(c *components.Context) (*config.ServerDetails, error) {
+	serverDetails, err := pluginsCommon.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if serverDetails.Url == "" {
+		return nil, errorutils.CheckErrorf("platform URL is mandatory for the workers commands")
+	}
+	if serverDetails.ArtifactoryUrl == "" {
+		serverDetails.ArtifactoryUrl = clientutils.AddTrailingSlashIfNeeded(serverDetails.Url) + "artifactory/"
+	}
+	return serverDetails, nil
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+)
+
+const workersApi = "worker/api/v1/"
+
+// Worker is a worker, as managed by the Workers REST API.
+type Worker struct {
+	Key         string `json:"key"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Debug       bool   `json:"debug"`
+	SourceCode  string `json:"sourceCode,omitempty"`
+	// The event which triggers the worker, such as BEFORE_DOWNLOAD, AFTER_CREATE or GENERIC_EVENT.
+	Action string `json:"action"`
+	// The criteria of the event, such as the repositories of a BEFORE_DOWNLOAD worker. Passed to the API as is.
+	FilterCriteria json.RawMessage `json:"filterCriteria,omitempty"`
+	Secrets        []WorkerSecret  `json:"secrets,omitempty"`
+	ProjectKey     string          `json:"projectKey,omitempty"`
+}
+
+type WorkerSecret struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type workersListResponse struct {
+	Workers []Worker `json:"workers"`
+}
+
+type workerTestRequest struct {
+	Code   string          `json:"code"`
+	Action string          `json:"action"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// workersClient sends the Workers REST API requests, using the HTTP client and the credentials of the Artifactory services manager.
+type workersClient struct {
+	client      *jfroghttpclient.JfrogHttpClient
+	httpDetails httputils.HttpClientDetails
+	apiUrl      string
+}
+
+func newWorkersClient(serverDetails *config.ServerDetails) (*workersClient, error) {
+	platformUrl := serverDetails.GetUrl()
+	if platformUrl == "" {
+		platformUrl = strings.TrimSuffix(clientutils.AddTrailingSlashIfNeeded(serverDetails.GetArtifactoryUrl()), "artifactory/")
+	}
+	if platformUrl == "" {
+		return nil, errorutils.CheckErrorf("the JFrog Platform URL is mandatory for the workers commands")
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.SetContentTypeApplicationJson()
+	return &workersClient{
+		client:      servicesManager.Client(),
+		httpDetails: httpDetails,
+		apiUrl:      clientutils.AddTrailingSlashIfNeeded(platformUrl) + workersApi,
+	}, nil
+}
+
+// getWorker returns the worker, or nil if it doesn't exist.
+func (wc *workersClient) getWorker(key string) (*Worker, error) {
+	resp, body, _, err := wc.client.SendGet(wc.apiUrl+"workers/"+url.PathEscape(key), true, &wc.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	worker := new(Worker)
+	return worker, errorutils.CheckError(json.Unmarshal(body, worker))
+}
+
+func (wc *workersClient) listWorkers() ([]Worker, error) {
+	resp, body, _, err := wc.client.SendGet(wc.apiUrl+"workers", true, &wc.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	response := new(workersListResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return response.Workers, nil
+}
+
+func (wc *workersClient) createWorker(worker *Worker) error {
+	content, err := json.Marshal(worker)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	resp, body, err := wc.client.SendPost(wc.apiUrl+"workers", content, &wc.httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func (wc *workersClient) updateWorker(worker *Worker) error {
+	content, err := json.Marshal(worker)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	resp, body, err := wc.client.SendPut(wc.apiUrl+"workers", content, &wc.httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+func (wc *workersClient) deleteWorker(key string) error {
+	resp, body, err := wc.client.SendDelete(wc.apiUrl+"workers/"+url.PathEscape(key), nil, &wc.httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+// testWorker runs the source code of the worker with the event data, without deploying it, and returns the response of the execution.
+func (wc *workersClient) testWorker(key string, request *workerTestRequest) (json.RawMessage, error) {
+	content, err := json.Marshal(request)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	resp, body, err := wc.client.SendPost(wc.apiUrl+"test/"+url.PathEscape(key), content, &wc.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createWorkersTestServer serves the Workers REST API, which stores the workers in the map.
+func createWorkersTestServer(t *testing.T, workers map[string]Worker) (*httptest.Server, *config.ServerDetails) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		content, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/worker/api/v1/workers":
+			list := workersListResponse{}
+			for _, worker := range workers {
+				list.Workers = append(list.Workers, worker)
+			}
+			writeJson(t, w, list)
+		case r.Method == http.MethodGet:
+			worker, ok := workers[filepath.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJson(t, w, worker)
+		case r.Method == http.MethodPost && r.URL.Path == "/worker/api/v1/workers", r.Method == http.MethodPut:
+			worker := Worker{}
+			require.NoError(t, json.Unmarshal(content, &worker))
+			_, exists := workers[worker.Key]
+			assert.Equal(t, r.Method == http.MethodPut, exists)
+			workers[worker.Key] = worker
+			if !exists {
+				w.WriteHeader(http.StatusCreated)
+			}
+		case r.Method == http.MethodPost:
+			request := workerTestRequest{}
+			require.NoError(t, json.Unmarshal(content, &request))
+			assert.Equal(t, "/worker/api/v1/test/block-unsigned", r.URL.Path)
+			assert.Equal(t, "BEFORE_DOWNLOAD", request.Action)
+			assert.Contains(t, request.Code, "DOWNLOAD_PROCEED")
+			writeJson(t, w, map[string]any{"result": map[string]string{"status": "DOWNLOAD_PROCEED"}, "data": request.Data})
+		case r.Method == http.MethodDelete:
+			delete(workers, filepath.Base(r.URL.Path))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+	return testServer, &config.ServerDetails{Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/artifactory/", AccessToken: "token"}
+}
+
+func writeJson(t *testing.T, w http.ResponseWriter, value any) {
+	content, err := json.Marshal(value)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	assert.NoError(t, err)
+}
+
+func TestDeployCommand(t *testing.T) {
+	t.Setenv("WORKER_TEST_SLACK_TOKEN", "secret-value")
+	workers := map[string]Worker{}
+	_, serverDetails := createWorkersTestServer(t, workers)
+
+	// The worker is created, and then updated.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, NewDeployCommand().SetServerDetails(serverDetails).SetDir(testWorkerDir).Run())
+		require.Contains(t, workers, "block-unsigned")
+		assert.Equal(t, []WorkerSecret{{Key: "WORKER_TEST_SLACK_TOKEN", Value: "secret-value"}}, workers["block-unsigned"].Secrets)
+	}
+}
+
+func TestListAndRemoveCommands(t *testing.T) {
+	workers := map[string]Worker{"audit": {Key: "audit", Action: "AFTER_CREATE", Enabled: true, SourceCode: "code", Secrets: []WorkerSecret{{Key: "K", Value: "V"}}}}
+	_, serverDetails := createWorkersTestServer(t, workers)
+
+	listCommand := NewListCommand().SetServerDetails(serverDetails).SetOutputFormat(format.Json)
+	require.NoError(t, listCommand.Run())
+	// The source code and the secrets aren't listed.
+	assert.Equal(t, []Worker{{Key: "audit", Action: "AFTER_CREATE", Enabled: true}}, listCommand.Workers())
+	assert.NoError(t, NewListCommand().SetServerDetails(serverDetails).Run())
+	assert.Error(t, NewListCommand().SetServerDetails(serverDetails).SetOutputFormat(format.Sarif).Run())
+
+	require.NoError(t, NewRemoveCommand().SetServerDetails(serverDetails).SetKey("audit").SetQuiet(true).Run())
+	assert.Empty(t, workers)
+	assert.ErrorContains(t, NewRemoveCommand().SetServerDetails(serverDetails).SetKey("audit").SetQuiet(true).Run(), "doesn't exist")
+}
+
+func TestTestRunCommand(t *testing.T) {
+	_, serverDetails := createWorkersTestServer(t, map[string]Worker{})
+	payloadPath := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(payloadPath, []byte(`{"metadata":{"repoPath":{"key":"libs-release-local","path":"app.jar"}}}`), 0644))
+
+	testRunCommand := NewTestRunCommand().SetServerDetails(serverDetails).SetDir(testWorkerDir).SetPayloadPath(payloadPath)
+	require.NoError(t, testRunCommand.Run())
+	assert.JSONEq(t, `{"result":{"status":"DOWNLOAD_PROCEED"},"data":{"metadata":{"repoPath":{"key":"libs-release-local","path":"app.jar"}}}}`, string(testRunCommand.Response()))
+
+	require.NoError(t, os.WriteFile(payloadPath, []byte(`{`), 0644))
+	assert.ErrorContains(t, NewTestRunCommand().SetServerDetails(serverDetails).SetDir(testWorkerDir).SetPayloadPath(payloadPath).Run(), "isn't a valid JSON")
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DeployCommand creates the worker described by the manifest in the directory, or updates it if it already exists.
+type DeployCommand struct {
+	serverDetails *config.ServerDetails
+	dir           string
+}
+
+func NewDeployCommand() *DeployCommand {
+	return &DeployCommand{dir: "."}
+}
+
+func (dc *DeployCommand) SetServerDetails(serverDetails *config.ServerDetails) *DeployCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+// SetDir sets the directory of the manifest of the worker.
+func (dc *DeployCommand) SetDir(dir string) *DeployCommand {
+	dc.dir = dir
+	return dc
+}
+
+func (dc *DeployCommand) CommandName() string {
+	return "worker_deploy"
+}
+
+func (dc *DeployCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DeployCommand) Run() error {
+	manifest, err := ReadManifest(dc.dir)
+	if err != nil {
+		return err
+	}
+	worker, err := manifest.toWorker()
+	if err != nil {
+		return err
+	}
+	client, err := newWorkersClient(dc.serverDetails)
+	if err != nil {
+		return err
+	}
+	existing, err := client.getWorker(worker.Key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		log.Info("Creating worker", worker.Key+"...")
+		err = client.createWorker(worker)
+	} else {
+		log.Info("Updating worker", worker.Key+"...")
+		err = client.updateWorker(worker)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Worker", worker.Key, "was deployed.")
+	return nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+	workers       []Worker
+}
+
+func NewListCommand() *ListCommand {
+	return &ListCommand{outputFormat: format.Table}
+}
+
+func (lc *ListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ListCommand {
+	lc.serverDetails = serverDetails
+	return lc
+}
+
+func (lc *ListCommand) SetOutputFormat(outputFormat format.OutputFormat) *ListCommand {
+	lc.outputFormat = outputFormat
+	return lc
+}
+
+func (lc *ListCommand) Workers() []Worker {
+	return lc.workers
+}
+
+func (lc *ListCommand) CommandName() string {
+	return "worker_list"
+}
+
+func (lc *ListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lc.serverDetails, nil
+}
+
+func (lc *ListCommand) Run() (err error) {
+	client, err := newWorkersClient(lc.serverDetails)
+	if err != nil {
+		return err
+	}
+	if lc.workers, err = client.listWorkers(); err != nil {
+		return err
+	}
+	// The source code and the secrets aren't listed.
+	for i := range lc.workers {
+		lc.workers[i].SourceCode = ""
+		lc.workers[i].Secrets = nil
+	}
+	switch lc.outputFormat {
+	case format.Json:
+		data, err := json.Marshal(lc.workers)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(data))
+		return nil
+	case format.Table:
+		return printWorkersTable(lc.workers)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for worker list. Acceptable values are: json, table", lc.outputFormat)
+	}
+}
+
+type workerRow struct {
+	Key         string `col-name:"Key"`
+	Action      string `col-name:"Action"`
+	Enabled     string `col-name:"Enabled"`
+	ProjectKey  string `col-name:"Project"`
+	Description string `col-name:"Description"`
+}
+
+func printWorkersTable(workers []Worker) error {
+	rows := make([]workerRow, 0, len(workers))
+	for _, worker := range workers {
+		enabled := "false"
+		if worker.Enabled {
+			enabled = "true"
+		}
+		rows = append(rows, workerRow{Key: worker.Key, Action: worker.Action, Enabled: enabled, ProjectKey: worker.ProjectKey, Description: strings.TrimSpace(worker.Description)})
+	}
+	return coreutils.PrintTable(rows, "Workers", "No workers were found", false)
+}
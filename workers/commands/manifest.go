@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ManifestFileName is the name of the file which describes the worker, in the directory of its source files.
+const ManifestFileName = "manifest.json"
+
+// The names of the secrets are also the names of the environment variables their values are read from.
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Manifest describes a worker whose source code is in a local directory.
+type Manifest struct {
+	// The key of the worker.
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Action      string `json:"action"`
+	// The path of the source code, relative to the manifest.
+	SourceCodePath string          `json:"sourceCodePath"`
+	Enabled        bool            `json:"enabled"`
+	Debug          bool            `json:"debug,omitempty"`
+	ProjectKey     string          `json:"projectKey,omitempty"`
+	FilterCriteria json.RawMessage `json:"filterCriteria,omitempty"`
+	// The names of the secrets of the worker. The value of each secret is read from the environment variable of the same name,
+	// so that the secrets of the pipeline can be passed to the worker without being written to files.
+	Secrets []string `json:"secrets,omitempty"`
+	// The directory of the manifest.
+	dir string
+}
+
+// ReadManifest reads the manifest of the worker in the directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestPath := filepath.Join(dir, ManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the worker manifest: %s", err.Error())
+	}
+	manifest := &Manifest{dir: dir}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the worker manifest %s: %s", manifestPath, err.Error())
+	}
+	return manifest, manifest.validate()
+}
+
+func (manifest *Manifest) validate() error {
+	switch {
+	case manifest.Name == "":
+		return errorutils.CheckErrorf("the worker manifest has no name")
+	case manifest.Action == "":
+		return errorutils.CheckErrorf("the worker manifest has no action")
+	case manifest.SourceCodePath == "":
+		return errorutils.CheckErrorf("the worker manifest has no sourceCodePath")
+	}
+	for _, secret := range manifest.Secrets {
+		if !secretNamePattern.MatchString(secret) {
+			return errorutils.CheckErrorf("the worker secret name '%s' isn't a valid environment variable name", secret)
+		}
+	}
+	return nil
+}
+
+func (manifest *Manifest) readSourceCode() (string, error) {
+	sourceCodePath := manifest.SourceCodePath
+	if !filepath.IsAbs(sourceCodePath) {
+		sourceCodePath = filepath.Join(manifest.dir, sourceCodePath)
+	}
+	sourceCode, err := os.ReadFile(sourceCodePath)
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to read the worker source code: %s", err.Error())
+	}
+	return string(sourceCode), nil
+}
+
+// readSecrets reads the values of the secrets from the environment. All the secrets must be set.
+func (manifest *Manifest) readSecrets() ([]WorkerSecret, error) {
+	var secrets []WorkerSecret
+	for _, name := range manifest.Secrets {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, errorutils.CheckErrorf("the value of the worker secret '%s' must be set by the %s environment variable", name, name)
+		}
+		secrets = append(secrets, WorkerSecret{Key: name, Value: value})
+	}
+	return secrets, nil
+}
+
+// toWorker creates the worker of the manifest, including its source code and the values of its secrets.
+func (manifest *Manifest) toWorker() (*Worker, error) {
+	sourceCode, err := manifest.readSourceCode()
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := manifest.readSecrets()
+	if err != nil {
+		return nil, err
+	}
+	return &Worker{
+		Key:            manifest.Name,
+		Description:    manifest.Description,
+		Enabled:        manifest.Enabled,
+		Debug:          manifest.Debug,
+		SourceCode:     sourceCode,
+		Action:         manifest.Action,
+		FilterCriteria: manifest.FilterCriteria,
+		Secrets:        secrets,
+		ProjectKey:     manifest.ProjectKey,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWorkerDir = "testdata/worker"
+
+func TestManifestToWorker(t *testing.T) {
+	t.Setenv("WORKER_TEST_SLACK_TOKEN", "secret-value")
+	manifest, err := ReadManifest(testWorkerDir)
+	require.NoError(t, err)
+	worker, err := manifest.toWorker()
+	require.NoError(t, err)
+	assert.Equal(t, "block-unsigned", worker.Key)
+	assert.Equal(t, "BEFORE_DOWNLOAD", worker.Action)
+	assert.True(t, worker.Enabled)
+	assert.Contains(t, worker.SourceCode, "DOWNLOAD_PROCEED")
+	assert.JSONEq(t, `{"artifactFilterCriteria":{"repoKeys":["libs-release-local"]}}`, string(worker.FilterCriteria))
+	assert.Equal(t, []WorkerSecret{{Key: "WORKER_TEST_SLACK_TOKEN", Value: "secret-value"}}, worker.Secrets)
+}
+
+func TestManifestToWorkerMissingSecret(t *testing.T) {
+	manifest, err := ReadManifest(testWorkerDir)
+	require.NoError(t, err)
+	_, err = manifest.toWorker()
+	assert.ErrorContains(t, err, "must be set by the WORKER_TEST_SLACK_TOKEN environment variable")
+}
+
+func TestReadManifestErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"no name":        `{"action":"GENERIC_EVENT","sourceCodePath":"worker.ts"}`,
+		"no action":      `{"name":"w","sourceCodePath":"worker.ts"}`,
+		"no source code": `{"name":"w","action":"GENERIC_EVENT"}`,
+		"invalid secret": `{"name":"w","action":"GENERIC_EVENT","sourceCodePath":"worker.ts","secrets":["SLACK-TOKEN"]}`,
+		"invalid json":   `{"name":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(content), 0644))
+			_, err := ReadManifest(dir)
+			assert.Error(t, err)
+		})
+	}
+	_, err := ReadManifest(t.TempDir())
+	assert.Error(t, err)
+}
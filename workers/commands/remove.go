@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type RemoveCommand struct {
+	serverDetails *config.ServerDetails
+	key           string
+	quiet         bool
+}
+
+func NewRemoveCommand() *RemoveCommand {
+	return &RemoveCommand{}
+}
+
+func (rc *RemoveCommand) SetServerDetails(serverDetails *config.ServerDetails) *RemoveCommand {
+	rc.serverDetails = serverDetails
+	return rc
+}
+
+func (rc *RemoveCommand) SetKey(key string) *RemoveCommand {
+	rc.key = key
+	return rc
+}
+
+func (rc *RemoveCommand) SetQuiet(quiet bool) *RemoveCommand {
+	rc.quiet = quiet
+	return rc
+}
+
+func (rc *RemoveCommand) CommandName() string {
+	return "worker_remove"
+}
+
+func (rc *RemoveCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rc.serverDetails, nil
+}
+
+func (rc *RemoveCommand) Run() error {
+	if !rc.quiet && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to remove worker '%s'?", rc.key), false) {
+		return nil
+	}
+	client, err := newWorkersClient(rc.serverDetails)
+	if err != nil {
+		return err
+	}
+	existing, err := client.getWorker(rc.key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errorutils.CheckErrorf("worker '%s' doesn't exist", rc.key)
+	}
+	if err = client.deleteWorker(rc.key); err != nil {
+		return err
+	}
+	log.Info("Worker", rc.key, "was removed.")
+	return nil
+}
@@ -0,0 +1,3 @@
+export default async (context: PlatformContext, data: BeforeDownloadRequest): Promise<BeforeDownloadResponse> => {
+    return { status: 'DOWNLOAD_PROCEED', message: 'proceed' };
+};
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// TestRunCommand runs the local source code of the worker described by the manifest in the directory, with an event payload.
+// The worker isn't deployed, so changes can be tried before they're deployed.
+type TestRunCommand struct {
+	serverDetails *config.ServerDetails
+	dir           string
+	payloadPath   string
+	response      json.RawMessage
+}
+
+func NewTestRunCommand() *TestRunCommand {
+	return &TestRunCommand{dir: "."}
+}
+
+func (trc *TestRunCommand) SetServerDetails(serverDetails *config.ServerDetails) *TestRunCommand {
+	trc.serverDetails = serverDetails
+	return trc
+}
+
+// SetDir sets the directory of the manifest of the worker.
+func (trc *TestRunCommand) SetDir(dir string) *TestRunCommand {
+	trc.dir = dir
+	return trc
+}
+
+// SetPayloadPath sets the path of a JSON file, which contains the data of the event the worker is run with. If empty, the data is empty.
+func (trc *TestRunCommand) SetPayloadPath(payloadPath string) *TestRunCommand {
+	trc.payloadPath = payloadPath
+	return trc
+}
+
+// Response returns the response of the execution of the worker.
+func (trc *TestRunCommand) Response() json.RawMessage {
+	return trc.response
+}
+
+func (trc *TestRunCommand) CommandName() string {
+	return "worker_test_run"
+}
+
+func (trc *TestRunCommand) ServerDetails() (*config.ServerDetails, error) {
+	return trc.serverDetails, nil
+}
+
+func (trc *TestRunCommand) Run() error {
+	manifest, err := ReadManifest(trc.dir)
+	if err != nil {
+		return err
+	}
+	sourceCode, err := manifest.readSourceCode()
+	if err != nil {
+		return err
+	}
+	payload, err := readPayload(trc.payloadPath)
+	if err != nil {
+		return err
+	}
+	client, err := newWorkersClient(trc.serverDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Running worker", manifest.Name+"...")
+	if trc.response, err = client.testWorker(manifest.Name, &workerTestRequest{Code: sourceCode, Action: manifest.Action, Data: payload}); err != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(trc.response))
+	return nil
+}
+
+func readPayload(payloadPath string) (json.RawMessage, error) {
+	if payloadPath == "" {
+		return json.RawMessage("{}"), nil
+	}
+	content, err := os.ReadFile(payloadPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the worker payload: %s", err.Error())
+	}
+	if !json.Valid(content) {
+		return nil, errorutils.CheckErrorf("the worker payload %s isn't a valid JSON", payloadPath)
+	}
+	return content, nil
+}